@@ -15,7 +15,23 @@ func AdminRequired(next echo.HandlerFunc) echo.HandlerFunc {
 		}
 
 		if claims.Role != models.AdminRole {
-			return apperrors.Forbidden.Wrap(nil, "この操作を行う権限がありません。管理者アカウントが必要です。")
+			return apperrors.Forbidden.WrapMessage(nil, apperrors.MsgAdminRequired, nil)
+		}
+
+		return next(c)
+	}
+}
+
+// HeadquartersRequired は本部の管理者アカウントだけを通します
+func HeadquartersRequired(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		claims, err := controllers.GetClaims(c)
+		if err != nil {
+			return err
+		}
+
+		if claims.Role != models.HeadquartersRole {
+			return apperrors.Forbidden.WrapMessage(nil, apperrors.MsgHeadquartersRequired, nil)
 		}
 
 		return next(c)
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"os"
 
@@ -14,18 +15,22 @@ import (
 	"github.com/labstack/echo/v4/middleware"
 )
 
-func NewRouter(adc controllers.AdminController, auc controllers.AuthController, orc controllers.OrderController, prc controllers.ItemController) *echo.Echo {
+func NewRouter(adc controllers.AdminController, auc controllers.AuthController, orc controllers.OrderController, prc controllers.ItemController, shc controllers.ShopController, pyc controllers.PaymentController, pmc controllers.PromotionController, ptc controllers.PointController, rpc controllers.ReportController, ctc controllers.CategoryController, tlc controllers.TranslationController) *echo.Echo {
 	e := echo.New()
 
 	e.HTTPErrorHandler = apperrors.ErrorHandler
 
+	// X-Request-Idがなければ発行して応答に付ける。エラーのログとproblem+jsonのrequest_idにも使う
+	e.Use(middleware.RequestID())
+
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		// 開発中はどのオリジンからでもアクセスを許可するためにAllowOriginFuncを使うと便利
 		AllowOriginFunc: func(origin string) (bool, error) {
 			return true, nil
 		},
-		AllowMethods: []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPatch, http.MethodPost, http.MethodDelete},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		AllowMethods:  []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPatch, http.MethodPost, http.MethodDelete},
+		AllowHeaders:  []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, controllers.GuestOrderTokenHeader, echo.HeaderXRequestID},
+		ExposeHeaders: []string{echo.HeaderXRequestID},
 	}))
 
 	jwtConfig := echojwt.Config{
@@ -38,6 +43,18 @@ func NewRouter(adc controllers.AdminController, auc controllers.AuthController,
 	}
 	jwtMiddleware := echojwt.WithConfig(jwtConfig)
 
+	// ゲストも使えるエンドポイント用。トークンがなければそのまま通し、不正なトークンは拒否する
+	optionalJwtConfig := jwtConfig
+	optionalJwtConfig.ContinueOnIgnoredError = true
+	optionalJwtConfig.ErrorHandler = func(c echo.Context, err error) error {
+		var extractionErr *echojwt.TokenExtractionError
+		if errors.As(err, &extractionErr) {
+			return nil
+		}
+		return apperrors.Unauthorized.WrapMessage(err, apperrors.MsgInvalidToken, nil)
+	}
+	optionalJwtMiddleware := echojwt.WithConfig(optionalJwtConfig)
+
 	e.GET("/health", func(c echo.Context) error {
 		return c.String(http.StatusOK, "OK")
 	})
@@ -45,16 +62,30 @@ func NewRouter(adc controllers.AdminController, auc controllers.AuthController,
 	// --- 認証不要なエンドポイント ---
 	e.POST("/auth/signup", auc.SignUpHandler)
 	e.POST("/auth/login", auc.LogInHandler)
+	e.GET("/shops", shc.GetNearbyShopsHandler)                          //現在地からの距離で店舗検索
 	e.GET("/shops/:shop_id/items", prc.GetItemListHandler)              //商品一覧取得　←いずみん
+	e.GET("/shops/:shop_id/menu", prc.GetMenuHandler)                   //カテゴリごとのメニュー取得
 	e.POST("/shops/:shop_id/guest-orders", orc.CreateGuestOrderHandler) //ゲスト用注文作成
+	e.POST("/payments/webhook", pyc.HandleWebhookHandler)               //決済代行会社からの決済結果通知（署名で検証）
 
 	// --- 認証が必要なエンドポイント ---
 	e.POST("/shops/:shop_id/orders", orc.CreateAuthenticatedOrderHandler, jwtMiddleware) //認証ユーザー用注文作成
-	e.GET("/orders", orc.GetOrderListHandler, jwtMiddleware)                             //ユーザーのアクティブ注文確認（cooking, completed）
+	e.GET("/orders", orc.GetOrderListHandler, jwtMiddleware)                             //ユーザーのアクティブ注文確認（pending_payment, cooking, completed）
 	e.GET("/orders/:order_id/status", orc.GetOrderStatusHandler, jwtMiddleware)          //注文ステータスと待ち人数の取得(このエンドポイントを定期的に叩いてリアルタイムに近い更新を可能にする。)
+	e.GET("/orders/:order_id/receipt", orc.GetReceiptHandler, optionalJwtMiddleware)     //領収書（適格請求書）の発行。ゲストはX-Guest-Order-Tokenヘッダーで指定
+	e.GET("/me/points", ptc.GetMyPointsHandler, jwtMiddleware)                           //ポイント残高と履歴
 	// 将来的に履歴機能が必要な場合:
 	// e.GET("/orders/history", orc.GetOrderHistoryHandler, jwtMiddleware)              //ユーザーの全注文履歴（handed含む）
 
+	// --- 本部の管理者用エンドポイント（全店舗共通のクーポン）---
+	headquartersGroup := e.Group("/admin/promotions")
+	headquartersGroup.Use(jwtMiddleware, middlewares.HeadquartersRequired)
+	{
+		headquartersGroup.POST("", pmc.CreateGlobalPromotionHandler)                     // 全店舗共通のクーポンを登録
+		headquartersGroup.GET("", pmc.GetGlobalPromotionsHandler)                        // 全店舗共通のクーポン一覧
+		headquartersGroup.DELETE("/:promotion_id", pmc.DeactivateGlobalPromotionHandler) // 全店舗共通のクーポンを無効化
+	}
+
 	// --- 管理者用エンドポイント　---
 	adminGroup := e.Group("/admin")
 	adminGroup.Use(jwtMiddleware, middlewares.AdminRequired)
@@ -64,6 +95,52 @@ func NewRouter(adc controllers.AdminController, auc controllers.AuthController,
 		adminGroup.PATCH("/orders/:order_id/status", adc.UpdateOrderStatusHandler)          // 管理者が注文ステータスを更新
 		adminGroup.PATCH("/items/:item_id/availability", adc.UpdateItemAvailabilityHandler) // 商品の販売可能状態更新　←いずみん
 		adminGroup.DELETE("/orders/:order_id/delete", adc.DeleteOrderHandler)               //管理者画面で注文を削除
+		adminGroup.PATCH("/shops/:shop_id/coordinates", shc.UpdateShopCoordinatesHandler)   // 店舗の緯度経度を設定
+		adminGroup.PATCH("/shops/:shop_id/prep-time", shc.UpdateDefaultPrepTimeHandler)     // 店舗の既定調理時間を設定
+		adminGroup.PATCH("/items/:item_id/prep-time", adc.UpdateItemPrepTimeHandler)        // 商品の調理時間を設定
+		adminGroup.PATCH("/items/:item_id/stock", adc.UpdateItemStockHandler)               // 商品のその日の在庫数を設定
+		adminGroup.POST("/items/:item_id/image", prc.UploadItemImageHandler)                // 商品画像をアップロード（サムネイルも生成）
+		adminGroup.POST("/items/:item_id/modifier-groups", adc.CreateModifierGroupHandler)  // 商品のオプショングループを登録
+		// 商品のオプショングループを削除
+		adminGroup.DELETE("/modifier-groups/:modifier_group_id", adc.DeleteModifierGroupHandler)
+		adminGroup.POST("/orders/:order_id/refunds", pyc.CreateRefundHandler) // 注文を全額または商品ごとに返金
+		// 店舗の適格請求書発行事業者の登録番号を設定
+		adminGroup.PATCH("/shops/:shop_id/invoice-registration-number", shc.UpdateInvoiceRegistrationNumberHandler)
+		adminGroup.POST("/shops/:shop_id/promotions", pmc.CreatePromotionHandler)  // 店舗のクーポンを登録
+		adminGroup.GET("/shops/:shop_id/promotions", pmc.GetShopPromotionsHandler) // 店舗のクーポン一覧
+		// 店舗のクーポンを無効化（利用記録は残す）
+		adminGroup.DELETE("/shops/:shop_id/promotions/:promotion_id", pmc.DeactivatePromotionHandler)
+		adminGroup.PATCH("/shops/:shop_id/point-rate", shc.UpdatePointRateHandler)      // 店舗のポイント還元率を設定
+		adminGroup.GET("/shops/:shop_id/reports/sales", rpc.GetSalesReportHandler)      // 日・週・月ごとの売上レポート
+		adminGroup.GET("/shops/:shop_id/reports/items", rpc.GetItemSalesReportHandler)  // 商品ごとの売上レポート
+		adminGroup.GET("/shops/:shop_id/reports/kitchen", rpc.GetKitchenMetricsHandler) // 厨房の所要時間と受け取られていない注文
+		adminGroup.GET("/shops/:shop_id/orders/export", rpc.ExportOrdersHandler)        // 会計向けの注文の書き出し（CSV・XLSX）
+		adminGroup.POST("/shops/:shop_id/menu/import", prc.ImportMenuHandler)           // メニューの一括取り込み（dry_run=trueで差分のみ）
+		adminGroup.GET("/shops/:shop_id/menu/export", prc.ExportMenuHandler)            // 取り込みと同じ形式でメニューを書き出し
+		adminGroup.POST("/shops/:shop_id/categories", ctc.CreateCategoryHandler)        // 店舗の商品カテゴリを登録
+		adminGroup.GET("/shops/:shop_id/categories", ctc.GetShopCategoriesHandler)      // 店舗の商品カテゴリ一覧
+		// カテゴリの名前と表示順を更新
+		adminGroup.PATCH("/shops/:shop_id/categories/:category_id", ctc.UpdateCategoryHandler)
+		// カテゴリを削除（カテゴリの商品はカテゴリなしになる）
+		adminGroup.DELETE("/shops/:shop_id/categories/:category_id", ctc.DeleteCategoryHandler)
+		adminGroup.PATCH("/shops/:shop_id/items/:item_id/category", ctc.AssignItemCategoryHandler) // 商品のカテゴリを設定
+		adminGroup.PATCH("/shops/:shop_id/time-zone", shc.UpdateTimeZoneHandler)                   // 販売時間帯を判定する店舗のタイムゾーンを設定
+		// 商品を注文できる時間帯を設定（空の配列でいつでも注文できる）
+		adminGroup.PUT("/items/:item_id/availability-schedule", adc.UpdateItemAvailabilityScheduleHandler)
+		adminGroup.PUT("/items/:item_id/bundle", adc.UpdateItemBundleHandler)            // セット商品の枠と構成商品を設定（空の配列でセットをやめる）
+		adminGroup.PUT("/items/:item_id/dietary-info", adc.UpdateItemDietaryInfoHandler) // 商品のアレルゲンと食事の制限を設定
+		// 店舗ごとの価格を変更（effective_atを指定すると予定として登録）
+		adminGroup.POST("/shops/:shop_id/items/:item_id/price-changes", prc.SchedulePriceChangeHandler)
+		adminGroup.GET("/shops/:shop_id/items/:item_id/price-changes", prc.GetPriceHistoryHandler) // 店舗ごとの価格と変更の履歴・予定
+		// まだ反映していない価格変更の予定を取り消し
+		adminGroup.DELETE("/shops/:shop_id/items/:item_id/price-changes/:price_change_id", prc.CancelPriceChangeHandler)
+		adminGroup.PUT("/items/:item_id/translations/:lang", tlc.UpsertItemTranslationHandler)    // 商品名と説明の翻訳を登録（en, zh, ko）
+		adminGroup.DELETE("/items/:item_id/translations/:lang", tlc.DeleteItemTranslationHandler) // 商品の翻訳を削除
+		adminGroup.PUT("/shops/:shop_id/translations/:lang", tlc.UpsertShopTranslationHandler)    // 店舗名と説明の翻訳を登録
+		adminGroup.DELETE("/shops/:shop_id/translations/:lang", tlc.DeleteShopTranslationHandler) // 店舗の翻訳を削除
+		// カテゴリ名の翻訳を登録・削除
+		adminGroup.PUT("/shops/:shop_id/categories/:category_id/translations/:lang", tlc.UpsertCategoryTranslationHandler)
+		adminGroup.DELETE("/shops/:shop_id/categories/:category_id/translations/:lang", tlc.DeleteCategoryTranslationHandler)
 	}
 	return e
 }
@@ -31,4 +31,7 @@ const (
 
 	// Conflict: 状態の競合（メールアドレスの重複、ステータスの不整合など）
 	Conflict ErrCode = "C001"
+
+	// PaymentFailed: 決済の失敗（カードが利用できない、決済代行会社でエラーになったなど）
+	PaymentFailed ErrCode = "P001"
 )
\ No newline at end of file
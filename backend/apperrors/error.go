@@ -6,6 +6,11 @@ type AppError struct {
 	ErrCode ErrCode `json:"err_code"`
 	Message string  `json:"message"`
 	Err     error   `json:"-"`
+
+	Errors []FieldError `json:"errors,omitempty"` // 項目ごとの検証エラー
+
+	MessageID MessageID `json:"-"` // カタログの文言の種類。空ならMessageをそのまま使う
+	Params    Params    `json:"-"` // カタログの文言に埋め込む値
 }
 
 func (e *AppError) Error() string {
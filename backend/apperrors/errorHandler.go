@@ -4,10 +4,13 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"slices"
 
 	"github.com/labstack/echo/v4"
 )
 //最終的に返ってきたエラーをクライアントにJSON形式で見せてあげる
+//メッセージはlangかAccept-Languageで決めた言語（日本語か英語）で返す
+//Acceptにapplication/problem+jsonを含むときはRFC 9457の形式で返す
 func ErrorHandler(err error, ctx echo.Context) {
 	if ctx.Response().Committed {
 		return
@@ -22,7 +25,11 @@ func ErrorHandler(err error, ctx echo.Context) {
 		}
 	}
 
-	slog.Error("error handled", "err_code", appErr.ErrCode, "detail", appErr.Error())
+	requestID := ctx.Response().Header().Get(echo.HeaderXRequestID)
+	if requestID == "" {
+		requestID = ctx.Request().Header.Get(echo.HeaderXRequestID)
+	}
+	slog.Error("error handled", "err_code", appErr.ErrCode, "request_id", requestID, "detail", appErr.Error())
 
 	var statusCode int
 	switch appErr.ErrCode {
@@ -36,11 +43,29 @@ func ErrorHandler(err error, ctx echo.Context) {
 		statusCode = http.StatusNotFound
 	case Conflict:
 		statusCode = http.StatusConflict
+	case PaymentFailed:
+		statusCode = http.StatusPaymentRequired
 	default:
 		statusCode = http.StatusInternalServerError
 	}
 
-	if err := ctx.JSON(statusCode, appErr); err != nil {
+	lang := NegotiateLanguage(ctx.QueryParam("lang"), ctx.Request().Header.Get("Accept-Language"))
+	header := ctx.Response().Header()
+	header.Set("Content-Language", lang)
+	for _, vary := range []string{"Accept-Language", echo.HeaderAccept} {
+		if !slices.Contains(header.Values(echo.HeaderVary), vary) {
+			header.Add(echo.HeaderVary, vary)
+		}
+	}
+
+	var res any = &AppError{ErrCode: appErr.ErrCode, Message: appErr.Localize(lang), Errors: appErr.LocalizeFields(lang)}
+	if acceptsProblem(ctx.Request().Header.Get(echo.HeaderAccept)) {
+		// ctx.JSONはContent-Typeが設定済みなら上書きしない
+		header.Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+		res = newProblem(appErr, statusCode, lang, ctx.Request().URL.Path, requestID)
+	}
+
+	if err := ctx.JSON(statusCode, res); err != nil {
 		slog.Error("failed to write error response", "error", err)
 	}
 }
@@ -0,0 +1,48 @@
+package apperrors
+
+import "github.com/A4-dev-team/mobileorder.git/i18n"
+
+// FieldError はリクエストの項目ごとの検証エラーです。フロントエンドが誤りのある項目を示すのに使います
+type FieldError struct {
+	Field   string `json:"field" example:"items[0].quantity"` // JSONでの項目の位置。クエリパラメータではパラメータ名
+	Rule    string `json:"rule" example:"min"`                // 満たさなかった検証ルール
+	Param   string `json:"param,omitempty" example:"1"`       // 検証ルールの引数
+	Message string `json:"message" example:"1以上で指定してください。"`
+
+	MessageID MessageID `json:"-"`
+	Params    Params    `json:"-"`
+}
+
+// NewFieldError はカタログのメッセージで項目の検証エラーを作ります。Messageには日本語の文言が入ります
+func NewFieldError(field string, rule string, param string, id MessageID, params Params) FieldError {
+	return FieldError{
+		Field:     field,
+		Rule:      rule,
+		Param:     param,
+		Message:   render(i18n.DefaultLanguage, catalogs[i18n.DefaultLanguage][id], params),
+		MessageID: id,
+		Params:    params,
+	}
+}
+
+// WrapFields は項目ごとの検証エラーを持つエラーで包みます
+func (code ErrCode) WrapFields(err error, fields []FieldError) error {
+	appErr := code.WrapMessage(err, MsgValidationFailed, nil).(*AppError)
+	appErr.Errors = fields
+	return appErr
+}
+
+// LocalizeFields は項目ごとの検証エラーを、メッセージを指定した言語にして返します
+func (e *AppError) LocalizeFields(lang string) []FieldError {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	fields := make([]FieldError, len(e.Errors))
+	for i, f := range e.Errors {
+		fields[i] = f
+		if text, ok := catalogs[lang][f.MessageID]; ok {
+			fields[i].Message = render(lang, text, f.Params)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,625 @@
+package apperrors
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/i18n"
+)
+
+// MessageID はクライアントに返すメッセージの種類です。言語ごとのカタログで文言を引きます
+type MessageID string
+
+const (
+	MsgInvalidRequestBody MessageID = "invalid_request_body"
+	MsgInvalidShopID      MessageID = "invalid_shop_id"
+	MsgInvalidItemID      MessageID = "invalid_item_id"
+	MsgInvalidOrderID     MessageID = "invalid_order_id"
+	MsgInvalidCategoryID  MessageID = "invalid_category_id"
+
+	MsgMissingToken              MessageID = "missing_token"
+	MsgInvalidToken              MessageID = "invalid_token"
+	MsgInvalidTokenClaims        MessageID = "invalid_token_claims"
+	MsgLoginOrGuestTokenRequired MessageID = "login_or_guest_token_required"
+	MsgAdminWithoutShop          MessageID = "admin_without_shop"
+	MsgShopAccessDenied          MessageID = "shop_access_denied"
+	MsgHeadquartersRequired      MessageID = "headquarters_required"
+
+	MsgShopNotFound    MessageID = "shop_not_found"
+	MsgItemNotInShop   MessageID = "item_not_in_shop"
+	MsgUnknownShopItem MessageID = "unknown_shop_item"
+	MsgUnknownItem     MessageID = "unknown_item"
+
+	MsgEmptyOrder                 MessageID = "empty_order"
+	MsgItemSoldOut                MessageID = "item_sold_out"
+	MsgItemInsufficientStock      MessageID = "item_insufficient_stock"
+	MsgItemOutsideSchedule        MessageID = "item_outside_schedule"
+	MsgItemOutsideScheduleUntil   MessageID = "item_outside_schedule_until"
+	MsgStockShortage              MessageID = "stock_shortage"
+	MsgLoginRequiredForPoints     MessageID = "login_required_for_points"
+	MsgInvalidPromotionCode       MessageID = "invalid_promotion_code"
+	MsgUnsupportedTranslationLang MessageID = "unsupported_translation_language"
+	MsgModifierNegativePrice      MessageID = "modifier_negative_price"
+	MsgOrderHasUnrefundedPayment  MessageID = "order_has_unrefunded_payment"
+	MsgOrderPaymentInProgress     MessageID = "order_payment_in_progress"
+
+	MsgInvalidPromotionID      MessageID = "invalid_promotion_id"
+	MsgInvalidModifierGroupID  MessageID = "invalid_modifier_group_id"
+	MsgInvalidPriceChangeID    MessageID = "invalid_price_change_id"
+	MsgInvalidQuery            MessageID = "invalid_query"
+	MsgInvalidSearchRadius     MessageID = "invalid_search_radius"
+	MsgSearchRadiusOutOfRange  MessageID = "search_radius_out_of_range"
+	MsgNearRequired            MessageID = "near_required"
+	MsgInvalidNearFormat       MessageID = "invalid_near_format"
+	MsgInvalidLatitude         MessageID = "invalid_latitude"
+	MsgInvalidLongitude        MessageID = "invalid_longitude"
+	MsgCoordinatesOutOfRange   MessageID = "coordinates_out_of_range"
+	MsgInvalidReceiptFormat    MessageID = "invalid_receipt_format"
+	MsgReceiptRecipientTooLong MessageID = "receipt_recipient_too_long"
+	MsgInvalidReportFrom       MessageID = "invalid_report_from"
+	MsgInvalidReportTo         MessageID = "invalid_report_to"
+	MsgReportRangeReversed     MessageID = "report_range_reversed"
+	MsgReportRangeTooLong      MessageID = "report_range_too_long"
+	MsgInvalidRole             MessageID = "invalid_role"
+	MsgInvalidOrderStatus      MessageID = "invalid_order_status"
+	MsgInvalidDiningOption     MessageID = "invalid_dining_option"
+	MsgInvalidSelectionType    MessageID = "invalid_selection_type"
+	MsgInvalidDiscountType     MessageID = "invalid_discount_type"
+
+	MsgAdminRequired   MessageID = "admin_required"
+	MsgAccessBlocked   MessageID = "access_blocked"
+	MsgEmailNotFound   MessageID = "email_not_found"
+	MsgTooManyRequests MessageID = "too_many_requests"
+	MsgEmailInUse      MessageID = "email_in_use"
+
+	MsgInvalidInvoiceNumber       MessageID = "invalid_invoice_number"
+	MsgInvalidTimeZone            MessageID = "invalid_time_zone"
+	MsgInvalidTimeOfDay           MessageID = "invalid_time_of_day"
+	MsgScheduleEndBeforeStart     MessageID = "schedule_end_before_start"
+	MsgScheduleOverlap            MessageID = "schedule_overlap"
+	MsgPriceChangeInPast          MessageID = "price_change_in_past"
+	MsgPriceChangeAlreadyApplied  MessageID = "price_change_already_applied"
+	MsgDuplicateCategoryName      MessageID = "duplicate_category_name"
+	MsgSingleSelectMaxOne         MessageID = "single_select_max_one"
+	MsgMinSelectExceedsOptions    MessageID = "min_select_exceeds_options"
+	MsgBundleComponentIsBundle    MessageID = "bundle_component_is_bundle"
+	MsgBundleSelfComponent        MessageID = "bundle_self_component"
+	MsgBundleSlotDuplicateItem    MessageID = "bundle_slot_duplicate_item"
+	MsgItemUsedInBundle           MessageID = "item_used_in_bundle"
+	MsgInvalidDryRun              MessageID = "invalid_dry_run"
+	MsgMenuFileRequired           MessageID = "menu_file_required"
+	MsgMenuFileTooLarge           MessageID = "menu_file_too_large"
+	MsgInvalidMenuFormat          MessageID = "invalid_menu_format"
+	MsgInvalidExportFormat        MessageID = "invalid_export_format"
+	MsgMenuFileUnreadable         MessageID = "menu_file_unreadable"
+	MsgMenuFileInvalidJSON        MessageID = "menu_file_invalid_json"
+	MsgMenuFileInvalidCSV         MessageID = "menu_file_invalid_csv"
+	MsgMenuFileHeaderUnreadable   MessageID = "menu_file_header_unreadable"
+	MsgMenuFileEmpty              MessageID = "menu_file_empty"
+	MsgMenuFileTooManyRows        MessageID = "menu_file_too_many_rows"
+	MsgMenuFileUnknownColumn      MessageID = "menu_file_unknown_column"
+	MsgMenuFileMissingColumn      MessageID = "menu_file_missing_column"
+	MsgMenuRowDuplicateItemID     MessageID = "menu_row_duplicate_item_id"
+	MsgMenuRowItemNotInShop       MessageID = "menu_row_item_not_in_shop"
+	MsgItemImageRequired          MessageID = "item_image_required"
+	MsgItemImageTooLarge          MessageID = "item_image_too_large"
+	MsgItemImageDimensionTooLarge MessageID = "item_image_dimension_too_large"
+	MsgUnsupportedImageType       MessageID = "unsupported_image_type"
+	MsgItemImageUnreadable        MessageID = "item_image_unreadable"
+
+	MsgModifierOptionDuplicated  MessageID = "modifier_option_duplicated"
+	MsgModifierOptionUnavailable MessageID = "modifier_option_unavailable"
+	MsgModifierMinSelect         MessageID = "modifier_min_select"
+	MsgModifierMaxSelect         MessageID = "modifier_max_select"
+	MsgUnknownModifierOption     MessageID = "unknown_modifier_option"
+	MsgBundleChoiceDuplicated    MessageID = "bundle_choice_duplicated"
+	MsgBundleSlotRequired        MessageID = "bundle_slot_required"
+	MsgBundleSlotSingleChoice    MessageID = "bundle_slot_single_choice"
+	MsgBundleChoiceUnavailable   MessageID = "bundle_choice_unavailable"
+	MsgUnknownBundleChoice       MessageID = "unknown_bundle_choice"
+	MsgBundleNegativePrice       MessageID = "bundle_negative_price"
+	MsgOrderClaimedByOtherUser   MessageID = "order_claimed_by_other_user"
+	MsgOrderStatusFinal          MessageID = "order_status_final"
+	MsgReceiptUnpaidOrder        MessageID = "receipt_unpaid_order"
+	MsgReceiptNotFound           MessageID = "receipt_not_found"
+	MsgOrderExportFailed         MessageID = "order_export_failed"
+
+	MsgInvalidDiscountPercentage    MessageID = "invalid_discount_percentage"
+	MsgPromotionEndsBeforeStart     MessageID = "promotion_ends_before_start"
+	MsgDuplicatePromotionCode       MessageID = "duplicate_promotion_code"
+	MsgPromotionMinSpend            MessageID = "promotion_min_spend"
+	MsgPromotionNoTargetItems       MessageID = "promotion_no_target_items"
+	MsgPromotionMinQuantity         MessageID = "promotion_min_quantity"
+	MsgPromotionNoDiscountableItems MessageID = "promotion_no_discountable_items"
+	MsgPromotionUserLimit           MessageID = "promotion_user_limit"
+	MsgPromotionUsageLimit          MessageID = "promotion_usage_limit"
+	MsgPointsExceedSubtotal         MessageID = "points_exceed_subtotal"
+	MsgInsufficientPoints           MessageID = "insufficient_points"
+	MsgPromotionLoginRequired       MessageID = "promotion_login_required"
+
+	MsgPaymentResultStatusConflict MessageID = "payment_result_status_conflict"
+	MsgRefundUnpaidOrder           MessageID = "refund_unpaid_order"
+	MsgOrderFullyRefunded          MessageID = "order_fully_refunded"
+	MsgRefundAmountExceeded        MessageID = "refund_amount_exceeded"
+	MsgRefundExceedsPayment        MessageID = "refund_exceeds_payment"
+	MsgOrderItemNotInOrder         MessageID = "order_item_not_in_order"
+	MsgRefundQuantityExceeded      MessageID = "refund_quantity_exceeded"
+	MsgNothingToRefund             MessageID = "nothing_to_refund"
+	MsgWebhookMissingPaymentID     MessageID = "webhook_missing_payment_id"
+	MsgUnsupportedWebhookType      MessageID = "unsupported_webhook_type"
+	MsgRequestBodyUnreadable       MessageID = "request_body_unreadable"
+	MsgWebhookSignatureRequired    MessageID = "webhook_signature_required"
+	MsgWebhookSecretMissing        MessageID = "webhook_secret_missing"
+	MsgInvalidWebhookSignature     MessageID = "invalid_webhook_signature"
+	MsgInvalidWebhookPayload       MessageID = "invalid_webhook_payload"
+	MsgPaymentNotCompleted         MessageID = "payment_not_completed"
+	MsgPaymentFailedOrderCancelled MessageID = "payment_failed_order_cancelled"
+	MsgRefundResultUnknown         MessageID = "refund_result_unknown"
+
+	// 項目ごとの検証エラー（FieldError）の文言
+	MsgValidationFailed MessageID = "validation_failed"
+	MsgFieldRequired    MessageID = "field_required"
+	MsgFieldMin         MessageID = "field_min"
+	MsgFieldMinLength   MessageID = "field_min_length"
+	MsgFieldMinItems    MessageID = "field_min_items"
+	MsgFieldMax         MessageID = "field_max"
+	MsgFieldMaxLength   MessageID = "field_max_length"
+	MsgFieldMaxItems    MessageID = "field_max_items"
+	MsgFieldLength      MessageID = "field_length"
+	MsgFieldGteField    MessageID = "field_gte_field"
+	MsgFieldOneOf       MessageID = "field_one_of"
+	MsgFieldUnique      MessageID = "field_unique"
+	MsgFieldEmail       MessageID = "field_email"
+	MsgFieldUUID        MessageID = "field_uuid"
+	MsgFieldAlphanum    MessageID = "field_alphanum"
+	MsgFieldDatetime    MessageID = "field_datetime"
+	MsgFieldTimeZone    MessageID = "field_time_zone"
+	MsgFieldInvalid     MessageID = "field_invalid"
+	MsgFieldInteger     MessageID = "field_integer"
+	MsgFieldBoolean     MessageID = "field_boolean"
+)
+
+// Params はメッセージに埋め込む値です。文言の {name} を値で置き換えます
+type Params map[string]any
+
+// messageNegotiator はエラーメッセージのカタログがある言語から言語を決めます。日本語が既定です
+var messageNegotiator = i18n.NewNegotiator(i18n.DefaultLanguage, "en")
+
+var catalogs = map[string]map[MessageID]string{
+	"ja": {
+		MsgInvalidRequestBody: "リクエストの形式が不正です。",
+		MsgInvalidShopID:      "店舗IDの形式が不正です。",
+		MsgInvalidItemID:      "商品IDの形式が不正です。",
+		MsgInvalidOrderID:     "注文IDの形式が不正です。",
+		MsgInvalidCategoryID:  "カテゴリIDの形式が不正です。",
+
+		MsgMissingToken:              "リクエストにトークンが含まれていません。",
+		MsgInvalidToken:              "トークンが無効か、有効期限が切れています。",
+		MsgInvalidTokenClaims:        "トークンクレームの解析に失敗しました。",
+		MsgLoginOrGuestTokenRequired: "ログインするか、ゲスト用トークンを指定してください。",
+		MsgAdminWithoutShop:          "店舗に紐づいていない管理者アカウントです。",
+		MsgShopAccessDenied:          "この店舗へのアクセス権がありません。",
+		MsgHeadquartersRequired:      "この操作を行う権限がありません。本部の管理者アカウントが必要です。",
+
+		MsgShopNotFound:    "指定された店舗が見つかりませんでした。",
+		MsgItemNotInShop:   "指定された商品が見つからないか、この店舗の商品ではありません。",
+		MsgUnknownShopItem: "リクエストに、存在しないか店舗に属さない商品が含まれています。",
+		MsgUnknownItem:     "リクエストに、存在しない商品が含まれています。",
+
+		MsgEmptyOrder:                 "注文には少なくとも1つの商品が必要です。",
+		MsgItemSoldOut:                "対象の商品 '{item_name}' (ID: {item_id}) は、現在在庫切れです",
+		MsgItemInsufficientStock:      "対象の商品 '{item_name}' (ID: {item_id}) の在庫が足りません（残り{remaining}個）",
+		MsgItemOutsideSchedule:        "対象の商品 '{item_name}' (ID: {item_id}) は、現在の時間帯には注文できません",
+		MsgItemOutsideScheduleUntil:   "対象の商品 '{item_name}' (ID: {item_id}) は、現在の時間帯には注文できません（{next_available_at}から注文できます）",
+		MsgStockShortage:              "商品 (ID: {item_id}) の在庫が足りません。",
+		MsgLoginRequiredForPoints:     "ポイントを使うにはログインしてください。",
+		MsgInvalidPromotionCode:       "クーポンコードが無効か、有効期限外です。",
+		MsgUnsupportedTranslationLang: "翻訳の言語はen, zh, koのいずれかで指定してください。",
+		MsgModifierNegativePrice:      "商品 '{item_name}' は、選んだオプションの値引きで価格が0円未満になるため注文できません",
+		MsgOrderHasUnrefundedPayment:  "返金していない支払いがある注文は削除できません。先に返金してください。",
+		MsgOrderPaymentInProgress:     "決済を確定している途中の注文は削除できません。",
+
+		MsgInvalidPromotionID:      "クーポンIDの形式が不正です。",
+		MsgInvalidModifierGroupID:  "オプショングループIDの形式が不正です。",
+		MsgInvalidPriceChangeID:    "価格変更IDの形式が不正です。",
+		MsgInvalidQuery:            "クエリパラメータの形式が不正です。",
+		MsgInvalidSearchRadius:     "検索半径の形式が不正です。",
+		MsgSearchRadiusOutOfRange:  "検索半径は0より大きく{max}メートル以下で指定してください。",
+		MsgNearRequired:            "検索地点(near)を指定してください。",
+		MsgInvalidNearFormat:       "検索地点は「緯度,経度」の形式で指定してください。",
+		MsgInvalidLatitude:         "緯度の形式が不正です。",
+		MsgInvalidLongitude:        "経度の形式が不正です。",
+		MsgCoordinatesOutOfRange:   "緯度は-90〜90、経度は-180〜180の範囲で指定してください。",
+		MsgInvalidReceiptFormat:    "出力形式はjson、text、pdfのいずれかで指定してください。",
+		MsgReceiptRecipientTooLong: "宛名は{max}文字以内で指定してください。",
+		MsgInvalidReportFrom:       "開始日はYYYY-MM-DDの形式で指定してください。",
+		MsgInvalidReportTo:         "終了日はYYYY-MM-DDの形式で指定してください。",
+		MsgReportRangeReversed:     "終了日は開始日以降の日付を指定してください。",
+		MsgReportRangeTooLong:      "集計期間は{max_days}日以内で指定してください。",
+		MsgInvalidRole:             "不正なロール値です: {value}",
+		MsgInvalidOrderStatus:      "不正なステータス値です: {value}",
+		MsgInvalidDiningOption:     "不正な飲食区分です: {value}",
+		MsgInvalidSelectionType:    "不正な選択方式です: {value}",
+		MsgInvalidDiscountType:     "不正な割引方式です: {value}",
+
+		MsgAdminRequired:   "この操作を行う権限がありません。管理者アカウントが必要です。",
+		MsgAccessBlocked:   "アクセスが一時的にブロックされています。10分後に再試行してください。",
+		MsgEmailNotFound:   "メールアドレスが見つかりません。",
+		MsgTooManyRequests: "短時間に多数のアクセスが検出されました。10分後に再試行してください。",
+		MsgEmailInUse:      "このメールアドレスは既に使用されています。",
+
+		MsgInvalidInvoiceNumber:       "登録番号は「T」と13桁の数字で指定してください。",
+		MsgInvalidTimeZone:            "タイムゾーン '{time_zone}' は使えません。\"Asia/Tokyo\"のようなIANAの名前で指定してください。",
+		MsgInvalidTimeOfDay:           "時刻 '{value}' はHH:MMの形式で指定してください。",
+		MsgScheduleEndBeforeStart:     "時間帯 {start}〜{end} の終了時刻は開始時刻より後にしてください。日をまたぐ場合は2つの時間帯に分けてください。",
+		MsgScheduleOverlap:            "{day}曜日の時間帯 {start}〜{end} と {other_start}〜{other_end} が重なっています。",
+		MsgPriceChangeInPast:          "反映する日時は現在より後にしてください。すぐに反映する場合は省略してください。",
+		MsgPriceChangeAlreadyApplied:  "すでに反映した価格変更は取り消せません。",
+		MsgDuplicateCategoryName:      "同じ名前のカテゴリが既に登録されています。",
+		MsgSingleSelectMaxOne:         "単一選択のオプショングループは最大選択数を1にしてください。",
+		MsgMinSelectExceedsOptions:    "最小選択数が選択肢の数を超えています。",
+		MsgBundleComponentIsBundle:    "'{item_name}' はセット商品のため、構成商品にはできません。",
+		MsgBundleSelfComponent:        "セット商品自身を構成商品にはできません。",
+		MsgBundleSlotDuplicateItem:    "枠 '{slot_name}' に同じ商品 (ID: {item_id}) が重複して指定されています。",
+		MsgItemUsedInBundle:           "この商品は他のセット商品の構成商品のため、セット商品にはできません。",
+		MsgInvalidDryRun:              "dry_runはtrueかfalseで指定してください。",
+		MsgMenuFileRequired:           "メニューファイルを file として指定してください。",
+		MsgMenuFileTooLarge:           "メニューファイルは{max_mb}MBまでです。",
+		MsgInvalidMenuFormat:          "メニューファイルの形式はcsvかjsonで指定してください。",
+		MsgInvalidExportFormat:        "出力形式はcsvかjsonで指定してください。",
+		MsgMenuFileUnreadable:         "メニューファイルを開けませんでした。",
+		MsgMenuFileInvalidJSON:        "メニューファイルをJSONとして読み取れませんでした。",
+		MsgMenuFileInvalidCSV:         "メニューファイルをCSVとして読み取れませんでした。",
+		MsgMenuFileHeaderUnreadable:   "メニューファイルの見出しの行を読み取れませんでした。",
+		MsgMenuFileEmpty:              "メニューファイルに商品がありません。",
+		MsgMenuFileTooManyRows:        "一度に取り込めるのは{max}行までです。",
+		MsgMenuFileUnknownColumn:      "メニューファイルに不明な列「{column}」があります。",
+		MsgMenuFileMissingColumn:      "メニューファイルに「{column}」の列がありません。",
+		MsgMenuRowDuplicateItemID:     "同じ商品IDが{row}行目にもあります。",
+		MsgMenuRowItemNotInShop:       "この店舗の商品ではありません。",
+		MsgItemImageRequired:          "画像を image として指定してください。",
+		MsgItemImageTooLarge:          "画像は{max_mb}MBまでです。",
+		MsgItemImageDimensionTooLarge: "画像の縦横は{max}ピクセルまでです。",
+		MsgUnsupportedImageType:       "画像はJPEG、PNG、WebPのいずれかで指定してください。",
+		MsgItemImageUnreadable:        "画像を読み取れませんでした。",
+
+		MsgModifierOptionDuplicated:  "商品 '{item_name}' のオプション (ID: {option_id}) が重複して指定されています",
+		MsgModifierOptionUnavailable: "商品 '{item_name}' のオプション '{option_name}' は、現在選択できません",
+		MsgModifierMinSelect:         "商品 '{item_name}' の '{group_name}' を{min}個以上選択してください",
+		MsgModifierMaxSelect:         "商品 '{item_name}' の '{group_name}' は{max}個まで選択できます",
+		MsgUnknownModifierOption:     "商品 '{item_name}' に存在しないオプションが指定されています",
+		MsgBundleChoiceDuplicated:    "商品 '{item_name}' のセットの選択肢 (ID: {choice_id}) が重複して指定されています",
+		MsgBundleSlotRequired:        "商品 '{item_name}' の '{slot_name}' を選択してください",
+		MsgBundleSlotSingleChoice:    "商品 '{item_name}' の '{slot_name}' は1つだけ選択できます",
+		MsgBundleChoiceUnavailable:   "商品 '{item_name}' の '{choice_name}' は、現在選択できません",
+		MsgUnknownBundleChoice:       "商品 '{item_name}' に存在しないセットの選択肢が指定されています",
+		MsgBundleNegativePrice:       "商品 '{item_name}' は、選んだセットの選択肢とオプションの値引きで価格が0円未満になるため注文できません",
+		MsgOrderClaimedByOtherUser:   "この注文は既に他のユーザーアカウントに紐付けられています。",
+		MsgOrderStatusFinal:          "ステータスが'{status}'の注文はこれ以上進められません。",
+		MsgReceiptUnpaidOrder:        "決済が完了していない注文の領収書は発行できません。",
+		MsgReceiptNotFound:           "注文が見つからないか、アクセス権がありません。",
+		MsgOrderExportFailed:         "注文の書き出しに失敗しました。",
+
+		MsgInvalidDiscountPercentage:    "割引率は1〜100%で指定してください。",
+		MsgPromotionEndsBeforeStart:     "有効期間の終了は開始より後にしてください。",
+		MsgDuplicatePromotionCode:       "同じコードのクーポンが既に登録されています。",
+		MsgPromotionMinSpend:            "このクーポンは小計（税込）{amount}円以上の注文で利用できます。",
+		MsgPromotionNoTargetItems:       "このクーポンの対象商品が注文に含まれていません。",
+		MsgPromotionMinQuantity:         "このクーポンは対象商品を{quantity}個以上注文すると利用できます。",
+		MsgPromotionNoDiscountableItems: "このクーポンで値引きできる商品が注文に含まれていません。",
+		MsgPromotionUserLimit:           "このクーポンは1人{limit}回までです。",
+		MsgPromotionUsageLimit:          "クーポンの利用上限に達しました。",
+		MsgPointsExceedSubtotal:         "ポイントは値引き後の小計（税込）{amount}円まで使えます。",
+		MsgInsufficientPoints:           "ポイントが足りません（残高: {balance}ポイント）。",
+		MsgPromotionLoginRequired:       "このクーポンはログインしてから利用してください。",
+
+		MsgPaymentResultStatusConflict: "ステータスが'{status}'の注文の決済結果は反映できません。",
+		MsgRefundUnpaidOrder:           "支払いが完了していない注文は返金できません。",
+		MsgOrderFullyRefunded:          "この注文は既に全額返金されています。",
+		MsgRefundAmountExceeded:        "返金額（{amount}円）が返金できる残額（{remaining}円）を超えています。",
+		MsgRefundExceedsPayment:        "返金額の合計が支払額を超えます。",
+		MsgOrderItemNotInOrder:         "注文商品 (ID: {order_item_id}) はこの注文に含まれていません。",
+		MsgRefundQuantityExceeded:      "注文商品 (ID: {order_item_id}) の返金できる数量は残り{remaining}個です。",
+		MsgNothingToRefund:             "返金できる注文商品がありません。",
+		MsgWebhookMissingPaymentID:     "Webhookに決済IDがありません。",
+		MsgUnsupportedWebhookType:      "未対応のWebhookの種類です: {type}",
+		MsgRequestBodyUnreadable:       "リクエストの読み込みに失敗しました。",
+		MsgWebhookSignatureRequired:    "Webhookの署名がありません。",
+		MsgWebhookSecretMissing:        "Webhookの署名鍵が設定されていません。",
+		MsgInvalidWebhookSignature:     "Webhookの署名が不正です。",
+		MsgInvalidWebhookPayload:       "Webhookの形式が不正です。",
+		MsgPaymentNotCompleted:         "決済を確定できませんでした（{reason}）。",
+		MsgPaymentFailedOrderCancelled: "決済が完了しなかったため、注文を取り消しました（{reason}）。",
+		MsgRefundResultUnknown:         "決済代行会社での返金の結果を確認できませんでした。返金依頼中として記録し、自動でやり直します。",
+
+		MsgValidationFailed: "入力内容に誤りがあります。",
+		MsgFieldRequired:    "必須です。",
+		MsgFieldMin:         "{param}以上で指定してください。",
+		MsgFieldMinLength:   "{param}文字以上で指定してください。",
+		MsgFieldMinItems:    "{param}件以上指定してください。",
+		MsgFieldMax:         "{param}以下で指定してください。",
+		MsgFieldMaxLength:   "{param}文字以内で指定してください。",
+		MsgFieldMaxItems:    "{param}件以内で指定してください。",
+		MsgFieldLength:      "{param}文字で指定してください。",
+		MsgFieldGteField:    "{param}以上で指定してください。",
+		MsgFieldOneOf:       "{values}のいずれかで指定してください。",
+		MsgFieldUnique:      "同じ値が重複しています。",
+		MsgFieldEmail:       "メールアドレスの形式で指定してください。",
+		MsgFieldUUID:        "UUIDの形式で指定してください。",
+		MsgFieldAlphanum:    "英数字で指定してください。",
+		MsgFieldDatetime:    "{format}の形式で指定してください。",
+		MsgFieldTimeZone:    "\"Asia/Tokyo\"のようなIANAのタイムゾーン名で指定してください。",
+		MsgFieldInvalid:     "値が不正です。",
+		MsgFieldInteger:     "整数で指定してください。",
+		MsgFieldBoolean:     "trueかfalseで指定してください。",
+	},
+	"en": {
+		MsgInvalidRequestBody: "The request body is malformed.",
+		MsgInvalidShopID:      "The shop ID is invalid.",
+		MsgInvalidItemID:      "The item ID is invalid.",
+		MsgInvalidOrderID:     "The order ID is invalid.",
+		MsgInvalidCategoryID:  "The category ID is invalid.",
+
+		MsgMissingToken:              "The request does not include a token.",
+		MsgInvalidToken:              "The token is invalid or has expired.",
+		MsgInvalidTokenClaims:        "Failed to parse the token claims.",
+		MsgLoginOrGuestTokenRequired: "Log in or provide a guest order token.",
+		MsgAdminWithoutShop:          "This admin account is not linked to a shop.",
+		MsgShopAccessDenied:          "You do not have access to this shop.",
+		MsgHeadquartersRequired:      "You do not have permission for this operation. A headquarters admin account is required.",
+
+		MsgShopNotFound:    "The shop was not found.",
+		MsgItemNotInShop:   "The item was not found or does not belong to this shop.",
+		MsgUnknownShopItem: "The request contains an item that does not exist or is not sold at this shop.",
+		MsgUnknownItem:     "The request contains an item that does not exist.",
+
+		MsgEmptyOrder:                 "An order must contain at least one item.",
+		MsgItemSoldOut:                "'{item_name}' (ID: {item_id}) is currently sold out.",
+		MsgItemInsufficientStock:      "Not enough '{item_name}' (ID: {item_id}) in stock ({remaining} left).",
+		MsgItemOutsideSchedule:        "'{item_name}' (ID: {item_id}) cannot be ordered at this time.",
+		MsgItemOutsideScheduleUntil:   "'{item_name}' (ID: {item_id}) cannot be ordered at this time. It will be available from {next_available_at}.",
+		MsgStockShortage:              "Not enough stock for item (ID: {item_id}).",
+		MsgLoginRequiredForPoints:     "Please log in to use points.",
+		MsgInvalidPromotionCode:       "The coupon code is invalid or has expired.",
+		MsgUnsupportedTranslationLang: "The translation language must be one of en, zh or ko.",
+		MsgModifierNegativePrice:      "'{item_name}' cannot be ordered because the selected options bring its price below zero.",
+		MsgOrderHasUnrefundedPayment:  "This order has a payment that has not been refunded. Refund it before deleting the order.",
+		MsgOrderPaymentInProgress:     "This order cannot be deleted while its payment is being captured.",
+
+		MsgInvalidPromotionID:      "The coupon ID is invalid.",
+		MsgInvalidModifierGroupID:  "The modifier group ID is invalid.",
+		MsgInvalidPriceChangeID:    "The price change ID is invalid.",
+		MsgInvalidQuery:            "The query parameters are invalid.",
+		MsgInvalidSearchRadius:     "The search radius is invalid.",
+		MsgSearchRadiusOutOfRange:  "The search radius must be greater than 0 and at most {max} meters.",
+		MsgNearRequired:            "Specify the search location (near).",
+		MsgInvalidNearFormat:       "The search location must be in the \"latitude,longitude\" format.",
+		MsgInvalidLatitude:         "The latitude is invalid.",
+		MsgInvalidLongitude:        "The longitude is invalid.",
+		MsgCoordinatesOutOfRange:   "The latitude must be between -90 and 90 and the longitude between -180 and 180.",
+		MsgInvalidReceiptFormat:    "The format must be one of json, text or pdf.",
+		MsgReceiptRecipientTooLong: "The recipient must be at most {max} characters.",
+		MsgInvalidReportFrom:       "The start date must be in the YYYY-MM-DD format.",
+		MsgInvalidReportTo:         "The end date must be in the YYYY-MM-DD format.",
+		MsgReportRangeReversed:     "The end date must be on or after the start date.",
+		MsgReportRangeTooLong:      "The report period must be at most {max_days} days.",
+		MsgInvalidRole:             "Invalid role: {value}",
+		MsgInvalidOrderStatus:      "Invalid order status: {value}",
+		MsgInvalidDiningOption:     "Invalid dining option: {value}",
+		MsgInvalidSelectionType:    "Invalid selection type: {value}",
+		MsgInvalidDiscountType:     "Invalid discount type: {value}",
+
+		MsgAdminRequired:   "You do not have permission for this operation. An admin account is required.",
+		MsgAccessBlocked:   "Access is temporarily blocked. Please try again in 10 minutes.",
+		MsgEmailNotFound:   "The email address was not found.",
+		MsgTooManyRequests: "Too many requests were detected in a short time. Please try again in 10 minutes.",
+		MsgEmailInUse:      "This email address is already in use.",
+
+		MsgInvalidInvoiceNumber:       "The registration number must be \"T\" followed by 13 digits.",
+		MsgInvalidTimeZone:            "The time zone '{time_zone}' is not supported. Use an IANA name such as \"Asia/Tokyo\".",
+		MsgInvalidTimeOfDay:           "The time '{value}' must be in the HH:MM format.",
+		MsgScheduleEndBeforeStart:     "The window {start}-{end} must end after it starts. Split a window that crosses midnight into two.",
+		MsgScheduleOverlap:            "The windows {start}-{end} and {other_start}-{other_end} on {day} overlap.",
+		MsgPriceChangeInPast:          "The effective time must be in the future. Omit it to apply the change immediately.",
+		MsgPriceChangeAlreadyApplied:  "A price change that has already taken effect cannot be cancelled.",
+		MsgDuplicateCategoryName:      "A category with the same name already exists.",
+		MsgSingleSelectMaxOne:         "A single-select modifier group must allow at most one selection.",
+		MsgMinSelectExceedsOptions:    "The minimum selection exceeds the number of options.",
+		MsgBundleComponentIsBundle:    "'{item_name}' is a bundle and cannot be a bundle component.",
+		MsgBundleSelfComponent:        "A bundle cannot contain itself.",
+		MsgBundleSlotDuplicateItem:    "The slot '{slot_name}' contains item (ID: {item_id}) more than once.",
+		MsgItemUsedInBundle:           "This item is a component of another bundle and cannot be a bundle.",
+		MsgInvalidDryRun:              "dry_run must be true or false.",
+		MsgMenuFileRequired:           "Attach the menu file as file.",
+		MsgMenuFileTooLarge:           "The menu file must be at most {max_mb} MB.",
+		MsgInvalidMenuFormat:          "The menu file format must be csv or json.",
+		MsgInvalidExportFormat:        "The format must be csv or json.",
+		MsgMenuFileUnreadable:         "The menu file could not be opened.",
+		MsgMenuFileInvalidJSON:        "The menu file could not be read as JSON.",
+		MsgMenuFileInvalidCSV:         "The menu file could not be read as CSV.",
+		MsgMenuFileHeaderUnreadable:   "The header row of the menu file could not be read.",
+		MsgMenuFileEmpty:              "The menu file contains no items.",
+		MsgMenuFileTooManyRows:        "At most {max} rows can be imported at once.",
+		MsgMenuFileUnknownColumn:      "The menu file has an unknown column \"{column}\".",
+		MsgMenuFileMissingColumn:      "The menu file is missing the \"{column}\" column.",
+		MsgMenuRowDuplicateItemID:     "The same item ID is also on row {row}.",
+		MsgMenuRowItemNotInShop:       "This item does not belong to the shop.",
+		MsgItemImageRequired:          "Attach the image as image.",
+		MsgItemImageTooLarge:          "The image must be at most {max_mb} MB.",
+		MsgItemImageDimensionTooLarge: "The image must be at most {max} pixels wide and high.",
+		MsgUnsupportedImageType:       "The image must be a JPEG, PNG or WebP file.",
+		MsgItemImageUnreadable:        "The image could not be read.",
+
+		MsgModifierOptionDuplicated:  "The option (ID: {option_id}) for '{item_name}' is specified more than once.",
+		MsgModifierOptionUnavailable: "The option '{option_name}' for '{item_name}' is currently unavailable.",
+		MsgModifierMinSelect:         "Select at least {min} of '{group_name}' for '{item_name}'.",
+		MsgModifierMaxSelect:         "Select at most {max} of '{group_name}' for '{item_name}'.",
+		MsgUnknownModifierOption:     "An option that does not exist was specified for '{item_name}'.",
+		MsgBundleChoiceDuplicated:    "The bundle choice (ID: {choice_id}) for '{item_name}' is specified more than once.",
+		MsgBundleSlotRequired:        "Select '{slot_name}' for '{item_name}'.",
+		MsgBundleSlotSingleChoice:    "Select only one '{slot_name}' for '{item_name}'.",
+		MsgBundleChoiceUnavailable:   "'{choice_name}' for '{item_name}' is currently unavailable.",
+		MsgUnknownBundleChoice:       "A bundle choice that does not exist was specified for '{item_name}'.",
+		MsgBundleNegativePrice:       "'{item_name}' cannot be ordered because the selected bundle choices and options bring its price below zero.",
+		MsgOrderClaimedByOtherUser:   "This order is already linked to another user account.",
+		MsgOrderStatusFinal:          "An order with status '{status}' cannot be advanced any further.",
+		MsgReceiptUnpaidOrder:        "A receipt cannot be issued for an order that has not been paid.",
+		MsgReceiptNotFound:           "The order was not found or you do not have access to it.",
+		MsgOrderExportFailed:         "Failed to export the orders.",
+
+		MsgInvalidDiscountPercentage:    "The discount rate must be between 1 and 100%.",
+		MsgPromotionEndsBeforeStart:     "The promotion must end after it starts.",
+		MsgDuplicatePromotionCode:       "A coupon with the same code already exists.",
+		MsgPromotionMinSpend:            "This coupon requires a subtotal (tax included) of at least ¥{amount}.",
+		MsgPromotionNoTargetItems:       "The order does not contain any items eligible for this coupon.",
+		MsgPromotionMinQuantity:         "This coupon requires at least {quantity} eligible items.",
+		MsgPromotionNoDiscountableItems: "The order does not contain any items this coupon can discount.",
+		MsgPromotionUserLimit:           "This coupon can be used up to {limit} times per person.",
+		MsgPromotionUsageLimit:          "This coupon has reached its usage limit.",
+		MsgPointsExceedSubtotal:         "Points can be used up to the discounted subtotal (tax included) of ¥{amount}.",
+		MsgInsufficientPoints:           "Not enough points (balance: {balance} points).",
+		MsgPromotionLoginRequired:       "Please log in to use this coupon.",
+
+		MsgPaymentResultStatusConflict: "The payment result cannot be applied to an order with status '{status}'.",
+		MsgRefundUnpaidOrder:           "An order that has not been paid cannot be refunded.",
+		MsgOrderFullyRefunded:          "This order has already been fully refunded.",
+		MsgRefundAmountExceeded:        "The refund amount (¥{amount}) exceeds the refundable balance (¥{remaining}).",
+		MsgRefundExceedsPayment:        "The total refund exceeds the amount paid.",
+		MsgOrderItemNotInOrder:         "The order item (ID: {order_item_id}) is not part of this order.",
+		MsgRefundQuantityExceeded:      "Only {remaining} more of the order item (ID: {order_item_id}) can be refunded.",
+		MsgNothingToRefund:             "There are no order items to refund.",
+		MsgWebhookMissingPaymentID:     "The webhook does not include a payment ID.",
+		MsgUnsupportedWebhookType:      "Unsupported webhook type: {type}",
+		MsgRequestBodyUnreadable:       "Failed to read the request.",
+		MsgWebhookSignatureRequired:    "The webhook signature is missing.",
+		MsgWebhookSecretMissing:        "The webhook signing key is not configured.",
+		MsgInvalidWebhookSignature:     "The webhook signature is invalid.",
+		MsgInvalidWebhookPayload:       "The webhook payload is malformed.",
+		MsgPaymentNotCompleted:         "The payment could not be completed ({reason}).",
+		MsgPaymentFailedOrderCancelled: "The payment did not complete, so the order was cancelled ({reason}).",
+		MsgRefundResultUnknown:         "The refund result could not be confirmed with the payment provider. It has been recorded as pending and will be retried automatically.",
+
+		MsgValidationFailed: "Some fields are invalid.",
+		MsgFieldRequired:    "This field is required.",
+		MsgFieldMin:         "Must be at least {param}.",
+		MsgFieldMinLength:   "Must be at least {param} characters.",
+		MsgFieldMinItems:    "Must contain at least {param} items.",
+		MsgFieldMax:         "Must be at most {param}.",
+		MsgFieldMaxLength:   "Must be at most {param} characters.",
+		MsgFieldMaxItems:    "Must contain at most {param} items.",
+		MsgFieldLength:      "Must be exactly {param} characters.",
+		MsgFieldGteField:    "Must be greater than or equal to {param}.",
+		MsgFieldOneOf:       "Must be one of {values}.",
+		MsgFieldUnique:      "Must not contain duplicate values.",
+		MsgFieldEmail:       "Must be a valid email address.",
+		MsgFieldUUID:        "Must be a valid UUID.",
+		MsgFieldAlphanum:    "Must contain only letters and digits.",
+		MsgFieldDatetime:    "Must be in the {format} format.",
+		MsgFieldTimeZone:    "Must be an IANA time zone name such as \"Asia/Tokyo\".",
+		MsgFieldInvalid:     "The value is invalid.",
+		MsgFieldInteger:     "Must be an integer.",
+		MsgFieldBoolean:     "Must be true or false.",
+	},
+}
+
+// codeMessages はMessageIDのないエラーの、ErrCodeごとの既定の文言です。
+// 日本語ではWrapに渡した文言をそのまま返すため、日本語以外の言語だけを持ちます
+var codeMessages = map[string]map[ErrCode]string{
+	"en": {
+		Unknown:             "An internal server error occurred.",
+		InsertDataFailed:    "Failed to save the data.",
+		GetDataFailed:       "Failed to retrieve the data.",
+		NoData:              "The requested data was not found.",
+		UpdateDataFailed:    "Failed to update the data.",
+		DeleteDataFailed:    "Failed to delete the data.",
+		ReqBodyDecodeFailed: "The request body is malformed.",
+		BadParam:            "The request parameters are invalid.",
+		ValidationFailed:    "The request failed validation.",
+		Unauthorized:        "Authentication failed.",
+		Forbidden:           "You do not have permission to perform this operation.",
+		Conflict:            "The request conflicts with the current state.",
+		PaymentFailed:       "The payment failed.",
+	},
+}
+
+// WrapMessage はカタログのメッセージでエラーを包みます。Messageには日本語の文言が入り、
+// ErrorHandlerがクライアントの言語の文言に置き換えます
+func (code ErrCode) WrapMessage(err error, id MessageID, params Params) error {
+	return &AppError{
+		ErrCode:   code,
+		Message:   render(i18n.DefaultLanguage, catalogs[i18n.DefaultLanguage][id], params),
+		Err:       err,
+		MessageID: id,
+		Params:    params,
+	}
+}
+
+// Text はカタログの文言を、パラメータを埋め込んで指定した言語で返します。カタログにない言語では日本語の文言を返します
+func Text(lang string, id MessageID, params Params) string {
+	text, ok := catalogs[lang][id]
+	if !ok {
+		lang, text = i18n.DefaultLanguage, catalogs[i18n.DefaultLanguage][id]
+	}
+	return render(lang, text, params)
+}
+
+// NegotiateLanguage はエラーメッセージの言語を、langかAccept-Languageからカタログのある言語の中で決めます
+func NegotiateLanguage(lang string, acceptLanguage string) string {
+	return messageNegotiator.Negotiate(lang, acceptLanguage)
+}
+
+// Localize はエラーのメッセージを指定した言語で返します。カタログに文言がなければErrCodeの既定の文言を、
+// 日本語ではWrapに渡した文言を返します
+func (e *AppError) Localize(lang string) string {
+	if text, ok := catalogs[lang][e.MessageID]; ok && e.MessageID != "" {
+		return render(lang, text, e.Params)
+	}
+	if lang == i18n.DefaultLanguage {
+		return e.Message
+	}
+	if text, ok := codeMessages[lang][e.ErrCode]; ok {
+		return text
+	}
+	return e.Message
+}
+
+// render は文言の {name} をパラメータの値で置き換えます。日時は言語に合わせた形式にします
+func render(lang string, text string, params Params) string {
+	if len(params) == 0 {
+		return text
+	}
+	pairs := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", formatParam(lang, value))
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+var japaneseWeekdays = [...]string{"日", "月", "火", "水", "木", "金", "土"}
+
+func formatParam(lang string, value any) string {
+	if values, ok := value.([]string); ok {
+		// 「food、standard」「food, standard」の形式
+		if lang == i18n.DefaultLanguage {
+			return strings.Join(values, "、")
+		}
+		return strings.Join(values, ", ")
+	}
+	if weekday, ok := value.(time.Weekday); ok {
+		// 「月」「Monday」の形式
+		if lang == i18n.DefaultLanguage {
+			return japaneseWeekdays[weekday]
+		}
+		return weekday.String()
+	}
+	t, ok := value.(time.Time)
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	if lang == i18n.DefaultLanguage {
+		// 「7月1日(火) 07:00」の形式
+		return fmt.Sprintf("%d月%d日(%s) %s", t.Month(), t.Day(), japaneseWeekdays[t.Weekday()], t.Format("15:04"))
+	}
+	return t.Format("Mon, Jan 2 15:04")
+}
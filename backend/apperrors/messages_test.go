@@ -0,0 +1,183 @@
+package apperrors_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/google/go-cmp/cmp"
+	"github.com/labstack/echo/v4"
+)
+
+func TestAppError_Localize(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	next := time.Date(2025, 7, 1, 7, 0, 0, 0, tokyo)
+
+	tests := []struct {
+		name   string
+		err    error
+		wantJa string
+		wantEn string
+	}{
+		{
+			name:   "パラメータを埋め込む",
+			err:    apperrors.BadParam.WrapMessage(nil, apperrors.MsgItemSoldOut, apperrors.Params{"item_name": "唐揚げ定食", "item_id": 1}),
+			wantJa: "対象の商品 '唐揚げ定食' (ID: 1) は、現在在庫切れです",
+			wantEn: "'唐揚げ定食' (ID: 1) is currently sold out.",
+		},
+		{
+			name:   "日時は言語に合わせた形式",
+			err:    apperrors.BadParam.WrapMessage(nil, apperrors.MsgItemOutsideScheduleUntil, apperrors.Params{"item_name": "モーニング", "item_id": 3, "next_available_at": next}),
+			wantJa: "対象の商品 'モーニング' (ID: 3) は、現在の時間帯には注文できません（7月1日(火) 07:00から注文できます）",
+			wantEn: "'モーニング' (ID: 3) cannot be ordered at this time. It will be available from Tue, Jul 1 07:00.",
+		},
+		{
+			name: "曜日は言語に合わせた名前",
+			err: apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgScheduleOverlap, apperrors.Params{
+				"day": time.Monday, "start": "07:00", "end": "11:00", "other_start": "10:00", "other_end": "14:00",
+			}),
+			wantJa: "月曜日の時間帯 07:00〜11:00 と 10:00〜14:00 が重なっています。",
+			wantEn: "The windows 07:00-11:00 and 10:00-14:00 on Monday overlap.",
+		},
+		{
+			name:   "決済代行会社の理由を埋め込む",
+			err:    apperrors.PaymentFailed.WrapMessage(nil, apperrors.MsgPaymentFailedOrderCancelled, apperrors.Params{"reason": "card_declined"}),
+			wantJa: "決済が完了しなかったため、注文を取り消しました（card_declined）。",
+			wantEn: "The payment did not complete, so the order was cancelled (card_declined).",
+		},
+		{
+			name:   "カタログにないメッセージはErrCodeの既定の文言",
+			err:    apperrors.NoData.Wrap(nil, "注文が見つかりません"),
+			wantJa: "注文が見つかりません",
+			wantEn: "The requested data was not found.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var appErr *apperrors.AppError
+			if !errors.As(tt.err, &appErr) {
+				t.Fatalf("err = %v, want *AppError", tt.err)
+			}
+			if appErr.Message != tt.wantJa {
+				t.Errorf("Message = %q, want %q", appErr.Message, tt.wantJa)
+			}
+			if got := appErr.Localize("ja"); got != tt.wantJa {
+				t.Errorf("Localize(ja) = %q, want %q", got, tt.wantJa)
+			}
+			if got := appErr.Localize("en"); got != tt.wantEn {
+				t.Errorf("Localize(en) = %q, want %q", got, tt.wantEn)
+			}
+		})
+	}
+}
+
+func TestErrorHandler_Language(t *testing.T) {
+	tests := []struct {
+		name           string
+		target         string
+		acceptLanguage string
+		wantLang       string
+		wantMessage    string
+	}{
+		{name: "指定なしは日本語", target: "/", wantLang: "ja", wantMessage: "店舗IDの形式が不正です。"},
+		{name: "Accept-Languageの英語", target: "/", acceptLanguage: "en-US,en;q=0.9", wantLang: "en", wantMessage: "The shop ID is invalid."},
+		{name: "カタログのない言語は次に優先される言語", target: "/", acceptLanguage: "zh-CN,en;q=0.8", wantLang: "en", wantMessage: "The shop ID is invalid."},
+		{name: "langはAccept-Languageより優先", target: "/?lang=ja", acceptLanguage: "en", wantLang: "ja", wantMessage: "店舗IDの形式が不正です。"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+			ctx := echo.New().NewContext(req, rec)
+
+			apperrors.ErrorHandler(apperrors.BadParam.WrapMessage(errors.New("strconv error"), apperrors.MsgInvalidShopID, nil), ctx)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+			if got := rec.Header().Get("Content-Language"); got != tt.wantLang {
+				t.Errorf("Content-Language = %q, want %q", got, tt.wantLang)
+			}
+			var body map[string]string
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to decode body: %v", err)
+			}
+			if body["err_code"] != string(apperrors.BadParam) || body["message"] != tt.wantMessage {
+				t.Errorf("body = %v, want message %q", body, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestErrorHandler_FieldErrors(t *testing.T) {
+	fields := []apperrors.FieldError{
+		apperrors.NewFieldError("items[0].quantity", "min", "1", apperrors.MsgFieldMin, apperrors.Params{"param": "1"}),
+		apperrors.NewFieldError("dining_option", "oneof", "takeout dine_in", apperrors.MsgFieldOneOf, apperrors.Params{"values": []string{"takeout", "dine_in"}}),
+	}
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           apperrors.AppError
+	}{
+		{
+			name: "日本語",
+			want: apperrors.AppError{
+				ErrCode: apperrors.ValidationFailed,
+				Message: "入力内容に誤りがあります。",
+				Errors: []apperrors.FieldError{
+					{Field: "items[0].quantity", Rule: "min", Param: "1", Message: "1以上で指定してください。"},
+					{Field: "dining_option", Rule: "oneof", Param: "takeout dine_in", Message: "takeout、dine_inのいずれかで指定してください。"},
+				},
+			},
+		},
+		{
+			name:           "英語",
+			acceptLanguage: "en",
+			want: apperrors.AppError{
+				ErrCode: apperrors.ValidationFailed,
+				Message: "Some fields are invalid.",
+				Errors: []apperrors.FieldError{
+					{Field: "items[0].quantity", Rule: "min", Param: "1", Message: "Must be at least 1."},
+					{Field: "dining_option", Rule: "oneof", Param: "takeout dine_in", Message: "Must be one of takeout, dine_in."},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+			ctx := echo.New().NewContext(req, rec)
+
+			apperrors.ErrorHandler(apperrors.ValidationFailed.WrapFields(errors.New("validation error"), fields), ctx)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+			var got apperrors.AppError
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("failed to decode body: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("body mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package apperrors
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// MIMEApplicationProblemJSON はRFC 9457のエラー応答のメディアタイプです
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// Problem はRFC 9457（Problem Details for HTTP APIs）の形式のエラー応答です。
+// Acceptにapplication/problem+jsonを含むリクエストにだけ、{err_code, message}の代わりに返します
+type Problem struct {
+	Type      string       `json:"type" example:"about:blank"`              // エラーの種類ごとの説明はないため常にabout:blank
+	Title     string       `json:"title" example:"Bad Request"`             // ステータスコードの説明
+	Status    int          `json:"status" example:"400"`                    // HTTPのステータスコード
+	Detail    string       `json:"detail" example:"入力内容に誤りがあります。"`          // langかAccept-Languageの言語のメッセージ
+	Instance  string       `json:"instance" example:"/shops/1/orders"`      // エラーになったリクエストのパス
+	ErrCode   ErrCode      `json:"err_code" example:"R003"`                 // 拡張メンバー。{err_code, message}のerr_codeと同じ
+	Errors    []FieldError `json:"errors,omitempty"`                        // 拡張メンバー。項目ごとの検証エラー
+	RequestID string       `json:"request_id,omitempty" example:"3f1c0b0e"` // 拡張メンバー。ログのrequest_idと同じ
+}
+
+func newProblem(appErr *AppError, status int, lang string, instance string, requestID string) *Problem {
+	return &Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    appErr.Localize(lang),
+		Instance:  instance,
+		ErrCode:   appErr.ErrCode,
+		Errors:    appErr.LocalizeFields(lang),
+		RequestID: requestID,
+	}
+}
+
+// acceptsProblem はAcceptがapplication/problem+jsonを含むか（q=0で拒否していないか）を返します。
+// 解釈できないqは、解釈できないメディアタイプと同じく含まないものとして扱います
+func acceptsProblem(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || mediaType != MIMEApplicationProblemJSON {
+			continue
+		}
+		if q, ok := params["q"]; ok {
+			weight, err := strconv.ParseFloat(q, 64)
+			return err == nil && weight > 0
+		}
+		return true
+	}
+	return false
+}
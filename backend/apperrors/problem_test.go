@@ -0,0 +1,134 @@
+package apperrors_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/google/go-cmp/cmp"
+	"github.com/labstack/echo/v4"
+)
+
+func TestErrorHandler_Problem(t *testing.T) {
+	fields := []apperrors.FieldError{
+		apperrors.NewFieldError("items[0].quantity", "min", "1", apperrors.MsgFieldMin, apperrors.Params{"param": "1"}),
+	}
+
+	tests := []struct {
+		name            string
+		accept          string
+		err             error
+		wantContentType string
+		wantBody        map[string]any
+	}{
+		{
+			name:            "problem+jsonを希望するとRFC 9457の形式",
+			accept:          "application/problem+json, application/json;q=0.9",
+			err:             apperrors.ValidationFailed.WrapFields(errors.New("validation error"), fields),
+			wantContentType: apperrors.MIMEApplicationProblemJSON,
+			wantBody: map[string]any{
+				"type":     "about:blank",
+				"title":    "Bad Request",
+				"status":   float64(http.StatusBadRequest),
+				"detail":   "Some fields are invalid.",
+				"instance": "/shops/1/orders",
+				"err_code": string(apperrors.ValidationFailed),
+				"errors": []any{
+					map[string]any{"field": "items[0].quantity", "rule": "min", "param": "1", "message": "Must be at least 1."},
+				},
+				"request_id": "req-123",
+			},
+		},
+		{
+			name:            "項目ごとのエラーがなければerrorsを含めない",
+			accept:          "application/problem+json",
+			err:             apperrors.NoData.Wrap(nil, "注文が見つかりません"),
+			wantContentType: apperrors.MIMEApplicationProblemJSON,
+			wantBody: map[string]any{
+				"type":       "about:blank",
+				"title":      "Not Found",
+				"status":     float64(http.StatusNotFound),
+				"detail":     "The requested data was not found.",
+				"instance":   "/shops/1/orders",
+				"err_code":   string(apperrors.NoData),
+				"request_id": "req-123",
+			},
+		},
+		{
+			name:            "q=0で拒否したときは従来の形式",
+			accept:          "application/problem+json;q=0, application/json",
+			err:             apperrors.NoData.Wrap(nil, "注文が見つかりません"),
+			wantContentType: echo.MIMEApplicationJSON,
+			wantBody:        map[string]any{"err_code": string(apperrors.NoData), "message": "The requested data was not found."},
+		},
+		{
+			name:            "Acceptがなければ従来の形式",
+			err:             apperrors.NoData.Wrap(nil, "注文が見つかりません"),
+			wantContentType: echo.MIMEApplicationJSON,
+			wantBody:        map[string]any{"err_code": string(apperrors.NoData), "message": "The requested data was not found."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/shops/1/orders?lang=en", nil)
+			if tt.accept != "" {
+				req.Header.Set(echo.HeaderAccept, tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			rec.Header().Set(echo.HeaderXRequestID, "req-123") // RequestIDミドルウェアが付けたID
+			ctx := echo.New().NewContext(req, rec)
+
+			apperrors.ErrorHandler(tt.err, ctx)
+
+			if got := rec.Header().Get(echo.HeaderContentType); !strings.HasPrefix(got, tt.wantContentType) {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantContentType)
+			}
+			if got := rec.Header().Values(echo.HeaderVary); !slices.Contains(got, echo.HeaderAccept) {
+				t.Errorf("Vary = %v, want to contain %q", got, echo.HeaderAccept)
+			}
+			var got map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("failed to decode body: %v", err)
+			}
+			if diff := cmp.Diff(tt.wantBody, got); diff != "" {
+				t.Errorf("body mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestErrorHandler_ProblemQuality(t *testing.T) {
+	tests := []struct {
+		name            string
+		accept          string
+		wantContentType string
+	}{
+		{name: "q=0.5は希望する", accept: "application/problem+json;q=0.5", wantContentType: apperrors.MIMEApplicationProblemJSON},
+		{name: "q=1は希望する", accept: "application/problem+json;q=1", wantContentType: apperrors.MIMEApplicationProblemJSON},
+		{name: "q=0.001は希望する", accept: "application/problem+json;q=0.001", wantContentType: apperrors.MIMEApplicationProblemJSON},
+		{name: "q=0.0は拒否", accept: "application/problem+json;q=0.0, application/json", wantContentType: echo.MIMEApplicationJSON},
+		{name: "q=0.000は拒否", accept: "application/problem+json;q=0.000, application/json", wantContentType: echo.MIMEApplicationJSON},
+		{name: "解釈できないqは含まないものとして扱う", accept: "application/problem+json;q=abc, application/json", wantContentType: echo.MIMEApplicationJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/shops/1/items", nil)
+			req.Header.Set(echo.HeaderAccept, tt.accept)
+			rec := httptest.NewRecorder()
+			ctx := echo.New().NewContext(req, rec)
+
+			apperrors.ErrorHandler(apperrors.NoData.Wrap(nil, "注文が見つかりません"), ctx)
+
+			if got := rec.Header().Get(echo.HeaderContentType); !strings.HasPrefix(got, tt.wantContentType) {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantContentType)
+			}
+		})
+	}
+}
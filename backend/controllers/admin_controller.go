@@ -7,6 +7,7 @@ import (
 	"github.com/A4-dev-team/mobileorder.git/apperrors"
 	"github.com/A4-dev-team/mobileorder.git/models"
 	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/A4-dev-team/mobileorder.git/validators"
 	"github.com/labstack/echo/v4"
 )
 
@@ -16,6 +17,13 @@ type AdminController interface {
 	UpdateOrderStatusHandler(ctx echo.Context) error
 	UpdateItemAvailabilityHandler(ctx echo.Context) error
 	DeleteOrderHandler(ctx echo.Context) error
+	UpdateItemPrepTimeHandler(ctx echo.Context) error
+	UpdateItemStockHandler(ctx echo.Context) error
+	CreateModifierGroupHandler(ctx echo.Context) error
+	DeleteModifierGroupHandler(ctx echo.Context) error
+	UpdateItemAvailabilityScheduleHandler(ctx echo.Context) error
+	UpdateItemBundleHandler(ctx echo.Context) error
+	UpdateItemDietaryInfoHandler(ctx echo.Context) error
 }
 
 type adminController struct {
@@ -45,7 +53,7 @@ func (c *adminController) GetCookingOrdersHandler(ctx echo.Context) error {
 	targetShopIDStr := ctx.Param("shop_id")
 	targetShopID, err := strconv.Atoi(targetShopIDStr)
 	if err != nil {
-		return apperrors.BadParam.Wrap(err, "店舗IDの形式が不正です。")
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
 	}
 
 	claims, err := GetClaims(ctx)
@@ -82,7 +90,7 @@ func (c *adminController) GetCompletedOrdersHandler(ctx echo.Context) error {
 	targetShopIDStr := ctx.Param("shop_id")
 	targetShopID, err := strconv.Atoi(targetShopIDStr)
 	if err != nil {
-		return apperrors.BadParam.Wrap(err, "店舗IDの形式が不正です。")
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
 	}
 
 	claims, err := GetClaims(ctx)
@@ -123,13 +131,13 @@ func (c *adminController) UpdateOrderStatusHandler(ctx echo.Context) error {
 		return err
 	}
 	if claims.ShopID == nil {
-		return apperrors.Forbidden.Wrap(nil, "店舗に紐づいていない管理者アカウントです。")
+		return apperrors.Forbidden.WrapMessage(nil, apperrors.MsgAdminWithoutShop, nil)
 	}
 	adminShopID := *claims.ShopID
 
 	targetOrderID, err := strconv.Atoi(ctx.Param("order_id"))
 	if err != nil {
-		return apperrors.BadParam.Wrap(err, "注文IDの形式が不正です。")
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidOrderID, nil)
 	}
 
 	err = c.s.UpdateOrderStatus(ctx.Request().Context(), adminShopID, targetOrderID)
@@ -142,7 +150,7 @@ func (c *adminController) UpdateOrderStatusHandler(ctx echo.Context) error {
 
 // DeleteOrderHandler は、管理者が担当する店舗の注文を削除します。
 // @Summary      注文の削除 (Admin)
-// @Description  管理者が担当する店舗の注文を削除します。
+// @Description  管理者が担当する店舗の注文を削除します。売上が確定したまま返金していない支払いがある注文は、先に返金しないと削除できません。
 // @Tags         管理者 (Admin)
 // @Accept       json
 // @Produce      json
@@ -153,6 +161,7 @@ func (c *adminController) UpdateOrderStatusHandler(ctx echo.Context) error {
 // @Failure      401 {object} map[string]string "認証に失敗しました"
 // @Failure      403 {object} map[string]string "この注文へのアクセス権がありません"
 // @Failure      404 {object} map[string]string "指定された注文が見つかりません"
+// @Failure      409 {object} map[string]string "返金していない支払いがある注文です"
 // @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
 // @Router       /admin/orders/{order_id}/delete [delete]
 func (c *adminController) DeleteOrderHandler(ctx echo.Context) error {
@@ -163,13 +172,13 @@ func (c *adminController) DeleteOrderHandler(ctx echo.Context) error {
 	}
 
 	if claims.ShopID == nil {
-		return apperrors.Forbidden.Wrap(nil, "店舗に紐づいていない管理者アカウントです。")
+		return apperrors.Forbidden.WrapMessage(nil, apperrors.MsgAdminWithoutShop, nil)
 	}
 	adminShopID := *claims.ShopID
 
 	targetOrderID, err := strconv.Atoi(ctx.Param("order_id"))
 	if err != nil {
-		return apperrors.BadParam.Wrap(err, "注文IDの形式が不正です。")
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidOrderID, nil)
 	}
 
 	err = c.s.DeleteOrder(ctx.Request().Context(), adminShopID, targetOrderID)
@@ -198,18 +207,18 @@ func (c *adminController) UpdateItemAvailabilityHandler(ctx echo.Context) error
 	itemIDParam := ctx.Param("item_id")
 	itemID, err := strconv.Atoi(itemIDParam)
 	if err != nil {
-		return apperrors.BadParam.Wrap(err, "商品IDの形式が不正です。")
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidItemID, nil)
 	}
 
 	// リクエストボディをバインド
 	var req models.UpdateItemAvailabilityRequest
 	if err := ctx.Bind(&req); err != nil {
-		return apperrors.BadParam.Wrap(err, "リクエスト形式が不正です。")
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
 	}
 
 	// バリデーション
 	if err := ctx.Validate(req); err != nil {
-		return apperrors.ValidationFailed.Wrap(err, "入力値が不正です。")
+		return apperrors.ValidationFailed.WrapMessage(err, apperrors.MsgValidationFailed, nil)
 	}
 
 	// サービス層で更新処理
@@ -221,3 +230,319 @@ func (c *adminController) UpdateItemAvailabilityHandler(ctx echo.Context) error
 		"message": "商品の販売状態を更新しました。",
 	})
 }
+
+// UpdateItemPrepTimeHandler は商品の調理時間を更新します
+// @Summary      商品の調理時間を更新 (Admin)
+// @Description  担当店舗での商品の調理時間（秒）を設定します。同じ商品を扱う他の店舗には影響しません。受け取り予定時刻の推定に使われます。nullを指定すると店舗の既定調理時間に戻ります。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        item_id path int true "商品ID"
+// @Param        request body models.UpdateItemPrepTimeRequest true "調理時間"
+// @Success      200 {object} map[string]string "成功メッセージ"
+// @Failure      400 {object} map[string]string "リクエストが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "店舗に紐づいていない管理者アカウントです"
+// @Failure      404 {object} map[string]string "商品が見つからないか、この店舗の商品ではありません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/items/{item_id}/prep-time [patch]
+func (c *adminController) UpdateItemPrepTimeHandler(ctx echo.Context) error {
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if claims.ShopID == nil {
+		return apperrors.Forbidden.WrapMessage(nil, apperrors.MsgAdminWithoutShop, nil)
+	}
+	adminShopID := *claims.ShopID
+
+	itemID, err := strconv.Atoi(ctx.Param("item_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidItemID, nil)
+	}
+
+	var req models.UpdateItemPrepTimeRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.UpdateItemPrepTimeRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	if err := c.s.UpdateItemPrepTime(ctx.Request().Context(), adminShopID, itemID, req.PrepSeconds); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "商品の調理時間を更新しました。"})
+}
+
+// UpdateItemStockHandler は商品のその日の在庫数を設定します
+// @Summary      商品の在庫数を設定 (Admin)
+// @Description  担当店舗の商品の在庫数を設定します。注文ごとに在庫数が減り、0になると売り切れになります。nullを指定すると在庫数を管理しなくなります。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        item_id path int true "商品ID"
+// @Param        request body models.UpdateItemStockRequest true "在庫数"
+// @Success      200 {object} map[string]string "成功メッセージ"
+// @Failure      400 {object} map[string]string "リクエストが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "店舗に紐づいていない管理者アカウントです"
+// @Failure      404 {object} map[string]string "商品が見つからないか、この店舗の商品ではありません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/items/{item_id}/stock [patch]
+func (c *adminController) UpdateItemStockHandler(ctx echo.Context) error {
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if claims.ShopID == nil {
+		return apperrors.Forbidden.WrapMessage(nil, apperrors.MsgAdminWithoutShop, nil)
+	}
+	adminShopID := *claims.ShopID
+
+	itemID, err := strconv.Atoi(ctx.Param("item_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidItemID, nil)
+	}
+
+	var req models.UpdateItemStockRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.UpdateItemStockRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	if err := c.s.UpdateItemStock(ctx.Request().Context(), adminShopID, itemID, req.StockQuantity); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "商品の在庫数を更新しました。"})
+}
+
+// CreateModifierGroupHandler は商品にオプショングループを登録します
+// @Summary      商品のオプショングループを登録 (Admin)
+// @Description  担当店舗の商品に、サイズやトッピングなどのオプショングループを選択肢ごと登録します。単一選択(single)の場合、最大選択数は1です。オプションは担当店舗だけに適用され、同じ商品を扱う他の店舗には表示されません。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        item_id path int true "商品ID"
+// @Param        request body models.CreateModifierGroupRequest true "オプショングループ"
+// @Success      201 {object} models.ModifierGroupResponse "登録したオプショングループ"
+// @Failure      400 {object} map[string]string "リクエストが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "店舗に紐づいていない管理者アカウントです"
+// @Failure      404 {object} map[string]string "商品が見つからないか、この店舗の商品ではありません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/items/{item_id}/modifier-groups [post]
+func (c *adminController) CreateModifierGroupHandler(ctx echo.Context) error {
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if claims.ShopID == nil {
+		return apperrors.Forbidden.WrapMessage(nil, apperrors.MsgAdminWithoutShop, nil)
+	}
+	adminShopID := *claims.ShopID
+
+	itemID, err := strconv.Atoi(ctx.Param("item_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidItemID, nil)
+	}
+
+	var req models.CreateModifierGroupRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.CreateModifierGroupRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	res, err := c.s.CreateModifierGroup(ctx.Request().Context(), adminShopID, itemID, req)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusCreated, res)
+}
+
+// DeleteModifierGroupHandler は商品のオプショングループを削除します
+// @Summary      商品のオプショングループを削除 (Admin)
+// @Description  担当店舗の商品のオプショングループを選択肢ごと削除します。過去の注文に記録されたオプションは残ります。
+// @Tags         管理者 (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        modifier_group_id path int true "オプショングループID"
+// @Success      200 {object} map[string]string "成功メッセージ"
+// @Failure      400 {object} map[string]string "オプショングループIDの形式が不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "店舗に紐づいていない管理者アカウントです"
+// @Failure      404 {object} map[string]string "オプショングループが見つかりません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/modifier-groups/{modifier_group_id} [delete]
+func (c *adminController) DeleteModifierGroupHandler(ctx echo.Context) error {
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if claims.ShopID == nil {
+		return apperrors.Forbidden.WrapMessage(nil, apperrors.MsgAdminWithoutShop, nil)
+	}
+	adminShopID := *claims.ShopID
+
+	modifierGroupID, err := strconv.Atoi(ctx.Param("modifier_group_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidModifierGroupID, nil)
+	}
+
+	if err := c.s.DeleteModifierGroup(ctx.Request().Context(), adminShopID, modifierGroupID); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "オプショングループを削除しました。"})
+}
+
+// UpdateItemAvailabilityScheduleHandler は商品を注文できる時間帯を設定します
+// @Summary      商品の販売時間帯を設定 (Admin)
+// @Description  担当店舗の商品を注文できる曜日と時間帯を、店舗のタイムゾーンの時刻で設定します（朝食は毎日07:00〜10:00、ランチは平日11:00〜14:00など）。既存の時間帯はすべて置き換わります。空の配列を指定するといつでも注文できます。時間帯の外では商品一覧で販売停止になり、注文できません。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        item_id path int true "商品ID"
+// @Param        request body models.UpdateItemAvailabilityScheduleRequest true "注文できる時間帯"
+// @Success      200 {object} models.ItemAvailabilityScheduleResponse "設定した時間帯"
+// @Failure      400 {object} map[string]string "リクエストが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "店舗に紐づいていない管理者アカウントです"
+// @Failure      404 {object} map[string]string "商品が見つからないか、この店舗の商品ではありません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/items/{item_id}/availability-schedule [put]
+func (c *adminController) UpdateItemAvailabilityScheduleHandler(ctx echo.Context) error {
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if claims.ShopID == nil {
+		return apperrors.Forbidden.WrapMessage(nil, apperrors.MsgAdminWithoutShop, nil)
+	}
+	adminShopID := *claims.ShopID
+
+	itemID, err := strconv.Atoi(ctx.Param("item_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidItemID, nil)
+	}
+
+	var req models.UpdateItemAvailabilityScheduleRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.UpdateItemAvailabilityScheduleRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	res, err := c.s.UpdateItemAvailabilitySchedule(ctx.Request().Context(), adminShopID, itemID, req)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// UpdateItemBundleHandler は商品をセット商品にし、枠と構成商品を設定します
+// @Summary      セット商品の構成を設定 (Admin)
+// @Description  担当店舗の商品をセット商品（ラーメン＋餃子セットなど）にします。枠ごとに構成商品を指定し、選択肢が1つの枠は固定、複数の枠はお客様が1つ選びます。セットの価格は商品の価格で、price_delta は選択肢ごとの加算額です。既存の枠はすべて置き換わり、空の配列を指定するとセットではない商品に戻ります。構成商品は担当店舗の商品に限り、セット商品は構成商品にできません。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        item_id path int true "商品ID"
+// @Param        request body models.UpdateItemBundleRequest true "セットの枠と構成商品"
+// @Success      200 {object} models.ItemBundleResponse "設定したセットの構成"
+// @Failure      400 {object} map[string]string "リクエストが不正か、構成商品が担当店舗の商品ではありません"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "店舗に紐づいていない管理者アカウントです"
+// @Failure      404 {object} map[string]string "商品が見つからないか、この店舗の商品ではありません"
+// @Failure      409 {object} map[string]string "他のセット商品の構成商品はセット商品にできません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/items/{item_id}/bundle [put]
+func (c *adminController) UpdateItemBundleHandler(ctx echo.Context) error {
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if claims.ShopID == nil {
+		return apperrors.Forbidden.WrapMessage(nil, apperrors.MsgAdminWithoutShop, nil)
+	}
+	adminShopID := *claims.ShopID
+
+	itemID, err := strconv.Atoi(ctx.Param("item_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidItemID, nil)
+	}
+
+	var req models.UpdateItemBundleRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.UpdateItemBundleRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	res, err := c.s.UpdateItemBundle(ctx.Request().Context(), adminShopID, itemID, req)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// UpdateItemDietaryInfoHandler は商品のアレルゲンと食事の制限を設定します
+// @Summary      商品のアレルゲンと食事の制限を設定 (Admin)
+// @Description  担当店舗の商品に含まれる特定原材料（shrimp=えび, crab=かに, walnut=くるみ, wheat=小麦, buckwheat=そば, egg=卵, milk=乳, peanut=落花生）と、対応している食事の制限（vegetarian, vegan, halal, gluten_free）を設定します。既存の設定はすべて置き換わり、空の配列を指定すると削除します。商品の情報なので、同じ商品を扱う全店舗に反映されます。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        item_id path int true "商品ID"
+// @Param        request body models.UpdateItemDietaryInfoRequest true "アレルゲンと食事の制限"
+// @Success      200 {object} models.ItemDietaryInfoResponse "設定したアレルゲンと食事の制限"
+// @Failure      400 {object} map[string]string "リクエストが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "店舗に紐づいていない管理者アカウントです"
+// @Failure      404 {object} map[string]string "商品が見つからないか、この店舗の商品ではありません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/items/{item_id}/dietary-info [put]
+func (c *adminController) UpdateItemDietaryInfoHandler(ctx echo.Context) error {
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if claims.ShopID == nil {
+		return apperrors.Forbidden.WrapMessage(nil, apperrors.MsgAdminWithoutShop, nil)
+	}
+	adminShopID := *claims.ShopID
+
+	itemID, err := strconv.Atoi(ctx.Param("item_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidItemID, nil)
+	}
+
+	var req models.UpdateItemDietaryInfoRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.UpdateItemDietaryInfoRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	res, err := c.s.UpdateItemDietaryInfo(ctx.Request().Context(), adminShopID, itemID, req)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
@@ -47,6 +47,53 @@ func (m *MockAdminService) UpdateItemAvailability(ctx context.Context, itemID in
 	return args.Error(0)
 }
 
+func (m *MockAdminService) UpdateItemPrepTime(ctx context.Context, adminShopID int, itemID int, prepSeconds *int) error {
+	args := m.Called(ctx, adminShopID, itemID, prepSeconds)
+	return args.Error(0)
+}
+
+func (m *MockAdminService) UpdateItemStock(ctx context.Context, adminShopID int, itemID int, stockQuantity *int) error {
+	args := m.Called(ctx, adminShopID, itemID, stockQuantity)
+	return args.Error(0)
+}
+
+func (m *MockAdminService) CreateModifierGroup(ctx context.Context, adminShopID int, itemID int, req models.CreateModifierGroupRequest) (*models.ModifierGroupResponse, error) {
+	args := m.Called(ctx, adminShopID, itemID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ModifierGroupResponse), args.Error(1)
+}
+
+func (m *MockAdminService) DeleteModifierGroup(ctx context.Context, adminShopID int, modifierGroupID int) error {
+	args := m.Called(ctx, adminShopID, modifierGroupID)
+	return args.Error(0)
+}
+
+func (m *MockAdminService) UpdateItemAvailabilitySchedule(ctx context.Context, adminShopID int, itemID int, req models.UpdateItemAvailabilityScheduleRequest) (*models.ItemAvailabilityScheduleResponse, error) {
+	args := m.Called(ctx, adminShopID, itemID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ItemAvailabilityScheduleResponse), args.Error(1)
+}
+
+func (m *MockAdminService) UpdateItemBundle(ctx context.Context, adminShopID int, itemID int, req models.UpdateItemBundleRequest) (*models.ItemBundleResponse, error) {
+	args := m.Called(ctx, adminShopID, itemID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ItemBundleResponse), args.Error(1)
+}
+
+func (m *MockAdminService) UpdateItemDietaryInfo(ctx context.Context, adminShopID int, itemID int, req models.UpdateItemDietaryInfoRequest) (*models.ItemDietaryInfoResponse, error) {
+	args := m.Called(ctx, adminShopID, itemID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ItemDietaryInfoResponse), args.Error(1)
+}
+
 // createTestToken はテスト用のJWTトークンを作成します
 func createTestToken(userID int, role models.UserRole, shopID *int) *jwt.Token {
 	claims := &models.JwtCustomClaims{
@@ -622,3 +669,911 @@ func TestAdminController_DeleteOrderHandler(t *testing.T) {
 		})
 	}
 }
+
+// TestAdminController_UpdateItemPrepTimeHandler のテストケース
+func TestAdminController_UpdateItemPrepTimeHandler(t *testing.T) {
+	prepSeconds := 600
+
+	tests := []struct {
+		name           string
+		itemID         string
+		requestBody    string
+		setupMock      func() *MockAdminService
+		setupToken     func() *jwt.Token
+		expectedStatus int
+		expectError    bool
+		expectedCode   apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: 調理時間の更新成功",
+			itemID:      "10",
+			requestBody: `{"prep_seconds":600}`,
+			setupMock: func() *MockAdminService {
+				mockService := new(MockAdminService)
+				mockService.On("UpdateItemPrepTime", mock.Anything, 1, 10, &prepSeconds).Return(nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "正常系: nullで店舗の既定値に戻す",
+			itemID:      "10",
+			requestBody: `{"prep_seconds":null}`,
+			setupMock: func() *MockAdminService {
+				mockService := new(MockAdminService)
+				mockService.On("UpdateItemPrepTime", mock.Anything, 1, 10, (*int)(nil)).Return(nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "異常系: 調理時間が上限を超えている",
+			itemID:      "10",
+			requestBody: `{"prep_seconds":7201}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 店舗の商品ではない",
+			itemID:      "99",
+			requestBody: `{"prep_seconds":600}`,
+			setupMock: func() *MockAdminService {
+				mockService := new(MockAdminService)
+				mockService.On("UpdateItemPrepTime", mock.Anything, 1, 99, &prepSeconds).Return(apperrors.NoData.Wrap(nil, "商品が見つかりません"))
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.NoData,
+		},
+		{
+			name:        "異常系: 商品IDの形式が不正",
+			itemID:      "invalid",
+			requestBody: `{"prep_seconds":600}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.BadParam,
+		},
+		{
+			name:        "異常系: 店舗IDがnilの管理者",
+			itemID:      "10",
+			requestBody: `{"prep_seconds":600}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, nil)
+			},
+			expectError:  true,
+			expectedCode: apperrors.Forbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewAdminController(mockService)
+
+			c, rec := createTestContextForOrder(
+				http.MethodPatch,
+				"/admin/items/"+tt.itemID+"/prep-time",
+				tt.requestBody,
+				map[string]string{"item_id": tt.itemID},
+				tt.setupToken(),
+			)
+
+			err := controller.UpdateItemPrepTimeHandler(c)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+// TestAdminController_UpdateItemStockHandler のテストケース
+func TestAdminController_UpdateItemStockHandler(t *testing.T) {
+	stock := 30
+	zero := 0
+
+	tests := []struct {
+		name           string
+		itemID         string
+		requestBody    string
+		setupMock      func() *MockAdminService
+		setupToken     func() *jwt.Token
+		expectedStatus int
+		expectError    bool
+		expectedCode   apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: 在庫数の設定成功",
+			itemID:      "10",
+			requestBody: `{"stock_quantity":30}`,
+			setupMock: func() *MockAdminService {
+				mockService := new(MockAdminService)
+				mockService.On("UpdateItemStock", mock.Anything, 1, 10, &stock).Return(nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "正常系: 在庫数0で売り切れにできる",
+			itemID:      "10",
+			requestBody: `{"stock_quantity":0}`,
+			setupMock: func() *MockAdminService {
+				mockService := new(MockAdminService)
+				mockService.On("UpdateItemStock", mock.Anything, 1, 10, &zero).Return(nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "正常系: nullで在庫数の管理をやめる",
+			itemID:      "10",
+			requestBody: `{"stock_quantity":null}`,
+			setupMock: func() *MockAdminService {
+				mockService := new(MockAdminService)
+				mockService.On("UpdateItemStock", mock.Anything, 1, 10, (*int)(nil)).Return(nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "異常系: 在庫数が負の値",
+			itemID:      "10",
+			requestBody: `{"stock_quantity":-1}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 店舗の商品ではない",
+			itemID:      "99",
+			requestBody: `{"stock_quantity":30}`,
+			setupMock: func() *MockAdminService {
+				mockService := new(MockAdminService)
+				mockService.On("UpdateItemStock", mock.Anything, 1, 99, &stock).Return(apperrors.NoData.Wrap(nil, "商品が見つかりません"))
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.NoData,
+		},
+		{
+			name:        "異常系: 店舗IDがnilの管理者",
+			itemID:      "10",
+			requestBody: `{"stock_quantity":30}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, nil)
+			},
+			expectError:  true,
+			expectedCode: apperrors.Forbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewAdminController(mockService)
+
+			c, rec := createTestContextForOrder(
+				http.MethodPatch,
+				"/admin/items/"+tt.itemID+"/stock",
+				tt.requestBody,
+				map[string]string{"item_id": tt.itemID},
+				tt.setupToken(),
+			)
+
+			err := controller.UpdateItemStockHandler(c)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+// TestAdminController_CreateModifierGroupHandler のテストケース
+func TestAdminController_CreateModifierGroupHandler(t *testing.T) {
+	validBody := `{"name":"サイズ","selection_type":"single","min_select":1,"max_select":1,"options":[{"name":"並盛り","price_delta":0},{"name":"大盛り","price_delta":100}]}`
+	validReq := models.CreateModifierGroupRequest{
+		Name:          "サイズ",
+		SelectionType: models.SingleSelect,
+		MinSelect:     1,
+		MaxSelect:     1,
+		Options: []models.CreateModifierOptionRequest{
+			{Name: "並盛り", PriceDelta: 0},
+			{Name: "大盛り", PriceDelta: 100},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		itemID           string
+		requestBody      string
+		setupMock        func() *MockAdminService
+		setupToken       func() *jwt.Token
+		expectedStatus   int
+		expectError      bool
+		expectedCode     apperrors.ErrCode
+		validateResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "正常系: オプショングループの登録成功",
+			itemID:      "5",
+			requestBody: validBody,
+			setupMock: func() *MockAdminService {
+				mockService := new(MockAdminService)
+				mockService.On("CreateModifierGroup", mock.Anything, 1, 5, validReq).Return(&models.ModifierGroupResponse{
+					ModifierGroupID: 1,
+					Name:            "サイズ",
+					SelectionType:   "single",
+					MinSelect:       1,
+					MaxSelect:       1,
+					Options: []models.ModifierOptionResponse{
+						{ModifierOptionID: 1, Name: "並盛り", PriceDelta: 0, IsAvailable: true},
+						{ModifierOptionID: 2, Name: "大盛り", PriceDelta: 100, IsAvailable: true},
+					},
+				}, nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusCreated,
+			validateResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var response models.ModifierGroupResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, 1, response.ModifierGroupID)
+				assert.Len(t, response.Options, 2)
+			},
+		},
+		{
+			name:        "異常系: 不正な選択方式",
+			itemID:      "5",
+			requestBody: `{"name":"サイズ","selection_type":"any","min_select":1,"max_select":1,"options":[{"name":"並盛り"}]}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ReqBodyDecodeFailed,
+		},
+		{
+			name:        "異常系: 選択肢がない",
+			itemID:      "5",
+			requestBody: `{"name":"サイズ","selection_type":"single","min_select":1,"max_select":1,"options":[]}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 最大選択数が最小選択数より小さい",
+			itemID:      "5",
+			requestBody: `{"name":"トッピング","selection_type":"multi","min_select":2,"max_select":1,"options":[{"name":"味玉"},{"name":"替え玉"}]}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 店舗の商品ではない",
+			itemID:      "5",
+			requestBody: validBody,
+			setupMock: func() *MockAdminService {
+				mockService := new(MockAdminService)
+				mockService.On("CreateModifierGroup", mock.Anything, 1, 5, validReq).Return(nil, apperrors.NoData.Wrap(nil, "商品が見つかりません"))
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.NoData,
+		},
+		{
+			name:        "異常系: 店舗IDがnilの管理者",
+			itemID:      "5",
+			requestBody: validBody,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, nil)
+			},
+			expectError:  true,
+			expectedCode: apperrors.Forbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewAdminController(mockService)
+
+			c, rec := createTestContextForOrder(
+				http.MethodPost,
+				"/admin/items/"+tt.itemID+"/modifier-groups",
+				tt.requestBody,
+				map[string]string{"item_id": tt.itemID},
+				tt.setupToken(),
+			)
+
+			err := controller.CreateModifierGroupHandler(c)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+				if tt.validateResponse != nil {
+					tt.validateResponse(t, rec)
+				}
+			}
+		})
+	}
+}
+
+// TestAdminController_DeleteModifierGroupHandler のテストケース
+func TestAdminController_DeleteModifierGroupHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		modifierGroupID string
+		setupMock       func() *MockAdminService
+		setupToken      func() *jwt.Token
+		expectedStatus  int
+		expectError     bool
+		expectedCode    apperrors.ErrCode
+	}{
+		{
+			name:            "正常系: オプショングループの削除成功",
+			modifierGroupID: "3",
+			setupMock: func() *MockAdminService {
+				mockService := new(MockAdminService)
+				mockService.On("DeleteModifierGroup", mock.Anything, 1, 3).Return(nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:            "異常系: オプショングループが見つからない",
+			modifierGroupID: "3",
+			setupMock: func() *MockAdminService {
+				mockService := new(MockAdminService)
+				mockService.On("DeleteModifierGroup", mock.Anything, 1, 3).Return(apperrors.NoData.Wrap(nil, "オプショングループが見つかりません"))
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.NoData,
+		},
+		{
+			name:            "異常系: オプショングループIDの形式が不正",
+			modifierGroupID: "invalid",
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.BadParam,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewAdminController(mockService)
+
+			c, rec := createTestContext(
+				http.MethodDelete,
+				"/admin/modifier-groups/"+tt.modifierGroupID,
+				map[string]string{"modifier_group_id": tt.modifierGroupID},
+				tt.setupToken(),
+			)
+
+			err := controller.DeleteModifierGroupHandler(c)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+// TestAdminController_UpdateItemAvailabilityScheduleHandler のテストケース
+func TestAdminController_UpdateItemAvailabilityScheduleHandler(t *testing.T) {
+	lunch := models.UpdateItemAvailabilityScheduleRequest{Windows: []models.AvailabilityWindowRequest{
+		{Days: []int{1, 2, 3, 4, 5}, Start: "11:00", End: "14:00"},
+	}}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func() *MockAdminService
+		setupToken     func() *jwt.Token
+		expectedStatus int
+		expectError    bool
+		expectedCode   apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: 平日のランチの時間帯を設定",
+			requestBody: `{"windows":[{"days":[1,2,3,4,5],"start":"11:00","end":"14:00"}]}`,
+			setupMock: func() *MockAdminService {
+				mockService := new(MockAdminService)
+				mockService.On("UpdateItemAvailabilitySchedule", mock.Anything, 1, 10, lunch).Return(&models.ItemAvailabilityScheduleResponse{ItemID: 10}, nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "正常系: 24:00で日の終わりまでにできる",
+			requestBody: `{"windows":[{"days":[0],"start":"17:00","end":"24:00"}]}`,
+			setupMock: func() *MockAdminService {
+				mockService := new(MockAdminService)
+				req := models.UpdateItemAvailabilityScheduleRequest{Windows: []models.AvailabilityWindowRequest{{Days: []int{0}, Start: "17:00", End: "24:00"}}}
+				mockService.On("UpdateItemAvailabilitySchedule", mock.Anything, 1, 10, req).Return(&models.ItemAvailabilityScheduleResponse{ItemID: 10}, nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "正常系: 空の配列でいつでも注文できるようにする",
+			requestBody: `{"windows":[]}`,
+			setupMock: func() *MockAdminService {
+				mockService := new(MockAdminService)
+				req := models.UpdateItemAvailabilityScheduleRequest{Windows: []models.AvailabilityWindowRequest{}}
+				mockService.On("UpdateItemAvailabilitySchedule", mock.Anything, 1, 10, req).Return(&models.ItemAvailabilityScheduleResponse{ItemID: 10}, nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "異常系: windowsがない",
+			requestBody: `{}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 曜日が範囲外",
+			requestBody: `{"windows":[{"days":[7],"start":"07:00","end":"10:00"}]}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 時刻の形式が不正",
+			requestBody: `{"windows":[{"days":[1],"start":"7時","end":"10:00"}]}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 店舗IDがnilの管理者",
+			requestBody: `{"windows":[]}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, nil)
+			},
+			expectError:  true,
+			expectedCode: apperrors.Forbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewAdminController(mockService)
+
+			c, rec := createTestContextForOrder(
+				http.MethodPut,
+				"/admin/items/10/availability-schedule",
+				tt.requestBody,
+				map[string]string{"item_id": "10"},
+				tt.setupToken(),
+			)
+
+			err := controller.UpdateItemAvailabilityScheduleHandler(c)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestAdminController_UpdateItemBundleHandler(t *testing.T) {
+	ramenGyoza := models.UpdateItemBundleRequest{Slots: []models.BundleSlotRequest{
+		{Name: "ラーメン", Choices: []models.BundleChoiceRequest{{ItemID: 5}, {ItemID: 6, PriceDelta: 100, SortOrder: 1}}},
+		{Name: "餃子", Quantity: 2, SortOrder: 1, Choices: []models.BundleChoiceRequest{{ItemID: 20}}},
+	}}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func() *MockAdminService
+		setupToken     func() *jwt.Token
+		expectedStatus int
+		expectError    bool
+		expectedCode   apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: ラーメンを選べて餃子が付くセットを設定",
+			requestBody: `{"slots":[{"name":"ラーメン","choices":[{"item_id":5},{"item_id":6,"price_delta":100,"sort_order":1}]},{"name":"餃子","quantity":2,"sort_order":1,"choices":[{"item_id":20}]}]}`,
+			setupMock: func() *MockAdminService {
+				mockService := new(MockAdminService)
+				mockService.On("UpdateItemBundle", mock.Anything, 1, 30, ramenGyoza).Return(&models.ItemBundleResponse{ItemID: 30}, nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "正常系: 空の配列でセットを解除",
+			requestBody: `{"slots":[]}`,
+			setupMock: func() *MockAdminService {
+				mockService := new(MockAdminService)
+				req := models.UpdateItemBundleRequest{Slots: []models.BundleSlotRequest{}}
+				mockService.On("UpdateItemBundle", mock.Anything, 1, 30, req).Return(&models.ItemBundleResponse{ItemID: 30}, nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "異常系: slotsがない",
+			requestBody: `{}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 選択肢のない枠",
+			requestBody: `{"slots":[{"name":"ラーメン","choices":[]}]}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 枠の数量が範囲外",
+			requestBody: `{"slots":[{"name":"餃子","quantity":11,"choices":[{"item_id":20}]}]}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 店舗IDがnilの管理者",
+			requestBody: `{"slots":[]}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, nil)
+			},
+			expectError:  true,
+			expectedCode: apperrors.Forbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewAdminController(mockService)
+
+			c, rec := createTestContextForOrder(
+				http.MethodPut,
+				"/admin/items/30/bundle",
+				tt.requestBody,
+				map[string]string{"item_id": "30"},
+				tt.setupToken(),
+			)
+
+			err := controller.UpdateItemBundleHandler(c)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestAdminController_UpdateItemDietaryInfoHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func() *MockAdminService
+		setupToken     func() *jwt.Token
+		expectedStatus int
+		expectError    bool
+		expectedCode   apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: 小麦と卵を含むベジタリアン向けの商品",
+			requestBody: `{"allergens":["wheat","egg"],"dietary_tags":["vegetarian"]}`,
+			setupMock: func() *MockAdminService {
+				mockService := new(MockAdminService)
+				req := models.UpdateItemDietaryInfoRequest{Allergens: []string{"wheat", "egg"}, DietaryTags: []string{"vegetarian"}}
+				res := &models.ItemDietaryInfoResponse{ItemID: 10, Allergens: []string{"egg", "wheat"}, DietaryTags: []string{"vegetarian"}}
+				mockService.On("UpdateItemDietaryInfo", mock.Anything, 1, 10, req).Return(res, nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "正常系: 空の配列で削除",
+			requestBody: `{"allergens":[],"dietary_tags":[]}`,
+			setupMock: func() *MockAdminService {
+				mockService := new(MockAdminService)
+				req := models.UpdateItemDietaryInfoRequest{Allergens: []string{}, DietaryTags: []string{}}
+				mockService.On("UpdateItemDietaryInfo", mock.Anything, 1, 10, req).Return(&models.ItemDietaryInfoResponse{ItemID: 10}, nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "異常系: 特定原材料でないアレルゲン",
+			requestBody: `{"allergens":["pork"],"dietary_tags":[]}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: アレルゲンの重複",
+			requestBody: `{"allergens":["egg","egg"],"dietary_tags":[]}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: dietary_tagsがない",
+			requestBody: `{"allergens":["egg"]}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 店舗IDがnilの管理者",
+			requestBody: `{"allergens":[],"dietary_tags":[]}`,
+			setupMock: func() *MockAdminService {
+				return new(MockAdminService)
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, nil)
+			},
+			expectError:  true,
+			expectedCode: apperrors.Forbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewAdminController(mockService)
+
+			c, rec := createTestContextForOrder(
+				http.MethodPut,
+				"/admin/items/10/dietary-info",
+				tt.requestBody,
+				map[string]string{"item_id": "10"},
+				tt.setupToken(),
+			)
+
+			err := controller.UpdateItemDietaryInfoHandler(c)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
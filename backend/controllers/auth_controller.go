@@ -44,12 +44,12 @@ func NewAuthController(s services.AuthServicer) AuthController {
 func (c *authController) SignUpHandler(ctx echo.Context) error {
 	req := models.AuthenticateRequest{}
 	if err := ctx.Bind(&req); err != nil {
-		return apperrors.ReqBodyDecodeFailed.Wrap(err, "リクエストの形式が不正です。")
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
 	}
 
 	validator := validators.NewValidator[models.AuthenticateRequest]()
 	if err := validator.Validate(req); err != nil {
-		return apperrors.ValidationFailed.Wrap(err, err.Error())
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
 	}
 
 	// レート制限チェック
@@ -99,12 +99,12 @@ func (c *authController) SignUpHandler(ctx echo.Context) error {
 func (c *authController) LogInHandler(ctx echo.Context) error {
 	req := models.AuthenticateRequest{}
 	if err := ctx.Bind(&req); err != nil {
-		return apperrors.ReqBodyDecodeFailed.Wrap(err, "リクエストの形式が不正です。")
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
 	}
 
 	validator := validators.NewValidator[models.AuthenticateRequest]()
 	if err := validator.Validate(req); err != nil {
-		return apperrors.ValidationFailed.Wrap(err, err.Error())
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
 	}
 
 	// レート制限チェック
@@ -0,0 +1,249 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/A4-dev-team/mobileorder.git/validators"
+	"github.com/labstack/echo/v4"
+)
+
+type CategoryController interface {
+	CreateCategoryHandler(ctx echo.Context) error
+	GetShopCategoriesHandler(ctx echo.Context) error
+	UpdateCategoryHandler(ctx echo.Context) error
+	DeleteCategoryHandler(ctx echo.Context) error
+	AssignItemCategoryHandler(ctx echo.Context) error
+}
+
+type categoryController struct {
+	s services.CategoryServicer
+}
+
+func NewCategoryController(s services.CategoryServicer) CategoryController {
+	return &categoryController{s}
+}
+
+// CreateCategoryHandler は店舗の商品カテゴリを登録します。
+// @Summary      カテゴリを登録 (Admin)
+// @Description  メニューのタブとして表示する商品カテゴリ（ラーメン、サイドメニュー、ドリンクなど）を登録します。sort_orderの小さい順に表示します。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id path int true "店舗ID"
+// @Param        request body models.CategoryRequest true "カテゴリ"
+// @Success      201 {object} models.CategoryResponse "登録したカテゴリ"
+// @Failure      400 {object} map[string]string "リクエストが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      409 {object} map[string]string "同じ名前のカテゴリが既に登録されています"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/categories [post]
+func (c *categoryController) CreateCategoryHandler(ctx echo.Context) error {
+	targetShopID, err := strconv.Atoi(ctx.Param("shop_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
+	}
+
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if err := AuthorizeShopAccess(claims, targetShopID); err != nil {
+		return err
+	}
+
+	var req models.CategoryRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.CategoryRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	res, err := c.s.CreateCategory(ctx.Request().Context(), targetShopID, req)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusCreated, res)
+}
+
+// GetShopCategoriesHandler は店舗のカテゴリ一覧を取得します。
+// @Summary      カテゴリ一覧を取得 (Admin)
+// @Description  店舗のカテゴリを商品のないものも含めて表示順に返します。
+// @Tags         管理者 (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id path int true "店舗ID"
+// @Success      200 {array} models.CategoryResponse "カテゴリのリスト"
+// @Failure      400 {object} map[string]string "店舗IDの形式が不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/categories [get]
+func (c *categoryController) GetShopCategoriesHandler(ctx echo.Context) error {
+	targetShopID, err := strconv.Atoi(ctx.Param("shop_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
+	}
+
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if err := AuthorizeShopAccess(claims, targetShopID); err != nil {
+		return err
+	}
+
+	categories, err := c.s.GetShopCategories(ctx.Request().Context(), targetShopID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, categories)
+}
+
+// UpdateCategoryHandler は店舗のカテゴリの名前と表示順を更新します。
+// @Summary      カテゴリを更新 (Admin)
+// @Description  カテゴリの名前と表示順（sort_order）を更新します。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id     path int true "店舗ID"
+// @Param        category_id path int true "カテゴリID"
+// @Param        request body models.CategoryRequest true "カテゴリ"
+// @Success      200 {object} models.CategoryResponse "更新したカテゴリ"
+// @Failure      400 {object} map[string]string "リクエストが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      404 {object} map[string]string "カテゴリが見つかりません"
+// @Failure      409 {object} map[string]string "同じ名前のカテゴリが既に登録されています"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/categories/{category_id} [patch]
+func (c *categoryController) UpdateCategoryHandler(ctx echo.Context) error {
+	targetShopID, err := strconv.Atoi(ctx.Param("shop_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
+	}
+	categoryID, err := strconv.Atoi(ctx.Param("category_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidCategoryID, nil)
+	}
+
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if err := AuthorizeShopAccess(claims, targetShopID); err != nil {
+		return err
+	}
+
+	var req models.CategoryRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.CategoryRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	res, err := c.s.UpdateCategory(ctx.Request().Context(), targetShopID, categoryID, req)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// DeleteCategoryHandler は店舗のカテゴリを削除します。
+// @Summary      カテゴリを削除 (Admin)
+// @Description  カテゴリを削除します。カテゴリの商品は削除されず、カテゴリなし（メニューの「その他」）になります。
+// @Tags         管理者 (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id     path int true "店舗ID"
+// @Param        category_id path int true "カテゴリID"
+// @Success      200 {object} map[string]string "成功メッセージ"
+// @Failure      400 {object} map[string]string "IDの形式が不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      404 {object} map[string]string "カテゴリが見つかりません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/categories/{category_id} [delete]
+func (c *categoryController) DeleteCategoryHandler(ctx echo.Context) error {
+	targetShopID, err := strconv.Atoi(ctx.Param("shop_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
+	}
+	categoryID, err := strconv.Atoi(ctx.Param("category_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidCategoryID, nil)
+	}
+
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if err := AuthorizeShopAccess(claims, targetShopID); err != nil {
+		return err
+	}
+
+	if err := c.s.DeleteCategory(ctx.Request().Context(), targetShopID, categoryID); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "カテゴリを削除しました。"})
+}
+
+// AssignItemCategoryHandler は店舗の商品のカテゴリを設定します。
+// @Summary      商品のカテゴリを設定 (Admin)
+// @Description  担当店舗の商品をカテゴリに割り当てます。category_idにnullを指定するとカテゴリなしに戻します。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id path int true "店舗ID"
+// @Param        item_id path int true "商品ID"
+// @Param        request body models.AssignItemCategoryRequest true "カテゴリ"
+// @Success      200 {object} map[string]string "成功メッセージ"
+// @Failure      400 {object} map[string]string "リクエストが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      404 {object} map[string]string "商品を店舗で扱っていないか、カテゴリが見つかりません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/items/{item_id}/category [patch]
+func (c *categoryController) AssignItemCategoryHandler(ctx echo.Context) error {
+	targetShopID, err := strconv.Atoi(ctx.Param("shop_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
+	}
+	itemID, err := strconv.Atoi(ctx.Param("item_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidItemID, nil)
+	}
+
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if err := AuthorizeShopAccess(claims, targetShopID); err != nil {
+		return err
+	}
+
+	var req models.AssignItemCategoryRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.AssignItemCategoryRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	if err := c.s.AssignItemCategory(ctx.Request().Context(), targetShopID, itemID, req.CategoryID); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "商品のカテゴリを設定しました。"})
+}
@@ -0,0 +1,233 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/controllers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCategoryService は CategoryServicer インターフェースのモック実装です
+type MockCategoryService struct {
+	mock.Mock
+}
+
+func (m *MockCategoryService) CreateCategory(ctx context.Context, shopID int, req models.CategoryRequest) (*models.CategoryResponse, error) {
+	args := m.Called(ctx, shopID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CategoryResponse), args.Error(1)
+}
+
+func (m *MockCategoryService) GetShopCategories(ctx context.Context, shopID int) ([]models.CategoryResponse, error) {
+	args := m.Called(ctx, shopID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CategoryResponse), args.Error(1)
+}
+
+func (m *MockCategoryService) UpdateCategory(ctx context.Context, shopID int, categoryID int, req models.CategoryRequest) (*models.CategoryResponse, error) {
+	args := m.Called(ctx, shopID, categoryID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CategoryResponse), args.Error(1)
+}
+
+func (m *MockCategoryService) DeleteCategory(ctx context.Context, shopID int, categoryID int) error {
+	args := m.Called(ctx, shopID, categoryID)
+	return args.Error(0)
+}
+
+func (m *MockCategoryService) AssignItemCategory(ctx context.Context, shopID int, itemID int, categoryID *int) error {
+	args := m.Called(ctx, shopID, itemID, categoryID)
+	return args.Error(0)
+}
+
+func TestCategoryController_CreateCategoryHandler(t *testing.T) {
+	ramen := models.CategoryRequest{Name: "ラーメン", SortOrder: 1}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func() *MockCategoryService
+		setupToken     func() *jwt.Token
+		expectedStatus int
+		expectedCode   apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: カテゴリを登録",
+			requestBody: `{"name":"ラーメン","sort_order":1}`,
+			setupMock: func() *MockCategoryService {
+				mockService := new(MockCategoryService)
+				mockService.On("CreateCategory", mock.Anything, 1, ramen).
+					Return(&models.CategoryResponse{CategoryID: 10, Name: "ラーメン", SortOrder: 1}, nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, intPtr(1))
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:        "異常系: 名前が空",
+			requestBody: `{"name":"","sort_order":1}`,
+			setupMock: func() *MockCategoryService {
+				return new(MockCategoryService)
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, intPtr(1))
+			},
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 同じ名前が登録済み",
+			requestBody: `{"name":"ラーメン","sort_order":1}`,
+			setupMock: func() *MockCategoryService {
+				mockService := new(MockCategoryService)
+				mockService.On("CreateCategory", mock.Anything, 1, ramen).
+					Return(nil, apperrors.Conflict.Wrap(nil, "同じ名前のカテゴリが既に登録されています。"))
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, intPtr(1))
+			},
+			expectedCode: apperrors.Conflict,
+		},
+		{
+			name:        "異常系: 他店舗の管理者",
+			requestBody: `{"name":"ラーメン","sort_order":1}`,
+			setupMock: func() *MockCategoryService {
+				return new(MockCategoryService)
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, intPtr(2))
+			},
+			expectedCode: apperrors.Forbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewCategoryController(mockService)
+			c, rec := createTestContextForOrder(
+				http.MethodPost,
+				"/admin/shops/1/categories",
+				tt.requestBody,
+				map[string]string{"shop_id": "1"},
+				tt.setupToken(),
+			)
+
+			err := controller.CreateCategoryHandler(c)
+
+			if tt.expectedCode != "" {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			var res models.CategoryResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, 10, res.CategoryID)
+		})
+	}
+}
+
+func TestCategoryController_AssignItemCategoryHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		itemID         string
+		requestBody    string
+		setupMock      func() *MockCategoryService
+		expectedStatus int
+		expectedCode   apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: カテゴリを設定",
+			itemID:      "5",
+			requestBody: `{"category_id":10}`,
+			setupMock: func() *MockCategoryService {
+				mockService := new(MockCategoryService)
+				mockService.On("AssignItemCategory", mock.Anything, 1, 5, intPtr(10)).Return(nil)
+				return mockService
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "正常系: nullでカテゴリなしに戻す",
+			itemID:      "5",
+			requestBody: `{"category_id":null}`,
+			setupMock: func() *MockCategoryService {
+				mockService := new(MockCategoryService)
+				mockService.On("AssignItemCategory", mock.Anything, 1, 5, (*int)(nil)).Return(nil)
+				return mockService
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "異常系: 商品IDが数値でない",
+			itemID:      "abc",
+			requestBody: `{"category_id":10}`,
+			setupMock: func() *MockCategoryService {
+				return new(MockCategoryService)
+			},
+			expectedCode: apperrors.BadParam,
+		},
+		{
+			name:        "異常系: 他店舗のカテゴリ",
+			itemID:      "5",
+			requestBody: `{"category_id":99}`,
+			setupMock: func() *MockCategoryService {
+				mockService := new(MockCategoryService)
+				mockService.On("AssignItemCategory", mock.Anything, 1, 5, intPtr(99)).
+					Return(apperrors.NoData.Wrap(nil, "指定された商品をこの店舗で扱っていないか、カテゴリがこの店舗のものではありません。"))
+				return mockService
+			},
+			expectedCode: apperrors.NoData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewCategoryController(mockService)
+			c, rec := createTestContextForOrder(
+				http.MethodPatch,
+				"/admin/shops/1/items/"+tt.itemID+"/category",
+				tt.requestBody,
+				map[string]string{"shop_id": "1", "item_id": tt.itemID},
+				createTestToken(1, models.AdminRole, intPtr(1)),
+			)
+
+			err := controller.AssignItemCategoryHandler(c)
+
+			if tt.expectedCode != "" {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/i18n"
 	"github.com/A4-dev-team/mobileorder.git/models"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
@@ -11,11 +12,11 @@ import (
 func GetClaims(ctx echo.Context) (*models.JwtCustomClaims, error) {
 	userToken, ok := ctx.Get("user").(*jwt.Token)
 	if !ok || userToken == nil {
-		return nil, apperrors.Unauthorized.Wrap(nil, "リクエストにトークンが含まれていません。")
+		return nil, apperrors.Unauthorized.WrapMessage(nil, apperrors.MsgMissingToken, nil)
 	}
 	claims, ok := userToken.Claims.(*models.JwtCustomClaims)
 	if !ok {
-		return nil, apperrors.Unauthorized.Wrap(nil, "トークンクレームの解析に失敗しました。")
+		return nil, apperrors.Unauthorized.WrapMessage(nil, apperrors.MsgInvalidTokenClaims, nil)
 	}
 	return claims, nil
 }
@@ -23,10 +24,29 @@ func GetClaims(ctx echo.Context) (*models.JwtCustomClaims, error) {
 // AuthorizeShopAccess は管理者の店舗アクセス権限をチェックします
 func AuthorizeShopAccess(claims *models.JwtCustomClaims, targetShopID int) error {
 	if claims.ShopID == nil {
-		return apperrors.Forbidden.Wrap(nil, "店舗に紐づいていない管理者アカウントです。")
+		return apperrors.Forbidden.WrapMessage(nil, apperrors.MsgAdminWithoutShop, nil)
 	}
 	if *claims.ShopID != targetShopID {
-		return apperrors.Forbidden.Wrap(nil, "この店舗へのアクセス権がありません。")
+		return apperrors.Forbidden.WrapMessage(nil, apperrors.MsgShopAccessDenied, nil)
 	}
 	return nil
 }
+
+// negotiateLanguage はlangクエリパラメータかAccept-Languageヘッダーから表示する言語を決め、Content-Languageに設定します。
+// 対応していない言語は日本語になります
+func negotiateLanguage(ctx echo.Context) string {
+	lang := i18n.Negotiate(ctx.QueryParam("lang"), ctx.Request().Header.Get("Accept-Language"))
+	header := ctx.Response().Header()
+	header.Set("Content-Language", lang)
+	header.Add(echo.HeaderVary, "Accept-Language")
+	return lang
+}
+
+// translationLanguage は翻訳を登録する言語のパスパラメータを検証します
+func translationLanguage(ctx echo.Context) (string, error) {
+	lang := ctx.Param("lang")
+	if !i18n.IsTranslationLanguage(lang) {
+		return "", apperrors.BadParam.WrapMessage(nil, apperrors.MsgUnsupportedTranslationLang, nil)
+	}
+	return lang, nil
+}
@@ -1,16 +1,32 @@
 package controllers
 
 import (
+	"fmt"
+	"io"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
 	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/A4-dev-team/mobileorder.git/validators"
 	"github.com/labstack/echo/v4"
 )
 
+// 取り込めるメニューファイルの大きさ（バイト）
+const maxMenuFileSize = 1 << 20
+
 type ItemController interface {
 	GetItemListHandler(ctx echo.Context) error
+	GetMenuHandler(ctx echo.Context) error
+	ImportMenuHandler(ctx echo.Context) error
+	ExportMenuHandler(ctx echo.Context) error
+	UploadItemImageHandler(ctx echo.Context) error
+	SchedulePriceChangeHandler(ctx echo.Context) error
+	GetPriceHistoryHandler(ctx echo.Context) error
+	CancelPriceChangeHandler(ctx echo.Context) error
 }
 
 type itemController struct {
@@ -27,12 +43,366 @@ func (c *itemController) GetItemListHandler(ctx echo.Context) error {
 	shopIDStr := ctx.Param("shop_id")
 	shopID, err := strconv.Atoi(shopIDStr)
 	if err != nil {
-		return apperrors.BadParam.Wrap(err, "店舗IDの形式が不正です。")
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
+	}
+	query, err := bindItemListQuery(ctx)
+	if err != nil {
+		return err
 	}
 
-	itemList, err := c.s.GetItemList(shopID)
+	itemList, err := c.s.GetItemList(shopID, query)
 	if err != nil {
 		return err
 	}
 	return ctx.JSON(http.StatusOK, itemList)
 }
+
+// bindItemListQuery は商品一覧の絞り込み条件と表示する言語を取り出します。
+// exclude_allergens（含む商品を除くアレルゲン）と dietary_tags（対応している食事の制限）はカンマ区切りで指定します
+func bindItemListQuery(ctx echo.Context) (models.ItemListQuery, error) {
+	query := models.ItemListQuery{
+		ExcludeAllergens: splitQueryList(ctx.QueryParam("exclude_allergens")),
+		DietaryTags:      splitQueryList(ctx.QueryParam("dietary_tags")),
+		Lang:             negotiateLanguage(ctx),
+	}
+	validator := validators.NewValidator[models.ItemListQuery]()
+	if err := validator.Validate(query); err != nil {
+		return query, apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+	return query, nil
+}
+
+// splitQueryList はカンマ区切りのクエリパラメータを分割します。空の要素は除きます
+func splitQueryList(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// GetMenuHandler は店舗のメニューをカテゴリごとにまとめて取得します。
+// @Summary      カテゴリごとのメニューを取得
+// @Description  店舗の商品をカテゴリの表示順（sort_order）にまとめて返します。商品のないカテゴリは含まず、カテゴリのない商品は最後の「その他」（category_idはnull）にまとめます。各商品の内容と絞り込み条件は商品一覧と同じです。langかAccept-Languageで英語・中国語・韓国語を指定すると、翻訳のある商品名・説明・カテゴリ名を翻訳します。
+// @Tags         商品 (Item)
+// @Produce      json
+// @Param        shop_id           path  int    true  "店舗ID"
+// @Param        exclude_allergens query string false "含む商品を除くアレルゲン（カンマ区切り）" example(shrimp,crab)
+// @Param        dietary_tags      query string false "全てに対応している商品だけを残す食事の制限（カンマ区切り）" example(vegetarian)
+// @Param        lang              query string false "表示する言語。Accept-Languageより優先します" Enums(ja, en, zh, ko)
+// @Param        Accept-Language   header string false "表示する言語の希望。対応していない言語は日本語になります" example(en-US,en;q=0.9)
+// @Success      200 {object} models.MenuResponse "カテゴリごとのメニュー"
+// @Failure      400 {object} map[string]string "店舗IDの形式か絞り込み条件が不正です"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /shops/{shop_id}/menu [get]
+func (c *itemController) GetMenuHandler(ctx echo.Context) error {
+	shopID, err := strconv.Atoi(ctx.Param("shop_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
+	}
+
+	query, err := bindItemListQuery(ctx)
+	if err != nil {
+		return err
+	}
+
+	menu, err := c.s.GetMenu(ctx.Request().Context(), shopID, query)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, menu)
+}
+
+// ImportMenuHandler はメニューファイルから店舗の商品をまとめて登録・更新します。
+// @Summary      メニューの一括取り込み (Admin)
+// @Description  CSVまたはJSONのメニューファイルを file としてアップロードし、item_id のない行は新しい商品として登録、item_id のある行は店舗の商品を更新します。変更は1つのトランザクションで反映し、エラーのある行が1つでもあれば何も反映せずに400を返します。dry_run=true では反映せずに差分と行ごとのエラーを返します。行ごとのエラーの文言は lang か Accept-Language の言語になります。ファイルの形式は format を省略すると拡張子で判断します。
+// @Tags         管理者 (Admin)
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id path     int    true  "店舗ID"
+// @Param        file    formData file   true  "メニューファイル（1MBまで、1000行まで）"
+// @Param        format  query    string false "ファイルの形式" Enums(csv, json)
+// @Param        dry_run query    bool   false "trueでは反映せずに差分だけを返す"
+// @Success      200 {object} models.MenuImportResponse "取り込み結果（dry_runでは差分）"
+// @Failure      400 {object} models.MenuImportResponse "エラーのある行があるため反映しませんでした"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/menu/import [post]
+func (c *itemController) ImportMenuHandler(ctx echo.Context) error {
+	targetShopID, err := authorizeMenuShop(ctx)
+	if err != nil {
+		return err
+	}
+	dryRun := false
+	if v := ctx.QueryParam("dry_run"); v != "" {
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidDryRun, nil)
+		}
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgMenuFileRequired, nil)
+	}
+	if fileHeader.Size > maxMenuFileSize {
+		return apperrors.BadParam.WrapMessage(nil, apperrors.MsgMenuFileTooLarge, apperrors.Params{"max_mb": maxMenuFileSize >> 20})
+	}
+	format := ctx.QueryParam("format")
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), ".")
+	}
+	if format != "csv" && format != "json" {
+		return apperrors.BadParam.WrapMessage(nil, apperrors.MsgInvalidMenuFormat, nil)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgMenuFileUnreadable, nil)
+	}
+	defer file.Close()
+
+	res, err := c.s.ImportMenu(ctx.Request().Context(), targetShopID, format, file, dryRun)
+	if err != nil {
+		return err
+	}
+	// 行ごとのエラーは、エラーのレスポンスと同じくlangかAccept-Languageの言語にする
+	lang := apperrors.NegotiateLanguage(ctx.QueryParam("lang"), ctx.Request().Header.Get("Accept-Language"))
+	for i, rowError := range res.Errors {
+		if rowError.MessageID != "" {
+			res.Errors[i].Message = apperrors.Text(lang, rowError.MessageID, rowError.Params)
+		}
+	}
+	if !res.DryRun && !res.Applied {
+		return ctx.JSON(http.StatusBadRequest, res)
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// ExportMenuHandler は店舗の商品一覧を、取り込みと同じ形式で書き出します。
+// @Summary      メニューの書き出し (Admin)
+// @Description  店舗の商品一覧（GET /shops/{shop_id}/items と同じ内容）を、メニューの一括取り込みと同じ形式のCSVまたはJSONで書き出します。在庫数が0の商品は is_available が false になります。
+// @Tags         管理者 (Admin)
+// @Produce      json
+// @Produce      text/csv
+// @Security     BearerAuth
+// @Param        shop_id path  int    true  "店舗ID"
+// @Param        format  query string false "形式（省略時はcsv）" Enums(csv, json)
+// @Success      200 {array}  models.MenuItemRow "メニュー"
+// @Failure      400 {object} map[string]string "店舗IDまたは形式が不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/menu/export [get]
+func (c *itemController) ExportMenuHandler(ctx echo.Context) error {
+	targetShopID, err := authorizeMenuShop(ctx)
+	if err != nil {
+		return err
+	}
+	format := ctx.QueryParam("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		return apperrors.BadParam.WrapMessage(nil, apperrors.MsgInvalidExportFormat, nil)
+	}
+
+	rows, err := c.s.ExportMenu(targetShopID)
+	if err != nil {
+		return err
+	}
+
+	filename := fmt.Sprintf("menu_shop%d.%s", targetShopID, format)
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+	if format == "json" {
+		return ctx.JSON(http.StatusOK, rows)
+	}
+	return ctx.Blob(http.StatusOK, "text/csv; charset=utf-8", services.RenderMenuCSV(rows))
+}
+
+// authorizeMenuShop は店舗IDを取り出し、管理者がその店舗にアクセスできるか確認します
+func authorizeMenuShop(ctx echo.Context) (int, error) {
+	targetShopID, err := strconv.Atoi(ctx.Param("shop_id"))
+	if err != nil {
+		return 0, apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
+	}
+
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err := AuthorizeShopAccess(claims, targetShopID); err != nil {
+		return 0, err
+	}
+	return targetShopID, nil
+}
+
+// UploadItemImageHandler は商品の画像をアップロードします。
+// @Summary      商品画像のアップロード (Admin)
+// @Description  担当店舗の商品の画像（JPEG・PNG・WebP、5MB・縦横6000pxまで）を image としてアップロードします。長辺1200pxまでの表示用の画像と長辺320pxまでのサムネイルを生成して保存し、以前の画像は削除します。生成する画像は透過がなければJPEG、あればPNGです。
+// @Tags         管理者 (Admin)
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        item_id path int true "商品ID"
+// @Param        image formData file true "商品画像"
+// @Success      200 {object} models.ItemImageResponse "保存した画像のURL"
+// @Failure      400 {object} map[string]string "画像の形式やサイズが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "店舗に紐づいていない管理者アカウントです"
+// @Failure      404 {object} map[string]string "商品が見つからないか、この店舗の商品ではありません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/items/{item_id}/image [post]
+func (c *itemController) UploadItemImageHandler(ctx echo.Context) error {
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if claims.ShopID == nil {
+		return apperrors.Forbidden.WrapMessage(nil, apperrors.MsgAdminWithoutShop, nil)
+	}
+	adminShopID := *claims.ShopID
+
+	itemID, err := strconv.Atoi(ctx.Param("item_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidItemID, nil)
+	}
+
+	fileHeader, err := ctx.FormFile("image")
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgItemImageRequired, nil)
+	}
+	if fileHeader.Size > services.MaxItemImageSize {
+		return apperrors.BadParam.WrapMessage(nil, apperrors.MsgItemImageTooLarge, apperrors.Params{"max_mb": services.MaxItemImageSize >> 20})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgItemImageUnreadable, nil)
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, services.MaxItemImageSize+1))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgItemImageUnreadable, nil)
+	}
+
+	res, err := c.s.UploadItemImage(ctx.Request().Context(), adminShopID, itemID, data)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// SchedulePriceChangeHandler は店舗の商品の価格を変更します。
+// @Summary      店舗ごとの価格を変更 (Admin)
+// @Description  店舗の商品の価格を変更します。effective_at を指定するとその日時に反映する予定として登録し、省略するとすぐに反映します。price を null にすると店舗ごとの価格をやめて全店舗共通の価格に戻します。変更はすべて履歴に残ります。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id path int                               true "店舗ID"
+// @Param        item_id path int                               true "商品ID"
+// @Param        request body models.SchedulePriceChangeRequest true "価格と反映する日時"
+// @Success      201 {object} models.PriceChangeResponse "登録した価格変更"
+// @Failure      400 {object} map[string]string "リクエストが不正か、反映する日時が過去です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      404 {object} map[string]string "商品が見つからないか、この店舗の商品ではありません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/items/{item_id}/price-changes [post]
+func (c *itemController) SchedulePriceChangeHandler(ctx echo.Context) error {
+	targetShopID, err := authorizeMenuShop(ctx)
+	if err != nil {
+		return err
+	}
+	itemID, err := strconv.Atoi(ctx.Param("item_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidItemID, nil)
+	}
+
+	var req models.SchedulePriceChangeRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.SchedulePriceChangeRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	res, err := c.s.SchedulePriceChange(ctx.Request().Context(), targetShopID, itemID, req)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusCreated, res)
+}
+
+// GetPriceHistoryHandler は店舗の商品の価格と、価格変更の履歴・予定を取得します。
+// @Summary      店舗ごとの価格の履歴 (Admin)
+// @Description  全店舗共通の価格、この店舗で現在販売している価格、反映済みと予定の価格変更（反映する日時の新しい順）を返します。
+// @Tags         管理者 (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id path int true "店舗ID"
+// @Param        item_id path int true "商品ID"
+// @Success      200 {object} models.PriceHistoryResponse "価格と価格変更の履歴"
+// @Failure      400 {object} map[string]string "IDの形式が不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      404 {object} map[string]string "商品が見つからないか、この店舗の商品ではありません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/items/{item_id}/price-changes [get]
+func (c *itemController) GetPriceHistoryHandler(ctx echo.Context) error {
+	targetShopID, err := authorizeMenuShop(ctx)
+	if err != nil {
+		return err
+	}
+	itemID, err := strconv.Atoi(ctx.Param("item_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidItemID, nil)
+	}
+
+	res, err := c.s.GetPriceHistory(ctx.Request().Context(), targetShopID, itemID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// CancelPriceChangeHandler はまだ反映していない価格変更の予定を取り消します。
+// @Summary      価格変更の予定を取り消し (Admin)
+// @Description  まだ反映していない価格変更の予定を削除します。反映済みの変更は履歴なので取り消せません。
+// @Tags         管理者 (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id         path int true "店舗ID"
+// @Param        item_id         path int true "商品ID"
+// @Param        price_change_id path int true "価格変更ID"
+// @Success      200 {object} map[string]string "成功メッセージ"
+// @Failure      400 {object} map[string]string "IDの形式が不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      404 {object} map[string]string "価格変更が見つかりません"
+// @Failure      409 {object} map[string]string "すでに反映した価格変更です"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/items/{item_id}/price-changes/{price_change_id} [delete]
+func (c *itemController) CancelPriceChangeHandler(ctx echo.Context) error {
+	targetShopID, err := authorizeMenuShop(ctx)
+	if err != nil {
+		return err
+	}
+	itemID, err := strconv.Atoi(ctx.Param("item_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidItemID, nil)
+	}
+	priceChangeID, err := strconv.Atoi(ctx.Param("price_change_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidPriceChangeID, nil)
+	}
+
+	if err := c.s.CancelPriceChange(ctx.Request().Context(), targetShopID, itemID, priceChangeID); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "価格変更の予定を取り消しました。"})
+}
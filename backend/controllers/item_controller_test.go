@@ -1 +1,579 @@
 package controllers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/controllers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockItemService は ItemServicer インターフェースのモック実装です
+type MockItemService struct {
+	mock.Mock
+}
+
+func (m *MockItemService) GetItemList(shopID int, query models.ItemListQuery) ([]models.ItemListResponse, error) {
+	args := m.Called(shopID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ItemListResponse), args.Error(1)
+}
+
+func (m *MockItemService) GetMenu(ctx context.Context, shopID int, query models.ItemListQuery) (*models.MenuResponse, error) {
+	args := m.Called(ctx, shopID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MenuResponse), args.Error(1)
+}
+
+func (m *MockItemService) ImportMenu(ctx context.Context, shopID int, format string, file io.Reader, dryRun bool) (*models.MenuImportResponse, error) {
+	args := m.Called(ctx, shopID, format, file, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MenuImportResponse), args.Error(1)
+}
+
+func (m *MockItemService) ExportMenu(shopID int) ([]models.MenuItemRow, error) {
+	args := m.Called(shopID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.MenuItemRow), args.Error(1)
+}
+
+func (m *MockItemService) UploadItemImage(ctx context.Context, shopID int, itemID int, data []byte) (*models.ItemImageResponse, error) {
+	args := m.Called(ctx, shopID, itemID, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ItemImageResponse), args.Error(1)
+}
+
+func (m *MockItemService) SchedulePriceChange(ctx context.Context, shopID int, itemID int, req models.SchedulePriceChangeRequest) (*models.PriceChangeResponse, error) {
+	args := m.Called(ctx, shopID, itemID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PriceChangeResponse), args.Error(1)
+}
+
+func (m *MockItemService) GetPriceHistory(ctx context.Context, shopID int, itemID int) (*models.PriceHistoryResponse, error) {
+	args := m.Called(ctx, shopID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PriceHistoryResponse), args.Error(1)
+}
+
+func (m *MockItemService) CancelPriceChange(ctx context.Context, shopID int, itemID int, priceChangeID int) error {
+	args := m.Called(ctx, shopID, itemID, priceChangeID)
+	return args.Error(0)
+}
+
+// createMenuUploadContext はメニューファイルをmultipartで送るリクエストのコンテキストを作ります
+func createMenuUploadContext(t *testing.T, path string, filename string, content string, shopAdminID int) (echo.Context, *httptest.ResponseRecorder) {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if filename != "" {
+		part, err := writer.CreateFormFile("file", filename)
+		assert.NoError(t, err)
+		_, err = io.WriteString(part, content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, writer.Close())
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, path, &body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("shop_id")
+	c.SetParamValues("1")
+	c.Set("user", createTestToken(1, models.AdminRole, intPtr(shopAdminID)))
+	return c, rec
+}
+
+func TestItemController_GetItemListHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		acceptLanguage string
+		wantQuery      models.ItemListQuery
+		expectedCode   apperrors.ErrCode
+	}{
+		{
+			name:      "正常系: 絞り込みなし",
+			path:      "/shops/1/items",
+			wantQuery: models.ItemListQuery{Lang: "ja"},
+		},
+		{
+			name:      "正常系: カンマ区切りでアレルゲンと食事の制限を指定",
+			path:      "/shops/1/items?exclude_allergens=shrimp,%20crab,&dietary_tags=vegetarian",
+			wantQuery: models.ItemListQuery{ExcludeAllergens: []string{"shrimp", "crab"}, DietaryTags: []string{"vegetarian"}, Lang: "ja"},
+		},
+		{
+			name:           "正常系: Accept-Languageで言語を決める",
+			path:           "/shops/1/items",
+			acceptLanguage: "en-US,en;q=0.9,ja;q=0.8",
+			wantQuery:      models.ItemListQuery{Lang: "en"},
+		},
+		{
+			name:           "正常系: langはAccept-Languageより優先",
+			path:           "/shops/1/items?lang=ko",
+			acceptLanguage: "en-US",
+			wantQuery:      models.ItemListQuery{Lang: "ko"},
+		},
+		{
+			name:           "正常系: 対応していない言語は日本語",
+			path:           "/shops/1/items",
+			acceptLanguage: "fr-FR",
+			wantQuery:      models.ItemListQuery{Lang: "ja"},
+		},
+		{
+			name:         "異常系: 特定原材料でないアレルゲン",
+			path:         "/shops/1/items?exclude_allergens=pork",
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:         "異常系: 不明な食事の制限",
+			path:         "/shops/1/items?dietary_tags=kosher",
+			expectedCode: apperrors.ValidationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockItemService)
+			defer mockService.AssertExpectations(t)
+			if tt.expectedCode == "" {
+				mockService.On("GetItemList", 1, tt.wantQuery).Return([]models.ItemListResponse{}, nil)
+			}
+
+			controller := controllers.NewItemController(mockService)
+			c, rec := createTestContextForOrder(http.MethodGet, tt.path, "", map[string]string{"shop_id": "1"}, nil)
+			if tt.acceptLanguage != "" {
+				c.Request().Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+
+			err := controller.GetItemListHandler(c)
+			if tt.expectedCode != "" {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.wantQuery.Lang, rec.Header().Get("Content-Language"))
+			assert.Equal(t, "Accept-Language", rec.Header().Get("Vary"))
+		})
+	}
+}
+
+func TestItemController_GetMenuHandler(t *testing.T) {
+	t.Run("カテゴリごとのメニュー", func(t *testing.T) {
+		menu := &models.MenuResponse{
+			ShopID: 1,
+			Sections: []models.MenuSection{
+				{CategoryID: intPtr(10), Name: "ラーメン", Items: []models.ItemListResponse{{ItemID: 1, ItemName: "醤油ラーメン", CategoryID: intPtr(10)}}},
+				{Name: "その他", Items: []models.ItemListResponse{{ItemID: 3, ItemName: "季節の一品"}}},
+			},
+		}
+		mockService := new(MockItemService)
+		defer mockService.AssertExpectations(t)
+		mockService.On("GetMenu", mock.Anything, 1, models.ItemListQuery{Lang: "ja"}).Return(menu, nil)
+
+		controller := controllers.NewItemController(mockService)
+		c, rec := createTestContextForOrder(http.MethodGet, "/shops/1/menu", "", map[string]string{"shop_id": "1"}, nil)
+
+		assert.NoError(t, controller.GetMenuHandler(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var got struct {
+			Sections []struct {
+				CategoryID *int   `json:"category_id"`
+				Name       string `json:"name"`
+			} `json:"sections"`
+		}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		assert.Len(t, got.Sections, 2)
+		assert.Equal(t, intPtr(10), got.Sections[0].CategoryID)
+		assert.Nil(t, got.Sections[1].CategoryID)
+		assert.Equal(t, "その他", got.Sections[1].Name)
+	})
+
+	t.Run("店舗IDが数値でない", func(t *testing.T) {
+		controller := controllers.NewItemController(new(MockItemService))
+		c, _ := createTestContextForOrder(http.MethodGet, "/shops/abc/menu", "", map[string]string{"shop_id": "abc"}, nil)
+
+		err := controller.GetMenuHandler(c)
+		assert.Error(t, err)
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			assert.Equal(t, apperrors.BadParam, appErr.ErrCode)
+		}
+	})
+}
+
+func TestItemController_ImportMenuHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		filename       string
+		shopAdminID    int
+		setupMock      func() *MockItemService
+		expectedStatus int
+		expectedCode   apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: 拡張子からCSVとして取り込む",
+			path:        "/admin/shops/1/menu/import",
+			filename:    "menu.csv",
+			shopAdminID: 1,
+			setupMock: func() *MockItemService {
+				mockService := new(MockItemService)
+				mockService.On("ImportMenu", mock.Anything, 1, "csv", mock.Anything, false).Return(&models.MenuImportResponse{ShopID: 1, Applied: true, Created: 1}, nil)
+				return mockService
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "正常系: dry_runではエラーがあっても200",
+			path:        "/admin/shops/1/menu/import?dry_run=true&format=json",
+			filename:    "menu.txt",
+			shopAdminID: 1,
+			setupMock: func() *MockItemService {
+				mockService := new(MockItemService)
+				mockService.On("ImportMenu", mock.Anything, 1, "json", mock.Anything, true).Return(&models.MenuImportResponse{
+					ShopID: 1, DryRun: true, Errors: []models.MenuRowError{{Row: 1, Field: "price", Message: "0以上で指定してください。"}},
+				}, nil)
+				return mockService
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "異常系: エラーのある行があり反映しなかった",
+			path:        "/admin/shops/1/menu/import",
+			filename:    "menu.json",
+			shopAdminID: 1,
+			setupMock: func() *MockItemService {
+				mockService := new(MockItemService)
+				mockService.On("ImportMenu", mock.Anything, 1, "json", mock.Anything, false).Return(&models.MenuImportResponse{
+					ShopID: 1, Errors: []models.MenuRowError{{Row: 2, Field: "item_id", Message: "この店舗の商品ではありません。"}},
+				}, nil)
+				return mockService
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "異常系: ファイルの形式を判断できない",
+			path:        "/admin/shops/1/menu/import",
+			filename:    "menu.xlsx",
+			shopAdminID: 1,
+			setupMock: func() *MockItemService {
+				return new(MockItemService)
+			},
+			expectedCode: apperrors.BadParam,
+		},
+		{
+			name:        "異常系: ファイルがない",
+			path:        "/admin/shops/1/menu/import",
+			shopAdminID: 1,
+			setupMock: func() *MockItemService {
+				return new(MockItemService)
+			},
+			expectedCode: apperrors.BadParam,
+		},
+		{
+			name:        "異常系: 他店舗の管理者",
+			path:        "/admin/shops/1/menu/import",
+			filename:    "menu.csv",
+			shopAdminID: 2,
+			setupMock: func() *MockItemService {
+				return new(MockItemService)
+			},
+			expectedCode: apperrors.Forbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewItemController(mockService)
+			c, rec := createMenuUploadContext(t, tt.path, tt.filename, "item_name,price,tax_category\nポテト,300,food\n", tt.shopAdminID)
+
+			err := controller.ImportMenuHandler(c)
+
+			if tt.expectedCode != "" {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			var res models.MenuImportResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, 1, res.ShopID)
+		})
+	}
+}
+
+func TestItemController_ImportMenuHandler_Language(t *testing.T) {
+	rowErrors := []models.MenuRowError{
+		{Row: 2, Field: "item_id", Message: "同じ商品IDが1行目にもあります。", MessageID: apperrors.MsgMenuRowDuplicateItemID, Params: apperrors.Params{"row": 1}},
+		{Row: 3, Message: "検証に失敗しました"},
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want []models.MenuRowError
+	}{
+		{
+			name: "指定なしは日本語",
+			path: "/admin/shops/1/menu/import?dry_run=true",
+			want: []models.MenuRowError{
+				{Row: 2, Field: "item_id", Message: "同じ商品IDが1行目にもあります。"},
+				{Row: 3, Message: "検証に失敗しました"},
+			},
+		},
+		{
+			name: "langの言語。カタログにない文言はそのまま",
+			path: "/admin/shops/1/menu/import?dry_run=true&lang=en",
+			want: []models.MenuRowError{
+				{Row: 2, Field: "item_id", Message: "The same item ID is also on row 1."},
+				{Row: 3, Message: "検証に失敗しました"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockItemService)
+			defer mockService.AssertExpectations(t)
+			mockService.On("ImportMenu", mock.Anything, 1, "csv", mock.Anything, true).Return(&models.MenuImportResponse{
+				ShopID: 1, DryRun: true, Errors: append([]models.MenuRowError(nil), rowErrors...),
+			}, nil)
+
+			controller := controllers.NewItemController(mockService)
+			c, rec := createMenuUploadContext(t, tt.path, "menu.csv", "item_name,price,tax_category\nポテト,300,food\n", 1)
+
+			assert.NoError(t, controller.ImportMenuHandler(c))
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var res models.MenuImportResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, tt.want, res.Errors)
+		})
+	}
+}
+
+func TestItemController_ExportMenuHandler(t *testing.T) {
+	rows := []models.MenuItemRow{{ItemID: intPtr(1), ItemName: "唐揚げ定食", Price: 800, TaxCategory: "food"}}
+
+	t.Run("CSV", func(t *testing.T) {
+		mockService := new(MockItemService)
+		defer mockService.AssertExpectations(t)
+		mockService.On("ExportMenu", 1).Return(rows, nil)
+
+		controller := controllers.NewItemController(mockService)
+		c, rec := createTestContextForOrder(http.MethodGet, "/admin/shops/1/menu/export", "", map[string]string{"shop_id": "1"}, createTestToken(1, models.AdminRole, intPtr(1)))
+
+		assert.NoError(t, controller.ExportMenuHandler(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, `attachment; filename="menu_shop1.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+		assert.Contains(t, rec.Body.String(), "1,唐揚げ定食,,800,food,,")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		mockService := new(MockItemService)
+		defer mockService.AssertExpectations(t)
+		mockService.On("ExportMenu", 1).Return(rows, nil)
+
+		controller := controllers.NewItemController(mockService)
+		c, rec := createTestContextForOrder(http.MethodGet, "/admin/shops/1/menu/export?format=json", "", map[string]string{"shop_id": "1"}, createTestToken(1, models.AdminRole, intPtr(1)))
+
+		assert.NoError(t, controller.ExportMenuHandler(c))
+		var got []models.MenuItemRow
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		assert.Equal(t, rows, got)
+	})
+
+	t.Run("形式が不正", func(t *testing.T) {
+		controller := controllers.NewItemController(new(MockItemService))
+		c, _ := createTestContextForOrder(http.MethodGet, "/admin/shops/1/menu/export?format=xlsx", "", map[string]string{"shop_id": "1"}, createTestToken(1, models.AdminRole, intPtr(1)))
+
+		err := controller.ExportMenuHandler(c)
+		assert.Error(t, err)
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			assert.Equal(t, apperrors.BadParam, appErr.ErrCode)
+		}
+	})
+}
+
+func TestItemController_UploadItemImageHandler(t *testing.T) {
+	newContext := func(t *testing.T, itemID string, image []byte, shopID *int) (echo.Context, *httptest.ResponseRecorder) {
+		t.Helper()
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		if image != nil {
+			part, err := writer.CreateFormFile("image", "ramen.png")
+			assert.NoError(t, err)
+			_, err = part.Write(image)
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, writer.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/items/"+itemID+"/image", &body)
+		req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(req, rec)
+		c.SetParamNames("item_id")
+		c.SetParamValues(itemID)
+		c.Set("user", createTestToken(1, models.AdminRole, shopID))
+		return c, rec
+	}
+	image := []byte("\x89PNG\r\n\x1a\nimage")
+
+	t.Run("担当店舗の商品の画像を保存", func(t *testing.T) {
+		mockService := new(MockItemService)
+		defer mockService.AssertExpectations(t)
+		mockService.On("UploadItemImage", mock.Anything, 1, 5, image).Return(&models.ItemImageResponse{
+			ItemID: 5, ImageURL: "/images/items/5/a.jpg", ThumbnailURL: "/images/items/5/a_thumb.jpg",
+		}, nil)
+
+		c, rec := newContext(t, "5", image, intPtr(1))
+		assert.NoError(t, controllers.NewItemController(mockService).UploadItemImageHandler(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"thumbnail_url":"/images/items/5/a_thumb.jpg"`)
+	})
+
+	tests := []struct {
+		name         string
+		itemID       string
+		image        []byte
+		shopID       *int
+		expectedCode apperrors.ErrCode
+	}{
+		{name: "画像がない", itemID: "5", shopID: intPtr(1), expectedCode: apperrors.BadParam},
+		{name: "商品IDが数値でない", itemID: "abc", image: image, shopID: intPtr(1), expectedCode: apperrors.BadParam},
+		{name: "店舗に紐づいていない管理者", itemID: "5", image: image, expectedCode: apperrors.Forbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newContext(t, tt.itemID, tt.image, tt.shopID)
+			err := controllers.NewItemController(new(MockItemService)).UploadItemImageHandler(c)
+			assert.Error(t, err)
+			if appErr, ok := err.(*apperrors.AppError); ok {
+				assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+			}
+		})
+	}
+}
+
+func TestItemController_SchedulePriceChangeHandler(t *testing.T) {
+	t.Run("予定の価格変更を登録", func(t *testing.T) {
+		effectiveAt := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+		mockService := new(MockItemService)
+		defer mockService.AssertExpectations(t)
+		mockService.On("SchedulePriceChange", mock.Anything, 1, 3, mock.MatchedBy(func(req models.SchedulePriceChangeRequest) bool {
+			return req.Price != nil && *req.Price == 800 && req.EffectiveAt != nil && req.EffectiveAt.Equal(effectiveAt)
+		})).Return(&models.PriceChangeResponse{PriceChangeID: 7, ItemID: 3, Price: intPtr(800), EffectiveAt: effectiveAt}, nil)
+
+		c, rec := createTestContextForOrder(http.MethodPost, "/admin/shops/1/items/3/price-changes",
+			`{"price": 800, "effective_at": "2025-10-01T09:00:00+09:00"}`,
+			map[string]string{"shop_id": "1", "item_id": "3"}, createTestToken(1, models.AdminRole, intPtr(1)))
+
+		assert.NoError(t, controllers.NewItemController(mockService).SchedulePriceChangeHandler(c))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"applied_at":null`)
+	})
+
+	tests := []struct {
+		name         string
+		shopID       string
+		itemID       string
+		body         string
+		adminShopID  *int
+		expectedCode apperrors.ErrCode
+	}{
+		{name: "価格が負", shopID: "1", itemID: "3", body: `{"price": -1}`, adminShopID: intPtr(1), expectedCode: apperrors.ValidationFailed},
+		{name: "商品IDが数値でない", shopID: "1", itemID: "abc", body: `{"price": 800}`, adminShopID: intPtr(1), expectedCode: apperrors.BadParam},
+		{name: "ほかの店舗の管理者", shopID: "1", itemID: "3", body: `{"price": 800}`, adminShopID: intPtr(2), expectedCode: apperrors.Forbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := createTestContextForOrder(http.MethodPost, "/admin/shops/"+tt.shopID+"/items/"+tt.itemID+"/price-changes", tt.body,
+				map[string]string{"shop_id": tt.shopID, "item_id": tt.itemID}, createTestToken(1, models.AdminRole, tt.adminShopID))
+			err := controllers.NewItemController(new(MockItemService)).SchedulePriceChangeHandler(c)
+			assert.Error(t, err)
+			if appErr, ok := err.(*apperrors.AppError); ok {
+				assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+			}
+		})
+	}
+}
+
+func TestItemController_GetPriceHistoryHandler(t *testing.T) {
+	mockService := new(MockItemService)
+	defer mockService.AssertExpectations(t)
+	mockService.On("GetPriceHistory", mock.Anything, 1, 3).Return(&models.PriceHistoryResponse{
+		ItemID: 3, BasePrice: 750, CurrentPrice: 800, Changes: []models.PriceChangeResponse{},
+	}, nil)
+
+	c, rec := createTestContextForOrder(http.MethodGet, "/admin/shops/1/items/3/price-changes", "",
+		map[string]string{"shop_id": "1", "item_id": "3"}, createTestToken(1, models.AdminRole, intPtr(1)))
+
+	assert.NoError(t, controllers.NewItemController(mockService).GetPriceHistoryHandler(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"current_price":800`)
+}
+
+func TestItemController_CancelPriceChangeHandler(t *testing.T) {
+	t.Run("予定を取り消し", func(t *testing.T) {
+		mockService := new(MockItemService)
+		defer mockService.AssertExpectations(t)
+		mockService.On("CancelPriceChange", mock.Anything, 1, 3, 7).Return(nil)
+
+		c, rec := createTestContextForOrder(http.MethodDelete, "/admin/shops/1/items/3/price-changes/7", "",
+			map[string]string{"shop_id": "1", "item_id": "3", "price_change_id": "7"}, createTestToken(1, models.AdminRole, intPtr(1)))
+
+		assert.NoError(t, controllers.NewItemController(mockService).CancelPriceChangeHandler(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("反映済みの変更は取り消せない", func(t *testing.T) {
+		mockService := new(MockItemService)
+		defer mockService.AssertExpectations(t)
+		mockService.On("CancelPriceChange", mock.Anything, 1, 3, 6).Return(apperrors.Conflict.Wrap(nil, "すでに反映した価格変更は取り消せません。"))
+
+		c, _ := createTestContextForOrder(http.MethodDelete, "/admin/shops/1/items/3/price-changes/6", "",
+			map[string]string{"shop_id": "1", "item_id": "3", "price_change_id": "6"}, createTestToken(1, models.AdminRole, intPtr(1)))
+
+		err := controllers.NewItemController(mockService).CancelPriceChangeHandler(c)
+		if appErr, ok := err.(*apperrors.AppError); assert.True(t, ok) {
+			assert.Equal(t, apperrors.Conflict, appErr.ErrCode)
+		}
+	})
+}
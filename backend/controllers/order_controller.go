@@ -1,9 +1,12 @@
 package controllers
 
 import (
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/A4-dev-team/mobileorder.git/apperrors"
 	"github.com/A4-dev-team/mobileorder.git/models"
@@ -12,11 +15,19 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	// ゲストが注文時に受け取ったトークンを送るヘッダー
+	GuestOrderTokenHeader = "X-Guest-Order-Token"
+	// 領収書の宛名の最大文字数
+	maxReceiptRecipientLength = 50
+)
+
 type OrderController interface {
 	CreateAuthenticatedOrderHandler(ctx echo.Context) error
 	CreateGuestOrderHandler(ctx echo.Context) error
 	GetOrderListHandler(ctx echo.Context) error
 	GetOrderStatusHandler(ctx echo.Context) error
+	GetReceiptHandler(ctx echo.Context) error
 }
 
 type orderController struct {
@@ -29,7 +40,7 @@ func NewOrderController(s services.OrderServicer) OrderController {
 
 // CreateAuthenticatedOrderHandler は認証済みユーザーの注文を作成します。
 // @Summary      認証ユーザーの注文作成 (Create Order - Authenticated)
-// @Description  認証済みのユーザーとして新しい注文を作成します。リクエストには有効なBearerトークンが必要です。
+// @Description  認証済みのユーザーとして新しい注文を作成し、決済します。決済が確定すると注文は調理中になり、承認待ちの場合は決済待ち（pending_payment）のまま返します。リクエストには有効なBearerトークンが必要です。
 // @Tags         注文 (Order)
 // @Accept       json
 // @Produce      json
@@ -39,23 +50,24 @@ func NewOrderController(s services.OrderServicer) OrderController {
 // @Success      201 {object} models.AuthenticatedOrderResponse "作成された注文ID"
 // @Failure      400 {object} map[string]string "リクエストボディまたは店舗IDが不正です"
 // @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      402 {object} map[string]string "決済が完了しなかったため注文を取り消しました"
 // @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
 // @Router       /shops/{shop_id}/orders [post]
 func (c *orderController) CreateAuthenticatedOrderHandler(ctx echo.Context) error {
 	reqItem := models.CreateOrderRequest{}
 	if err := ctx.Bind(&reqItem); err != nil {
-		return apperrors.ReqBodyDecodeFailed.Wrap(err, "リクエストの形式が不正です。")
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
 	}
 
 	validator := validators.NewValidator[models.CreateOrderRequest]()
 	if err := validator.Validate(reqItem); err != nil {
-		return apperrors.ValidationFailed.Wrap(err, err.Error())
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
 	}
 
 	shopIDStr := ctx.Param("shop_id")
 	shopID, err := strconv.Atoi(shopIDStr)
 	if err != nil {
-		return apperrors.BadParam.Wrap(err, "店舗IDの形式が不正です。")
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
 	}
 
 	claims, err := GetClaims(ctx)
@@ -66,13 +78,15 @@ func (c *orderController) CreateAuthenticatedOrderHandler(ctx echo.Context) erro
 
 	log.Printf("Authenticated user (ID: %d) order flow", userID)
 
-	createdOrder, err := c.s.CreateAuthenticatedOrder(ctx.Request().Context(), userID, shopID, reqItem.Items)
+	createdOrder, err := c.s.CreateAuthenticatedOrder(ctx.Request().Context(), userID, shopID, reqItem)
 	if err != nil {
 		return err
 	}
 
 	resOrder := models.AuthenticatedOrderResponse{
-		OrderID: uint(createdOrder.OrderID),
+		OrderID:             uint(createdOrder.OrderID),
+		Status:              createdOrder.Status.String(),
+		OrderAmountResponse: newOrderAmountResponse(createdOrder),
 	}
 
 	return ctx.JSON(http.StatusCreated, resOrder)
@@ -80,7 +94,7 @@ func (c *orderController) CreateAuthenticatedOrderHandler(ctx echo.Context) erro
 
 // CreateGuestOrderHandler はゲストユーザーの注文を作成します。
 // @Summary      ゲストの注文作成 (Create Order - Guest)
-// @Description  未ログインのゲストユーザーとして新しい注文を作成します。認証は不要です。
+// @Description  未ログインのゲストユーザーとして新しい注文を作成し、決済します。決済が確定すると注文は調理中になり、承認待ちの場合は決済待ち（pending_payment）のまま返します。認証は不要です。
 // @Tags         注文 (Order)
 // @Accept       json
 // @Produce      json
@@ -88,27 +102,28 @@ func (c *orderController) CreateAuthenticatedOrderHandler(ctx echo.Context) erro
 // @Param        order body models.CreateOrderRequest true "注文内容 (Order details)"
 // @Success      201 {object} models.CreateOrderResponse "作成された注文IDとゲスト用トークン"
 // @Failure      400 {object} map[string]string "リクエストボディまたは店舗IDが不正です"
+// @Failure      402 {object} map[string]string "決済が完了しなかったため注文を取り消しました"
 // @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
 // @Router       /shops/{shop_id}/guest-orders [post]
 func (c *orderController) CreateGuestOrderHandler(ctx echo.Context) error {
 	reqItem := models.CreateOrderRequest{}
 	if err := ctx.Bind(&reqItem); err != nil {
-		return apperrors.ReqBodyDecodeFailed.Wrap(err, "リクエストの形式が不正です。")
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
 	}
 	validator := validators.NewValidator[models.CreateOrderRequest]()
 	if err := validator.Validate(reqItem); err != nil {
-		return apperrors.ValidationFailed.Wrap(err, err.Error())
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
 	}
 
 	shopIDStr := ctx.Param("shop_id")
 	shopID, err := strconv.Atoi(shopIDStr)
 	if err != nil {
-		return apperrors.BadParam.Wrap(err, "店舗IDの形式が不正です。")
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
 	}
 
 	log.Println("Guest user order flow")
 
-	createdOrder, err := c.s.CreateOrder(ctx.Request().Context(), shopID, reqItem.Items)
+	createdOrder, err := c.s.CreateOrder(ctx.Request().Context(), shopID, reqItem)
 	if err != nil {
 		return err
 	}
@@ -116,7 +131,10 @@ func (c *orderController) CreateGuestOrderHandler(ctx echo.Context) error {
 	resOrder := models.CreateOrderResponse{
 		OrderID:         createdOrder.OrderID,
 		GuestOrderToken: createdOrder.GuestOrderToken.String,
+		Status:          createdOrder.Status.String(),
 		Message:         "Order created successfully as a guest. Please sign up to claim this order.",
+
+		OrderAmountResponse: newOrderAmountResponse(createdOrder),
 	}
 
 	return ctx.JSON(http.StatusCreated, resOrder)
@@ -124,11 +142,12 @@ func (c *orderController) CreateGuestOrderHandler(ctx echo.Context) error {
 
 // GetOrderListHandler は、ユーザーのアクティブな注文履歴を取得します。
 // @Summary      アクティブな注文履歴の取得 (Get Active Order List)
-// @Description  ログイン中のユーザーの、現在アクティブな（調理中または調理完了）注文履歴を取得します。このAPIは常に'cooking'と'completed'ステータスの注文のみを返します。
+// @Description  ログイン中のユーザーの、現在アクティブな（決済待ち・調理中・調理完了）注文履歴を取得します。このAPIは常に'pending_payment'、'cooking'、'completed'ステータスの注文のみを返します。langかAccept-Languageで英語・中国語・韓国語を指定すると、翻訳のある店舗名・商品名を翻訳します。
 // @Tags         注文 (Order)
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
+// @Param        lang query string false "表示する言語。Accept-Languageより優先します" Enums(ja, en, zh, ko)
 // @Success      200 {array} models.OrderListResponse "アクティブな注文履歴のリスト"
 // @Failure      401 {object} map[string]string "認証に失敗しました"
 // @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
@@ -141,7 +160,7 @@ func (c *orderController) GetOrderListHandler(ctx echo.Context) error {
 	}
 	userID := claims.UserID
 
-	orderList, err := c.s.GetUserOrders(ctx.Request().Context(), userID)
+	orderList, err := c.s.GetUserOrders(ctx.Request().Context(), userID, negotiateLanguage(ctx))
 	if err != nil {
 		return err
 	}
@@ -167,7 +186,7 @@ func (c *orderController) GetOrderStatusHandler(ctx echo.Context) error {
 	orderIDStr := ctx.Param("order_id")
 	orderID, err := strconv.Atoi(orderIDStr)
 	if err != nil {
-		return apperrors.BadParam.Wrap(err, "注文IDの形式が不正です。")
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidOrderID, nil)
 	}
 
 	claims, err := GetClaims(ctx)
@@ -182,3 +201,85 @@ func (c *orderController) GetOrderStatusHandler(ctx echo.Context) error {
 	}
 	return ctx.JSON(http.StatusOK, status)
 }
+
+// GetReceiptHandler は注文の領収書を発行します。
+// @Summary      領収書の発行 (Get Receipt)
+// @Description  注文の領収書をJSON・テキスト・PDFで返します。店舗に適格請求書発行事業者の登録番号が設定されていれば、税率ごとの内訳付きの適格請求書になります。ログイン中のユーザーはBearerトークン、ゲストは注文時に受け取ったゲスト用トークンを X-Guest-Order-Token ヘッダーで指定します。
+// @Tags         注文 (Order)
+// @Produce      json,plain,application/pdf
+// @Security     BearerAuth
+// @Param        order_id            path   int    true  "注文ID (Order ID)"
+// @Param        format              query  string false "出力形式（省略時はjson）" Enums(json, text, pdf)
+// @Param        recipient           query  string false "宛名（50文字まで）"
+// @Param        X-Guest-Order-Token header string false "ゲスト用トークン"
+// @Success      200 {object} models.ReceiptResponse "領収書"
+// @Failure      400 {object} map[string]string "注文IDまたはクエリパラメータが不正です"
+// @Failure      401 {object} map[string]string "トークンが指定されていません"
+// @Failure      404 {object} map[string]string "注文が見つからないか、アクセス権がありません"
+// @Failure      409 {object} map[string]string "決済が完了していない注文です"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /orders/{order_id}/receipt [get]
+func (c *orderController) GetReceiptHandler(ctx echo.Context) error {
+	orderID, err := strconv.Atoi(ctx.Param("order_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidOrderID, nil)
+	}
+
+	format := ctx.QueryParam("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "text" && format != "pdf" {
+		return apperrors.BadParam.WrapMessage(nil, apperrors.MsgInvalidReceiptFormat, nil)
+	}
+	recipient := strings.TrimSpace(ctx.QueryParam("recipient"))
+	if utf8.RuneCountInString(recipient) > maxReceiptRecipientLength {
+		return apperrors.BadParam.WrapMessage(nil, apperrors.MsgReceiptRecipientTooLong, apperrors.Params{"max": maxReceiptRecipientLength})
+	}
+
+	// ログインしていればユーザー本人の注文、していなければゲスト用トークンの注文として扱う
+	var userID *int
+	if claims, err := GetClaims(ctx); err == nil {
+		userID = &claims.UserID
+	}
+	guestToken := ctx.Request().Header.Get(GuestOrderTokenHeader)
+	if userID == nil && guestToken == "" {
+		return apperrors.Unauthorized.WrapMessage(nil, apperrors.MsgLoginOrGuestTokenRequired, nil)
+	}
+
+	receipt, err := c.s.GetReceipt(ctx.Request().Context(), orderID, userID, guestToken, recipient)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "text":
+		return ctx.String(http.StatusOK, services.RenderReceiptText(receipt))
+	case "pdf":
+		ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`inline; filename="receipt-%d.pdf"`, receipt.OrderID))
+		return ctx.Blob(http.StatusOK, "application/pdf", services.RenderReceiptPDF(receipt))
+	default:
+		return ctx.JSON(http.StatusOK, receipt)
+	}
+}
+
+// newOrderAmountResponse は作成した注文の金額と消費税・値引きの内訳をレスポンスの形にします
+func newOrderAmountResponse(order *models.Order) models.OrderAmountResponse {
+	taxBreakdown := order.TaxLines
+	if taxBreakdown == nil {
+		taxBreakdown = []models.OrderTaxLine{}
+	}
+	discounts := order.Discounts
+	if discounts == nil {
+		discounts = []models.OrderDiscount{}
+	}
+	return models.OrderAmountResponse{
+		DiningOption:   order.DiningOption.String(),
+		SubtotalAmount: order.SubtotalAmount,
+		DiscountAmount: order.DiscountAmount,
+		TaxAmount:      order.TaxAmount,
+		TotalAmount:    order.TotalAmount,
+		TaxBreakdown:   taxBreakdown,
+		Discounts:      discounts,
+	}
+}
@@ -24,24 +24,24 @@ type MockOrderService struct {
 	mock.Mock
 }
 
-func (m *MockOrderService) CreateOrder(ctx context.Context, shopID int, reqItem []models.OrderItemRequest) (*models.Order, error) {
-	args := m.Called(ctx, shopID, reqItem)
+func (m *MockOrderService) CreateOrder(ctx context.Context, shopID int, req models.CreateOrderRequest) (*models.Order, error) {
+	args := m.Called(ctx, shopID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.Order), args.Error(1)
 }
 
-func (m *MockOrderService) CreateAuthenticatedOrder(ctx context.Context, userID int, shopID int, items []models.OrderItemRequest) (*models.Order, error) {
-	args := m.Called(ctx, userID, shopID, items)
+func (m *MockOrderService) CreateAuthenticatedOrder(ctx context.Context, userID int, shopID int, req models.CreateOrderRequest) (*models.Order, error) {
+	args := m.Called(ctx, userID, shopID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.Order), args.Error(1)
 }
 
-func (m *MockOrderService) GetUserOrders(ctx context.Context, userID int) ([]models.OrderListResponse, error) {
-	args := m.Called(ctx, userID)
+func (m *MockOrderService) GetUserOrders(ctx context.Context, userID int, lang string) ([]models.OrderListResponse, error) {
+	args := m.Called(ctx, userID, lang)
 	return args.Get(0).([]models.OrderListResponse), args.Error(1)
 }
 
@@ -53,6 +53,14 @@ func (m *MockOrderService) GetOrderStatus(ctx context.Context, userID int, order
 	return args.Get(0).(*models.OrderStatusResponse), args.Error(1)
 }
 
+func (m *MockOrderService) GetReceipt(ctx context.Context, orderID int, userID *int, guestToken string, recipient string) (*models.ReceiptResponse, error) {
+	args := m.Called(ctx, orderID, userID, guestToken, recipient)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReceiptResponse), args.Error(1)
+}
+
 // createTestContextForOrder はOrder用のEchoコンテキストを作成します
 func createTestContextForOrder(method, path string, body string, pathParams map[string]string, token *jwt.Token) (echo.Context, *httptest.ResponseRecorder) {
 	e := echo.New()
@@ -117,8 +125,8 @@ func TestOrderController_CreateAuthenticatedOrderHandler(t *testing.T) {
 					UpdatedAt:   time.Now(),
 				}
 
-				mockService.On("CreateAuthenticatedOrder", mock.Anything, 1, 1, mock.MatchedBy(func(items []models.OrderItemRequest) bool {
-					return len(items) == 1 && items[0].ItemID == 1 && items[0].Quantity == 2
+				mockService.On("CreateAuthenticatedOrder", mock.Anything, 1, 1, mock.MatchedBy(func(req models.CreateOrderRequest) bool {
+					return len(req.Items) == 1 && req.Items[0].ItemID == 1 && req.Items[0].Quantity == 2
 				})).Return(order, nil)
 
 				return mockService
@@ -254,8 +262,8 @@ func TestOrderController_CreateGuestOrderHandler(t *testing.T) {
 					UpdatedAt:       time.Now(),
 				}
 
-				mockService.On("CreateOrder", mock.Anything, 1, mock.MatchedBy(func(items []models.OrderItemRequest) bool {
-					return len(items) == 1 && items[0].ItemID == 1 && items[0].Quantity == 2
+				mockService.On("CreateOrder", mock.Anything, 1, mock.MatchedBy(func(req models.CreateOrderRequest) bool {
+					return len(req.Items) == 1 && req.Items[0].ItemID == 1 && req.Items[0].Quantity == 2
 				})).Return(order, nil)
 
 				return mockService
@@ -268,9 +276,84 @@ func TestOrderController_CreateGuestOrderHandler(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, 1, response.OrderID)
 				assert.Equal(t, "15ff4999-2cfd-41f3-b744-926e7c5c7a0e", response.GuestOrderToken)
+				assert.Equal(t, "cooking", response.Status)
 				assert.NotEmpty(t, response.Message)
 			},
 		},
+		{
+			name:        "正常系: メモとアレルギー申告がサービス層に渡される",
+			requestBody: `{"items":[{"item_id":1,"quantity":1,"note":"ネギ抜き"}],"note":"えびアレルギーです","has_allergy":true}`,
+			pathParams:  map[string]string{"shop_id": "1"},
+			setupMock: func() *MockOrderService {
+				mockService := new(MockOrderService)
+
+				order := &models.Order{
+					OrderID:         2,
+					ShopID:          1,
+					Status:          models.Cooking,
+					TotalAmount:     1000,
+					GuestOrderToken: sql.NullString{String: "15ff4999-2cfd-41f3-b744-926e7c5c7a0e", Valid: true},
+				}
+
+				mockService.On("CreateOrder", mock.Anything, 1, mock.MatchedBy(func(req models.CreateOrderRequest) bool {
+					return req.Note == "えびアレルギーです" && req.HasAllergy &&
+						len(req.Items) == 1 && req.Items[0].Note == "ネギ抜き"
+				})).Return(order, nil)
+
+				return mockService
+			},
+			expectedStatus: http.StatusCreated,
+			expectError:    false,
+		},
+		{
+			name:        "正常系: 店内飲食の注文は税率ごとの内訳を返す",
+			requestBody: `{"items":[{"item_id":1,"quantity":2}],"dining_option":"dine_in"}`,
+			pathParams:  map[string]string{"shop_id": "1"},
+			setupMock: func() *MockOrderService {
+				mockService := new(MockOrderService)
+
+				order := &models.Order{
+					OrderID:         3,
+					ShopID:          1,
+					Status:          models.Cooking,
+					GuestOrderToken: sql.NullString{String: "15ff4999-2cfd-41f3-b744-926e7c5c7a0e", Valid: true},
+					DiningOption:    models.DineIn,
+					SubtotalAmount:  1700,
+					TaxAmount:       154,
+					TotalAmount:     1700,
+					TaxLines:        []models.OrderTaxLine{{OrderID: 3, TaxRate: 10, TaxableAmount: 1700, TaxAmount: 154}},
+				}
+
+				mockService.On("CreateOrder", mock.Anything, 1, mock.MatchedBy(func(req models.CreateOrderRequest) bool {
+					return req.DiningOption == models.DineIn
+				})).Return(order, nil)
+
+				return mockService
+			},
+			expectedStatus: http.StatusCreated,
+			expectError:    false,
+			validateResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var response models.CreateOrderResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, "dine_in", response.DiningOption)
+				assert.Equal(t, 1700, response.SubtotalAmount)
+				assert.Equal(t, 154, response.TaxAmount)
+				assert.Equal(t, 1700, response.TotalAmount)
+				assert.Equal(t, []models.OrderTaxLine{{TaxRate: 10, TaxableAmount: 1700, TaxAmount: 154}}, response.TaxBreakdown)
+			},
+		},
+		{
+			name:        "異常系: 不正な飲食区分",
+			requestBody: `{"items":[{"item_id":1,"quantity":1}],"dining_option":"delivery"}`,
+			pathParams:  map[string]string{"shop_id": "1"},
+			setupMock: func() *MockOrderService {
+				return new(MockOrderService)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectError:    true,
+			expectedCode:   apperrors.ReqBodyDecodeFailed,
+		},
 		{
 			name:        "異常系: 不正なJSONリクエスト",
 			requestBody: `{"items":}`,
@@ -293,6 +376,17 @@ func TestOrderController_CreateGuestOrderHandler(t *testing.T) {
 			expectError:    true,
 			expectedCode:   apperrors.ValidationFailed,
 		},
+		{
+			name:        "異常系: バリデーションエラー（メモが長すぎる）",
+			requestBody: `{"items":[{"item_id":1,"quantity":1}],"note":"` + strings.Repeat("あ", 201) + `"}`,
+			pathParams:  map[string]string{"shop_id": "1"},
+			setupMock: func() *MockOrderService {
+				return new(MockOrderService)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectError:    true,
+			expectedCode:   apperrors.ValidationFailed,
+		},
 		{
 			name:        "異常系: 不正な店舗ID",
 			requestBody: `{"items":[{"item_id":1,"quantity":2}]}`,
@@ -389,7 +483,7 @@ func TestOrderController_GetOrderListHandler(t *testing.T) {
 					},
 				}
 
-				mockService.On("GetUserOrders", mock.Anything, 1).Return(orders, nil)
+				mockService.On("GetUserOrders", mock.Anything, 1, "ja").Return(orders, nil)
 				return mockService
 			},
 			setupToken: func() *jwt.Token {
@@ -411,7 +505,7 @@ func TestOrderController_GetOrderListHandler(t *testing.T) {
 			setupMock: func() *MockOrderService {
 				mockService := new(MockOrderService)
 				orders := []models.OrderListResponse{}
-				mockService.On("GetUserOrders", mock.Anything, 1).Return(orders, nil)
+				mockService.On("GetUserOrders", mock.Anything, 1, "ja").Return(orders, nil)
 				return mockService
 			},
 			setupToken: func() *jwt.Token {
@@ -430,7 +524,7 @@ func TestOrderController_GetOrderListHandler(t *testing.T) {
 			name: "異常系: サービス層でエラー",
 			setupMock: func() *MockOrderService {
 				mockService := new(MockOrderService)
-				mockService.On("GetUserOrders", mock.Anything, 1).Return(
+				mockService.On("GetUserOrders", mock.Anything, 1, "ja").Return(
 					[]models.OrderListResponse{}, apperrors.Unknown.Wrap(nil, "内部エラーが発生しました"))
 				return mockService
 			},
@@ -584,3 +678,169 @@ func TestOrderController_GetOrderStatusHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestOrderController_GetReceiptHandler(t *testing.T) {
+	registrationNumber := "T1234567890123"
+	receipt := &models.ReceiptResponse{
+		OrderID:                   10,
+		ShopName:                  "A4食堂",
+		ShopLocation:              "神戸市灘区六甲台町1-1",
+		InvoiceRegistrationNumber: &registrationNumber,
+		IsQualifiedInvoice:        true,
+		TransactionDate:           time.Date(2025, 8, 14, 10, 0, 0, 0, time.UTC),
+		IssuedAt:                  time.Date(2025, 8, 14, 11, 0, 0, 0, time.UTC),
+		DiningOption:              "takeout",
+		Lines: []models.ReceiptLineResponse{
+			{ItemName: "唐揚げ定食", Quantity: 2, UnitPrice: 850, Amount: 1700, TaxRate: 8, IsReducedRate: true},
+		},
+		SubtotalAmount: 1700,
+		TaxAmount:      125,
+		TotalAmount:    1700,
+		TaxBreakdown: []models.OrderTaxLine{
+			{TaxRate: 8, TaxableAmount: 1700, TaxAmount: 125},
+		},
+	}
+	withRecipient := *receipt
+	withRecipient.Recipient = "株式会社サンプル"
+
+	tests := []struct {
+		name             string
+		query            string
+		guestToken       string
+		setupMock        func() *MockOrderService
+		setupToken       func() *jwt.Token
+		expectedStatus   int
+		expectError      bool
+		expectedCode     apperrors.ErrCode
+		validateResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "正常系: ログインユーザーがJSONで取得",
+			setupMock: func() *MockOrderService {
+				mockService := new(MockOrderService)
+				mockService.On("GetReceipt", mock.Anything, 10, intPtr(1), "", "").Return(receipt, nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.CustomerRole, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var response models.ReceiptResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, 10, response.OrderID)
+				assert.True(t, response.IsQualifiedInvoice)
+				assert.Equal(t, 1700, response.TotalAmount)
+			},
+		},
+		{
+			name:       "正常系: ゲストが宛名付きのテキストで取得",
+			query:      "?format=text&recipient=%E6%A0%AA%E5%BC%8F%E4%BC%9A%E7%A4%BE%E3%82%B5%E3%83%B3%E3%83%97%E3%83%AB",
+			guestToken: "guest-token",
+			setupMock: func() *MockOrderService {
+				mockService := new(MockOrderService)
+				mockService.On("GetReceipt", mock.Anything, 10, (*int)(nil), "guest-token", "株式会社サンプル").Return(&withRecipient, nil)
+				return mockService
+			},
+			setupToken:     func() *jwt.Token { return nil },
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Contains(t, rec.Header().Get(echo.HeaderContentType), "text/plain")
+				assert.Contains(t, rec.Body.String(), "株式会社サンプル 様")
+				assert.Contains(t, rec.Body.String(), "登録番号: T1234567890123")
+			},
+		},
+		{
+			name:  "正常系: PDFで取得",
+			query: "?format=pdf",
+			setupMock: func() *MockOrderService {
+				mockService := new(MockOrderService)
+				mockService.On("GetReceipt", mock.Anything, 10, intPtr(1), "", "").Return(receipt, nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.CustomerRole, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, "application/pdf", rec.Header().Get(echo.HeaderContentType))
+				assert.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), "receipt-10.pdf")
+				assert.True(t, strings.HasPrefix(rec.Body.String(), "%PDF-"))
+			},
+		},
+		{
+			name: "異常系: トークンもゲスト用トークンもない",
+			setupMock: func() *MockOrderService {
+				return new(MockOrderService)
+			},
+			setupToken:   func() *jwt.Token { return nil },
+			expectError:  true,
+			expectedCode: apperrors.Unauthorized,
+		},
+		{
+			name:  "異常系: 出力形式が不正",
+			query: "?format=csv",
+			setupMock: func() *MockOrderService {
+				return new(MockOrderService)
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.CustomerRole, nil)
+			},
+			expectError:  true,
+			expectedCode: apperrors.BadParam,
+		},
+		{
+			name:  "異常系: 宛名が長すぎる",
+			query: "?recipient=" + strings.Repeat("a", 51),
+			setupMock: func() *MockOrderService {
+				return new(MockOrderService)
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.CustomerRole, nil)
+			},
+			expectError:  true,
+			expectedCode: apperrors.BadParam,
+		},
+		{
+			name:       "異常系: 注文が見つからないかアクセス権がない",
+			guestToken: "other-token",
+			setupMock: func() *MockOrderService {
+				mockService := new(MockOrderService)
+				mockService.On("GetReceipt", mock.Anything, 10, (*int)(nil), "other-token", "").Return(
+					nil, apperrors.NoData.Wrap(nil, "注文が見つからないか、アクセス権がありません。"))
+				return mockService
+			},
+			setupToken:   func() *jwt.Token { return nil },
+			expectError:  true,
+			expectedCode: apperrors.NoData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewOrderController(mockService)
+			c, rec := createTestContextForOrder(http.MethodGet, "/orders/10/receipt"+tt.query, "", map[string]string{"order_id": "10"}, tt.setupToken())
+			if tt.guestToken != "" {
+				c.Request().Header.Set(controllers.GuestOrderTokenHeader, tt.guestToken)
+			}
+
+			err := controller.GetReceiptHandler(c)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				var appErr *apperrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+				if tt.validateResponse != nil {
+					tt.validateResponse(t, rec)
+				}
+			}
+		})
+	}
+}
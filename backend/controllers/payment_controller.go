@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/A4-dev-team/mobileorder.git/validators"
+	"github.com/labstack/echo/v4"
+)
+
+// Webhookの本文として受け付ける最大サイズ
+const maxPaymentWebhookBytes = 64 << 10
+
+type PaymentController interface {
+	HandleWebhookHandler(ctx echo.Context) error
+	CreateRefundHandler(ctx echo.Context) error
+}
+
+type paymentController struct {
+	s services.PaymentServicer
+}
+
+func NewPaymentController(s services.PaymentServicer) PaymentController {
+	return &paymentController{s}
+}
+
+// HandleWebhookHandler は決済代行会社からの決済結果の通知を受け付けます。
+// @Summary      決済結果の通知を受け付ける (Payment Webhook)
+// @Description  決済代行会社が非同期の決済結果を通知するためのエンドポイントです。X-Payment-Signatureヘッダーの署名を検証してから、決済待ちの注文に結果を反映します。
+// @Tags         決済 (Payment)
+// @Accept       json
+// @Produce      json
+// @Param        X-Payment-Signature header string true "本文のHMAC-SHA256署名（16進数）"
+// @Success      200 {object} map[string]string "成功メッセージ"
+// @Failure      400 {object} map[string]string "通知の形式が不正です"
+// @Failure      401 {object} map[string]string "署名が不正です"
+// @Failure      404 {object} map[string]string "対象の決済が見つかりません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /payments/webhook [post]
+func (c *paymentController) HandleWebhookHandler(ctx echo.Context) error {
+	// 署名は受け取った本文そのものに対して検証するため、Bindせずに読み込む
+	payload, err := io.ReadAll(io.LimitReader(ctx.Request().Body, maxPaymentWebhookBytes))
+	if err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgRequestBodyUnreadable, nil)
+	}
+
+	signature := ctx.Request().Header.Get("X-Payment-Signature")
+	if signature == "" {
+		return apperrors.Unauthorized.WrapMessage(nil, apperrors.MsgWebhookSignatureRequired, nil)
+	}
+
+	if err := c.s.HandleWebhook(ctx.Request().Context(), payload, signature); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "決済結果を反映しました。"})
+}
+
+// CreateRefundHandler は注文を返金します
+// @Summary      注文を返金 (Admin)
+// @Description  担当店舗の支払い済みの注文を返金します。itemsで注文商品と数量を指定すると注文時の単価（オプション込み）で部分返金し、省略するとまだ返金していない金額を全額返金します。返金額の合計は支払額を超えられません。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        order_id path int true "注文ID"
+// @Param        request body models.CreateRefundRequest true "返金理由と返金する注文商品"
+// @Success      201 {object} models.RefundResponse "返金結果"
+// @Failure      400 {object} map[string]string "リクエストが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      402 {object} map[string]string "決済代行会社での返金に失敗しました"
+// @Failure      403 {object} map[string]string "店舗に紐づいていない管理者アカウントです"
+// @Failure      404 {object} map[string]string "注文が見つからないか、この店舗の管轄外です"
+// @Failure      409 {object} map[string]string "支払いが完了していないか、返金できる金額・数量を超えています"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/orders/{order_id}/refunds [post]
+func (c *paymentController) CreateRefundHandler(ctx echo.Context) error {
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if claims.ShopID == nil {
+		return apperrors.Forbidden.WrapMessage(nil, apperrors.MsgAdminWithoutShop, nil)
+	}
+	adminShopID := *claims.ShopID
+
+	orderID, err := strconv.Atoi(ctx.Param("order_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidOrderID, nil)
+	}
+
+	var req models.CreateRefundRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.CreateRefundRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	res, err := c.s.RefundOrder(ctx.Request().Context(), adminShopID, claims.UserID, orderID, req)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusCreated, res)
+}
@@ -0,0 +1,280 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/controllers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPaymentService は PaymentServicer のモック
+type MockPaymentService struct {
+	mock.Mock
+}
+
+func (m *MockPaymentService) ProcessOrderPayment(ctx context.Context, order *models.Order, paymentToken string) error {
+	args := m.Called(ctx, order, paymentToken)
+	return args.Error(0)
+}
+
+func (m *MockPaymentService) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+	args := m.Called(ctx, payload, signature)
+	return args.Error(0)
+}
+
+func (m *MockPaymentService) RefundOrder(ctx context.Context, adminShopID int, staffUserID int, orderID int, req models.CreateRefundRequest) (*models.RefundResponse, error) {
+	args := m.Called(ctx, adminShopID, staffUserID, orderID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RefundResponse), args.Error(1)
+}
+
+func (m *MockPaymentService) ExpireStalePayments(ctx context.Context, orderedBefore time.Time) error {
+	args := m.Called(ctx, orderedBefore)
+	return args.Error(0)
+}
+
+func (m *MockPaymentService) RetryPendingRefunds(ctx context.Context, createdBefore time.Time) error {
+	args := m.Called(ctx, createdBefore)
+	return args.Error(0)
+}
+
+func TestPaymentController_HandleWebhookHandler(t *testing.T) {
+	const payload = `{"type":"payment.authorized","provider_payment_id":"mock_pay_abc"}`
+
+	tests := []struct {
+		name           string
+		signature      string
+		setupMock      func() *MockPaymentService
+		expectedStatus int
+		expectError    bool
+		expectedCode   apperrors.ErrCode
+	}{
+		{
+			name:      "正常系: 本文と署名がそのままサービス層に渡される",
+			signature: "abc123",
+			setupMock: func() *MockPaymentService {
+				mockService := new(MockPaymentService)
+				mockService.On("HandleWebhook", mock.Anything, []byte(payload), "abc123").Return(nil)
+				return mockService
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "異常系: 署名ヘッダーがない",
+			signature: "",
+			setupMock: func() *MockPaymentService {
+				return new(MockPaymentService)
+			},
+			expectError:  true,
+			expectedCode: apperrors.Unauthorized,
+		},
+		{
+			name:      "異常系: 署名の検証に失敗",
+			signature: "invalid",
+			setupMock: func() *MockPaymentService {
+				mockService := new(MockPaymentService)
+				mockService.On("HandleWebhook", mock.Anything, []byte(payload), "invalid").Return(
+					apperrors.Unauthorized.Wrap(nil, "Webhookの署名が不正です。"))
+				return mockService
+			},
+			expectError:  true,
+			expectedCode: apperrors.Unauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewPaymentController(mockService)
+			c, rec := createTestContextForOrder(http.MethodPost, "/payments/webhook", payload, nil, nil)
+			if tt.signature != "" {
+				c.Request().Header.Set("X-Payment-Signature", tt.signature)
+			}
+
+			err := controller.HandleWebhookHandler(c)
+
+			if tt.expectError {
+				var appErr *apperrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestPaymentController_CreateRefundHandler(t *testing.T) {
+	partialBody := `{"reason":"品切れのため","items":[{"order_item_id":12,"quantity":1}]}`
+	partialReq := models.CreateRefundRequest{
+		Reason: "品切れのため",
+		Items:  []models.RefundItemRequest{{OrderItemID: 12, Quantity: 1}},
+	}
+	adminToken := func() *jwt.Token {
+		adminShopID := 1
+		return createTestToken(7, models.AdminRole, &adminShopID)
+	}
+
+	tests := []struct {
+		name             string
+		orderID          string
+		requestBody      string
+		setupMock        func() *MockPaymentService
+		setupToken       func() *jwt.Token
+		expectedStatus   int
+		expectError      bool
+		expectedCode     apperrors.ErrCode
+		validateResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "正常系: 部分返金。操作した管理者が返金者として渡される",
+			orderID:     "10",
+			requestBody: partialBody,
+			setupMock: func() *MockPaymentService {
+				mockService := new(MockPaymentService)
+				mockService.On("RefundOrder", mock.Anything, 1, 7, 10, partialReq).Return(&models.RefundResponse{
+					RefundID:      1,
+					OrderID:       10,
+					Amount:        800,
+					Reason:        "品切れのため",
+					StaffUserID:   7,
+					Items:         []models.RefundItemResponse{{OrderItemID: 12, ItemName: "唐揚げ定食", Quantity: 1, Amount: 800}},
+					RefundedTotal: 800,
+					PaidAmount:    1500,
+				}, nil)
+				return mockService
+			},
+			setupToken:     adminToken,
+			expectedStatus: http.StatusCreated,
+			validateResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var response models.RefundResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, 800, response.Amount)
+				assert.Equal(t, 800, response.RefundedTotal)
+				assert.Len(t, response.Items, 1)
+			},
+		},
+		{
+			name:        "正常系: 商品を指定しない全額返金",
+			orderID:     "10",
+			requestBody: `{"reason":"注文取り消し"}`,
+			setupMock: func() *MockPaymentService {
+				mockService := new(MockPaymentService)
+				mockService.On("RefundOrder", mock.Anything, 1, 7, 10, models.CreateRefundRequest{Reason: "注文取り消し"}).Return(&models.RefundResponse{
+					RefundID:      2,
+					OrderID:       10,
+					Amount:        1500,
+					RefundedTotal: 1500,
+					PaidAmount:    1500,
+				}, nil)
+				return mockService
+			},
+			setupToken:     adminToken,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:        "異常系: 理由がない",
+			orderID:     "10",
+			requestBody: `{"items":[{"order_item_id":12,"quantity":1}]}`,
+			setupMock: func() *MockPaymentService {
+				return new(MockPaymentService)
+			},
+			setupToken:   adminToken,
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 数量が0",
+			orderID:     "10",
+			requestBody: `{"reason":"品切れのため","items":[{"order_item_id":12,"quantity":0}]}`,
+			setupMock: func() *MockPaymentService {
+				return new(MockPaymentService)
+			},
+			setupToken:   adminToken,
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 注文IDが数値ではない",
+			orderID:     "abc",
+			requestBody: partialBody,
+			setupMock: func() *MockPaymentService {
+				return new(MockPaymentService)
+			},
+			setupToken:   adminToken,
+			expectError:  true,
+			expectedCode: apperrors.BadParam,
+		},
+		{
+			name:        "異常系: 支払額を超える返金",
+			orderID:     "10",
+			requestBody: partialBody,
+			setupMock: func() *MockPaymentService {
+				mockService := new(MockPaymentService)
+				mockService.On("RefundOrder", mock.Anything, 1, 7, 10, partialReq).Return(nil, apperrors.Conflict.Wrap(nil, "返金額の合計が支払額を超えます。"))
+				return mockService
+			},
+			setupToken:   adminToken,
+			expectError:  true,
+			expectedCode: apperrors.Conflict,
+		},
+		{
+			name:        "異常系: 店舗IDがnilの管理者",
+			orderID:     "10",
+			requestBody: partialBody,
+			setupMock: func() *MockPaymentService {
+				return new(MockPaymentService)
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(7, models.AdminRole, nil)
+			},
+			expectError:  true,
+			expectedCode: apperrors.Forbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewPaymentController(mockService)
+			c, rec := createTestContextForOrder(
+				http.MethodPost,
+				"/admin/orders/"+tt.orderID+"/refunds",
+				tt.requestBody,
+				map[string]string{"order_id": tt.orderID},
+				tt.setupToken(),
+			)
+
+			err := controller.CreateRefundHandler(c)
+
+			if tt.expectError {
+				var appErr *apperrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+				if tt.validateResponse != nil {
+					tt.validateResponse(t, rec)
+				}
+			}
+		})
+	}
+}
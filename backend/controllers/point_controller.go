@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/labstack/echo/v4"
+)
+
+type PointController interface {
+	GetMyPointsHandler(ctx echo.Context) error
+}
+
+type pointController struct {
+	s services.PointServicer
+}
+
+func NewPointController(s services.PointServicer) PointController {
+	return &pointController{s}
+}
+
+// GetMyPointsHandler はログイン中のユーザーのポイント残高と履歴を取得します。
+// @Summary      ポイント残高と履歴の取得 (Get My Points)
+// @Description  ポイント残高、最も早く失効するポイントとその有効期限、直近50件の履歴（新しい順）を返します。ポイントは注文のお渡し時に付与され、獲得から1年で失効します。
+// @Tags         ポイント (Point)
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} models.PointsResponse "ポイント残高と履歴"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /me/points [get]
+func (c *pointController) GetMyPointsHandler(ctx echo.Context) error {
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	points, err := c.s.GetMyPoints(ctx.Request().Context(), claims.UserID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, points)
+}
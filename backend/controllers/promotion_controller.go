@@ -0,0 +1,225 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/A4-dev-team/mobileorder.git/validators"
+	"github.com/labstack/echo/v4"
+)
+
+type PromotionController interface {
+	CreatePromotionHandler(ctx echo.Context) error
+	GetShopPromotionsHandler(ctx echo.Context) error
+	DeactivatePromotionHandler(ctx echo.Context) error
+	CreateGlobalPromotionHandler(ctx echo.Context) error
+	GetGlobalPromotionsHandler(ctx echo.Context) error
+	DeactivateGlobalPromotionHandler(ctx echo.Context) error
+}
+
+type promotionController struct {
+	s services.PromotionServicer
+}
+
+func NewPromotionController(s services.PromotionServicer) PromotionController {
+	return &promotionController{s}
+}
+
+// CreatePromotionHandler は店舗のクーポンを登録します。
+// @Summary      クーポンを登録 (Admin)
+// @Description  店舗で使えるクーポンコードを登録します。割引率(percentage)は1〜100%、値引き額(fixed)は円で指定します。item_idsを省略するとすべての商品が対象です。max_discounted_unitsを指定すると対象商品の安いものから指定数だけ値引きします（例: 2杯目無料は percentage 100、min_item_quantity 2、max_discounted_units 1）。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id path int true "店舗ID"
+// @Param        request body models.CreatePromotionRequest true "クーポン"
+// @Success      201 {object} models.PromotionResponse "登録したクーポン"
+// @Failure      400 {object} map[string]string "リクエストが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      409 {object} map[string]string "同じコードのクーポンが既に登録されています"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/promotions [post]
+func (c *promotionController) CreatePromotionHandler(ctx echo.Context) error {
+	targetShopID, err := strconv.Atoi(ctx.Param("shop_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
+	}
+
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if err := AuthorizeShopAccess(claims, targetShopID); err != nil {
+		return err
+	}
+
+	var req models.CreatePromotionRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.CreatePromotionRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	res, err := c.s.CreatePromotion(ctx.Request().Context(), targetShopID, req)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusCreated, res)
+}
+
+// GetShopPromotionsHandler は店舗のクーポン一覧を取得します。
+// @Summary      クーポン一覧を取得 (Admin)
+// @Description  店舗のクーポンを利用回数とあわせて新しい順に返します。全店舗共通のクーポンは含みません。
+// @Tags         管理者 (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id path int true "店舗ID"
+// @Success      200 {array} models.PromotionResponse "クーポンのリスト"
+// @Failure      400 {object} map[string]string "店舗IDの形式が不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/promotions [get]
+func (c *promotionController) GetShopPromotionsHandler(ctx echo.Context) error {
+	targetShopID, err := strconv.Atoi(ctx.Param("shop_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
+	}
+
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if err := AuthorizeShopAccess(claims, targetShopID); err != nil {
+		return err
+	}
+
+	promotions, err := c.s.GetShopPromotions(ctx.Request().Context(), targetShopID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, promotions)
+}
+
+// DeactivatePromotionHandler は店舗のクーポンを無効にします。
+// @Summary      クーポンを無効化 (Admin)
+// @Description  クーポンを以後の注文で使えなくします。利用記録と、既に値引きした注文はそのまま残ります。
+// @Tags         管理者 (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id      path int true "店舗ID"
+// @Param        promotion_id path int true "クーポンID"
+// @Success      200 {object} map[string]string "成功メッセージ"
+// @Failure      400 {object} map[string]string "IDの形式が不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      404 {object} map[string]string "クーポンが見つかりません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/promotions/{promotion_id} [delete]
+func (c *promotionController) DeactivatePromotionHandler(ctx echo.Context) error {
+	targetShopID, err := strconv.Atoi(ctx.Param("shop_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
+	}
+	promotionID, err := strconv.Atoi(ctx.Param("promotion_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidPromotionID, nil)
+	}
+
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if err := AuthorizeShopAccess(claims, targetShopID); err != nil {
+		return err
+	}
+
+	if err := c.s.DeactivatePromotion(ctx.Request().Context(), targetShopID, promotionID); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "クーポンを無効にしました。"})
+}
+
+// CreateGlobalPromotionHandler は全店舗共通のクーポンを登録します。
+// @Summary      全店舗共通のクーポンを登録 (Headquarters)
+// @Description  全店舗で使えるクーポンコードを登録します。本部の管理者アカウントだけが使えます。指定方法は店舗のクーポンと同じで、item_idsにはどの店舗の商品でも指定できます。店舗に同じコードのクーポンがある場合、その店舗では店舗のクーポンが優先されます。
+// @Tags         本部 (Headquarters)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body models.CreatePromotionRequest true "クーポン"
+// @Success      201 {object} models.PromotionResponse "登録したクーポン"
+// @Failure      400 {object} map[string]string "リクエストが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "本部の管理者アカウントが必要です"
+// @Failure      409 {object} map[string]string "同じコードの全店舗共通のクーポンが既に登録されています"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/promotions [post]
+func (c *promotionController) CreateGlobalPromotionHandler(ctx echo.Context) error {
+	var req models.CreatePromotionRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.CreatePromotionRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	res, err := c.s.CreateGlobalPromotion(ctx.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusCreated, res)
+}
+
+// GetGlobalPromotionsHandler は全店舗共通のクーポン一覧を取得します。
+// @Summary      全店舗共通のクーポン一覧を取得 (Headquarters)
+// @Description  全店舗共通のクーポンを利用回数とあわせて新しい順に返します。本部の管理者アカウントだけが使えます。
+// @Tags         本部 (Headquarters)
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} models.PromotionResponse "クーポンのリスト"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "本部の管理者アカウントが必要です"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/promotions [get]
+func (c *promotionController) GetGlobalPromotionsHandler(ctx echo.Context) error {
+	promotions, err := c.s.GetGlobalPromotions(ctx.Request().Context())
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, promotions)
+}
+
+// DeactivateGlobalPromotionHandler は全店舗共通のクーポンを無効にします。
+// @Summary      全店舗共通のクーポンを無効化 (Headquarters)
+// @Description  全店舗共通のクーポンを以後の注文で使えなくします。利用記録と、既に値引きした注文はそのまま残ります。本部の管理者アカウントだけが使えます。
+// @Tags         本部 (Headquarters)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        promotion_id path int true "クーポンID"
+// @Success      200 {object} map[string]string "成功メッセージ"
+// @Failure      400 {object} map[string]string "クーポンIDの形式が不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "本部の管理者アカウントが必要です"
+// @Failure      404 {object} map[string]string "クーポンが見つかりません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/promotions/{promotion_id} [delete]
+func (c *promotionController) DeactivateGlobalPromotionHandler(ctx echo.Context) error {
+	promotionID, err := strconv.Atoi(ctx.Param("promotion_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidPromotionID, nil)
+	}
+
+	if err := c.s.DeactivateGlobalPromotion(ctx.Request().Context(), promotionID); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "クーポンを無効にしました。"})
+}
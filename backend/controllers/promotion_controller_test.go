@@ -0,0 +1,335 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/controllers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPromotionService は PromotionServicer インターフェースのモック実装です
+type MockPromotionService struct {
+	mock.Mock
+}
+
+func (m *MockPromotionService) CreatePromotion(ctx context.Context, shopID int, req models.CreatePromotionRequest) (*models.PromotionResponse, error) {
+	args := m.Called(ctx, shopID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PromotionResponse), args.Error(1)
+}
+
+func (m *MockPromotionService) GetShopPromotions(ctx context.Context, shopID int) ([]models.PromotionResponse, error) {
+	args := m.Called(ctx, shopID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.PromotionResponse), args.Error(1)
+}
+
+func (m *MockPromotionService) DeactivatePromotion(ctx context.Context, shopID int, promotionID int) error {
+	args := m.Called(ctx, shopID, promotionID)
+	return args.Error(0)
+}
+
+func (m *MockPromotionService) CreateGlobalPromotion(ctx context.Context, req models.CreatePromotionRequest) (*models.PromotionResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PromotionResponse), args.Error(1)
+}
+
+func (m *MockPromotionService) GetGlobalPromotions(ctx context.Context) ([]models.PromotionResponse, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.PromotionResponse), args.Error(1)
+}
+
+func (m *MockPromotionService) DeactivateGlobalPromotion(ctx context.Context, promotionID int) error {
+	args := m.Called(ctx, promotionID)
+	return args.Error(0)
+}
+
+func TestPromotionController_CreatePromotionHandler(t *testing.T) {
+	secondDrinkFree := models.CreatePromotionRequest{
+		Code:               "DRINK2",
+		Name:               "ドリンク2杯目無料",
+		DiscountType:       models.PercentageDiscount,
+		DiscountValue:      100,
+		MinItemQuantity:    2,
+		MaxDiscountedUnits: intPtr(1),
+		ItemIDs:            []int{5},
+	}
+
+	tests := []struct {
+		name           string
+		shopID         string
+		requestBody    string
+		setupMock      func() *MockPromotionService
+		setupToken     func() *jwt.Token
+		expectedStatus int
+		expectedCode   apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: 2杯目無料のクーポンを登録",
+			shopID:      "1",
+			requestBody: `{"code":"DRINK2","name":"ドリンク2杯目無料","discount_type":"percentage","discount_value":100,"min_item_quantity":2,"max_discounted_units":1,"item_ids":[5]}`,
+			setupMock: func() *MockPromotionService {
+				mockService := new(MockPromotionService)
+				mockService.On("CreatePromotion", mock.Anything, 1, secondDrinkFree).Return(&models.PromotionResponse{
+					PromotionID: 3, Code: "DRINK2", DiscountType: "percentage", DiscountValue: 100, IsActive: true, ItemIDs: []int{5},
+				}, nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, intPtr(1))
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:        "異常系: 不正な割引方式",
+			shopID:      "1",
+			requestBody: `{"code":"DRINK2","name":"ドリンク2杯目無料","discount_type":"free","discount_value":100}`,
+			setupMock: func() *MockPromotionService {
+				return new(MockPromotionService)
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, intPtr(1))
+			},
+			expectedCode: apperrors.ReqBodyDecodeFailed,
+		},
+		{
+			name:        "異常系: コードに記号が含まれる",
+			shopID:      "1",
+			requestBody: `{"code":"DRINK-2","name":"ドリンク2杯目無料","discount_type":"percentage","discount_value":100}`,
+			setupMock: func() *MockPromotionService {
+				return new(MockPromotionService)
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, intPtr(1))
+			},
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 同じコードが登録済み",
+			shopID:      "1",
+			requestBody: `{"code":"DRINK2","name":"ドリンク2杯目無料","discount_type":"percentage","discount_value":100,"min_item_quantity":2,"max_discounted_units":1,"item_ids":[5]}`,
+			setupMock: func() *MockPromotionService {
+				mockService := new(MockPromotionService)
+				mockService.On("CreatePromotion", mock.Anything, 1, secondDrinkFree).
+					Return(nil, apperrors.Conflict.Wrap(nil, "同じコードのクーポンが既に登録されています。"))
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, intPtr(1))
+			},
+			expectedCode: apperrors.Conflict,
+		},
+		{
+			name:        "異常系: 他店舗の管理者",
+			shopID:      "1",
+			requestBody: `{"code":"DRINK2","name":"ドリンク2杯目無料","discount_type":"percentage","discount_value":100}`,
+			setupMock: func() *MockPromotionService {
+				return new(MockPromotionService)
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, intPtr(2))
+			},
+			expectedCode: apperrors.Forbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewPromotionController(mockService)
+			c, rec := createTestContextForOrder(
+				http.MethodPost,
+				"/admin/shops/"+tt.shopID+"/promotions",
+				tt.requestBody,
+				map[string]string{"shop_id": tt.shopID},
+				tt.setupToken(),
+			)
+
+			err := controller.CreatePromotionHandler(c)
+
+			if tt.expectedCode != "" {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			var res models.PromotionResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, "DRINK2", res.Code)
+		})
+	}
+}
+
+func TestPromotionController_DeactivatePromotionHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		promotionID    string
+		setupMock      func() *MockPromotionService
+		expectedStatus int
+		expectedCode   apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: クーポンを無効化",
+			promotionID: "3",
+			setupMock: func() *MockPromotionService {
+				mockService := new(MockPromotionService)
+				mockService.On("DeactivatePromotion", mock.Anything, 1, 3).Return(nil)
+				return mockService
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "異常系: クーポンIDが数値でない",
+			promotionID: "abc",
+			setupMock: func() *MockPromotionService {
+				return new(MockPromotionService)
+			},
+			expectedCode: apperrors.BadParam,
+		},
+		{
+			name:        "異常系: 他店舗のクーポン",
+			promotionID: "4",
+			setupMock: func() *MockPromotionService {
+				mockService := new(MockPromotionService)
+				mockService.On("DeactivatePromotion", mock.Anything, 1, 4).
+					Return(apperrors.NoData.Wrap(nil, "指定されたクーポンが見つからないか、この店舗のものではありません。"))
+				return mockService
+			},
+			expectedCode: apperrors.NoData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewPromotionController(mockService)
+			c, rec := createTestContextForOrder(
+				http.MethodDelete,
+				"/admin/shops/1/promotions/"+tt.promotionID,
+				"",
+				map[string]string{"shop_id": "1", "promotion_id": tt.promotionID},
+				createTestToken(1, models.AdminRole, intPtr(1)),
+			)
+
+			err := controller.DeactivatePromotionHandler(c)
+
+			if tt.expectedCode != "" {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestPromotionController_CreateGlobalPromotionHandler(t *testing.T) {
+	welcome := models.CreatePromotionRequest{
+		Code:          "WELCOME",
+		Name:          "はじめての方100円引き",
+		DiscountType:  models.FixedDiscount,
+		DiscountValue: 100,
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func() *MockPromotionService
+		expectedStatus int
+		expectedCode   apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: 全店舗共通のクーポンを登録",
+			requestBody: `{"code":"WELCOME","name":"はじめての方100円引き","discount_type":"fixed","discount_value":100}`,
+			setupMock: func() *MockPromotionService {
+				mockService := new(MockPromotionService)
+				mockService.On("CreateGlobalPromotion", mock.Anything, welcome).Return(&models.PromotionResponse{
+					PromotionID: 5, Code: "WELCOME", DiscountType: "fixed", DiscountValue: 100, IsActive: true, ItemIDs: []int{},
+				}, nil)
+				return mockService
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:        "異常系: 値引き額がない",
+			requestBody: `{"code":"WELCOME","name":"はじめての方100円引き","discount_type":"fixed"}`,
+			setupMock: func() *MockPromotionService {
+				return new(MockPromotionService)
+			},
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 存在しない商品が対象",
+			requestBody: `{"code":"WELCOME","name":"はじめての方100円引き","discount_type":"fixed","discount_value":100,"item_ids":[999]}`,
+			setupMock: func() *MockPromotionService {
+				req := welcome
+				req.ItemIDs = []int{999}
+				mockService := new(MockPromotionService)
+				mockService.On("CreateGlobalPromotion", mock.Anything, req).
+					Return(nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgUnknownItem, nil))
+				return mockService
+			},
+			expectedCode: apperrors.BadParam,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewPromotionController(mockService)
+			c, rec := createTestContextForOrder(
+				http.MethodPost,
+				"/admin/promotions",
+				tt.requestBody,
+				nil,
+				createTestToken(1, models.HeadquartersRole, nil),
+			)
+
+			err := controller.CreateGlobalPromotionHandler(c)
+
+			if tt.expectedCode != "" {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			var res models.PromotionResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, "WELCOME", res.Code)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/A4-dev-team/mobileorder.git/validators"
+	"github.com/labstack/echo/v4"
+)
+
+type ReportController interface {
+	GetSalesReportHandler(ctx echo.Context) error
+	GetItemSalesReportHandler(ctx echo.Context) error
+	GetKitchenMetricsHandler(ctx echo.Context) error
+	ExportOrdersHandler(ctx echo.Context) error
+}
+
+type reportController struct {
+	s services.ReportServicer
+}
+
+func NewReportController(s services.ReportServicer) ReportController {
+	return &reportController{s}
+}
+
+// GetSalesReportHandler は店舗の売上を期間ごとに集計します。
+// @Summary      売上レポート (Admin)
+// @Description  店舗の注文件数・売上（税込）・値引き後の売上（税抜）・客単価・販売数を、日本時間の日・週（月曜始まり）・月ごとに集計します。決済待ちの注文は含みません。返金は元の注文の期間に計上します。期間を省略すると今日までの30日間です（最大366日）。
+// @Tags         管理者 (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id  path  int    true  "店舗ID"
+// @Param        from     query string false "開始日（YYYY-MM-DD、日本時間）"
+// @Param        to       query string false "終了日（YYYY-MM-DD、日本時間、この日を含む）"
+// @Param        group_by query string false "集計単位（省略時はday）" Enums(day, week, month)
+// @Success      200 {object} models.SalesReportResponse "売上レポート"
+// @Failure      400 {object} map[string]string "店舗IDまたはクエリパラメータが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/reports/sales [get]
+func (c *reportController) GetSalesReportHandler(ctx echo.Context) error {
+	targetShopID, query, err := bindReportQuery[models.ReportQuery](ctx)
+	if err != nil {
+		return err
+	}
+
+	report, err := c.s.GetSalesReport(ctx.Request().Context(), targetShopID, query)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, report)
+}
+
+// GetItemSalesReportHandler は店舗の売上を商品ごとに集計します。
+// @Summary      商品別売上レポート (Admin)
+// @Description  期間内に注文された商品ごとの販売数・注文件数・売上（注文時の単価とオプションの税込合計）を売上の多い順に返します。注文全体の値引きは含みません。期間の指定は売上レポートと同じです。
+// @Tags         管理者 (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id path  int    true  "店舗ID"
+// @Param        from    query string false "開始日（YYYY-MM-DD、日本時間）"
+// @Param        to      query string false "終了日（YYYY-MM-DD、日本時間、この日を含む）"
+// @Success      200 {object} models.ItemSalesReportResponse "商品別売上レポート"
+// @Failure      400 {object} map[string]string "店舗IDまたはクエリパラメータが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/reports/items [get]
+func (c *reportController) GetItemSalesReportHandler(ctx echo.Context) error {
+	targetShopID, query, err := bindReportQuery[models.ReportQuery](ctx)
+	if err != nil {
+		return err
+	}
+
+	report, err := c.s.GetItemSalesReport(ctx.Request().Context(), targetShopID, query)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, report)
+}
+
+// GetKitchenMetricsHandler は店舗の厨房の所要時間を集計します。
+// @Summary      厨房の所要時間 (Admin)
+// @Description  注文から調理完了まで（cook_time）と調理完了からお渡しまで（pickup_wait）の時間の中央値・90パーセンタイル・最大値を、全体・注文した時刻（日本時間）・商品ごとに返します。あわせて、調理完了から uncollected_minutes 分（省略時は15分）を過ぎても受け取られていない注文を返します。期間の指定は売上レポートと同じです。
+// @Tags         管理者 (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id             path  int    true  "店舗ID"
+// @Param        from                query string false "開始日（YYYY-MM-DD、日本時間）"
+// @Param        to                  query string false "終了日（YYYY-MM-DD、日本時間、この日を含む）"
+// @Param        uncollected_minutes query int    false "受け取られていない注文とみなすまでの時間（分、1〜1440）"
+// @Success      200 {object} models.KitchenMetricsResponse "厨房の所要時間"
+// @Failure      400 {object} map[string]string "店舗IDまたはクエリパラメータが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/reports/kitchen [get]
+func (c *reportController) GetKitchenMetricsHandler(ctx echo.Context) error {
+	targetShopID, query, err := bindReportQuery[models.KitchenMetricsQuery](ctx)
+	if err != nil {
+		return err
+	}
+
+	metrics, err := c.s.GetKitchenMetrics(ctx.Request().Context(), targetShopID, query)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, metrics)
+}
+
+// ExportOrdersHandler は店舗の注文を会計向けにCSVまたはXLSXで書き出します。
+// @Summary      注文の書き出し (Admin)
+// @Description  期間内の注文を商品ごとに1行ずつ、注文日時（日本時間）・ステータス・飲食区分・お客様・注文時の単価とオプション加算額・税率・注文全体の小計・値引き・消費税・合計とともに書き出します。お客様は会員のメールアドレスで、ゲスト注文は「ゲスト」と伏せたトークンの末尾4文字です。決済待ちの注文は含みません。CSVはExcelで開けるようBOM付きのUTF-8です。期間の指定は売上レポートと同じです。
+// @Tags         管理者 (Admin)
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security     BearerAuth
+// @Param        shop_id path  int    true  "店舗ID"
+// @Param        format  query string false "形式（省略時はcsv）" Enums(csv, xlsx)
+// @Param        from    query string false "開始日（YYYY-MM-DD、日本時間）"
+// @Param        to      query string false "終了日（YYYY-MM-DD、日本時間、この日を含む）"
+// @Success      200 {file} file "注文の一覧"
+// @Failure      400 {object} map[string]string "店舗IDまたはクエリパラメータが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/orders/export [get]
+func (c *reportController) ExportOrdersHandler(ctx echo.Context) error {
+	targetShopID, query, err := bindReportQuery[models.OrderExportQuery](ctx)
+	if err != nil {
+		return err
+	}
+
+	export, err := c.s.ExportOrders(ctx.Request().Context(), targetShopID, query)
+	if err != nil {
+		return err
+	}
+	// ヘッダーは最初に書き込むときに送るため、書き始める前のエラーは通常のエラーレスポンスになる
+	return export.WriteTo(&attachmentWriter{ctx: ctx, filename: export.Filename, contentType: export.ContentType})
+}
+
+// attachmentWriter は最初に書き込むときにダウンロード用のヘッダーを付けてレスポンスを書き始めます
+type attachmentWriter struct {
+	ctx         echo.Context
+	filename    string
+	contentType string
+}
+
+func (w *attachmentWriter) Write(p []byte) (int, error) {
+	res := w.ctx.Response()
+	if !res.Committed {
+		res.Header().Set(echo.HeaderContentType, w.contentType)
+		res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", w.filename))
+		res.WriteHeader(http.StatusOK)
+	}
+	return res.Write(p)
+}
+
+// bindReportQuery は店舗IDと集計の条件を取り出し、管理者がその店舗にアクセスできるか確認します
+func bindReportQuery[T any](ctx echo.Context) (int, T, error) {
+	var query T
+	targetShopID, err := strconv.Atoi(ctx.Param("shop_id"))
+	if err != nil {
+		return 0, query, apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
+	}
+
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return 0, query, err
+	}
+	if err := AuthorizeShopAccess(claims, targetShopID); err != nil {
+		return 0, query, err
+	}
+
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ctx, &query); err != nil {
+		return 0, query, apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidQuery, nil)
+	}
+	validator := validators.NewValidator[T]()
+	if err := validator.Validate(query); err != nil {
+		return 0, query, apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+	return targetShopID, query, nil
+}
@@ -0,0 +1,362 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/controllers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReportService は ReportServicer インターフェースのモック実装です
+type MockReportService struct {
+	mock.Mock
+}
+
+func (m *MockReportService) GetSalesReport(ctx context.Context, shopID int, query models.ReportQuery) (*models.SalesReportResponse, error) {
+	args := m.Called(ctx, shopID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SalesReportResponse), args.Error(1)
+}
+
+func (m *MockReportService) GetItemSalesReport(ctx context.Context, shopID int, query models.ReportQuery) (*models.ItemSalesReportResponse, error) {
+	args := m.Called(ctx, shopID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ItemSalesReportResponse), args.Error(1)
+}
+
+func (m *MockReportService) GetKitchenMetrics(ctx context.Context, shopID int, query models.KitchenMetricsQuery) (*models.KitchenMetricsResponse, error) {
+	args := m.Called(ctx, shopID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.KitchenMetricsResponse), args.Error(1)
+}
+
+func (m *MockReportService) ExportOrders(ctx context.Context, shopID int, query models.OrderExportQuery) (*services.OrderExport, error) {
+	args := m.Called(ctx, shopID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.OrderExport), args.Error(1)
+}
+
+func TestReportController_GetSalesReportHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		shopID         string
+		queryString    string
+		setupMock      func() *MockReportService
+		setupToken     func() *jwt.Token
+		expectedStatus int
+		expectedCode   apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: 週ごとの売上",
+			shopID:      "1",
+			queryString: "?from=2025-04-01&to=2025-04-30&group_by=week",
+			setupMock: func() *MockReportService {
+				mockService := new(MockReportService)
+				query := models.ReportQuery{From: "2025-04-01", To: "2025-04-30", GroupBy: "week"}
+				mockService.On("GetSalesReport", mock.Anything, 1, query).Return(&models.SalesReportResponse{
+					ShopID: 1, From: "2025-04-01", To: "2025-04-30", GroupBy: "week",
+					Summary: models.SalesReportEntry{OrderCount: 2, Revenue: 2000, AverageTicket: 1000},
+				}, nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, intPtr(1))
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "正常系: 条件を省略",
+			shopID: "1",
+			setupMock: func() *MockReportService {
+				mockService := new(MockReportService)
+				mockService.On("GetSalesReport", mock.Anything, 1, models.ReportQuery{}).Return(&models.SalesReportResponse{ShopID: 1, GroupBy: "day"}, nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, intPtr(1))
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "異常系: 日付の形式が不正",
+			shopID:      "1",
+			queryString: "?from=2025/04/01",
+			setupMock: func() *MockReportService {
+				return new(MockReportService)
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, intPtr(1))
+			},
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 集計単位が不正",
+			shopID:      "1",
+			queryString: "?group_by=year",
+			setupMock: func() *MockReportService {
+				return new(MockReportService)
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, intPtr(1))
+			},
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:   "異常系: 店舗IDが数値でない",
+			shopID: "abc",
+			setupMock: func() *MockReportService {
+				return new(MockReportService)
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, intPtr(1))
+			},
+			expectedCode: apperrors.BadParam,
+		},
+		{
+			name:   "異常系: 他店舗の管理者",
+			shopID: "1",
+			setupMock: func() *MockReportService {
+				return new(MockReportService)
+			},
+			setupToken: func() *jwt.Token {
+				return createTestToken(1, models.AdminRole, intPtr(2))
+			},
+			expectedCode: apperrors.Forbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewReportController(mockService)
+			c, rec := createTestContextForOrder(
+				http.MethodGet,
+				"/admin/shops/"+tt.shopID+"/reports/sales"+tt.queryString,
+				"",
+				map[string]string{"shop_id": tt.shopID},
+				tt.setupToken(),
+			)
+
+			err := controller.GetSalesReportHandler(c)
+
+			if tt.expectedCode != "" {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			var res models.SalesReportResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, 1, res.ShopID)
+		})
+	}
+}
+
+func TestReportController_GetItemSalesReportHandler(t *testing.T) {
+	mockService := new(MockReportService)
+	defer mockService.AssertExpectations(t)
+	query := models.ReportQuery{From: "2025-04-01", To: "2025-04-30"}
+	mockService.On("GetItemSalesReport", mock.Anything, 1, query).Return(&models.ItemSalesReportResponse{
+		ShopID: 1, From: "2025-04-01", To: "2025-04-30",
+		Items: []models.ItemSalesReportEntry{{ItemID: 1, ItemName: "唐揚げ定食", Quantity: 3, OrderCount: 2, SalesAmount: 2550}},
+	}, nil)
+
+	controller := controllers.NewReportController(mockService)
+	c, rec := createTestContextForOrder(
+		http.MethodGet,
+		"/admin/shops/1/reports/items?from=2025-04-01&to=2025-04-30",
+		"",
+		map[string]string{"shop_id": "1"},
+		createTestToken(1, models.AdminRole, intPtr(1)),
+	)
+
+	assert.NoError(t, controller.GetItemSalesReportHandler(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res models.ItemSalesReportResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Len(t, res.Items, 1)
+	assert.Equal(t, 2550, res.Items[0].SalesAmount)
+}
+
+func TestReportController_GetKitchenMetricsHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		queryString  string
+		setupMock    func() *MockReportService
+		expectedCode apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: 受け取られていない注文の基準を指定",
+			queryString: "?from=2025-04-01&uncollected_minutes=30",
+			setupMock: func() *MockReportService {
+				mockService := new(MockReportService)
+				query := models.KitchenMetricsQuery{From: "2025-04-01", UncollectedMinutes: 30}
+				mockService.On("GetKitchenMetrics", mock.Anything, 1, query).Return(&models.KitchenMetricsResponse{
+					ShopID: 1, UncollectedMinutes: 30,
+					CookTime: models.DurationStats{Count: 3, P50Seconds: 600, P90Seconds: 900, MaxSeconds: 900},
+				}, nil)
+				return mockService
+			},
+		},
+		{
+			name:        "異常系: 基準の時間が数値でない",
+			queryString: "?uncollected_minutes=abc",
+			setupMock: func() *MockReportService {
+				return new(MockReportService)
+			},
+			expectedCode: apperrors.BadParam,
+		},
+		{
+			name:        "異常系: 基準の時間が1日を超える",
+			queryString: "?uncollected_minutes=1441",
+			setupMock: func() *MockReportService {
+				return new(MockReportService)
+			},
+			expectedCode: apperrors.ValidationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewReportController(mockService)
+			c, rec := createTestContextForOrder(
+				http.MethodGet,
+				"/admin/shops/1/reports/kitchen"+tt.queryString,
+				"",
+				map[string]string{"shop_id": "1"},
+				createTestToken(1, models.AdminRole, intPtr(1)),
+			)
+
+			err := controller.GetKitchenMetricsHandler(c)
+
+			if tt.expectedCode != "" {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var res models.KitchenMetricsResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, 600, res.CookTime.P50Seconds)
+		})
+	}
+}
+
+func TestReportController_ExportOrdersHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		queryString  string
+		setupMock    func() *MockReportService
+		expectedCode apperrors.ErrCode
+		expectedBody string
+	}{
+		{
+			name:        "正常系: CSVを書き出す",
+			queryString: "?format=csv&from=2025-04-01&to=2025-04-30",
+			setupMock: func() *MockReportService {
+				mockService := new(MockReportService)
+				query := models.OrderExportQuery{Format: "csv", From: "2025-04-01", To: "2025-04-30"}
+				mockService.On("ExportOrders", mock.Anything, 1, query).Return(&services.OrderExport{
+					Filename:    "orders_shop1_20250401_20250430.csv",
+					ContentType: "text/csv; charset=utf-8",
+					WriteTo: func(w io.Writer) error {
+						_, err := io.WriteString(w, "注文ID\n1\n")
+						return err
+					},
+				}, nil)
+				return mockService
+			},
+			expectedBody: "注文ID\n1\n",
+		},
+		{
+			name:        "異常系: 書き始める前に失敗",
+			queryString: "?format=xlsx",
+			setupMock: func() *MockReportService {
+				mockService := new(MockReportService)
+				mockService.On("ExportOrders", mock.Anything, 1, models.OrderExportQuery{Format: "xlsx"}).Return(&services.OrderExport{
+					Filename:    "orders_shop1_20250401_20250430.xlsx",
+					ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+					WriteTo: func(w io.Writer) error {
+						return apperrors.GetDataFailed.Wrap(errors.New("db error"), "書き出す注文の取得に失敗しました。")
+					},
+				}, nil)
+				return mockService
+			},
+			expectedCode: apperrors.GetDataFailed,
+		},
+		{
+			name:        "異常系: 形式が不正",
+			queryString: "?format=pdf",
+			setupMock: func() *MockReportService {
+				return new(MockReportService)
+			},
+			expectedCode: apperrors.ValidationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewReportController(mockService)
+			c, rec := createTestContextForOrder(
+				http.MethodGet,
+				"/admin/shops/1/orders/export"+tt.queryString,
+				"",
+				map[string]string{"shop_id": "1"},
+				createTestToken(1, models.AdminRole, intPtr(1)),
+			)
+
+			err := controller.ExportOrdersHandler(c)
+
+			if tt.expectedCode != "" {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+				// 書き始める前のエラーはまだレスポンスを送っていない
+				assert.False(t, c.Response().Committed)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+			assert.Equal(t, `attachment; filename="orders_shop1_20250401_20250430.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+			assert.Equal(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
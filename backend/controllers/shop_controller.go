@@ -0,0 +1,322 @@
+package controllers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/A4-dev-team/mobileorder.git/validators"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// 距離検索の半径（メートル）の既定値と上限
+	defaultSearchRadiusMeters = 1000
+	maxSearchRadiusMeters     = 50000
+)
+
+type ShopController interface {
+	GetNearbyShopsHandler(ctx echo.Context) error
+	UpdateShopCoordinatesHandler(ctx echo.Context) error
+	UpdateDefaultPrepTimeHandler(ctx echo.Context) error
+	UpdateInvoiceRegistrationNumberHandler(ctx echo.Context) error
+	UpdatePointRateHandler(ctx echo.Context) error
+	UpdateTimeZoneHandler(ctx echo.Context) error
+}
+
+type shopController struct {
+	s services.ShopServicer
+}
+
+func NewShopController(s services.ShopServicer) ShopController {
+	return &shopController{s}
+}
+
+// GetNearbyShopsHandler は指定地点の周辺にある店舗を近い順に取得します。
+// @Summary      周辺店舗の検索 (Search Nearby Shops)
+// @Description  near=緯度,経度 で指定した地点から radius メートル以内の店舗を、大円距離の近い順に返します。radiusを省略した場合は1000mです。langかAccept-Languageで英語・中国語・韓国語を指定すると、翻訳のある店舗名・説明を翻訳します。
+// @Tags         店舗 (Shop)
+// @Accept       json
+// @Produce      json
+// @Param        near   query string true  "検索地点の緯度,経度 (例: 34.7256,135.2352)"
+// @Param        radius query number false "検索半径（メートル、最大50000）"
+// @Param        lang   query string false "表示する言語。Accept-Languageより優先します" Enums(ja, en, zh, ko)
+// @Success      200 {array} models.NearbyShopResponse "距離付きの店舗リスト"
+// @Failure      400 {object} map[string]string "クエリパラメータが不正です"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /shops [get]
+func (c *shopController) GetNearbyShopsHandler(ctx echo.Context) error {
+	latitude, longitude, err := parseLatLng(ctx.QueryParam("near"))
+	if err != nil {
+		return err
+	}
+
+	radius := float64(defaultSearchRadiusMeters)
+	if radiusStr := ctx.QueryParam("radius"); radiusStr != "" {
+		radius, err = strconv.ParseFloat(radiusStr, 64)
+		if err != nil {
+			return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidSearchRadius, nil)
+		}
+		if math.IsNaN(radius) || radius <= 0 || radius > maxSearchRadiusMeters {
+			return apperrors.BadParam.WrapMessage(nil, apperrors.MsgSearchRadiusOutOfRange, apperrors.Params{"max": maxSearchRadiusMeters})
+		}
+	}
+
+	shops, err := c.s.GetNearbyShops(ctx.Request().Context(), latitude, longitude, radius, negotiateLanguage(ctx))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, shops)
+}
+
+// UpdateShopCoordinatesHandler は店舗の緯度経度を更新します。
+// @Summary      店舗の位置情報を更新 (Admin)
+// @Description  管理者が担当する店舗の緯度経度を設定します。距離検索の対象になるのは緯度経度が設定された店舗のみです。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id path int true "店舗ID"
+// @Param        request body models.UpdateShopCoordinatesRequest true "緯度経度"
+// @Success      200 {object} map[string]string "成功メッセージ"
+// @Failure      400 {object} map[string]string "リクエストが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      404 {object} map[string]string "店舗が見つかりません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/coordinates [patch]
+func (c *shopController) UpdateShopCoordinatesHandler(ctx echo.Context) error {
+	targetShopID, err := strconv.Atoi(ctx.Param("shop_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
+	}
+
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if err := AuthorizeShopAccess(claims, targetShopID); err != nil {
+		return err
+	}
+
+	var req models.UpdateShopCoordinatesRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.UpdateShopCoordinatesRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	if err := c.s.UpdateShopCoordinates(ctx.Request().Context(), targetShopID, *req.Latitude, *req.Longitude); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "店舗の位置情報を更新しました。"})
+}
+
+// UpdateDefaultPrepTimeHandler は店舗の既定調理時間を更新します。
+// @Summary      店舗の既定調理時間を更新 (Admin)
+// @Description  調理時間が個別に設定されていない商品に使う、店舗の既定調理時間（秒）を設定します。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id path int true "店舗ID"
+// @Param        request body models.UpdateShopPrepTimeRequest true "既定調理時間"
+// @Success      200 {object} map[string]string "成功メッセージ"
+// @Failure      400 {object} map[string]string "リクエストが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      404 {object} map[string]string "店舗が見つかりません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/prep-time [patch]
+func (c *shopController) UpdateDefaultPrepTimeHandler(ctx echo.Context) error {
+	targetShopID, err := strconv.Atoi(ctx.Param("shop_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
+	}
+
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if err := AuthorizeShopAccess(claims, targetShopID); err != nil {
+		return err
+	}
+
+	var req models.UpdateShopPrepTimeRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.UpdateShopPrepTimeRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	if err := c.s.UpdateDefaultPrepTime(ctx.Request().Context(), targetShopID, req.DefaultPrepSeconds); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "店舗の既定調理時間を更新しました。"})
+}
+
+// UpdateInvoiceRegistrationNumberHandler は店舗の適格請求書発行事業者の登録番号を更新します。
+// @Summary      店舗の登録番号を更新 (Admin)
+// @Description  領収書に記載する適格請求書発行事業者の登録番号（T + 13桁の数字）を設定します。nullを送ると登録番号を削除します。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id path int true "店舗ID"
+// @Param        request body models.UpdateInvoiceRegistrationNumberRequest true "登録番号"
+// @Success      200 {object} map[string]string "成功メッセージ"
+// @Failure      400 {object} map[string]string "リクエストが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      404 {object} map[string]string "店舗が見つかりません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/invoice-registration-number [patch]
+func (c *shopController) UpdateInvoiceRegistrationNumberHandler(ctx echo.Context) error {
+	targetShopID, err := strconv.Atoi(ctx.Param("shop_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
+	}
+
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if err := AuthorizeShopAccess(claims, targetShopID); err != nil {
+		return err
+	}
+
+	var req models.UpdateInvoiceRegistrationNumberRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.UpdateInvoiceRegistrationNumberRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	if err := c.s.UpdateInvoiceRegistrationNumber(ctx.Request().Context(), targetShopID, req.InvoiceRegistrationNumber); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "店舗の登録番号を更新しました。"})
+}
+
+// UpdatePointRateHandler は店舗のポイント還元率を更新します。
+// @Summary      店舗のポイント還元率を更新 (Admin)
+// @Description  お渡しした注文に付与するポイントを、値引き後の合計から消費税を除いた金額100円あたりのポイント数（0〜100）で設定します。0にするとポイントを付与しません。変更は以後にお渡しする注文から反映されます。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id path int true "店舗ID"
+// @Param        request body models.UpdateShopPointRateRequest true "ポイント還元率"
+// @Success      200 {object} map[string]string "成功メッセージ"
+// @Failure      400 {object} map[string]string "リクエストが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      404 {object} map[string]string "店舗が見つかりません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/point-rate [patch]
+func (c *shopController) UpdatePointRateHandler(ctx echo.Context) error {
+	targetShopID, err := strconv.Atoi(ctx.Param("shop_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
+	}
+
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if err := AuthorizeShopAccess(claims, targetShopID); err != nil {
+		return err
+	}
+
+	var req models.UpdateShopPointRateRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.UpdateShopPointRateRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	if err := c.s.UpdatePointRate(ctx.Request().Context(), targetShopID, *req.PointRate); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "店舗のポイント還元率を更新しました。"})
+}
+
+// UpdateTimeZoneHandler は店舗のタイムゾーンを更新します。
+// @Summary      店舗のタイムゾーンを更新 (Admin)
+// @Description  商品の販売時間帯（朝食は7時〜10時など）を判定するタイムゾーンを"Asia/Tokyo"のようなIANAの名前で設定します。既定は"Asia/Tokyo"です。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id path int true "店舗ID"
+// @Param        request body models.UpdateShopTimeZoneRequest true "タイムゾーン"
+// @Success      200 {object} map[string]string "成功メッセージ"
+// @Failure      400 {object} map[string]string "リクエストが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      404 {object} map[string]string "店舗が見つかりません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/time-zone [patch]
+func (c *shopController) UpdateTimeZoneHandler(ctx echo.Context) error {
+	targetShopID, err := strconv.Atoi(ctx.Param("shop_id"))
+	if err != nil {
+		return apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidShopID, nil)
+	}
+
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return err
+	}
+	if err := AuthorizeShopAccess(claims, targetShopID); err != nil {
+		return err
+	}
+
+	var req models.UpdateShopTimeZoneRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.UpdateShopTimeZoneRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	if err := c.s.UpdateTimeZone(ctx.Request().Context(), targetShopID, req.TimeZone); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "店舗のタイムゾーンを更新しました。"})
+}
+
+// parseLatLng は "緯度,経度" 形式の文字列を解析します
+func parseLatLng(s string) (float64, float64, error) {
+	if s == "" {
+		return 0, 0, apperrors.BadParam.WrapMessage(nil, apperrors.MsgNearRequired, nil)
+	}
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return 0, 0, apperrors.BadParam.WrapMessage(nil, apperrors.MsgInvalidNearFormat, nil)
+	}
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return 0, 0, apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidLatitude, nil)
+	}
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return 0, 0, apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidLongitude, nil)
+	}
+	if math.IsNaN(latitude) || math.IsNaN(longitude) || latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return 0, 0, apperrors.BadParam.WrapMessage(nil, apperrors.MsgCoordinatesOutOfRange, nil)
+	}
+	return latitude, longitude, nil
+}
@@ -0,0 +1,685 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/controllers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockShopService は ShopServicer インターフェースのモック実装です
+type MockShopService struct {
+	mock.Mock
+}
+
+func (m *MockShopService) GetNearbyShops(ctx context.Context, latitude float64, longitude float64, radiusMeters float64, lang string) ([]models.NearbyShopResponse, error) {
+	args := m.Called(ctx, latitude, longitude, radiusMeters, lang)
+	return args.Get(0).([]models.NearbyShopResponse), args.Error(1)
+}
+
+func (m *MockShopService) UpdateShopCoordinates(ctx context.Context, shopID int, latitude float64, longitude float64) error {
+	args := m.Called(ctx, shopID, latitude, longitude)
+	return args.Error(0)
+}
+
+func (m *MockShopService) UpdateDefaultPrepTime(ctx context.Context, shopID int, defaultPrepSeconds int) error {
+	args := m.Called(ctx, shopID, defaultPrepSeconds)
+	return args.Error(0)
+}
+
+func (m *MockShopService) UpdateInvoiceRegistrationNumber(ctx context.Context, shopID int, registrationNumber *string) error {
+	args := m.Called(ctx, shopID, registrationNumber)
+	return args.Error(0)
+}
+
+func (m *MockShopService) UpdatePointRate(ctx context.Context, shopID int, pointRate int) error {
+	args := m.Called(ctx, shopID, pointRate)
+	return args.Error(0)
+}
+
+func (m *MockShopService) UpdateTimeZone(ctx context.Context, shopID int, timeZone string) error {
+	args := m.Called(ctx, shopID, timeZone)
+	return args.Error(0)
+}
+
+// TestShopController_GetNearbyShopsHandler のテストケース
+func TestShopController_GetNearbyShopsHandler(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		setupMock        func() *MockShopService
+		expectedStatus   int
+		expectError      bool
+		expectedCode     apperrors.ErrCode
+		validateResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "正常系: 半径指定ありで周辺店舗を取得",
+			query: "?near=34.6916,135.1925&radius=2000",
+			setupMock: func() *MockShopService {
+				mockService := new(MockShopService)
+				mockService.On("GetNearbyShops", mock.Anything, 34.6916, 135.1925, 2000.0, "ja").Return([]models.NearbyShopResponse{
+					{ShopID: 3, Name: "三宮ベーカリーカフェ", Latitude: 34.6916, Longitude: 135.1925, DistanceMeters: 0},
+					{ShopID: 5, Name: "カフェ・ド・異人館", Latitude: 34.7010, Longitude: 135.1905, DistanceMeters: 1061.4},
+				}, nil)
+				return mockService
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var response []models.NearbyShopResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Len(t, response, 2)
+				assert.Equal(t, 3, response[0].ShopID)
+				assert.Equal(t, 1061.4, response[1].DistanceMeters)
+			},
+		},
+		{
+			name:  "正常系: 半径省略時は既定値1000m",
+			query: "?near=34.7256,135.2352",
+			setupMock: func() *MockShopService {
+				mockService := new(MockShopService)
+				mockService.On("GetNearbyShops", mock.Anything, 34.7256, 135.2352, 1000.0, "ja").Return([]models.NearbyShopResponse{}, nil)
+				return mockService
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "異常系: nearが未指定",
+			query: "",
+			setupMock: func() *MockShopService {
+				return new(MockShopService)
+			},
+			expectError:  true,
+			expectedCode: apperrors.BadParam,
+		},
+		{
+			name:  "異常系: nearの形式が不正",
+			query: "?near=34.7256",
+			setupMock: func() *MockShopService {
+				return new(MockShopService)
+			},
+			expectError:  true,
+			expectedCode: apperrors.BadParam,
+		},
+		{
+			name:  "異常系: 緯度が範囲外",
+			query: "?near=91,135.2352",
+			setupMock: func() *MockShopService {
+				return new(MockShopService)
+			},
+			expectError:  true,
+			expectedCode: apperrors.BadParam,
+		},
+		{
+			name:  "異常系: 半径が上限を超える",
+			query: "?near=34.7256,135.2352&radius=100000",
+			setupMock: func() *MockShopService {
+				return new(MockShopService)
+			},
+			expectError:  true,
+			expectedCode: apperrors.BadParam,
+		},
+		{
+			name:  "異常系: サービス層でエラー発生",
+			query: "?near=34.7256,135.2352",
+			setupMock: func() *MockShopService {
+				mockService := new(MockShopService)
+				mockService.On("GetNearbyShops", mock.Anything, 34.7256, 135.2352, 1000.0, "ja").Return([]models.NearbyShopResponse{}, apperrors.GetDataFailed.Wrap(nil, "データベースエラー"))
+				return mockService
+			},
+			expectError:  true,
+			expectedCode: apperrors.GetDataFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewShopController(mockService)
+
+			c, rec := createTestContextForOrder(http.MethodGet, "/shops"+tt.query, "", nil, nil)
+
+			err := controller.GetNearbyShopsHandler(c)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+				if tt.validateResponse != nil {
+					tt.validateResponse(t, rec)
+				}
+			}
+		})
+	}
+}
+
+// TestShopController_UpdateShopCoordinatesHandler のテストケース
+func TestShopController_UpdateShopCoordinatesHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		shopID         string
+		requestBody    string
+		setupMock      func() *MockShopService
+		setupToken     func() *jwt.Token
+		expectedStatus int
+		expectError    bool
+		expectedCode   apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: 位置情報の更新成功",
+			shopID:      "1",
+			requestBody: `{"latitude":34.7256,"longitude":135.2352}`,
+			setupMock: func() *MockShopService {
+				mockService := new(MockShopService)
+				mockService.On("UpdateShopCoordinates", mock.Anything, 1, 34.7256, 135.2352).Return(nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "正常系: 緯度経度が0でも更新できる",
+			shopID:      "1",
+			requestBody: `{"latitude":0,"longitude":0}`,
+			setupMock: func() *MockShopService {
+				mockService := new(MockShopService)
+				mockService.On("UpdateShopCoordinates", mock.Anything, 1, 0.0, 0.0).Return(nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "異常系: 経度が未指定",
+			shopID:      "1",
+			requestBody: `{"latitude":34.7256}`,
+			setupMock: func() *MockShopService {
+				return new(MockShopService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 経度が範囲外",
+			shopID:      "1",
+			requestBody: `{"latitude":34.7256,"longitude":181}`,
+			setupMock: func() *MockShopService {
+				return new(MockShopService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 他店舗の管理者",
+			shopID:      "1",
+			requestBody: `{"latitude":34.7256,"longitude":135.2352}`,
+			setupMock: func() *MockShopService {
+				return new(MockShopService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 2
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.Forbidden,
+		},
+		{
+			name:        "異常系: 店舗IDの形式が不正",
+			shopID:      "invalid",
+			requestBody: `{"latitude":34.7256,"longitude":135.2352}`,
+			setupMock: func() *MockShopService {
+				return new(MockShopService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.BadParam,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewShopController(mockService)
+
+			c, rec := createTestContextForOrder(
+				http.MethodPatch,
+				"/admin/shops/"+tt.shopID+"/coordinates",
+				tt.requestBody,
+				map[string]string{"shop_id": tt.shopID},
+				tt.setupToken(),
+			)
+
+			err := controller.UpdateShopCoordinatesHandler(c)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+// TestShopController_UpdateDefaultPrepTimeHandler のテストケース
+func TestShopController_UpdateDefaultPrepTimeHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		shopID         string
+		requestBody    string
+		setupMock      func() *MockShopService
+		setupToken     func() *jwt.Token
+		expectedStatus int
+		expectError    bool
+		expectedCode   apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: 既定調理時間の更新成功",
+			shopID:      "1",
+			requestBody: `{"default_prep_seconds":420}`,
+			setupMock: func() *MockShopService {
+				mockService := new(MockShopService)
+				mockService.On("UpdateDefaultPrepTime", mock.Anything, 1, 420).Return(nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "異常系: 既定調理時間が未指定",
+			shopID:      "1",
+			requestBody: `{}`,
+			setupMock: func() *MockShopService {
+				return new(MockShopService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 他店舗の管理者",
+			shopID:      "1",
+			requestBody: `{"default_prep_seconds":420}`,
+			setupMock: func() *MockShopService {
+				return new(MockShopService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 2
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.Forbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewShopController(mockService)
+
+			c, rec := createTestContextForOrder(
+				http.MethodPatch,
+				"/admin/shops/"+tt.shopID+"/prep-time",
+				tt.requestBody,
+				map[string]string{"shop_id": tt.shopID},
+				tt.setupToken(),
+			)
+
+			err := controller.UpdateDefaultPrepTimeHandler(c)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestShopController_UpdateInvoiceRegistrationNumberHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		shopID         string
+		requestBody    string
+		setupMock      func() *MockShopService
+		setupToken     func() *jwt.Token
+		expectedStatus int
+		expectError    bool
+		expectedCode   apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: 登録番号の更新成功",
+			shopID:      "1",
+			requestBody: `{"invoice_registration_number":"T1234567890123"}`,
+			setupMock: func() *MockShopService {
+				mockService := new(MockShopService)
+				mockService.On("UpdateInvoiceRegistrationNumber", mock.Anything, 1, stringPtr("T1234567890123")).Return(nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "正常系: nullで登録番号を削除",
+			shopID:      "1",
+			requestBody: `{"invoice_registration_number":null}`,
+			setupMock: func() *MockShopService {
+				mockService := new(MockShopService)
+				mockService.On("UpdateInvoiceRegistrationNumber", mock.Anything, 1, (*string)(nil)).Return(nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "異常系: 登録番号の桁数が不正",
+			shopID:      "1",
+			requestBody: `{"invoice_registration_number":"T123"}`,
+			setupMock: func() *MockShopService {
+				return new(MockShopService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 他店舗の管理者",
+			shopID:      "1",
+			requestBody: `{"invoice_registration_number":"T1234567890123"}`,
+			setupMock: func() *MockShopService {
+				return new(MockShopService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 2
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.Forbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewShopController(mockService)
+
+			c, rec := createTestContextForOrder(
+				http.MethodPatch,
+				"/admin/shops/"+tt.shopID+"/invoice-registration-number",
+				tt.requestBody,
+				map[string]string{"shop_id": tt.shopID},
+				tt.setupToken(),
+			)
+
+			err := controller.UpdateInvoiceRegistrationNumberHandler(c)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestShopController_UpdatePointRateHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		shopID         string
+		requestBody    string
+		setupMock      func() *MockShopService
+		setupToken     func() *jwt.Token
+		expectedStatus int
+		expectError    bool
+		expectedCode   apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: 還元率の更新成功",
+			shopID:      "1",
+			requestBody: `{"point_rate":2}`,
+			setupMock: func() *MockShopService {
+				mockService := new(MockShopService)
+				mockService.On("UpdatePointRate", mock.Anything, 1, 2).Return(nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "正常系: 0でポイントの付与を止める",
+			shopID:      "1",
+			requestBody: `{"point_rate":0}`,
+			setupMock: func() *MockShopService {
+				mockService := new(MockShopService)
+				mockService.On("UpdatePointRate", mock.Anything, 1, 0).Return(nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "異常系: 還元率の指定がない",
+			shopID:      "1",
+			requestBody: `{}`,
+			setupMock: func() *MockShopService {
+				return new(MockShopService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 還元率が100を超える",
+			shopID:      "1",
+			requestBody: `{"point_rate":101}`,
+			setupMock: func() *MockShopService {
+				return new(MockShopService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 他店舗の管理者",
+			shopID:      "1",
+			requestBody: `{"point_rate":2}`,
+			setupMock: func() *MockShopService {
+				return new(MockShopService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 2
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.Forbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewShopController(mockService)
+
+			c, rec := createTestContextForOrder(
+				http.MethodPatch,
+				"/admin/shops/"+tt.shopID+"/point-rate",
+				tt.requestBody,
+				map[string]string{"shop_id": tt.shopID},
+				tt.setupToken(),
+			)
+
+			err := controller.UpdatePointRateHandler(c)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+// TestShopController_UpdateTimeZoneHandler のテストケース
+func TestShopController_UpdateTimeZoneHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func() *MockShopService
+		setupToken     func() *jwt.Token
+		expectedStatus int
+		expectError    bool
+		expectedCode   apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: タイムゾーンの更新成功",
+			requestBody: `{"time_zone":"America/New_York"}`,
+			setupMock: func() *MockShopService {
+				mockService := new(MockShopService)
+				mockService.On("UpdateTimeZone", mock.Anything, 1, "America/New_York").Return(nil)
+				return mockService
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "異常系: 存在しないタイムゾーン",
+			requestBody: `{"time_zone":"Tokyo"}`,
+			setupMock: func() *MockShopService {
+				return new(MockShopService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 1
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:        "異常系: 他店舗の管理者",
+			requestBody: `{"time_zone":"Asia/Tokyo"}`,
+			setupMock: func() *MockShopService {
+				return new(MockShopService)
+			},
+			setupToken: func() *jwt.Token {
+				adminShopID := 2
+				return createTestToken(1, models.AdminRole, &adminShopID)
+			},
+			expectError:  true,
+			expectedCode: apperrors.Forbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := tt.setupMock()
+			defer mockService.AssertExpectations(t)
+
+			controller := controllers.NewShopController(mockService)
+
+			c, rec := createTestContextForOrder(
+				http.MethodPatch,
+				"/admin/shops/1/time-zone",
+				tt.requestBody,
+				map[string]string{"shop_id": "1"},
+				tt.setupToken(),
+			)
+
+			err := controller.UpdateTimeZoneHandler(c)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				if appErr, ok := err.(*apperrors.AppError); ok {
+					assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/A4-dev-team/mobileorder.git/validators"
+	"github.com/labstack/echo/v4"
+)
+
+type TranslationController interface {
+	UpsertItemTranslationHandler(ctx echo.Context) error
+	DeleteItemTranslationHandler(ctx echo.Context) error
+	UpsertCategoryTranslationHandler(ctx echo.Context) error
+	DeleteCategoryTranslationHandler(ctx echo.Context) error
+	UpsertShopTranslationHandler(ctx echo.Context) error
+	DeleteShopTranslationHandler(ctx echo.Context) error
+}
+
+type translationController struct {
+	s services.TranslationServicer
+}
+
+func NewTranslationController(s services.TranslationServicer) TranslationController {
+	return &translationController{s}
+}
+
+// UpsertItemTranslationHandler は商品名と説明の翻訳を登録・更新します。
+// @Summary      商品の翻訳を登録 (Admin)
+// @Description  担当店舗の商品の商品名と説明の翻訳を登録します。同じ言語の翻訳があれば置き換えます。descriptionを省略すると説明は日本語のまま表示します。商品の情報なので、同じ商品を扱う全店舗に反映されます。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        item_id path int    true "商品ID"
+// @Param        lang    path string true "翻訳の言語" Enums(en, zh, ko)
+// @Param        request body models.ItemTranslationRequest true "商品の翻訳"
+// @Success      200 {object} models.ItemTranslation "登録した翻訳"
+// @Failure      400 {object} map[string]string "リクエストが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "店舗に紐づいていない管理者アカウントです"
+// @Failure      404 {object} map[string]string "商品が見つからないか、この店舗の商品ではありません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/items/{item_id}/translations/{lang} [put]
+func (c *translationController) UpsertItemTranslationHandler(ctx echo.Context) error {
+	adminShopID, itemID, lang, err := bindItemTranslationParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	var req models.ItemTranslationRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.ItemTranslationRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	res, err := c.s.UpsertItemTranslation(ctx.Request().Context(), adminShopID, itemID, lang, req)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// DeleteItemTranslationHandler は商品の翻訳を削除します。
+// @Summary      商品の翻訳を削除 (Admin)
+// @Description  担当店舗の商品の指定した言語の翻訳を削除します。削除後は日本語で表示します。
+// @Tags         管理者 (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        item_id path int    true "商品ID"
+// @Param        lang    path string true "翻訳の言語" Enums(en, zh, ko)
+// @Success      200 {object} map[string]string "成功メッセージ"
+// @Failure      400 {object} map[string]string "商品IDか言語が不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "店舗に紐づいていない管理者アカウントです"
+// @Failure      404 {object} map[string]string "翻訳が見つかりません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/items/{item_id}/translations/{lang} [delete]
+func (c *translationController) DeleteItemTranslationHandler(ctx echo.Context) error {
+	adminShopID, itemID, lang, err := bindItemTranslationParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.s.DeleteItemTranslation(ctx.Request().Context(), adminShopID, itemID, lang); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "商品の翻訳を削除しました。"})
+}
+
+// bindItemTranslationParams は管理者の店舗と、パスパラメータの商品IDと言語を取り出します
+func bindItemTranslationParams(ctx echo.Context) (int, int, string, error) {
+	claims, err := GetClaims(ctx)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	if claims.ShopID == nil {
+		return 0, 0, "", apperrors.Forbidden.WrapMessage(nil, apperrors.MsgAdminWithoutShop, nil)
+	}
+	itemID, err := strconv.Atoi(ctx.Param("item_id"))
+	if err != nil {
+		return 0, 0, "", apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidItemID, nil)
+	}
+	lang, err := translationLanguage(ctx)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	return *claims.ShopID, itemID, lang, nil
+}
+
+// UpsertCategoryTranslationHandler はカテゴリ名の翻訳を登録・更新します。
+// @Summary      カテゴリの翻訳を登録 (Admin)
+// @Description  店舗のカテゴリ名の翻訳を登録します。同じ言語の翻訳があれば置き換えます。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id     path int    true "店舗ID"
+// @Param        category_id path int    true "カテゴリID"
+// @Param        lang        path string true "翻訳の言語" Enums(en, zh, ko)
+// @Param        request body models.CategoryTranslationRequest true "カテゴリの翻訳"
+// @Success      200 {object} models.CategoryTranslation "登録した翻訳"
+// @Failure      400 {object} map[string]string "リクエストが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      404 {object} map[string]string "カテゴリが見つかりません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/categories/{category_id}/translations/{lang} [put]
+func (c *translationController) UpsertCategoryTranslationHandler(ctx echo.Context) error {
+	targetShopID, categoryID, lang, err := bindCategoryTranslationParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	var req models.CategoryTranslationRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.CategoryTranslationRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	res, err := c.s.UpsertCategoryTranslation(ctx.Request().Context(), targetShopID, categoryID, lang, req)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// DeleteCategoryTranslationHandler はカテゴリ名の翻訳を削除します。
+// @Summary      カテゴリの翻訳を削除 (Admin)
+// @Description  店舗のカテゴリ名の指定した言語の翻訳を削除します。削除後は日本語で表示します。
+// @Tags         管理者 (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id     path int    true "店舗ID"
+// @Param        category_id path int    true "カテゴリID"
+// @Param        lang        path string true "翻訳の言語" Enums(en, zh, ko)
+// @Success      200 {object} map[string]string "成功メッセージ"
+// @Failure      400 {object} map[string]string "IDか言語が不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      404 {object} map[string]string "翻訳が見つかりません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/categories/{category_id}/translations/{lang} [delete]
+func (c *translationController) DeleteCategoryTranslationHandler(ctx echo.Context) error {
+	targetShopID, categoryID, lang, err := bindCategoryTranslationParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.s.DeleteCategoryTranslation(ctx.Request().Context(), targetShopID, categoryID, lang); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "カテゴリの翻訳を削除しました。"})
+}
+
+// bindCategoryTranslationParams は管理者が担当する店舗と、パスパラメータのカテゴリIDと言語を取り出します
+func bindCategoryTranslationParams(ctx echo.Context) (int, int, string, error) {
+	targetShopID, err := authorizeMenuShop(ctx)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	categoryID, err := strconv.Atoi(ctx.Param("category_id"))
+	if err != nil {
+		return 0, 0, "", apperrors.BadParam.WrapMessage(err, apperrors.MsgInvalidCategoryID, nil)
+	}
+	lang, err := translationLanguage(ctx)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	return targetShopID, categoryID, lang, nil
+}
+
+// UpsertShopTranslationHandler は店舗名と説明の翻訳を登録・更新します。
+// @Summary      店舗の翻訳を登録 (Admin)
+// @Description  店舗名と説明の翻訳を登録します。同じ言語の翻訳があれば置き換えます。descriptionを省略すると説明は日本語のまま表示します。店舗の検索と注文一覧に反映されます。
+// @Tags         管理者 (Admin)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id path int    true "店舗ID"
+// @Param        lang    path string true "翻訳の言語" Enums(en, zh, ko)
+// @Param        request body models.ShopTranslationRequest true "店舗の翻訳"
+// @Success      200 {object} models.ShopTranslation "登録した翻訳"
+// @Failure      400 {object} map[string]string "リクエストが不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      404 {object} map[string]string "店舗が見つかりません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/translations/{lang} [put]
+func (c *translationController) UpsertShopTranslationHandler(ctx echo.Context) error {
+	targetShopID, err := authorizeMenuShop(ctx)
+	if err != nil {
+		return err
+	}
+	lang, err := translationLanguage(ctx)
+	if err != nil {
+		return err
+	}
+
+	var req models.ShopTranslationRequest
+	if err := ctx.Bind(&req); err != nil {
+		return apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidRequestBody, nil)
+	}
+	validator := validators.NewValidator[models.ShopTranslationRequest]()
+	if err := validator.Validate(req); err != nil {
+		return apperrors.ValidationFailed.WrapFields(err, validators.FieldErrors(err))
+	}
+
+	res, err := c.s.UpsertShopTranslation(ctx.Request().Context(), targetShopID, lang, req)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// DeleteShopTranslationHandler は店舗名と説明の翻訳を削除します。
+// @Summary      店舗の翻訳を削除 (Admin)
+// @Description  店舗名と説明の指定した言語の翻訳を削除します。削除後は日本語で表示します。
+// @Tags         管理者 (Admin)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shop_id path int    true "店舗ID"
+// @Param        lang    path string true "翻訳の言語" Enums(en, zh, ko)
+// @Success      200 {object} map[string]string "成功メッセージ"
+// @Failure      400 {object} map[string]string "店舗IDか言語が不正です"
+// @Failure      401 {object} map[string]string "認証に失敗しました"
+// @Failure      403 {object} map[string]string "この店舗へのアクセス権がありません"
+// @Failure      404 {object} map[string]string "翻訳が見つかりません"
+// @Failure      500 {object} map[string]string "サーバー内部でエラーが発生しました"
+// @Router       /admin/shops/{shop_id}/translations/{lang} [delete]
+func (c *translationController) DeleteShopTranslationHandler(ctx echo.Context) error {
+	targetShopID, err := authorizeMenuShop(ctx)
+	if err != nil {
+		return err
+	}
+	lang, err := translationLanguage(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.s.DeleteShopTranslation(ctx.Request().Context(), targetShopID, lang); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "店舗の翻訳を削除しました。"})
+}
@@ -0,0 +1,202 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/controllers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTranslationService はTranslationServicerのモック
+type MockTranslationService struct {
+	mock.Mock
+}
+
+func (m *MockTranslationService) UpsertItemTranslation(ctx context.Context, adminShopID int, itemID int, lang string, req models.ItemTranslationRequest) (*models.ItemTranslation, error) {
+	args := m.Called(ctx, adminShopID, itemID, lang, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ItemTranslation), args.Error(1)
+}
+
+func (m *MockTranslationService) DeleteItemTranslation(ctx context.Context, adminShopID int, itemID int, lang string) error {
+	args := m.Called(ctx, adminShopID, itemID, lang)
+	return args.Error(0)
+}
+
+func (m *MockTranslationService) UpsertCategoryTranslation(ctx context.Context, shopID int, categoryID int, lang string, req models.CategoryTranslationRequest) (*models.CategoryTranslation, error) {
+	args := m.Called(ctx, shopID, categoryID, lang, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CategoryTranslation), args.Error(1)
+}
+
+func (m *MockTranslationService) DeleteCategoryTranslation(ctx context.Context, shopID int, categoryID int, lang string) error {
+	args := m.Called(ctx, shopID, categoryID, lang)
+	return args.Error(0)
+}
+
+func (m *MockTranslationService) UpsertShopTranslation(ctx context.Context, shopID int, lang string, req models.ShopTranslationRequest) (*models.ShopTranslation, error) {
+	args := m.Called(ctx, shopID, lang, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ShopTranslation), args.Error(1)
+}
+
+func (m *MockTranslationService) DeleteShopTranslation(ctx context.Context, shopID int, lang string) error {
+	args := m.Called(ctx, shopID, lang)
+	return args.Error(0)
+}
+
+func TestTranslationController_UpsertItemTranslationHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		lang         string
+		requestBody  string
+		shopID       *int
+		setupMock    func(*MockTranslationService)
+		expectedCode apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: 英語の商品名と説明",
+			lang:        "en",
+			requestBody: `{"item_name":"Special Tonkotsu Ramen","description":"Rich pork bone broth"}`,
+			shopID:      intPtr(2),
+			setupMock: func(m *MockTranslationService) {
+				req := models.ItemTranslationRequest{ItemName: "Special Tonkotsu Ramen", Description: stringPtr("Rich pork bone broth")}
+				res := &models.ItemTranslation{ItemID: 5, Lang: "en", ItemName: req.ItemName, Description: req.Description}
+				m.On("UpsertItemTranslation", mock.Anything, 2, 5, "en", req).Return(res, nil)
+			},
+		},
+		{
+			name:         "異常系: 日本語は翻訳として登録できない",
+			lang:         "ja",
+			requestBody:  `{"item_name":"特製豚骨ラーメン"}`,
+			shopID:       intPtr(2),
+			expectedCode: apperrors.BadParam,
+		},
+		{
+			name:         "異常系: 対応していない言語",
+			lang:         "fr",
+			requestBody:  `{"item_name":"Ramen"}`,
+			shopID:       intPtr(2),
+			expectedCode: apperrors.BadParam,
+		},
+		{
+			name:         "異常系: 商品名がない",
+			lang:         "en",
+			requestBody:  `{"description":"Rich pork bone broth"}`,
+			shopID:       intPtr(2),
+			expectedCode: apperrors.ValidationFailed,
+		},
+		{
+			name:         "異常系: 店舗に紐づいていない管理者",
+			lang:         "en",
+			requestBody:  `{"item_name":"Ramen"}`,
+			expectedCode: apperrors.Forbidden,
+		},
+		{
+			name:        "異常系: 店舗の商品ではない",
+			lang:        "zh",
+			requestBody: `{"item_name":"拉面"}`,
+			shopID:      intPtr(2),
+			setupMock: func(m *MockTranslationService) {
+				m.On("UpsertItemTranslation", mock.Anything, 2, 5, "zh", models.ItemTranslationRequest{ItemName: "拉面"}).
+					Return(nil, apperrors.NoData.Wrap(nil, "指定された商品が見つからないか、この店舗の商品ではありません。"))
+			},
+			expectedCode: apperrors.NoData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockTranslationService)
+			defer mockService.AssertExpectations(t)
+			if tt.setupMock != nil {
+				tt.setupMock(mockService)
+			}
+
+			controller := controllers.NewTranslationController(mockService)
+			params := map[string]string{"item_id": "5", "lang": tt.lang}
+			c, rec := createTestContextForOrder(http.MethodPut, "/admin/items/5/translations/"+tt.lang, tt.requestBody, params, createTestToken(1, models.AdminRole, tt.shopID))
+
+			err := controller.UpsertItemTranslationHandler(c)
+			if tt.expectedCode != "" {
+				var appErr *apperrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedCode, appErr.ErrCode)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var res models.ItemTranslation
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, "Special Tonkotsu Ramen", res.ItemName)
+		})
+	}
+}
+
+func TestTranslationController_CategoryAndShopTranslationHandlers(t *testing.T) {
+	t.Run("正常系: カテゴリ名の翻訳を登録", func(t *testing.T) {
+		mockService := new(MockTranslationService)
+		defer mockService.AssertExpectations(t)
+		req := models.CategoryTranslationRequest{Name: "Ramen"}
+		mockService.On("UpsertCategoryTranslation", mock.Anything, 2, 3, "en", req).
+			Return(&models.CategoryTranslation{CategoryID: 3, Lang: "en", Name: "Ramen"}, nil)
+
+		params := map[string]string{"shop_id": "2", "category_id": "3", "lang": "en"}
+		c, rec := createTestContextForOrder(http.MethodPut, "/admin/shops/2/categories/3/translations/en", `{"name":"Ramen"}`, params, createTestToken(1, models.AdminRole, intPtr(2)))
+
+		err := controllers.NewTranslationController(mockService).UpsertCategoryTranslationHandler(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("異常系: 担当していない店舗のカテゴリ", func(t *testing.T) {
+		mockService := new(MockTranslationService)
+		params := map[string]string{"shop_id": "1", "category_id": "3", "lang": "en"}
+		c, _ := createTestContextForOrder(http.MethodDelete, "/admin/shops/1/categories/3/translations/en", "", params, createTestToken(1, models.AdminRole, intPtr(2)))
+
+		err := controllers.NewTranslationController(mockService).DeleteCategoryTranslationHandler(c)
+		var appErr *apperrors.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.Forbidden, appErr.ErrCode)
+		mockService.AssertNotCalled(t, "DeleteCategoryTranslation")
+	})
+
+	t.Run("正常系: 店舗の翻訳を削除", func(t *testing.T) {
+		mockService := new(MockTranslationService)
+		defer mockService.AssertExpectations(t)
+		mockService.On("DeleteShopTranslation", mock.Anything, 2, "ko").Return(nil)
+
+		params := map[string]string{"shop_id": "2", "lang": "ko"}
+		c, rec := createTestContextForOrder(http.MethodDelete, "/admin/shops/2/translations/ko", "", params, createTestToken(1, models.AdminRole, intPtr(2)))
+
+		err := controllers.NewTranslationController(mockService).DeleteShopTranslationHandler(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("異常系: 店舗名の長さの上限を超える", func(t *testing.T) {
+		mockService := new(MockTranslationService)
+		params := map[string]string{"shop_id": "2", "lang": "en"}
+		body := `{"name":"` + strings.Repeat("a", 256) + `"}`
+		c, _ := createTestContextForOrder(http.MethodPut, "/admin/shops/2/translations/en", body, params, createTestToken(1, models.AdminRole, intPtr(2)))
+
+		err := controllers.NewTranslationController(mockService).UpsertShopTranslationHandler(c)
+		var appErr *apperrors.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.ValidationFailed, appErr.ErrCode)
+		mockService.AssertNotCalled(t, "UpsertShopTranslation")
+	})
+}
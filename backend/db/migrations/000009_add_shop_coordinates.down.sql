@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_shops_latitude_longitude;
+
+ALTER TABLE shops
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude;
@@ -0,0 +1,6 @@
+ALTER TABLE shops
+    ADD COLUMN latitude DOUBLE PRECISION NULL CHECK (latitude BETWEEN -90 AND 90),
+    ADD COLUMN longitude DOUBLE PRECISION NULL CHECK (longitude BETWEEN -180 AND 180);
+
+-- 距離検索のバウンディングボックス絞り込み用
+CREATE INDEX idx_shops_latitude_longitude ON shops (latitude, longitude);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_orders_shop_completed_at;
+DROP INDEX IF EXISTS idx_orders_shop_status_order_date;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS completed_at;
+ALTER TABLE shop_item DROP COLUMN IF EXISTS prep_seconds;
+ALTER TABLE shops DROP COLUMN IF EXISTS default_prep_seconds;
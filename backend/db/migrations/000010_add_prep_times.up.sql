@@ -0,0 +1,15 @@
+-- 店舗ごとの既定調理時間（秒）。商品に個別設定がない場合に使う
+ALTER TABLE shops
+    ADD COLUMN default_prep_seconds INTEGER NOT NULL DEFAULT 300 CHECK (default_prep_seconds > 0);
+
+-- 店舗の商品ごとの調理時間（秒）。NULLの場合は店舗の既定値を使う。
+-- 他の店舗で同じ商品を扱っていても、店舗の管理者が自分の店舗の値だけを変えられるよう shop_item に持つ
+ALTER TABLE shop_item
+    ADD COLUMN prep_seconds INTEGER NULL CHECK (prep_seconds > 0);
+
+-- 調理完了日時。実績の調理時間から受け取り予定時刻の推定を補正するために使う
+ALTER TABLE orders
+    ADD COLUMN completed_at TIMESTAMP NULL;
+
+CREATE INDEX idx_orders_shop_status_order_date ON orders (shop_id, status, order_date);
+CREATE INDEX idx_orders_shop_completed_at ON orders (shop_id, completed_at) WHERE completed_at IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE shop_item DROP COLUMN IF EXISTS stock_quantity;
@@ -0,0 +1,3 @@
+-- 店舗ごとの在庫数。NULLの場合は在庫数を管理しない（is_availableのみで販売可否を判断）
+ALTER TABLE shop_item
+    ADD COLUMN stock_quantity INTEGER NULL CHECK (stock_quantity >= 0);
@@ -0,0 +1,5 @@
+ALTER TABLE order_item DROP COLUMN IF EXISTS modifier_price_delta;
+
+DROP TABLE IF EXISTS order_item_modifiers;
+DROP TABLE IF EXISTS modifier_options;
+DROP TABLE IF EXISTS modifier_groups;
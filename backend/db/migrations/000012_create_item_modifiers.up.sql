@@ -0,0 +1,71 @@
+-- 商品のオプショングループ（サイズ、トッピング、辛さなど）
+-- 同じ商品を扱う他の店舗のオプションを変えないよう、店舗の商品（shop_item）に紐づける
+-- selection_type: 1 = 単一選択, 2 = 複数選択
+CREATE TABLE modifier_groups (
+    modifier_group_id SERIAL PRIMARY KEY,
+    shop_id INT NOT NULL,
+    item_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    selection_type SMALLINT NOT NULL,
+    min_select INT NOT NULL DEFAULT 0,
+    max_select INT NOT NULL DEFAULT 1,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (shop_id, item_id) REFERENCES shop_item(shop_id, item_id) ON DELETE CASCADE,
+    CHECK (selection_type IN (1, 2)),
+    CHECK (min_select >= 0 AND max_select >= 1 AND min_select <= max_select),
+    CHECK (selection_type = 2 OR max_select = 1)
+);
+
+CREATE INDEX idx_modifier_groups_shop_item ON modifier_groups (shop_id, item_id);
+
+CREATE TRIGGER trigger_update_modifier_groups_updated_at
+BEFORE UPDATE ON modifier_groups
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- オプショングループの選択肢。price_deltaは商品価格への加算額（値引きは負の値）
+CREATE TABLE modifier_options (
+    modifier_option_id SERIAL PRIMARY KEY,
+    modifier_group_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    price_delta INTEGER NOT NULL DEFAULT 0,
+    is_available BOOLEAN NOT NULL DEFAULT TRUE,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (modifier_group_id) REFERENCES modifier_groups(modifier_group_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_modifier_options_group_id ON modifier_options (modifier_group_id);
+
+CREATE TRIGGER trigger_update_modifier_options_updated_at
+BEFORE UPDATE ON modifier_options
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- 注文時に選ばれたオプション。メニュー変更の影響を受けないよう名前と価格を保存する
+CREATE TABLE order_item_modifiers (
+    order_item_modifier_id SERIAL PRIMARY KEY,
+    order_item_id INT NOT NULL,
+    modifier_option_id INT NULL,
+    group_name VARCHAR(255) NOT NULL,
+    option_name VARCHAR(255) NOT NULL,
+    price_delta INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (order_item_id) REFERENCES order_item(order_item_id) ON DELETE CASCADE,
+    FOREIGN KEY (modifier_option_id) REFERENCES modifier_options(modifier_option_id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_order_item_modifiers_order_item_id ON order_item_modifiers (order_item_id);
+
+CREATE TRIGGER trigger_update_order_item_modifiers_updated_at
+BEFORE UPDATE ON order_item_modifiers
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- 1個あたりのオプション加算額の合計。小計は (price_at_order + modifier_price_delta) * quantity
+ALTER TABLE order_item
+    ADD COLUMN modifier_price_delta INTEGER NOT NULL DEFAULT 0;
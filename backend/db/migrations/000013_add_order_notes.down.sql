@@ -0,0 +1,5 @@
+ALTER TABLE order_item DROP COLUMN IF EXISTS note;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS has_allergy,
+    DROP COLUMN IF EXISTS note;
@@ -0,0 +1,8 @@
+-- 注文全体へのお客様からのメモ（アレルギーや箸不要など）とアレルギーの有無
+ALTER TABLE orders
+    ADD COLUMN note VARCHAR(200) NULL,
+    ADD COLUMN has_allergy BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- 商品ごとのメモ
+ALTER TABLE order_item
+    ADD COLUMN note VARCHAR(100) NULL;
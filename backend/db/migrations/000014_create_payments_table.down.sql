@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_orders_pending_payment_order_date;
+
+DROP TRIGGER IF EXISTS trigger_update_payments_updated_at ON payments;
+DROP TABLE IF EXISTS payments;
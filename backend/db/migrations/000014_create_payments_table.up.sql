@@ -0,0 +1,30 @@
+-- 注文に対する決済。status: 1 = 承認待ち, 2 = 与信済み, 3 = 売上確定, 4 = 失敗, 5 = 売上確定中
+-- 決済代行会社への売上確定の依頼はトランザクションの外で行うため、依頼する前に売上確定中にして、同じ決済を二重に確定しないようにする
+-- 注文が削除されても決済の記録は残すため、order_idはON DELETE SET NULLにする
+-- 決済が確定するまでの注文は orders.status = 4 (PendingPayment) になる
+CREATE TABLE payments (
+    payment_id SERIAL PRIMARY KEY,
+    order_id INT NULL,
+    provider VARCHAR(50) NOT NULL,
+    provider_payment_id VARCHAR(255) NULL,
+    amount INTEGER NOT NULL,
+    status SMALLINT NOT NULL DEFAULT 1,
+    failure_reason VARCHAR(255) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE SET NULL,
+    UNIQUE (provider, provider_payment_id),
+    CHECK (amount >= 0),
+    CHECK (status IN (1, 2, 3, 4, 5))
+);
+
+CREATE INDEX idx_payments_order_id ON payments (order_id);
+
+-- 決済が失敗した注文は削除せず、取り消し（orders.status = 5）として残す。
+-- 決済待ちのまま放置された注文を定期的に探すための索引
+CREATE INDEX idx_orders_pending_payment_order_date ON orders (order_date) WHERE status = 4;
+
+CREATE TRIGGER trigger_update_payments_updated_at
+BEFORE UPDATE ON payments
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
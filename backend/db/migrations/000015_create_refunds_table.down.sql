@@ -0,0 +1,9 @@
+ALTER TABLE payments
+    DROP CONSTRAINT IF EXISTS payments_refunded_amount_check,
+    DROP COLUMN IF EXISTS refunded_amount;
+
+DROP TRIGGER IF EXISTS trigger_update_refund_items_updated_at ON refund_items;
+DROP TABLE IF EXISTS refund_items;
+
+DROP TRIGGER IF EXISTS trigger_update_refunds_updated_at ON refunds;
+DROP TABLE IF EXISTS refunds;
@@ -0,0 +1,57 @@
+-- 決済に対する返金。staff_user_idは返金を行った店舗スタッフ。status: 1 = 返金依頼中, 2 = 返金済み
+-- 決済代行会社への返金はトランザクションの外で行うため、依頼する前に返金依頼中として記録し、結果が返ってから返金済みにする。
+-- 結果が分からなかった返金は返金依頼中のまま残し、同じ冪等キーでやり直す
+-- 注文が削除されても返金の記録は残すため、order_idはON DELETE SET NULLにする
+CREATE TABLE refunds (
+    refund_id SERIAL PRIMARY KEY,
+    payment_id INT NOT NULL,
+    order_id INT NULL,
+    amount INTEGER NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    staff_user_id INT NOT NULL,
+    provider_refund_id VARCHAR(255) NULL,
+    status SMALLINT NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (payment_id) REFERENCES payments(payment_id),
+    FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE SET NULL,
+    FOREIGN KEY (staff_user_id) REFERENCES users(user_id),
+    CHECK (amount > 0),
+    CHECK (status IN (1, 2))
+);
+
+CREATE INDEX idx_refunds_order_id ON refunds (order_id);
+CREATE INDEX idx_refunds_payment_id ON refunds (payment_id);
+CREATE INDEX idx_refunds_pending_created_at ON refunds (created_at) WHERE status = 1;
+
+CREATE TRIGGER trigger_update_refunds_updated_at
+BEFORE UPDATE ON refunds
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- 返金した注文商品と数量。金額は (price_at_order + modifier_price_delta) * quantity
+CREATE TABLE refund_items (
+    refund_item_id SERIAL PRIMARY KEY,
+    refund_id INT NOT NULL,
+    order_item_id INT NULL,
+    quantity INT NOT NULL,
+    amount INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (refund_id) REFERENCES refunds(refund_id) ON DELETE CASCADE,
+    FOREIGN KEY (order_item_id) REFERENCES order_item(order_item_id) ON DELETE SET NULL,
+    CHECK (quantity > 0),
+    CHECK (amount >= 0)
+);
+
+CREATE INDEX idx_refund_items_order_item_id ON refund_items (order_item_id);
+
+CREATE TRIGGER trigger_update_refund_items_updated_at
+BEFORE UPDATE ON refund_items
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- 返金済みの合計額。支払額を超えないようにする
+ALTER TABLE payments
+    ADD COLUMN refunded_amount INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT payments_refunded_amount_check CHECK (refunded_amount >= 0 AND refunded_amount <= amount);
@@ -0,0 +1,14 @@
+DROP TABLE IF EXISTS order_tax_lines;
+
+ALTER TABLE order_item
+    DROP COLUMN IF EXISTS tax_rate;
+
+ALTER TABLE orders
+    DROP CONSTRAINT IF EXISTS orders_dining_option_check,
+    DROP COLUMN IF EXISTS tax_amount,
+    DROP COLUMN IF EXISTS subtotal_amount,
+    DROP COLUMN IF EXISTS dining_option;
+
+ALTER TABLE items
+    DROP CONSTRAINT IF EXISTS items_tax_category_check,
+    DROP COLUMN IF EXISTS tax_category;
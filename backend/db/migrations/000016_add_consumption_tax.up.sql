@@ -0,0 +1,29 @@
+-- 商品の消費税区分。1: 飲食料品（持ち帰りは軽減税率）、2: 酒類など（常に標準税率）
+-- 商品の価格は税込として扱う（総額表示）。消費税は税率ごとの税込金額から割り戻して計算する
+ALTER TABLE items
+    ADD COLUMN tax_category SMALLINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT items_tax_category_check CHECK (tax_category IN (1, 2));
+
+-- 飲食区分（1: 持ち帰り、2: 店内飲食）と、税込の小計・合計に含まれる消費税額。total_amountは税込の小計と同じ
+ALTER TABLE orders
+    ADD COLUMN dining_option SMALLINT NOT NULL DEFAULT 1,
+    ADD COLUMN subtotal_amount INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN tax_amount INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT orders_dining_option_check CHECK (dining_option IN (1, 2));
+
+-- 税を計算する前の注文は、合計額をそのまま小計として扱う
+UPDATE orders SET subtotal_amount = total_amount;
+
+-- 注文時に適用した消費税率（%）。税を計算する前の注文は0
+ALTER TABLE order_item
+    ADD COLUMN tax_rate SMALLINT NOT NULL DEFAULT 0;
+
+-- 税率ごとの税込の小計と含まれる消費税額。端数は税率ごとに1回だけ処理する
+CREATE TABLE order_tax_lines (
+    order_id INT NOT NULL,
+    tax_rate SMALLINT NOT NULL,
+    taxable_amount INTEGER NOT NULL,
+    tax_amount INTEGER NOT NULL,
+    PRIMARY KEY (order_id, tax_rate),
+    FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE CASCADE
+);
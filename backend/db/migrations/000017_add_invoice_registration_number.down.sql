@@ -0,0 +1,3 @@
+ALTER TABLE shops
+    DROP CONSTRAINT IF EXISTS shops_invoice_registration_number_check,
+    DROP COLUMN IF EXISTS invoice_registration_number;
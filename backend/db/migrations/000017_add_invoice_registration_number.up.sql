@@ -0,0 +1,4 @@
+-- 適格請求書発行事業者の登録番号（T + 13桁の数字）。未登録の店舗ではNULL
+ALTER TABLE shops
+    ADD COLUMN invoice_registration_number VARCHAR(14),
+    ADD CONSTRAINT shops_invoice_registration_number_check CHECK (invoice_registration_number ~ '^T[0-9]{13}$');
@@ -0,0 +1,10 @@
+ALTER TABLE orders
+    DROP CONSTRAINT IF EXISTS orders_discount_amount_check,
+    DROP COLUMN IF EXISTS discount_amount;
+
+DROP TABLE IF EXISTS order_discounts;
+DROP TABLE IF EXISTS promotion_redemptions;
+DROP TABLE IF EXISTS promotion_items;
+
+DROP TRIGGER IF EXISTS trigger_update_promotions_updated_at ON promotions;
+DROP TABLE IF EXISTS promotions;
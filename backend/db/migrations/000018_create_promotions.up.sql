@@ -0,0 +1,85 @@
+-- クーポン（プロモーションコード）。shop_idがNULLのものは全店舗で使える
+-- discount_type 1: 割引率（%）、2: 値引き額（円）。金額はすべて税込
+CREATE TABLE promotions (
+    promotion_id SERIAL PRIMARY KEY,
+    shop_id INT NULL,
+    code VARCHAR(32) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    discount_type SMALLINT NOT NULL,
+    discount_value INTEGER NOT NULL,
+    min_spend INTEGER NOT NULL DEFAULT 0,
+    min_item_quantity INTEGER NOT NULL DEFAULT 0,
+    max_discounted_units INTEGER NULL,
+    starts_at TIMESTAMP NULL,
+    ends_at TIMESTAMP NULL,
+    usage_limit_total INTEGER NULL,
+    usage_limit_per_user INTEGER NULL,
+    used_count INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (shop_id) REFERENCES shops(shop_id) ON DELETE CASCADE,
+    CONSTRAINT promotions_discount_check CHECK (
+        (discount_type = 1 AND discount_value BETWEEN 1 AND 100) OR
+        (discount_type = 2 AND discount_value > 0)
+    ),
+    CHECK (min_spend >= 0),
+    CHECK (min_item_quantity >= 0),
+    CHECK (max_discounted_units IS NULL OR max_discounted_units > 0),
+    CHECK (starts_at IS NULL OR ends_at IS NULL OR starts_at < ends_at),
+    CHECK (usage_limit_per_user IS NULL OR usage_limit_per_user > 0),
+    -- 利用回数は上限を超えられない。同時に使われた場合も条件付きのUPDATEで弾く
+    CONSTRAINT promotions_used_count_check CHECK (used_count >= 0 AND (usage_limit_total IS NULL OR used_count <= usage_limit_total))
+);
+
+-- コードは大文字小文字を区別せず、店舗ごと（全店舗共通は1つのグループとして）に一意
+CREATE UNIQUE INDEX idx_promotions_shop_code ON promotions (COALESCE(shop_id, 0), UPPER(code));
+
+CREATE TRIGGER trigger_update_promotions_updated_at
+BEFORE UPDATE ON promotions
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- クーポンの対象商品。登録がないクーポンはすべての商品が対象
+CREATE TABLE promotion_items (
+    promotion_id INT NOT NULL,
+    item_id INT NOT NULL,
+    PRIMARY KEY (promotion_id, item_id),
+    FOREIGN KEY (promotion_id) REFERENCES promotions(promotion_id) ON DELETE CASCADE,
+    FOREIGN KEY (item_id) REFERENCES items(item_id) ON DELETE CASCADE
+);
+
+-- クーポンの利用記録。1つの注文に使えるクーポンは1つ
+CREATE TABLE promotion_redemptions (
+    redemption_id SERIAL PRIMARY KEY,
+    promotion_id INT NOT NULL,
+    order_id INT NOT NULL UNIQUE,
+    user_id INT NULL,
+    discount_amount INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (promotion_id) REFERENCES promotions(promotion_id) ON DELETE CASCADE,
+    FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE SET NULL,
+    CHECK (discount_amount > 0)
+);
+
+CREATE INDEX idx_promotion_redemptions_promotion_user ON promotion_redemptions (promotion_id, user_id);
+
+-- 注文の値引きの税率ごとの内訳（税込）。消費税は値引き後の金額から割り戻して計算する
+CREATE TABLE order_discounts (
+    order_id INT NOT NULL,
+    tax_rate SMALLINT NOT NULL,
+    promotion_id INT NULL,
+    code VARCHAR(32) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    amount INTEGER NOT NULL,
+    PRIMARY KEY (order_id, tax_rate),
+    FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE CASCADE,
+    FOREIGN KEY (promotion_id) REFERENCES promotions(promotion_id) ON DELETE SET NULL,
+    CHECK (amount > 0)
+);
+
+-- 値引きの合計（税込）。total_amountは小計から値引きを引いた額
+ALTER TABLE orders
+    ADD COLUMN discount_amount INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT orders_discount_amount_check CHECK (discount_amount >= 0 AND discount_amount <= subtotal_amount);
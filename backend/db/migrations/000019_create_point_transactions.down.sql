@@ -0,0 +1,12 @@
+DELETE FROM order_discounts WHERE source <> 1;
+ALTER TABLE order_discounts
+    DROP CONSTRAINT order_discounts_pkey,
+    ADD PRIMARY KEY (order_id, tax_rate),
+    DROP CONSTRAINT IF EXISTS order_discounts_source_check,
+    DROP COLUMN IF EXISTS source;
+
+DROP TABLE IF EXISTS point_transactions;
+
+ALTER TABLE shops
+    DROP CONSTRAINT IF EXISTS shops_point_rate_check,
+    DROP COLUMN IF EXISTS point_rate;
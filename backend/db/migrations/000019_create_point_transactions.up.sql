@@ -0,0 +1,40 @@
+-- 店舗ごとのポイント還元率。税抜で値引き後の金額100円につき何ポイント付けるか（0で付与しない）
+ALTER TABLE shops
+    ADD COLUMN point_rate SMALLINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT shops_point_rate_check CHECK (point_rate BETWEEN 0 AND 100);
+
+-- ポイントの台帳。残高はこの表から求める
+-- kind 1: 獲得、2: 利用、3: 失効、4: 返還（決済失敗などで取り消した利用分）
+-- 獲得と返還の行は有効期限を持ち、remainingに使われていない残りを持つ。利用は有効期限の近いものから差し引く
+-- 利用の行のexpires_atには、差し引いた分のうち最も遅い有効期限を残す（返還するときの有効期限に使う）
+CREATE TABLE point_transactions (
+    point_transaction_id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    order_id INT NULL,
+    shop_id INT NULL,
+    kind SMALLINT NOT NULL,
+    points INTEGER NOT NULL,
+    remaining INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE SET NULL,
+    FOREIGN KEY (shop_id) REFERENCES shops(shop_id) ON DELETE SET NULL,
+    CHECK (kind BETWEEN 1 AND 4),
+    CONSTRAINT point_transactions_points_check CHECK (
+        (kind IN (1, 4) AND points > 0 AND remaining BETWEEN 0 AND points AND expires_at IS NOT NULL) OR
+        (kind IN (2, 3) AND points < 0 AND remaining = 0)
+    )
+);
+
+-- 同じ注文でポイントを二重に付与・利用・返還しない
+CREATE UNIQUE INDEX idx_point_transactions_order_kind ON point_transactions (order_id, kind) WHERE order_id IS NOT NULL;
+CREATE INDEX idx_point_transactions_user_created ON point_transactions (user_id, created_at DESC);
+CREATE INDEX idx_point_transactions_user_lots ON point_transactions (user_id, expires_at) WHERE remaining > 0;
+
+-- 注文の値引きの種類。1: クーポン、2: ポイント。クーポンとポイントは同じ注文で併用できる
+ALTER TABLE order_discounts
+    ADD COLUMN source SMALLINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT order_discounts_source_check CHECK (source IN (1, 2)),
+    DROP CONSTRAINT order_discounts_pkey,
+    ADD PRIMARY KEY (order_id, source, tax_rate);
@@ -0,0 +1,5 @@
+-- お渡し日時。調理完了からお渡しまでの時間（受け取りの待ち時間）の集計に使う
+ALTER TABLE orders
+    ADD COLUMN handed_at TIMESTAMP NULL;
+
+CREATE INDEX idx_orders_shop_handed_at ON orders (shop_id, handed_at) WHERE handed_at IS NOT NULL;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_shop_item_category_id;
+
+ALTER TABLE shop_item DROP COLUMN IF EXISTS category_id;
+
+DROP TRIGGER IF EXISTS trigger_update_categories_updated_at ON categories;
+DROP TABLE IF EXISTS categories;
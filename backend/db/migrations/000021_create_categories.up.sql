@@ -0,0 +1,23 @@
+-- 店舗ごとの商品カテゴリ（ラーメン、サイドメニュー、ドリンクなど）。sort_orderの小さい順にメニューのタブとして表示する
+CREATE TABLE categories (
+    category_id SERIAL PRIMARY KEY,
+    shop_id INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (shop_id, name),
+    FOREIGN KEY (shop_id) REFERENCES shops(shop_id) ON DELETE CASCADE,
+    CHECK (sort_order >= 0)
+);
+
+CREATE TRIGGER trigger_update_categories_updated_at
+BEFORE UPDATE ON categories
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- 商品は複数の店舗で共有するため、カテゴリは店舗の商品（shop_item）ごとに設定する。NULLはカテゴリなし
+ALTER TABLE shop_item
+    ADD COLUMN category_id INT NULL REFERENCES categories(category_id) ON DELETE SET NULL;
+
+CREATE INDEX idx_shop_item_category_id ON shop_item (category_id);
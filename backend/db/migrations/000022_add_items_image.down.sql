@@ -0,0 +1,3 @@
+ALTER TABLE items
+    DROP COLUMN IF EXISTS thumbnail_key,
+    DROP COLUMN IF EXISTS image_key;
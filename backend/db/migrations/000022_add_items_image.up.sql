@@ -0,0 +1,4 @@
+-- 商品画像の保存先（BlobStoreのキー）。表示用の画像とサムネイルをアップロード時に生成する。NULLは画像なし
+ALTER TABLE items
+    ADD COLUMN image_key VARCHAR(255) NULL,
+    ADD COLUMN thumbnail_key VARCHAR(255) NULL;
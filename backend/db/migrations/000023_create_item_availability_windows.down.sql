@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS item_availability_windows;
+
+ALTER TABLE shops DROP COLUMN IF EXISTS time_zone;
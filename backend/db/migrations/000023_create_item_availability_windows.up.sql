@@ -0,0 +1,19 @@
+-- 店舗のタイムゾーン。商品の販売時間帯はこのタイムゾーンの曜日と時刻で判定する
+ALTER TABLE shops
+    ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT 'Asia/Tokyo';
+
+-- 店舗の商品（shop_item）を注文できる時間帯。時間帯が1つもない商品はいつでも注文できる。
+-- 曜日（0=日曜〜6=土曜）ごとに、0時からの分で [start_minute, end_minute) を表す。日をまたぐ時間帯は2日に分けて登録する
+CREATE TABLE item_availability_windows (
+    window_id SERIAL PRIMARY KEY,
+    shop_item_id INT NOT NULL,
+    day_of_week SMALLINT NOT NULL,
+    start_minute INT NOT NULL,
+    end_minute INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (shop_item_id) REFERENCES shop_item(shop_item_id) ON DELETE CASCADE,
+    CHECK (day_of_week BETWEEN 0 AND 6),
+    CHECK (start_minute >= 0 AND start_minute < end_minute AND end_minute <= 1440)
+);
+
+CREATE INDEX idx_item_availability_windows_shop_item_id ON item_availability_windows (shop_item_id);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS shop_item_price_changes;
@@ -0,0 +1,13 @@
+-- 店舗ごとの価格の変更履歴と予定。店舗での価格は、effective_at を過ぎた最も新しい変更の price（変更がなければ items.price）になる。
+-- price がNULLの変更は店舗ごとの価格をやめて items.price に戻す
+CREATE TABLE shop_item_price_changes (
+    price_change_id SERIAL PRIMARY KEY,
+    shop_item_id INT NOT NULL,
+    price INT NULL,
+    effective_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (shop_item_id) REFERENCES shop_item(shop_item_id) ON DELETE CASCADE,
+    CHECK (price IS NULL OR price >= 0)
+);
+
+CREATE INDEX idx_shop_item_price_changes_shop_item_id ON shop_item_price_changes (shop_item_id, effective_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS order_item_components;
+DROP TABLE IF EXISTS bundle_choices;
+DROP TABLE IF EXISTS bundle_slots;
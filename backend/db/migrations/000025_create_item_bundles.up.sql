@@ -0,0 +1,50 @@
+-- セットメニュー。枠（bundle_slots）を持つ商品はセット商品になり、セットの価格はセット商品のitems.price（店舗ごとの価格）
+-- 選択肢が1つの枠は固定の構成商品、複数の枠はお客様が1つ選ぶ。quantityはセット1つあたりの構成商品の数
+-- 枠は店舗ごとに設定する。同じ商品を扱う他の店舗のセットを変えないよう、店舗の商品（shop_item）に紐づける
+CREATE TABLE bundle_slots (
+    bundle_slot_id SERIAL PRIMARY KEY,
+    shop_id INT NOT NULL,
+    item_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    quantity INT NOT NULL DEFAULT 1,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (shop_id, item_id) REFERENCES shop_item(shop_id, item_id) ON DELETE CASCADE,
+    CHECK (quantity >= 1)
+);
+
+CREATE INDEX idx_bundle_slots_shop_item ON bundle_slots (shop_id, item_id);
+
+-- 枠の選択肢となる構成商品。price_deltaはセット価格への加算額（値引きは負の値）
+CREATE TABLE bundle_choices (
+    bundle_choice_id SERIAL PRIMARY KEY,
+    bundle_slot_id INT NOT NULL,
+    item_id INT NOT NULL,
+    price_delta INTEGER NOT NULL DEFAULT 0,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (bundle_slot_id) REFERENCES bundle_slots(bundle_slot_id) ON DELETE CASCADE,
+    FOREIGN KEY (item_id) REFERENCES items(item_id) ON DELETE CASCADE,
+    UNIQUE (bundle_slot_id, item_id)
+);
+
+CREATE INDEX idx_bundle_choices_item_id ON bundle_choices (item_id);
+
+-- 注文したセットの構成商品。メニュー変更の影響を受けないよう枠と商品の名前、加算額を保存する。
+-- quantityはセット1つあたりの数で、在庫数はorder_item.quantityを掛けた数だけ減らす
+CREATE TABLE order_item_components (
+    order_item_component_id SERIAL PRIMARY KEY,
+    order_item_id INT NOT NULL,
+    bundle_choice_id INT NULL,
+    item_id INT NOT NULL,
+    slot_name VARCHAR(255) NOT NULL,
+    item_name VARCHAR(255) NOT NULL,
+    quantity INT NOT NULL,
+    price_delta INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (order_item_id) REFERENCES order_item(order_item_id) ON DELETE CASCADE,
+    FOREIGN KEY (bundle_choice_id) REFERENCES bundle_choices(bundle_choice_id) ON DELETE SET NULL,
+    FOREIGN KEY (item_id) REFERENCES items(item_id)
+);
+
+CREATE INDEX idx_order_item_components_order_item_id ON order_item_components (order_item_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS item_dietary_tags;
+DROP TABLE IF EXISTS item_allergens;
@@ -0,0 +1,20 @@
+-- 商品に含まれるアレルゲン。表示が義務付けられている特定原材料の8品目を英語のコードで登録する
+-- （shrimp=えび, crab=かに, walnut=くるみ, wheat=小麦, buckwheat=そば, egg=卵, milk=乳, peanut=落花生）
+CREATE TABLE item_allergens (
+    item_id INT NOT NULL,
+    allergen VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (item_id, allergen),
+    FOREIGN KEY (item_id) REFERENCES items(item_id) ON DELETE CASCADE,
+    CHECK (allergen IN ('shrimp', 'crab', 'walnut', 'wheat', 'buckwheat', 'egg', 'milk', 'peanut'))
+);
+
+-- 商品が対応している食事の制限（ベジタリアン・ハラールなど）
+CREATE TABLE item_dietary_tags (
+    item_id INT NOT NULL,
+    tag VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (item_id, tag),
+    FOREIGN KEY (item_id) REFERENCES items(item_id) ON DELETE CASCADE,
+    CHECK (tag IN ('vegetarian', 'vegan', 'halal', 'gluten_free'))
+);
@@ -0,0 +1,36 @@
+-- メニューと店舗の翻訳。元の内容は日本語で items・categories・shops に登録し、言語ごとの翻訳をここに登録する。
+-- 翻訳のない言語や項目（説明がNULL）は日本語で表示する
+CREATE TABLE item_translations (
+    item_id INT NOT NULL,
+    lang VARCHAR(8) NOT NULL,
+    item_name VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (item_id, lang),
+    FOREIGN KEY (item_id) REFERENCES items(item_id) ON DELETE CASCADE,
+    CHECK (lang IN ('en', 'zh', 'ko'))
+);
+
+CREATE TABLE category_translations (
+    category_id INT NOT NULL,
+    lang VARCHAR(8) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (category_id, lang),
+    FOREIGN KEY (category_id) REFERENCES categories(category_id) ON DELETE CASCADE,
+    CHECK (lang IN ('en', 'zh', 'ko'))
+);
+
+CREATE TABLE shop_translations (
+    shop_id INT NOT NULL,
+    lang VARCHAR(8) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (shop_id, lang),
+    FOREIGN KEY (shop_id) REFERENCES shops(shop_id) ON DELETE CASCADE,
+    CHECK (lang IN ('en', 'zh', 'ko'))
+);
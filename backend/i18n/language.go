@@ -0,0 +1,64 @@
+// Package i18n はAPIが応答する言語を決めます
+package i18n
+
+import (
+	"slices"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLanguage は既定の言語です。メニューや店舗の元の内容はこの言語で登録します
+const DefaultLanguage = "ja"
+
+// Languages は応答できる言語です。DefaultLanguage 以外は翻訳を登録した内容だけが切り替わります
+var Languages = []string{DefaultLanguage, "en", "zh", "ko"}
+
+// Negotiator は決まった言語の中から応答する言語を決めます
+type Negotiator struct {
+	languages []string
+	matcher   language.Matcher
+}
+
+// NewNegotiator は languages の中から言語を決める Negotiator を作ります。
+// 先頭の言語が、一致する言語がない場合の既定になります
+func NewNegotiator(languages ...string) *Negotiator {
+	tags := make([]language.Tag, len(languages))
+	for i, lang := range languages {
+		tags[i] = language.MustParse(lang)
+	}
+	return &Negotiator{languages: languages, matcher: language.NewMatcher(tags)}
+}
+
+// Negotiate は応答する言語を決めます。lang が指定されていればそれを、なければ Accept-Language ヘッダーの優先順位を使い、
+// 対応している言語の中から最も近いものを返します。解釈できない指定や対応していない言語だけの場合は既定の言語を返します
+func (n *Negotiator) Negotiate(lang string, acceptLanguage string) string {
+	var desired []language.Tag
+	if lang != "" {
+		if tag, err := language.Parse(lang); err == nil {
+			desired = []language.Tag{tag}
+		}
+	} else if tags, _, err := language.ParseAcceptLanguage(acceptLanguage); err == nil {
+		desired = tags
+	}
+	if len(desired) == 0 {
+		return n.languages[0]
+	}
+
+	_, index, confidence := n.matcher.Match(desired...)
+	if confidence == language.No {
+		return n.languages[0]
+	}
+	return n.languages[index]
+}
+
+var contentNegotiator = NewNegotiator(Languages...)
+
+// Negotiate は Languages の中から応答する言語を決めます
+func Negotiate(lang string, acceptLanguage string) string {
+	return contentNegotiator.Negotiate(lang, acceptLanguage)
+}
+
+// IsTranslationLanguage は翻訳を登録できる言語（DefaultLanguage 以外の応答できる言語）かを判定します
+func IsTranslationLanguage(lang string) bool {
+	return lang != DefaultLanguage && slices.Contains(Languages, lang)
+}
@@ -0,0 +1,62 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/i18n"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		lang           string
+		acceptLanguage string
+		want           string
+	}{
+		{name: "指定なしは日本語", want: "ja"},
+		{name: "Accept-Languageの地域付きの英語", acceptLanguage: "en-US,en;q=0.9", want: "en"},
+		{name: "Accept-Languageの優先順位", acceptLanguage: "fr;q=0.9, ko;q=0.8, en;q=0.5", want: "ko"},
+		{name: "簡体字の中国語", acceptLanguage: "zh-CN", want: "zh"},
+		{name: "対応していない言語だけなら日本語", acceptLanguage: "fr-FR, de;q=0.8", want: "ja"},
+		{name: "解釈できないAccept-Languageは日本語", acceptLanguage: "en;q=abc", want: "ja"},
+		{name: "langはAccept-Languageより優先", lang: "en", acceptLanguage: "ko", want: "en"},
+		{name: "langで日本語を指定", lang: "ja", acceptLanguage: "en", want: "ja"},
+		{name: "対応していないlangは日本語", lang: "fr", acceptLanguage: "en", want: "ja"},
+		{name: "解釈できないlangは日本語", lang: "!!", want: "ja"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := i18n.Negotiate(tt.lang, tt.acceptLanguage); got != tt.want {
+				t.Errorf("Negotiate(%q, %q) = %q, want %q", tt.lang, tt.acceptLanguage, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsTranslationLanguage(t *testing.T) {
+	for lang, want := range map[string]bool{"en": true, "zh": true, "ko": true, "ja": false, "fr": false, "": false} {
+		if got := i18n.IsTranslationLanguage(lang); got != want {
+			t.Errorf("IsTranslationLanguage(%q) = %v, want %v", lang, got, want)
+		}
+	}
+}
+
+func TestNegotiator(t *testing.T) {
+	// 翻訳のない言語より、Accept-Languageで次に優先される対応言語を選ぶ
+	n := i18n.NewNegotiator("ja", "en")
+	tests := []struct {
+		acceptLanguage string
+		want           string
+	}{
+		{acceptLanguage: "zh-CN, en;q=0.8", want: "en"},
+		{acceptLanguage: "ko", want: "ja"},
+		{acceptLanguage: "en-GB", want: "en"},
+		{acceptLanguage: "", want: "ja"},
+	}
+	for _, tt := range tests {
+		if got := n.Negotiate("", tt.acceptLanguage); got != tt.want {
+			t.Errorf("Negotiate(%q) = %q, want %q", tt.acceptLanguage, got, tt.want)
+		}
+	}
+}
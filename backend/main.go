@@ -8,6 +8,7 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // 店舗のタイムゾーンをOSのタイムゾーンデータなしで読み込めるようにする
 
 	"github.com/A4-dev-team/mobileorder.git/api"
 	"github.com/A4-dev-team/mobileorder.git/connectDB"
@@ -18,6 +19,12 @@ import (
 	_ "github.com/lib/pq"
 )
 
+const (
+	paymentSweepInterval    = time.Minute      // 決済待ちの注文を確認する間隔
+	pendingPaymentTimeout   = 15 * time.Minute // 注文からこの時間が過ぎても決済待ちの注文は片付ける
+	pendingRefundRetryDelay = time.Minute      // 依頼中の返金と重ならないよう、登録からこの時間が過ぎた返金をやり直す
+)
+
 // @title        Mobile Order API
 // @version      1.0
 // @description  モバイルオーダー（事前注文・決済）システムのためのAPI仕様書です。
@@ -39,18 +46,62 @@ func main() {
 	orderRepository := repositories.NewOrderRepository()
 	shopRepository := repositories.NewShopRepository()
 	itemRepository := repositories.NewItemRepository()
+	paymentRepository := repositories.NewPaymentRepository()
+	refundRepository := repositories.NewRefundRepository()
+	promotionRepository := repositories.NewPromotionRepository()
+	pointRepository := repositories.NewPointRepository()
+	reportRepository := repositories.NewReportRepository()
+	categoryRepository := repositories.NewCategoryRepository()
+	translationRepository := repositories.NewTranslationRepository()
+
+	// 商品画像の保存先。IMAGE_STORAGE=s3 でS3互換ストレージ、それ以外はローカルのディレクトリに保存して /images で配信する
+	imageDir := os.Getenv("IMAGE_DIR")
+	if imageDir == "" {
+		imageDir = "./uploads"
+	}
+	var imageStore services.BlobStore = services.NewLocalBlobStore(imageDir, "/images")
+	if os.Getenv("IMAGE_STORAGE") == "s3" {
+		imageStore = services.NewS3BlobStore(services.S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PublicBaseURL:   os.Getenv("S3_PUBLIC_BASE_URL"),
+		}, nil)
+	}
 
-	adminService := services.NewAdminService(orderRepository, itemRepository, db)
-	authService := services.NewAuthService(userRepository, shopRepository, orderRepository, db)
-	orderService := services.NewOrderService(orderRepository, itemRepository, db)
-	itemService := services.NewItemService(itemRepository, db)
+	// 決済代行会社の本番連携が入るまではローカルのモック決済を使う
+	paymentProvider := services.NewMockPaymentProvider(os.Getenv("PAYMENT_WEBHOOK_SECRET"))
+
+	adminService := services.NewAdminService(orderRepository, itemRepository, pointRepository, paymentRepository, promotionRepository, db)
+	authService := services.NewAuthService(userRepository, shopRepository, orderRepository, pointRepository, db)
+	paymentService := services.NewPaymentService(paymentRepository, refundRepository, orderRepository, itemRepository, promotionRepository, pointRepository, paymentProvider, db)
+	orderService := services.NewOrderService(orderRepository, itemRepository, promotionRepository, pointRepository, translationRepository, paymentService, db)
+	itemService := services.NewItemService(itemRepository, categoryRepository, translationRepository, imageStore, db)
+	shopService := services.NewShopService(shopRepository, translationRepository, db)
+	promotionService := services.NewPromotionService(promotionRepository, itemRepository, db)
+	pointService := services.NewPointService(pointRepository, db)
+	reportService := services.NewReportService(reportRepository, db)
+	categoryService := services.NewCategoryService(categoryRepository, db)
+	translationService := services.NewTranslationService(translationRepository, db)
 
 	adminController := controllers.NewAdminController(adminService)
 	authController := controllers.NewAuthController(authService)
 	orderController := controllers.NewOrderController(orderService)
 	itemController := controllers.NewItemController(itemService)
-
-	e := api.NewRouter(adminController, authController, orderController, itemController)
+	shopController := controllers.NewShopController(shopService)
+	paymentController := controllers.NewPaymentController(paymentService)
+	promotionController := controllers.NewPromotionController(promotionService)
+	pointController := controllers.NewPointController(pointService)
+	reportController := controllers.NewReportController(reportService)
+	categoryController := controllers.NewCategoryController(categoryService)
+	translationController := controllers.NewTranslationController(translationService)
+
+	e := api.NewRouter(adminController, authController, orderController, itemController, shopController, paymentController, promotionController, pointController, reportController, categoryController, translationController)
+	if _, ok := imageStore.(*services.LocalBlobStore); ok {
+		e.Static("/images", imageDir)
+	}
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -63,9 +114,31 @@ func main() {
 		}
 	}()
 
+	// 決済待ちのまま放置された注文を定期的に片付け（売上確定のやり直しか取り消し）、結果の分からなかった返金をやり直す
+	sweepCtx, stopSweep := context.WithCancel(context.Background())
+	defer stopSweep()
+	go func() {
+		ticker := time.NewTicker(paymentSweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-sweepCtx.Done():
+				return
+			case now := <-ticker.C:
+				if err := paymentService.ExpireStalePayments(sweepCtx, now.Add(-pendingPaymentTimeout)); err != nil {
+					log.Printf("failed to expire stale payments: %v", err)
+				}
+				if err := paymentService.RetryPendingRefunds(sweepCtx, now.Add(-pendingRefundRetryDelay)); err != nil {
+					log.Printf("failed to retry pending refunds: %v", err)
+				}
+			}
+		}
+	}()
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	<-quit
+	stopSweep()
 
 	log.Println("Shutting ndown server...")
 
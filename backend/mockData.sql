@@ -1,6 +1,6 @@
 -- データのクリア (開発時に毎回クリーンな状態にするため)
 -- 外部キー制約があるため、TRUNCATEの順番に注意
-TRUNCATE TABLE order_item, orders, shop_staff, shop_item, users, shops, items RESTART IDENTITY CASCADE;
+TRUNCATE TABLE item_translations, category_translations, shop_translations, item_dietary_tags, item_allergens, order_item_components, bundle_choices, bundle_slots, shop_item_price_changes, item_availability_windows, categories, point_transactions, promotion_redemptions, promotion_items, promotions, order_discounts, order_tax_lines, refund_items, refunds, payments, order_item_modifiers, modifier_options, modifier_groups, order_item, orders, shop_staff, shop_item, users, shops, items RESTART IDENTITY CASCADE;
 
 -- ユーザーを15人作成 (管理者5人、顧客10人)
 -- role: 1 = Customer, 2 = Admin
@@ -22,12 +22,16 @@ INSERT INTO users(email, role) VALUES
 ('watanabe@example.com', 1);  -- ID: 15
 
 -- 店舗を5件作成
-INSERT INTO shops(name, description, location) VALUES
-('A4食堂', '安くて美味しい、学生街の定食屋です。', '神戸市灘区六甲台町1-1'),
-('元町ラーメン一番星', '豚骨と魚介のWスープが自慢。', '神戸市中央区元町通2-9-1'),
-('三宮ベーカリーカフェ', '毎朝焼き上げるパンと自家焙煎コーヒー。', '神戸市中央区三宮町1-8-1'),
-('ハーバーランド・クレープ', '港の景色を眺めながら楽しむ、もちもちクレープ。', '神戸市中央区東川崎町1-6-1'),
-('カフェ・ド・異人館', 'レトロな雰囲気でくつろぐ、北野の隠れ家カフェ。', '神戸市中央区北野町3-10-20');
+INSERT INTO shops(name, description, location, latitude, longitude) VALUES
+('A4食堂', '安くて美味しい、学生街の定食屋です。', '神戸市灘区六甲台町1-1', 34.7256, 135.2352),
+('元町ラーメン一番星', '豚骨と魚介のWスープが自慢。', '神戸市中央区元町通2-9-1', 34.6887, 135.1866),
+('三宮ベーカリーカフェ', '毎朝焼き上げるパンと自家焙煎コーヒー。', '神戸市中央区三宮町1-8-1', 34.6916, 135.1925),
+('ハーバーランド・クレープ', '港の景色を眺めながら楽しむ、もちもちクレープ。', '神戸市中央区東川崎町1-6-1', 34.6791, 135.1838),
+('カフェ・ド・異人館', 'レトロな雰囲気でくつろぐ、北野の隠れ家カフェ。', '神戸市中央区北野町3-10-20', 34.7010, 135.1905);
+
+-- 適格請求書発行事業者として登録済みの店舗は、領収書に登録番号を記載する
+UPDATE shops SET invoice_registration_number = 'T1234567890123' WHERE shop_id = 1;
+UPDATE shops SET invoice_registration_number = 'T9876543210987' WHERE shop_id = 3;
 
 -- 管理者と店舗の関連付け (shop_staffテーブル)
 INSERT INTO shop_staff(user_id, shop_id) VALUES
@@ -60,12 +64,16 @@ INSERT INTO items (item_name, description, price) VALUES
 ('チーズケーキ', '濃厚でなめらかな口溶けのベイクドチーズケーキ。', 650),
 ('アイスティー', 'アールグレイの爽やかな香り。', 550);
 
+-- セット商品 (ID: 16)。構成は bundle_slots / bundle_choices で登録する
+INSERT INTO items (item_name, description, price) VALUES
+('ラーメン丼セット', 'お好きなラーメンとチャーシュー丼のセット。', 1250);
+
 -- 店舗と商品の関連付け (shop_itemテーブル)
 INSERT INTO shop_item(shop_id, item_id) VALUES
 -- A4食堂 (shop_id: 1)
 (1, 1), (1, 2), (1, 3), (1, 4),
 -- 元町ラーメン一番星 (shop_id: 2)
-(2, 5), (2, 6), (2, 7), (2, 4), -- ラーメン屋でもビールは売る
+(2, 5), (2, 6), (2, 7), (2, 4), (2, 16), -- ラーメン屋でもビールは売る
 -- 三宮ベーカリーカフェ (shop_id: 3)
 (3, 8), (3, 9), (3, 10), (3, 11),
 -- ハーバーランド・クレープ (shop_id: 4)
@@ -73,25 +81,167 @@ INSERT INTO shop_item(shop_id, item_id) VALUES
 -- カフェ・ド・異人館 (shop_id: 5)
 (5, 10), (5, 11), (5, 14), (5, 15); -- こちらのカフェでもコーヒーとラテは売る
 
+-- 数量限定の商品は在庫数を設定する（NULLは在庫数を管理しない）
+UPDATE shop_item SET stock_quantity = 30 WHERE shop_id = 1 AND item_id = 3; -- 日替わりランチは1日30食
+UPDATE shop_item SET stock_quantity = 20 WHERE shop_id = 2 AND item_id = 7; -- チャーシュー丼は1日20食
+
+-- 注文できる時間帯（店舗のタイムゾーンの曜日 0=日曜〜6=土曜 と、0時からの分）。時間帯のない商品はいつでも注文できる
+-- 日替わりランチは平日11:00〜14:00、クロワッサンは毎朝7:00〜10:00
+INSERT INTO item_availability_windows (shop_item_id, day_of_week, start_minute, end_minute)
+SELECT si.shop_item_id, d.day, 660, 840
+FROM shop_item si CROSS JOIN generate_series(1, 5) AS d(day)
+WHERE si.shop_id = 1 AND si.item_id = 3;
+INSERT INTO item_availability_windows (shop_item_id, day_of_week, start_minute, end_minute)
+SELECT si.shop_item_id, d.day, 420, 600
+FROM shop_item si CROSS JOIN generate_series(0, 6) AS d(day)
+WHERE si.shop_id = 3 AND si.item_id = 8;
+
+-- 店舗ごとの価格。ラーメン屋のビールは600円（すぐに反映する変更として登録し、最初に価格を読んだときに反映する）
+INSERT INTO shop_item_price_changes (shop_item_id, price, effective_at)
+SELECT si.shop_item_id, 600, NOW() AT TIME ZONE 'UTC'
+FROM shop_item si
+WHERE si.shop_id = 2 AND si.item_id = 4;
+
+-- メニューのカテゴリ（sort_orderの小さい順にタブとして表示する）
+INSERT INTO categories (shop_id, name, sort_order) VALUES
+(1, '定食', 1),       -- ID: 1
+(1, 'ドリンク', 2),   -- ID: 2
+(2, 'ラーメン', 1),   -- ID: 3
+(2, 'ご飯もの', 2),   -- ID: 4
+(2, 'ドリンク', 3);   -- ID: 5
+
+UPDATE shop_item SET category_id = 1 WHERE shop_id = 1 AND item_id IN (1, 2, 3);
+UPDATE shop_item SET category_id = 2 WHERE shop_id = 1 AND item_id = 4;
+UPDATE shop_item SET category_id = 3 WHERE shop_id = 2 AND item_id IN (5, 6, 16);
+UPDATE shop_item SET category_id = 4 WHERE shop_id = 2 AND item_id = 7;
+UPDATE shop_item SET category_id = 5 WHERE shop_id = 2 AND item_id = 4;
+
+-- 酒類は軽減税率の対象外 (tax_category: 1 = 飲食料品, 2 = 標準税率)
+UPDATE items SET tax_category = 2 WHERE item_id = 4;
+
+-- 商品に含まれる特定原材料（えび・かに・くるみ・小麦・そば・卵・乳・落花生）
+INSERT INTO item_allergens (item_id, allergen) VALUES
+(1, 'wheat'), (1, 'egg'),                   -- 唐揚げ定食
+(2, 'wheat'),                               -- 生姜焼き定食
+(5, 'wheat'), (5, 'egg'),                   -- 特製豚骨ラーメン
+(6, 'wheat'), (6, 'egg'),                   -- 味玉つけ麺
+(8, 'wheat'), (8, 'egg'), (8, 'milk'),      -- クロワッサン
+(9, 'wheat'),                               -- バゲット
+(11, 'milk'),                               -- カフェラテ
+(12, 'wheat'), (12, 'egg'), (12, 'milk'),   -- チョコバナナ生クリーム
+(13, 'wheat'), (13, 'egg'), (13, 'milk'),   -- ストロベリーチーズケーキ
+(14, 'wheat'), (14, 'egg'), (14, 'milk');   -- チーズケーキ
+
+-- 対応している食事の制限
+INSERT INTO item_dietary_tags (item_id, tag) VALUES
+(8, 'vegetarian'),
+(9, 'vegetarian'), (9, 'vegan'),
+(10, 'vegetarian'), (10, 'vegan'), (10, 'gluten_free'),
+(11, 'vegetarian'), (11, 'gluten_free'),
+(12, 'vegetarian'), (13, 'vegetarian'), (14, 'vegetarian'),
+(15, 'vegetarian'), (15, 'vegan'), (15, 'gluten_free');
+
+-- 留学生向けの翻訳（翻訳のない商品・カテゴリは日本語で表示する）
+INSERT INTO shop_translations (shop_id, lang, name, description) VALUES
+(2, 'en', 'Motomachi Ramen Ichibanboshi', 'Famous for our double soup of pork bone and seafood.'),
+(2, 'zh', '元町拉面一番星', '招牌是猪骨和海鲜的双重汤底。'),
+(2, 'ko', '모토마치 라멘 이치방보시', NULL);
+
+INSERT INTO category_translations (category_id, lang, name) VALUES
+(3, 'en', 'Ramen'), (3, 'zh', '拉面'), (3, 'ko', '라멘'),
+(4, 'en', 'Rice Bowls'),
+(5, 'en', 'Drinks');
+
+INSERT INTO item_translations (item_id, lang, item_name, description) VALUES
+(4, 'en', 'Bottled Beer (medium)', 'Enjoy it with your meal.'),
+(5, 'en', 'Special Tonkotsu Ramen', 'Rich pork bone broth simmered for 8 hours.'),
+(5, 'zh', '特制猪骨拉面', '熬煮8小时的浓厚汤底。'),
+(5, 'ko', '특제 돈코츠 라멘', NULL),
+(6, 'en', 'Tsukemen with Seasoned Egg', 'Chewy thick noodles with a rich seafood dipping soup.'),
+(7, 'en', 'Chashu Rice Bowl', 'Braised pork over rice with our special sauce.'),
+(16, 'en', 'Ramen & Rice Bowl Set', 'Your choice of ramen with a chashu rice bowl.');
+
+-- 商品のオプション (selection_type: 1 = 単一選択, 2 = 複数選択)
+INSERT INTO modifier_groups (shop_id, item_id, name, selection_type, min_select, max_select, sort_order) VALUES
+(2, 5, '麺の量', 1, 1, 1, 1),    -- ID: 1 元町ラーメン一番星の特製豚骨ラーメン
+(2, 5, 'トッピング', 2, 0, 3, 2), -- ID: 2 元町ラーメン一番星の特製豚骨ラーメン
+(3, 10, 'サイズ', 1, 1, 1, 1);   -- ID: 3 店舗3のブレンドコーヒー
+
+INSERT INTO modifier_options (modifier_group_id, name, price_delta, sort_order) VALUES
+(1, '並盛り', 0, 1),     -- ID: 1
+(1, '大盛り', 100, 2),   -- ID: 2
+(2, '味玉', 120, 1),     -- ID: 3
+(2, 'ねぎ抜き', 0, 2),   -- ID: 4
+(2, '替え玉', 150, 3),   -- ID: 5
+(3, 'レギュラー', 0, 1), -- ID: 6
+(3, 'ラージ', 80, 2);    -- ID: 7
+
+-- セット商品の枠と選択肢。ラーメンは選べて（つけ麺は+100円）、チャーシュー丼は固定
+INSERT INTO bundle_slots (item_id, name, quantity, sort_order) VALUES
+(16, 'ラーメン', 1, 1), -- ID: 1
+(16, 'ご飯もの', 1, 2); -- ID: 2
+
+INSERT INTO bundle_choices (bundle_slot_id, item_id, price_delta, sort_order) VALUES
+(1, 5, 0, 1),   -- ID: 1 特製豚骨ラーメン
+(1, 6, 100, 2), -- ID: 2 味玉つけ麺
+(2, 7, 0, 1);   -- ID: 3 チャーシュー丼
+
 -- 注文データ (ordersテーブル)
--- status: 1=cooking, 2=completed, 3=handed
-INSERT INTO orders (user_id, shop_id, order_date, total_amount, guest_order_token, status) VALUES
+-- status: 1=cooking, 2=completed, 3=handed, 4=pending_payment
+-- dining_option: 1=takeout (飲食料品は8%), 2=dine_in (10%)。金額は税抜の小計 + 消費税 = 合計
+INSERT INTO orders (user_id, shop_id, order_date, total_amount, guest_order_token, status, dining_option, subtotal_amount, tax_amount) VALUES
 -- customer1 (ID:6) の注文
-(6, 1, NOW() - INTERVAL '20 minutes', 850, NULL, 1), -- A4食堂で唐揚げ定食 (調理中)
-(6, 2, NOW() - INTERVAL '1 day', 1050, NULL, 2), -- 昨日、元町ラーメンでつけ麺 (調理完了)
+(6, 1, NOW() - INTERVAL '20 minutes', 918, NULL, 1, 1, 850, 68), -- A4食堂で唐揚げ定食を持ち帰り (調理中)
+(6, 2, NOW() - INTERVAL '1 day', 1155, NULL, 2, 2, 1050, 105), -- 昨日、元町ラーメンでつけ麺を店内飲食 (調理完了)
 -- customer2 (ID:7) の注文
-(7, 1, NOW() - INTERVAL '10 minutes', 900, NULL, 1), -- A4食堂で生姜焼き定食 (調理中)
+(7, 1, NOW() - INTERVAL '10 minutes', 972, NULL, 1, 1, 900, 72), -- A4食堂で生姜焼き定食を持ち帰り (調理中)
 -- ゲストユーザーの注文 (user_idがNULL)
-(NULL, 3, NOW() - INTERVAL '5 minutes', 900, 'guest-token-12345', 1); -- 三宮ベーカリーでクロワッサンとコーヒー (調理中)
+(NULL, 3, NOW() - INTERVAL '5 minutes', 885, 'guest-token-12345', 1, 1, 820, 65); -- 三宮ベーカリーでクロワッサンとコーヒーを持ち帰り (調理中)
+
+-- 調理完了日時。昨日のつけ麺はまだ受け取られていない（受け取られていない注文として厨房の所要時間に表示される）
+UPDATE orders SET completed_at = order_date + INTERVAL '12 minutes' WHERE order_id = 2;
 
 -- 注文と商品の関連付け (order_itemテーブル)
-INSERT INTO order_item (order_id, item_id, quantity, price_at_order) VALUES
+INSERT INTO order_item (order_id, item_id, quantity, price_at_order, tax_rate) VALUES
 -- 注文ID: 1 (唐揚げ定食)
-(1, 1, 1, 850),
+(1, 1, 1, 850, 8),
 -- 注文ID: 2 (味玉つけ麺)
-(2, 6, 1, 1050),
+(2, 6, 1, 1050, 10),
 -- 注文ID: 3 (生姜焼き定食)
-(3, 2, 1, 900),
+(3, 2, 1, 900, 8),
 -- 注文ID: 4 (クロワッサンとコーヒー)
-(4, 8, 1, 320),
-(4, 10, 1, 500);
+(4, 8, 1, 320, 8),
+(4, 10, 1, 500, 8);
+
+-- 税率ごとの内訳 (order_tax_linesテーブル)
+INSERT INTO order_tax_lines (order_id, tax_rate, taxable_amount, tax_amount) VALUES
+(1, 8, 850, 68),
+(2, 10, 1050, 105),
+(3, 8, 900, 72),
+(4, 8, 820, 65);
+
+-- 決済データ (paymentsテーブル)。モック決済で売上確定済み
+-- status: 1=pending, 2=authorized, 3=captured, 4=failed
+INSERT INTO payments (order_id, provider, provider_payment_id, amount, status) VALUES
+(1, 'mock', 'mock_pay_seed_1', 918, 3),
+(2, 'mock', 'mock_pay_seed_2', 1155, 3),
+(3, 'mock', 'mock_pay_seed_3', 972, 3),
+(4, 'mock', 'mock_pay_seed_4', 885, 3);
+
+-- クーポンデータ (promotionsテーブル)
+-- discount_type: 1=percentage (割引率), 2=fixed (値引き額)。shop_idがNULLのクーポンは全店舗で使える
+INSERT INTO promotions (shop_id, code, name, discount_type, discount_value, min_spend, min_item_quantity, max_discounted_units, usage_limit_per_user) VALUES
+(NULL, 'WELCOME100', 'はじめての注文100円引き', 2, 100, 500, 0, NULL, 1), -- ID: 1
+(3, 'DRINK2', 'コーヒー2杯目無料', 1, 100, 0, 2, 1, NULL);                -- ID: 2
+
+-- クーポンの対象商品 (promotion_itemsテーブル)
+INSERT INTO promotion_items (promotion_id, item_id) VALUES
+(2, 10);
+
+-- ポイント還元率 (100円あたりのポイント数。既定は1)
+UPDATE shops SET point_rate = 2 WHERE shop_id = 3; -- 三宮ベーカリーカフェはポイント2倍
+
+-- ポイントの台帳 (point_transactionsテーブル)
+-- kind: 1=earn (獲得), 2=redeem (利用), 3=expire (失効), 4=restore (返還)
+INSERT INTO point_transactions (user_id, kind, points, remaining, expires_at) VALUES
+(6, 1, 300, 300, NOW() + INTERVAL '1 year'); -- customer1 に300ポイント
@@ -13,9 +13,10 @@ import (
 type UserRole int
 
 const (
-	UnknownRole  UserRole = iota // 0 (ゼロ値)
-	CustomerRole                 // 1
-	AdminRole                    // 2
+	UnknownRole      UserRole = iota // 0 (ゼロ値)
+	CustomerRole                     // 1
+	AdminRole                        // 2
+	HeadquartersRole                 // 3 (本部の管理者。店舗に紐づかず、全店舗共通のクーポンを管理する)
 )
 
 func (r UserRole) String() string {
@@ -24,6 +25,8 @@ func (r UserRole) String() string {
 		return "customer"
 	case AdminRole:
 		return "admin"
+	case HeadquartersRole:
+		return "headquarters"
 	default:
 		return "unknown"
 	}
@@ -45,8 +48,10 @@ func (r *UserRole) UnmarshalJSON(b []byte) error {
 		*r = CustomerRole
 	case "admin":
 		*r = AdminRole
+	case "headquarters":
+		*r = HeadquartersRole
 	default:
-		return apperrors.ValidationFailed.Wrap(nil, "不正なロール値です: "+s)
+		return apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgInvalidRole, apperrors.Params{"value": s})
 	}
 	return nil
 }
@@ -57,10 +62,12 @@ func (r *UserRole) UnmarshalJSON(b []byte) error {
 type OrderStatus int
 
 const (
-	UnknownStatus OrderStatus = iota // 0
-	Cooking                          // 1 (調理中)
-	Completed                        // 2 (調理完了)
-	Handed                           // 3 (お渡し済み)
+	UnknownStatus  OrderStatus = iota // 0
+	Cooking                           // 1 (調理中)
+	Completed                         // 2 (調理完了)
+	Handed                            // 3 (お渡し済み)
+	PendingPayment                    // 4 (決済待ち。決済が確定するまで厨房には表示しない)
+	Cancelled                         // 5 (取り消し。決済が失敗した注文。売上には含めない)
 )
 
 func (s OrderStatus) String() string {
@@ -71,6 +78,10 @@ func (s OrderStatus) String() string {
 		return "completed"
 	case Handed:
 		return "handed"
+	case PendingPayment:
+		return "pending_payment"
+	case Cancelled:
+		return "cancelled"
 	default:
 		return "unknown"
 	}
@@ -92,14 +103,309 @@ func (s *OrderStatus) UnmarshalJSON(b []byte) error {
 		*s = Completed
 	case "handed":
 		*s = Handed
+	case "pending_payment":
+		*s = PendingPayment
+	case "cancelled":
+		*s = Cancelled
 	default:
-		return apperrors.ValidationFailed.Wrap(nil, "不正なステータス値です: "+str)
+		return apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgInvalidOrderStatus, apperrors.Params{"value": str})
 	}
 	return nil
 }
 
 // ---------------定義終わり----------------
 
+// --- PaymentStatus 型と定数の定義 ---
+type PaymentStatus int
+
+const (
+	UnknownPaymentStatus PaymentStatus = iota // 0
+	PaymentPending                            // 1 (承認待ち)
+	PaymentAuthorized                         // 2 (与信済み)
+	PaymentCaptured                           // 3 (売上確定)
+	PaymentFailed                             // 4 (失敗)
+	PaymentCapturing                          // 5 (売上確定中。決済代行会社に売上確定を依頼して結果を待っている)
+)
+
+func (s PaymentStatus) String() string {
+	switch s {
+	case PaymentPending:
+		return "pending"
+	case PaymentAuthorized:
+		return "authorized"
+	case PaymentCaptured:
+		return "captured"
+	case PaymentFailed:
+		return "failed"
+	case PaymentCapturing:
+		return "capturing"
+	default:
+		return "unknown"
+	}
+}
+
+func (s PaymentStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// ---------------定義終わり----------------
+
+// --- RefundStatus 型と定数の定義 ---
+type RefundStatus int
+
+const (
+	UnknownRefundStatus RefundStatus = iota // 0
+	RefundPending                           // 1 (返金依頼中。決済代行会社の結果を待っている)
+	RefundSucceeded                         // 2 (返金済み)
+)
+
+func (s RefundStatus) String() string {
+	switch s {
+	case RefundPending:
+		return "pending"
+	case RefundSucceeded:
+		return "succeeded"
+	default:
+		return "unknown"
+	}
+}
+
+func (s RefundStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// ---------------定義終わり----------------
+
+// --- TaxCategory 型と定数の定義 ---
+type TaxCategory int
+
+const (
+	UnknownTaxCategory  TaxCategory = iota // 0
+	TaxCategoryFood                        // 1 (飲食料品。持ち帰りは軽減税率)
+	TaxCategoryStandard                    // 2 (酒類など。店内飲食・持ち帰りとも標準税率)
+)
+
+func (c TaxCategory) String() string {
+	switch c {
+	case TaxCategoryFood:
+		return "food"
+	case TaxCategoryStandard:
+		return "standard"
+	default:
+		return "unknown"
+	}
+}
+
+func (c TaxCategory) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// ---------------定義終わり----------------
+
+// --- アレルゲンと食事の制限の定義 ---
+
+// 表示が義務付けられている特定原材料（8品目）
+const (
+	AllergenShrimp    = "shrimp"    // えび
+	AllergenCrab      = "crab"      // かに
+	AllergenWalnut    = "walnut"    // くるみ
+	AllergenWheat     = "wheat"     // 小麦
+	AllergenBuckwheat = "buckwheat" // そば
+	AllergenEgg       = "egg"       // 卵
+	AllergenMilk      = "milk"      // 乳
+	AllergenPeanut    = "peanut"    // 落花生
+)
+
+// 商品が対応している食事の制限
+const (
+	DietaryVegetarian = "vegetarian"  // 肉・魚を使わない
+	DietaryVegan      = "vegan"       // 動物由来の食材を使わない
+	DietaryHalal      = "halal"       // ハラール認証の食材のみ
+	DietaryGlutenFree = "gluten_free" // グルテンを含まない
+)
+
+// ---------------定義終わり----------------
+
+// --- DiningOption 型と定数の定義 ---
+type DiningOption int
+
+const (
+	UnknownDiningOption DiningOption = iota // 0
+	Takeout                                 // 1 (持ち帰り)
+	DineIn                                  // 2 (店内飲食)
+)
+
+func (o DiningOption) String() string {
+	switch o {
+	case Takeout:
+		return "takeout"
+	case DineIn:
+		return "dine_in"
+	default:
+		return "unknown"
+	}
+}
+
+func (o DiningOption) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.String())
+}
+
+func (o *DiningOption) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	switch str {
+	case "takeout":
+		*o = Takeout
+	case "dine_in":
+		*o = DineIn
+	default:
+		return apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgInvalidDiningOption, apperrors.Params{"value": str})
+	}
+	return nil
+}
+
+// ---------------定義終わり----------------
+
+// --- ModifierSelectionType 型と定数の定義 ---
+type ModifierSelectionType int
+
+const (
+	UnknownSelection ModifierSelectionType = iota // 0
+	SingleSelect                                  // 1 (単一選択)
+	MultiSelect                                   // 2 (複数選択)
+)
+
+func (t ModifierSelectionType) String() string {
+	switch t {
+	case SingleSelect:
+		return "single"
+	case MultiSelect:
+		return "multi"
+	default:
+		return "unknown"
+	}
+}
+
+func (t ModifierSelectionType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (t *ModifierSelectionType) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	switch str {
+	case "single":
+		*t = SingleSelect
+	case "multi":
+		*t = MultiSelect
+	default:
+		return apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgInvalidSelectionType, apperrors.Params{"value": str})
+	}
+	return nil
+}
+
+// ---------------定義終わり----------------
+
+// --- DiscountType 型と定数の定義 ---
+type DiscountType int
+
+const (
+	UnknownDiscountType DiscountType = iota // 0
+	PercentageDiscount                      // 1 (割引率。discount_valueは%)
+	FixedDiscount                           // 2 (値引き額。discount_valueは円)
+)
+
+func (t DiscountType) String() string {
+	switch t {
+	case PercentageDiscount:
+		return "percentage"
+	case FixedDiscount:
+		return "fixed"
+	default:
+		return "unknown"
+	}
+}
+
+func (t DiscountType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (t *DiscountType) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	switch str {
+	case "percentage":
+		*t = PercentageDiscount
+	case "fixed":
+		*t = FixedDiscount
+	default:
+		return apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgInvalidDiscountType, apperrors.Params{"value": str})
+	}
+	return nil
+}
+
+// 注文の値引きの種類
+type DiscountSource int
+
+const (
+	UnknownDiscountSource DiscountSource = iota // 0
+	PromotionSource                             // 1 (クーポン)
+	PointsSource                                // 2 (ポイント利用)
+)
+
+func (s DiscountSource) String() string {
+	switch s {
+	case PromotionSource:
+		return "promotion"
+	case PointsSource:
+		return "points"
+	default:
+		return "unknown"
+	}
+}
+
+func (s DiscountSource) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// ポイントの台帳の取引の種類
+type PointTransactionKind int
+
+const (
+	UnknownPointTransactionKind PointTransactionKind = iota // 0
+	PointEarned                                             // 1 (お渡し時の獲得)
+	PointRedeemed                                           // 2 (注文での利用)
+	PointExpired                                            // 3 (有効期限切れによる失効)
+	PointRestored                                           // 4 (決済失敗や注文の取り消しによる返還)
+)
+
+func (k PointTransactionKind) String() string {
+	switch k {
+	case PointEarned:
+		return "earn"
+	case PointRedeemed:
+		return "redeem"
+	case PointExpired:
+		return "expire"
+	case PointRestored:
+		return "restore"
+	default:
+		return "unknown"
+	}
+}
+
+func (k PointTransactionKind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.String())
+}
+
+// ---------------定義終わり----------------
+
 type User struct {
 	UserID    int       `json:"user_id" db:"user_id"`
 	Email     string    `json:"email" db:"email"`
@@ -124,9 +430,19 @@ type Shop struct {
 	Name        string    `json:"name" db:"name"`
 	Description string    `json:"description" db:"description"`
 	Location    string    `json:"location" db:"location"`
+	Latitude    *float64  `json:"latitude" db:"latitude"`   // 未設定の店舗ではNULL
+	Longitude   *float64  `json:"longitude" db:"longitude"` // 未設定の店舗ではNULL
 	IsOpen      bool      `json:"is_open" db:"is_open"`
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+
+	DefaultPrepSeconds int `json:"default_prep_seconds" db:"default_prep_seconds"` // 商品に調理時間の設定がない場合の既定値（秒）
+
+	InvoiceRegistrationNumber *string `json:"invoice_registration_number" db:"invoice_registration_number"` // 適格請求書発行事業者の登録番号。未登録ではNULL
+
+	PointRate int `json:"point_rate" db:"point_rate"` // 値引き後の合計から消費税を除いた金額100円につき付与するポイント。0では付与しない
+
+	TimeZone string `json:"time_zone" db:"time_zone"` // 商品の販売時間帯を判定するタイムゾーン（IANA名）
 }
 
 type Order struct {
@@ -137,18 +453,72 @@ type Order struct {
 	TotalAmount     int            `db:"total_amount"`
 	GuestOrderToken sql.NullString `db:"guest_order_token"` // ゲスト注文では一時的なトークンが入る
 	Status          OrderStatus    `db:"status"`
+	CompletedAt     sql.NullTime   `db:"completed_at"` // 調理完了になった日時
+	HandedAt        sql.NullTime   `db:"handed_at"`    // お渡し済みになった日時
 	CreatedAt       time.Time      `db:"created_at"`
 	UpdatedAt       time.Time      `db:"updated_at"`
+
+	Note       sql.NullString `db:"note"`        // お客様から厨房へのメモ
+	HasAllergy bool           `db:"has_allergy"` // アレルギーの申告あり
+
+	// 消費税。小計・値引き・合計は税込で、TaxAmountは合計に含まれる消費税額
+	DiningOption   DiningOption   `db:"dining_option"`
+	SubtotalAmount int            `db:"subtotal_amount"` // 税込の小計
+	TaxAmount      int            `db:"tax_amount"`
+	TaxLines       []OrderTaxLine `db:"-"` // 税率ごとの内訳（値引き後）
+
+	// クーポンの値引き（税込）。TotalAmountは小計から値引きを引いた額
+	DiscountAmount int             `db:"discount_amount"`
+	Discounts      []OrderDiscount `db:"-"` // 税率ごとの値引きの内訳
+}
+
+// 注文の税率ごとの小計と含まれる消費税額。端数は税率ごとに1回だけ切り捨てる
+type OrderTaxLine struct {
+	OrderID       int `json:"-" db:"order_id"`
+	TaxRate       int `json:"tax_rate" db:"tax_rate" example:"8"`                // %
+	TaxableAmount int `json:"taxable_amount" db:"taxable_amount" example:"1700"` // 税込の対象金額
+	TaxAmount     int `json:"tax_amount" db:"tax_amount" example:"125"`
+}
+
+// 注文に適用した値引き（クーポン・ポイント利用）の税率ごとの内訳。金額は税込
+type OrderDiscount struct {
+	OrderID     int            `json:"-" db:"order_id"`
+	Source      DiscountSource `json:"source" db:"source" swaggertype:"string" enums:"promotion,points" example:"promotion"`
+	PromotionID sql.NullInt64  `json:"-" db:"promotion_id"`                 // ポイント利用やクーポンが削除された場合はNULL
+	Code        string         `json:"code" db:"code" example:"WELCOME100"` // ポイント利用では空文字
+	Name        string         `json:"name" db:"name" example:"新入生100円引き"`
+	TaxRate     int            `json:"tax_rate" db:"tax_rate" example:"8"` // %
+	Amount      int            `json:"amount" db:"amount" example:"100"`
 }
 
 type Item struct {
-	ItemID      int       `json:"item_id" db:"item_id"`
-	ItemName    string    `json:"item_name" db:"item_name"`
-	Description string    `json:"description" db:"description"`
-	Price       int       `json:"price" db:"price"`
-	IsAvailable bool      `json:"is_available" db:"is_available"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	ItemID      int         `json:"item_id" db:"item_id"`
+	ItemName    string      `json:"item_name" db:"item_name"`
+	Description string      `json:"description" db:"description"`
+	Price       int         `json:"price" db:"price"`
+	IsAvailable bool        `json:"is_available" db:"is_available"`
+	PrepSeconds *int        `json:"prep_seconds" db:"prep_seconds"` // 店舗での調理時間（shop_itemから取得）。NULLの場合は店舗の既定調理時間を使う
+	TaxCategory TaxCategory `json:"tax_category" db:"tax_category"` // 消費税の区分
+	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at" db:"updated_at"`
+
+	ImageKey     *string `json:"-" db:"image_key"`     // BlobStoreのキー。NULLは画像なし
+	ThumbnailKey *string `json:"-" db:"thumbnail_key"` // BlobStoreのキー。NULLは画像なし
+
+	StockQuantity *int `json:"stock_quantity" db:"stock_quantity"` // 店舗の在庫数（shop_itemから取得）。NULLは在庫数を管理しない
+	CategoryID    *int `json:"category_id" db:"category_id"`       // 店舗でのカテゴリ（shop_itemから取得）。NULLはカテゴリなし
+
+	BasePrice           int                  `json:"-" db:"base_price"` // 店舗ごとの価格を適用する前のitems.price（Priceは店舗での価格）
+	TimeZone            string               `json:"-" db:"time_zone"`  // 店舗のタイムゾーン（shopsから取得）
+	AvailabilityWindows []AvailabilityWindow `json:"-" db:"-"`          // 店舗で注文できる時間帯。空ならいつでも注文できる
+}
+
+// 店舗の商品を注文できる時間帯。曜日ごとに、0時からの分で [StartMinute, EndMinute) を表す
+type AvailabilityWindow struct {
+	ItemID      int          `db:"item_id"`
+	DayOfWeek   time.Weekday `db:"day_of_week"`
+	StartMinute int          `db:"start_minute"`
+	EndMinute   int          `db:"end_minute"` // 1440は日の終わり（24:00）
 }
 
 type OrderItem struct {
@@ -159,11 +529,267 @@ type OrderItem struct {
 	PriceAtOrder int       `db:"price_at_order"`
 	CreatedAt    time.Time `db:"created_at"`
 	UpdatedAt    time.Time `db:"updated_at"`
+
+	ModifierPriceDelta int                  `db:"modifier_price_delta"` // 1個あたりのオプションとセットの選択肢の加算額の合計
+	Modifiers          []OrderItemModifier  `db:"-"`                    // 注文時に選ばれたオプション
+	Components         []OrderItemComponent `db:"-"`                    // セット商品の構成商品
+	Note               sql.NullString       `db:"note"`                 // 商品ごとのメモ
+	TaxRate            int                  `db:"tax_rate"`             // 注文時に適用した消費税率（%）。税を計算する前の注文は0
+}
+
+type ModifierGroup struct {
+	ModifierGroupID int                   `db:"modifier_group_id"`
+	ShopID          int                   `db:"shop_id"` // グループを登録した店舗。オプションは店舗ごとに設定する
+	ItemID          int                   `db:"item_id"`
+	Name            string                `db:"name"`
+	SelectionType   ModifierSelectionType `db:"selection_type"`
+	MinSelect       int                   `db:"min_select"`
+	MaxSelect       int                   `db:"max_select"`
+	SortOrder       int                   `db:"sort_order"`
+	Options         []ModifierOption      `db:"-"`
+	CreatedAt       time.Time             `db:"created_at"`
+	UpdatedAt       time.Time             `db:"updated_at"`
+}
+
+type ModifierOption struct {
+	ModifierOptionID int       `db:"modifier_option_id"`
+	ModifierGroupID  int       `db:"modifier_group_id"`
+	Name             string    `db:"name"`
+	PriceDelta       int       `db:"price_delta"` // 商品価格への加算額（値引きは負の値）
+	IsAvailable      bool      `db:"is_available"`
+	SortOrder        int       `db:"sort_order"`
+	CreatedAt        time.Time `db:"created_at"`
+	UpdatedAt        time.Time `db:"updated_at"`
+}
+
+// 注文時点のオプション名と価格を保存したもの
+type OrderItemModifier struct {
+	OrderItemModifierID int           `db:"order_item_modifier_id"`
+	OrderItemID         int           `db:"order_item_id"`
+	ModifierOptionID    sql.NullInt64 `db:"modifier_option_id"` // 選択肢が削除された場合はNULL
+	GroupName           string        `db:"group_name"`
+	OptionName          string        `db:"option_name"`
+	PriceDelta          int           `db:"price_delta"`
+}
+
+// セット商品の枠。選択肢が1つの枠は固定の構成商品、複数の枠はお客様が1つ選ぶ
+type BundleSlot struct {
+	BundleSlotID int            `db:"bundle_slot_id"`
+	ShopID       int            `db:"shop_id"` // 枠を設定した店舗。セットの構成は店舗ごとに設定する
+	ItemID       int            `db:"item_id"` // セット商品
+	Name         string         `db:"name"`
+	Quantity     int            `db:"quantity"` // セット1つあたりの構成商品の数
+	SortOrder    int            `db:"sort_order"`
+	Choices      []BundleChoice `db:"-"`
+	CreatedAt    time.Time      `db:"created_at"`
+}
+
+// セット商品の枠の選択肢となる構成商品。ItemName以降は店舗での構成商品の状態
+type BundleChoice struct {
+	BundleChoiceID int       `db:"bundle_choice_id"`
+	BundleSlotID   int       `db:"bundle_slot_id"`
+	ItemID         int       `db:"item_id"`     // 構成商品
+	PriceDelta     int       `db:"price_delta"` // セット価格への加算額（値引きは負の値）
+	SortOrder      int       `db:"sort_order"`
+	CreatedAt      time.Time `db:"created_at"`
+
+	ItemName            string               `db:"item_name"`
+	Price               int                  `db:"price"`          // 店舗で単品で注文した場合の価格
+	IsAvailable         bool                 `db:"is_available"`   // 販売停止中か、店舗で扱っていない場合はfalse
+	StockQuantity       *int                 `db:"stock_quantity"` // NULLは在庫数を管理しない
+	AvailabilityWindows []AvailabilityWindow `db:"-"`              // 店舗で注文できる時間帯
+}
+
+// 注文時点のセットの構成商品の名前と加算額を保存したもの
+type OrderItemComponent struct {
+	OrderItemComponentID int           `db:"order_item_component_id"`
+	OrderItemID          int           `db:"order_item_id"`
+	BundleChoiceID       sql.NullInt64 `db:"bundle_choice_id"` // セットの構成が変わった場合はNULL
+	ItemID               int           `db:"item_id"`
+	SlotName             string        `db:"slot_name"`
+	ItemName             string        `db:"item_name"`
+	Quantity             int           `db:"quantity"` // セット1つあたりの数
+	PriceDelta           int           `db:"price_delta"`
+}
+
+// 注文に対する決済。注文が削除されても記録は残す
+type Payment struct {
+	PaymentID         int            `db:"payment_id"`
+	OrderID           sql.NullInt64  `db:"order_id"` // 注文が削除された場合はNULL
+	Provider          string         `db:"provider"`
+	ProviderPaymentID sql.NullString `db:"provider_payment_id"` // 決済代行会社側のID。承認を依頼するまではNULL
+	Amount            int            `db:"amount"`              // 円単位の整数
+	Status            PaymentStatus  `db:"status"`
+	FailureReason     sql.NullString `db:"failure_reason"`
+	RefundedAmount    int            `db:"refunded_amount"` // 返金済みと返金依頼中の合計額。Amountを超えない
+	CreatedAt         time.Time      `db:"created_at"`
+	UpdatedAt         time.Time      `db:"updated_at"`
+}
+
+// 決済に対する返金。ItemsがRefundItemの合計になる
+type Refund struct {
+	RefundID         int            `db:"refund_id"`
+	PaymentID        int            `db:"payment_id"`
+	OrderID          sql.NullInt64  `db:"order_id"` // 注文が削除された場合はNULL
+	Amount           int            `db:"amount"`   // 円単位の整数
+	Reason           string         `db:"reason"`
+	StaffUserID      int            `db:"staff_user_id"`      // 返金を行った店舗スタッフ
+	ProviderRefundID sql.NullString `db:"provider_refund_id"` // 決済代行会社側の返金ID
+	Status           RefundStatus   `db:"status"`
+	Items            []RefundItem   `db:"-"`
+	CreatedAt        time.Time      `db:"created_at"`
+	UpdatedAt        time.Time      `db:"updated_at"`
+}
+
+type RefundItem struct {
+	RefundItemID int `db:"refund_item_id"`
+	RefundID     int `db:"refund_id"`
+	OrderItemID  int `db:"order_item_id"`
+	Quantity     int `db:"quantity"`
+	Amount       int `db:"amount"` // 注文時の単価（オプション込み） × Quantity の税込金額
 }
 
 type ShopItem struct {
-	ShopID    int       `db:"shop_id"`
-	ItemID    int       `db:"item_id"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ShopID        int       `db:"shop_id"`
+	ItemID        int       `db:"item_id"`
+	StockQuantity *int      `db:"stock_quantity"` // NULLは在庫数を管理しない
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+	CategoryID    *int      `db:"category_id"` // NULLはカテゴリなし
+}
+
+// 店舗の商品の価格変更。EffectiveAtを過ぎた最も新しい変更の価格が店舗での価格になる。
+// Priceがnilの変更は店舗ごとの価格をやめてitems.priceに戻す
+type PriceChange struct {
+	PriceChangeID int          `db:"price_change_id"`
+	ShopItemID    int          `db:"shop_item_id"`
+	ItemID        int          `db:"item_id"` // shop_itemから取得
+	Price         *int         `db:"price"`
+	EffectiveAt   time.Time    `db:"effective_at"`
+	AppliedAt     sql.NullTime `db:"applied_at"` // 反映した日時（EffectiveAt）。反映前の予定の変更ではNULL
+	CreatedAt     time.Time    `db:"created_at"`
+}
+
+// 店舗の商品カテゴリ。SortOrderの小さい順にメニューのタブとして表示する
+type Category struct {
+	CategoryID int       `db:"category_id"`
+	ShopID     int       `db:"shop_id"`
+	Name       string    `db:"name"`
+	SortOrder  int       `db:"sort_order"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// 商品名と説明の翻訳。説明がNULLの場合は元の説明を表示する
+type ItemTranslation struct {
+	ItemID      int       `json:"item_id" db:"item_id" example:"5"`
+	Lang        string    `json:"lang" db:"lang" example:"en"`
+	ItemName    string    `json:"item_name" db:"item_name" example:"Special Tonkotsu Ramen"`
+	Description *string   `json:"description" db:"description" example:"Rich pork bone broth simmered for 8 hours."`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// カテゴリ名の翻訳
+type CategoryTranslation struct {
+	CategoryID int       `json:"category_id" db:"category_id" example:"3"`
+	Lang       string    `json:"lang" db:"lang" example:"en"`
+	Name       string    `json:"name" db:"name" example:"Ramen"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// 店舗名と説明の翻訳。説明がNULLの場合は元の説明を表示する
+type ShopTranslation struct {
+	ShopID      int       `json:"shop_id" db:"shop_id" example:"2"`
+	Lang        string    `json:"lang" db:"lang" example:"en"`
+	Name        string    `json:"name" db:"name" example:"Motomachi Ramen Ichibanboshi"`
+	Description *string   `json:"description" db:"description" example:"Famous for our double soup of pork bone and seafood."`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// クーポン（プロモーションコード）。ShopIDがNULLのものは全店舗で使える
+type Promotion struct {
+	PromotionID   int           `db:"promotion_id"`
+	ShopID        sql.NullInt64 `db:"shop_id"`
+	Code          string        `db:"code"`
+	Name          string        `db:"name"`
+	DiscountType  DiscountType  `db:"discount_type"`
+	DiscountValue int           `db:"discount_value"` // 割引率（%）または値引き額（円）
+
+	MinSpend           int           `db:"min_spend"`            // 値引き前の小計（税込）の下限
+	MinItemQuantity    int           `db:"min_item_quantity"`    // 対象商品の合計数量の下限
+	MaxDiscountedUnits sql.NullInt64 `db:"max_discounted_units"` // 値引きする対象商品の数量の上限。安い順に選ぶ
+	StartsAt           sql.NullTime  `db:"starts_at"`
+	EndsAt             sql.NullTime  `db:"ends_at"`
+
+	UsageLimitTotal   sql.NullInt64 `db:"usage_limit_total"`
+	UsageLimitPerUser sql.NullInt64 `db:"usage_limit_per_user"` // 設定されたクーポンはログインしたユーザーだけが使える
+	UsedCount         int           `db:"used_count"`
+	IsActive          bool          `db:"is_active"`
+	ItemIDs           []int         `db:"-"` // 対象商品。空の場合はすべての商品が対象
+	CreatedAt         time.Time     `db:"created_at"`
+	UpdatedAt         time.Time     `db:"updated_at"`
+}
+
+// ポイントの台帳の1行。獲得と返還の行は有効期限と使われていない残りを持つ
+type PointTransaction struct {
+	PointTransactionID int                  `db:"point_transaction_id"`
+	UserID             int                  `db:"user_id"`
+	OrderID            sql.NullInt64        `db:"order_id"` // 失効の行や、注文が削除された場合はNULL
+	ShopID             sql.NullInt64        `db:"shop_id"`
+	Kind               PointTransactionKind `db:"kind"`
+	Points             int                  `db:"points"` // 獲得・返還は正、利用・失効は負
+	Remaining          int                  `db:"remaining"`
+	ExpiresAt          sql.NullTime         `db:"expires_at"`
+	CreatedAt          time.Time            `db:"created_at"`
+}
+
+// 売上レポートの集計期間ごとの1行。金額は円単位で、返金は元の注文の期間に計上する
+type SalesPeriod struct {
+	PeriodStart    time.Time `db:"period_start"` // 日本時間での期間の初日
+	OrderCount     int       `db:"order_count"`
+	ItemsSold      int       `db:"items_sold"`
+	SubtotalAmount int       `db:"subtotal_amount"` // 値引き前の小計（税込）
+	DiscountAmount int       `db:"discount_amount"`
+	TaxAmount      int       `db:"tax_amount"`
+	TotalAmount    int       `db:"total_amount"`    // 税込
+	RefundedAmount int       `db:"refunded_amount"` // 税込
+}
+
+// 商品ごとの売上。金額は注文時の単価（オプション込み） × 数量（税込）で、注文全体の値引きは含まない
+type ItemSales struct {
+	ItemID      int    `db:"item_id"`
+	ItemName    string `db:"item_name"`
+	Quantity    int    `db:"quantity"`
+	OrderCount  int    `db:"order_count"`
+	SalesAmount int    `db:"sales_amount"`
+}
+
+// 調理完了した注文の商品ごとの1行。注文日時・調理完了日時・お渡し日時から厨房の所要時間を集計する
+type KitchenTiming struct {
+	OrderID     int          `db:"order_id"`
+	ItemID      int          `db:"item_id"`
+	ItemName    string       `db:"item_name"`
+	OrderDate   time.Time    `db:"order_date"`
+	CompletedAt time.Time    `db:"completed_at"`
+	HandedAt    sql.NullTime `db:"handed_at"` // お渡し前の注文や、お渡し日時を記録する前にお渡しした注文はNULL
+}
+
+// 会計向けに書き出す注文の商品ごとの1行。金額は円単位で、単価・オプション加算額も税込
+type OrderExportLine struct {
+	OrderID            int
+	OrderDate          time.Time
+	Status             OrderStatus
+	DiningOption       DiningOption
+	CustomerEmail      sql.NullString // ゲスト注文ではNULL
+	GuestOrderToken    sql.NullString // ゲスト注文のみ
+	SubtotalAmount     int            // 注文全体の値引き前の小計（税込）
+	DiscountAmount     int
+	TaxAmount          int
+	TotalAmount        int // 注文全体の合計（税込）
+	ItemID             int
+	ItemName           string
+	Quantity           int
+	PriceAtOrder       int
+	ModifierPriceDelta int
+	TaxRate            int // %
 }
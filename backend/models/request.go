@@ -4,10 +4,25 @@ import "time"
 
 type CreateOrderRequest struct {
 	Items []OrderItemRequest `json:"items" validate:"required,min=1,dive"`
+
+	Note       string `json:"note,omitempty" validate:"max=200" example:"えびアレルギーがあります。箸は不要です。"` // 厨房へのメモ
+	HasAllergy bool   `json:"has_allergy,omitempty" example:"true"`                             // アレルギーの申告
+
+	PaymentToken string `json:"payment_token,omitempty" validate:"max=255" example:"tok_visa"` // 決済代行会社がカード情報をトークン化したもの
+
+	DiningOption DiningOption `json:"dining_option,omitempty" swaggertype:"string" enums:"takeout,dine_in" example:"takeout"` // 省略時は持ち帰り
+
+	PromotionCode string `json:"promotion_code,omitempty" validate:"max=32" example:"WELCOME100"` // クーポンコード（大文字小文字は区別しない）
+
+	UsePoints int `json:"use_points,omitempty" validate:"min=0,max=1000000" example:"100"` // 使うポイント（1ポイント1円）。ログインしたユーザーだけが使える
 }
 type OrderItemRequest struct {
 	ItemID   int `json:"item_id" validate:"required,min=1" example:"1"`
 	Quantity int `json:"quantity" validate:"required,min=1" example:"2"`
+
+	ModifierOptionIDs []int  `json:"modifier_option_ids,omitempty" validate:"omitempty,max=50,dive,min=1"` // 選択したオプションのID
+	BundleChoiceIDs   []int  `json:"bundle_choice_ids,omitempty" validate:"omitempty,max=20,dive,min=1"`   // セット商品で選んだ選択肢のID。選択肢が1つの枠は省略できる
+	Note              string `json:"note,omitempty" validate:"max=100" example:"辛さ控えめで"`                   // 商品ごとのメモ
 }
 
 type AuthenticateRequest struct {
@@ -27,3 +42,194 @@ type LoginAttempt struct {
 type UpdateItemAvailabilityRequest struct {
 	IsAvailable bool `json:"is_available" validate:"required" example:"false"`
 }
+
+// 店舗の緯度経度更新リクエスト
+type UpdateShopCoordinatesRequest struct {
+	Latitude  *float64 `json:"latitude" validate:"required,min=-90,max=90" example:"34.7256"`
+	Longitude *float64 `json:"longitude" validate:"required,min=-180,max=180" example:"135.2352"`
+}
+
+// 店舗の適格請求書発行事業者の登録番号更新リクエスト（nullで登録番号を削除する）
+type UpdateInvoiceRegistrationNumberRequest struct {
+	InvoiceRegistrationNumber *string `json:"invoice_registration_number" validate:"omitempty,len=14" example:"T1234567890123"`
+}
+
+// 商品の調理時間更新リクエスト（nullで店舗の既定値に戻す）
+type UpdateItemPrepTimeRequest struct {
+	PrepSeconds *int `json:"prep_seconds" validate:"omitempty,min=1,max=7200" example:"420"`
+}
+
+// 店舗のポイント還元率更新リクエスト（0でポイントを付与しない）
+type UpdateShopPointRateRequest struct {
+	PointRate *int `json:"point_rate" validate:"required,min=0,max=100" example:"1"` // 値引き後の合計から消費税を除いた金額100円につき付与するポイント
+}
+
+// 店舗の既定調理時間更新リクエスト
+type UpdateShopPrepTimeRequest struct {
+	DefaultPrepSeconds int `json:"default_prep_seconds" validate:"required,min=1,max=7200" example:"300"`
+}
+
+// 店舗のタイムゾーン更新リクエスト。商品の販売時間帯はこのタイムゾーンで判定する
+type UpdateShopTimeZoneRequest struct {
+	TimeZone string `json:"time_zone" validate:"required,timezone" example:"Asia/Tokyo"`
+}
+
+// 商品を注文できる時間帯の更新リクエスト（空の配列でいつでも注文できるようにする）
+type UpdateItemAvailabilityScheduleRequest struct {
+	Windows []AvailabilityWindowRequest `json:"windows" validate:"required,max=50,dive"`
+}
+
+// 商品を注文できる時間帯。日をまたぐ時間帯は2つに分けて指定する
+type AvailabilityWindowRequest struct {
+	Days  []int  `json:"days" validate:"required,min=1,max=7,dive,min=0,max=6" example:"1,2,3,4,5"` // 0=日曜〜6=土曜
+	Start string `json:"start" validate:"required,datetime=15:04" example:"11:00"`
+	End   string `json:"end" validate:"required,datetime=15:04|eq=24:00" example:"14:00"` // 24:00で日の終わりまで
+}
+
+// 店舗の商品の価格変更リクエスト。priceをnullにすると全店舗共通の価格に戻し、effective_atを省略するとすぐに反映する
+type SchedulePriceChangeRequest struct {
+	Price       *int       `json:"price" validate:"omitempty,min=0,max=1000000" example:"800"`
+	EffectiveAt *time.Time `json:"effective_at" example:"2025-10-01T00:00:00+09:00"`
+}
+
+// 商品のアレルゲンと食事の制限の更新リクエスト。それぞれ全て置き換わり、空の配列にすると削除する
+type UpdateItemDietaryInfoRequest struct {
+	Allergens   []string `json:"allergens" validate:"required,unique,dive,oneof=shrimp crab walnut wheat buckwheat egg milk peanut" example:"wheat,egg"`
+	DietaryTags []string `json:"dietary_tags" validate:"required,unique,dive,oneof=vegetarian vegan halal gluten_free" example:"vegetarian"`
+}
+
+// 商品名と説明の翻訳の登録リクエスト。descriptionを省略すると元の説明を表示する
+type ItemTranslationRequest struct {
+	ItemName    string  `json:"item_name" validate:"required,max=255" example:"Special Tonkotsu Ramen"`
+	Description *string `json:"description" validate:"omitempty,max=1000" example:"Rich pork bone broth simmered for 8 hours."`
+}
+
+// カテゴリ名の翻訳の登録リクエスト
+type CategoryTranslationRequest struct {
+	Name string `json:"name" validate:"required,max=255" example:"Ramen"`
+}
+
+// 店舗名と説明の翻訳の登録リクエスト。descriptionを省略すると元の説明を表示する
+type ShopTranslationRequest struct {
+	Name        string  `json:"name" validate:"required,max=255" example:"Motomachi Ramen Ichibanboshi"`
+	Description *string `json:"description" validate:"omitempty,max=1000" example:"Famous for our double soup of pork bone and seafood."`
+}
+
+// 商品の在庫数更新リクエスト（nullで在庫数の管理をやめる）
+type UpdateItemStockRequest struct {
+	StockQuantity *int `json:"stock_quantity" validate:"omitempty,min=0,max=100000" example:"30"`
+}
+
+// 商品のオプショングループ作成リクエスト
+type CreateModifierGroupRequest struct {
+	Name          string                        `json:"name" validate:"required,max=255" example:"サイズ"`
+	SelectionType ModifierSelectionType         `json:"selection_type" validate:"required" swaggertype:"string" enums:"single,multi" example:"single"`
+	MinSelect     int                           `json:"min_select" validate:"min=0,max=50" example:"1"`
+	MaxSelect     int                           `json:"max_select" validate:"required,min=1,max=50,gtefield=MinSelect" example:"1"`
+	SortOrder     int                           `json:"sort_order" example:"0"`
+	Options       []CreateModifierOptionRequest `json:"options" validate:"required,min=1,max=50,dive"`
+}
+
+type CreateModifierOptionRequest struct {
+	Name       string `json:"name" validate:"required,max=255" example:"大盛り"`
+	PriceDelta int    `json:"price_delta" validate:"min=-100000,max=100000" example:"100"`
+	SortOrder  int    `json:"sort_order" example:"0"`
+}
+
+// セット商品の構成の更新リクエスト。枠は全て置き換わり、空の配列にするとセットではない商品に戻る
+type UpdateItemBundleRequest struct {
+	Slots []BundleSlotRequest `json:"slots" validate:"required,max=10,dive"`
+}
+
+// セット商品の枠。選択肢が1つなら固定の構成商品、複数ならお客様が1つ選ぶ
+type BundleSlotRequest struct {
+	Name      string                `json:"name" validate:"required,max=255" example:"ラーメン"`
+	Quantity  int                   `json:"quantity" validate:"omitempty,min=1,max=10" example:"1"` // セット1つあたりの数。省略すると1
+	SortOrder int                   `json:"sort_order" example:"0"`
+	Choices   []BundleChoiceRequest `json:"choices" validate:"required,min=1,max=20,dive"`
+}
+
+type BundleChoiceRequest struct {
+	ItemID     int `json:"item_id" validate:"required,min=1" example:"5"`               // 店舗で扱っている商品
+	PriceDelta int `json:"price_delta" validate:"min=-100000,max=100000" example:"100"` // セット価格への加算額
+	SortOrder  int `json:"sort_order" example:"0"`
+}
+
+// 返金リクエスト。Itemsを省略すると、まだ返金していない分を全額返金する
+type CreateRefundRequest struct {
+	Reason string              `json:"reason" validate:"required,max=255" example:"品切れのため"`
+	Items  []RefundItemRequest `json:"items,omitempty" validate:"omitempty,max=100,dive"`
+}
+
+type RefundItemRequest struct {
+	OrderItemID int `json:"order_item_id" validate:"required,min=1" example:"12"`
+	Quantity    int `json:"quantity" validate:"required,min=1" example:"1"`
+}
+
+// クーポン作成リクエスト。ItemIDsを省略するとすべての商品が対象になる
+type CreatePromotionRequest struct {
+	Code          string       `json:"code" validate:"required,alphanum,max=32" example:"WELCOME100"`
+	Name          string       `json:"name" validate:"required,max=255" example:"新入生100円引き"`
+	DiscountType  DiscountType `json:"discount_type" validate:"required" swaggertype:"string" enums:"percentage,fixed" example:"fixed"`
+	DiscountValue int          `json:"discount_value" validate:"required,min=1,max=100000" example:"100"` // percentageは1〜100（%）、fixedは円
+
+	MinSpend           int        `json:"min_spend" validate:"min=0,max=1000000" example:"500"`
+	MinItemQuantity    int        `json:"min_item_quantity" validate:"min=0,max=100" example:"0"`
+	MaxDiscountedUnits *int       `json:"max_discounted_units,omitempty" validate:"omitempty,min=1,max=100" example:"1"`
+	StartsAt           *time.Time `json:"starts_at,omitempty" example:"2025-04-01T00:00:00+09:00"`
+	EndsAt             *time.Time `json:"ends_at,omitempty" example:"2025-04-30T23:59:59+09:00"`
+
+	UsageLimitTotal   *int  `json:"usage_limit_total,omitempty" validate:"omitempty,min=1" example:"500"`
+	UsageLimitPerUser *int  `json:"usage_limit_per_user,omitempty" validate:"omitempty,min=1" example:"1"`
+	ItemIDs           []int `json:"item_ids,omitempty" validate:"omitempty,max=100,dive,min=1"`
+}
+
+// 売上レポートの条件。日付は日本時間で、fromとtoの日を含む。省略すると直近30日
+type ReportQuery struct {
+	From    string `query:"from" validate:"omitempty,datetime=2006-01-02" example:"2025-04-01"`
+	To      string `query:"to" validate:"omitempty,datetime=2006-01-02" example:"2025-04-30"`
+	GroupBy string `query:"group_by" validate:"omitempty,oneof=day week month" example:"day"` // 省略するとday
+}
+
+// 厨房の所要時間の集計条件。期間の指定はReportQueryと同じ。uncollected_minutesを省略すると15分
+type KitchenMetricsQuery struct {
+	From               string `query:"from" validate:"omitempty,datetime=2006-01-02" example:"2025-04-01"`
+	To                 string `query:"to" validate:"omitempty,datetime=2006-01-02" example:"2025-04-30"`
+	UncollectedMinutes int    `query:"uncollected_minutes" validate:"omitempty,min=1,max=1440" example:"15"` // 調理完了からこの時間を過ぎても受け取られていない注文を返す
+}
+
+// 商品一覧の絞り込み条件と応答する言語。絞り込み条件はクエリパラメータでそれぞれカンマ区切りで指定する
+type ItemListQuery struct {
+	ExcludeAllergens []string `query:"exclude_allergens" validate:"unique,dive,oneof=shrimp crab walnut wheat buckwheat egg milk peanut" example:"shrimp,crab"` // いずれかを含む商品を除く
+	DietaryTags      []string `query:"dietary_tags" validate:"unique,dive,oneof=vegetarian vegan halal gluten_free" example:"vegetarian"`                       // 全てに対応している商品だけを残す
+	Lang             string   // langかAccept-Languageで決めた言語。ja以外では翻訳のある商品名・説明・カテゴリ名を翻訳する
+}
+
+// 注文の書き出しの条件。期間の指定はReportQueryと同じ。formatを省略するとcsv
+type OrderExportQuery struct {
+	Format string `query:"format" validate:"omitempty,oneof=csv xlsx" example:"csv"`
+	From   string `query:"from" validate:"omitempty,datetime=2006-01-02" example:"2025-04-01"`
+	To     string `query:"to" validate:"omitempty,datetime=2006-01-02" example:"2025-04-30"`
+}
+
+// メニューファイル（CSV・JSON）の1行。item_idを省略すると新しい商品として登録し、指定すると店舗の商品を更新する
+type MenuItemRow struct {
+	ItemID        *int   `json:"item_id,omitempty" validate:"omitempty,min=1" example:"1"`
+	ItemName      string `json:"item_name" validate:"required,max=255" example:"唐揚げ定食"`
+	Description   string `json:"description" validate:"max=1000" example:"国産鶏もも肉の唐揚げ"`
+	Price         int    `json:"price" validate:"min=0,max=1000000" example:"800"`                    // 税込
+	TaxCategory   string `json:"tax_category" validate:"required,oneof=food standard" example:"food"` // 消費税の区分
+	IsAvailable   *bool  `json:"is_available,omitempty" example:"true"`                               // 省略すると、新しい商品は販売中、既存の商品は変更しない
+	StockQuantity *int   `json:"stock_quantity" validate:"omitempty,min=0" example:"20"`              // nullは在庫数を管理しない
+}
+
+// 商品カテゴリの登録・更新
+type CategoryRequest struct {
+	Name      string `json:"name" validate:"required,max=100" example:"ラーメン"`
+	SortOrder int    `json:"sort_order" validate:"min=0,max=10000" example:"1"` // 小さいほど先に表示する
+}
+
+// 店舗の商品のカテゴリを設定する。nullでカテゴリなしに戻す
+type AssignItemCategoryRequest struct {
+	CategoryID *int `json:"category_id" validate:"omitempty,min=1" example:"1"`
+}
@@ -1,12 +1,30 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+)
 
 // 注文作成レスポンス
 type CreateOrderResponse struct {
 	OrderID         int    `json:"order_id" example:"6"`
 	GuestOrderToken string `json:"guest_order_token" example:"15ff4999-2cfd-41f3-b744-926e7c5c7a0"`
+	Status          string `json:"status" example:"cooking"` // 決済の確定を待っている場合は"pending_payment"
 	Message         string `json:"message" example:"Order created successfully as a guest. Please sign up to claim this order."`
+
+	OrderAmountResponse
+}
+
+// 注文の金額と消費税の内訳。商品の価格は税込で、TotalAmountは小計から値引きを引いた額。TaxAmountは合計に含まれる消費税額
+type OrderAmountResponse struct {
+	DiningOption   string          `json:"dining_option" example:"takeout"` // "takeout" or "dine_in"
+	SubtotalAmount int             `json:"subtotal_amount" example:"1700"`
+	DiscountAmount int             `json:"discount_amount" example:"100"` // クーポンの値引き（税込）
+	TaxAmount      int             `json:"tax_amount" example:"118"`
+	TotalAmount    int             `json:"total_amount" example:"1600"`
+	TaxBreakdown   []OrderTaxLine  `json:"tax_breakdown"` // 税率ごとの値引き後の小計と消費税額
+	Discounts      []OrderDiscount `json:"discounts"`     // 税率ごとの値引きの内訳
 }
 
 // ユーザー作成レスポンス
@@ -31,11 +49,42 @@ type OrderListResponse struct {
 	Status       string       `json:"status"` // "cooking" or "completed"
 	WaitingCount int          `json:"waiting_count"`
 	Items        []ItemDetail `json:"items"`
+
+	EstimatedReadyAt *time.Time `json:"estimated_ready_at"` // 受け取り予定時刻の推定値。調理完了済みの場合は完了日時
+
+	DiningOption   string         `json:"dining_option"`
+	SubtotalAmount int            `json:"subtotal_amount"`
+	TaxAmount      int            `json:"tax_amount"`
+	TaxBreakdown   []OrderTaxLine `json:"tax_breakdown"` // 税を計算する前の注文では空
+
+	DiscountAmount int             `json:"discount_amount"` // クーポンの値引き（税込）
+	Discounts      []OrderDiscount `json:"discounts"`
 }
 
 type ItemDetail struct {
-	ItemName string `json:"item_name"`
-	Quantity int    `json:"quantity"`
+	OrderItemID int    `json:"order_item_id,omitempty"` // 部分返金で返金する商品を指定するときに使う
+	ItemID      int    `json:"item_id"`
+	ItemName    string `json:"item_name"`
+	Quantity    int    `json:"quantity"`
+
+	Modifiers  []ItemModifierDetail  `json:"modifiers,omitempty"`  // 選択されたオプション
+	Components []ItemComponentDetail `json:"components,omitempty"` // セット商品の構成商品。厨房ではこちらを調理する
+	Note       *string               `json:"note,omitempty"`       // 商品ごとのメモ
+}
+
+// 注文したセット商品の構成商品
+type ItemComponentDetail struct {
+	SlotName   string `json:"slot_name" example:"ラーメン"`
+	ItemName   string `json:"item_name" example:"味玉つけ麺"`
+	Quantity   int    `json:"quantity" example:"1"` // セット1つあたりの数
+	PriceDelta int    `json:"price_delta" example:"100"`
+}
+
+// 注文商品に選択されたオプション
+type ItemModifierDetail struct {
+	GroupName  string `json:"group_name" example:"サイズ"`
+	OptionName string `json:"option_name" example:"大盛り"`
+	PriceDelta int    `json:"price_delta" example:"100"`
 }
 
 // 注文のステータスと待ち人数表示レスポンス
@@ -43,6 +92,8 @@ type OrderStatusResponse struct {
 	OrderID      int    `json:"order_id"`
 	Status       string `json:"status"`
 	WaitingCount int    `json:"waiting_count"`
+
+	EstimatedReadyAt *time.Time `json:"estimated_ready_at"` // 受け取り予定時刻の推定値。調理完了済みの場合は完了日時
 }
 
 type LoginResponse struct {
@@ -54,8 +105,116 @@ type ItemListResponse struct {
 	ItemID      int    `json:"item_id"`
 	ItemName    string `json:"item_name"`
 	Description string `json:"description"`
-	Price       int    `json:"price"`
+	Price       int    `json:"price"` // 税込
 	IsAvailable bool   `json:"is_available"`
+
+	StockQuantity  *int                    `json:"stock_quantity"`                                          // 残り在庫数。在庫数を管理していない商品ではnull
+	CategoryID     *int                    `json:"category_id"`                                             // カテゴリのない商品ではnull
+	TaxCategory    TaxCategory             `json:"tax_category" swaggertype:"string" enums:"food,standard"` // 価格は税込。foodは持ち帰りで8%、店内飲食で10%
+	ModifierGroups []ModifierGroupResponse `json:"modifier_groups,omitempty"`                               // 選択できるオプション
+	Bundle         *BundleResponse         `json:"bundle,omitempty"`                                        // セット商品の構成。セットでない商品では省略
+	Allergens      []string                `json:"allergens" example:"wheat,egg"`                           // 含まれる特定原材料（8品目）。含まないか未登録の場合は空の配列
+	DietaryTags    []string                `json:"dietary_tags" example:"vegetarian"`                       // 対応している食事の制限
+
+	ImageURL     *string `json:"image_url" example:"https://cdn.example.com/items/1/9f1c.jpg"`           // 画像のない商品ではnull
+	ThumbnailURL *string `json:"thumbnail_url" example:"https://cdn.example.com/items/1/9f1c_thumb.jpg"` // 長辺320pxまでのサムネイル
+
+	AvailabilityWindows []AvailabilityWindowResponse `json:"availability_windows,omitempty"`                        // 注文できる時間帯。いつでも注文できる商品では省略
+	NextAvailableAt     *time.Time                   `json:"next_available_at" example:"2025-07-01T07:00:00+09:00"` // 時間帯の外で、次に注文できるようになる日時。時間帯の中や設定のない商品ではnull
+}
+
+// 店舗の商品の価格変更
+type PriceChangeResponse struct {
+	PriceChangeID int        `json:"price_change_id" example:"1"`
+	ItemID        int        `json:"item_id" example:"3"`
+	Price         *int       `json:"price" example:"800"`                         // nullは全店舗共通の価格に戻す変更
+	EffectiveAt   time.Time  `json:"effective_at" example:"2025-10-01T00:00:00Z"` // 反映する日時（UTC）
+	AppliedAt     *time.Time `json:"applied_at" example:"2025-10-01T00:00:03Z"`   // 反映した日時。予定の変更ではnull
+	CreatedAt     time.Time  `json:"created_at" example:"2025-09-20T10:00:00Z"`
+}
+
+// 店舗の商品の価格と、価格変更の履歴・予定
+type PriceHistoryResponse struct {
+	ItemID       int                   `json:"item_id" example:"3"`
+	BasePrice    int                   `json:"base_price" example:"750"`    // 全店舗共通の価格（items.price）
+	CurrentPrice int                   `json:"current_price" example:"800"` // この店舗で現在販売している価格
+	Changes      []PriceChangeResponse `json:"changes"`                     // 反映する日時の新しい順
+}
+
+// 商品のアレルゲンと食事の制限
+type ItemDietaryInfoResponse struct {
+	ItemID      int      `json:"item_id" example:"1"`
+	Allergens   []string `json:"allergens" example:"wheat,egg"`
+	DietaryTags []string `json:"dietary_tags" example:"vegetarian"`
+}
+
+// 商品の販売時間帯の更新結果
+type ItemAvailabilityScheduleResponse struct {
+	ItemID  int                          `json:"item_id" example:"1"`
+	Windows []AvailabilityWindowResponse `json:"windows"` // 空の場合はいつでも注文できる
+}
+
+// 商品を注文できる時間帯（店舗のタイムゾーンの時刻）
+type AvailabilityWindowResponse struct {
+	DayOfWeek int    `json:"day_of_week" example:"1"` // 0=日曜〜6=土曜
+	Start     string `json:"start" example:"07:00"`
+	End       string `json:"end" example:"10:00"` // 24:00は日の終わり
+}
+
+// 商品のオプショングループ
+type ModifierGroupResponse struct {
+	ModifierGroupID int                      `json:"modifier_group_id" example:"1"`
+	Name            string                   `json:"name" example:"サイズ"`
+	SelectionType   string                   `json:"selection_type" example:"single"`
+	MinSelect       int                      `json:"min_select" example:"1"`
+	MaxSelect       int                      `json:"max_select" example:"1"`
+	Options         []ModifierOptionResponse `json:"options"`
+}
+
+type ModifierOptionResponse struct {
+	ModifierOptionID int    `json:"modifier_option_id" example:"1"`
+	Name             string `json:"name" example:"大盛り"`
+	PriceDelta       int    `json:"price_delta" example:"100"`
+	IsAvailable      bool   `json:"is_available" example:"true"`
+}
+
+// セット商品の構成。セットの価格は商品のprice
+type BundleResponse struct {
+	RegularPrice int                  `json:"regular_price" example:"1350"` // 各枠の最初の選択肢を単品で注文した場合の合計
+	Slots        []BundleSlotResponse `json:"slots"`
+}
+
+// セット商品の構成の更新結果
+type ItemBundleResponse struct {
+	ItemID int                  `json:"item_id" example:"16"`
+	Slots  []BundleSlotResponse `json:"slots"` // 空の場合はセットではない商品
+}
+
+type BundleSlotResponse struct {
+	BundleSlotID int                    `json:"bundle_slot_id" example:"1"`
+	Name         string                 `json:"name" example:"ラーメン"`
+	Quantity     int                    `json:"quantity" example:"1"` // セット1つあたりの数
+	Choices      []BundleChoiceResponse `json:"choices"`              // 1つだけの場合は固定の構成商品
+}
+
+type BundleChoiceResponse struct {
+	BundleChoiceID int    `json:"bundle_choice_id" example:"1"`
+	ItemID         int    `json:"item_id" example:"5"`
+	ItemName       string `json:"item_name" example:"特製豚骨ラーメン"`
+	PriceDelta     int    `json:"price_delta" example:"0"`
+	IsAvailable    bool   `json:"is_available" example:"true"` // 売り切れや販売時間帯の外ではfalse
+}
+
+// 距離検索の店舗一覧レスポンス
+type NearbyShopResponse struct {
+	ShopID         int     `json:"shop_id" example:"1"`
+	Name           string  `json:"name" example:"A4食堂"`
+	Description    string  `json:"description" example:"安くて美味しい、学生街の定食屋です。"`
+	Location       string  `json:"location" example:"神戸市灘区六甲台町1-1"`
+	IsOpen         bool    `json:"is_open" example:"true"`
+	Latitude       float64 `json:"latitude" example:"34.7256"`
+	Longitude      float64 `json:"longitude" example:"135.2352"`
+	DistanceMeters float64 `json:"distance_meters" example:"412.5"`
 }
 
 type AdminOrderResponse struct {
@@ -65,8 +224,252 @@ type AdminOrderResponse struct {
 	TotalAmount   int          `json:"total_amount"`
 	Status        string       `json:"status"`
 	Items         []ItemDetail `json:"items"`
+
+	// 厨房で目立たせる情報。NeedsAttentionはアレルギー申告かメモ（注文・商品）がある場合にtrue
+	Note           *string `json:"note"`
+	HasAllergy     bool    `json:"has_allergy"`
+	NeedsAttention bool    `json:"needs_attention"`
+
+	RefundedAmount int    `json:"refunded_amount"` // 返金済みの合計額
+	DiningOption   string `json:"dining_option"`   // "takeout" or "dine_in"
 }
 
 type AuthenticatedOrderResponse struct {
-	OrderID uint `json:"order_id"`
+	OrderID uint   `json:"order_id"`
+	Status  string `json:"status" example:"cooking"` // 決済の確定を待っている場合は"pending_payment"
+
+	OrderAmountResponse
+}
+
+// 返金結果
+type RefundResponse struct {
+	RefundID      int                  `json:"refund_id" example:"1"`
+	OrderID       int                  `json:"order_id" example:"10"`
+	Amount        int                  `json:"amount" example:"800"`
+	Reason        string               `json:"reason" example:"品切れのため"`
+	StaffUserID   int                  `json:"staff_user_id" example:"2"`
+	Items         []RefundItemResponse `json:"items"`
+	RefundedTotal int                  `json:"refunded_total" example:"800"` // この返金を含む、注文の返金済み合計額
+	PaidAmount    int                  `json:"paid_amount" example:"1500"`
+	CreatedAt     time.Time            `json:"created_at"`
+}
+
+type RefundItemResponse struct {
+	OrderItemID int    `json:"order_item_id" example:"12"`
+	ItemName    string `json:"item_name" example:"唐揚げ定食"`
+	Quantity    int    `json:"quantity" example:"1"`
+	Amount      int    `json:"amount" example:"800"`
+}
+
+// 領収書。登録番号と税率ごとの内訳がそろっている場合は適格請求書（インボイス）として扱える
+type ReceiptResponse struct {
+	OrderID                   int                   `json:"order_id" example:"10"`
+	Recipient                 string                `json:"recipient" example:"株式会社サンプル"` // 宛名。指定がなければ空
+	ShopName                  string                `json:"shop_name" example:"A4食堂"`
+	ShopLocation              string                `json:"shop_location" example:"神戸市灘区六甲台町1-1"`
+	InvoiceRegistrationNumber *string               `json:"invoice_registration_number" example:"T1234567890123"` // 店舗が未登録の場合はnull
+	IsQualifiedInvoice        bool                  `json:"is_qualified_invoice"`
+	TransactionDate           time.Time             `json:"transaction_date"` // 注文日時
+	IssuedAt                  time.Time             `json:"issued_at"`
+	DiningOption              string                `json:"dining_option" example:"takeout"` // "takeout" or "dine_in"
+	Lines                     []ReceiptLineResponse `json:"lines"`
+	SubtotalAmount            int                   `json:"subtotal_amount" example:"1700"`
+	DiscountAmount            int                   `json:"discount_amount" example:"0"`
+	TaxAmount                 int                   `json:"tax_amount" example:"125"`
+	TotalAmount               int                   `json:"total_amount" example:"1700"`
+	TaxBreakdown              []OrderTaxLine        `json:"tax_breakdown"` // 税率ごとの値引き後の小計（税込）と含まれる消費税額
+	Discounts                 []OrderDiscount       `json:"discounts"`     // クーポンとポイントの値引きの税率ごとの内訳（税込）
+}
+
+// 領収書の明細行。金額は税込
+type ReceiptLineResponse struct {
+	ItemName      string `json:"item_name" example:"唐揚げ定食"`
+	Quantity      int    `json:"quantity" example:"2"`
+	UnitPrice     int    `json:"unit_price" example:"850"`
+	Amount        int    `json:"amount" example:"1700"`
+	TaxRate       int    `json:"tax_rate" example:"8"`
+	IsReducedRate bool   `json:"is_reduced_rate"` // 軽減税率の対象
+}
+
+// クーポン。shop_idがnullのものは全店舗で使える
+type PromotionResponse struct {
+	PromotionID   int    `json:"promotion_id" example:"1"`
+	ShopID        *int   `json:"shop_id" example:"1"`
+	Code          string `json:"code" example:"WELCOME100"`
+	Name          string `json:"name" example:"新入生100円引き"`
+	DiscountType  string `json:"discount_type" example:"fixed"` // "percentage" or "fixed"
+	DiscountValue int    `json:"discount_value" example:"100"`
+
+	MinSpend           int        `json:"min_spend" example:"500"`
+	MinItemQuantity    int        `json:"min_item_quantity" example:"0"`
+	MaxDiscountedUnits *int       `json:"max_discounted_units"`
+	StartsAt           *time.Time `json:"starts_at"`
+	EndsAt             *time.Time `json:"ends_at"`
+
+	UsageLimitTotal   *int  `json:"usage_limit_total" example:"500"`
+	UsageLimitPerUser *int  `json:"usage_limit_per_user" example:"1"`
+	UsedCount         int   `json:"used_count" example:"12"`
+	IsActive          bool  `json:"is_active" example:"true"`
+	ItemIDs           []int `json:"item_ids"` // 空の場合はすべての商品が対象
+}
+
+// ポイントの残高と履歴
+type PointsResponse struct {
+	Balance        int                        `json:"balance" example:"320"`
+	NextExpiration *PointExpirationResponse   `json:"next_expiration"` // 最も早く失効するポイント。残高がない場合はnull
+	History        []PointTransactionResponse `json:"history"`         // 新しい順
+}
+
+type PointExpirationResponse struct {
+	Points    int       `json:"points" example:"120"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type PointTransactionResponse struct {
+	Kind      string     `json:"kind" example:"earn"` // "earn", "redeem", "expire" or "restore"
+	Points    int        `json:"points" example:"12"` // 獲得・返還は正、利用・失効は負
+	OrderID   *int       `json:"order_id" example:"10"`
+	ShopID    *int       `json:"shop_id" example:"1"`
+	ExpiresAt *time.Time `json:"expires_at"` // 獲得・返還したポイントの有効期限
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// 売上レポート。revenueは税込の売上、net_salesは値引き後の売上から消費税を除いた額
+type SalesReportResponse struct {
+	ShopID  int                `json:"shop_id" example:"1"`
+	From    string             `json:"from" example:"2025-04-01"`
+	To      string             `json:"to" example:"2025-04-30"`
+	GroupBy string             `json:"group_by" example:"day"` // "day", "week" or "month"
+	Summary SalesReportEntry   `json:"summary"`                // 期間全体の合計
+	Periods []SalesReportEntry `json:"periods"`                // 注文のない期間も0で含む
+}
+
+type SalesReportEntry struct {
+	PeriodStart    string `json:"period_start,omitempty" example:"2025-04-01"` // 週は月曜日、月は1日
+	OrderCount     int    `json:"order_count" example:"42"`
+	ItemsSold      int    `json:"items_sold" example:"65"`
+	Revenue        int    `json:"revenue" example:"48600"`
+	NetSales       int    `json:"net_sales" example:"45000"`
+	DiscountAmount int    `json:"discount_amount" example:"500"`
+	TaxAmount      int    `json:"tax_amount" example:"3600"`
+	RefundedAmount int    `json:"refunded_amount" example:"0"`
+	AverageTicket  int    `json:"average_ticket" example:"1157"` // 1注文あたりの税込の売上。1円未満は切り捨て
+}
+
+// 商品ごとの売上レポート。売上の多い順
+type ItemSalesReportResponse struct {
+	ShopID int                    `json:"shop_id" example:"1"`
+	From   string                 `json:"from" example:"2025-04-01"`
+	To     string                 `json:"to" example:"2025-04-30"`
+	Items  []ItemSalesReportEntry `json:"items"`
+}
+
+type ItemSalesReportEntry struct {
+	ItemID      int    `json:"item_id" example:"1"`
+	ItemName    string `json:"item_name" example:"唐揚げ定食"`
+	Quantity    int    `json:"quantity" example:"30"`
+	OrderCount  int    `json:"order_count" example:"28"`
+	SalesAmount int    `json:"sales_amount" example:"25500"` // 税込。注文全体の値引きは含まない
+}
+
+// 厨房の所要時間。cook_timeは注文から調理完了まで、pickup_waitは調理完了からお渡しまで
+type KitchenMetricsResponse struct {
+	ShopID             int                        `json:"shop_id" example:"1"`
+	From               string                     `json:"from" example:"2025-04-01"`
+	To                 string                     `json:"to" example:"2025-04-30"`
+	CookTime           DurationStats              `json:"cook_time"`
+	PickupWait         DurationStats              `json:"pickup_wait"`
+	ByHour             []KitchenHourMetrics       `json:"by_hour"` // 注文した時刻（日本時間）ごと。注文のない時間帯は含まない
+	ByItem             []KitchenItemMetrics       `json:"by_item"` // その商品を含む注文の所要時間
+	UncollectedMinutes int                        `json:"uncollected_minutes" example:"15"`
+	UncollectedOrders  []UncollectedOrderResponse `json:"uncollected_orders"` // 期間にかかわらず、いま受け取られていない注文。調理完了の古い順
+}
+
+// 所要時間の統計（秒）。p50とp90は最近傍順位法で求める
+type DurationStats struct {
+	Count      int `json:"count" example:"120"`
+	P50Seconds int `json:"p50_seconds" example:"420"`
+	P90Seconds int `json:"p90_seconds" example:"780"`
+	MaxSeconds int `json:"max_seconds" example:"1500"`
+}
+
+type KitchenHourMetrics struct {
+	Hour       int           `json:"hour" example:"12"` // 0〜23
+	CookTime   DurationStats `json:"cook_time"`
+	PickupWait DurationStats `json:"pickup_wait"`
+}
+
+type KitchenItemMetrics struct {
+	ItemID     int           `json:"item_id" example:"1"`
+	ItemName   string        `json:"item_name" example:"唐揚げ定食"`
+	CookTime   DurationStats `json:"cook_time"`
+	PickupWait DurationStats `json:"pickup_wait"`
+}
+
+type UncollectedOrderResponse struct {
+	OrderID        int       `json:"order_id" example:"10"`
+	OrderDate      time.Time `json:"order_date"`
+	CompletedAt    time.Time `json:"completed_at"`
+	WaitingSeconds int       `json:"waiting_seconds" example:"1320"` // 調理完了からの経過時間
+}
+
+// メニューの取り込み結果。エラーのある行が1つでもあれば何も反映しない
+type MenuImportResponse struct {
+	ShopID    int              `json:"shop_id" example:"1"`
+	DryRun    bool             `json:"dry_run" example:"false"`
+	Applied   bool             `json:"applied" example:"true"` // 変更を反映したか
+	Created   int              `json:"created" example:"3"`
+	Updated   int              `json:"updated" example:"2"`
+	Unchanged int              `json:"unchanged" example:"10"`
+	Changes   []MenuItemChange `json:"changes"` // 登録・更新する商品。変更のない商品は含まない
+	Errors    []MenuRowError   `json:"errors"`
+}
+
+type MenuItemChange struct {
+	Row      int               `json:"row" example:"2"`                               // 何行目の商品か（見出しを除いて1から数える）
+	Action   string            `json:"action" enums:"create,update" example:"update"` // create: 登録、update: 更新
+	ItemID   *int              `json:"item_id" example:"1"`                           // 登録する商品は反映するまでnull
+	ItemName string            `json:"item_name" example:"唐揚げ定食"`
+	Fields   []MenuFieldChange `json:"fields"`
+}
+
+// 商品の項目ごとの変更。登録する商品ではbeforeがnull
+type MenuFieldChange struct {
+	Field  string `json:"field" example:"price"`
+	Before any    `json:"before" swaggertype:"string" example:"800"`
+	After  any    `json:"after" swaggertype:"string" example:"850"`
+}
+
+type MenuRowError struct {
+	Row     int    `json:"row" example:"3"`
+	Field   string `json:"field,omitempty" example:"price"` // 行全体のエラーでは空
+	Message string `json:"message" example:"0以上で指定してください。"`
+
+	MessageID apperrors.MessageID `json:"-"` // カタログの文言の種類。空ならMessageをそのまま使う
+	Params    apperrors.Params    `json:"-"`
+}
+
+type CategoryResponse struct {
+	CategoryID int    `json:"category_id" example:"1"`
+	Name       string `json:"name" example:"ラーメン"`
+	SortOrder  int    `json:"sort_order" example:"1"`
+}
+
+// カテゴリごとにまとめた店舗のメニュー
+type MenuResponse struct {
+	ShopID   int           `json:"shop_id" example:"1"`
+	Sections []MenuSection `json:"sections"` // カテゴリの表示順。商品のないカテゴリは含まず、カテゴリのない商品は最後の「その他」にまとめる
+}
+
+type MenuSection struct {
+	CategoryID *int               `json:"category_id" example:"1"` // 「その他」ではnull
+	Name       string             `json:"name" example:"ラーメン"`
+	Items      []ItemListResponse `json:"items"`
+}
+
+// アップロードした商品画像
+type ItemImageResponse struct {
+	ItemID       int    `json:"item_id" example:"1"`
+	ImageURL     string `json:"image_url" example:"https://cdn.example.com/items/1/9f1c.jpg"`
+	ThumbnailURL string `json:"thumbnail_url" example:"https://cdn.example.com/items/1/9f1c_thumb.jpg"`
 }
@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/lib/pq"
+)
+
+type CategoryRepository interface {
+	CreateCategory(ctx context.Context, dbtx DBTX, category *models.Category) error
+	FindCategoriesByShopID(ctx context.Context, dbtx DBTX, shopID int) ([]models.Category, error)
+	UpdateCategory(ctx context.Context, dbtx DBTX, category *models.Category) error
+	DeleteCategory(ctx context.Context, dbtx DBTX, shopID int, categoryID int) error
+	AssignItemCategory(ctx context.Context, dbtx DBTX, shopID int, itemID int, categoryID *int) error
+}
+
+type categoryRepository struct{}
+
+func NewCategoryRepository() CategoryRepository {
+	return &categoryRepository{}
+}
+
+// CreateCategory は店舗の商品カテゴリを登録します。生成されたIDはcategoryに設定されます。
+// 店舗に同じ名前のカテゴリがある場合はConflictを返します。
+func (r *categoryRepository) CreateCategory(ctx context.Context, dbtx DBTX, category *models.Category) error {
+	query := `
+		INSERT INTO categories (shop_id, name, sort_order)
+		VALUES ($1, $2, $3)
+		RETURNING category_id, created_at, updated_at
+	`
+	err := dbtx.QueryRowxContext(ctx, query, category.ShopID, category.Name, category.SortOrder).
+		Scan(&category.CategoryID, &category.CreatedAt, &category.UpdatedAt)
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == "23505" {
+			return apperrors.Conflict.WrapMessage(err, apperrors.MsgDuplicateCategoryName, nil)
+		}
+		return apperrors.InsertDataFailed.Wrap(err, "カテゴリの登録に失敗しました。")
+	}
+	return nil
+}
+
+// FindCategoriesByShopID は店舗のカテゴリを表示順に取得します
+func (r *categoryRepository) FindCategoriesByShopID(ctx context.Context, dbtx DBTX, shopID int) ([]models.Category, error) {
+	query := `SELECT * FROM categories WHERE shop_id = $1 ORDER BY sort_order, category_id`
+	var categories []models.Category
+	if err := dbtx.SelectContext(ctx, &categories, query, shopID); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "カテゴリ一覧の取得に失敗しました。")
+	}
+	return categories, nil
+}
+
+// UpdateCategory は店舗のカテゴリの名前と表示順を更新します。
+// 店舗のカテゴリでなければNoData、同じ名前のカテゴリがある場合はConflictを返します。
+func (r *categoryRepository) UpdateCategory(ctx context.Context, dbtx DBTX, category *models.Category) error {
+	query := `
+		UPDATE categories SET name = $1, sort_order = $2
+		WHERE category_id = $3 AND shop_id = $4
+		RETURNING created_at, updated_at
+	`
+	err := dbtx.QueryRowxContext(ctx, query, category.Name, category.SortOrder, category.CategoryID, category.ShopID).
+		Scan(&category.CreatedAt, &category.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperrors.NoData.Wrap(err, "指定されたカテゴリが見つからないか、この店舗のものではありません。")
+		}
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == "23505" {
+			return apperrors.Conflict.WrapMessage(err, apperrors.MsgDuplicateCategoryName, nil)
+		}
+		return apperrors.UpdateDataFailed.Wrap(err, "カテゴリの更新に失敗しました。")
+	}
+	return nil
+}
+
+// DeleteCategory は店舗のカテゴリを削除します。カテゴリの商品はカテゴリなしになります。
+func (r *categoryRepository) DeleteCategory(ctx context.Context, dbtx DBTX, shopID int, categoryID int) error {
+	query := `DELETE FROM categories WHERE category_id = $1 AND shop_id = $2`
+	result, err := dbtx.ExecContext(ctx, query, categoryID, shopID)
+	if err != nil {
+		return apperrors.DeleteDataFailed.Wrap(err, "カテゴリの削除に失敗しました。")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.DeleteDataFailed.Wrap(err, "削除結果の取得に失敗しました。")
+	}
+	if rowsAffected == 0 {
+		return apperrors.NoData.Wrap(nil, "指定されたカテゴリが見つからないか、この店舗のものではありません。")
+	}
+	return nil
+}
+
+// AssignItemCategory は店舗の商品のカテゴリを設定します（nilでカテゴリなしに戻す）。
+// 商品を店舗で扱っていないか、カテゴリが店舗のものでない場合はNoDataを返します。
+func (r *categoryRepository) AssignItemCategory(ctx context.Context, dbtx DBTX, shopID int, itemID int, categoryID *int) error {
+	query := `
+		UPDATE shop_item SET category_id = $1, updated_at = NOW()
+		WHERE shop_id = $2 AND item_id = $3
+		AND ($1::INT IS NULL OR EXISTS (SELECT 1 FROM categories c WHERE c.category_id = $1 AND c.shop_id = $2))
+	`
+	result, err := dbtx.ExecContext(ctx, query, categoryID, shopID, itemID)
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "商品のカテゴリの設定に失敗しました。")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "更新結果の取得に失敗しました。")
+	}
+	if rowsAffected == 0 {
+		return apperrors.NoData.Wrap(nil, "指定された商品をこの店舗で扱っていないか、カテゴリがこの店舗のものではありません。")
+	}
+	return nil
+}
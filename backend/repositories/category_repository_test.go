@@ -0,0 +1,69 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+)
+
+func TestCategoryRepository(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("transaction rollback failed: %v", err)
+		}
+	}()
+
+	setupItemRepositoryTestData(t, tx)
+	repo := repositories.NewCategoryRepository()
+
+	drinks := &models.Category{ShopID: itemTestShopID1, Name: "ドリンク", SortOrder: 2}
+	testhelpers.AssertNoError(t, repo.CreateCategory(ctx, tx, drinks))
+	ramen := &models.Category{ShopID: itemTestShopID1, Name: "ラーメン", SortOrder: 1}
+	testhelpers.AssertNoError(t, repo.CreateCategory(ctx, tx, ramen))
+	otherShop := &models.Category{ShopID: itemTestShopID2, Name: "ラーメン", SortOrder: 1}
+	testhelpers.AssertNoError(t, repo.CreateCategory(ctx, tx, otherShop))
+
+	// 同じ店舗で同じ名前は登録できない
+	testhelpers.AssertAppError(t, repo.CreateCategory(ctx, tx, &models.Category{ShopID: itemTestShopID1, Name: "ラーメン"}), apperrors.Conflict)
+
+	categories, err := repo.FindCategoriesByShopID(ctx, tx, itemTestShopID1)
+	testhelpers.AssertNoError(t, err)
+	if len(categories) != 2 || categories[0].Name != "ラーメン" || categories[1].Name != "ドリンク" {
+		t.Fatalf("categories are not ordered by sort_order: %+v", categories)
+	}
+
+	// 商品のカテゴリは担当店舗のカテゴリのみ設定できる
+	testhelpers.AssertNoError(t, repo.AssignItemCategory(ctx, tx, itemTestShopID1, itemTestItemID1, &ramen.CategoryID))
+	testhelpers.AssertAppError(t, repo.AssignItemCategory(ctx, tx, itemTestShopID1, itemTestItemID2, &otherShop.CategoryID), apperrors.NoData)
+	testhelpers.AssertAppError(t, repo.AssignItemCategory(ctx, tx, itemTestShopID1, itemTestItemID3, &ramen.CategoryID), apperrors.NoData)
+
+	items, err := repositories.NewItemRepository().GetItemList(tx, itemTestShopID1)
+	testhelpers.AssertNoError(t, err)
+	if items[0].CategoryID == nil || *items[0].CategoryID != ramen.CategoryID || items[1].CategoryID != nil {
+		t.Errorf("unexpected item categories: %v, %v", items[0].CategoryID, items[1].CategoryID)
+	}
+
+	drinks.Name, drinks.SortOrder = "ソフトドリンク", 0
+	testhelpers.AssertNoError(t, repo.UpdateCategory(ctx, tx, drinks))
+	testhelpers.AssertAppError(t, repo.UpdateCategory(ctx, tx, &models.Category{CategoryID: otherShop.CategoryID, ShopID: itemTestShopID1, Name: "麺類"}), apperrors.NoData)
+
+	// カテゴリを削除すると商品はカテゴリなしになる
+	testhelpers.AssertAppError(t, repo.DeleteCategory(ctx, tx, itemTestShopID2, ramen.CategoryID), apperrors.NoData)
+	testhelpers.AssertNoError(t, repo.DeleteCategory(ctx, tx, itemTestShopID1, ramen.CategoryID))
+	items, err = repositories.NewItemRepository().GetItemList(tx, itemTestShopID1)
+	testhelpers.AssertNoError(t, err)
+	if items[0].CategoryID != nil {
+		t.Errorf("category_id = %v, want nil after the category is deleted", *items[0].CategoryID)
+	}
+}
@@ -2,7 +2,9 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/A4-dev-team/mobileorder.git/apperrors"
 	"github.com/A4-dev-team/mobileorder.git/models"
@@ -11,8 +13,26 @@ import (
 
 type ItemRepository interface {
 	ValidateAndGetItemsForShop(ctx context.Context, dbtx DBTX, shopID int, itemIDs []int) (map[int]models.Item, error)
-	GetItemList(dbtx DBTX, shopID int) ([]models.ItemListResponse, error)
+	GetItemList(dbtx DBTX, shopID int) ([]ItemListDB, error)
 	UpdateItemAvailability(ctx context.Context, dbtx DBTX, itemID int, isAvailable bool) error
+	UpdateItemPrepTime(ctx context.Context, dbtx DBTX, shopID int, itemID int, prepSeconds *int) error
+	DecrementStock(ctx context.Context, dbtx DBTX, shopID int, itemID int, quantity int) error
+	RestockOrderItems(ctx context.Context, dbtx DBTX, shopID int, orderID int) error
+	UpdateItemStock(ctx context.Context, dbtx DBTX, shopID int, itemID int, stockQuantity *int) error
+	FindModifierGroupsByItemIDs(ctx context.Context, dbtx DBTX, shopID int, itemIDs []int) (map[int][]models.ModifierGroup, error)
+	CreateModifierGroup(ctx context.Context, dbtx DBTX, shopID int, group *models.ModifierGroup) error
+	DeleteModifierGroup(ctx context.Context, dbtx DBTX, shopID int, modifierGroupID int) error
+	FindShopMenuItems(ctx context.Context, dbtx DBTX, shopID int) ([]models.Item, error)
+	CreateShopMenuItem(ctx context.Context, dbtx DBTX, shopID int, item *models.Item) error
+	UpdateShopMenuItem(ctx context.Context, dbtx DBTX, shopID int, item *models.Item) error
+	UpdateItemImage(ctx context.Context, dbtx DBTX, shopID int, itemID int, imageKey string, thumbnailKey string) ([]string, error)
+	ReplaceAvailabilityWindows(ctx context.Context, dbtx DBTX, shopID int, itemID int, windows []models.AvailabilityWindow) error
+	CreatePriceChange(ctx context.Context, dbtx DBTX, shopID int, change *models.PriceChange) error
+	FindPriceChanges(ctx context.Context, dbtx DBTX, shopID int, itemID int) ([]models.PriceChange, error)
+	CancelPriceChange(ctx context.Context, dbtx DBTX, shopID int, itemID int, priceChangeID int) error
+	FindBundleSlotsByItemIDs(ctx context.Context, dbtx DBTX, shopID int, itemIDs []int) (map[int][]models.BundleSlot, error)
+	ReplaceBundleSlots(ctx context.Context, dbtx DBTX, shopID int, itemID int, slots []models.BundleSlot) error
+	ReplaceItemDietaryInfo(ctx context.Context, dbtx DBTX, shopID int, itemID int, allergens []string, dietaryTags []string) error
 }
 
 type itemRepository struct {
@@ -35,12 +55,18 @@ func (r *itemRepository) ValidateAndGetItemsForShop(ctx context.Context, dbtx DB
 		SELECT
 			i.item_id,
 			i.item_name,
-			i.price,
-			i.is_available
+			COALESCE(pc.price, i.price) AS price,
+			i.price AS base_price,
+			i.is_available,
+			i.tax_category,
+			si.stock_quantity,
+			s.time_zone
 		FROM
 			items i
 		INNER JOIN
 			shop_item si ON i.item_id = si.item_id
+		INNER JOIN
+			shops s ON si.shop_id = s.shop_id` + currentPriceChangeJoin + `
 		WHERE
 			si.shop_id = ? AND i.item_id IN (?)
 	`
@@ -57,10 +83,15 @@ func (r *itemRepository) ValidateAndGetItemsForShop(ctx context.Context, dbtx DB
 	}
 
 	if len(items) != len(itemIDs) {
-		return nil, apperrors.BadParam.Wrap(errors.New("invalid item id requested"), "リクエストに、存在しないか店舗に属さない商品が含まれています。")
+		return nil, apperrors.BadParam.WrapMessage(errors.New("invalid item id requested"), apperrors.MsgUnknownShopItem, nil)
 	}
 
+	windowsMap, err := findAvailabilityWindows(ctx, dbtx, shopID, itemIDs)
+	if err != nil {
+		return nil, err
+	}
 	for _, i := range items {
+		i.AvailabilityWindows = windowsMap[i.ItemID]
 		itemMap[i.ItemID] = i
 	}
 
@@ -68,11 +99,31 @@ func (r *itemRepository) ValidateAndGetItemsForShop(ctx context.Context, dbtx DB
 
 }
 
-func (r *itemRepository) GetItemList(dbtx DBTX, shopID int) ([]models.ItemListResponse, error) {
+// 店舗の商品一覧の1件。レスポンスに含めない画像のキー・共通の価格・販売時間帯も持つ
+type ItemListDB struct {
+	ItemID        int
+	ItemName      string
+	Description   string
+	Price         int // 店舗での価格
+	BasePrice     int // 店舗ごとの価格を適用する前のitems.price
+	IsAvailable   bool
+	StockQuantity *int
+	CategoryID    *int
+	TaxCategory   models.TaxCategory
+	ImageKey      *string // URLに変換する前のBlobStoreのキー
+	ThumbnailKey  *string
+	TimeZone      string // 時間帯を判定する店舗のタイムゾーン
+	Schedule      []models.AvailabilityWindow
+	Allergens     []string
+	DietaryTags   []string
+}
+
+func (r *itemRepository) GetItemList(dbtx DBTX, shopID int) ([]ItemListDB, error) {
 	query := `
-		SELECT i.item_id, i.item_name, i.description, i.price, i.is_available
+		SELECT i.item_id, i.item_name, i.description, COALESCE(pc.price, i.price) AS price, i.price AS base_price, i.is_available, i.tax_category, i.image_key, i.thumbnail_key, si.stock_quantity, si.category_id, s.time_zone
 		FROM items i
 		INNER JOIN shop_item si ON i.item_id = si.item_id
+		INNER JOIN shops s ON si.shop_id = s.shop_id` + currentPriceChangeJoin + `
 		WHERE si.shop_id = $1
 		ORDER BY i.item_id
 	`
@@ -82,14 +133,44 @@ func (r *itemRepository) GetItemList(dbtx DBTX, shopID int) ([]models.ItemListRe
 		return nil, apperrors.GetDataFailed.Wrap(err, "商品一覧の取得に失敗しました。")
 	}
 
-	var response []models.ItemListResponse
+	itemIDs := make([]int, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ItemID
+	}
+	windowsMap, err := findAvailabilityWindows(context.Background(), dbtx, shopID, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	allergensMap, dietaryTagsMap, err := findDietaryInfo(context.Background(), dbtx, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []ItemListDB
 	for _, item := range items {
-		itemResponse := models.ItemListResponse{
+		itemResponse := ItemListDB{
 			ItemID:      item.ItemID,
 			ItemName:    item.ItemName,
 			Description: item.Description,
 			Price:       item.Price,
-			IsAvailable: item.IsAvailable,
+			BasePrice:   item.BasePrice,
+			// 在庫数が0になった商品は自動的に売り切れとして扱う
+			IsAvailable:   item.IsAvailable && (item.StockQuantity == nil || *item.StockQuantity > 0),
+			StockQuantity: item.StockQuantity,
+			CategoryID:    item.CategoryID,
+			TaxCategory:   item.TaxCategory,
+			ImageKey:      item.ImageKey,
+			ThumbnailKey:  item.ThumbnailKey,
+			TimeZone:      item.TimeZone,
+			Schedule:      windowsMap[item.ItemID],
+			Allergens:     allergensMap[item.ItemID],
+			DietaryTags:   dietaryTagsMap[item.ItemID],
+		}
+		if itemResponse.Allergens == nil {
+			itemResponse.Allergens = []string{}
+		}
+		if itemResponse.DietaryTags == nil {
+			itemResponse.DietaryTags = []string{}
 		}
 
 		response = append(response, itemResponse)
@@ -118,3 +199,701 @@ func (r *itemRepository) UpdateItemAvailability(ctx context.Context, dbtx DBTX,
 
 	return nil
 }
+
+// UpdateItemPrepTime は店舗で扱っている商品の、その店舗での調理時間を更新します（nilで店舗の既定値に戻す）
+func (r *itemRepository) UpdateItemPrepTime(ctx context.Context, dbtx DBTX, shopID int, itemID int, prepSeconds *int) error {
+	query := `
+		UPDATE shop_item SET prep_seconds = $1, updated_at = NOW()
+		WHERE item_id = $2 AND shop_id = $3
+	`
+
+	result, err := dbtx.ExecContext(ctx, query, prepSeconds, itemID, shopID)
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "商品の調理時間の更新に失敗しました。")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "更新結果の確認に失敗しました。")
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NoData.WrapMessage(nil, apperrors.MsgItemNotInShop, nil)
+	}
+
+	return nil
+}
+
+// DecrementStock は店舗の在庫数を条件付きUPDATEで減らします。
+// 在庫数を管理していない商品は何もしません。在庫が足りない場合はConflictを返します。
+func (r *itemRepository) DecrementStock(ctx context.Context, dbtx DBTX, shopID int, itemID int, quantity int) error {
+	query := `
+		UPDATE shop_item SET stock_quantity = stock_quantity - $1, updated_at = NOW()
+		WHERE shop_id = $2 AND item_id = $3
+		AND stock_quantity IS NOT NULL AND stock_quantity >= $1
+	`
+
+	result, err := dbtx.ExecContext(ctx, query, quantity, shopID, itemID)
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "在庫数の更新に失敗しました。")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "更新結果の確認に失敗しました。")
+	}
+
+	if rowsAffected == 0 {
+		// 在庫数を管理していない商品であれば問題ない
+		var tracked bool
+		checkQuery := `SELECT stock_quantity IS NOT NULL FROM shop_item WHERE shop_id = $1 AND item_id = $2`
+		if err := dbtx.GetContext(ctx, &tracked, checkQuery, shopID, itemID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apperrors.NoData.Wrap(err, "指定された商品はこの店舗で扱っていません。")
+			}
+			return apperrors.GetDataFailed.Wrap(err, "在庫数の確認に失敗しました。")
+		}
+		if tracked {
+			return apperrors.Conflict.WrapMessage(nil, apperrors.MsgStockShortage, apperrors.Params{"item_id": itemID})
+		}
+	}
+
+	return nil
+}
+
+// RestockOrderItems は注文に含まれる商品（セット商品は構成商品も）の数量を店舗の在庫数に戻します。
+// 在庫数を管理していない商品は対象外です。注文を削除する前に呼び出してください。
+func (r *itemRepository) RestockOrderItems(ctx context.Context, dbtx DBTX, shopID int, orderID int) error {
+	query := `
+		UPDATE shop_item si
+		SET stock_quantity = si.stock_quantity + oi.quantity, updated_at = NOW()
+		FROM (
+			SELECT item_id, SUM(quantity) AS quantity
+			FROM (
+				SELECT item_id, quantity FROM order_item WHERE order_id = $1
+				UNION ALL
+				-- セットの構成商品はセットの数量を掛けた数を戻す
+				SELECT oic.item_id, oi.quantity * oic.quantity
+				FROM order_item_components oic
+				INNER JOIN order_item oi ON oic.order_item_id = oi.order_item_id
+				WHERE oi.order_id = $1
+			) lines
+			GROUP BY item_id
+		) oi
+		WHERE si.shop_id = $2 AND si.item_id = oi.item_id AND si.stock_quantity IS NOT NULL
+	`
+
+	if _, err := dbtx.ExecContext(ctx, query, orderID, shopID); err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "在庫数の戻しに失敗しました。")
+	}
+	return nil
+}
+
+// UpdateItemStock は店舗の商品の在庫数を設定します（nilで在庫数の管理をやめる）
+func (r *itemRepository) UpdateItemStock(ctx context.Context, dbtx DBTX, shopID int, itemID int, stockQuantity *int) error {
+	query := `UPDATE shop_item SET stock_quantity = $1, updated_at = NOW() WHERE shop_id = $2 AND item_id = $3`
+
+	result, err := dbtx.ExecContext(ctx, query, stockQuantity, shopID, itemID)
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "在庫数の更新に失敗しました。")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "更新結果の確認に失敗しました。")
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NoData.WrapMessage(nil, apperrors.MsgItemNotInShop, nil)
+	}
+
+	return nil
+}
+
+// FindModifierGroupsByItemIDs は店舗での商品ごとのオプショングループを選択肢付きで取得します。
+// グループと選択肢はsort_order順に並びます。
+func (r *itemRepository) FindModifierGroupsByItemIDs(ctx context.Context, dbtx DBTX, shopID int, itemIDs []int) (map[int][]models.ModifierGroup, error) {
+	groupsMap := make(map[int][]models.ModifierGroup)
+	if len(itemIDs) == 0 {
+		return groupsMap, nil
+	}
+
+	groupQuery, args, err := sqlx.In(`
+		SELECT modifier_group_id, shop_id, item_id, name, selection_type, min_select, max_select, sort_order, created_at, updated_at
+		FROM modifier_groups
+		WHERE shop_id = ? AND item_id IN (?)
+		ORDER BY item_id, sort_order, modifier_group_id
+	`, shopID, itemIDs)
+	if err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "データベースクエリの構築に失敗しました。")
+	}
+	groupQuery = dbtx.Rebind(groupQuery)
+
+	var groups []models.ModifierGroup
+	if err := dbtx.SelectContext(ctx, &groups, groupQuery, args...); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "オプショングループの取得に失敗しました。")
+	}
+	if len(groups) == 0 {
+		return groupsMap, nil
+	}
+
+	groupIDs := make([]int, len(groups))
+	for i, g := range groups {
+		groupIDs[i] = g.ModifierGroupID
+	}
+
+	optionQuery, args, err := sqlx.In(`
+		SELECT modifier_option_id, modifier_group_id, name, price_delta, is_available, sort_order, created_at, updated_at
+		FROM modifier_options
+		WHERE modifier_group_id IN (?)
+		ORDER BY modifier_group_id, sort_order, modifier_option_id
+	`, groupIDs)
+	if err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "データベースクエリの構築に失敗しました。")
+	}
+	optionQuery = dbtx.Rebind(optionQuery)
+
+	var options []models.ModifierOption
+	if err := dbtx.SelectContext(ctx, &options, optionQuery, args...); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "オプションの選択肢の取得に失敗しました。")
+	}
+
+	optionsByGroup := make(map[int][]models.ModifierOption)
+	for _, o := range options {
+		optionsByGroup[o.ModifierGroupID] = append(optionsByGroup[o.ModifierGroupID], o)
+	}
+
+	for _, g := range groups {
+		g.Options = optionsByGroup[g.ModifierGroupID]
+		groupsMap[g.ItemID] = append(groupsMap[g.ItemID], g)
+	}
+
+	return groupsMap, nil
+}
+
+// CreateModifierGroup は店舗で扱っている商品に、その店舗のオプショングループを選択肢ごと登録します。
+// 生成されたIDはgroupとその選択肢に設定されます。
+func (r *itemRepository) CreateModifierGroup(ctx context.Context, dbtx DBTX, shopID int, group *models.ModifierGroup) error {
+	groupQuery := `
+		INSERT INTO modifier_groups (shop_id, item_id, name, selection_type, min_select, max_select, sort_order)
+		SELECT $7, $1, $2, $3, $4, $5, $6
+		WHERE EXISTS (SELECT 1 FROM shop_item si WHERE si.item_id = $1 AND si.shop_id = $7)
+		RETURNING modifier_group_id, created_at, updated_at
+	`
+	err := dbtx.QueryRowxContext(
+		ctx,
+		groupQuery,
+		group.ItemID,
+		group.Name,
+		group.SelectionType,
+		group.MinSelect,
+		group.MaxSelect,
+		group.SortOrder,
+		shopID,
+	).Scan(&group.ModifierGroupID, &group.CreatedAt, &group.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperrors.NoData.WrapMessage(err, apperrors.MsgItemNotInShop, nil)
+		}
+		return apperrors.InsertDataFailed.Wrap(err, "オプショングループの登録に失敗しました。")
+	}
+	group.ShopID = shopID
+
+	stmt, err := dbtx.PreparexContext(ctx, `
+		INSERT INTO modifier_options (modifier_group_id, name, price_delta, is_available, sort_order)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING modifier_option_id, created_at, updated_at
+	`)
+	if err != nil {
+		return apperrors.InsertDataFailed.Wrap(err, "オプション登録の準備に失敗しました。")
+	}
+	defer stmt.Close()
+
+	for i := range group.Options {
+		option := &group.Options[i]
+		option.ModifierGroupID = group.ModifierGroupID
+		if err := stmt.QueryRowxContext(ctx, option.ModifierGroupID, option.Name, option.PriceDelta, option.IsAvailable, option.SortOrder).
+			Scan(&option.ModifierOptionID, &option.CreatedAt, &option.UpdatedAt); err != nil {
+			return apperrors.InsertDataFailed.Wrap(err, "オプションの選択肢の登録に失敗しました。")
+		}
+	}
+
+	return nil
+}
+
+// DeleteModifierGroup は店舗のオプショングループを削除します。
+// 過去の注文に保存されたオプション名と価格は残ります。
+func (r *itemRepository) DeleteModifierGroup(ctx context.Context, dbtx DBTX, shopID int, modifierGroupID int) error {
+	query := `DELETE FROM modifier_groups WHERE modifier_group_id = $1 AND shop_id = $2`
+
+	result, err := dbtx.ExecContext(ctx, query, modifierGroupID, shopID)
+	if err != nil {
+		return apperrors.DeleteDataFailed.Wrap(err, "オプショングループの削除に失敗しました。")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.DeleteDataFailed.Wrap(err, "削除結果の取得に失敗しました。")
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NoData.Wrap(nil, "指定されたオプショングループが見つからないか、この店舗の商品のものではありません。")
+	}
+
+	return nil
+}
+
+// FindShopMenuItems は店舗で扱っている商品を、販売状態と在庫数を加工せずに商品ID順で取得します
+func (r *itemRepository) FindShopMenuItems(ctx context.Context, dbtx DBTX, shopID int) ([]models.Item, error) {
+	query := `
+		SELECT i.item_id, i.item_name, COALESCE(i.description, '') AS description, i.price,
+			COALESCE(i.is_available, TRUE) AS is_available, i.tax_category, si.stock_quantity
+		FROM items i
+		INNER JOIN shop_item si ON i.item_id = si.item_id
+		WHERE si.shop_id = $1
+		ORDER BY i.item_id
+	`
+
+	var items []models.Item
+	if err := dbtx.SelectContext(ctx, &items, query, shopID); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "店舗の商品の取得に失敗しました。")
+	}
+	return items, nil
+}
+
+// CreateShopMenuItem は商品を登録して店舗の商品にします。登録した商品IDを item.ItemID に設定します
+func (r *itemRepository) CreateShopMenuItem(ctx context.Context, dbtx DBTX, shopID int, item *models.Item) error {
+	query := `
+		WITH new_item AS (
+			INSERT INTO items (item_name, description, price, is_available, tax_category)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING item_id
+		)
+		INSERT INTO shop_item (shop_id, item_id, stock_quantity)
+		SELECT $6, item_id, $7 FROM new_item
+		RETURNING item_id
+	`
+
+	err := dbtx.QueryRowxContext(ctx, query, item.ItemName, item.Description, item.Price, item.IsAvailable, item.TaxCategory, shopID, item.StockQuantity).Scan(&item.ItemID)
+	if err != nil {
+		return apperrors.InsertDataFailed.Wrap(err, "商品の登録に失敗しました。")
+	}
+	return nil
+}
+
+// UpdateShopMenuItem は店舗で扱っている商品の名前・説明・価格・販売状態・税区分と、店舗の在庫数を更新します
+func (r *itemRepository) UpdateShopMenuItem(ctx context.Context, dbtx DBTX, shopID int, item *models.Item) error {
+	query := `
+		WITH updated_item AS (
+			UPDATE items
+			SET item_name = $1, description = $2, price = $3, is_available = $4, tax_category = $5, updated_at = NOW()
+			WHERE item_id = $6
+			AND EXISTS (SELECT 1 FROM shop_item si WHERE si.item_id = $6 AND si.shop_id = $7)
+			RETURNING item_id
+		)
+		UPDATE shop_item SET stock_quantity = $8, updated_at = NOW()
+		WHERE shop_id = $7 AND item_id IN (SELECT item_id FROM updated_item)
+	`
+
+	result, err := dbtx.ExecContext(ctx, query, item.ItemName, item.Description, item.Price, item.IsAvailable, item.TaxCategory, item.ItemID, shopID, item.StockQuantity)
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "商品の更新に失敗しました。")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "更新結果の確認に失敗しました。")
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NoData.WrapMessage(nil, apperrors.MsgItemNotInShop, nil)
+	}
+
+	return nil
+}
+
+// UpdateItemImage は担当店舗の商品の画像のキーを設定し、差し替える前の画像のキーを返します。
+// 商品が見つからないか、店舗の商品でない場合はNoDataを返します。
+func (r *itemRepository) UpdateItemImage(ctx context.Context, dbtx DBTX, shopID int, itemID int, imageKey string, thumbnailKey string) ([]string, error) {
+	query := `
+		UPDATE items i SET image_key = $1, thumbnail_key = $2, updated_at = NOW()
+		FROM (SELECT item_id, image_key, thumbnail_key FROM items WHERE item_id = $3 FOR UPDATE) old
+		WHERE i.item_id = old.item_id
+		AND EXISTS (SELECT 1 FROM shop_item si WHERE si.item_id = $3 AND si.shop_id = $4)
+		RETURNING old.image_key, old.thumbnail_key
+	`
+	var oldImageKey, oldThumbnailKey sql.NullString
+	err := dbtx.QueryRowxContext(ctx, query, imageKey, thumbnailKey, itemID, shopID).Scan(&oldImageKey, &oldThumbnailKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NoData.WrapMessage(err, apperrors.MsgItemNotInShop, nil)
+		}
+		return nil, apperrors.UpdateDataFailed.Wrap(err, "商品画像の更新に失敗しました。")
+	}
+
+	var previousKeys []string
+	for _, key := range []sql.NullString{oldImageKey, oldThumbnailKey} {
+		if key.Valid {
+			previousKeys = append(previousKeys, key.String)
+		}
+	}
+	return previousKeys, nil
+}
+
+// findAvailabilityWindows は店舗の商品ごとの注文できる時間帯を、曜日と開始時刻の順で取得します
+func findAvailabilityWindows(ctx context.Context, dbtx DBTX, shopID int, itemIDs []int) (map[int][]models.AvailabilityWindow, error) {
+	windowsMap := make(map[int][]models.AvailabilityWindow)
+	if len(itemIDs) == 0 {
+		return windowsMap, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT si.item_id, w.day_of_week, w.start_minute, w.end_minute
+		FROM item_availability_windows w
+		INNER JOIN shop_item si ON w.shop_item_id = si.shop_item_id
+		WHERE si.shop_id = ? AND si.item_id IN (?)
+		ORDER BY si.item_id, w.day_of_week, w.start_minute
+	`, shopID, itemIDs)
+	if err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "データベースクエリの構築に失敗しました。")
+	}
+	query = dbtx.Rebind(query)
+
+	var windows []models.AvailabilityWindow
+	if err := dbtx.SelectContext(ctx, &windows, query, args...); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "商品の販売時間帯の取得に失敗しました。")
+	}
+	for _, w := range windows {
+		windowsMap[w.ItemID] = append(windowsMap[w.ItemID], w)
+	}
+	return windowsMap, nil
+}
+
+// ReplaceAvailabilityWindows は店舗の商品を注文できる時間帯をすべて置き換えます（空でいつでも注文できるようにする）。
+// 削除と登録を行うため、トランザクション内で呼び出してください。
+func (r *itemRepository) ReplaceAvailabilityWindows(ctx context.Context, dbtx DBTX, shopID int, itemID int, windows []models.AvailabilityWindow) error {
+	var shopItemID int
+	lockQuery := `SELECT shop_item_id FROM shop_item WHERE shop_id = $1 AND item_id = $2 FOR UPDATE`
+	if err := dbtx.GetContext(ctx, &shopItemID, lockQuery, shopID, itemID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperrors.NoData.WrapMessage(err, apperrors.MsgItemNotInShop, nil)
+		}
+		return apperrors.GetDataFailed.Wrap(err, "店舗の商品の取得に失敗しました。")
+	}
+
+	if _, err := dbtx.ExecContext(ctx, `DELETE FROM item_availability_windows WHERE shop_item_id = $1`, shopItemID); err != nil {
+		return apperrors.DeleteDataFailed.Wrap(err, "商品の販売時間帯の削除に失敗しました。")
+	}
+
+	for _, w := range windows {
+		query := `INSERT INTO item_availability_windows (shop_item_id, day_of_week, start_minute, end_minute) VALUES ($1, $2, $3, $4)`
+		if _, err := dbtx.ExecContext(ctx, query, shopItemID, w.DayOfWeek, w.StartMinute, w.EndMinute); err != nil {
+			return apperrors.InsertDataFailed.Wrap(err, "商品の販売時間帯の登録に失敗しました。")
+		}
+	}
+	return nil
+}
+
+// currentPriceChangeJoin は店舗の商品（si）に、反映する日時を過ぎた最も新しい価格変更（pc）を結合します。
+// 店舗での価格は COALESCE(pc.price, i.price) で、変更のない商品や価格がNULLの変更ではitems.priceになります
+const currentPriceChangeJoin = `
+		LEFT JOIN LATERAL (
+			SELECT price FROM shop_item_price_changes
+			WHERE shop_item_id = si.shop_item_id AND effective_at <= NOW()
+			ORDER BY effective_at DESC, price_change_id DESC
+			LIMIT 1
+		) pc ON TRUE`
+
+// CreatePriceChange は店舗の商品の価格変更を登録します。
+// change.EffectiveAt がゼロ値の場合は現在の日時で登録します。生成されたIDなどはchangeに設定されます。
+func (r *itemRepository) CreatePriceChange(ctx context.Context, dbtx DBTX, shopID int, change *models.PriceChange) error {
+	var effectiveAt *time.Time
+	if !change.EffectiveAt.IsZero() {
+		utc := change.EffectiveAt.UTC()
+		effectiveAt = &utc
+	}
+
+	query := `
+		INSERT INTO shop_item_price_changes (shop_item_id, price, effective_at)
+		SELECT si.shop_item_id, $1, COALESCE($2, NOW())
+		FROM shop_item si
+		WHERE si.shop_id = $3 AND si.item_id = $4
+		RETURNING price_change_id, shop_item_id, effective_at, CASE WHEN effective_at <= NOW() THEN effective_at END, created_at
+	`
+	err := dbtx.QueryRowxContext(ctx, query, change.Price, effectiveAt, shopID, change.ItemID).
+		Scan(&change.PriceChangeID, &change.ShopItemID, &change.EffectiveAt, &change.AppliedAt, &change.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperrors.NoData.WrapMessage(err, apperrors.MsgItemNotInShop, nil)
+		}
+		return apperrors.InsertDataFailed.Wrap(err, "価格変更の登録に失敗しました。")
+	}
+	return nil
+}
+
+// FindPriceChanges は店舗の商品の価格変更を、反映済みのものと予定のものを合わせて反映する日時の新しい順に取得します
+func (r *itemRepository) FindPriceChanges(ctx context.Context, dbtx DBTX, shopID int, itemID int) ([]models.PriceChange, error) {
+	var exists bool
+	existsQuery := `SELECT EXISTS (SELECT 1 FROM shop_item WHERE shop_id = $1 AND item_id = $2)`
+	if err := dbtx.GetContext(ctx, &exists, existsQuery, shopID, itemID); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "店舗の商品の取得に失敗しました。")
+	}
+	if !exists {
+		return nil, apperrors.NoData.WrapMessage(nil, apperrors.MsgItemNotInShop, nil)
+	}
+
+	query := `
+		SELECT pc.price_change_id, pc.shop_item_id, si.item_id, pc.price, pc.effective_at,
+			CASE WHEN pc.effective_at <= NOW() THEN pc.effective_at END AS applied_at, pc.created_at
+		FROM shop_item_price_changes pc
+		INNER JOIN shop_item si ON pc.shop_item_id = si.shop_item_id
+		WHERE si.shop_id = $1 AND si.item_id = $2
+		ORDER BY pc.effective_at DESC, pc.price_change_id DESC
+	`
+	var changes []models.PriceChange
+	if err := dbtx.SelectContext(ctx, &changes, query, shopID, itemID); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "価格変更の履歴の取得に失敗しました。")
+	}
+	return changes, nil
+}
+
+// CancelPriceChange はまだ反映していない店舗の商品の価格変更を取り消します。
+// 反映済みの変更は履歴として残すため、取り消せずConflictを返します。トランザクション内で呼び出してください。
+func (r *itemRepository) CancelPriceChange(ctx context.Context, dbtx DBTX, shopID int, itemID int, priceChangeID int) error {
+	var applied bool
+	checkQuery := `
+		SELECT pc.effective_at <= NOW()
+		FROM shop_item_price_changes pc
+		INNER JOIN shop_item si ON pc.shop_item_id = si.shop_item_id
+		WHERE pc.price_change_id = $1 AND si.shop_id = $2 AND si.item_id = $3
+		FOR UPDATE OF pc
+	`
+	if err := dbtx.GetContext(ctx, &applied, checkQuery, priceChangeID, shopID, itemID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperrors.NoData.Wrap(err, "指定された価格変更が見つかりませんでした。")
+		}
+		return apperrors.GetDataFailed.Wrap(err, "価格変更の取得に失敗しました。")
+	}
+	if applied {
+		return apperrors.Conflict.WrapMessage(nil, apperrors.MsgPriceChangeAlreadyApplied, nil)
+	}
+
+	if _, err := dbtx.ExecContext(ctx, `DELETE FROM shop_item_price_changes WHERE price_change_id = $1`, priceChangeID); err != nil {
+		return apperrors.DeleteDataFailed.Wrap(err, "価格変更の取り消しに失敗しました。")
+	}
+	return nil
+}
+
+// FindBundleSlotsByItemIDs は店舗でのセット商品の枠を選択肢付きで取得します。セットでない商品は結果に含まれません。
+// 選択肢には店舗での構成商品の価格・販売状態・在庫数・販売時間帯を設定し、店舗で扱っていない構成商品は販売停止として扱います。
+// 枠と選択肢はsort_order順に並びます。
+func (r *itemRepository) FindBundleSlotsByItemIDs(ctx context.Context, dbtx DBTX, shopID int, itemIDs []int) (map[int][]models.BundleSlot, error) {
+	slotsMap := make(map[int][]models.BundleSlot)
+	if len(itemIDs) == 0 {
+		return slotsMap, nil
+	}
+
+	slotQuery, args, err := sqlx.In(`
+		SELECT bundle_slot_id, shop_id, item_id, name, quantity, sort_order, created_at
+		FROM bundle_slots
+		WHERE shop_id = ? AND item_id IN (?)
+		ORDER BY item_id, sort_order, bundle_slot_id
+	`, shopID, itemIDs)
+	if err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "データベースクエリの構築に失敗しました。")
+	}
+	slotQuery = dbtx.Rebind(slotQuery)
+
+	var slots []models.BundleSlot
+	if err := dbtx.SelectContext(ctx, &slots, slotQuery, args...); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "セット商品の枠の取得に失敗しました。")
+	}
+	if len(slots) == 0 {
+		return slotsMap, nil
+	}
+
+	slotIDs := make([]int, len(slots))
+	for i, slot := range slots {
+		slotIDs[i] = slot.BundleSlotID
+	}
+
+	choiceQuery, args, err := sqlx.In(`
+		SELECT
+			bc.bundle_choice_id, bc.bundle_slot_id, bc.item_id, bc.price_delta, bc.sort_order, bc.created_at,
+			i.item_name,
+			COALESCE(pc.price, i.price) AS price,
+			i.is_available AND si.shop_item_id IS NOT NULL AS is_available,
+			si.stock_quantity
+		FROM bundle_choices bc
+		INNER JOIN items i ON bc.item_id = i.item_id
+		LEFT JOIN shop_item si ON si.item_id = bc.item_id AND si.shop_id = ?`+currentPriceChangeJoin+`
+		WHERE bc.bundle_slot_id IN (?)
+		ORDER BY bc.bundle_slot_id, bc.sort_order, bc.bundle_choice_id
+	`, shopID, slotIDs)
+	if err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "データベースクエリの構築に失敗しました。")
+	}
+	choiceQuery = dbtx.Rebind(choiceQuery)
+
+	var choices []models.BundleChoice
+	if err := dbtx.SelectContext(ctx, &choices, choiceQuery, args...); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "セット商品の選択肢の取得に失敗しました。")
+	}
+
+	componentIDs := make([]int, 0, len(choices))
+	for _, c := range choices {
+		componentIDs = append(componentIDs, c.ItemID)
+	}
+	windowsMap, err := findAvailabilityWindows(ctx, dbtx, shopID, componentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	choicesBySlot := make(map[int][]models.BundleChoice)
+	for _, c := range choices {
+		c.AvailabilityWindows = windowsMap[c.ItemID]
+		choicesBySlot[c.BundleSlotID] = append(choicesBySlot[c.BundleSlotID], c)
+	}
+
+	for _, slot := range slots {
+		slot.Choices = choicesBySlot[slot.BundleSlotID]
+		slotsMap[slot.ItemID] = append(slotsMap[slot.ItemID], slot)
+	}
+
+	return slotsMap, nil
+}
+
+// ReplaceBundleSlots は店舗で扱っている商品の、その店舗でのセットの枠を選択肢ごとすべて置き換えます（空でセットではない商品に戻す）。
+// 店舗の他のセット商品の構成商品はセット商品にできません。生成されたIDはslotsとその選択肢に設定されます。
+// 削除と登録を行うため、トランザクション内で呼び出してください。
+func (r *itemRepository) ReplaceBundleSlots(ctx context.Context, dbtx DBTX, shopID int, itemID int, slots []models.BundleSlot) error {
+	var shopItemID int
+	lockQuery := `SELECT shop_item_id FROM shop_item WHERE shop_id = $1 AND item_id = $2 FOR UPDATE`
+	if err := dbtx.GetContext(ctx, &shopItemID, lockQuery, shopID, itemID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperrors.NoData.WrapMessage(err, apperrors.MsgItemNotInShop, nil)
+		}
+		return apperrors.GetDataFailed.Wrap(err, "店舗の商品の取得に失敗しました。")
+	}
+
+	if len(slots) > 0 {
+		var isComponent bool
+		componentQuery := `
+			SELECT EXISTS (
+				SELECT 1 FROM bundle_choices bc
+				INNER JOIN bundle_slots bs ON bc.bundle_slot_id = bs.bundle_slot_id
+				WHERE bc.item_id = $1 AND bs.shop_id = $2
+			)
+		`
+		if err := dbtx.GetContext(ctx, &isComponent, componentQuery, itemID, shopID); err != nil {
+			return apperrors.GetDataFailed.Wrap(err, "セット商品の構成の確認に失敗しました。")
+		}
+		if isComponent {
+			return apperrors.Conflict.WrapMessage(nil, apperrors.MsgItemUsedInBundle, nil)
+		}
+	}
+
+	// 選択肢は枠と一緒に削除される。過去の注文に保存された構成商品の名前と加算額は残る
+	if _, err := dbtx.ExecContext(ctx, `DELETE FROM bundle_slots WHERE shop_id = $1 AND item_id = $2`, shopID, itemID); err != nil {
+		return apperrors.DeleteDataFailed.Wrap(err, "セット商品の枠の削除に失敗しました。")
+	}
+
+	for i := range slots {
+		slot := &slots[i]
+		slot.ShopID = shopID
+		slot.ItemID = itemID
+		slotQuery := `
+			INSERT INTO bundle_slots (shop_id, item_id, name, quantity, sort_order)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING bundle_slot_id, created_at
+		`
+		if err := dbtx.QueryRowxContext(ctx, slotQuery, slot.ShopID, slot.ItemID, slot.Name, slot.Quantity, slot.SortOrder).
+			Scan(&slot.BundleSlotID, &slot.CreatedAt); err != nil {
+			return apperrors.InsertDataFailed.Wrap(err, "セット商品の枠の登録に失敗しました。")
+		}
+
+		for j := range slot.Choices {
+			choice := &slot.Choices[j]
+			choice.BundleSlotID = slot.BundleSlotID
+			choiceQuery := `
+				INSERT INTO bundle_choices (bundle_slot_id, item_id, price_delta, sort_order)
+				VALUES ($1, $2, $3, $4)
+				RETURNING bundle_choice_id, created_at
+			`
+			if err := dbtx.QueryRowxContext(ctx, choiceQuery, choice.BundleSlotID, choice.ItemID, choice.PriceDelta, choice.SortOrder).
+				Scan(&choice.BundleChoiceID, &choice.CreatedAt); err != nil {
+				return apperrors.InsertDataFailed.Wrap(err, "セット商品の選択肢の登録に失敗しました。")
+			}
+		}
+	}
+	return nil
+}
+
+// findDietaryInfo は商品ごとのアレルゲンと食事の制限を、それぞれコードの順で取得します
+func findDietaryInfo(ctx context.Context, dbtx DBTX, itemIDs []int) (map[int][]string, map[int][]string, error) {
+	allergensMap := make(map[int][]string)
+	dietaryTagsMap := make(map[int][]string)
+	if len(itemIDs) == 0 {
+		return allergensMap, dietaryTagsMap, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT item_id, 'allergen' AS kind, allergen AS code FROM item_allergens WHERE item_id IN (?)
+		UNION ALL
+		SELECT item_id, 'dietary_tag' AS kind, tag AS code FROM item_dietary_tags WHERE item_id IN (?)
+		ORDER BY item_id, kind, code
+	`, itemIDs, itemIDs)
+	if err != nil {
+		return nil, nil, apperrors.GetDataFailed.Wrap(err, "データベースクエリの構築に失敗しました。")
+	}
+	query = dbtx.Rebind(query)
+
+	var rows []struct {
+		ItemID int    `db:"item_id"`
+		Kind   string `db:"kind"`
+		Code   string `db:"code"`
+	}
+	if err := dbtx.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, nil, apperrors.GetDataFailed.Wrap(err, "商品のアレルゲンと食事の制限の取得に失敗しました。")
+	}
+	for _, row := range rows {
+		if row.Kind == "allergen" {
+			allergensMap[row.ItemID] = append(allergensMap[row.ItemID], row.Code)
+		} else {
+			dietaryTagsMap[row.ItemID] = append(dietaryTagsMap[row.ItemID], row.Code)
+		}
+	}
+	return allergensMap, dietaryTagsMap, nil
+}
+
+// ReplaceItemDietaryInfo は店舗で扱っている商品のアレルゲンと食事の制限をすべて置き換えます。
+// 商品の情報は全店舗共通です。削除と登録を行うため、トランザクション内で呼び出してください。
+func (r *itemRepository) ReplaceItemDietaryInfo(ctx context.Context, dbtx DBTX, shopID int, itemID int, allergens []string, dietaryTags []string) error {
+	var exists bool
+	checkQuery := `SELECT EXISTS (SELECT 1 FROM shop_item WHERE shop_id = $1 AND item_id = $2)`
+	if err := dbtx.GetContext(ctx, &exists, checkQuery, shopID, itemID); err != nil {
+		return apperrors.GetDataFailed.Wrap(err, "店舗の商品の取得に失敗しました。")
+	}
+	if !exists {
+		return apperrors.NoData.WrapMessage(nil, apperrors.MsgItemNotInShop, nil)
+	}
+
+	if _, err := dbtx.ExecContext(ctx, `DELETE FROM item_allergens WHERE item_id = $1`, itemID); err != nil {
+		return apperrors.DeleteDataFailed.Wrap(err, "商品のアレルゲンの削除に失敗しました。")
+	}
+	for _, allergen := range allergens {
+		if _, err := dbtx.ExecContext(ctx, `INSERT INTO item_allergens (item_id, allergen) VALUES ($1, $2)`, itemID, allergen); err != nil {
+			return apperrors.InsertDataFailed.Wrap(err, "商品のアレルゲンの登録に失敗しました。")
+		}
+	}
+
+	if _, err := dbtx.ExecContext(ctx, `DELETE FROM item_dietary_tags WHERE item_id = $1`, itemID); err != nil {
+		return apperrors.DeleteDataFailed.Wrap(err, "商品の食事の制限の削除に失敗しました。")
+	}
+	for _, tag := range dietaryTags {
+		if _, err := dbtx.ExecContext(ctx, `INSERT INTO item_dietary_tags (item_id, tag) VALUES ($1, $2)`, itemID, tag); err != nil {
+			return apperrors.InsertDataFailed.Wrap(err, "商品の食事の制限の登録に失敗しました。")
+		}
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/A4-dev-team/mobileorder.git/apperrors"
 	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
@@ -38,9 +39,11 @@ func createItemTestShop(shopID int, name string) models.Shop {
 
 func createItemTestItem(itemID int, itemName string, price int) models.Item {
 	return models.Item{
-		ItemID:   itemID,
-		ItemName: itemName,
-		Price:    price,
+		ItemID:      itemID,
+		ItemName:    itemName,
+		Price:       price,
+		BasePrice:   price,                  // 店舗ごとの価格がなければ全店舗共通の価格と同じ
+		TaxCategory: models.TaxCategoryFood, // DBの既定値
 	}
 }
 
@@ -96,7 +99,7 @@ func setupItemRepositoryTestData(t *testing.T, tx *sqlx.Tx) {
 
 func assertItemMapsEqual(t *testing.T, expected, actual map[int]models.Item) {
 	t.Helper()
-	opts := cmpopts.IgnoreFields(models.Item{}, "Description", "IsAvailable", "CreatedAt", "UpdatedAt")
+	opts := cmpopts.IgnoreFields(models.Item{}, "Description", "IsAvailable", "CreatedAt", "UpdatedAt", "TimeZone")
 	if diff := cmp.Diff(expected, actual, opts); diff != "" {
 		t.Errorf("item map mismatch (-want +got):\n%s", diff)
 	}
@@ -175,3 +178,678 @@ func TestItemRepository_ValidateAndGetItemsForShop(t *testing.T) {
 		})
 	}
 }
+
+func intPtr(i int) *int {
+	return &i
+}
+
+// setItemTestStock - shop_itemの在庫数を設定するヘルパー関数
+func setItemTestStock(t *testing.T, tx *sqlx.Tx, shopID, itemID int, stock int) {
+	t.Helper()
+	if _, err := tx.Exec(`UPDATE shop_item SET stock_quantity = $1 WHERE shop_id = $2 AND item_id = $3`, stock, shopID, itemID); err != nil {
+		t.Fatalf("failed to set stock: %v", err)
+	}
+}
+
+// getItemTestStock - shop_itemの在庫数を取得するヘルパー関数
+func getItemTestStock(t *testing.T, tx *sqlx.Tx, shopID, itemID int) *int {
+	t.Helper()
+	var stock *int
+	if err := tx.Get(&stock, `SELECT stock_quantity FROM shop_item WHERE shop_id = $1 AND item_id = $2`, shopID, itemID); err != nil {
+		t.Fatalf("failed to get stock: %v", err)
+	}
+	return stock
+}
+
+func TestItemRepository_DecrementStock(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tests := []struct {
+		name          string
+		itemID        int
+		quantity      int
+		setup         func(t *testing.T, tx *sqlx.Tx)
+		wantStock     *int
+		expectErrCode apperrors.ErrCode
+	}{
+		{
+			name:     "正常系: 在庫数が注文数だけ減る",
+			itemID:   itemTestItemID1,
+			quantity: 3,
+			setup: func(t *testing.T, tx *sqlx.Tx) {
+				setItemTestStock(t, tx, itemTestShopID1, itemTestItemID1, 5)
+			},
+			wantStock: intPtr(2),
+		},
+		{
+			name:     "正常系: 在庫をちょうど使い切ると0になる",
+			itemID:   itemTestItemID1,
+			quantity: 5,
+			setup: func(t *testing.T, tx *sqlx.Tx) {
+				setItemTestStock(t, tx, itemTestShopID1, itemTestItemID1, 5)
+			},
+			wantStock: intPtr(0),
+		},
+		{
+			name:      "正常系: 在庫数を管理していない商品は変化しない",
+			itemID:    itemTestItemID2,
+			quantity:  100,
+			setup:     func(t *testing.T, tx *sqlx.Tx) {},
+			wantStock: nil,
+		},
+		{
+			name:     "異常系: 在庫が足りない",
+			itemID:   itemTestItemID1,
+			quantity: 6,
+			setup: func(t *testing.T, tx *sqlx.Tx) {
+				setItemTestStock(t, tx, itemTestShopID1, itemTestItemID1, 5)
+			},
+			wantStock:     intPtr(5),
+			expectErrCode: apperrors.Conflict,
+		},
+		{
+			name:          "異常系: 店舗で扱っていない商品",
+			itemID:        itemTestItemID3,
+			quantity:      1,
+			setup:         func(t *testing.T, tx *sqlx.Tx) {},
+			expectErrCode: apperrors.NoData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := db.MustBegin()
+			defer func() {
+				if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+					t.Logf("transaction rollback failed: %v", err)
+				}
+			}()
+
+			setupItemRepositoryTestData(t, tx)
+			tt.setup(t, tx)
+
+			repo := repositories.NewItemRepository()
+			err := repo.DecrementStock(ctx, tx, itemTestShopID1, tt.itemID, tt.quantity)
+
+			if tt.expectErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectErrCode)
+				if tt.expectErrCode == apperrors.NoData {
+					return
+				}
+			} else {
+				testhelpers.AssertNoError(t, err)
+			}
+
+			if diff := cmp.Diff(tt.wantStock, getItemTestStock(t, tx, itemTestShopID1, tt.itemID)); diff != "" {
+				t.Errorf("stock mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestItemRepository_RestockOrderItems(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("transaction rollback failed: %v", err)
+		}
+	}()
+
+	setupItemRepositoryTestData(t, tx)
+	setItemTestStock(t, tx, itemTestShopID1, itemTestItemID1, 2)
+
+	var orderID int
+	if err := tx.Get(&orderID, `INSERT INTO orders (shop_id, total_amount, status) VALUES ($1, 0, $2) RETURNING order_id`, itemTestShopID1, models.Cooking); err != nil {
+		t.Fatalf("failed to insert order: %v", err)
+	}
+	if _, err := tx.Exec(`
+		INSERT INTO order_item (order_id, item_id, quantity, price_at_order)
+		VALUES ($1, $2, 3, 100), ($1, $3, 4, 200)`,
+		orderID, itemTestItemID1, itemTestItemID2); err != nil {
+		t.Fatalf("failed to insert order items: %v", err)
+	}
+
+	repo := repositories.NewItemRepository()
+	err := repo.RestockOrderItems(ctx, tx, itemTestShopID1, orderID)
+	testhelpers.AssertNoError(t, err)
+
+	if diff := cmp.Diff(intPtr(5), getItemTestStock(t, tx, itemTestShopID1, itemTestItemID1)); diff != "" {
+		t.Errorf("tracked stock mismatch (-want +got):\n%s", diff)
+	}
+	if got := getItemTestStock(t, tx, itemTestShopID1, itemTestItemID2); got != nil {
+		t.Errorf("untracked stock should stay NULL, got %d", *got)
+	}
+}
+
+func TestItemRepository_UpdateItemStock(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tests := []struct {
+		name          string
+		shopID        int
+		itemID        int
+		stock         *int
+		expectErrCode apperrors.ErrCode
+	}{
+		{
+			name:   "正常系: 在庫数を設定できる",
+			shopID: itemTestShopID1,
+			itemID: itemTestItemID1,
+			stock:  intPtr(30),
+		},
+		{
+			name:   "正常系: nilで在庫数の管理をやめる",
+			shopID: itemTestShopID1,
+			itemID: itemTestItemID1,
+			stock:  nil,
+		},
+		{
+			name:          "異常系: 他の店舗の商品",
+			shopID:        itemTestShopID1,
+			itemID:        itemTestItemID3,
+			stock:         intPtr(30),
+			expectErrCode: apperrors.NoData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := db.MustBegin()
+			defer func() {
+				if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+					t.Logf("transaction rollback failed: %v", err)
+				}
+			}()
+
+			setupItemRepositoryTestData(t, tx)
+			setItemTestStock(t, tx, itemTestShopID1, itemTestItemID1, 10)
+
+			repo := repositories.NewItemRepository()
+			err := repo.UpdateItemStock(ctx, tx, tt.shopID, tt.itemID, tt.stock)
+
+			if tt.expectErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectErrCode)
+				return
+			}
+			testhelpers.AssertNoError(t, err)
+			if diff := cmp.Diff(tt.stock, getItemTestStock(t, tx, tt.shopID, tt.itemID)); diff != "" {
+				t.Errorf("stock mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestItemRepository_CreateAndFindModifierGroups(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("transaction rollback failed: %v", err)
+		}
+	}()
+
+	setupItemRepositoryTestData(t, tx)
+	repo := repositories.NewItemRepository()
+
+	toppings := &models.ModifierGroup{
+		ItemID:        itemTestItemID1,
+		Name:          "トッピング",
+		SelectionType: models.MultiSelect,
+		MinSelect:     0,
+		MaxSelect:     2,
+		SortOrder:     2,
+		Options: []models.ModifierOption{
+			{Name: "味玉", PriceDelta: 120, IsAvailable: true, SortOrder: 2},
+			{Name: "ねぎ抜き", PriceDelta: -20, IsAvailable: true, SortOrder: 1},
+		},
+	}
+	size := &models.ModifierGroup{
+		ItemID:        itemTestItemID1,
+		Name:          "サイズ",
+		SelectionType: models.SingleSelect,
+		MinSelect:     1,
+		MaxSelect:     1,
+		SortOrder:     1,
+		Options: []models.ModifierOption{
+			{Name: "大盛り", PriceDelta: 100, IsAvailable: true},
+		},
+	}
+	for _, g := range []*models.ModifierGroup{toppings, size} {
+		testhelpers.AssertNoError(t, repo.CreateModifierGroup(ctx, tx, itemTestShopID1, g))
+		if g.ModifierGroupID == 0 {
+			t.Fatal("ModifierGroupIDが設定されていません")
+		}
+	}
+
+	// 他店舗の商品には登録できない
+	other := &models.ModifierGroup{ItemID: itemTestItemID3, Name: "サイズ", SelectionType: models.SingleSelect, MinSelect: 1, MaxSelect: 1}
+	testhelpers.AssertAppError(t, repo.CreateModifierGroup(ctx, tx, itemTestShopID1, other), apperrors.NoData)
+
+	got, err := repo.FindModifierGroupsByItemIDs(ctx, tx, itemTestShopID1, []int{itemTestItemID1, itemTestItemID2})
+	testhelpers.AssertNoError(t, err)
+
+	if _, ok := got[itemTestItemID2]; ok {
+		t.Error("オプションのない商品は結果に含まれるべきではありません")
+	}
+	groups := got[itemTestItemID1]
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(groups))
+	}
+	if groups[0].Name != "サイズ" || groups[1].Name != "トッピング" {
+		t.Errorf("groups are not ordered by sort_order: %q, %q", groups[0].Name, groups[1].Name)
+	}
+	if groups[1].Options[0].Name != "ねぎ抜き" || groups[1].Options[1].Name != "味玉" {
+		t.Errorf("options are not ordered by sort_order: %q, %q", groups[1].Options[0].Name, groups[1].Options[1].Name)
+	}
+
+	// 同じ商品を扱う他の店舗には、登録した店舗のグループは表示されず、削除もできない
+	tx.MustExec(`INSERT INTO shop_item (shop_id, item_id) VALUES ($1, $2)`, itemTestShopID2, itemTestItemID1)
+	otherShop, err := repo.FindModifierGroupsByItemIDs(ctx, tx, itemTestShopID2, []int{itemTestItemID1})
+	testhelpers.AssertNoError(t, err)
+	if len(otherShop) != 0 {
+		t.Errorf("他の店舗のオプショングループが含まれています: %v", otherShop)
+	}
+	testhelpers.AssertAppError(t, repo.DeleteModifierGroup(ctx, tx, itemTestShopID2, size.ModifierGroupID), apperrors.NoData)
+	testhelpers.AssertNoError(t, repo.DeleteModifierGroup(ctx, tx, itemTestShopID1, size.ModifierGroupID))
+}
+
+func TestItemRepository_ShopMenuItems(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("transaction rollback failed: %v", err)
+		}
+	}()
+
+	setupItemRepositoryTestData(t, tx)
+	// テストデータは商品IDを指定して登録しているため、採番をその後ろから始める
+	tx.MustExec(`SELECT setval('items_item_id_seq', (SELECT MAX(item_id) FROM items))`)
+	repo := repositories.NewItemRepository()
+
+	created := &models.Item{ItemName: "New Item", Description: "説明", Price: 500, IsAvailable: true, TaxCategory: models.TaxCategoryStandard, StockQuantity: intPtr(5)}
+	testhelpers.AssertNoError(t, repo.CreateShopMenuItem(ctx, tx, itemTestShopID1, created))
+	if created.ItemID == 0 {
+		t.Fatal("登録した商品IDが設定されていません")
+	}
+
+	updated := &models.Item{ItemID: itemTestItemID1, ItemName: "Item 1 改", Price: 150, IsAvailable: false, TaxCategory: models.TaxCategoryFood, StockQuantity: intPtr(3)}
+	testhelpers.AssertNoError(t, repo.UpdateShopMenuItem(ctx, tx, itemTestShopID1, updated))
+
+	// 他の店舗の商品は更新できない
+	other := &models.Item{ItemID: itemTestItemID3, ItemName: "Item 3", Price: 300, TaxCategory: models.TaxCategoryFood}
+	testhelpers.AssertAppError(t, repo.UpdateShopMenuItem(ctx, tx, itemTestShopID1, other), apperrors.NoData)
+
+	items, err := repo.FindShopMenuItems(ctx, tx, itemTestShopID1)
+	testhelpers.AssertNoError(t, err)
+	want := []models.Item{
+		*updated,
+		{ItemID: itemTestItemID2, ItemName: "Item 2", Price: itemTestPrice2, IsAvailable: true, TaxCategory: models.TaxCategoryFood},
+		*created,
+	}
+	if diff := cmp.Diff(want, items, cmpopts.IgnoreFields(models.Item{}, "CreatedAt", "UpdatedAt")); diff != "" {
+		t.Errorf("items mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestItemRepository_UpdateItemImage(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("transaction rollback failed: %v", err)
+		}
+	}()
+
+	setupItemRepositoryTestData(t, tx)
+	repo := repositories.NewItemRepository()
+
+	previous, err := repo.UpdateItemImage(ctx, tx, itemTestShopID1, itemTestItemID1, "items/1/a.jpg", "items/1/a_thumb.jpg")
+	testhelpers.AssertNoError(t, err)
+	if len(previous) != 0 {
+		t.Errorf("previous keys = %v, want none", previous)
+	}
+
+	// 差し替えると前の画像のキーを返す
+	previous, err = repo.UpdateItemImage(ctx, tx, itemTestShopID1, itemTestItemID1, "items/1/b.png", "items/1/b_thumb.png")
+	testhelpers.AssertNoError(t, err)
+	if diff := cmp.Diff([]string{"items/1/a.jpg", "items/1/a_thumb.jpg"}, previous); diff != "" {
+		t.Errorf("previous keys mismatch (-want +got):\n%s", diff)
+	}
+
+	items, err := repo.GetItemList(tx, itemTestShopID1)
+	testhelpers.AssertNoError(t, err)
+	if items[0].ImageKey == nil || *items[0].ImageKey != "items/1/b.png" || *items[0].ThumbnailKey != "items/1/b_thumb.png" {
+		t.Errorf("unexpected image keys: %v, %v", items[0].ImageKey, items[0].ThumbnailKey)
+	}
+
+	// 他の店舗の商品の画像は変更できない
+	_, err = repo.UpdateItemImage(ctx, tx, itemTestShopID1, itemTestItemID3, "items/3/a.jpg", "items/3/a_thumb.jpg")
+	testhelpers.AssertAppError(t, err, apperrors.NoData)
+}
+
+func TestItemRepository_ReplaceAvailabilityWindows(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("transaction rollback failed: %v", err)
+		}
+	}()
+
+	setupItemRepositoryTestData(t, tx)
+	repo := repositories.NewItemRepository()
+
+	// 平日のランチ
+	lunch := []models.AvailabilityWindow{
+		{DayOfWeek: time.Monday, StartMinute: 11 * 60, EndMinute: 14 * 60},
+		{DayOfWeek: time.Friday, StartMinute: 11 * 60, EndMinute: 14 * 60},
+	}
+	testhelpers.AssertNoError(t, repo.ReplaceAvailabilityWindows(ctx, tx, itemTestShopID1, itemTestItemID1, lunch))
+
+	itemMap, err := repo.ValidateAndGetItemsForShop(ctx, tx, itemTestShopID1, []int{itemTestItemID1, itemTestItemID2})
+	testhelpers.AssertNoError(t, err)
+	want := []models.AvailabilityWindow{
+		{ItemID: itemTestItemID1, DayOfWeek: time.Monday, StartMinute: 660, EndMinute: 840},
+		{ItemID: itemTestItemID1, DayOfWeek: time.Friday, StartMinute: 660, EndMinute: 840},
+	}
+	if diff := cmp.Diff(want, itemMap[itemTestItemID1].AvailabilityWindows); diff != "" {
+		t.Errorf("windows mismatch (-want +got):\n%s", diff)
+	}
+	if itemMap[itemTestItemID1].TimeZone != "Asia/Tokyo" {
+		t.Errorf("TimeZone = %q, want Asia/Tokyo", itemMap[itemTestItemID1].TimeZone)
+	}
+	if len(itemMap[itemTestItemID2].AvailabilityWindows) != 0 {
+		t.Errorf("windows of item 2 = %v, want none", itemMap[itemTestItemID2].AvailabilityWindows)
+	}
+
+	items, err := repo.GetItemList(tx, itemTestShopID1)
+	testhelpers.AssertNoError(t, err)
+	if diff := cmp.Diff(want, items[0].Schedule); diff != "" {
+		t.Errorf("item list schedule mismatch (-want +got):\n%s", diff)
+	}
+
+	// 置き換えると前の時間帯は残らない。空にするといつでも注文できる
+	testhelpers.AssertNoError(t, repo.ReplaceAvailabilityWindows(ctx, tx, itemTestShopID1, itemTestItemID1, nil))
+	itemMap, err = repo.ValidateAndGetItemsForShop(ctx, tx, itemTestShopID1, []int{itemTestItemID1})
+	testhelpers.AssertNoError(t, err)
+	if len(itemMap[itemTestItemID1].AvailabilityWindows) != 0 {
+		t.Errorf("windows = %v, want none", itemMap[itemTestItemID1].AvailabilityWindows)
+	}
+
+	// 他の店舗の商品の時間帯は変更できない
+	err = repo.ReplaceAvailabilityWindows(ctx, tx, itemTestShopID1, itemTestItemID3, lunch)
+	testhelpers.AssertAppError(t, err, apperrors.NoData)
+}
+
+func TestItemRepository_BundleSlots(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("transaction rollback failed: %v", err)
+		}
+	}()
+
+	setupItemRepositoryTestData(t, tx)
+	setItemTestStock(t, tx, itemTestShopID1, itemTestItemID2, 5)
+	repo := repositories.NewItemRepository()
+
+	// Item 1 をセットにする。Item 4 は店舗で扱っていないので選べない
+	slots := []models.BundleSlot{
+		{Name: "メイン", Quantity: 1, Choices: []models.BundleChoice{
+			{ItemID: itemTestItemID2},
+			{ItemID: itemTestItemID4, PriceDelta: 50, SortOrder: 1},
+		}},
+	}
+	testhelpers.AssertNoError(t, repo.ReplaceBundleSlots(ctx, tx, itemTestShopID1, itemTestItemID1, slots))
+
+	slotsMap, err := repo.FindBundleSlotsByItemIDs(ctx, tx, itemTestShopID1, []int{itemTestItemID1, itemTestItemID2})
+	testhelpers.AssertNoError(t, err)
+	if _, ok := slotsMap[itemTestItemID2]; ok {
+		t.Errorf("item 2 is not a bundle, got %v", slotsMap[itemTestItemID2])
+	}
+	want := []models.BundleSlot{
+		{ShopID: itemTestShopID1, ItemID: itemTestItemID1, Name: "メイン", Quantity: 1, Choices: []models.BundleChoice{
+			{ItemID: itemTestItemID2, ItemName: "Item 2", Price: itemTestPrice2, IsAvailable: true, StockQuantity: intPtr(5)},
+			{ItemID: itemTestItemID4, ItemName: "Item 4", Price: itemTestPrice4, PriceDelta: 50, SortOrder: 1},
+		}},
+	}
+	opts := []cmp.Option{
+		cmpopts.IgnoreFields(models.BundleSlot{}, "BundleSlotID", "CreatedAt"),
+		cmpopts.IgnoreFields(models.BundleChoice{}, "BundleChoiceID", "BundleSlotID", "CreatedAt"),
+		cmpopts.EquateEmpty(),
+	}
+	if diff := cmp.Diff(want, slotsMap[itemTestItemID1], opts...); diff != "" {
+		t.Errorf("bundle slots mismatch (-want +got):\n%s", diff)
+	}
+
+	// 構成商品をセットにはできない
+	err = repo.ReplaceBundleSlots(ctx, tx, itemTestShopID1, itemTestItemID2, slots)
+	testhelpers.AssertAppError(t, err, apperrors.Conflict)
+
+	// 他の店舗の商品の構成は変更できない
+	err = repo.ReplaceBundleSlots(ctx, tx, itemTestShopID1, itemTestItemID3, slots)
+	testhelpers.AssertAppError(t, err, apperrors.NoData)
+
+	// 同じ商品を扱う他の店舗には、設定した店舗の枠は表示されず、他の店舗で構成を変えても影響しない
+	tx.MustExec(`INSERT INTO shop_item (shop_id, item_id) VALUES ($1, $2)`, itemTestShopID2, itemTestItemID1)
+	otherShop, err := repo.FindBundleSlotsByItemIDs(ctx, tx, itemTestShopID2, []int{itemTestItemID1})
+	testhelpers.AssertNoError(t, err)
+	if len(otherShop) != 0 {
+		t.Errorf("他の店舗のセットの枠が含まれています: %v", otherShop)
+	}
+	testhelpers.AssertNoError(t, repo.ReplaceBundleSlots(ctx, tx, itemTestShopID2, itemTestItemID1, nil))
+	slotsMap, err = repo.FindBundleSlotsByItemIDs(ctx, tx, itemTestShopID1, []int{itemTestItemID1})
+	testhelpers.AssertNoError(t, err)
+	if len(slotsMap[itemTestItemID1]) != 1 {
+		t.Errorf("slots = %v, want the slot of shop %d", slotsMap, itemTestShopID1)
+	}
+
+	// 空にするとセットを解除する
+	testhelpers.AssertNoError(t, repo.ReplaceBundleSlots(ctx, tx, itemTestShopID1, itemTestItemID1, nil))
+	slotsMap, err = repo.FindBundleSlotsByItemIDs(ctx, tx, itemTestShopID1, []int{itemTestItemID1})
+	testhelpers.AssertNoError(t, err)
+	if len(slotsMap) != 0 {
+		t.Errorf("slots = %v, want none", slotsMap)
+	}
+}
+
+func TestItemRepository_RestockOrderItemsWithComponents(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("transaction rollback failed: %v", err)
+		}
+	}()
+
+	setupItemRepositoryTestData(t, tx)
+	setItemTestStock(t, tx, itemTestShopID1, itemTestItemID2, 1)
+
+	var orderID, orderItemID int
+	if err := tx.Get(&orderID, `INSERT INTO orders (shop_id, total_amount, status) VALUES ($1, 0, $2) RETURNING order_id`, itemTestShopID1, models.Cooking); err != nil {
+		t.Fatalf("failed to insert order: %v", err)
+	}
+	if err := tx.Get(&orderItemID, `
+		INSERT INTO order_item (order_id, item_id, quantity, price_at_order)
+		VALUES ($1, $2, 3, 100) RETURNING order_item_id`,
+		orderID, itemTestItemID1); err != nil {
+		t.Fatalf("failed to insert order item: %v", err)
+	}
+	// セット1つに Item 2 が2個入っている
+	if _, err := tx.Exec(`
+		INSERT INTO order_item_components (order_item_id, item_id, slot_name, item_name, quantity)
+		VALUES ($1, $2, 'メイン', 'Item 2', 2)`,
+		orderItemID, itemTestItemID2); err != nil {
+		t.Fatalf("failed to insert order item components: %v", err)
+	}
+
+	repo := repositories.NewItemRepository()
+	testhelpers.AssertNoError(t, repo.RestockOrderItems(ctx, tx, itemTestShopID1, orderID))
+
+	if diff := cmp.Diff(intPtr(7), getItemTestStock(t, tx, itemTestShopID1, itemTestItemID2)); diff != "" {
+		t.Errorf("component stock mismatch (-want +got):\n%s", diff)
+	}
+	if got := getItemTestStock(t, tx, itemTestShopID1, itemTestItemID1); got != nil {
+		t.Errorf("untracked stock should stay NULL, got %d", *got)
+	}
+}
+
+func TestItemRepository_ReplaceItemDietaryInfo(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("transaction rollback failed: %v", err)
+		}
+	}()
+
+	setupItemRepositoryTestData(t, tx)
+	repo := repositories.NewItemRepository()
+
+	testhelpers.AssertNoError(t, repo.ReplaceItemDietaryInfo(ctx, tx, itemTestShopID1, itemTestItemID1, []string{"wheat", "egg"}, []string{"vegetarian"}))
+
+	items, err := repo.GetItemList(tx, itemTestShopID1)
+	testhelpers.AssertNoError(t, err)
+	if diff := cmp.Diff([]string{"egg", "wheat"}, items[0].Allergens); diff != "" {
+		t.Errorf("allergens mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"vegetarian"}, items[0].DietaryTags); diff != "" {
+		t.Errorf("dietary tags mismatch (-want +got):\n%s", diff)
+	}
+	// 登録のない商品は空の配列
+	if items[1].Allergens == nil || len(items[1].Allergens) != 0 || items[1].DietaryTags == nil || len(items[1].DietaryTags) != 0 {
+		t.Errorf("item 2 = %v, %v, want empty slices", items[1].Allergens, items[1].DietaryTags)
+	}
+
+	// 置き換えると前の登録は残らない
+	testhelpers.AssertNoError(t, repo.ReplaceItemDietaryInfo(ctx, tx, itemTestShopID1, itemTestItemID1, []string{"milk"}, nil))
+	items, err = repo.GetItemList(tx, itemTestShopID1)
+	testhelpers.AssertNoError(t, err)
+	if diff := cmp.Diff([]string{"milk"}, items[0].Allergens); diff != "" {
+		t.Errorf("allergens mismatch (-want +got):\n%s", diff)
+	}
+	if len(items[0].DietaryTags) != 0 {
+		t.Errorf("dietary tags = %v, want none", items[0].DietaryTags)
+	}
+
+	// 他の店舗の商品は変更できない
+	err = repo.ReplaceItemDietaryInfo(ctx, tx, itemTestShopID1, itemTestItemID3, []string{"egg"}, nil)
+	testhelpers.AssertAppError(t, err, apperrors.NoData)
+}
+
+func TestItemRepository_PriceChanges(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("transaction rollback failed: %v", err)
+		}
+	}()
+
+	setupItemRepositoryTestData(t, tx)
+	repo := repositories.NewItemRepository()
+
+	// すぐに反映する変更は店舗での価格を上書きする
+	immediate := &models.PriceChange{ItemID: itemTestItemID1, Price: intPtr(150)}
+	testhelpers.AssertNoError(t, repo.CreatePriceChange(ctx, tx, itemTestShopID1, immediate))
+	if !immediate.AppliedAt.Valid {
+		t.Errorf("immediate change is not applied")
+	}
+
+	itemMap, err := repo.ValidateAndGetItemsForShop(ctx, tx, itemTestShopID1, []int{itemTestItemID1})
+	testhelpers.AssertNoError(t, err)
+	if got := itemMap[itemTestItemID1]; got.Price != 150 || got.BasePrice != itemTestPrice1 {
+		t.Errorf("price = %d, base price = %d, want 150, %d", got.Price, got.BasePrice, itemTestPrice1)
+	}
+	items, err := repo.GetItemList(tx, itemTestShopID1)
+	testhelpers.AssertNoError(t, err)
+	if items[0].Price != 150 || items[0].BasePrice != itemTestPrice1 {
+		t.Errorf("item list price = %d, base price = %d, want 150, %d", items[0].Price, items[0].BasePrice, itemTestPrice1)
+	}
+
+	// 予定の変更は日時になるまで反映しない
+	scheduled := &models.PriceChange{ItemID: itemTestItemID1, Price: intPtr(180), EffectiveAt: time.Now().Add(24 * time.Hour)}
+	testhelpers.AssertNoError(t, repo.CreatePriceChange(ctx, tx, itemTestShopID1, scheduled))
+	if scheduled.AppliedAt.Valid {
+		t.Errorf("scheduled change is applied")
+	}
+	itemMap, err = repo.ValidateAndGetItemsForShop(ctx, tx, itemTestShopID1, []int{itemTestItemID1})
+	testhelpers.AssertNoError(t, err)
+	if itemMap[itemTestItemID1].Price != 150 {
+		t.Errorf("price = %d, want 150 before the scheduled change", itemMap[itemTestItemID1].Price)
+	}
+
+	changes, err := repo.FindPriceChanges(ctx, tx, itemTestShopID1, itemTestItemID1)
+	testhelpers.AssertNoError(t, err)
+	if len(changes) != 2 || changes[0].PriceChangeID != scheduled.PriceChangeID || changes[1].PriceChangeID != immediate.PriceChangeID {
+		t.Fatalf("changes = %+v, want scheduled then immediate", changes)
+	}
+
+	// 日時を過ぎた予定は、価格を読むときに店舗での価格になる
+	_, err = tx.Exec(`UPDATE shop_item_price_changes SET effective_at = effective_at - INTERVAL '2 days' WHERE price_change_id = $1`, scheduled.PriceChangeID)
+	testhelpers.AssertNoError(t, err)
+	itemMap, err = repo.ValidateAndGetItemsForShop(ctx, tx, itemTestShopID1, []int{itemTestItemID1})
+	testhelpers.AssertNoError(t, err)
+	if itemMap[itemTestItemID1].Price != 180 {
+		t.Errorf("price = %d, want 180 after the scheduled change", itemMap[itemTestItemID1].Price)
+	}
+
+	// 反映済みの変更は取り消せない
+	err = repo.CancelPriceChange(ctx, tx, itemTestShopID1, itemTestItemID1, scheduled.PriceChangeID)
+	testhelpers.AssertAppError(t, err, apperrors.Conflict)
+
+	// 予定の変更は取り消せる。nullの変更は全店舗共通の価格に戻す予定
+	reset := &models.PriceChange{ItemID: itemTestItemID1, EffectiveAt: time.Now().Add(time.Hour)}
+	testhelpers.AssertNoError(t, repo.CreatePriceChange(ctx, tx, itemTestShopID1, reset))
+	testhelpers.AssertNoError(t, repo.CancelPriceChange(ctx, tx, itemTestShopID1, itemTestItemID1, reset.PriceChangeID))
+	err = repo.CancelPriceChange(ctx, tx, itemTestShopID1, itemTestItemID1, reset.PriceChangeID)
+	testhelpers.AssertAppError(t, err, apperrors.NoData)
+
+	// すぐに全店舗共通の価格に戻す
+	testhelpers.AssertNoError(t, repo.CreatePriceChange(ctx, tx, itemTestShopID1, &models.PriceChange{ItemID: itemTestItemID1}))
+	itemMap, err = repo.ValidateAndGetItemsForShop(ctx, tx, itemTestShopID1, []int{itemTestItemID1})
+	testhelpers.AssertNoError(t, err)
+	if itemMap[itemTestItemID1].Price != itemTestPrice1 {
+		t.Errorf("price = %d, want %d after reset", itemMap[itemTestItemID1].Price, itemTestPrice1)
+	}
+
+	// 他の店舗の商品の価格は変更できない
+	err = repo.CreatePriceChange(ctx, tx, itemTestShopID1, &models.PriceChange{ItemID: itemTestItemID3, Price: intPtr(100)})
+	testhelpers.AssertAppError(t, err, apperrors.NoData)
+	_, err = repo.FindPriceChanges(ctx, tx, itemTestShopID1, itemTestItemID3)
+	testhelpers.AssertAppError(t, err, apperrors.NoData)
+}
@@ -16,12 +16,20 @@ type OrderRepository interface {
 	UpdateUserIDByGuestToken(ctx context.Context, dbtx DBTX, guestToken string, userID int) error
 	FindActiveUserOrders(ctx context.Context, dbtx DBTX, userID int) ([]OrderWithDetailsDB, error)
 	FindItemsByOrderIDs(ctx context.Context, dbtx DBTX, orderIDs []int) (map[int][]models.ItemDetail, error)
+	FindTaxLinesByOrderIDs(ctx context.Context, dbtx DBTX, orderIDs []int) (map[int][]models.OrderTaxLine, error)
+	FindDiscountsByOrderIDs(ctx context.Context, dbtx DBTX, orderIDs []int) (map[int][]models.OrderDiscount, error)
 	FindOrderByIDAndUser(ctx context.Context, dbtx DBTX, orderID int, userID int) (*models.Order, error)
 	CountWaitingOrders(ctx context.Context, dbtx DBTX, shopID int, orderDate time.Time) (int, error)
 	FindShopOrdersByStatuses(ctx context.Context, dbtx DBTX, shopID int, statuses []models.OrderStatus) ([]AdminOrderDBResult, error)
 	FindOrderByIDAndShopID(ctx context.Context, dbtx DBTX, orderID int, shopID int) (*models.Order, error)
+	FindOrderByIDAndShopIDForUpdate(ctx context.Context, dbtx DBTX, orderID int, shopID int) (*models.Order, error)
+	FindOrderByID(ctx context.Context, dbtx DBTX, orderID int) (*models.Order, error)
 	UpdateOrderStatus(ctx context.Context, dbtx DBTX, orderID int, shopID int, newStatus models.OrderStatus) error
 	DeleteOrderByIDAndShopID(ctx context.Context, dbtx DBTX, orderID int, shopID int) error
+	FindCookingQueue(ctx context.Context, dbtx DBTX, shopID int) ([]KitchenQueueEntry, error)
+	FindRecentKitchenDurations(ctx context.Context, dbtx DBTX, shopID int, since time.Time, limit int) ([]KitchenDurationSample, error)
+	FindReceiptOrder(ctx context.Context, dbtx DBTX, orderID int) (*ReceiptOrderDB, error)
+	FindReceiptLines(ctx context.Context, dbtx DBTX, orderID int) ([]ReceiptLineDB, error)
 }
 
 type orderRepository struct{}
@@ -32,35 +40,90 @@ func NewOrderRepository() OrderRepository {
 
 func (r *orderRepository) CreateOrder(ctx context.Context, dbtx DBTX, order *models.Order, items []models.OrderItem) error {
 	orderQuery := `
-		INSERT INTO orders (user_id, shop_id, order_date, total_amount, guest_order_token, status)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING order_id, created_at, updated_at
+		INSERT INTO orders (user_id, shop_id, order_date, total_amount, guest_order_token, status, note, has_allergy, dining_option, subtotal_amount, tax_amount, discount_amount)
+		VALUES ($1, $2, NOW(), $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING order_id, order_date, created_at, updated_at
 	`
 	err := dbtx.QueryRowxContext(
 		ctx,
 		orderQuery,
 		order.UserID,
 		order.ShopID,
-		time.Now(),
 		order.TotalAmount,
 		order.GuestOrderToken,
 		order.Status,
-	).Scan(&order.OrderID, &order.CreatedAt, &order.UpdatedAt)
+		order.Note,
+		order.HasAllergy,
+		order.DiningOption,
+		order.SubtotalAmount,
+		order.TaxAmount,
+		order.DiscountAmount,
+	).Scan(&order.OrderID, &order.OrderDate, &order.CreatedAt, &order.UpdatedAt)
 
 	if err != nil {
 		return apperrors.InsertDataFailed.Wrap(err, "注文の作成に失敗しました。")
 	}
 
-	stmt, err := dbtx.PreparexContext(ctx, "INSERT INTO order_item (order_id, item_id, quantity, price_at_order) VALUES ($1, $2, $3, $4)")
+	stmt, err := dbtx.PreparexContext(ctx, "INSERT INTO order_item (order_id, item_id, quantity, price_at_order, modifier_price_delta, note, tax_rate) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING order_item_id")
 	if err != nil {
 		return apperrors.InsertDataFailed.Wrap(err, "注文商品登録の準備に失敗しました。")
 	}
 	defer stmt.Close()
 
-	for _, item := range items {
-		if _, err = stmt.ExecContext(ctx, order.OrderID, item.ItemID, item.Quantity, item.PriceAtOrder); err != nil {
+	for i := range items {
+		item := &items[i]
+		if err = stmt.QueryRowxContext(ctx, order.OrderID, item.ItemID, item.Quantity, item.PriceAtOrder, item.ModifierPriceDelta, item.Note, item.TaxRate).Scan(&item.OrderItemID); err != nil {
 			return apperrors.InsertDataFailed.Wrap(err, "注文商品の登録に失敗しました。")
 		}
+		item.OrderID = order.OrderID
+
+		for _, modifier := range item.Modifiers {
+			modifierQuery := `
+				INSERT INTO order_item_modifiers (order_item_id, modifier_option_id, group_name, option_name, price_delta)
+				VALUES ($1, $2, $3, $4, $5)
+			`
+			if _, err = dbtx.ExecContext(ctx, modifierQuery, item.OrderItemID, modifier.ModifierOptionID, modifier.GroupName, modifier.OptionName, modifier.PriceDelta); err != nil {
+				return apperrors.InsertDataFailed.Wrap(err, "注文商品のオプションの登録に失敗しました。")
+			}
+		}
+
+		for j := range item.Components {
+			component := &item.Components[j]
+			component.OrderItemID = item.OrderItemID
+			componentQuery := `
+				INSERT INTO order_item_components (order_item_id, bundle_choice_id, item_id, slot_name, item_name, quantity, price_delta)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)
+				RETURNING order_item_component_id
+			`
+			if err = dbtx.QueryRowxContext(ctx, componentQuery, component.OrderItemID, component.BundleChoiceID, component.ItemID,
+				component.SlotName, component.ItemName, component.Quantity, component.PriceDelta).Scan(&component.OrderItemComponentID); err != nil {
+				return apperrors.InsertDataFailed.Wrap(err, "注文したセットの構成商品の登録に失敗しました。")
+			}
+		}
+	}
+
+	for i := range order.TaxLines {
+		line := &order.TaxLines[i]
+		line.OrderID = order.OrderID
+		taxQuery := `
+			INSERT INTO order_tax_lines (order_id, tax_rate, taxable_amount, tax_amount)
+			VALUES ($1, $2, $3, $4)
+		`
+		if _, err = dbtx.ExecContext(ctx, taxQuery, line.OrderID, line.TaxRate, line.TaxableAmount, line.TaxAmount); err != nil {
+			return apperrors.InsertDataFailed.Wrap(err, "注文の消費税の内訳の登録に失敗しました。")
+		}
+	}
+
+	for i := range order.Discounts {
+		discount := &order.Discounts[i]
+		discount.OrderID = order.OrderID
+		discountQuery := `
+			INSERT INTO order_discounts (order_id, source, tax_rate, promotion_id, code, name, amount)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`
+		if _, err = dbtx.ExecContext(ctx, discountQuery, discount.OrderID, discount.Source, discount.TaxRate, discount.PromotionID, discount.Code, discount.Name, discount.Amount); err != nil {
+			return apperrors.InsertDataFailed.Wrap(err, "注文の値引きの登録に失敗しました。")
+		}
 	}
 
 	return nil
@@ -91,7 +154,7 @@ func (r *orderRepository) UpdateUserIDByGuestToken(ctx context.Context, dbtx DBT
 		}
 
 		if existingUserID.Valid {
-			return apperrors.Conflict.Wrap(nil, "この注文は既に他のユーザーアカウントに紐付けられています。")
+			return apperrors.Conflict.WrapMessage(nil, apperrors.MsgOrderClaimedByOtherUser, nil)
 		}
 
 		return apperrors.NoData.Wrap(nil, "指定されたゲスト注文は見つかりませんでした。")
@@ -102,23 +165,36 @@ func (r *orderRepository) UpdateUserIDByGuestToken(ctx context.Context, dbtx DBT
 // ユーザーの注文情報をとってくる。
 type OrderWithDetailsDB struct {
 	OrderID      int                `db:"order_id"`
+	ShopID       int                `db:"shop_id"`
 	ShopName     string             `db:"shop_name"`
 	Location     string             `db:"location"`
 	OrderDate    time.Time          `db:"order_date"`
 	TotalAmount  int                `db:"total_amount"`
 	Status       models.OrderStatus `db:"status"`
 	WaitingCount int                `db:"waiting_count"`
+	CompletedAt  sql.NullTime       `db:"completed_at"`
+
+	DiningOption   models.DiningOption `db:"dining_option"`
+	SubtotalAmount int                 `db:"subtotal_amount"`
+	TaxAmount      int                 `db:"tax_amount"`
+	DiscountAmount int                 `db:"discount_amount"`
 }
 
 func (r *orderRepository) FindActiveUserOrders(ctx context.Context, dbtx DBTX, userID int) ([]OrderWithDetailsDB, error) {
 	query := `
 		SELECT
 			o.order_id,
+			o.shop_id,
 			s.name AS shop_name,
 			s.location,
 			o.order_date,
 			o.total_amount,
 			o.status,
+			o.completed_at,
+			o.dining_option,
+			o.subtotal_amount,
+			o.tax_amount,
+			o.discount_amount,
 			CASE
 				WHEN o.status = $1 THEN
 					(SELECT COUNT(*)
@@ -131,13 +207,13 @@ func (r *orderRepository) FindActiveUserOrders(ctx context.Context, dbtx DBTX, u
 		INNER JOIN
 			shops s ON o.shop_id = s.shop_id
 		WHERE
-			o.status IN ($1, $2) AND o.user_id = $3
+			o.status IN ($1, $2, $4) AND o.user_id = $3
 		ORDER BY
 			o.order_date DESC;
 	`
 
 	var orders []OrderWithDetailsDB
-	if err := dbtx.SelectContext(ctx, &orders, query, models.Cooking, models.Completed, userID); err != nil {
+	if err := dbtx.SelectContext(ctx, &orders, query, models.Cooking, models.Completed, userID, models.PendingPayment); err != nil {
 		return nil, apperrors.GetDataFailed.Wrap(err, "アクティブな注文履歴の取得に失敗しました。")
 	}
 
@@ -151,10 +227,11 @@ func (r *orderRepository) FindItemsByOrderIDs(ctx context.Context, dbtx DBTX, or
 	}
 
 	query, args, err := sqlx.In(`
-		SELECT oi.order_id, i.item_name, oi.quantity
+		SELECT oi.order_id, oi.order_item_id, oi.item_id, i.item_name, oi.quantity, oi.note
 		FROM order_item oi
 		INNER JOIN items i ON oi.item_id = i.item_id
 		WHERE oi.order_id IN (?)
+		ORDER BY oi.order_item_id
 	`, orderIDs)
 	if err != nil {
 		return nil, err
@@ -166,24 +243,155 @@ func (r *orderRepository) FindItemsByOrderIDs(ctx context.Context, dbtx DBTX, or
 		return nil, apperrors.GetDataFailed.Wrap(err, "データベースクエリの実行に失敗しました。")
 	}
 	defer rows.Close()
+
+	// オプションとセットの構成商品を後から紐付けるため、注文商品IDから格納位置を引けるようにしておく
+	type itemPosition struct {
+		orderID int
+		index   int
+	}
 	itemsMap := make(map[int][]models.ItemDetail)
+	positions := make(map[int]itemPosition)
+	var orderItemIDs []int
 	for rows.Next() {
-		var orderID int
+		var orderID, orderItemID int
 		var item models.ItemDetail
-		if err := rows.Scan(&orderID, &item.ItemName, &item.Quantity); err != nil {
+		var note sql.NullString
+		if err := rows.Scan(&orderID, &orderItemID, &item.ItemID, &item.ItemName, &item.Quantity, &note); err != nil {
 			return nil, apperrors.GetDataFailed.Wrap(err, "注文商品データの読み取りに失敗しました。")
 		}
+		if note.Valid {
+			item.Note = &note.String
+		}
+		item.OrderItemID = orderItemID
+		positions[orderItemID] = itemPosition{orderID: orderID, index: len(itemsMap[orderID])}
+		orderItemIDs = append(orderItemIDs, orderItemID)
 		itemsMap[orderID] = append(itemsMap[orderID], item)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "注文商品データの読み取りに失敗しました。")
+	}
+
+	if len(orderItemIDs) == 0 {
+		return itemsMap, nil
+	}
+
+	modifierQuery, args, err := sqlx.In(`
+		SELECT order_item_id, group_name, option_name, price_delta
+		FROM order_item_modifiers
+		WHERE order_item_id IN (?)
+		ORDER BY order_item_modifier_id
+	`, orderItemIDs)
+	if err != nil {
+		return nil, err
+	}
+	modifierQuery = dbtx.Rebind(modifierQuery)
+
+	var modifiers []models.OrderItemModifier
+	if err := dbtx.SelectContext(ctx, &modifiers, modifierQuery, args...); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "注文商品のオプションの取得に失敗しました。")
+	}
+	for _, m := range modifiers {
+		pos := positions[m.OrderItemID]
+		item := &itemsMap[pos.orderID][pos.index]
+		item.Modifiers = append(item.Modifiers, models.ItemModifierDetail{
+			GroupName:  m.GroupName,
+			OptionName: m.OptionName,
+			PriceDelta: m.PriceDelta,
+		})
+	}
+
+	componentQuery, args, err := sqlx.In(`
+		SELECT order_item_id, slot_name, item_name, quantity, price_delta
+		FROM order_item_components
+		WHERE order_item_id IN (?)
+		ORDER BY order_item_component_id
+	`, orderItemIDs)
+	if err != nil {
+		return nil, err
+	}
+	componentQuery = dbtx.Rebind(componentQuery)
+
+	var components []models.OrderItemComponent
+	if err := dbtx.SelectContext(ctx, &components, componentQuery, args...); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "注文したセットの構成商品の取得に失敗しました。")
+	}
+	for _, c := range components {
+		pos := positions[c.OrderItemID]
+		item := &itemsMap[pos.orderID][pos.index]
+		item.Components = append(item.Components, models.ItemComponentDetail{
+			SlotName:   c.SlotName,
+			ItemName:   c.ItemName,
+			Quantity:   c.Quantity,
+			PriceDelta: c.PriceDelta,
+		})
+	}
 
 	return itemsMap, nil
 }
 
+// FindTaxLinesByOrderIDs は注文ごとの税率別の小計と消費税額を税率の低い順に取得します。
+// 税を計算する前の注文は結果に含まれません。
+func (r *orderRepository) FindTaxLinesByOrderIDs(ctx context.Context, dbtx DBTX, orderIDs []int) (map[int][]models.OrderTaxLine, error) {
+	linesMap := make(map[int][]models.OrderTaxLine)
+	if len(orderIDs) == 0 {
+		return linesMap, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT order_id, tax_rate, taxable_amount, tax_amount
+		FROM order_tax_lines
+		WHERE order_id IN (?)
+		ORDER BY order_id, tax_rate
+	`, orderIDs)
+	if err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "データベースクエリの構築に失敗しました。")
+	}
+	query = dbtx.Rebind(query)
+
+	var lines []models.OrderTaxLine
+	if err := dbtx.SelectContext(ctx, &lines, query, args...); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "注文の消費税の内訳の取得に失敗しました。")
+	}
+	for _, line := range lines {
+		linesMap[line.OrderID] = append(linesMap[line.OrderID], line)
+	}
+	return linesMap, nil
+}
+
+// FindDiscountsByOrderIDs は注文ごとのクーポンの値引きの内訳を税率の低い順に取得します。
+// クーポンを使っていない注文は結果に含まれません。
+func (r *orderRepository) FindDiscountsByOrderIDs(ctx context.Context, dbtx DBTX, orderIDs []int) (map[int][]models.OrderDiscount, error) {
+	discountsMap := make(map[int][]models.OrderDiscount)
+	if len(orderIDs) == 0 {
+		return discountsMap, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT order_id, source, tax_rate, promotion_id, code, name, amount
+		FROM order_discounts
+		WHERE order_id IN (?)
+		ORDER BY order_id, source, tax_rate
+	`, orderIDs)
+	if err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "データベースクエリの構築に失敗しました。")
+	}
+	query = dbtx.Rebind(query)
+
+	var discounts []models.OrderDiscount
+	if err := dbtx.SelectContext(ctx, &discounts, query, args...); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "注文の値引きの取得に失敗しました。")
+	}
+	for _, discount := range discounts {
+		discountsMap[discount.OrderID] = append(discountsMap[discount.OrderID], discount)
+	}
+	return discountsMap, nil
+}
+
 func (r *orderRepository) FindOrderByIDAndUser(ctx context.Context, dbtx DBTX, orderID int, userID int) (*models.Order, error) {
 	var order models.Order
-	// アクティブな注文（cooking, completed）のみを取得
-	query := "SELECT * FROM orders WHERE order_id = $1 AND user_id = $2 AND status IN ($3, $4)"
-	if err := dbtx.GetContext(ctx, &order, query, orderID, userID, models.Cooking, models.Completed); err != nil {
+	// アクティブな注文（cooking, completed, pending_payment）のみを取得
+	query := "SELECT * FROM orders WHERE order_id = $1 AND user_id = $2 AND status IN ($3, $4, $5)"
+	if err := dbtx.GetContext(ctx, &order, query, orderID, userID, models.Cooking, models.Completed, models.PendingPayment); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, apperrors.NoData.Wrap(err, "注文が見つからないか、アクセス権がありません。")
 		}
@@ -203,11 +411,15 @@ func (r *orderRepository) CountWaitingOrders(ctx context.Context, dbtx DBTX, sho
 
 // 管理者が注文取得
 type AdminOrderDBResult struct {
-	OrderID       int                `db:"order_id"`
-	CustomerEmail sql.NullString     `db:"email"`
-	OrderDate     time.Time          `db:"order_date"`
-	TotalAmount   int                `db:"total_amount"` // 円単位の整数
-	Status        models.OrderStatus `db:"status"`
+	OrderID        int                 `db:"order_id"`
+	CustomerEmail  sql.NullString      `db:"email"`
+	OrderDate      time.Time           `db:"order_date"`
+	TotalAmount    int                 `db:"total_amount"` // 円単位の整数
+	Status         models.OrderStatus  `db:"status"`
+	Note           sql.NullString      `db:"note"`
+	HasAllergy     bool                `db:"has_allergy"`
+	RefundedAmount int                 `db:"refunded_amount"` // 返金済みの合計額（返金依頼中は含めない）
+	DiningOption   models.DiningOption `db:"dining_option"`
 }
 
 func (r *orderRepository) FindShopOrdersByStatuses(ctx context.Context, dbtx DBTX, shopID int, statuses []models.OrderStatus) ([]AdminOrderDBResult, error) {
@@ -216,7 +428,8 @@ func (r *orderRepository) FindShopOrdersByStatuses(ctx context.Context, dbtx DBT
 	}
 	query, args, err := sqlx.In(`
 		SELECT
-			o.order_id, u.email, o.order_date, o.total_amount, o.status
+			o.order_id, u.email, o.order_date, o.total_amount, o.status, o.note, o.has_allergy, o.dining_option,
+			COALESCE((SELECT SUM(r.amount) FROM refunds r WHERE r.order_id = o.order_id AND r.status = ?), 0) AS refunded_amount
 		FROM
 			orders o
 		LEFT JOIN
@@ -225,7 +438,7 @@ func (r *orderRepository) FindShopOrdersByStatuses(ctx context.Context, dbtx DBT
 			o.shop_id = ? AND o.status IN (?)
 		ORDER BY
 			o.order_date ASC
-	`, shopID, statuses)
+	`, models.RefundSucceeded, shopID, statuses)
 	if err != nil {
 		return nil, apperrors.GetDataFailed.Wrap(err, "データベースクエリの構築に失敗しました。")
 	}
@@ -252,9 +465,48 @@ func (r *orderRepository) FindOrderByIDAndShopID(ctx context.Context, dbtx DBTX,
 	return &order, nil
 }
 
+// FindOrderByIDAndShopIDForUpdate は店舗の注文を行ロックを取って取得します。
+// 削除と決済の取り消しが同時に在庫やポイントを戻さないよう、トランザクション内で使う。
+func (r *orderRepository) FindOrderByIDAndShopIDForUpdate(ctx context.Context, dbtx DBTX, orderID int, shopID int) (*models.Order, error) {
+	var order models.Order
+	query := `SELECT * FROM orders WHERE order_id = $1 AND shop_id = $2 FOR UPDATE`
+	err := dbtx.GetContext(ctx, &order, query, orderID, shopID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NoData.Wrap(err, "注文が見つからないか、この店舗の管轄外です。")
+		}
+		return nil, apperrors.GetDataFailed.Wrap(err, "注文情報の取得に失敗しました。")
+	}
+
+	return &order, nil
+}
+
+func (r *orderRepository) FindOrderByID(ctx context.Context, dbtx DBTX, orderID int) (*models.Order, error) {
+	var order models.Order
+	query := `SELECT * FROM orders WHERE order_id = $1`
+	if err := dbtx.GetContext(ctx, &order, query, orderID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NoData.Wrap(err, "注文が見つかりません。")
+		}
+		return nil, apperrors.GetDataFailed.Wrap(err, "注文情報の取得に失敗しました。")
+	}
+
+	return &order, nil
+}
+
 func (r *orderRepository) UpdateOrderStatus(ctx context.Context, dbtx DBTX, orderID int, shopID int, newStatus models.OrderStatus) error {
-	query := `UPDATE orders SET status = $1, updated_at = NOW() WHERE order_id = $2 AND shop_id = $3`
-	result, err := dbtx.ExecContext(ctx, query, newStatus, orderID, shopID)
+	// 調理完了への遷移時は完了日時（受け取り予定時刻の推定に使う）、お渡し済みへの遷移時はお渡し日時を記録する。
+	// 注文日時と差を取るため、どちらもDBのNOW()で記録する
+	query := `
+		UPDATE orders
+		SET
+			status = $1,
+			completed_at = CASE WHEN $1 = $4 THEN NOW() ELSE completed_at END,
+			handed_at = CASE WHEN $1 = $5 THEN NOW() ELSE handed_at END,
+			updated_at = NOW()
+		WHERE order_id = $2 AND shop_id = $3
+	`
+	result, err := dbtx.ExecContext(ctx, query, newStatus, orderID, shopID, models.Completed, models.Handed)
 	if err != nil {
 		return apperrors.UpdateDataFailed.Wrap(err, "注文ステータスの更新に失敗しました。")
 	}
@@ -286,3 +538,157 @@ func (r *orderRepository) DeleteOrderByIDAndShopID(ctx context.Context, dbtx DBT
 
 	return nil
 }
+
+// 調理中の注文と、その注文の調理にかかる見込み時間
+type KitchenQueueEntry struct {
+	OrderID         int       `db:"order_id"`
+	OrderDate       time.Time `db:"order_date"`
+	WorkloadSeconds int       `db:"workload_seconds"` // 商品ごとの調理時間×数量の合計
+}
+
+// FindCookingQueue は店舗の調理中の注文を調理順（注文日時順）に取得します
+func (r *orderRepository) FindCookingQueue(ctx context.Context, dbtx DBTX, shopID int) ([]KitchenQueueEntry, error) {
+	query := `
+		SELECT
+			o.order_id,
+			o.order_date,
+			COALESCE(SUM(oi.quantity * COALESCE(si.prep_seconds, s.default_prep_seconds)), 0) AS workload_seconds
+		FROM
+			orders o
+		INNER JOIN
+			shops s ON o.shop_id = s.shop_id
+		LEFT JOIN
+			order_item oi ON o.order_id = oi.order_id
+		LEFT JOIN
+			shop_item si ON si.shop_id = o.shop_id AND si.item_id = oi.item_id
+		WHERE
+			o.shop_id = $1 AND o.status = $2
+		GROUP BY
+			o.order_id, o.order_date
+		ORDER BY
+			o.order_date ASC, o.order_id ASC
+	`
+
+	var queue []KitchenQueueEntry
+	if err := dbtx.SelectContext(ctx, &queue, query, shopID, models.Cooking); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "調理待ちの注文の取得に失敗しました。")
+	}
+	return queue, nil
+}
+
+// 調理完了した注文の、見込み時間と実際にかかった時間
+type KitchenDurationSample struct {
+	CompletedAt     time.Time `db:"completed_at"`
+	WorkloadSeconds int       `db:"workload_seconds"`
+	ActualSeconds   float64   `db:"actual_seconds"`
+}
+
+// FindRecentKitchenDurations は店舗でsince以降に調理完了した注文の実績を新しい順に最大limit件取得します。
+// 実際の調理時間は、注文日時と直前の注文の完了日時の遅い方から完了までの時間とします。
+func (r *orderRepository) FindRecentKitchenDurations(ctx context.Context, dbtx DBTX, shopID int, since time.Time, limit int) ([]KitchenDurationSample, error) {
+	query := `
+		SELECT
+			completed_at, workload_seconds, actual_seconds
+		FROM (
+			SELECT
+				o.completed_at,
+				(
+					SELECT COALESCE(SUM(oi.quantity * COALESCE(si.prep_seconds, s.default_prep_seconds)), 0)
+					FROM order_item oi
+					LEFT JOIN shop_item si ON si.shop_id = o.shop_id AND si.item_id = oi.item_id
+					WHERE oi.order_id = o.order_id
+				) AS workload_seconds,
+				EXTRACT(EPOCH FROM (
+					o.completed_at - GREATEST(o.order_date, LAG(o.completed_at) OVER (ORDER BY o.completed_at, o.order_id))
+				))::DOUBLE PRECISION AS actual_seconds
+			FROM
+				orders o
+			INNER JOIN
+				shops s ON o.shop_id = s.shop_id
+			WHERE
+				o.shop_id = $1 AND o.completed_at IS NOT NULL AND o.completed_at >= $2
+		) AS samples
+		ORDER BY
+			completed_at DESC
+		LIMIT $3
+	`
+
+	var samples []KitchenDurationSample
+	if err := dbtx.SelectContext(ctx, &samples, query, shopID, since, limit); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "調理時間の実績の取得に失敗しました。")
+	}
+	return samples, nil
+}
+
+// 領収書の発行に使う注文と店舗の情報
+type ReceiptOrderDB struct {
+	OrderID                   int                 `db:"order_id"`
+	UserID                    sql.NullInt64       `db:"user_id"`
+	GuestOrderToken           sql.NullString      `db:"guest_order_token"`
+	Status                    models.OrderStatus  `db:"status"`
+	OrderDate                 time.Time           `db:"order_date"`
+	DiningOption              models.DiningOption `db:"dining_option"`
+	SubtotalAmount            int                 `db:"subtotal_amount"`
+	TaxAmount                 int                 `db:"tax_amount"`
+	TotalAmount               int                 `db:"total_amount"`
+	DiscountAmount            int                 `db:"discount_amount"`
+	ShopName                  string              `db:"shop_name"`
+	ShopLocation              string              `db:"shop_location"`
+	InvoiceRegistrationNumber sql.NullString      `db:"invoice_registration_number"`
+}
+
+// FindReceiptOrder は領収書に記載する注文と店舗の情報を取得します。
+// 注文したユーザーかどうかの確認は呼び出し側で行います。
+func (r *orderRepository) FindReceiptOrder(ctx context.Context, dbtx DBTX, orderID int) (*ReceiptOrderDB, error) {
+	query := `
+		SELECT
+			o.order_id, o.user_id, o.guest_order_token, o.status, o.order_date, o.dining_option,
+			o.subtotal_amount, o.tax_amount, o.total_amount, o.discount_amount,
+			s.name AS shop_name, COALESCE(s.location, '') AS shop_location, s.invoice_registration_number
+		FROM
+			orders o
+		INNER JOIN
+			shops s ON o.shop_id = s.shop_id
+		WHERE
+			o.order_id = $1
+	`
+	var order ReceiptOrderDB
+	if err := dbtx.GetContext(ctx, &order, query, orderID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NoData.Wrap(err, "注文が見つからないか、アクセス権がありません。")
+		}
+		return nil, apperrors.GetDataFailed.Wrap(err, "注文情報の取得に失敗しました。")
+	}
+	return &order, nil
+}
+
+// 領収書の明細行。UnitPriceは注文時の単価（税込）にオプションの差額を加えたもの
+type ReceiptLineDB struct {
+	ItemName  string `db:"item_name"`
+	Quantity  int    `db:"quantity"`
+	UnitPrice int    `db:"unit_price"`
+	TaxRate   int    `db:"tax_rate"` // 注文時に適用した消費税率（%）。税を計算する前の注文は0
+}
+
+// FindReceiptLines は注文した商品を注文時の単価と税率付きで登録順に取得します
+func (r *orderRepository) FindReceiptLines(ctx context.Context, dbtx DBTX, orderID int) ([]ReceiptLineDB, error) {
+	query := `
+		SELECT
+			i.item_name, oi.quantity,
+			oi.price_at_order + oi.modifier_price_delta AS unit_price,
+			oi.tax_rate
+		FROM
+			order_item oi
+		INNER JOIN
+			items i ON oi.item_id = i.item_id
+		WHERE
+			oi.order_id = $1
+		ORDER BY
+			oi.order_item_id
+	`
+	var lines []ReceiptLineDB
+	if err := dbtx.SelectContext(ctx, &lines, query, orderID); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "注文商品の取得に失敗しました。")
+	}
+	return lines, nil
+}
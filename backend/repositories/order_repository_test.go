@@ -105,10 +105,11 @@ func newTestOrderItem(orderID, itemID, quantity int, priceAtOrder int) models.Or
 
 func newTestOrder(userID, shopID int, totalAmount int, status models.OrderStatus) *models.Order {
 	return &models.Order{
-		UserID:      sql.NullInt64{Int64: int64(userID), Valid: true},
-		ShopID:      shopID,
-		TotalAmount: totalAmount,
-		Status:      status,
+		UserID:       sql.NullInt64{Int64: int64(userID), Valid: true},
+		ShopID:       shopID,
+		TotalAmount:  totalAmount,
+		Status:       status,
+		DiningOption: models.Takeout,
 	}
 }
 
@@ -451,13 +452,13 @@ func TestOrderRepository_FindActiveUserOrders(t *testing.T) {
 			userID: testUserID1,
 			assertion: func(t *testing.T, got []repositories.OrderWithDetailsDB) {
 				expected := []repositories.OrderWithDetailsDB{
-					{OrderID: 3, ShopName: "Shop A", Location: "Location A", Status: models.Completed, WaitingCount: 0},
-					{OrderID: 2, ShopName: "Shop A", Location: "Location A", Status: models.Cooking, WaitingCount: 2},
-					{OrderID: 1, ShopName: "Shop A", Location: "Location A", Status: models.Cooking, WaitingCount: 1},
-					{OrderID: 6, ShopName: "Shop B", Location: "Location B", Status: models.Cooking, WaitingCount: 0},
+					{OrderID: 3, ShopID: testShopID1, ShopName: "Shop A", Location: "Location A", Status: models.Completed, WaitingCount: 0},
+					{OrderID: 2, ShopID: testShopID1, ShopName: "Shop A", Location: "Location A", Status: models.Cooking, WaitingCount: 2},
+					{OrderID: 1, ShopID: testShopID1, ShopName: "Shop A", Location: "Location A", Status: models.Cooking, WaitingCount: 1},
+					{OrderID: 6, ShopID: testShopID2, ShopName: "Shop B", Location: "Location B", Status: models.Cooking, WaitingCount: 0},
 				}
 				opts := []cmp.Option{
-					cmpopts.IgnoreFields(repositories.OrderWithDetailsDB{}, "OrderDate", "TotalAmount"),
+					cmpopts.IgnoreFields(repositories.OrderWithDetailsDB{}, "OrderDate", "DiningOption", "SubtotalAmount", "TaxAmount", "TotalAmount"),
 					cmpopts.EquateEmpty(),
 				}
 				if diff := cmp.Diff(expected, got, opts...); diff != "" {
@@ -588,15 +589,18 @@ func TestOrderRepository_FindItemsByOrderIDs(t *testing.T) {
 			assertion: func(t *testing.T, got map[int][]models.ItemDetail) {
 				expected := map[int][]models.ItemDetail{
 					1: {
-						{ItemName: "Item A", Quantity: testQuantity1},
-						{ItemName: "Item B", Quantity: testQuantity2},
+						{ItemID: testItemID1, ItemName: "Item A", Quantity: testQuantity1},
+						{ItemID: testItemID2, ItemName: "Item B", Quantity: testQuantity2},
 					},
 					2: {
-						{ItemName: "Item A", Quantity: testQuantity3},
+						{ItemID: testItemID1, ItemName: "Item A", Quantity: testQuantity3},
 					},
 				}
-				opts := cmpopts.SortSlices(func(a, b models.ItemDetail) bool { return a.ItemName < b.ItemName })
-				if diff := cmp.Diff(expected, got, opts); diff != "" {
+				opts := []cmp.Option{
+					cmpopts.SortSlices(func(a, b models.ItemDetail) bool { return a.ItemName < b.ItemName }),
+					cmpopts.IgnoreFields(models.ItemDetail{}, "OrderItemID"),
+				}
+				if diff := cmp.Diff(expected, got, opts...); diff != "" {
 					t.Errorf("FindItemsByOrderIDs の結果が一致しません (-want +got):\n%s", diff)
 				}
 			},
@@ -616,15 +620,18 @@ func TestOrderRepository_FindItemsByOrderIDs(t *testing.T) {
 			assertion: func(t *testing.T, got map[int][]models.ItemDetail) {
 				expected := map[int][]models.ItemDetail{
 					1: {
-						{ItemName: "Item A", Quantity: testQuantity1},
-						{ItemName: "Item B", Quantity: testQuantity2},
+						{ItemID: testItemID1, ItemName: "Item A", Quantity: testQuantity1},
+						{ItemID: testItemID2, ItemName: "Item B", Quantity: testQuantity2},
 					},
 				}
 				if _, ok := got[3]; ok {
 					t.Error("アイテムがない注文ID 3 は結果に含まれるべきではありません")
 				}
-				opts := cmpopts.SortSlices(func(a, b models.ItemDetail) bool { return a.ItemName < b.ItemName })
-				if diff := cmp.Diff(expected, got, opts); diff != "" {
+				opts := []cmp.Option{
+					cmpopts.SortSlices(func(a, b models.ItemDetail) bool { return a.ItemName < b.ItemName }),
+					cmpopts.IgnoreFields(models.ItemDetail{}, "OrderItemID"),
+				}
+				if diff := cmp.Diff(expected, got, opts...); diff != "" {
 					t.Errorf("FindItemsByOrderIDs の結果が一致しません (-want +got):\n%s", diff)
 				}
 			},
@@ -1022,6 +1029,46 @@ func TestFindOrderByIDAndShopID(t *testing.T) {
 	}
 }
 
+// TestFindOrderByIDAndShopIDForUpdate - 注文IDと店舗IDで注文をロックして取得するテスト
+func TestFindOrderByIDAndShopIDForUpdate(t *testing.T) {
+	db := NewTestDB(t)
+
+	tests := []struct {
+		name            string
+		orderID         int
+		shopID          int
+		wantStatus      models.OrderStatus
+		expectedErrCode apperrors.ErrCode
+	}{
+		{name: "正常に注文を取得できる", orderID: testOrderID1, shopID: testShopID1, wantStatus: models.Cooking},
+		{name: "異なる店舗IDでは注文が取得できない", orderID: testOrderID1, shopID: testShopID2, expectedErrCode: apperrors.NoData},
+		{name: "存在しない注文IDでは注文が取得できない", orderID: nonExistentOrderID, shopID: testShopID1, expectedErrCode: apperrors.NoData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := db.MustBegin()
+			defer tx.Rollback()
+
+			createTestUser(t, tx, testUserID1, fmt.Sprintf("user%d@test.com", testUserID1))
+			createTestShop(t, tx, testShopID1, fmt.Sprintf("Test Shop %d", testShopID1))
+			createTestOrder(t, tx, testOrderID1, testUserID1, testShopID1, models.Cooking)
+
+			repo := repositories.NewOrderRepository()
+			got, err := repo.FindOrderByIDAndShopIDForUpdate(context.Background(), tx, tt.orderID, tt.shopID)
+
+			if tt.expectedErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+				return
+			}
+			testhelpers.AssertNoError(t, err)
+			if got.OrderID != tt.orderID || got.ShopID != tt.shopID || got.Status != tt.wantStatus {
+				t.Errorf("got order %+v, want order_id=%d shop_id=%d status=%v", got, tt.orderID, tt.shopID, tt.wantStatus)
+			}
+		})
+	}
+}
+
 // TestUpdateOrderStatus - 注文ステータス更新テスト
 func TestUpdateOrderStatus(t *testing.T) {
 	db := NewTestDB(t)
@@ -1165,3 +1212,383 @@ func TestDeleteOrderByIDAndShopID(t *testing.T) {
 		})
 	}
 }
+
+// TestFindCookingQueue - 調理待ちキュー取得テスト
+func TestFindCookingQueue(t *testing.T) {
+	db := NewTestDB(t)
+
+	baseTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		shopID int
+		setup  func(*sqlx.Tx)
+		want   []repositories.KitchenQueueEntry
+	}{
+		{
+			name:   "調理中の注文を古い順に、商品の調理時間の合計とともに取得できる",
+			shopID: testShopID1,
+			setup: func(tx *sqlx.Tx) {
+				createTestUser(t, tx, testUserID1, fmt.Sprintf("user%d@test.com", testUserID1))
+				createTestShop(t, tx, testShopID1, fmt.Sprintf("Test Shop %d", testShopID1))
+				createTestShop(t, tx, testShopID2, fmt.Sprintf("Test Shop %d", testShopID2))
+				if _, err := tx.Exec(`UPDATE shops SET default_prep_seconds = 120 WHERE shop_id = $1`, testShopID1); err != nil {
+					t.Fatalf("既定調理時間の設定に失敗しました: %v", err)
+				}
+				if _, err := tx.Exec(`INSERT INTO items (item_id, item_name, price) VALUES ($1, 'Item A', 100), ($2, 'Item B', 200)`, testItemID1, testItemID2); err != nil {
+					t.Fatalf("テスト用商品の作成に失敗しました: %v", err)
+				}
+				// Item Aは店舗1でだけ調理時間を設定し、店舗2では既定値を使う
+				if _, err := tx.Exec(`INSERT INTO shop_item (shop_id, item_id, prep_seconds) VALUES ($1, $3, 300), ($1, $4, NULL), ($2, $3, NULL)`, testShopID1, testShopID2, testItemID1, testItemID2); err != nil {
+					t.Fatalf("テスト用店舗商品の作成に失敗しました: %v", err)
+				}
+
+				createTestOrderWithTime(t, tx, testOrderID1, testUserID1, testShopID1, models.Cooking, baseTime.Add(5*time.Minute))
+				createTestOrderWithTime(t, tx, testOrderID2, testUserID1, testShopID1, models.Cooking, baseTime)
+				createTestOrderWithTime(t, tx, testOrderID3, testUserID1, testShopID1, models.Completed, baseTime)
+				createTestOrderWithTime(t, tx, testOrderID4, testUserID1, testShopID2, models.Cooking, baseTime)
+
+				_, err := tx.Exec(`
+					INSERT INTO order_item (order_id, item_id, quantity, price_at_order)
+					VALUES ($1, $2, 2, 100), ($1, $3, 1, 200), ($4, $2, 1, 100), ($5, $2, 1, 100)`,
+					testOrderID1, testItemID1, testItemID2, testOrderID2, testOrderID3)
+				if err != nil {
+					t.Fatalf("テスト用注文商品の作成に失敗しました: %v", err)
+				}
+			},
+			want: []repositories.KitchenQueueEntry{
+				{OrderID: testOrderID2, OrderDate: baseTime, WorkloadSeconds: 300},
+				{OrderID: testOrderID1, OrderDate: baseTime.Add(5 * time.Minute), WorkloadSeconds: 2*300 + 120},
+			},
+		},
+		{
+			name:   "調理中の注文がない場合は空を返す",
+			shopID: testShopID1,
+			setup:  func(tx *sqlx.Tx) {},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := db.MustBegin()
+			defer tx.Rollback()
+
+			tt.setup(tx)
+
+			repo := repositories.NewOrderRepository()
+			got, err := repo.FindCookingQueue(context.Background(), tx, tt.shopID)
+
+			testhelpers.AssertNoError(t, err)
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("FindCookingQueue() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestOrderRepository_CreateOrderWithModifiers - オプション付き注文の保存と取得のテスト
+func TestOrderRepository_CreateOrderWithModifiers(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("トランザクションのロールバックに失敗しました: %v", err)
+		}
+	}()
+
+	createTestUser(t, tx, testUserID1, fmt.Sprintf("user%d@test.com", testUserID1))
+	createTestShop(t, tx, testShopID1, fmt.Sprintf("Test Shop %d", testShopID1))
+	for _, item := range newTestItems() {
+		if _, err := tx.NamedExec(`INSERT INTO items (item_id, item_name, price) VALUES (:item_id, :item_name, :price)`, item); err != nil {
+			t.Fatalf("アイテムの挿入に失敗しました: %v", err)
+		}
+	}
+
+	order := newTestOrder(testUserID1, testShopID1, testAmount1, models.Cooking)
+	withModifiers := newTestOrderItem(0, testItemID1, testQuantity2, testPrice1)
+	withModifiers.ModifierPriceDelta = 80
+	withModifiers.Modifiers = []models.OrderItemModifier{
+		{GroupName: "サイズ", OptionName: "大盛り", PriceDelta: 100},
+		{GroupName: "トッピング", OptionName: "ねぎ抜き", PriceDelta: -20},
+	}
+	items := []models.OrderItem{
+		withModifiers,
+		newTestOrderItem(0, testItemID1, testQuantity1, testPrice1),
+	}
+
+	repo := repositories.NewOrderRepository()
+	err := repo.CreateOrder(ctx, tx, order, items)
+	testhelpers.AssertNoError(t, err)
+
+	var storedDelta int
+	if err := tx.Get(&storedDelta, "SELECT modifier_price_delta FROM order_item WHERE order_item_id = $1", items[0].OrderItemID); err != nil {
+		t.Fatalf("注文アイテムの取得に失敗しました: %v", err)
+	}
+	if storedDelta != 80 {
+		t.Errorf("modifier_price_delta = %d, want 80", storedDelta)
+	}
+
+	got, err := repo.FindItemsByOrderIDs(ctx, tx, []int{order.OrderID})
+	testhelpers.AssertNoError(t, err)
+
+	expected := map[int][]models.ItemDetail{
+		order.OrderID: {
+			{
+				OrderItemID: items[0].OrderItemID,
+				ItemID:      testItemID1,
+				ItemName:    "Item A",
+				Quantity:    testQuantity2,
+				Modifiers: []models.ItemModifierDetail{
+					{GroupName: "サイズ", OptionName: "大盛り", PriceDelta: 100},
+					{GroupName: "トッピング", OptionName: "ねぎ抜き", PriceDelta: -20},
+				},
+			},
+			{OrderItemID: items[1].OrderItemID, ItemID: testItemID1, ItemName: "Item A", Quantity: testQuantity1},
+		},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("FindItemsByOrderIDs の結果が一致しません (-want +got):\n%s", diff)
+	}
+}
+
+func TestOrderRepository_CreateOrderWithComponents(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("トランザクションのロールバックに失敗しました: %v", err)
+		}
+	}()
+
+	createTestUser(t, tx, testUserID1, fmt.Sprintf("user%d@test.com", testUserID1))
+	createTestShop(t, tx, testShopID1, fmt.Sprintf("Test Shop %d", testShopID1))
+	for _, item := range newTestItems() {
+		if _, err := tx.NamedExec(`INSERT INTO items (item_id, item_name, price) VALUES (:item_id, :item_name, :price)`, item); err != nil {
+			t.Fatalf("アイテムの挿入に失敗しました: %v", err)
+		}
+	}
+
+	// Item A をセットとして注文し、構成商品の Item B を2個付ける
+	order := newTestOrder(testUserID1, testShopID1, testAmount1, models.Cooking)
+	bundle := newTestOrderItem(0, testItemID1, testQuantity1, testPrice1)
+	bundle.ModifierPriceDelta = 100
+	bundle.Components = []models.OrderItemComponent{
+		{ItemID: testItemID2, SlotName: "サイド", ItemName: "Item B", Quantity: 2, PriceDelta: 100},
+	}
+	items := []models.OrderItem{bundle}
+
+	repo := repositories.NewOrderRepository()
+	err := repo.CreateOrder(ctx, tx, order, items)
+	testhelpers.AssertNoError(t, err)
+
+	if items[0].Components[0].OrderItemComponentID == 0 {
+		t.Error("構成商品のIDが設定されていません")
+	}
+
+	got, err := repo.FindItemsByOrderIDs(ctx, tx, []int{order.OrderID})
+	testhelpers.AssertNoError(t, err)
+
+	expected := map[int][]models.ItemDetail{
+		order.OrderID: {
+			{
+				OrderItemID: items[0].OrderItemID,
+				ItemID:      testItemID1,
+				ItemName:    "Item A",
+				Quantity:    testQuantity1,
+				Components: []models.ItemComponentDetail{
+					{SlotName: "サイド", ItemName: "Item B", Quantity: 2, PriceDelta: 100},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("FindItemsByOrderIDs の結果が一致しません (-want +got):\n%s", diff)
+	}
+}
+
+func TestOrderRepository_CreateOrderWithNotes(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("トランザクションのロールバックに失敗しました: %v", err)
+		}
+	}()
+
+	createTestUser(t, tx, testUserID1, fmt.Sprintf("user%d@test.com", testUserID1))
+	createTestShop(t, tx, testShopID1, fmt.Sprintf("Test Shop %d", testShopID1))
+	for _, item := range newTestItems() {
+		if _, err := tx.NamedExec(`INSERT INTO items (item_id, item_name, price) VALUES (:item_id, :item_name, :price)`, item); err != nil {
+			t.Fatalf("アイテムの挿入に失敗しました: %v", err)
+		}
+	}
+
+	order := newTestOrder(testUserID1, testShopID1, testAmount1, models.Cooking)
+	order.Note = sql.NullString{String: "えびアレルギーです", Valid: true}
+	order.HasAllergy = true
+	withNote := newTestOrderItem(0, testItemID1, testQuantity1, testPrice1)
+	withNote.Note = sql.NullString{String: "ネギ抜き", Valid: true}
+	items := []models.OrderItem{
+		withNote,
+		newTestOrderItem(0, testItemID1, testQuantity1, testPrice1),
+	}
+
+	repo := repositories.NewOrderRepository()
+	err := repo.CreateOrder(ctx, tx, order, items)
+	testhelpers.AssertNoError(t, err)
+
+	orders, err := repo.FindShopOrdersByStatuses(ctx, tx, testShopID1, []models.OrderStatus{models.Cooking})
+	testhelpers.AssertNoError(t, err)
+	if len(orders) != 1 {
+		t.Fatalf("注文数 = %d, want 1", len(orders))
+	}
+	if orders[0].Note != order.Note || !orders[0].HasAllergy {
+		t.Errorf("注文のメモ = %+v, has_allergy = %v, want %+v, true", orders[0].Note, orders[0].HasAllergy, order.Note)
+	}
+
+	got, err := repo.FindItemsByOrderIDs(ctx, tx, []int{order.OrderID})
+	testhelpers.AssertNoError(t, err)
+
+	note := "ネギ抜き"
+	expected := map[int][]models.ItemDetail{
+		order.OrderID: {
+			{OrderItemID: items[0].OrderItemID, ItemID: testItemID1, ItemName: "Item A", Quantity: testQuantity1, Note: &note},
+			{OrderItemID: items[1].OrderItemID, ItemID: testItemID1, ItemName: "Item A", Quantity: testQuantity1},
+		},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("FindItemsByOrderIDs の結果が一致しません (-want +got):\n%s", diff)
+	}
+}
+
+func TestOrderRepository_CreateOrderWithTaxLines(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("トランザクションのロールバックに失敗しました: %v", err)
+		}
+	}()
+
+	createTestUser(t, tx, testUserID1, fmt.Sprintf("user%d@test.com", testUserID1))
+	createTestShop(t, tx, testShopID1, fmt.Sprintf("Test Shop %d", testShopID1))
+	for _, item := range newTestItems() {
+		if _, err := tx.NamedExec(`INSERT INTO items (item_id, item_name, price) VALUES (:item_id, :item_name, :price)`, item); err != nil {
+			t.Fatalf("アイテムの挿入に失敗しました: %v", err)
+		}
+	}
+
+	order := newTestOrder(testUserID1, testShopID1, 1100, models.Cooking)
+	order.DiningOption = models.DineIn
+	order.SubtotalAmount = 1000
+	order.TaxAmount = 100
+	order.TaxLines = []models.OrderTaxLine{
+		{TaxRate: 10, TaxableAmount: 1000, TaxAmount: 100},
+	}
+	item := newTestOrderItem(0, testItemID1, 1, 1000)
+	item.TaxRate = 10
+
+	repo := repositories.NewOrderRepository()
+	err := repo.CreateOrder(ctx, tx, order, []models.OrderItem{item})
+	testhelpers.AssertNoError(t, err)
+
+	orders, err := repo.FindShopOrdersByStatuses(ctx, tx, testShopID1, []models.OrderStatus{models.Cooking})
+	testhelpers.AssertNoError(t, err)
+	if len(orders) != 1 {
+		t.Fatalf("注文数 = %d, want 1", len(orders))
+	}
+	if orders[0].DiningOption != models.DineIn {
+		t.Errorf("飲食区分 = %v, want %v", orders[0].DiningOption, models.DineIn)
+	}
+
+	got, err := repo.FindTaxLinesByOrderIDs(ctx, tx, []int{order.OrderID})
+	testhelpers.AssertNoError(t, err)
+
+	expected := map[int][]models.OrderTaxLine{
+		order.OrderID: {
+			{OrderID: order.OrderID, TaxRate: 10, TaxableAmount: 1000, TaxAmount: 100},
+		},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("FindTaxLinesByOrderIDs の結果が一致しません (-want +got):\n%s", diff)
+	}
+}
+
+func TestOrderRepository_FindReceipt(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("トランザクションのロールバックに失敗しました: %v", err)
+		}
+	}()
+
+	createTestUser(t, tx, testUserID1, fmt.Sprintf("user%d@test.com", testUserID1))
+	createTestShop(t, tx, testShopID1, "Shop A")
+	if _, err := tx.Exec(`UPDATE shops SET invoice_registration_number = 'T1234567890123' WHERE shop_id = $1`, testShopID1); err != nil {
+		t.Fatalf("登録番号の設定に失敗しました: %v", err)
+	}
+	for _, item := range newTestItems() {
+		if _, err := tx.NamedExec(`INSERT INTO items (item_id, item_name, price) VALUES (:item_id, :item_name, :price)`, item); err != nil {
+			t.Fatalf("アイテムの挿入に失敗しました: %v", err)
+		}
+	}
+
+	order := newTestOrder(testUserID1, testShopID1, 1188, models.Handed)
+	order.GuestOrderToken = sql.NullString{String: "guest-token", Valid: true}
+	order.SubtotalAmount = 1100
+	order.TaxAmount = 88
+	withModifier := newTestOrderItem(0, testItemID1, 2, 500)
+	withModifier.ModifierPriceDelta = 50
+	withModifier.TaxRate = 8
+	items := []models.OrderItem{withModifier}
+
+	repo := repositories.NewOrderRepository()
+	testhelpers.AssertNoError(t, repo.CreateOrder(ctx, tx, order, items))
+
+	got, err := repo.FindReceiptOrder(ctx, tx, order.OrderID)
+	testhelpers.AssertNoError(t, err)
+	want := &repositories.ReceiptOrderDB{
+		OrderID:                   order.OrderID,
+		UserID:                    order.UserID,
+		GuestOrderToken:           order.GuestOrderToken,
+		Status:                    models.Handed,
+		DiningOption:              models.Takeout,
+		SubtotalAmount:            1100,
+		TaxAmount:                 88,
+		TotalAmount:               1188,
+		ShopName:                  "Shop A",
+		InvoiceRegistrationNumber: sql.NullString{String: "T1234567890123", Valid: true},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(repositories.ReceiptOrderDB{}, "OrderDate")); diff != "" {
+		t.Errorf("FindReceiptOrder の結果が一致しません (-want +got):\n%s", diff)
+	}
+
+	lines, err := repo.FindReceiptLines(ctx, tx, order.OrderID)
+	testhelpers.AssertNoError(t, err)
+	wantLines := []repositories.ReceiptLineDB{
+		{ItemName: "Item A", Quantity: 2, UnitPrice: 550, TaxRate: 8},
+	}
+	if diff := cmp.Diff(wantLines, lines); diff != "" {
+		t.Errorf("FindReceiptLines の結果が一致しません (-want +got):\n%s", diff)
+	}
+
+	_, err = repo.FindReceiptOrder(ctx, tx, 99999)
+	testhelpers.AssertAppError(t, err, apperrors.NoData)
+}
@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+)
+
+type PaymentRepository interface {
+	CreatePayment(ctx context.Context, dbtx DBTX, payment *models.Payment) error
+	FindPaymentByProviderPaymentIDForUpdate(ctx context.Context, dbtx DBTX, provider string, providerPaymentID string) (*models.Payment, error)
+	FindPaymentByIDForUpdate(ctx context.Context, dbtx DBTX, paymentID int) (*models.Payment, error)
+	FindLatestPaymentByOrderIDForUpdate(ctx context.Context, dbtx DBTX, orderID int) (*models.Payment, error)
+	UpdatePaymentResult(ctx context.Context, dbtx DBTX, payment *models.Payment) error
+	MarkPaymentCapturing(ctx context.Context, dbtx DBTX, payment *models.Payment) (bool, error)
+	FindStalePendingPaymentOrderIDs(ctx context.Context, dbtx DBTX, orderedBefore time.Time) ([]int, error)
+	FindCapturedPaymentByOrderIDForUpdate(ctx context.Context, dbtx DBTX, orderID int) (*models.Payment, error)
+	AddRefundedAmount(ctx context.Context, dbtx DBTX, paymentID int, amount int) error
+}
+
+type paymentRepository struct{}
+
+func NewPaymentRepository() PaymentRepository {
+	return &paymentRepository{}
+}
+
+func (r *paymentRepository) CreatePayment(ctx context.Context, dbtx DBTX, payment *models.Payment) error {
+	query := `
+		INSERT INTO payments (order_id, provider, provider_payment_id, amount, status, failure_reason)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING payment_id, created_at, updated_at
+	`
+	err := dbtx.QueryRowxContext(
+		ctx,
+		query,
+		payment.OrderID,
+		payment.Provider,
+		payment.ProviderPaymentID,
+		payment.Amount,
+		payment.Status,
+		payment.FailureReason,
+	).Scan(&payment.PaymentID, &payment.CreatedAt, &payment.UpdatedAt)
+	if err != nil {
+		return apperrors.InsertDataFailed.Wrap(err, "決済情報の登録に失敗しました。")
+	}
+	return nil
+}
+
+// FindPaymentByProviderPaymentIDForUpdate は決済代行会社側のIDから決済を取得し、行ロックを取ります。
+// Webhookの重複配信や同時配信で同じ決済を二重に処理しないよう、トランザクション内で使ってください。
+func (r *paymentRepository) FindPaymentByProviderPaymentIDForUpdate(ctx context.Context, dbtx DBTX, provider string, providerPaymentID string) (*models.Payment, error) {
+	var payment models.Payment
+	query := `SELECT * FROM payments WHERE provider = $1 AND provider_payment_id = $2 FOR UPDATE`
+	if err := dbtx.GetContext(ctx, &payment, query, provider, providerPaymentID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NoData.Wrap(err, "対象の決済が見つかりません。")
+		}
+		return nil, apperrors.GetDataFailed.Wrap(err, "決済情報の取得に失敗しました。")
+	}
+	return &payment, nil
+}
+
+// FindPaymentByIDForUpdate は決済を取得し、行ロックを取ります
+func (r *paymentRepository) FindPaymentByIDForUpdate(ctx context.Context, dbtx DBTX, paymentID int) (*models.Payment, error) {
+	var payment models.Payment
+	query := `SELECT * FROM payments WHERE payment_id = $1 FOR UPDATE`
+	if err := dbtx.GetContext(ctx, &payment, query, paymentID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NoData.Wrap(err, "対象の決済が見つかりません。")
+		}
+		return nil, apperrors.GetDataFailed.Wrap(err, "決済情報の取得に失敗しました。")
+	}
+	return &payment, nil
+}
+
+// FindLatestPaymentByOrderIDForUpdate は注文の最新の決済を取得し、行ロックを取ります。決済がない場合はNoDataを返します
+func (r *paymentRepository) FindLatestPaymentByOrderIDForUpdate(ctx context.Context, dbtx DBTX, orderID int) (*models.Payment, error) {
+	var payment models.Payment
+	query := `SELECT * FROM payments WHERE order_id = $1 ORDER BY payment_id DESC LIMIT 1 FOR UPDATE`
+	if err := dbtx.GetContext(ctx, &payment, query, orderID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NoData.Wrap(err, "注文の決済が見つかりません。")
+		}
+		return nil, apperrors.GetDataFailed.Wrap(err, "決済情報の取得に失敗しました。")
+	}
+	return &payment, nil
+}
+
+// UpdatePaymentResult は決済代行会社から返ってきた結果（ID・状態・失敗理由）を保存します
+func (r *paymentRepository) UpdatePaymentResult(ctx context.Context, dbtx DBTX, payment *models.Payment) error {
+	query := `
+		UPDATE payments
+		SET provider_payment_id = $1, status = $2, failure_reason = $3
+		WHERE payment_id = $4
+	`
+	result, err := dbtx.ExecContext(ctx, query, payment.ProviderPaymentID, payment.Status, payment.FailureReason, payment.PaymentID)
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "決済情報の更新に失敗しました。")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "更新結果の確認に失敗しました。")
+	}
+	if rowsAffected == 0 {
+		return apperrors.NoData.Wrap(nil, "更新対象の決済が見つかりません。")
+	}
+	return nil
+}
+
+// MarkPaymentCapturing は承認待ちか与信済みの決済を売上確定中にし、決済代行会社側のIDを保存します。
+// 既に他の処理が売上確定中にしたか結果が確定している場合は何もせずにfalseを返します。
+// 決済代行会社に売上確定を依頼する前に呼び、同じ決済を二重に確定しないようにしてください。
+func (r *paymentRepository) MarkPaymentCapturing(ctx context.Context, dbtx DBTX, payment *models.Payment) (bool, error) {
+	query := `
+		UPDATE payments
+		SET provider_payment_id = $1, status = $2, failure_reason = NULL
+		WHERE payment_id = $3 AND status IN ($4, $5)
+	`
+	result, err := dbtx.ExecContext(ctx, query, payment.ProviderPaymentID, models.PaymentCapturing, payment.PaymentID, models.PaymentPending, models.PaymentAuthorized)
+	if err != nil {
+		return false, apperrors.UpdateDataFailed.Wrap(err, "決済情報の更新に失敗しました。")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.UpdateDataFailed.Wrap(err, "更新結果の確認に失敗しました。")
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+	payment.Status = models.PaymentCapturing
+	payment.FailureReason = sql.NullString{}
+	return true, nil
+}
+
+// FindStalePendingPaymentOrderIDs は orderedBefore より前に注文されたまま、決済待ちになっている注文のIDを古い順に取得します
+func (r *paymentRepository) FindStalePendingPaymentOrderIDs(ctx context.Context, dbtx DBTX, orderedBefore time.Time) ([]int, error) {
+	query := `SELECT order_id FROM orders WHERE status = $1 AND order_date < $2 ORDER BY order_date, order_id`
+	var orderIDs []int
+	if err := dbtx.SelectContext(ctx, &orderIDs, query, models.PendingPayment, orderedBefore.UTC()); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "決済待ちの注文の取得に失敗しました。")
+	}
+	return orderIDs, nil
+}
+
+// FindCapturedPaymentByOrderIDForUpdate は注文の売上確定済みの決済を取得し、行ロックを取ります。
+// 同じ注文への返金が同時に行われても支払額を超えないよう、トランザクション内で使ってください。
+func (r *paymentRepository) FindCapturedPaymentByOrderIDForUpdate(ctx context.Context, dbtx DBTX, orderID int) (*models.Payment, error) {
+	var payment models.Payment
+	query := `SELECT * FROM payments WHERE order_id = $1 AND status = $2 ORDER BY payment_id DESC LIMIT 1 FOR UPDATE`
+	if err := dbtx.GetContext(ctx, &payment, query, orderID, models.PaymentCaptured); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NoData.Wrap(err, "売上が確定した決済が見つかりません。")
+		}
+		return nil, apperrors.GetDataFailed.Wrap(err, "決済情報の取得に失敗しました。")
+	}
+	return &payment, nil
+}
+
+// AddRefundedAmount は決済の返金済み合計額を増やします。支払額を超える場合はConflictを返します
+func (r *paymentRepository) AddRefundedAmount(ctx context.Context, dbtx DBTX, paymentID int, amount int) error {
+	query := `
+		UPDATE payments
+		SET refunded_amount = refunded_amount + $1
+		WHERE payment_id = $2 AND refunded_amount + $1 <= amount
+	`
+	result, err := dbtx.ExecContext(ctx, query, amount, paymentID)
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "返金済み金額の更新に失敗しました。")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "更新結果の確認に失敗しました。")
+	}
+	if rowsAffected == 0 {
+		return apperrors.Conflict.WrapMessage(nil, apperrors.MsgRefundExceedsPayment, nil)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestPaymentRepository_CreateFindAndUpdate(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("トランザクションのロールバックに失敗しました: %v", err)
+		}
+	}()
+
+	createTestUser(t, tx, testUserID1, fmt.Sprintf("user%d@test.com", testUserID1))
+	createTestShop(t, tx, testShopID1, fmt.Sprintf("Test Shop %d", testShopID1))
+	createTestOrder(t, tx, testOrderID1, testUserID1, testShopID1, models.PendingPayment)
+
+	repo := repositories.NewPaymentRepository()
+	payment := &models.Payment{
+		OrderID:  sql.NullInt64{Int64: testOrderID1, Valid: true},
+		Provider: "mock",
+		Amount:   testTotalAmount1,
+		Status:   models.PaymentPending,
+	}
+	testhelpers.AssertNoError(t, repo.CreatePayment(ctx, tx, payment))
+	if payment.PaymentID <= 0 {
+		t.Fatalf("PaymentID が採番されていません: %d", payment.PaymentID)
+	}
+
+	// 決済代行会社のIDが付くまでは検索できない
+	_, err := repo.FindPaymentByProviderPaymentIDForUpdate(ctx, tx, "mock", "mock_pay_1")
+	testhelpers.AssertAppError(t, err, apperrors.NoData)
+
+	payment.ProviderPaymentID = sql.NullString{String: "mock_pay_1", Valid: true}
+	payment.Status = models.PaymentFailed
+	payment.FailureReason = sql.NullString{String: "card_declined", Valid: true}
+	testhelpers.AssertNoError(t, repo.UpdatePaymentResult(ctx, tx, payment))
+
+	got, err := repo.FindPaymentByProviderPaymentIDForUpdate(ctx, tx, "mock", "mock_pay_1")
+	testhelpers.AssertNoError(t, err)
+	if diff := cmp.Diff(*payment, *got, cmpopts.IgnoreFields(models.Payment{}, "CreatedAt", "UpdatedAt")); diff != "" {
+		t.Errorf("取得した決済が一致しません (-want +got):\n%s", diff)
+	}
+
+	// 別の決済代行会社の同じIDとは区別する
+	_, err = repo.FindPaymentByProviderPaymentIDForUpdate(ctx, tx, "other", "mock_pay_1")
+	testhelpers.AssertAppError(t, err, apperrors.NoData)
+
+	// 注文を削除しても決済の記録は残る
+	if _, err := tx.Exec("DELETE FROM orders WHERE order_id = $1", testOrderID1); err != nil {
+		t.Fatalf("注文の削除に失敗しました: %v", err)
+	}
+	got, err = repo.FindPaymentByProviderPaymentIDForUpdate(ctx, tx, "mock", "mock_pay_1")
+	testhelpers.AssertNoError(t, err)
+	if got.OrderID.Valid {
+		t.Errorf("注文削除後の OrderID = %v, want NULL", got.OrderID)
+	}
+
+	missing := &models.Payment{PaymentID: 99999, Status: models.PaymentCaptured}
+	testhelpers.AssertAppError(t, repo.UpdatePaymentResult(ctx, tx, missing), apperrors.NoData)
+}
+
+func TestPaymentRepository_MarkPaymentCapturingAndFindStale(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("トランザクションのロールバックに失敗しました: %v", err)
+		}
+	}()
+
+	now := time.Now().UTC()
+	createTestUser(t, tx, testUserID1, fmt.Sprintf("user%d@test.com", testUserID1))
+	createTestShop(t, tx, testShopID1, fmt.Sprintf("Test Shop %d", testShopID1))
+	createTestOrderWithTime(t, tx, testOrderID1, testUserID1, testShopID1, models.PendingPayment, now.Add(-time.Hour))
+	createTestOrderWithTime(t, tx, testOrderID2, testUserID1, testShopID1, models.PendingPayment, now)
+
+	repo := repositories.NewPaymentRepository()
+	payment := &models.Payment{
+		OrderID:  sql.NullInt64{Int64: testOrderID1, Valid: true},
+		Provider: "mock",
+		Amount:   testTotalAmount1,
+		Status:   models.PaymentPending,
+	}
+	testhelpers.AssertNoError(t, repo.CreatePayment(ctx, tx, payment))
+
+	payment.ProviderPaymentID = sql.NullString{String: "mock_pay_1", Valid: true}
+	claimed, err := repo.MarkPaymentCapturing(ctx, tx, payment)
+	testhelpers.AssertNoError(t, err)
+	if !claimed || payment.Status != models.PaymentCapturing {
+		t.Fatalf("claimed = %v, Status = %v, want true, %v", claimed, payment.Status, models.PaymentCapturing)
+	}
+
+	// 既に売上確定中の決済は二重に確定しない
+	claimed, err = repo.MarkPaymentCapturing(ctx, tx, payment)
+	testhelpers.AssertNoError(t, err)
+	if claimed {
+		t.Error("売上確定中の決済をもう一度売上確定中にできてしまいました")
+	}
+
+	got, err := repo.FindLatestPaymentByOrderIDForUpdate(ctx, tx, testOrderID1)
+	testhelpers.AssertNoError(t, err)
+	if got.PaymentID != payment.PaymentID || got.Status != models.PaymentCapturing || got.ProviderPaymentID.String != "mock_pay_1" {
+		t.Errorf("想定外の決済: %+v", got)
+	}
+	_, err = repo.FindLatestPaymentByOrderIDForUpdate(ctx, tx, testOrderID2)
+	testhelpers.AssertAppError(t, err, apperrors.NoData)
+
+	// 指定した時刻より前に注文された決済待ちの注文だけを返す
+	orderIDs, err := repo.FindStalePendingPaymentOrderIDs(ctx, tx, now.Add(-time.Minute))
+	testhelpers.AssertNoError(t, err)
+	if diff := cmp.Diff([]int{testOrderID1}, orderIDs); diff != "" {
+		t.Errorf("決済待ちの注文が一致しません (-want +got):\n%s", diff)
+	}
+}
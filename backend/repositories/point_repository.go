@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+)
+
+type PointRepository interface {
+	CreditPointsForOrder(ctx context.Context, dbtx DBTX, orderID int, expiresAt time.Time) (int, error)
+	CreditPointsForGuestOrder(ctx context.Context, dbtx DBTX, guestToken string, expiresAt time.Time) (int, error)
+	ExpirePoints(ctx context.Context, dbtx DBTX, userID int, now time.Time) error
+	RedeemPoints(ctx context.Context, dbtx DBTX, userID int, orderID int, shopID int, points int, now time.Time) error
+	RestorePointsByOrderID(ctx context.Context, dbtx DBTX, orderID int) error
+	FindPointLots(ctx context.Context, dbtx DBTX, userID int, now time.Time) ([]models.PointTransaction, error)
+	FindPointTransactions(ctx context.Context, dbtx DBTX, userID int, limit int) ([]models.PointTransaction, error)
+}
+
+type pointRepository struct{}
+
+func NewPointRepository() PointRepository {
+	return &pointRepository{}
+}
+
+// CreditPointsForOrder はお渡し済みの注文に、店舗の還元率でポイントを付与します。付与したポイントを返します。
+// ゲスト注文、付与するポイントが0の注文、付与済みの注文では何もせず0を返します。
+func (r *pointRepository) CreditPointsForOrder(ctx context.Context, dbtx DBTX, orderID int, expiresAt time.Time) (int, error) {
+	return r.creditPoints(ctx, dbtx, "o.order_id = $1", orderID, expiresAt)
+}
+
+// CreditPointsForGuestOrder はユーザーに引き継いだゲスト注文が既にお渡し済みであれば、さかのぼってポイントを付与します。
+// 付与したポイントを返します。お渡し前の注文はお渡しの時点で付与するため、ここでは何もしません。
+func (r *pointRepository) CreditPointsForGuestOrder(ctx context.Context, dbtx DBTX, guestToken string, expiresAt time.Time) (int, error) {
+	return r.creditPoints(ctx, dbtx, "o.guest_order_token = $1", guestToken, expiresAt)
+}
+
+// creditPoints は条件に合う注文にポイントを付与します。対象は値引き後の小計から消費税を除いた金額（税抜）で、1ポイント未満は切り捨てます
+func (r *pointRepository) creditPoints(ctx context.Context, dbtx DBTX, condition string, arg any, expiresAt time.Time) (int, error) {
+	query := `
+		INSERT INTO point_transactions (user_id, order_id, shop_id, kind, points, remaining, expires_at)
+		SELECT o.user_id, o.order_id, o.shop_id, $2::SMALLINT, p.points, p.points, $3::TIMESTAMP
+		FROM orders o
+		JOIN shops s ON s.shop_id = o.shop_id
+		CROSS JOIN LATERAL (SELECT (o.subtotal_amount - o.discount_amount - o.tax_amount) * s.point_rate / 100 AS points) p
+		WHERE ` + condition + ` AND o.user_id IS NOT NULL AND o.status = $4 AND p.points > 0
+		ON CONFLICT (order_id, kind) WHERE order_id IS NOT NULL DO NOTHING
+		RETURNING points
+	`
+	var points int
+	if err := dbtx.QueryRowxContext(ctx, query, arg, models.PointEarned, expiresAt, models.Handed).Scan(&points); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, apperrors.InsertDataFailed.Wrap(err, "ポイントの付与に失敗しました。")
+	}
+	return points, nil
+}
+
+// ExpirePoints は有効期限が過ぎたポイントの残りを失効させ、失効した合計を1行にまとめて台帳に記録します
+func (r *pointRepository) ExpirePoints(ctx context.Context, dbtx DBTX, userID int, now time.Time) error {
+	query := `
+		WITH lots AS (
+			SELECT point_transaction_id, remaining
+			FROM point_transactions
+			WHERE user_id = $1 AND remaining > 0 AND expires_at <= $2
+			FOR UPDATE
+		), cleared AS (
+			UPDATE point_transactions pt
+			SET remaining = 0
+			FROM lots
+			WHERE pt.point_transaction_id = lots.point_transaction_id
+		)
+		INSERT INTO point_transactions (user_id, kind, points)
+		SELECT $1::INT, $3::SMALLINT, -SUM(remaining)
+		FROM lots
+		HAVING SUM(remaining) > 0
+	`
+	if _, err := dbtx.ExecContext(ctx, query, userID, now, models.PointExpired); err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "ポイントの失効に失敗しました。")
+	}
+	return nil
+}
+
+// RedeemPoints は注文でポイントを使います。有効期限の近いものから差し引き、残高が足りない場合はConflictを返します。
+// 注文の登録と同じトランザクション内で使ってください。
+func (r *pointRepository) RedeemPoints(ctx context.Context, dbtx DBTX, userID int, orderID int, shopID int, points int, now time.Time) error {
+	// 同時に使われても残高を超えないよう、差し引く対象の行をロックしてから数える
+	lotsQuery := `
+		SELECT *
+		FROM point_transactions
+		WHERE user_id = $1 AND remaining > 0 AND expires_at > $2
+		ORDER BY expires_at, point_transaction_id
+		FOR UPDATE
+	`
+	var lots []models.PointTransaction
+	if err := dbtx.SelectContext(ctx, &lots, lotsQuery, userID, now); err != nil {
+		return apperrors.GetDataFailed.Wrap(err, "ポイント残高の取得に失敗しました。")
+	}
+	balance := 0
+	for _, lot := range lots {
+		balance += lot.Remaining
+	}
+	if balance < points {
+		return apperrors.Conflict.WrapMessage(nil, apperrors.MsgInsufficientPoints, apperrors.Params{"balance": balance})
+	}
+
+	rest := points
+	var latestExpiry time.Time
+	for _, lot := range lots {
+		if rest == 0 {
+			break
+		}
+		take := min(lot.Remaining, rest)
+		rest -= take
+		latestExpiry = lot.ExpiresAt.Time
+
+		updateQuery := `UPDATE point_transactions SET remaining = remaining - $1 WHERE point_transaction_id = $2`
+		if _, err := dbtx.ExecContext(ctx, updateQuery, take, lot.PointTransactionID); err != nil {
+			return apperrors.UpdateDataFailed.Wrap(err, "ポイント残高の更新に失敗しました。")
+		}
+	}
+
+	// 返還するときに使うため、差し引いた分のうち最も遅い有効期限を残す
+	insertQuery := `
+		INSERT INTO point_transactions (user_id, order_id, shop_id, kind, points, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	if _, err := dbtx.ExecContext(ctx, insertQuery, userID, orderID, shopID, models.PointRedeemed, -points, latestExpiry); err != nil {
+		return apperrors.InsertDataFailed.Wrap(err, "ポイントの利用の記録に失敗しました。")
+	}
+	return nil
+}
+
+// RestorePointsByOrderID は注文で使ったポイントを返還します。
+// ポイントを使っていない注文や、返還済みの注文では何もしません。
+func (r *pointRepository) RestorePointsByOrderID(ctx context.Context, dbtx DBTX, orderID int) error {
+	query := `
+		INSERT INTO point_transactions (user_id, order_id, shop_id, kind, points, remaining, expires_at)
+		SELECT user_id, order_id, shop_id, $2::SMALLINT, -points, -points, expires_at
+		FROM point_transactions
+		WHERE order_id = $1 AND kind = $3
+		ON CONFLICT (order_id, kind) WHERE order_id IS NOT NULL DO NOTHING
+	`
+	if _, err := dbtx.ExecContext(ctx, query, orderID, models.PointRestored, models.PointRedeemed); err != nil {
+		return apperrors.InsertDataFailed.Wrap(err, "ポイントの返還に失敗しました。")
+	}
+	return nil
+}
+
+// FindPointLots は使えるポイントが残っている獲得・返還の行を、有効期限の近い順に取得します
+func (r *pointRepository) FindPointLots(ctx context.Context, dbtx DBTX, userID int, now time.Time) ([]models.PointTransaction, error) {
+	query := `
+		SELECT *
+		FROM point_transactions
+		WHERE user_id = $1 AND remaining > 0 AND expires_at > $2
+		ORDER BY expires_at, point_transaction_id
+	`
+	var lots []models.PointTransaction
+	if err := dbtx.SelectContext(ctx, &lots, query, userID, now); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "ポイント残高の取得に失敗しました。")
+	}
+	return lots, nil
+}
+
+// FindPointTransactions はユーザーのポイントの台帳を新しい順に最大limit件取得します
+func (r *pointRepository) FindPointTransactions(ctx context.Context, dbtx DBTX, userID int, limit int) ([]models.PointTransaction, error) {
+	query := `
+		SELECT *
+		FROM point_transactions
+		WHERE user_id = $1
+		ORDER BY created_at DESC, point_transaction_id DESC
+		LIMIT $2
+	`
+	var transactions []models.PointTransaction
+	if err := dbtx.SelectContext(ctx, &transactions, query, userID, limit); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "ポイント履歴の取得に失敗しました。")
+	}
+	return transactions, nil
+}
@@ -0,0 +1,100 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+)
+
+func TestPointRepository_EarnRedeemRestoreExpire(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("トランザクションのロールバックに失敗しました: %v", err)
+		}
+	}()
+
+	createTestUser(t, tx, testUserID1, fmt.Sprintf("user%d@test.com", testUserID1))
+	createTestShop(t, tx, testShopID1, fmt.Sprintf("Test Shop %d", testShopID1))
+	testhelpers.AssertNoError(t, repositories.NewShopRepository().UpdateShopPointRate(ctx, tx, testShopID1, 5))
+
+	orderRepo := repositories.NewOrderRepository()
+	handed := newTestOrder(testUserID1, testShopID1, 1080, models.Handed)
+	handed.SubtotalAmount = 1000
+	testhelpers.AssertNoError(t, orderRepo.CreateOrder(ctx, tx, handed, nil))
+	cooking := newTestOrder(testUserID1, testShopID1, 540, models.Cooking)
+	cooking.SubtotalAmount = 500
+	testhelpers.AssertNoError(t, orderRepo.CreateOrder(ctx, tx, cooking, nil))
+
+	repo := repositories.NewPointRepository()
+	now := time.Now()
+	expiresAt := now.AddDate(1, 0, 0)
+	balance := func() int {
+		t.Helper()
+		lots, err := repo.FindPointLots(ctx, tx, testUserID1, now)
+		testhelpers.AssertNoError(t, err)
+		sum := 0
+		for _, lot := range lots {
+			sum += lot.Remaining
+		}
+		return sum
+	}
+
+	// 1000円 × 5ポイント / 100円 = 50ポイント。2回目は付与済みなので何もしない
+	points, err := repo.CreditPointsForOrder(ctx, tx, handed.OrderID, expiresAt)
+	testhelpers.AssertNoError(t, err)
+	if points != 50 {
+		t.Errorf("付与したポイント = %d, want 50", points)
+	}
+	points, err = repo.CreditPointsForOrder(ctx, tx, handed.OrderID, expiresAt)
+	testhelpers.AssertNoError(t, err)
+	if points != 0 {
+		t.Errorf("付与済みの注文に再び付与しました: %d", points)
+	}
+	// お渡し前の注文には付与しない
+	points, err = repo.CreditPointsForOrder(ctx, tx, cooking.OrderID, expiresAt)
+	testhelpers.AssertNoError(t, err)
+	if points != 0 {
+		t.Errorf("お渡し前の注文に付与しました: %d", points)
+	}
+
+	testhelpers.AssertNoError(t, repo.RedeemPoints(ctx, tx, testUserID1, cooking.OrderID, testShopID1, 30, now))
+	if got := balance(); got != 20 {
+		t.Errorf("利用後の残高 = %d, want 20", got)
+	}
+	err = repo.RedeemPoints(ctx, tx, testUserID1, cooking.OrderID, testShopID1, 21, now)
+	testhelpers.AssertAppError(t, err, apperrors.Conflict)
+
+	// 返還は1回だけ
+	testhelpers.AssertNoError(t, repo.RestorePointsByOrderID(ctx, tx, cooking.OrderID))
+	testhelpers.AssertNoError(t, repo.RestorePointsByOrderID(ctx, tx, cooking.OrderID))
+	if got := balance(); got != 50 {
+		t.Errorf("返還後の残高 = %d, want 50", got)
+	}
+
+	// 有効期限を過ぎると残りをまとめて失効させる
+	later := expiresAt.Add(time.Second)
+	testhelpers.AssertNoError(t, repo.ExpirePoints(ctx, tx, testUserID1, later))
+	lots, err := repo.FindPointLots(ctx, tx, testUserID1, now)
+	testhelpers.AssertNoError(t, err)
+	if len(lots) != 0 {
+		t.Errorf("失効後に使えるポイントが残っています: %+v", lots)
+	}
+	history, err := repo.FindPointTransactions(ctx, tx, testUserID1, 10)
+	testhelpers.AssertNoError(t, err)
+	if len(history) != 4 || history[0].Kind != models.PointExpired || history[0].Points != -50 {
+		t.Errorf("台帳が想定外です: %+v", history)
+	}
+}
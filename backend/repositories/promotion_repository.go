@@ -0,0 +1,260 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type PromotionRepository interface {
+	FindPromotionByCodeForUpdate(ctx context.Context, dbtx DBTX, shopID int, code string) (*models.Promotion, error)
+	CountUserRedemptions(ctx context.Context, dbtx DBTX, promotionID int, userID int) (int, error)
+	RedeemPromotion(ctx context.Context, dbtx DBTX, promotionID int, orderID int, userID sql.NullInt64, discountAmount int) error
+	ReleaseRedemptionByOrderID(ctx context.Context, dbtx DBTX, orderID int) error
+	CreatePromotion(ctx context.Context, dbtx DBTX, promo *models.Promotion) error
+	FindPromotionsByShopID(ctx context.Context, dbtx DBTX, shopID int) ([]models.Promotion, error)
+	FindGlobalPromotions(ctx context.Context, dbtx DBTX) ([]models.Promotion, error)
+	DeactivatePromotion(ctx context.Context, dbtx DBTX, shopID int, promotionID int) error
+	DeactivateGlobalPromotion(ctx context.Context, dbtx DBTX, promotionID int) error
+}
+
+type promotionRepository struct{}
+
+func NewPromotionRepository() PromotionRepository {
+	return &promotionRepository{}
+}
+
+// FindPromotionByCodeForUpdate は店舗で使えるクーポンをコードで探し、行ロックを取ります。
+// コードは大文字小文字を区別せず、店舗のクーポンと全店舗共通のクーポンで同じコードがある場合は店舗のものを優先します。
+// 利用回数の確認と加算を同時に行う注文で競合しないよう、トランザクション内で使ってください。
+func (r *promotionRepository) FindPromotionByCodeForUpdate(ctx context.Context, dbtx DBTX, shopID int, code string) (*models.Promotion, error) {
+	query := `
+		SELECT *
+		FROM promotions
+		WHERE (shop_id = $1 OR shop_id IS NULL) AND UPPER(code) = UPPER($2)
+		ORDER BY shop_id NULLS LAST
+		LIMIT 1
+		FOR UPDATE
+	`
+	var promo models.Promotion
+	if err := dbtx.GetContext(ctx, &promo, query, shopID, code); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NoData.Wrap(err, "クーポンコードが見つかりません。")
+		}
+		return nil, apperrors.GetDataFailed.Wrap(err, "クーポンの取得に失敗しました。")
+	}
+
+	itemIDsMap, err := r.findItemIDs(ctx, dbtx, []int{promo.PromotionID})
+	if err != nil {
+		return nil, err
+	}
+	promo.ItemIDs = itemIDsMap[promo.PromotionID]
+	return &promo, nil
+}
+
+// CountUserRedemptions はユーザーがクーポンを使った回数を返します
+func (r *promotionRepository) CountUserRedemptions(ctx context.Context, dbtx DBTX, promotionID int, userID int) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM promotion_redemptions WHERE promotion_id = $1 AND user_id = $2`
+	if err := dbtx.GetContext(ctx, &count, query, promotionID, userID); err != nil {
+		return 0, apperrors.GetDataFailed.Wrap(err, "クーポンの利用回数の取得に失敗しました。")
+	}
+	return count, nil
+}
+
+// RedeemPromotion はクーポンの利用回数を1つ増やし、注文での利用を記録します。
+// 全体の利用上限に達している場合はConflictを返します。注文の登録と同じトランザクション内で使ってください。
+func (r *promotionRepository) RedeemPromotion(ctx context.Context, dbtx DBTX, promotionID int, orderID int, userID sql.NullInt64, discountAmount int) error {
+	// 上限の確認と加算を1つのUPDATEで行い、同時に使われても上限を超えないようにする
+	updateQuery := `
+		UPDATE promotions
+		SET used_count = used_count + 1
+		WHERE promotion_id = $1 AND (usage_limit_total IS NULL OR used_count < usage_limit_total)
+	`
+	result, err := dbtx.ExecContext(ctx, updateQuery, promotionID)
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "クーポンの利用回数の更新に失敗しました。")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "更新結果の取得に失敗しました。")
+	}
+	if rowsAffected == 0 {
+		return apperrors.Conflict.WrapMessage(nil, apperrors.MsgPromotionUsageLimit, nil)
+	}
+
+	insertQuery := `
+		INSERT INTO promotion_redemptions (promotion_id, order_id, user_id, discount_amount)
+		VALUES ($1, $2, $3, $4)
+	`
+	if _, err := dbtx.ExecContext(ctx, insertQuery, promotionID, orderID, userID, discountAmount); err != nil {
+		return apperrors.InsertDataFailed.Wrap(err, "クーポンの利用記録の登録に失敗しました。")
+	}
+	return nil
+}
+
+// ReleaseRedemptionByOrderID は注文でのクーポンの利用を取り消し、利用回数を1つ戻します。
+// クーポンを使っていない注文では何もしません。
+func (r *promotionRepository) ReleaseRedemptionByOrderID(ctx context.Context, dbtx DBTX, orderID int) error {
+	query := `
+		WITH released AS (
+			DELETE FROM promotion_redemptions WHERE order_id = $1 RETURNING promotion_id
+		)
+		UPDATE promotions p
+		SET used_count = p.used_count - 1
+		FROM released
+		WHERE p.promotion_id = released.promotion_id
+	`
+	if _, err := dbtx.ExecContext(ctx, query, orderID); err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "クーポンの利用の取り消しに失敗しました。")
+	}
+	return nil
+}
+
+// CreatePromotion はクーポンを対象商品とあわせて登録します。生成されたIDはpromoに設定されます。
+// 同じ店舗（全店舗共通のクーポンは全店舗共通の中）に同じコードがある場合はConflictを返します。
+func (r *promotionRepository) CreatePromotion(ctx context.Context, dbtx DBTX, promo *models.Promotion) error {
+	query := `
+		INSERT INTO promotions (
+			shop_id, code, name, discount_type, discount_value, min_spend, min_item_quantity, max_discounted_units,
+			starts_at, ends_at, usage_limit_total, usage_limit_per_user
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING promotion_id, used_count, is_active, created_at, updated_at
+	`
+	err := dbtx.QueryRowxContext(
+		ctx,
+		query,
+		promo.ShopID,
+		promo.Code,
+		promo.Name,
+		promo.DiscountType,
+		promo.DiscountValue,
+		promo.MinSpend,
+		promo.MinItemQuantity,
+		promo.MaxDiscountedUnits,
+		promo.StartsAt,
+		promo.EndsAt,
+		promo.UsageLimitTotal,
+		promo.UsageLimitPerUser,
+	).Scan(&promo.PromotionID, &promo.UsedCount, &promo.IsActive, &promo.CreatedAt, &promo.UpdatedAt)
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == "23505" {
+			return apperrors.Conflict.WrapMessage(err, apperrors.MsgDuplicatePromotionCode, nil)
+		}
+		return apperrors.InsertDataFailed.Wrap(err, "クーポンの登録に失敗しました。")
+	}
+
+	for _, itemID := range promo.ItemIDs {
+		itemQuery := `INSERT INTO promotion_items (promotion_id, item_id) VALUES ($1, $2)`
+		if _, err := dbtx.ExecContext(ctx, itemQuery, promo.PromotionID, itemID); err != nil {
+			// 全店舗共通のクーポンは店舗の商品かどうかを確認しないため、存在しない商品は外部キー制約で検出する
+			if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == "23503" {
+				return apperrors.BadParam.WrapMessage(err, apperrors.MsgUnknownItem, nil)
+			}
+			return apperrors.InsertDataFailed.Wrap(err, "クーポンの対象商品の登録に失敗しました。")
+		}
+	}
+	return nil
+}
+
+// FindPromotionsByShopID は店舗のクーポンを新しい順に取得します。全店舗共通のクーポンは含みません。
+func (r *promotionRepository) FindPromotionsByShopID(ctx context.Context, dbtx DBTX, shopID int) ([]models.Promotion, error) {
+	return r.findPromotions(ctx, dbtx, `SELECT * FROM promotions WHERE shop_id = $1 ORDER BY promotion_id DESC`, shopID)
+}
+
+// FindGlobalPromotions は全店舗共通のクーポンを新しい順に取得します
+func (r *promotionRepository) FindGlobalPromotions(ctx context.Context, dbtx DBTX) ([]models.Promotion, error) {
+	return r.findPromotions(ctx, dbtx, `SELECT * FROM promotions WHERE shop_id IS NULL ORDER BY promotion_id DESC`)
+}
+
+// findPromotions はクーポンを対象商品とあわせて取得します
+func (r *promotionRepository) findPromotions(ctx context.Context, dbtx DBTX, query string, args ...any) ([]models.Promotion, error) {
+	var promos []models.Promotion
+	if err := dbtx.SelectContext(ctx, &promos, query, args...); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "クーポン一覧の取得に失敗しました。")
+	}
+	if len(promos) == 0 {
+		return promos, nil
+	}
+
+	promotionIDs := make([]int, len(promos))
+	for i, p := range promos {
+		promotionIDs[i] = p.PromotionID
+	}
+	itemIDsMap, err := r.findItemIDs(ctx, dbtx, promotionIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range promos {
+		promos[i].ItemIDs = itemIDsMap[promos[i].PromotionID]
+	}
+	return promos, nil
+}
+
+// DeactivatePromotion は店舗のクーポンを使えなくします。利用記録を残すため削除はしません。
+func (r *promotionRepository) DeactivatePromotion(ctx context.Context, dbtx DBTX, shopID int, promotionID int) error {
+	query := `UPDATE promotions SET is_active = FALSE WHERE promotion_id = $1 AND shop_id = $2`
+	result, err := dbtx.ExecContext(ctx, query, promotionID, shopID)
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "クーポンの無効化に失敗しました。")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "更新結果の取得に失敗しました。")
+	}
+	if rowsAffected == 0 {
+		return apperrors.NoData.Wrap(nil, "指定されたクーポンが見つからないか、この店舗のものではありません。")
+	}
+	return nil
+}
+
+// DeactivateGlobalPromotion は全店舗共通のクーポンを使えなくします。利用記録を残すため削除はしません。
+func (r *promotionRepository) DeactivateGlobalPromotion(ctx context.Context, dbtx DBTX, promotionID int) error {
+	query := `UPDATE promotions SET is_active = FALSE WHERE promotion_id = $1 AND shop_id IS NULL`
+	result, err := dbtx.ExecContext(ctx, query, promotionID)
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "クーポンの無効化に失敗しました。")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "更新結果の取得に失敗しました。")
+	}
+	if rowsAffected == 0 {
+		return apperrors.NoData.Wrap(nil, "指定されたクーポンが見つからないか、全店舗共通のものではありません。")
+	}
+	return nil
+}
+
+// findItemIDs はクーポンIDごとの対象商品IDを取得します
+func (r *promotionRepository) findItemIDs(ctx context.Context, dbtx DBTX, promotionIDs []int) (map[int][]int, error) {
+	query, args, err := sqlx.In(`
+		SELECT promotion_id, item_id
+		FROM promotion_items
+		WHERE promotion_id IN (?)
+		ORDER BY promotion_id, item_id
+	`, promotionIDs)
+	if err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "データベースクエリの構築に失敗しました。")
+	}
+	query = dbtx.Rebind(query)
+
+	var rows []struct {
+		PromotionID int `db:"promotion_id"`
+		ItemID      int `db:"item_id"`
+	}
+	if err := dbtx.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "クーポンの対象商品の取得に失敗しました。")
+	}
+
+	itemIDsMap := make(map[int][]int, len(promotionIDs))
+	for _, row := range rows {
+		itemIDsMap[row.PromotionID] = append(itemIDsMap[row.PromotionID], row.ItemID)
+	}
+	return itemIDsMap, nil
+}
@@ -0,0 +1,120 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPromotionRepository_RedeemAndRelease(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("トランザクションのロールバックに失敗しました: %v", err)
+		}
+	}()
+
+	createTestUser(t, tx, testUserID1, fmt.Sprintf("user%d@test.com", testUserID1))
+	createTestShop(t, tx, testShopID1, fmt.Sprintf("Test Shop %d", testShopID1))
+	for _, item := range newTestItems() {
+		if _, err := tx.NamedExec(`INSERT INTO items (item_id, item_name, price) VALUES (:item_id, :item_name, :price)`, item); err != nil {
+			t.Fatalf("アイテムの挿入に失敗しました: %v", err)
+		}
+	}
+
+	repo := repositories.NewPromotionRepository()
+	global := &models.Promotion{Code: "WELCOME", Name: "全店共通", DiscountType: models.FixedDiscount, DiscountValue: 100}
+	testhelpers.AssertNoError(t, repo.CreatePromotion(ctx, tx, global))
+	promo := &models.Promotion{
+		ShopID:          sql.NullInt64{Int64: testShopID1, Valid: true},
+		Code:            "WELCOME",
+		Name:            "店舗限定",
+		DiscountType:    models.PercentageDiscount,
+		DiscountValue:   10,
+		UsageLimitTotal: sql.NullInt64{Int64: 1, Valid: true},
+		ItemIDs:         []int{testItemID2},
+	}
+	testhelpers.AssertNoError(t, repo.CreatePromotion(ctx, tx, promo))
+	if !promo.IsActive || promo.UsedCount != 0 {
+		t.Errorf("登録直後のクーポンが想定外です: %+v", promo)
+	}
+
+	// 同じ店舗に同じコードは登録できない（大文字小文字は区別しない）
+	duplicate := &models.Promotion{ShopID: promo.ShopID, Code: "welcome", Name: "重複", DiscountType: models.FixedDiscount, DiscountValue: 50}
+	testhelpers.AssertAppError(t, repo.CreatePromotion(ctx, tx, duplicate), apperrors.Conflict)
+
+	// 店舗のクーポンが全店舗共通のものより優先される
+	got, err := repo.FindPromotionByCodeForUpdate(ctx, tx, testShopID1, "welcome")
+	testhelpers.AssertNoError(t, err)
+	if got.PromotionID != promo.PromotionID {
+		t.Errorf("クーポンID = %d, want %d", got.PromotionID, promo.PromotionID)
+	}
+	if diff := cmp.Diff([]int{testItemID2}, got.ItemIDs); diff != "" {
+		t.Errorf("対象商品が一致しません (-want +got):\n%s", diff)
+	}
+	other, err := repo.FindPromotionByCodeForUpdate(ctx, tx, testShopID2, "WELCOME")
+	testhelpers.AssertNoError(t, err)
+	if other.PromotionID != global.PromotionID {
+		t.Errorf("他店舗では全店舗共通のクーポンが使われるべきです: got %d, want %d", other.PromotionID, global.PromotionID)
+	}
+	_, err = repo.FindPromotionByCodeForUpdate(ctx, tx, testShopID1, "NOPE")
+	testhelpers.AssertAppError(t, err, apperrors.NoData)
+
+	order := newTestOrder(testUserID1, testShopID1, testPrice2, models.Cooking)
+	testhelpers.AssertNoError(t, repositories.NewOrderRepository().CreateOrder(ctx, tx, order, []models.OrderItem{
+		newTestOrderItem(0, testItemID2, testQuantity1, testPrice2),
+	}))
+	userID := sql.NullInt64{Int64: testUserID1, Valid: true}
+
+	testhelpers.AssertNoError(t, repo.RedeemPromotion(ctx, tx, promo.PromotionID, order.OrderID, userID, 20))
+	count, err := repo.CountUserRedemptions(ctx, tx, promo.PromotionID, testUserID1)
+	testhelpers.AssertNoError(t, err)
+	if count != 1 {
+		t.Errorf("利用回数 = %d, want 1", count)
+	}
+
+	// 全体の利用上限（1回）に達している
+	err = repo.RedeemPromotion(ctx, tx, promo.PromotionID, order.OrderID, userID, 20)
+	testhelpers.AssertAppError(t, err, apperrors.Conflict)
+
+	// 決済失敗などで利用を取り消すと、再び使えるようになる
+	testhelpers.AssertNoError(t, repo.ReleaseRedemptionByOrderID(ctx, tx, order.OrderID))
+	count, err = repo.CountUserRedemptions(ctx, tx, promo.PromotionID, testUserID1)
+	testhelpers.AssertNoError(t, err)
+	if count != 0 {
+		t.Errorf("取り消し後の利用回数 = %d, want 0", count)
+	}
+	promos, err := repo.FindPromotionsByShopID(ctx, tx, testShopID1)
+	testhelpers.AssertNoError(t, err)
+	if len(promos) != 1 || promos[0].UsedCount != 0 {
+		t.Errorf("店舗のクーポン一覧が想定外です: %+v", promos)
+	}
+
+	testhelpers.AssertNoError(t, repo.DeactivatePromotion(ctx, tx, testShopID1, promo.PromotionID))
+	testhelpers.AssertAppError(t, repo.DeactivatePromotion(ctx, tx, testShopID2, promo.PromotionID), apperrors.NoData)
+
+	// 全店舗共通のクーポンは店舗のクーポンと別に一覧・無効化する
+	globals, err := repo.FindGlobalPromotions(ctx, tx)
+	testhelpers.AssertNoError(t, err)
+	if len(globals) != 1 || globals[0].PromotionID != global.PromotionID {
+		t.Errorf("全店舗共通のクーポン一覧が想定外です: %+v", globals)
+	}
+	testhelpers.AssertAppError(t, repo.DeactivateGlobalPromotion(ctx, tx, promo.PromotionID), apperrors.NoData)
+	testhelpers.AssertNoError(t, repo.DeactivateGlobalPromotion(ctx, tx, global.PromotionID))
+
+	// 存在しない商品を対象にするとBadParam（トランザクションが中断されるため最後に確認する）
+	unknownItem := &models.Promotion{Code: "NOITEM", Name: "存在しない商品", DiscountType: models.FixedDiscount, DiscountValue: 50, ItemIDs: []int{99999}}
+	testhelpers.AssertAppError(t, repo.CreatePromotion(ctx, tx, unknownItem), apperrors.BadParam)
+}
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+)
+
+type RefundRepository interface {
+	FindRefundableOrderItems(ctx context.Context, dbtx DBTX, orderID int) ([]RefundableOrderItemDB, error)
+	CreateRefund(ctx context.Context, dbtx DBTX, refund *models.Refund) error
+	CompleteRefund(ctx context.Context, dbtx DBTX, refundID int, providerRefundID string) error
+	FindPendingRefunds(ctx context.Context, dbtx DBTX, createdBefore time.Time) ([]PendingRefundDB, error)
+}
+
+type refundRepository struct{}
+
+func NewRefundRepository() RefundRepository {
+	return &refundRepository{}
+}
+
+// 返金額の計算に使う注文商品。UnitPriceは注文時の単価（税込）にオプションの差額を加えたもの
+type RefundableOrderItemDB struct {
+	OrderItemID      int    `db:"order_item_id"`
+	ItemName         string `db:"item_name"`
+	Quantity         int    `db:"quantity"`
+	UnitPrice        int    `db:"unit_price"`
+	TaxRate          int    `db:"tax_rate"`          // 注文時に適用した消費税率（%）
+	RefundedQuantity int    `db:"refunded_quantity"` // これまでに返金した数量
+}
+
+// 決済代行会社の結果を待っている返金。やり直しに必要な決済代行会社側の決済IDを持つ
+type PendingRefundDB struct {
+	RefundID          int    `db:"refund_id"`
+	Amount            int    `db:"amount"`
+	ProviderPaymentID string `db:"provider_payment_id"`
+}
+
+// FindRefundableOrderItems は注文商品と返金済みの数量を取得します。返金依頼中の数量も返金済みに含めます
+func (r *refundRepository) FindRefundableOrderItems(ctx context.Context, dbtx DBTX, orderID int) ([]RefundableOrderItemDB, error) {
+	query := `
+		SELECT
+			oi.order_item_id, i.item_name, oi.quantity,
+			oi.price_at_order + oi.modifier_price_delta AS unit_price,
+			oi.tax_rate,
+			COALESCE((SELECT SUM(ri.quantity) FROM refund_items ri WHERE ri.order_item_id = oi.order_item_id), 0) AS refunded_quantity
+		FROM
+			order_item oi
+		INNER JOIN
+			items i ON oi.item_id = i.item_id
+		WHERE
+			oi.order_id = $1
+		ORDER BY
+			oi.order_item_id
+	`
+	var items []RefundableOrderItemDB
+	if err := dbtx.SelectContext(ctx, &items, query, orderID); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "注文商品の取得に失敗しました。")
+	}
+	return items, nil
+}
+
+// CreateRefund は返金と返金した注文商品を登録します
+func (r *refundRepository) CreateRefund(ctx context.Context, dbtx DBTX, refund *models.Refund) error {
+	query := `
+		INSERT INTO refunds (payment_id, order_id, amount, reason, staff_user_id, provider_refund_id, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING refund_id, created_at, updated_at
+	`
+	err := dbtx.QueryRowxContext(
+		ctx,
+		query,
+		refund.PaymentID,
+		refund.OrderID,
+		refund.Amount,
+		refund.Reason,
+		refund.StaffUserID,
+		refund.ProviderRefundID,
+		refund.Status,
+	).Scan(&refund.RefundID, &refund.CreatedAt, &refund.UpdatedAt)
+	if err != nil {
+		return apperrors.InsertDataFailed.Wrap(err, "返金の登録に失敗しました。")
+	}
+
+	if len(refund.Items) == 0 {
+		return nil
+	}
+	stmt, err := dbtx.PreparexContext(ctx, "INSERT INTO refund_items (refund_id, order_item_id, quantity, amount) VALUES ($1, $2, $3, $4) RETURNING refund_item_id")
+	if err != nil {
+		return apperrors.InsertDataFailed.Wrap(err, "返金商品登録の準備に失敗しました。")
+	}
+	defer stmt.Close()
+
+	for i := range refund.Items {
+		item := &refund.Items[i]
+		item.RefundID = refund.RefundID
+		if err := stmt.QueryRowxContext(ctx, item.RefundID, item.OrderItemID, item.Quantity, item.Amount).Scan(&item.RefundItemID); err != nil {
+			return apperrors.InsertDataFailed.Wrap(err, "返金商品の登録に失敗しました。")
+		}
+	}
+	return nil
+}
+
+// CompleteRefund は返金依頼中の返金を返金済みにし、決済代行会社側の返金IDを保存します。
+// 既に返金済みの場合は何もしません（やり直しと元の依頼が同時に終わった場合）。
+func (r *refundRepository) CompleteRefund(ctx context.Context, dbtx DBTX, refundID int, providerRefundID string) error {
+	query := `
+		UPDATE refunds
+		SET status = $1, provider_refund_id = $2
+		WHERE refund_id = $3 AND status = $4
+	`
+	providerID := sql.NullString{String: providerRefundID, Valid: providerRefundID != ""}
+	if _, err := dbtx.ExecContext(ctx, query, models.RefundSucceeded, providerID, refundID, models.RefundPending); err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "返金情報の更新に失敗しました。")
+	}
+	return nil
+}
+
+// FindPendingRefunds は createdBefore より前に登録されたまま返金依頼中になっている返金を古い順に取得します
+func (r *refundRepository) FindPendingRefunds(ctx context.Context, dbtx DBTX, createdBefore time.Time) ([]PendingRefundDB, error) {
+	query := `
+		SELECT r.refund_id, r.amount, p.provider_payment_id
+		FROM refunds r
+		INNER JOIN payments p ON r.payment_id = p.payment_id
+		WHERE r.status = $1 AND r.created_at < $2
+		ORDER BY r.created_at, r.refund_id
+	`
+	var refunds []PendingRefundDB
+	if err := dbtx.SelectContext(ctx, &refunds, query, models.RefundPending, createdBefore.UTC()); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "返金依頼中の返金の取得に失敗しました。")
+	}
+	return refunds, nil
+}
@@ -0,0 +1,120 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRefundRepository_CreateRefundAndRefundedAmount(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("トランザクションのロールバックに失敗しました: %v", err)
+		}
+	}()
+
+	createTestUser(t, tx, testUserID1, fmt.Sprintf("user%d@test.com", testUserID1))
+	createTestShop(t, tx, testShopID1, fmt.Sprintf("Test Shop %d", testShopID1))
+	for _, item := range newTestItems() {
+		if _, err := tx.NamedExec(`INSERT INTO items (item_id, item_name, price) VALUES (:item_id, :item_name, :price)`, item); err != nil {
+			t.Fatalf("アイテムの挿入に失敗しました: %v", err)
+		}
+	}
+
+	// Item A を2個（オプションで+50円）、Item B を1個
+	order := newTestOrder(testUserID1, testShopID1, (testPrice1+50)*testQuantity2+testPrice2, models.Cooking)
+	withModifier := newTestOrderItem(0, testItemID1, testQuantity2, testPrice1)
+	withModifier.ModifierPriceDelta = 50
+	items := []models.OrderItem{
+		withModifier,
+		newTestOrderItem(0, testItemID2, testQuantity1, testPrice2),
+	}
+	testhelpers.AssertNoError(t, repositories.NewOrderRepository().CreateOrder(ctx, tx, order, items))
+
+	paymentRepo := repositories.NewPaymentRepository()
+	payment := &models.Payment{
+		OrderID:           sql.NullInt64{Int64: int64(order.OrderID), Valid: true},
+		Provider:          "mock",
+		ProviderPaymentID: sql.NullString{String: "mock_pay_refund", Valid: true},
+		Amount:            order.TotalAmount,
+		Status:            models.PaymentCaptured,
+	}
+	testhelpers.AssertNoError(t, paymentRepo.CreatePayment(ctx, tx, payment))
+
+	// 売上が確定した決済だけを返金の対象にする
+	_, err := paymentRepo.FindCapturedPaymentByOrderIDForUpdate(ctx, tx, 99999)
+	testhelpers.AssertAppError(t, err, apperrors.NoData)
+	got, err := paymentRepo.FindCapturedPaymentByOrderIDForUpdate(ctx, tx, order.OrderID)
+	testhelpers.AssertNoError(t, err)
+	if got.PaymentID != payment.PaymentID || got.RefundedAmount != 0 {
+		t.Errorf("想定外の決済: %+v", got)
+	}
+
+	repo := repositories.NewRefundRepository()
+	refund := &models.Refund{
+		PaymentID:   payment.PaymentID,
+		OrderID:     sql.NullInt64{Int64: int64(order.OrderID), Valid: true},
+		Amount:      testPrice1 + 50,
+		Reason:      "品切れのため",
+		StaffUserID: testUserID1,
+		Status:      models.RefundPending,
+		Items: []models.RefundItem{
+			{OrderItemID: items[0].OrderItemID, Quantity: 1, Amount: testPrice1 + 50},
+		},
+	}
+	testhelpers.AssertNoError(t, repo.CreateRefund(ctx, tx, refund))
+	if refund.RefundID <= 0 || refund.Items[0].RefundItemID <= 0 {
+		t.Fatalf("返金IDが採番されていません: %+v", refund)
+	}
+
+	// 返金依頼中の返金はやり直しの対象になり、返金済みにすると対象から外れる
+	pending, err := repo.FindPendingRefunds(ctx, tx, time.Now().Add(time.Minute))
+	testhelpers.AssertNoError(t, err)
+	wantPending := []repositories.PendingRefundDB{{RefundID: refund.RefundID, Amount: refund.Amount, ProviderPaymentID: "mock_pay_refund"}}
+	if diff := cmp.Diff(wantPending, pending); diff != "" {
+		t.Errorf("FindPendingRefunds の結果が一致しません (-want +got):\n%s", diff)
+	}
+	testhelpers.AssertNoError(t, repo.CompleteRefund(ctx, tx, refund.RefundID, "mock_re_1"))
+	pending, err = repo.FindPendingRefunds(ctx, tx, time.Now().Add(time.Minute))
+	testhelpers.AssertNoError(t, err)
+	if len(pending) != 0 {
+		t.Errorf("返金済みの返金がやり直しの対象に残っています: %+v", pending)
+	}
+
+	// 単価はオプションの差額込みで、返金済みの数量を含める
+	lines, err := repo.FindRefundableOrderItems(ctx, tx, order.OrderID)
+	testhelpers.AssertNoError(t, err)
+	expected := []repositories.RefundableOrderItemDB{
+		{OrderItemID: items[0].OrderItemID, ItemName: "Item A", Quantity: testQuantity2, UnitPrice: testPrice1 + 50, RefundedQuantity: 1},
+		{OrderItemID: items[1].OrderItemID, ItemName: "Item B", Quantity: testQuantity1, UnitPrice: testPrice2, RefundedQuantity: 0},
+	}
+	if diff := cmp.Diff(expected, lines); diff != "" {
+		t.Errorf("FindRefundableOrderItems の結果が一致しません (-want +got):\n%s", diff)
+	}
+
+	// 返金済みの合計は支払額を超えられない
+	testhelpers.AssertNoError(t, paymentRepo.AddRefundedAmount(ctx, tx, payment.PaymentID, refund.Amount))
+	testhelpers.AssertAppError(t, paymentRepo.AddRefundedAmount(ctx, tx, payment.PaymentID, order.TotalAmount), apperrors.Conflict)
+	testhelpers.AssertNoError(t, paymentRepo.AddRefundedAmount(ctx, tx, payment.PaymentID, order.TotalAmount-refund.Amount))
+
+	// 管理画面の注文一覧に返金済みの合計が載る
+	orders, err := repositories.NewOrderRepository().FindShopOrdersByStatuses(ctx, tx, testShopID1, []models.OrderStatus{models.Cooking})
+	testhelpers.AssertNoError(t, err)
+	if len(orders) != 1 || orders[0].RefundedAmount != refund.Amount {
+		t.Errorf("注文一覧の返金済み合計 = %+v, want %d", orders, refund.Amount)
+	}
+}
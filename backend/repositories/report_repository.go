@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+)
+
+// reportTimeZone は売上を集計する日・週・月の区切りに使うタイムゾーンです。注文日時はUTCで保存しています
+const reportTimeZone = "Asia/Tokyo"
+
+type ReportRepository interface {
+	FindSalesByPeriod(ctx context.Context, dbtx DBTX, shopID int, from time.Time, to time.Time, unit string) ([]models.SalesPeriod, error)
+	FindItemSales(ctx context.Context, dbtx DBTX, shopID int, from time.Time, to time.Time) ([]models.ItemSales, error)
+	FindKitchenTimings(ctx context.Context, dbtx DBTX, shopID int, from time.Time, to time.Time) ([]models.KitchenTiming, error)
+	FindUncollectedOrders(ctx context.Context, dbtx DBTX, shopID int, completedBefore time.Time) ([]models.Order, error)
+	StreamOrderExportLines(ctx context.Context, dbtx DBTX, shopID int, from time.Time, to time.Time, fn func(models.OrderExportLine) error) error
+}
+
+type reportRepository struct{}
+
+func NewReportRepository() ReportRepository {
+	return &reportRepository{}
+}
+
+// FindSalesByPeriod は from 以上 to 未満に注文された店舗の注文を、日本時間の unit（day, week, month）ごとに集計します。
+// 決済待ちと取り消した注文は含めません。注文のない期間の行は返しません。
+func (r *reportRepository) FindSalesByPeriod(ctx context.Context, dbtx DBTX, shopID int, from time.Time, to time.Time, unit string) ([]models.SalesPeriod, error) {
+	query := `
+		SELECT
+			date_trunc($4, o.order_date AT TIME ZONE 'UTC' AT TIME ZONE '` + reportTimeZone + `')::DATE AS period_start,
+			COUNT(*) AS order_count,
+			COALESCE(SUM(oi.items_sold), 0) AS items_sold,
+			SUM(o.subtotal_amount) AS subtotal_amount,
+			SUM(o.discount_amount) AS discount_amount,
+			SUM(o.tax_amount) AS tax_amount,
+			SUM(o.total_amount) AS total_amount,
+			COALESCE(SUM(rf.refunded_amount), 0) AS refunded_amount
+		FROM orders o
+		LEFT JOIN LATERAL (SELECT SUM(quantity) AS items_sold FROM order_item WHERE order_id = o.order_id) oi ON TRUE
+		LEFT JOIN LATERAL (SELECT SUM(amount) AS refunded_amount FROM refunds WHERE order_id = o.order_id AND status = $7) rf ON TRUE
+		WHERE o.shop_id = $1 AND o.order_date >= $2 AND o.order_date < $3 AND o.status NOT IN ($5, $6)
+		GROUP BY period_start
+		ORDER BY period_start
+	`
+	var periods []models.SalesPeriod
+	if err := dbtx.SelectContext(ctx, &periods, query, shopID, from.UTC(), to.UTC(), unit, models.PendingPayment, models.Cancelled, models.RefundSucceeded); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "売上の集計に失敗しました。")
+	}
+	return periods, nil
+}
+
+// FindItemSales は from 以上 to 未満に注文された店舗の注文を商品ごとに集計し、売上の多い順に返します。
+// 決済待ちと取り消した注文は含めません。
+func (r *reportRepository) FindItemSales(ctx context.Context, dbtx DBTX, shopID int, from time.Time, to time.Time) ([]models.ItemSales, error) {
+	query := `
+		SELECT
+			oi.item_id,
+			i.item_name,
+			SUM(oi.quantity) AS quantity,
+			COUNT(DISTINCT o.order_id) AS order_count,
+			SUM((oi.price_at_order + oi.modifier_price_delta) * oi.quantity) AS sales_amount
+		FROM order_item oi
+		JOIN orders o ON o.order_id = oi.order_id
+		JOIN items i ON i.item_id = oi.item_id
+		WHERE o.shop_id = $1 AND o.order_date >= $2 AND o.order_date < $3 AND o.status NOT IN ($4, $5)
+		GROUP BY oi.item_id, i.item_name
+		ORDER BY sales_amount DESC, oi.item_id
+	`
+	var items []models.ItemSales
+	if err := dbtx.SelectContext(ctx, &items, query, shopID, from.UTC(), to.UTC(), models.PendingPayment, models.Cancelled); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "商品ごとの売上の集計に失敗しました。")
+	}
+	return items, nil
+}
+
+// FindKitchenTimings は from 以上 to 未満に注文され、調理完了した店舗の注文を商品ごとに1行ずつ取得します
+func (r *reportRepository) FindKitchenTimings(ctx context.Context, dbtx DBTX, shopID int, from time.Time, to time.Time) ([]models.KitchenTiming, error) {
+	query := `
+		SELECT o.order_id, oi.item_id, i.item_name, o.order_date, o.completed_at, o.handed_at
+		FROM orders o
+		JOIN order_item oi ON oi.order_id = o.order_id
+		JOIN items i ON i.item_id = oi.item_id
+		WHERE o.shop_id = $1 AND o.order_date >= $2 AND o.order_date < $3 AND o.completed_at IS NOT NULL
+		ORDER BY o.order_id, oi.item_id
+	`
+	var timings []models.KitchenTiming
+	if err := dbtx.SelectContext(ctx, &timings, query, shopID, from.UTC(), to.UTC()); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "調理時間の実績の取得に失敗しました。")
+	}
+	return timings, nil
+}
+
+// FindUncollectedOrders は completedBefore 以前に調理完了したまま、まだお渡ししていない店舗の注文を調理完了の古い順に取得します
+func (r *reportRepository) FindUncollectedOrders(ctx context.Context, dbtx DBTX, shopID int, completedBefore time.Time) ([]models.Order, error) {
+	query := `
+		SELECT *
+		FROM orders
+		WHERE shop_id = $1 AND status = $2 AND completed_at <= $3
+		ORDER BY completed_at, order_id
+	`
+	var orders []models.Order
+	if err := dbtx.SelectContext(ctx, &orders, query, shopID, models.Completed, completedBefore.UTC()); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "受け取られていない注文の取得に失敗しました。")
+	}
+	return orders, nil
+}
+
+// StreamOrderExportLines は from 以上 to 未満に注文された店舗の注文を商品ごとに1行ずつ読み出し、注文日時の順に fn に渡します。
+// 全件をメモリに載せないよう1行ずつ渡します。決済待ちと取り消した注文は含めません。fn がエラーを返すとその時点で中断します。
+func (r *reportRepository) StreamOrderExportLines(ctx context.Context, dbtx DBTX, shopID int, from time.Time, to time.Time, fn func(models.OrderExportLine) error) error {
+	query := `
+		SELECT
+			o.order_id, o.order_date, o.status, o.dining_option, u.email, o.guest_order_token,
+			o.subtotal_amount, o.discount_amount, o.tax_amount, o.total_amount,
+			oi.item_id, i.item_name, oi.quantity, oi.price_at_order, oi.modifier_price_delta, oi.tax_rate
+		FROM orders o
+		JOIN order_item oi ON oi.order_id = o.order_id
+		JOIN items i ON i.item_id = oi.item_id
+		LEFT JOIN users u ON u.user_id = o.user_id
+		WHERE o.shop_id = $1 AND o.order_date >= $2 AND o.order_date < $3 AND o.status NOT IN ($4, $5)
+		ORDER BY o.order_date, o.order_id, oi.order_item_id
+	`
+	rows, err := dbtx.QueryContext(ctx, query, shopID, from.UTC(), to.UTC(), models.PendingPayment, models.Cancelled)
+	if err != nil {
+		return apperrors.GetDataFailed.Wrap(err, "書き出す注文の取得に失敗しました。")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line models.OrderExportLine
+		if err := rows.Scan(
+			&line.OrderID, &line.OrderDate, &line.Status, &line.DiningOption, &line.CustomerEmail, &line.GuestOrderToken,
+			&line.SubtotalAmount, &line.DiscountAmount, &line.TaxAmount, &line.TotalAmount,
+			&line.ItemID, &line.ItemName, &line.Quantity, &line.PriceAtOrder, &line.ModifierPriceDelta, &line.TaxRate,
+		); err != nil {
+			return apperrors.GetDataFailed.Wrap(err, "書き出す注文の読み取りに失敗しました。")
+		}
+		if err := fn(line); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return apperrors.GetDataFailed.Wrap(err, "書き出す注文の読み取りに失敗しました。")
+	}
+	return nil
+}
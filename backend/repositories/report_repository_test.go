@@ -0,0 +1,221 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+)
+
+func TestReportRepository_SalesAndItems(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("トランザクションのロールバックに失敗しました: %v", err)
+		}
+	}()
+
+	createTestUser(t, tx, testUserID1, fmt.Sprintf("user%d@test.com", testUserID1))
+	createTestShop(t, tx, testShopID1, fmt.Sprintf("Test Shop %d", testShopID1))
+	for _, item := range newTestItems() {
+		if _, err := tx.NamedExec(`INSERT INTO items (item_id, item_name, price) VALUES (:item_id, :item_name, :price)`, item); err != nil {
+			t.Fatalf("アイテムの挿入に失敗しました: %v", err)
+		}
+	}
+
+	orderRepo := repositories.NewOrderRepository()
+	createOrder := func(orderDate time.Time, status models.OrderStatus, items ...models.OrderItem) {
+		t.Helper()
+		subtotal := 0
+		for _, item := range items {
+			subtotal += item.PriceAtOrder * item.Quantity
+		}
+		order := newTestOrder(testUserID1, testShopID1, subtotal, status)
+		order.OrderDate = orderDate
+		order.SubtotalAmount = subtotal
+		testhelpers.AssertNoError(t, orderRepo.CreateOrder(ctx, tx, order, items))
+	}
+	// 日本時間の4月1日23:30と、UTCではまだ4月1日の日本時間4月2日0:30
+	createOrder(time.Date(2025, 4, 1, 14, 30, 0, 0, time.UTC), models.Handed, newTestOrderItem(0, testItemID1, 2, testPrice1))
+	createOrder(time.Date(2025, 4, 1, 15, 30, 0, 0, time.UTC), models.Cooking, newTestOrderItem(0, testItemID2, 1, testPrice2))
+	// 決済待ちの注文と期間外の注文は集計しない
+	createOrder(time.Date(2025, 4, 1, 16, 0, 0, 0, time.UTC), models.PendingPayment, newTestOrderItem(0, testItemID1, 5, testPrice1))
+	createOrder(time.Date(2025, 4, 3, 0, 0, 0, 0, time.UTC), models.Handed, newTestOrderItem(0, testItemID1, 1, testPrice1))
+
+	jst := time.FixedZone("JST", 9*60*60)
+	from := time.Date(2025, 4, 1, 0, 0, 0, 0, jst)
+	to := time.Date(2025, 4, 3, 0, 0, 0, 0, jst)
+	repo := repositories.NewReportRepository()
+
+	periods, err := repo.FindSalesByPeriod(ctx, tx, testShopID1, from, to, "day")
+	testhelpers.AssertNoError(t, err)
+	if len(periods) != 2 {
+		t.Fatalf("期間の数 = %d, want 2: %+v", len(periods), periods)
+	}
+	if got := periods[0].PeriodStart.Format("2006-01-02"); got != "2025-04-01" || periods[0].OrderCount != 1 || periods[0].ItemsSold != 2 {
+		t.Errorf("4月1日の集計が想定外です: %+v", periods[0])
+	}
+	if got := periods[1].PeriodStart.Format("2006-01-02"); got != "2025-04-02" || periods[1].SubtotalAmount != testPrice2 {
+		t.Errorf("4月2日の集計が想定外です: %+v", periods[1])
+	}
+
+	weeks, err := repo.FindSalesByPeriod(ctx, tx, testShopID1, from, to, "week")
+	testhelpers.AssertNoError(t, err)
+	if len(weeks) != 1 || weeks[0].PeriodStart.Format("2006-01-02") != "2025-03-31" || weeks[0].OrderCount != 2 {
+		t.Errorf("週の集計が想定外です: %+v", weeks)
+	}
+
+	items, err := repo.FindItemSales(ctx, tx, testShopID1, from, to)
+	testhelpers.AssertNoError(t, err)
+	if len(items) != 2 {
+		t.Fatalf("商品の数 = %d, want 2: %+v", len(items), items)
+	}
+	for _, item := range items {
+		want := map[int]int{testItemID1: 2 * testPrice1, testItemID2: testPrice2}[item.ItemID]
+		if item.SalesAmount != want {
+			t.Errorf("商品%dの売上 = %d, want %d", item.ItemID, item.SalesAmount, want)
+		}
+	}
+}
+
+func TestReportRepository_KitchenTimings(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("トランザクションのロールバックに失敗しました: %v", err)
+		}
+	}()
+
+	createTestUser(t, tx, testUserID1, fmt.Sprintf("user%d@test.com", testUserID1))
+	createTestShop(t, tx, testShopID1, fmt.Sprintf("Test Shop %d", testShopID1))
+	for _, item := range newTestItems() {
+		if _, err := tx.NamedExec(`INSERT INTO items (item_id, item_name, price) VALUES (:item_id, :item_name, :price)`, item); err != nil {
+			t.Fatalf("アイテムの挿入に失敗しました: %v", err)
+		}
+	}
+
+	orderRepo := repositories.NewOrderRepository()
+	orderDate := time.Now().UTC().Add(-time.Hour)
+	newOrder := func() *models.Order {
+		t.Helper()
+		order := newTestOrder(testUserID1, testShopID1, testPrice1, models.Cooking)
+		order.OrderDate = orderDate
+		testhelpers.AssertNoError(t, orderRepo.CreateOrder(ctx, tx, order, []models.OrderItem{
+			newTestOrderItem(0, testItemID1, testQuantity1, testPrice1),
+		}))
+		return order
+	}
+	handed := newOrder()
+	uncollected := newOrder()
+	newOrder() // 調理中の注文は集計しない
+
+	// ステータスを更新すると調理完了日時とお渡し日時が記録される
+	testhelpers.AssertNoError(t, orderRepo.UpdateOrderStatus(ctx, tx, handed.OrderID, testShopID1, models.Completed))
+	testhelpers.AssertNoError(t, orderRepo.UpdateOrderStatus(ctx, tx, handed.OrderID, testShopID1, models.Handed))
+	testhelpers.AssertNoError(t, orderRepo.UpdateOrderStatus(ctx, tx, uncollected.OrderID, testShopID1, models.Completed))
+
+	repo := repositories.NewReportRepository()
+	timings, err := repo.FindKitchenTimings(ctx, tx, testShopID1, orderDate.Add(-time.Hour), orderDate.Add(time.Hour))
+	testhelpers.AssertNoError(t, err)
+	if len(timings) != 2 {
+		t.Fatalf("実績の数 = %d, want 2: %+v", len(timings), timings)
+	}
+	for _, timing := range timings {
+		wantHanded := timing.OrderID == handed.OrderID
+		if timing.HandedAt.Valid != wantHanded {
+			t.Errorf("注文%dのお渡し日時が想定外です: %+v", timing.OrderID, timing.HandedAt)
+		}
+	}
+
+	orders, err := repo.FindUncollectedOrders(ctx, tx, testShopID1, time.Now().UTC().Add(time.Minute))
+	testhelpers.AssertNoError(t, err)
+	if len(orders) != 1 || orders[0].OrderID != uncollected.OrderID {
+		t.Errorf("受け取られていない注文が想定外です: %+v", orders)
+	}
+}
+
+func TestReportRepository_StreamOrderExportLines(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("トランザクションのロールバックに失敗しました: %v", err)
+		}
+	}()
+
+	email := fmt.Sprintf("user%d@test.com", testUserID1)
+	createTestUser(t, tx, testUserID1, email)
+	createTestShop(t, tx, testShopID1, fmt.Sprintf("Test Shop %d", testShopID1))
+	for _, item := range newTestItems() {
+		if _, err := tx.NamedExec(`INSERT INTO items (item_id, item_name, price) VALUES (:item_id, :item_name, :price)`, item); err != nil {
+			t.Fatalf("アイテムの挿入に失敗しました: %v", err)
+		}
+	}
+
+	orderRepo := repositories.NewOrderRepository()
+	orderDate := time.Date(2025, 4, 1, 3, 0, 0, 0, time.UTC)
+	member := newTestOrder(testUserID1, testShopID1, testPrice1, models.Handed)
+	member.OrderDate = orderDate
+	testhelpers.AssertNoError(t, orderRepo.CreateOrder(ctx, tx, member, []models.OrderItem{
+		newTestOrderItem(0, testItemID1, testQuantity1, testPrice1),
+		newTestOrderItem(0, testItemID2, 1, testPrice2),
+	}))
+	guest := newTestOrder(0, testShopID1, testPrice1, models.Cooking)
+	guest.UserID = sql.NullInt64{}
+	guest.GuestOrderToken = sql.NullString{String: "guest-token-1234", Valid: true}
+	guest.OrderDate = orderDate.Add(time.Hour)
+	testhelpers.AssertNoError(t, orderRepo.CreateOrder(ctx, tx, guest, []models.OrderItem{
+		newTestOrderItem(0, testItemID1, 1, testPrice1),
+	}))
+	// 決済待ちの注文は書き出さない
+	pending := newTestOrder(testUserID1, testShopID1, testPrice1, models.PendingPayment)
+	pending.OrderDate = orderDate
+	testhelpers.AssertNoError(t, orderRepo.CreateOrder(ctx, tx, pending, []models.OrderItem{
+		newTestOrderItem(0, testItemID1, 1, testPrice1),
+	}))
+
+	var lines []models.OrderExportLine
+	repo := repositories.NewReportRepository()
+	err := repo.StreamOrderExportLines(ctx, tx, testShopID1, orderDate.Add(-time.Hour), orderDate.Add(2*time.Hour), func(line models.OrderExportLine) error {
+		lines = append(lines, line)
+		return nil
+	})
+	testhelpers.AssertNoError(t, err)
+	if len(lines) != 3 {
+		t.Fatalf("行の数 = %d, want 3: %+v", len(lines), lines)
+	}
+	if lines[0].OrderID != member.OrderID || lines[0].CustomerEmail.String != email || lines[1].ItemID != testItemID2 {
+		t.Errorf("会員の注文の行が想定外です: %+v", lines[:2])
+	}
+	if lines[2].OrderID != guest.OrderID || lines[2].CustomerEmail.Valid || lines[2].GuestOrderToken.String != "guest-token-1234" {
+		t.Errorf("ゲストの注文の行が想定外です: %+v", lines[2])
+	}
+
+	// fnがエラーを返すとそこで中断する
+	stop := errors.New("stop")
+	count := 0
+	err = repo.StreamOrderExportLines(ctx, tx, testShopID1, orderDate.Add(-time.Hour), orderDate.Add(2*time.Hour), func(line models.OrderExportLine) error {
+		count++
+		return stop
+	})
+	if !errors.Is(err, stop) || count != 1 {
+		t.Errorf("err = %v, count = %d, want stop, 1", err, count)
+	}
+}
@@ -3,12 +3,19 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"math"
 
 	"github.com/A4-dev-team/mobileorder.git/apperrors"
 )
 
 type ShopRepository interface {
 	FindShopIDByAdminID(ctx context.Context, dbtx DBTX, userID int) (int, error)
+	FindShopsWithinRadius(ctx context.Context, dbtx DBTX, latitude float64, longitude float64, radiusMeters float64) ([]NearbyShopDBResult, error)
+	UpdateShopCoordinates(ctx context.Context, dbtx DBTX, shopID int, latitude float64, longitude float64) error
+	UpdateShopDefaultPrepTime(ctx context.Context, dbtx DBTX, shopID int, defaultPrepSeconds int) error
+	UpdateShopInvoiceRegistrationNumber(ctx context.Context, dbtx DBTX, shopID int, registrationNumber *string) error
+	UpdateShopPointRate(ctx context.Context, dbtx DBTX, shopID int, pointRate int) error
+	UpdateShopTimeZone(ctx context.Context, dbtx DBTX, shopID int, timeZone string) error
 }
 
 type shopRepository struct{}
@@ -39,3 +46,173 @@ func (r *shopRepository) FindShopIDByAdminID(ctx context.Context, dbtx DBTX, use
 		return 0, apperrors.Unknown.Wrap(err, "データ不整合が発生しました。")
 	}
 }
+
+// 距離検索で取得する店舗情報
+type NearbyShopDBResult struct {
+	ShopID         int     `db:"shop_id"`
+	Name           string  `db:"name"`
+	Description    string  `db:"description"`
+	Location       string  `db:"location"`
+	IsOpen         bool    `db:"is_open"`
+	Latitude       float64 `db:"latitude"`
+	Longitude      float64 `db:"longitude"`
+	DistanceMeters float64 `db:"distance_meters"`
+}
+
+// 緯度1度あたりの距離（メートル）。地球の平均半径6371kmから算出
+const metersPerDegreeLatitude = math.Pi * 6371000.0 / 180
+
+// FindShopsWithinRadius は指定地点から半径radiusMeters以内の店舗を近い順に取得します。
+// 緯度経度のインデックスが効くようにバウンディングボックスで絞り込んでから大円距離（ハバーサイン公式）を計算します。
+func (r *shopRepository) FindShopsWithinRadius(ctx context.Context, dbtx DBTX, latitude float64, longitude float64, radiusMeters float64) ([]NearbyShopDBResult, error) {
+	latDelta := radiusMeters / metersPerDegreeLatitude
+	minLat, maxLat := math.Max(latitude-latDelta, -90), math.Min(latitude+latDelta, 90)
+
+	// 極付近や日付変更線をまたぐ場合は経度での絞り込みを行わない
+	minLng, maxLng := -180.0, 180.0
+	if cosLat := math.Cos(latitude * math.Pi / 180); maxLat < 90 && minLat > -90 && cosLat > 0 {
+		lngDelta := latDelta / cosLat
+		if longitude-lngDelta >= -180 && longitude+lngDelta <= 180 {
+			minLng, maxLng = longitude-lngDelta, longitude+lngDelta
+		}
+	}
+
+	query := `
+		SELECT
+			shop_id, name, description, location, is_open, latitude, longitude, distance_meters
+		FROM (
+			SELECT
+				s.shop_id,
+				s.name,
+				COALESCE(s.description, '') AS description,
+				COALESCE(s.location, '') AS location,
+				COALESCE(s.is_open, FALSE) AS is_open,
+				s.latitude,
+				s.longitude,
+				2 * 6371000.0 * ASIN(LEAST(1, SQRT(
+					POWER(SIN(RADIANS(s.latitude - $1) / 2), 2) +
+					COS(RADIANS($1)) * COS(RADIANS(s.latitude)) * POWER(SIN(RADIANS(s.longitude - $2) / 2), 2)
+				))) AS distance_meters
+			FROM
+				shops s
+			WHERE
+				s.latitude BETWEEN $3 AND $4
+				AND s.longitude BETWEEN $5 AND $6
+		) AS candidates
+		WHERE
+			distance_meters <= $7
+		ORDER BY
+			distance_meters ASC, shop_id ASC
+	`
+
+	var shops []NearbyShopDBResult
+	if err := dbtx.SelectContext(ctx, &shops, query, latitude, longitude, minLat, maxLat, minLng, maxLng, radiusMeters); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "周辺店舗の取得に失敗しました。")
+	}
+	return shops, nil
+}
+
+// UpdateShopCoordinates は店舗の緯度経度を更新します
+func (r *shopRepository) UpdateShopCoordinates(ctx context.Context, dbtx DBTX, shopID int, latitude float64, longitude float64) error {
+	query := `UPDATE shops SET latitude = $1, longitude = $2, updated_at = NOW() WHERE shop_id = $3`
+
+	result, err := dbtx.ExecContext(ctx, query, latitude, longitude, shopID)
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "店舗の位置情報の更新に失敗しました。")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "更新結果の確認に失敗しました。")
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NoData.WrapMessage(nil, apperrors.MsgShopNotFound, nil)
+	}
+
+	return nil
+}
+
+// UpdateShopDefaultPrepTime は店舗の既定調理時間を更新します
+func (r *shopRepository) UpdateShopDefaultPrepTime(ctx context.Context, dbtx DBTX, shopID int, defaultPrepSeconds int) error {
+	query := `UPDATE shops SET default_prep_seconds = $1, updated_at = NOW() WHERE shop_id = $2`
+
+	result, err := dbtx.ExecContext(ctx, query, defaultPrepSeconds, shopID)
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "店舗の既定調理時間の更新に失敗しました。")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "更新結果の確認に失敗しました。")
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NoData.WrapMessage(nil, apperrors.MsgShopNotFound, nil)
+	}
+
+	return nil
+}
+
+// UpdateShopInvoiceRegistrationNumber は店舗の適格請求書発行事業者の登録番号を更新します。nilで登録番号を削除します
+func (r *shopRepository) UpdateShopInvoiceRegistrationNumber(ctx context.Context, dbtx DBTX, shopID int, registrationNumber *string) error {
+	query := `UPDATE shops SET invoice_registration_number = $1, updated_at = NOW() WHERE shop_id = $2`
+
+	result, err := dbtx.ExecContext(ctx, query, registrationNumber, shopID)
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "店舗の登録番号の更新に失敗しました。")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "更新結果の確認に失敗しました。")
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NoData.WrapMessage(nil, apperrors.MsgShopNotFound, nil)
+	}
+
+	return nil
+}
+
+// UpdateShopPointRate は店舗のポイント還元率を更新します。変更は以後にお渡しする注文から反映されます
+func (r *shopRepository) UpdateShopPointRate(ctx context.Context, dbtx DBTX, shopID int, pointRate int) error {
+	query := `UPDATE shops SET point_rate = $1, updated_at = NOW() WHERE shop_id = $2`
+
+	result, err := dbtx.ExecContext(ctx, query, pointRate, shopID)
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "店舗のポイント還元率の更新に失敗しました。")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "更新結果の確認に失敗しました。")
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NoData.WrapMessage(nil, apperrors.MsgShopNotFound, nil)
+	}
+
+	return nil
+}
+
+// UpdateShopTimeZone は店舗のタイムゾーンを更新します。商品の販売時間帯はこのタイムゾーンで判定されます
+func (r *shopRepository) UpdateShopTimeZone(ctx context.Context, dbtx DBTX, shopID int, timeZone string) error {
+	query := `UPDATE shops SET time_zone = $1, updated_at = NOW() WHERE shop_id = $2`
+
+	result, err := dbtx.ExecContext(ctx, query, timeZone, shopID)
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "店舗のタイムゾーンの更新に失敗しました。")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.UpdateDataFailed.Wrap(err, "更新結果の確認に失敗しました。")
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NoData.WrapMessage(nil, apperrors.MsgShopNotFound, nil)
+	}
+
+	return nil
+}
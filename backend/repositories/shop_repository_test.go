@@ -8,6 +8,7 @@ import (
 	"github.com/A4-dev-team/mobileorder.git/apperrors"
 	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
 	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/google/go-cmp/cmp"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -104,3 +105,234 @@ func TestFindShopIDByAdminID(t *testing.T) {
 		})
 	}
 }
+
+// createTestShopWithCoordinates - 緯度経度付きのテスト用店舗を作成するヘルパー関数
+func createTestShopWithCoordinates(t *testing.T, tx *sqlx.Tx, shopID int, latitude float64, longitude float64) {
+	t.Helper()
+
+	query := `INSERT INTO shops (shop_id, name, latitude, longitude) VALUES ($1, $2, $3, $4)`
+	_, err := tx.Exec(query, shopID, fmt.Sprintf("Geo Shop %d", shopID), latitude, longitude)
+	if err != nil {
+		t.Fatalf("テスト用店舗の作成に失敗しました: %v", err)
+	}
+}
+
+// TestFindShopsWithinRadius - 距離検索のテスト
+func TestFindShopsWithinRadius(t *testing.T) {
+	db := NewTestDB(t)
+
+	// 三宮駅付近を基準とする
+	const baseLat, baseLng = 34.6916, 135.1925
+
+	tests := []struct {
+		name        string
+		radius      float64
+		setup       func(*sqlx.Tx)
+		wantShopIDs []int
+	}{
+		{
+			name:   "正常系: 半径内の店舗のみを近い順に返す",
+			radius: 2000,
+			setup: func(tx *sqlx.Tx) {
+				createTestShopWithCoordinates(t, tx, 201, 34.7010, 135.1905) // 約1.1km
+				createTestShopWithCoordinates(t, tx, 202, 34.6917, 135.1926) // 約15m
+				createTestShopWithCoordinates(t, tx, 203, 34.7256, 135.2352) // 約5.4km
+			},
+			wantShopIDs: []int{202, 201},
+		},
+		{
+			name:   "正常系: 緯度経度が未設定の店舗は対象外",
+			radius: 5000,
+			setup: func(tx *sqlx.Tx) {
+				_, err := tx.Exec(`INSERT INTO shops (shop_id, name) VALUES (204, 'No Geo Shop')`)
+				if err != nil {
+					t.Fatalf("テスト用店舗の作成に失敗しました: %v", err)
+				}
+			},
+			wantShopIDs: []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := db.MustBegin()
+			defer tx.Rollback()
+
+			// 既存データの影響を受けないよう位置情報をクリア
+			tx.MustExec(`UPDATE shops SET latitude = NULL, longitude = NULL`)
+			tt.setup(tx)
+
+			repo := repositories.NewShopRepository()
+			got, err := repo.FindShopsWithinRadius(context.Background(), tx, baseLat, baseLng, tt.radius)
+			testhelpers.AssertNoError(t, err)
+
+			gotIDs := make([]int, len(got))
+			for i, shop := range got {
+				gotIDs[i] = shop.ShopID
+				if shop.DistanceMeters > tt.radius {
+					t.Errorf("shop %d: distance %v exceeds radius %v", shop.ShopID, shop.DistanceMeters, tt.radius)
+				}
+			}
+			if fmt.Sprint(gotIDs) != fmt.Sprint(tt.wantShopIDs) {
+				t.Errorf("FindShopsWithinRadius() shop IDs = %v, want %v", gotIDs, tt.wantShopIDs)
+			}
+		})
+	}
+}
+
+// TestUpdateShopCoordinates - 店舗の緯度経度更新のテスト
+func TestUpdateShopCoordinates(t *testing.T) {
+	db := NewTestDB(t)
+
+	tests := []struct {
+		name            string
+		shopID          int
+		setup           func(*sqlx.Tx)
+		expectedErrCode apperrors.ErrCode
+	}{
+		{
+			name:   "正常系: 緯度経度を更新できる",
+			shopID: 205,
+			setup: func(tx *sqlx.Tx) {
+				createTestShopWithCoordinates(t, tx, 205, 0, 0)
+			},
+		},
+		{
+			name:            "異常系: 存在しない店舗はNoDataエラー",
+			shopID:          9999,
+			setup:           func(tx *sqlx.Tx) {},
+			expectedErrCode: apperrors.NoData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := db.MustBegin()
+			defer tx.Rollback()
+
+			tt.setup(tx)
+
+			repo := repositories.NewShopRepository()
+			err := repo.UpdateShopCoordinates(context.Background(), tx, tt.shopID, 34.7256, 135.2352)
+
+			if tt.expectedErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+				return
+			}
+			testhelpers.AssertNoError(t, err)
+
+			var lat, lng float64
+			if err := tx.QueryRow(`SELECT latitude, longitude FROM shops WHERE shop_id = $1`, tt.shopID).Scan(&lat, &lng); err != nil {
+				t.Fatalf("更新後の店舗取得に失敗しました: %v", err)
+			}
+			if lat != 34.7256 || lng != 135.2352 {
+				t.Errorf("coordinates = (%v, %v), want (34.7256, 135.2352)", lat, lng)
+			}
+		})
+	}
+}
+
+func TestUpdateShopInvoiceRegistrationNumber(t *testing.T) {
+	db := NewTestDB(t)
+
+	validNumber := "T1234567890123"
+	invalidNumber := "T123"
+	tests := []struct {
+		name               string
+		shopID             int
+		registrationNumber *string
+		setup              func(*sqlx.Tx)
+		expectedErrCode    apperrors.ErrCode
+	}{
+		{
+			name:               "正常系: 登録番号を設定できる",
+			shopID:             206,
+			registrationNumber: &validNumber,
+			setup: func(tx *sqlx.Tx) {
+				createTestShopWithCoordinates(t, tx, 206, 0, 0)
+			},
+		},
+		{
+			name:               "正常系: nilで登録番号を削除できる",
+			shopID:             207,
+			registrationNumber: nil,
+			setup: func(tx *sqlx.Tx) {
+				createTestShopWithCoordinates(t, tx, 207, 0, 0)
+				if _, err := tx.Exec(`UPDATE shops SET invoice_registration_number = $1 WHERE shop_id = 207`, validNumber); err != nil {
+					t.Fatalf("登録番号の設定に失敗しました: %v", err)
+				}
+			},
+		},
+		{
+			name:               "異常系: 形式が不正な登録番号はDBの制約で拒否される",
+			shopID:             208,
+			registrationNumber: &invalidNumber,
+			setup: func(tx *sqlx.Tx) {
+				createTestShopWithCoordinates(t, tx, 208, 0, 0)
+			},
+			expectedErrCode: apperrors.UpdateDataFailed,
+		},
+		{
+			name:               "異常系: 存在しない店舗はNoDataエラー",
+			shopID:             9999,
+			registrationNumber: &validNumber,
+			setup:              func(tx *sqlx.Tx) {},
+			expectedErrCode:    apperrors.NoData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := db.MustBegin()
+			defer tx.Rollback()
+
+			tt.setup(tx)
+
+			repo := repositories.NewShopRepository()
+			err := repo.UpdateShopInvoiceRegistrationNumber(context.Background(), tx, tt.shopID, tt.registrationNumber)
+
+			if tt.expectedErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+				return
+			}
+			testhelpers.AssertNoError(t, err)
+
+			var got *string
+			if err := tx.QueryRow(`SELECT invoice_registration_number FROM shops WHERE shop_id = $1`, tt.shopID).Scan(&got); err != nil {
+				t.Fatalf("更新後の店舗取得に失敗しました: %v", err)
+			}
+			if diff := cmp.Diff(tt.registrationNumber, got); diff != "" {
+				t.Errorf("登録番号が一致しません (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateShopTimeZone(t *testing.T) {
+	db := NewTestDB(t)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	createTestShopWithCoordinates(t, tx, 209, 0, 0)
+	repo := repositories.NewShopRepository()
+
+	var got string
+	if err := tx.QueryRow(`SELECT time_zone FROM shops WHERE shop_id = 209`).Scan(&got); err != nil {
+		t.Fatalf("店舗の取得に失敗しました: %v", err)
+	}
+	if got != "Asia/Tokyo" {
+		t.Errorf("default time_zone = %q, want Asia/Tokyo", got)
+	}
+
+	testhelpers.AssertNoError(t, repo.UpdateShopTimeZone(context.Background(), tx, 209, "America/New_York"))
+	if err := tx.QueryRow(`SELECT time_zone FROM shops WHERE shop_id = 209`).Scan(&got); err != nil {
+		t.Fatalf("更新後の店舗取得に失敗しました: %v", err)
+	}
+	if got != "America/New_York" {
+		t.Errorf("time_zone = %q, want America/New_York", got)
+	}
+
+	err := repo.UpdateShopTimeZone(context.Background(), tx, 9999, "Asia/Tokyo")
+	testhelpers.AssertAppError(t, err, apperrors.NoData)
+}
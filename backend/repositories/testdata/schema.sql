@@ -1,3 +1,30 @@
+DROP TABLE IF EXISTS point_transactions;
+DROP TABLE IF EXISTS order_discounts;
+DROP TABLE IF EXISTS promotion_redemptions;
+DROP TABLE IF EXISTS promotion_items;
+
+DROP TRIGGER IF EXISTS trigger_update_promotions_updated_at ON promotions;
+DROP TABLE IF EXISTS promotions;
+
+DROP TABLE IF EXISTS order_tax_lines;
+
+DROP TRIGGER IF EXISTS trigger_update_refund_items_updated_at ON refund_items;
+DROP TABLE IF EXISTS refund_items;
+
+DROP TRIGGER IF EXISTS trigger_update_refunds_updated_at ON refunds;
+DROP TABLE IF EXISTS refunds;
+
+DROP TRIGGER IF EXISTS trigger_update_payments_updated_at ON payments;
+DROP TABLE IF EXISTS payments;
+
+DROP TABLE IF EXISTS order_item_components;
+DROP TABLE IF EXISTS bundle_choices;
+DROP TABLE IF EXISTS bundle_slots;
+
+DROP TABLE IF EXISTS order_item_modifiers;
+DROP TABLE IF EXISTS modifier_options;
+DROP TABLE IF EXISTS modifier_groups;
+
 DROP TRIGGER IF EXISTS trigger_shop_staff_updated_at ON shop_staff;
 DROP TABLE IF EXISTS shop_staff;
 
@@ -7,9 +34,20 @@ DROP TABLE IF EXISTS order_item;
 DROP TRIGGER IF EXISTS trigger_update_orders_updated_at ON orders;
 DROP TABLE IF EXISTS orders;
 
+DROP TABLE IF EXISTS shop_translations;
+DROP TABLE IF EXISTS category_translations;
+DROP TABLE IF EXISTS item_translations;
+DROP TABLE IF EXISTS item_dietary_tags;
+DROP TABLE IF EXISTS item_allergens;
+DROP TABLE IF EXISTS shop_item_price_changes;
+DROP TABLE IF EXISTS item_availability_windows;
+
 DROP TRIGGER IF EXISTS trigger_update_shop_item_updated_at ON shop_item;
 DROP TABLE IF EXISTS shop_item;
 
+DROP TRIGGER IF EXISTS trigger_update_categories_updated_at ON categories;
+DROP TABLE IF EXISTS categories;
+
 DROP TRIGGER IF EXISTS trigger_update_items_updated_at ON items;
 DROP TABLE IF EXISTS items;
 
@@ -147,4 +185,505 @@ CREATE TABLE shop_staff (
 CREATE TRIGGER trigger_shop_staff_updated_at
 BEFORE UPDATE ON shop_staff
 FOR EACH ROW
-EXECUTE FUNCTION update_updated_at_column();
\ No newline at end of file
+EXECUTE FUNCTION update_updated_at_column();
+
+-- 000009_add_shop_coordinates.up.sql
+ALTER TABLE shops
+    ADD COLUMN latitude DOUBLE PRECISION NULL CHECK (latitude BETWEEN -90 AND 90),
+    ADD COLUMN longitude DOUBLE PRECISION NULL CHECK (longitude BETWEEN -180 AND 180);
+
+CREATE INDEX idx_shops_latitude_longitude ON shops (latitude, longitude);
+
+-- 000010_add_prep_times.up.sql
+ALTER TABLE shops
+    ADD COLUMN default_prep_seconds INTEGER NOT NULL DEFAULT 300 CHECK (default_prep_seconds > 0);
+
+ALTER TABLE shop_item
+    ADD COLUMN prep_seconds INTEGER NULL CHECK (prep_seconds > 0);
+
+ALTER TABLE orders
+    ADD COLUMN completed_at TIMESTAMP NULL;
+
+CREATE INDEX idx_orders_shop_status_order_date ON orders (shop_id, status, order_date);
+CREATE INDEX idx_orders_shop_completed_at ON orders (shop_id, completed_at) WHERE completed_at IS NOT NULL;
+
+-- 000011_add_shop_item_stock.up.sql
+ALTER TABLE shop_item
+    ADD COLUMN stock_quantity INTEGER NULL CHECK (stock_quantity >= 0);
+
+-- 000012_create_item_modifiers.up.sql
+CREATE TABLE modifier_groups (
+    modifier_group_id SERIAL PRIMARY KEY,
+    shop_id INT NOT NULL,
+    item_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    selection_type SMALLINT NOT NULL,
+    min_select INT NOT NULL DEFAULT 0,
+    max_select INT NOT NULL DEFAULT 1,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (shop_id, item_id) REFERENCES shop_item(shop_id, item_id) ON DELETE CASCADE,
+    CHECK (selection_type IN (1, 2)),
+    CHECK (min_select >= 0 AND max_select >= 1 AND min_select <= max_select),
+    CHECK (selection_type = 2 OR max_select = 1)
+);
+
+CREATE INDEX idx_modifier_groups_shop_item ON modifier_groups (shop_id, item_id);
+
+CREATE TRIGGER trigger_update_modifier_groups_updated_at
+BEFORE UPDATE ON modifier_groups
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE modifier_options (
+    modifier_option_id SERIAL PRIMARY KEY,
+    modifier_group_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    price_delta INTEGER NOT NULL DEFAULT 0,
+    is_available BOOLEAN NOT NULL DEFAULT TRUE,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (modifier_group_id) REFERENCES modifier_groups(modifier_group_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_modifier_options_group_id ON modifier_options (modifier_group_id);
+
+CREATE TRIGGER trigger_update_modifier_options_updated_at
+BEFORE UPDATE ON modifier_options
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE order_item_modifiers (
+    order_item_modifier_id SERIAL PRIMARY KEY,
+    order_item_id INT NOT NULL,
+    modifier_option_id INT NULL,
+    group_name VARCHAR(255) NOT NULL,
+    option_name VARCHAR(255) NOT NULL,
+    price_delta INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (order_item_id) REFERENCES order_item(order_item_id) ON DELETE CASCADE,
+    FOREIGN KEY (modifier_option_id) REFERENCES modifier_options(modifier_option_id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_order_item_modifiers_order_item_id ON order_item_modifiers (order_item_id);
+
+CREATE TRIGGER trigger_update_order_item_modifiers_updated_at
+BEFORE UPDATE ON order_item_modifiers
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE order_item
+    ADD COLUMN modifier_price_delta INTEGER NOT NULL DEFAULT 0;
+
+-- 000013_add_order_notes.up.sql
+ALTER TABLE orders
+    ADD COLUMN note VARCHAR(200) NULL,
+    ADD COLUMN has_allergy BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE order_item
+    ADD COLUMN note VARCHAR(100) NULL;
+
+-- 000014_create_payments_table.up.sql
+CREATE TABLE payments (
+    payment_id SERIAL PRIMARY KEY,
+    order_id INT NULL,
+    provider VARCHAR(50) NOT NULL,
+    provider_payment_id VARCHAR(255) NULL,
+    amount INTEGER NOT NULL,
+    status SMALLINT NOT NULL DEFAULT 1,
+    failure_reason VARCHAR(255) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE SET NULL,
+    UNIQUE (provider, provider_payment_id),
+    CHECK (amount >= 0),
+    CHECK (status IN (1, 2, 3, 4, 5))
+);
+
+CREATE INDEX idx_payments_order_id ON payments (order_id);
+
+CREATE INDEX idx_orders_pending_payment_order_date ON orders (order_date) WHERE status = 4;
+
+CREATE TRIGGER trigger_update_payments_updated_at
+BEFORE UPDATE ON payments
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- 000015_create_refunds_table.up.sql
+CREATE TABLE refunds (
+    refund_id SERIAL PRIMARY KEY,
+    payment_id INT NOT NULL,
+    order_id INT NULL,
+    amount INTEGER NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    staff_user_id INT NOT NULL,
+    provider_refund_id VARCHAR(255) NULL,
+    status SMALLINT NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (payment_id) REFERENCES payments(payment_id),
+    FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE SET NULL,
+    FOREIGN KEY (staff_user_id) REFERENCES users(user_id),
+    CHECK (amount > 0),
+    CHECK (status IN (1, 2))
+);
+
+CREATE INDEX idx_refunds_order_id ON refunds (order_id);
+CREATE INDEX idx_refunds_payment_id ON refunds (payment_id);
+CREATE INDEX idx_refunds_pending_created_at ON refunds (created_at) WHERE status = 1;
+
+CREATE TRIGGER trigger_update_refunds_updated_at
+BEFORE UPDATE ON refunds
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE refund_items (
+    refund_item_id SERIAL PRIMARY KEY,
+    refund_id INT NOT NULL,
+    order_item_id INT NULL,
+    quantity INT NOT NULL,
+    amount INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (refund_id) REFERENCES refunds(refund_id) ON DELETE CASCADE,
+    FOREIGN KEY (order_item_id) REFERENCES order_item(order_item_id) ON DELETE SET NULL,
+    CHECK (quantity > 0),
+    CHECK (amount >= 0)
+);
+
+CREATE INDEX idx_refund_items_order_item_id ON refund_items (order_item_id);
+
+CREATE TRIGGER trigger_update_refund_items_updated_at
+BEFORE UPDATE ON refund_items
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE payments
+    ADD COLUMN refunded_amount INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT payments_refunded_amount_check CHECK (refunded_amount >= 0 AND refunded_amount <= amount);
+
+-- 000016_add_consumption_tax.up.sql
+ALTER TABLE items
+    ADD COLUMN tax_category SMALLINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT items_tax_category_check CHECK (tax_category IN (1, 2));
+
+ALTER TABLE orders
+    ADD COLUMN dining_option SMALLINT NOT NULL DEFAULT 1,
+    ADD COLUMN subtotal_amount INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN tax_amount INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT orders_dining_option_check CHECK (dining_option IN (1, 2));
+
+UPDATE orders SET subtotal_amount = total_amount;
+
+ALTER TABLE order_item
+    ADD COLUMN tax_rate SMALLINT NOT NULL DEFAULT 0;
+
+CREATE TABLE order_tax_lines (
+    order_id INT NOT NULL,
+    tax_rate SMALLINT NOT NULL,
+    taxable_amount INTEGER NOT NULL,
+    tax_amount INTEGER NOT NULL,
+    PRIMARY KEY (order_id, tax_rate),
+    FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE CASCADE
+);
+
+-- 000017_add_invoice_registration_number.up.sql
+ALTER TABLE shops
+    ADD COLUMN invoice_registration_number VARCHAR(14),
+    ADD CONSTRAINT shops_invoice_registration_number_check CHECK (invoice_registration_number ~ '^T[0-9]{13}$');
+
+-- 000018_create_promotions.up.sql
+CREATE TABLE promotions (
+    promotion_id SERIAL PRIMARY KEY,
+    shop_id INT NULL,
+    code VARCHAR(32) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    discount_type SMALLINT NOT NULL,
+    discount_value INTEGER NOT NULL,
+    min_spend INTEGER NOT NULL DEFAULT 0,
+    min_item_quantity INTEGER NOT NULL DEFAULT 0,
+    max_discounted_units INTEGER NULL,
+    starts_at TIMESTAMP NULL,
+    ends_at TIMESTAMP NULL,
+    usage_limit_total INTEGER NULL,
+    usage_limit_per_user INTEGER NULL,
+    used_count INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (shop_id) REFERENCES shops(shop_id) ON DELETE CASCADE,
+    CONSTRAINT promotions_discount_check CHECK (
+        (discount_type = 1 AND discount_value BETWEEN 1 AND 100) OR
+        (discount_type = 2 AND discount_value > 0)
+    ),
+    CHECK (min_spend >= 0),
+    CHECK (min_item_quantity >= 0),
+    CHECK (max_discounted_units IS NULL OR max_discounted_units > 0),
+    CHECK (starts_at IS NULL OR ends_at IS NULL OR starts_at < ends_at),
+    CHECK (usage_limit_per_user IS NULL OR usage_limit_per_user > 0),
+    CONSTRAINT promotions_used_count_check CHECK (used_count >= 0 AND (usage_limit_total IS NULL OR used_count <= usage_limit_total))
+);
+
+CREATE UNIQUE INDEX idx_promotions_shop_code ON promotions (COALESCE(shop_id, 0), UPPER(code));
+
+CREATE TRIGGER trigger_update_promotions_updated_at
+BEFORE UPDATE ON promotions
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE promotion_items (
+    promotion_id INT NOT NULL,
+    item_id INT NOT NULL,
+    PRIMARY KEY (promotion_id, item_id),
+    FOREIGN KEY (promotion_id) REFERENCES promotions(promotion_id) ON DELETE CASCADE,
+    FOREIGN KEY (item_id) REFERENCES items(item_id) ON DELETE CASCADE
+);
+
+CREATE TABLE promotion_redemptions (
+    redemption_id SERIAL PRIMARY KEY,
+    promotion_id INT NOT NULL,
+    order_id INT NOT NULL UNIQUE,
+    user_id INT NULL,
+    discount_amount INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (promotion_id) REFERENCES promotions(promotion_id) ON DELETE CASCADE,
+    FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE SET NULL,
+    CHECK (discount_amount > 0)
+);
+
+CREATE INDEX idx_promotion_redemptions_promotion_user ON promotion_redemptions (promotion_id, user_id);
+
+CREATE TABLE order_discounts (
+    order_id INT NOT NULL,
+    tax_rate SMALLINT NOT NULL,
+    promotion_id INT NULL,
+    code VARCHAR(32) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    amount INTEGER NOT NULL,
+    PRIMARY KEY (order_id, tax_rate),
+    FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE CASCADE,
+    FOREIGN KEY (promotion_id) REFERENCES promotions(promotion_id) ON DELETE SET NULL,
+    CHECK (amount > 0)
+);
+
+ALTER TABLE orders
+    ADD COLUMN discount_amount INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT orders_discount_amount_check CHECK (discount_amount >= 0 AND discount_amount <= subtotal_amount);
+
+-- 000019_create_point_transactions.up.sql
+ALTER TABLE shops
+    ADD COLUMN point_rate SMALLINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT shops_point_rate_check CHECK (point_rate BETWEEN 0 AND 100);
+
+CREATE TABLE point_transactions (
+    point_transaction_id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    order_id INT NULL,
+    shop_id INT NULL,
+    kind SMALLINT NOT NULL,
+    points INTEGER NOT NULL,
+    remaining INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    FOREIGN KEY (order_id) REFERENCES orders(order_id) ON DELETE SET NULL,
+    FOREIGN KEY (shop_id) REFERENCES shops(shop_id) ON DELETE SET NULL,
+    CHECK (kind BETWEEN 1 AND 4),
+    CONSTRAINT point_transactions_points_check CHECK (
+        (kind IN (1, 4) AND points > 0 AND remaining BETWEEN 0 AND points AND expires_at IS NOT NULL) OR
+        (kind IN (2, 3) AND points < 0 AND remaining = 0)
+    )
+);
+
+CREATE UNIQUE INDEX idx_point_transactions_order_kind ON point_transactions (order_id, kind) WHERE order_id IS NOT NULL;
+CREATE INDEX idx_point_transactions_user_created ON point_transactions (user_id, created_at DESC);
+CREATE INDEX idx_point_transactions_user_lots ON point_transactions (user_id, expires_at) WHERE remaining > 0;
+
+ALTER TABLE order_discounts
+    ADD COLUMN source SMALLINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT order_discounts_source_check CHECK (source IN (1, 2)),
+    DROP CONSTRAINT order_discounts_pkey,
+    ADD PRIMARY KEY (order_id, source, tax_rate);
+
+-- 000020_add_orders_handed_at.up.sql
+ALTER TABLE orders
+    ADD COLUMN handed_at TIMESTAMP NULL;
+
+CREATE INDEX idx_orders_shop_handed_at ON orders (shop_id, handed_at) WHERE handed_at IS NOT NULL;
+
+-- 000021_create_categories.up.sql
+CREATE TABLE categories (
+    category_id SERIAL PRIMARY KEY,
+    shop_id INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (shop_id, name),
+    FOREIGN KEY (shop_id) REFERENCES shops(shop_id) ON DELETE CASCADE,
+    CHECK (sort_order >= 0)
+);
+
+CREATE TRIGGER trigger_update_categories_updated_at
+BEFORE UPDATE ON categories
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE shop_item
+    ADD COLUMN category_id INT NULL REFERENCES categories(category_id) ON DELETE SET NULL;
+
+CREATE INDEX idx_shop_item_category_id ON shop_item (category_id);
+
+-- 000022_add_items_image.up.sql
+ALTER TABLE items
+    ADD COLUMN image_key VARCHAR(255) NULL,
+    ADD COLUMN thumbnail_key VARCHAR(255) NULL;
+
+-- 000023_create_item_availability_windows.up.sql
+ALTER TABLE shops
+    ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT 'Asia/Tokyo';
+
+CREATE TABLE item_availability_windows (
+    window_id SERIAL PRIMARY KEY,
+    shop_item_id INT NOT NULL,
+    day_of_week SMALLINT NOT NULL,
+    start_minute INT NOT NULL,
+    end_minute INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (shop_item_id) REFERENCES shop_item(shop_item_id) ON DELETE CASCADE,
+    CHECK (day_of_week BETWEEN 0 AND 6),
+    CHECK (start_minute >= 0 AND start_minute < end_minute AND end_minute <= 1440)
+);
+
+CREATE INDEX idx_item_availability_windows_shop_item_id ON item_availability_windows (shop_item_id);
+
+-- 000024_create_shop_item_price_changes.up.sql
+-- 店舗ごとの価格の変更履歴と予定。店舗での価格は、effective_at を過ぎた最も新しい変更の price（変更がなければ items.price）になる。
+-- price がNULLの変更は店舗ごとの価格をやめて items.price に戻す
+CREATE TABLE shop_item_price_changes (
+    price_change_id SERIAL PRIMARY KEY,
+    shop_item_id INT NOT NULL,
+    price INT NULL,
+    effective_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (shop_item_id) REFERENCES shop_item(shop_item_id) ON DELETE CASCADE,
+    CHECK (price IS NULL OR price >= 0)
+);
+
+CREATE INDEX idx_shop_item_price_changes_shop_item_id ON shop_item_price_changes (shop_item_id, effective_at);
+
+-- 000025_create_item_bundles.up.sql
+-- セットメニュー。枠（bundle_slots）を持つ商品はセット商品になり、セットの価格はセット商品のitems.price（店舗ごとの価格）
+-- 選択肢が1つの枠は固定の構成商品、複数の枠はお客様が1つ選ぶ。quantityはセット1つあたりの構成商品の数
+-- 枠は店舗ごとに設定する。同じ商品を扱う他の店舗のセットを変えないよう、店舗の商品（shop_item）に紐づける
+CREATE TABLE bundle_slots (
+    bundle_slot_id SERIAL PRIMARY KEY,
+    shop_id INT NOT NULL,
+    item_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    quantity INT NOT NULL DEFAULT 1,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (shop_id, item_id) REFERENCES shop_item(shop_id, item_id) ON DELETE CASCADE,
+    CHECK (quantity >= 1)
+);
+
+CREATE INDEX idx_bundle_slots_shop_item ON bundle_slots (shop_id, item_id);
+
+-- 枠の選択肢となる構成商品。price_deltaはセット価格への加算額（値引きは負の値）
+CREATE TABLE bundle_choices (
+    bundle_choice_id SERIAL PRIMARY KEY,
+    bundle_slot_id INT NOT NULL,
+    item_id INT NOT NULL,
+    price_delta INTEGER NOT NULL DEFAULT 0,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (bundle_slot_id) REFERENCES bundle_slots(bundle_slot_id) ON DELETE CASCADE,
+    FOREIGN KEY (item_id) REFERENCES items(item_id) ON DELETE CASCADE,
+    UNIQUE (bundle_slot_id, item_id)
+);
+
+CREATE INDEX idx_bundle_choices_item_id ON bundle_choices (item_id);
+
+-- 注文したセットの構成商品。メニュー変更の影響を受けないよう枠と商品の名前、加算額を保存する。
+-- quantityはセット1つあたりの数で、在庫数はorder_item.quantityを掛けた数だけ減らす
+CREATE TABLE order_item_components (
+    order_item_component_id SERIAL PRIMARY KEY,
+    order_item_id INT NOT NULL,
+    bundle_choice_id INT NULL,
+    item_id INT NOT NULL,
+    slot_name VARCHAR(255) NOT NULL,
+    item_name VARCHAR(255) NOT NULL,
+    quantity INT NOT NULL,
+    price_delta INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (order_item_id) REFERENCES order_item(order_item_id) ON DELETE CASCADE,
+    FOREIGN KEY (bundle_choice_id) REFERENCES bundle_choices(bundle_choice_id) ON DELETE SET NULL,
+    FOREIGN KEY (item_id) REFERENCES items(item_id)
+);
+
+CREATE INDEX idx_order_item_components_order_item_id ON order_item_components (order_item_id);
+
+-- 000026_create_item_dietary_info.up.sql
+-- 商品に含まれるアレルゲン。表示が義務付けられている特定原材料の8品目を英語のコードで登録する
+-- （shrimp=えび, crab=かに, walnut=くるみ, wheat=小麦, buckwheat=そば, egg=卵, milk=乳, peanut=落花生）
+CREATE TABLE item_allergens (
+    item_id INT NOT NULL,
+    allergen VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (item_id, allergen),
+    FOREIGN KEY (item_id) REFERENCES items(item_id) ON DELETE CASCADE,
+    CHECK (allergen IN ('shrimp', 'crab', 'walnut', 'wheat', 'buckwheat', 'egg', 'milk', 'peanut'))
+);
+
+-- 商品が対応している食事の制限（ベジタリアン・ハラールなど）
+CREATE TABLE item_dietary_tags (
+    item_id INT NOT NULL,
+    tag VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (item_id, tag),
+    FOREIGN KEY (item_id) REFERENCES items(item_id) ON DELETE CASCADE,
+    CHECK (tag IN ('vegetarian', 'vegan', 'halal', 'gluten_free'))
+);
+
+-- 000027_create_translations.up.sql
+-- メニューと店舗の翻訳。元の内容は日本語で items・categories・shops に登録し、言語ごとの翻訳をここに登録する。
+-- 翻訳のない言語や項目（説明がNULL）は日本語で表示する
+CREATE TABLE item_translations (
+    item_id INT NOT NULL,
+    lang VARCHAR(8) NOT NULL,
+    item_name VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (item_id, lang),
+    FOREIGN KEY (item_id) REFERENCES items(item_id) ON DELETE CASCADE,
+    CHECK (lang IN ('en', 'zh', 'ko'))
+);
+
+CREATE TABLE category_translations (
+    category_id INT NOT NULL,
+    lang VARCHAR(8) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (category_id, lang),
+    FOREIGN KEY (category_id) REFERENCES categories(category_id) ON DELETE CASCADE,
+    CHECK (lang IN ('en', 'zh', 'ko'))
+);
+
+CREATE TABLE shop_translations (
+    shop_id INT NOT NULL,
+    lang VARCHAR(8) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (shop_id, lang),
+    FOREIGN KEY (shop_id) REFERENCES shops(shop_id) ON DELETE CASCADE,
+    CHECK (lang IN ('en', 'zh', 'ko'))
+);
@@ -0,0 +1,218 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type TranslationRepository interface {
+	FindItemTranslations(ctx context.Context, dbtx DBTX, lang string, itemIDs []int) (map[int]models.ItemTranslation, error)
+	FindCategoryTranslations(ctx context.Context, dbtx DBTX, lang string, categoryIDs []int) (map[int]models.CategoryTranslation, error)
+	FindShopTranslations(ctx context.Context, dbtx DBTX, lang string, shopIDs []int) (map[int]models.ShopTranslation, error)
+	UpsertItemTranslation(ctx context.Context, dbtx DBTX, shopID int, translation *models.ItemTranslation) error
+	DeleteItemTranslation(ctx context.Context, dbtx DBTX, shopID int, itemID int, lang string) error
+	UpsertCategoryTranslation(ctx context.Context, dbtx DBTX, shopID int, translation *models.CategoryTranslation) error
+	DeleteCategoryTranslation(ctx context.Context, dbtx DBTX, shopID int, categoryID int, lang string) error
+	UpsertShopTranslation(ctx context.Context, dbtx DBTX, translation *models.ShopTranslation) error
+	DeleteShopTranslation(ctx context.Context, dbtx DBTX, shopID int, lang string) error
+}
+
+type translationRepository struct{}
+
+func NewTranslationRepository() TranslationRepository {
+	return &translationRepository{}
+}
+
+// FindItemTranslations は商品の指定した言語の翻訳を取得します。翻訳のない商品は結果に含まれません
+func (r *translationRepository) FindItemTranslations(ctx context.Context, dbtx DBTX, lang string, itemIDs []int) (map[int]models.ItemTranslation, error) {
+	translations := make(map[int]models.ItemTranslation)
+	if len(itemIDs) == 0 {
+		return translations, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT item_id, lang, item_name, description, updated_at
+		FROM item_translations
+		WHERE lang = ? AND item_id IN (?)
+	`, lang, itemIDs)
+	if err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "データベースクエリの構築に失敗しました。")
+	}
+	query = dbtx.Rebind(query)
+
+	var rows []models.ItemTranslation
+	if err := dbtx.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "商品の翻訳の取得に失敗しました。")
+	}
+	for _, t := range rows {
+		translations[t.ItemID] = t
+	}
+	return translations, nil
+}
+
+// FindCategoryTranslations はカテゴリの指定した言語の翻訳を取得します。翻訳のないカテゴリは結果に含まれません
+func (r *translationRepository) FindCategoryTranslations(ctx context.Context, dbtx DBTX, lang string, categoryIDs []int) (map[int]models.CategoryTranslation, error) {
+	translations := make(map[int]models.CategoryTranslation)
+	if len(categoryIDs) == 0 {
+		return translations, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT category_id, lang, name, updated_at
+		FROM category_translations
+		WHERE lang = ? AND category_id IN (?)
+	`, lang, categoryIDs)
+	if err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "データベースクエリの構築に失敗しました。")
+	}
+	query = dbtx.Rebind(query)
+
+	var rows []models.CategoryTranslation
+	if err := dbtx.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "カテゴリの翻訳の取得に失敗しました。")
+	}
+	for _, t := range rows {
+		translations[t.CategoryID] = t
+	}
+	return translations, nil
+}
+
+// FindShopTranslations は店舗の指定した言語の翻訳を取得します。翻訳のない店舗は結果に含まれません
+func (r *translationRepository) FindShopTranslations(ctx context.Context, dbtx DBTX, lang string, shopIDs []int) (map[int]models.ShopTranslation, error) {
+	translations := make(map[int]models.ShopTranslation)
+	if len(shopIDs) == 0 {
+		return translations, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT shop_id, lang, name, description, updated_at
+		FROM shop_translations
+		WHERE lang = ? AND shop_id IN (?)
+	`, lang, shopIDs)
+	if err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "データベースクエリの構築に失敗しました。")
+	}
+	query = dbtx.Rebind(query)
+
+	var rows []models.ShopTranslation
+	if err := dbtx.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, apperrors.GetDataFailed.Wrap(err, "店舗の翻訳の取得に失敗しました。")
+	}
+	for _, t := range rows {
+		translations[t.ShopID] = t
+	}
+	return translations, nil
+}
+
+// UpsertItemTranslation は店舗で扱っている商品の翻訳を登録し、同じ言語の翻訳があれば置き換えます。
+// 商品の翻訳は全店舗共通です。店舗の商品でなければNoDataを返します
+func (r *translationRepository) UpsertItemTranslation(ctx context.Context, dbtx DBTX, shopID int, translation *models.ItemTranslation) error {
+	query := `
+		INSERT INTO item_translations (item_id, lang, item_name, description)
+		SELECT $1, $2, $3, $4
+		WHERE EXISTS (SELECT 1 FROM shop_item WHERE shop_id = $5 AND item_id = $1)
+		ON CONFLICT (item_id, lang) DO UPDATE
+		SET item_name = EXCLUDED.item_name, description = EXCLUDED.description, updated_at = NOW()
+		RETURNING updated_at
+	`
+	err := dbtx.QueryRowxContext(ctx, query, translation.ItemID, translation.Lang, translation.ItemName, translation.Description, shopID).
+		Scan(&translation.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperrors.NoData.WrapMessage(err, apperrors.MsgItemNotInShop, nil)
+		}
+		return apperrors.InsertDataFailed.Wrap(err, "商品の翻訳の登録に失敗しました。")
+	}
+	return nil
+}
+
+// DeleteItemTranslation は店舗で扱っている商品の翻訳を削除します。翻訳がなければNoDataを返します
+func (r *translationRepository) DeleteItemTranslation(ctx context.Context, dbtx DBTX, shopID int, itemID int, lang string) error {
+	query := `
+		DELETE FROM item_translations
+		WHERE item_id = $1 AND lang = $2
+		AND EXISTS (SELECT 1 FROM shop_item WHERE shop_id = $3 AND item_id = $1)
+	`
+	return execTranslationDelete(ctx, dbtx, "商品の翻訳", query, itemID, lang, shopID)
+}
+
+// UpsertCategoryTranslation は店舗のカテゴリの翻訳を登録し、同じ言語の翻訳があれば置き換えます。
+// 店舗のカテゴリでなければNoDataを返します
+func (r *translationRepository) UpsertCategoryTranslation(ctx context.Context, dbtx DBTX, shopID int, translation *models.CategoryTranslation) error {
+	query := `
+		INSERT INTO category_translations (category_id, lang, name)
+		SELECT $1, $2, $3
+		WHERE EXISTS (SELECT 1 FROM categories WHERE category_id = $1 AND shop_id = $4)
+		ON CONFLICT (category_id, lang) DO UPDATE
+		SET name = EXCLUDED.name, updated_at = NOW()
+		RETURNING updated_at
+	`
+	err := dbtx.QueryRowxContext(ctx, query, translation.CategoryID, translation.Lang, translation.Name, shopID).
+		Scan(&translation.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperrors.NoData.Wrap(err, "指定されたカテゴリが見つかりませんでした。")
+		}
+		return apperrors.InsertDataFailed.Wrap(err, "カテゴリの翻訳の登録に失敗しました。")
+	}
+	return nil
+}
+
+// DeleteCategoryTranslation は店舗のカテゴリの翻訳を削除します。翻訳がなければNoDataを返します
+func (r *translationRepository) DeleteCategoryTranslation(ctx context.Context, dbtx DBTX, shopID int, categoryID int, lang string) error {
+	query := `
+		DELETE FROM category_translations
+		WHERE category_id = $1 AND lang = $2
+		AND EXISTS (SELECT 1 FROM categories WHERE category_id = $1 AND shop_id = $3)
+	`
+	return execTranslationDelete(ctx, dbtx, "カテゴリの翻訳", query, categoryID, lang, shopID)
+}
+
+// UpsertShopTranslation は店舗の翻訳を登録し、同じ言語の翻訳があれば置き換えます。店舗がなければNoDataを返します
+func (r *translationRepository) UpsertShopTranslation(ctx context.Context, dbtx DBTX, translation *models.ShopTranslation) error {
+	query := `
+		INSERT INTO shop_translations (shop_id, lang, name, description)
+		SELECT $1, $2, $3, $4
+		WHERE EXISTS (SELECT 1 FROM shops WHERE shop_id = $1)
+		ON CONFLICT (shop_id, lang) DO UPDATE
+		SET name = EXCLUDED.name, description = EXCLUDED.description, updated_at = NOW()
+		RETURNING updated_at
+	`
+	err := dbtx.QueryRowxContext(ctx, query, translation.ShopID, translation.Lang, translation.Name, translation.Description).
+		Scan(&translation.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperrors.NoData.WrapMessage(err, apperrors.MsgShopNotFound, nil)
+		}
+		return apperrors.InsertDataFailed.Wrap(err, "店舗の翻訳の登録に失敗しました。")
+	}
+	return nil
+}
+
+// DeleteShopTranslation は店舗の翻訳を削除します。翻訳がなければNoDataを返します
+func (r *translationRepository) DeleteShopTranslation(ctx context.Context, dbtx DBTX, shopID int, lang string) error {
+	query := `DELETE FROM shop_translations WHERE shop_id = $1 AND lang = $2`
+	return execTranslationDelete(ctx, dbtx, "店舗の翻訳", query, shopID, lang)
+}
+
+// execTranslationDelete は翻訳を削除するクエリを実行し、削除した行がなければNoDataを返します
+func execTranslationDelete(ctx context.Context, dbtx DBTX, target string, query string, args ...any) error {
+	result, err := dbtx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return apperrors.DeleteDataFailed.Wrapf(err, "%sの削除に失敗しました。", target)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.DeleteDataFailed.Wrap(err, "削除結果の確認に失敗しました。")
+	}
+	if rowsAffected == 0 {
+		return apperrors.NoData.Wrapf(nil, "指定された%sが見つかりませんでした。", target)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTranslationRepository(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			t.Logf("transaction rollback failed: %v", err)
+		}
+	}()
+
+	setupItemRepositoryTestData(t, tx)
+	repo := repositories.NewTranslationRepository()
+
+	description := "Rich pork bone broth"
+	ramen := &models.ItemTranslation{ItemID: itemTestItemID1, Lang: "en", ItemName: "Ramen", Description: &description}
+	testhelpers.AssertNoError(t, repo.UpsertItemTranslation(ctx, tx, itemTestShopID1, ramen))
+	if ramen.UpdatedAt.IsZero() {
+		t.Error("UpdatedAt is not set")
+	}
+	// 同じ言語で登録すると置き換わる
+	testhelpers.AssertNoError(t, repo.UpsertItemTranslation(ctx, tx, itemTestShopID1, &models.ItemTranslation{ItemID: itemTestItemID1, Lang: "en", ItemName: "Tonkotsu Ramen"}))
+	testhelpers.AssertNoError(t, repo.UpsertItemTranslation(ctx, tx, itemTestShopID1, &models.ItemTranslation{ItemID: itemTestItemID1, Lang: "ko", ItemName: "라멘"}))
+
+	// 他の店舗の商品には登録できない
+	err := repo.UpsertItemTranslation(ctx, tx, itemTestShopID1, &models.ItemTranslation{ItemID: itemTestItemID3, Lang: "en", ItemName: "Gyoza"})
+	testhelpers.AssertAppError(t, err, apperrors.NoData)
+
+	items, err := repo.FindItemTranslations(ctx, tx, "en", []int{itemTestItemID1, itemTestItemID2})
+	testhelpers.AssertNoError(t, err)
+	if len(items) != 1 || items[itemTestItemID1].ItemName != "Tonkotsu Ramen" || items[itemTestItemID1].Description != nil {
+		t.Errorf("item translations = %+v", items)
+	}
+
+	testhelpers.AssertNoError(t, repo.DeleteItemTranslation(ctx, tx, itemTestShopID1, itemTestItemID1, "en"))
+	testhelpers.AssertAppError(t, repo.DeleteItemTranslation(ctx, tx, itemTestShopID1, itemTestItemID1, "en"), apperrors.NoData)
+	items, err = repo.FindItemTranslations(ctx, tx, "ko", []int{itemTestItemID1})
+	testhelpers.AssertNoError(t, err)
+	if items[itemTestItemID1].ItemName != "라멘" {
+		t.Errorf("ko translation = %+v, want it to remain", items)
+	}
+
+	// カテゴリは店舗のカテゴリだけに登録できる
+	categoryRepo := repositories.NewCategoryRepository()
+	category := &models.Category{ShopID: itemTestShopID1, Name: "ラーメン", SortOrder: 1}
+	testhelpers.AssertNoError(t, categoryRepo.CreateCategory(ctx, tx, category))
+	testhelpers.AssertNoError(t, repo.UpsertCategoryTranslation(ctx, tx, itemTestShopID1, &models.CategoryTranslation{CategoryID: category.CategoryID, Lang: "zh", Name: "拉面"}))
+	err = repo.UpsertCategoryTranslation(ctx, tx, itemTestShopID2, &models.CategoryTranslation{CategoryID: category.CategoryID, Lang: "en", Name: "Ramen"})
+	testhelpers.AssertAppError(t, err, apperrors.NoData)
+	testhelpers.AssertAppError(t, repo.DeleteCategoryTranslation(ctx, tx, itemTestShopID2, category.CategoryID, "zh"), apperrors.NoData)
+
+	categories, err := repo.FindCategoryTranslations(ctx, tx, "zh", []int{category.CategoryID})
+	testhelpers.AssertNoError(t, err)
+	if categories[category.CategoryID].Name != "拉面" {
+		t.Errorf("category translations = %+v", categories)
+	}
+	testhelpers.AssertNoError(t, repo.DeleteCategoryTranslation(ctx, tx, itemTestShopID1, category.CategoryID, "zh"))
+
+	shopDescription := "Famous for our double soup"
+	testhelpers.AssertNoError(t, repo.UpsertShopTranslation(ctx, tx, &models.ShopTranslation{ShopID: itemTestShopID1, Lang: "en", Name: "Ichibanboshi", Description: &shopDescription}))
+	testhelpers.AssertAppError(t, repo.UpsertShopTranslation(ctx, tx, &models.ShopTranslation{ShopID: 999999, Lang: "en", Name: "None"}), apperrors.NoData)
+
+	shops, err := repo.FindShopTranslations(ctx, tx, "en", []int{itemTestShopID1, itemTestShopID2})
+	testhelpers.AssertNoError(t, err)
+	gotNames := map[int]string{}
+	for id, shop := range shops {
+		gotNames[id] = shop.Name
+	}
+	if diff := cmp.Diff(map[int]string{itemTestShopID1: "Ichibanboshi"}, gotNames); diff != "" {
+		t.Errorf("shop translations mismatch (-want +got):\n%s", diff)
+	}
+	testhelpers.AssertNoError(t, repo.DeleteShopTranslation(ctx, tx, itemTestShopID1, "en"))
+	testhelpers.AssertAppError(t, repo.DeleteShopTranslation(ctx, tx, itemTestShopID1, "en"), apperrors.NoData)
+
+	// IDがなければ問い合わせない
+	empty, err := repo.FindItemTranslations(ctx, tx, "en", nil)
+	testhelpers.AssertNoError(t, err)
+	if len(empty) != 0 {
+		t.Errorf("empty = %+v", empty)
+	}
+}
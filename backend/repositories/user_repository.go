@@ -32,7 +32,7 @@ func (r *userRepository) CreateUser(ctx context.Context, dbtx DBTX, user *models
 	)
 	if err != nil {
 		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == "23505" {
-			return apperrors.Conflict.Wrap(err, "このメールアドレスは既に使用されています。")
+			return apperrors.Conflict.WrapMessage(err, apperrors.MsgEmailInUse, nil)
 		}
 		return apperrors.InsertDataFailed.Wrap(err, "ユーザーの作成に失敗しました。")
 	}
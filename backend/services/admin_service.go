@@ -2,6 +2,9 @@ package services
 
 import (
 	"context"
+	"errors"
+	"sort"
+	"time"
 
 	"github.com/A4-dev-team/mobileorder.git/apperrors"
 	"github.com/A4-dev-team/mobileorder.git/models"
@@ -15,18 +18,31 @@ type AdminServicer interface {
 	UpdateOrderStatus(ctx context.Context, adminShopID int, targetOrderID int) error
 	UpdateItemAvailability(ctx context.Context, itemID int, isAvailable bool) error
 	DeleteOrder(ctx context.Context, adminShopID int, targetOrderID int) error
+	UpdateItemPrepTime(ctx context.Context, adminShopID int, itemID int, prepSeconds *int) error
+	UpdateItemStock(ctx context.Context, adminShopID int, itemID int, stockQuantity *int) error
+	CreateModifierGroup(ctx context.Context, adminShopID int, itemID int, req models.CreateModifierGroupRequest) (*models.ModifierGroupResponse, error)
+	DeleteModifierGroup(ctx context.Context, adminShopID int, modifierGroupID int) error
+	UpdateItemAvailabilitySchedule(ctx context.Context, adminShopID int, itemID int, req models.UpdateItemAvailabilityScheduleRequest) (*models.ItemAvailabilityScheduleResponse, error)
+	UpdateItemBundle(ctx context.Context, adminShopID int, itemID int, req models.UpdateItemBundleRequest) (*models.ItemBundleResponse, error)
+	UpdateItemDietaryInfo(ctx context.Context, adminShopID int, itemID int, req models.UpdateItemDietaryInfoRequest) (*models.ItemDietaryInfoResponse, error)
 }
 
 type adminService struct {
 	orr repositories.OrderRepository
 	itr repositories.ItemRepository
+	ptr repositories.PointRepository
+	pyr repositories.PaymentRepository
+	prr repositories.PromotionRepository
 	db  *sqlx.DB
 }
 
-func NewAdminService(orr repositories.OrderRepository, itr repositories.ItemRepository, db *sqlx.DB) AdminServicer {
+func NewAdminService(orr repositories.OrderRepository, itr repositories.ItemRepository, ptr repositories.PointRepository, pyr repositories.PaymentRepository, prr repositories.PromotionRepository, db *sqlx.DB) AdminServicer {
 	return &adminService{
 		orr: orr,
 		itr: itr,
+		ptr: ptr,
+		pyr: pyr,
+		prr: prr,
 		db:  db,
 	}
 }
@@ -69,13 +85,24 @@ func (s *adminService) assembleAdminOrderResponses(ctx context.Context, dbOrders
 			emailPtr = &dbOrder.CustomerEmail.String
 		}
 
+		var notePtr *string
+		if dbOrder.Note.Valid {
+			notePtr = &dbOrder.Note.String
+		}
+
+		items := itemsMap[dbOrder.OrderID]
 		responses[i] = models.AdminOrderResponse{
-			OrderID:       dbOrder.OrderID,
-			CustomerEmail: emailPtr,
-			OrderDate:     dbOrder.OrderDate,
-			TotalAmount:   dbOrder.TotalAmount,
-			Status:        dbOrder.Status.String(),
-			Items:         itemsMap[dbOrder.OrderID],
+			OrderID:        dbOrder.OrderID,
+			CustomerEmail:  emailPtr,
+			OrderDate:      dbOrder.OrderDate,
+			TotalAmount:    dbOrder.TotalAmount,
+			Status:         dbOrder.Status.String(),
+			Items:          items,
+			Note:           notePtr,
+			HasAllergy:     dbOrder.HasAllergy,
+			NeedsAttention: dbOrder.HasAllergy || notePtr != nil || hasItemNote(items),
+			RefundedAmount: dbOrder.RefundedAmount,
+			DiningOption:   dbOrder.DiningOption.String(),
 		}
 	}
 	return responses, nil
@@ -113,13 +140,21 @@ func (s *adminService) UpdateOrderStatus(ctx context.Context, adminShopID int, t
 	case models.Completed:
 		nextStatus = models.Handed
 	default:
-		return apperrors.Conflict.Wrapf(nil, "ステータスが'%s'の注文はこれ以上進められません。", currentOrder.Status.String())
+		return apperrors.Conflict.WrapMessage(nil, apperrors.MsgOrderStatusFinal, apperrors.Params{"status": currentOrder.Status.String()})
+	}
+
+	if err = s.orr.UpdateOrderStatus(ctx, tx, targetOrderID, adminShopID, nextStatus); err != nil {
+		return err
 	}
 
-	return s.orr.UpdateOrderStatus(ctx, tx, targetOrderID, adminShopID, nextStatus)
+	// お渡しした時点で、ログインして注文したユーザーにポイントを付与する
+	if nextStatus == models.Handed {
+		_, err = s.ptr.CreditPointsForOrder(ctx, tx, targetOrderID, pointExpiresAt(time.Now()))
+	}
+	return err
 }
 
-func (s *adminService) DeleteOrder(ctx context.Context, adminShopID int, targetOrderID int) error {
+func (s *adminService) DeleteOrder(ctx context.Context, adminShopID int, targetOrderID int) (err error) {
 	tx, err := s.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return apperrors.Unknown.Wrap(err, "トランザクションの開始に失敗しました。")
@@ -138,16 +173,276 @@ func (s *adminService) DeleteOrder(ctx context.Context, adminShopID int, targetO
 		}
 	}()
 
-	// 注文の存在確認と削除を同一トランザクション内で実行
-	_, err = s.orr.FindOrderByIDAndShopID(ctx, tx, targetOrderID, adminShopID)
+	// 注文の存在確認と削除を同一トランザクション内で実行する。
+	// 同時の削除や決済の取り消しが在庫やポイントを二重に戻さないよう、注文の行をロックしてから状態を見る
+	currentOrder, err := s.orr.FindOrderByIDAndShopIDForUpdate(ctx, tx, targetOrderID, adminShopID)
 	if err != nil {
 		return err
 	}
+	if err = s.ensureNoUnrefundedPayment(ctx, tx, targetOrderID); err != nil {
+		return err
+	}
 
-	return s.orr.DeleteOrderByIDAndShopID(ctx, tx, targetOrderID, adminShopID)
+	// お渡し前に取り消された注文の商品は在庫に、使ったポイントは残高に戻し、クーポンの利用も取り消す（決済の失敗で取り消した注文は戻し済み）
+	if currentOrder.Status != models.Handed && currentOrder.Status != models.Cancelled {
+		if err = s.itr.RestockOrderItems(ctx, tx, adminShopID, targetOrderID); err != nil {
+			return err
+		}
+		if err = s.prr.ReleaseRedemptionByOrderID(ctx, tx, targetOrderID); err != nil {
+			return err
+		}
+		if err = s.ptr.RestorePointsByOrderID(ctx, tx, targetOrderID); err != nil {
+			return err
+		}
+	}
+
+	err = s.orr.DeleteOrderByIDAndShopID(ctx, tx, targetOrderID, adminShopID)
+	return err
+}
+
+// ensureNoUnrefundedPayment は注文に売上が確定したまま返金していない支払いがあればConflictを返します。
+// 削除すると決済の order_id がNULLになり、返金できなくなるため、先に返金してもらう。
+func (s *adminService) ensureNoUnrefundedPayment(ctx context.Context, dbtx repositories.DBTX, orderID int) error {
+	payment, err := s.pyr.FindLatestPaymentByOrderIDForUpdate(ctx, dbtx, orderID)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) && appErr.ErrCode == apperrors.NoData {
+			return nil
+		}
+		return err
+	}
+
+	switch {
+	case payment.Status == models.PaymentCapturing:
+		return apperrors.Conflict.WrapMessage(nil, apperrors.MsgOrderPaymentInProgress, nil)
+	case payment.Status == models.PaymentCaptured && payment.RefundedAmount < payment.Amount:
+		return apperrors.Conflict.WrapMessage(nil, apperrors.MsgOrderHasUnrefundedPayment, nil)
+	}
+	return nil
 }
 
 // UpdateItemAvailability は商品の販売状態を更新します
 func (s *adminService) UpdateItemAvailability(ctx context.Context, itemID int, isAvailable bool) error {
 	return s.itr.UpdateItemAvailability(ctx, s.db, itemID, isAvailable)
 }
+
+// UpdateItemPrepTime は担当店舗の商品の調理時間を更新します
+func (s *adminService) UpdateItemPrepTime(ctx context.Context, adminShopID int, itemID int, prepSeconds *int) error {
+	return s.itr.UpdateItemPrepTime(ctx, s.db, adminShopID, itemID, prepSeconds)
+}
+
+// UpdateItemStock は担当店舗の商品のその日の在庫数を設定します
+func (s *adminService) UpdateItemStock(ctx context.Context, adminShopID int, itemID int, stockQuantity *int) error {
+	return s.itr.UpdateItemStock(ctx, s.db, adminShopID, itemID, stockQuantity)
+}
+
+// CreateModifierGroup は担当店舗の商品にオプショングループを登録します
+func (s *adminService) CreateModifierGroup(ctx context.Context, adminShopID int, itemID int, req models.CreateModifierGroupRequest) (*models.ModifierGroupResponse, error) {
+	if req.SelectionType == models.SingleSelect && req.MaxSelect != 1 {
+		return nil, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgSingleSelectMaxOne, nil)
+	}
+	if req.MinSelect > len(req.Options) {
+		return nil, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgMinSelectExceedsOptions, nil)
+	}
+
+	group := &models.ModifierGroup{
+		ItemID:        itemID,
+		Name:          req.Name,
+		SelectionType: req.SelectionType,
+		MinSelect:     req.MinSelect,
+		MaxSelect:     req.MaxSelect,
+		SortOrder:     req.SortOrder,
+		Options:       make([]models.ModifierOption, len(req.Options)),
+	}
+	for i, o := range req.Options {
+		group.Options[i] = models.ModifierOption{
+			Name:        o.Name,
+			PriceDelta:  o.PriceDelta,
+			IsAvailable: true,
+			SortOrder:   o.SortOrder,
+		}
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.Unknown.Wrap(err, "トランザクションの開始に失敗しました。")
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+			if err != nil {
+				err = apperrors.Unknown.Wrap(err, "トランザクションのコミットに失敗しました。")
+			}
+		}
+	}()
+
+	// グループと選択肢をまとめて登録する
+	if err = s.itr.CreateModifierGroup(ctx, tx, adminShopID, group); err != nil {
+		return nil, err
+	}
+
+	return &toModifierGroupResponses([]models.ModifierGroup{*group})[0], nil
+}
+
+// DeleteModifierGroup は担当店舗の商品のオプショングループを削除します
+func (s *adminService) DeleteModifierGroup(ctx context.Context, adminShopID int, modifierGroupID int) error {
+	return s.itr.DeleteModifierGroup(ctx, s.db, adminShopID, modifierGroupID)
+}
+
+// UpdateItemAvailabilitySchedule は担当店舗の商品を注文できる時間帯を置き換えます。空にするといつでも注文できます
+func (s *adminService) UpdateItemAvailabilitySchedule(ctx context.Context, adminShopID int, itemID int, req models.UpdateItemAvailabilityScheduleRequest) (res *models.ItemAvailabilityScheduleResponse, err error) {
+	windows, err := buildAvailabilityWindows(req.Windows)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.Unknown.Wrap(err, "トランザクションの開始に失敗しました。")
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+			if err != nil {
+				err = apperrors.Unknown.Wrap(err, "トランザクションのコミットに失敗しました。")
+			}
+		}
+	}()
+
+	if err = s.itr.ReplaceAvailabilityWindows(ctx, tx, adminShopID, itemID, windows); err != nil {
+		return nil, err
+	}
+	res = &models.ItemAvailabilityScheduleResponse{ItemID: itemID, Windows: toAvailabilityWindowResponses(windows)}
+	if res.Windows == nil {
+		res.Windows = []models.AvailabilityWindowResponse{}
+	}
+	return res, nil
+}
+
+// UpdateItemBundle は担当店舗の商品をセット商品にし、枠と構成商品を置き換えます。空にするとセットではない商品に戻ります。
+// 構成商品は担当店舗で扱っている商品に限り、セット商品を構成商品にすることはできません
+func (s *adminService) UpdateItemBundle(ctx context.Context, adminShopID int, itemID int, req models.UpdateItemBundleRequest) (res *models.ItemBundleResponse, err error) {
+	slots, componentIDs, err := buildBundleSlots(itemID, req.Slots)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.Unknown.Wrap(err, "トランザクションの開始に失敗しました。")
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+			if err != nil {
+				err = apperrors.Unknown.Wrap(err, "トランザクションのコミットに失敗しました。")
+			}
+		}
+	}()
+
+	loc := defaultShopLocation
+	if len(componentIDs) > 0 {
+		components, err := s.itr.ValidateAndGetItemsForShop(ctx, tx, adminShopID, componentIDs)
+		if err != nil {
+			return nil, err
+		}
+		nested, err := s.itr.FindBundleSlotsByItemIDs(ctx, tx, adminShopID, componentIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range componentIDs {
+			if len(nested[id]) > 0 {
+				return nil, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgBundleComponentIsBundle, apperrors.Params{"item_name": components[id].ItemName})
+			}
+		}
+		loc = shopLocation(components[componentIDs[0]].TimeZone)
+	}
+
+	if err = s.itr.ReplaceBundleSlots(ctx, tx, adminShopID, itemID, slots); err != nil {
+		return nil, err
+	}
+
+	// 構成商品の名前と販売状態を付けて返す
+	slotsMap, err := s.itr.FindBundleSlotsByItemIDs(ctx, tx, adminShopID, []int{itemID})
+	if err != nil {
+		return nil, err
+	}
+	return &models.ItemBundleResponse{ItemID: itemID, Slots: toBundleSlotResponses(slotsMap[itemID], loc, time.Now())}, nil
+}
+
+// UpdateItemDietaryInfo は担当店舗の商品のアレルゲンと食事の制限を置き換えます。商品の情報なので全店舗に反映されます
+func (s *adminService) UpdateItemDietaryInfo(ctx context.Context, adminShopID int, itemID int, req models.UpdateItemDietaryInfoRequest) (res *models.ItemDietaryInfoResponse, err error) {
+	// 商品一覧と同じくコードの順で返す
+	allergens := append([]string{}, req.Allergens...)
+	sort.Strings(allergens)
+	dietaryTags := append([]string{}, req.DietaryTags...)
+	sort.Strings(dietaryTags)
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.Unknown.Wrap(err, "トランザクションの開始に失敗しました。")
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+			if err != nil {
+				err = apperrors.Unknown.Wrap(err, "トランザクションのコミットに失敗しました。")
+			}
+		}
+	}()
+
+	if err = s.itr.ReplaceItemDietaryInfo(ctx, tx, adminShopID, itemID, allergens, dietaryTags); err != nil {
+		return nil, err
+	}
+	return &models.ItemDietaryInfoResponse{ItemID: itemID, Allergens: allergens, DietaryTags: dietaryTags}, nil
+}
+
+// buildBundleSlots はセット商品の枠の指定を検証して枠に変換し、構成商品のIDを重複を除いて返します
+func buildBundleSlots(itemID int, req []models.BundleSlotRequest) ([]models.BundleSlot, []int, error) {
+	slots := make([]models.BundleSlot, len(req))
+	var componentIDs []int
+	seen := make(map[int]bool)
+	for i, r := range req {
+		slot := models.BundleSlot{Name: r.Name, Quantity: r.Quantity, SortOrder: r.SortOrder, Choices: make([]models.BundleChoice, len(r.Choices))}
+		if slot.Quantity == 0 {
+			slot.Quantity = 1
+		}
+		inSlot := make(map[int]bool, len(r.Choices))
+		for j, c := range r.Choices {
+			if c.ItemID == itemID {
+				return nil, nil, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgBundleSelfComponent, nil)
+			}
+			if inSlot[c.ItemID] {
+				return nil, nil, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgBundleSlotDuplicateItem, apperrors.Params{"slot_name": r.Name, "item_id": c.ItemID})
+			}
+			inSlot[c.ItemID] = true
+			if !seen[c.ItemID] {
+				seen[c.ItemID] = true
+				componentIDs = append(componentIDs, c.ItemID)
+			}
+			slot.Choices[j] = models.BundleChoice{ItemID: c.ItemID, PriceDelta: c.PriceDelta, SortOrder: c.SortOrder}
+		}
+		slots[i] = slot
+	}
+	return slots, componentIDs, nil
+}
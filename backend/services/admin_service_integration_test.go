@@ -85,7 +85,7 @@ func TestAdminService_UpdateOrderStatus_Integration(t *testing.T) {
 	itemRepo := repositories.NewItemRepository()
 
 	// サービス初期化
-	adminService := services.NewAdminService(orderRepo, itemRepo, db)
+	adminService := services.NewAdminService(orderRepo, itemRepo, repositories.NewPointRepository(), repositories.NewPaymentRepository(), repositories.NewPromotionRepository(), db)
 
 	ctx := context.Background()
 
@@ -197,7 +197,7 @@ func TestAdminService_DeleteOrder_Integration(t *testing.T) {
 	itemRepo := repositories.NewItemRepository()
 
 	// サービス初期化
-	adminService := services.NewAdminService(orderRepo, itemRepo, db)
+	adminService := services.NewAdminService(orderRepo, itemRepo, repositories.NewPointRepository(), repositories.NewPaymentRepository(), repositories.NewPromotionRepository(), db)
 
 	ctx := context.Background()
 
@@ -230,6 +230,52 @@ func TestAdminService_DeleteOrder_Integration(t *testing.T) {
 		}
 	})
 
+	t.Run("正常系: お渡し前の注文を削除するとクーポンの利用を取り消す", func(t *testing.T) {
+		orderID := createTestOrder(t, db, 1, models.Cooking)
+		var promotionID int
+		err := db.QueryRow(
+			"INSERT INTO promotions (shop_id, code, name, discount_type, discount_value, used_count) VALUES (1, $1, 'テスト', $2, 100, 1) RETURNING promotion_id",
+			fmt.Sprintf("DELETE%d", orderID), models.FixedDiscount,
+		).Scan(&promotionID)
+		if err != nil {
+			t.Fatalf("Failed to create promotion: %v", err)
+		}
+		if _, err := db.Exec("INSERT INTO promotion_redemptions (promotion_id, order_id, discount_amount) VALUES ($1, $2, 100)", promotionID, orderID); err != nil {
+			t.Fatalf("Failed to create redemption: %v", err)
+		}
+
+		testhelpers.AssertNoError(t, adminService.DeleteOrder(ctx, 1, orderID))
+
+		var usedCount int
+		if err := db.QueryRow("SELECT used_count FROM promotions WHERE promotion_id = $1", promotionID).Scan(&usedCount); err != nil {
+			t.Fatalf("Failed to get promotion: %v", err)
+		}
+		if usedCount != 0 {
+			t.Errorf("used_count = %d, want 0", usedCount)
+		}
+	})
+
+	t.Run("異常系: 返金していない支払いがある注文は削除できない", func(t *testing.T) {
+		orderID := createTestOrder(t, db, 1, models.Cooking)
+		var paymentID int
+		err := db.QueryRow(
+			"INSERT INTO payments (order_id, provider, provider_payment_id, amount, status) VALUES ($1, 'mock', $2, 1000, $3) RETURNING payment_id",
+			orderID, fmt.Sprintf("mock_pay_delete_%d", orderID), models.PaymentCaptured,
+		).Scan(&paymentID)
+		if err != nil {
+			t.Fatalf("Failed to create payment: %v", err)
+		}
+
+		err = adminService.DeleteOrder(ctx, 1, orderID)
+		testhelpers.AssertAppError(t, err, apperrors.Conflict)
+
+		// 全額返金したあとは削除できる
+		if _, err := db.Exec("UPDATE payments SET refunded_amount = amount WHERE payment_id = $1", paymentID); err != nil {
+			t.Fatalf("Failed to update payment: %v", err)
+		}
+		testhelpers.AssertNoError(t, adminService.DeleteOrder(ctx, 1, orderID))
+	})
+
 	t.Run("異常系: 存在しない注文の削除", func(t *testing.T) {
 		// 存在しない注文IDで削除を試行
 		err := adminService.DeleteOrder(ctx, 1, 99999)
@@ -270,7 +316,7 @@ func TestAdminService_TransactionRollback_Integration(t *testing.T) {
 	itemRepo := repositories.NewItemRepository()
 
 	// サービス初期化
-	adminService := services.NewAdminService(orderRepo, itemRepo, db)
+	adminService := services.NewAdminService(orderRepo, itemRepo, repositories.NewPointRepository(), repositories.NewPaymentRepository(), repositories.NewPromotionRepository(), db)
 
 	ctx := context.Background()
 
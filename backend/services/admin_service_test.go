@@ -18,12 +18,13 @@ import (
 
 // OrderRepositoryMockForAdmin - AdminService用のOrderRepositoryのモック実装
 type OrderRepositoryMockForAdmin struct {
-	FindShopOrdersByStatusesFunc func(ctx context.Context, dbtx repositories.DBTX, shopID int, statuses []models.OrderStatus) ([]repositories.AdminOrderDBResult, error)
-	FindItemsByOrderIDsFunc      func(ctx context.Context, dbtx repositories.DBTX, orderIDs []int) (map[int][]models.ItemDetail, error)
-	FindOrderByIDAndShopIDFunc   func(ctx context.Context, dbtx repositories.DBTX, orderID int, shopID int) (*models.Order, error)
-	UpdateOrderStatusFunc        func(ctx context.Context, dbtx repositories.DBTX, orderID int, shopID int, newStatus models.OrderStatus) error
-	DeleteOrderByIDAndShopIDFunc func(ctx context.Context, dbtx repositories.DBTX, orderID int, shopID int) error
-	CountWaitingOrdersFunc       func(ctx context.Context, dbtx repositories.DBTX, shopID int, orderDate time.Time) (int, error)
+	FindShopOrdersByStatusesFunc        func(ctx context.Context, dbtx repositories.DBTX, shopID int, statuses []models.OrderStatus) ([]repositories.AdminOrderDBResult, error)
+	FindItemsByOrderIDsFunc             func(ctx context.Context, dbtx repositories.DBTX, orderIDs []int) (map[int][]models.ItemDetail, error)
+	FindOrderByIDAndShopIDFunc          func(ctx context.Context, dbtx repositories.DBTX, orderID int, shopID int) (*models.Order, error)
+	FindOrderByIDAndShopIDForUpdateFunc func(ctx context.Context, dbtx repositories.DBTX, orderID int, shopID int) (*models.Order, error)
+	UpdateOrderStatusFunc               func(ctx context.Context, dbtx repositories.DBTX, orderID int, shopID int, newStatus models.OrderStatus) error
+	DeleteOrderByIDAndShopIDFunc        func(ctx context.Context, dbtx repositories.DBTX, orderID int, shopID int) error
+	CountWaitingOrdersFunc              func(ctx context.Context, dbtx repositories.DBTX, shopID int, orderDate time.Time) (int, error)
 }
 
 func NewOrderRepositoryMockForAdmin() *OrderRepositoryMockForAdmin {
@@ -63,6 +64,10 @@ func (m *OrderRepositoryMockForAdmin) FindOrderByIDAndShopID(ctx context.Context
 	return m.FindOrderByIDAndShopIDFunc(ctx, dbtx, orderID, shopID)
 }
 
+func (m *OrderRepositoryMockForAdmin) FindOrderByIDAndShopIDForUpdate(ctx context.Context, dbtx repositories.DBTX, orderID int, shopID int) (*models.Order, error) {
+	return m.FindOrderByIDAndShopIDForUpdateFunc(ctx, dbtx, orderID, shopID)
+}
+
 func (m *OrderRepositoryMockForAdmin) UpdateOrderStatus(ctx context.Context, dbtx repositories.DBTX, orderID int, shopID int, newStatus models.OrderStatus) error {
 	return m.UpdateOrderStatusFunc(ctx, dbtx, orderID, shopID, newStatus)
 }
@@ -71,11 +76,39 @@ func (m *OrderRepositoryMockForAdmin) DeleteOrderByIDAndShopID(ctx context.Conte
 	return m.DeleteOrderByIDAndShopIDFunc(ctx, dbtx, orderID, shopID)
 }
 
+func (m *OrderRepositoryMockForAdmin) FindCookingQueue(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]repositories.KitchenQueueEntry, error) {
+	panic("not implemented")
+}
+
+func (m *OrderRepositoryMockForAdmin) FindRecentKitchenDurations(ctx context.Context, dbtx repositories.DBTX, shopID int, since time.Time, limit int) ([]repositories.KitchenDurationSample, error) {
+	panic("not implemented")
+}
+
+func (m *OrderRepositoryMockForAdmin) FindOrderByID(ctx context.Context, dbtx repositories.DBTX, orderID int) (*models.Order, error) {
+	panic("not implemented")
+}
+
+func (m *OrderRepositoryMockForAdmin) FindTaxLinesByOrderIDs(ctx context.Context, dbtx repositories.DBTX, orderIDs []int) (map[int][]models.OrderTaxLine, error) {
+	panic("not implemented")
+}
+
+func (m *OrderRepositoryMockForAdmin) FindReceiptOrder(ctx context.Context, dbtx repositories.DBTX, orderID int) (*repositories.ReceiptOrderDB, error) {
+	panic("not implemented")
+}
+
+func (m *OrderRepositoryMockForAdmin) FindReceiptLines(ctx context.Context, dbtx repositories.DBTX, orderID int) ([]repositories.ReceiptLineDB, error) {
+	panic("not implemented")
+}
+
+func (m *OrderRepositoryMockForAdmin) FindDiscountsByOrderIDs(ctx context.Context, dbtx repositories.DBTX, orderIDs []int) (map[int][]models.OrderDiscount, error) {
+	panic("not implemented")
+}
+
 // ItemRepositoryMock - ItemRepositoryのモック実装
 type ItemRepositoryMock struct {
 }
 
-func (m *ItemRepositoryMock) GetItemList(dbtx repositories.DBTX, shopID int) ([]models.ItemListResponse, error) {
+func (m *ItemRepositoryMock) GetItemList(dbtx repositories.DBTX, shopID int) ([]repositories.ItemListDB, error) {
 	panic("not implemented")
 }
 
@@ -107,6 +140,78 @@ func (m *ItemRepositoryMock) ValidateAndGetItemsForShop(ctx context.Context, dbt
 	panic("not implemented")
 }
 
+func (m *ItemRepositoryMock) UpdateItemPrepTime(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, prepSeconds *int) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMock) DecrementStock(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, quantity int) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMock) RestockOrderItems(ctx context.Context, dbtx repositories.DBTX, shopID int, orderID int) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMock) UpdateItemStock(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, stockQuantity *int) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMock) FindModifierGroupsByItemIDs(ctx context.Context, dbtx repositories.DBTX, shopID int, itemIDs []int) (map[int][]models.ModifierGroup, error) {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMock) CreateModifierGroup(ctx context.Context, dbtx repositories.DBTX, shopID int, group *models.ModifierGroup) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMock) DeleteModifierGroup(ctx context.Context, dbtx repositories.DBTX, shopID int, modifierGroupID int) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMock) FindShopMenuItems(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]models.Item, error) {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMock) CreateShopMenuItem(ctx context.Context, dbtx repositories.DBTX, shopID int, item *models.Item) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMock) UpdateShopMenuItem(ctx context.Context, dbtx repositories.DBTX, shopID int, item *models.Item) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMock) UpdateItemImage(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, imageKey string, thumbnailKey string) ([]string, error) {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMock) ReplaceAvailabilityWindows(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, windows []models.AvailabilityWindow) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMock) CreatePriceChange(ctx context.Context, dbtx repositories.DBTX, shopID int, change *models.PriceChange) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMock) FindPriceChanges(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int) ([]models.PriceChange, error) {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMock) CancelPriceChange(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, priceChangeID int) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMock) FindBundleSlotsByItemIDs(ctx context.Context, dbtx repositories.DBTX, shopID int, itemIDs []int) (map[int][]models.BundleSlot, error) {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMock) ReplaceBundleSlots(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, slots []models.BundleSlot) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMock) ReplaceItemDietaryInfo(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, allergens []string, dietaryTags []string) error {
+	panic("not implemented")
+}
+
 // テスト用データ生成関数
 func createTestAdminOrderDBResult(orderID int, email string, totalAmount int, status models.OrderStatus) repositories.AdminOrderDBResult {
 	var customerEmail sql.NullString
@@ -224,7 +329,7 @@ func TestAdminService_GetCookingOrders(t *testing.T) {
 			mockDB := &sqlx.DB{}
 
 			// サービス初期化
-			adminService := services.NewAdminService(mockRepo, mockItemRepo, mockDB)
+			adminService := services.NewAdminService(mockRepo, mockItemRepo, nil, nil, nil, mockDB)
 
 			// テスト実行
 			ctx := context.Background()
@@ -332,7 +437,7 @@ func TestAdminService_GetCompletedOrders(t *testing.T) {
 			mockDB := &sqlx.DB{}
 
 			// サービス初期化
-			adminService := services.NewAdminService(mockRepo, mockItemRepo, mockDB)
+			adminService := services.NewAdminService(mockRepo, mockItemRepo, nil, nil, nil, mockDB)
 
 			// テスト実行
 			ctx := context.Background()
@@ -400,7 +505,7 @@ func TestAdminService_AssembleResponse_Integration(t *testing.T) {
 	mockDB := &sqlx.DB{}
 
 	// サービス初期化
-	adminService := services.NewAdminService(mockRepo, mockItemRepo, mockDB)
+	adminService := services.NewAdminService(mockRepo, mockItemRepo, nil, nil, nil, mockDB)
 
 	// テスト実行
 	ctx := context.Background()
@@ -451,6 +556,64 @@ func TestAdminService_AssembleResponse_Integration(t *testing.T) {
 	}
 }
 
+// TestAdminService_AssembleResponse_NeedsAttention - メモ・アレルギー申告の強調表示のテスト
+func TestAdminService_AssembleResponse_NeedsAttention(t *testing.T) {
+	itemNote := "ネギ抜き"
+	orderNote := "小さい子ども用に取り皿をください"
+
+	mockRepo := NewOrderRepositoryMockForAdmin()
+	mockRepo.FindShopOrdersByStatusesFunc = func(ctx context.Context, dbtx repositories.DBTX, shopID int, statuses []models.OrderStatus) ([]repositories.AdminOrderDBResult, error) {
+		allergy := createTestAdminOrderDBResult(1, "", 800, models.Cooking)
+		allergy.HasAllergy = true
+		withOrderNote := createTestAdminOrderDBResult(2, "", 800, models.Cooking)
+		withOrderNote.Note = sql.NullString{String: orderNote, Valid: true}
+		return []repositories.AdminOrderDBResult{
+			allergy,
+			withOrderNote,
+			createTestAdminOrderDBResult(3, "", 800, models.Cooking),
+			createTestAdminOrderDBResult(4, "", 800, models.Cooking),
+		}, nil
+	}
+	mockRepo.FindItemsByOrderIDsFunc = func(ctx context.Context, dbtx repositories.DBTX, orderIDs []int) (map[int][]models.ItemDetail, error) {
+		return map[int][]models.ItemDetail{
+			1: {{ItemName: "醤油ラーメン", Quantity: 1}},
+			2: {{ItemName: "醤油ラーメン", Quantity: 1}},
+			3: {{ItemName: "醤油ラーメン", Quantity: 1, Note: &itemNote}},
+			4: {{ItemName: "醤油ラーメン", Quantity: 1}},
+		}, nil
+	}
+
+	adminService := services.NewAdminService(mockRepo, &ItemRepositoryMock{}, nil, nil, nil, &sqlx.DB{})
+	result, err := adminService.GetCookingOrders(context.Background(), 1)
+	testhelpers.AssertNoError(t, err)
+	if len(result) != 4 {
+		t.Fatalf("期待される注文数 = 4, 実際 = %d", len(result))
+	}
+
+	expected := []struct {
+		hasAllergy     bool
+		note           *string
+		needsAttention bool
+	}{
+		{hasAllergy: true, needsAttention: true},
+		{note: &orderNote, needsAttention: true},
+		{needsAttention: true},
+		{needsAttention: false},
+	}
+	for i, want := range expected {
+		got := result[i]
+		if got.HasAllergy != want.hasAllergy {
+			t.Errorf("注文%d: 期待されるHasAllergy = %v, 実際 = %v", got.OrderID, want.hasAllergy, got.HasAllergy)
+		}
+		if !cmp.Equal(got.Note, want.note) {
+			t.Errorf("注文%d: 期待されるNote = %v, 実際 = %v", got.OrderID, want.note, got.Note)
+		}
+		if got.NeedsAttention != want.needsAttention {
+			t.Errorf("注文%d: 期待されるNeedsAttention = %v, 実際 = %v", got.OrderID, want.needsAttention, got.NeedsAttention)
+		}
+	}
+}
+
 // TestAdminService_UpdateOrderStatus - UpdateOrderStatusメソッドのテスト
 func TestAdminService_UpdateOrderStatus(t *testing.T) {
 	tests := []struct {
@@ -563,7 +726,7 @@ func TestAdminService_UpdateOrderStatus(t *testing.T) {
 			mockDB := &sqlx.DB{}
 
 			// サービス初期化
-			adminService := services.NewAdminService(mockRepo, mockItemRepo, mockDB)
+			adminService := services.NewAdminService(mockRepo, mockItemRepo, nil, nil, nil, mockDB)
 
 			// テスト実行
 			ctx := context.Background()
@@ -612,7 +775,7 @@ func TestAdminService_DeleteOrder(t *testing.T) {
 			expectedErrCode: "",
 		},
 		{
-			name:          "異常系: 注文が存在しない場合はFindOrderByIDAndShopIDでエラー",
+			name:          "異常系: 注文が存在しない場合はFindOrderByIDAndShopIDForUpdateでエラー",
 			adminShopID:   1,
 			targetOrderID: 999,
 			mockFindOrderFunc: func(ctx context.Context, dbtx repositories.DBTX, orderID int, shopID int) (*models.Order, error) {
@@ -657,7 +820,7 @@ func TestAdminService_DeleteOrder(t *testing.T) {
 			// モックの設定
 			mockRepo := NewOrderRepositoryMockForAdmin()
 			mockItemRepo := &ItemRepositoryMock{}
-			mockRepo.FindOrderByIDAndShopIDFunc = tt.mockFindOrderFunc
+			mockRepo.FindOrderByIDAndShopIDForUpdateFunc = tt.mockFindOrderFunc
 			if tt.mockDeleteOrderFunc != nil {
 				mockRepo.DeleteOrderByIDAndShopIDFunc = tt.mockDeleteOrderFunc
 			}
@@ -666,7 +829,7 @@ func TestAdminService_DeleteOrder(t *testing.T) {
 			mockDB := &sqlx.DB{}
 
 			// サービス初期化
-			adminService := services.NewAdminService(mockRepo, mockItemRepo, mockDB)
+			adminService := services.NewAdminService(mockRepo, mockItemRepo, nil, nil, nil, mockDB)
 
 			// テスト実行
 			ctx := context.Background()
@@ -683,3 +846,118 @@ func TestAdminService_DeleteOrder(t *testing.T) {
 		})
 	}
 }
+
+// TestAdminService_CreateModifierGroup - CreateModifierGroupの入力検証テスト
+func TestAdminService_CreateModifierGroup(t *testing.T) {
+	options := []models.CreateModifierOptionRequest{
+		{Name: "並盛り", PriceDelta: 0},
+		{Name: "大盛り", PriceDelta: 100},
+	}
+
+	tests := []struct {
+		name            string
+		req             models.CreateModifierGroupRequest
+		expectedErrCode apperrors.ErrCode
+	}{
+		{
+			name: "異常系: 単一選択で最大選択数が1ではない",
+			req: models.CreateModifierGroupRequest{
+				Name:          "サイズ",
+				SelectionType: models.SingleSelect,
+				MinSelect:     1,
+				MaxSelect:     2,
+				Options:       options,
+			},
+			expectedErrCode: apperrors.ValidationFailed,
+		},
+		{
+			name: "異常系: 最小選択数が選択肢の数を超えている",
+			req: models.CreateModifierGroupRequest{
+				Name:          "トッピング",
+				SelectionType: models.MultiSelect,
+				MinSelect:     3,
+				MaxSelect:     3,
+				Options:       options,
+			},
+			expectedErrCode: apperrors.ValidationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adminService := services.NewAdminService(NewOrderRepositoryMockForAdmin(), &ItemRepositoryMock{}, nil, nil, nil, &sqlx.DB{})
+
+			res, err := adminService.CreateModifierGroup(context.Background(), 1, 5, tt.req)
+
+			testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+			if res != nil {
+				t.Errorf("expected nil response, got %+v", res)
+			}
+		})
+	}
+}
+
+func TestAdminService_UpdateItemAvailabilitySchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		windows []models.AvailabilityWindowRequest
+	}{
+		{
+			name:    "異常系: 終了時刻が開始時刻より前",
+			windows: []models.AvailabilityWindowRequest{{Days: []int{5}, Start: "22:00", End: "02:00"}},
+		},
+		{
+			name:    "異常系: 開始時刻と終了時刻が同じ",
+			windows: []models.AvailabilityWindowRequest{{Days: []int{1}, Start: "11:00", End: "11:00"}},
+		},
+		{
+			name: "異常系: 同じ曜日の時間帯が重なる",
+			windows: []models.AvailabilityWindowRequest{
+				{Days: []int{1, 2, 3, 4, 5}, Start: "07:00", End: "10:00"},
+				{Days: []int{3}, Start: "09:30", End: "11:00"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adminService := services.NewAdminService(NewOrderRepositoryMockForAdmin(), &ItemRepositoryMock{}, nil, nil, nil, &sqlx.DB{})
+
+			res, err := adminService.UpdateItemAvailabilitySchedule(context.Background(), 1, 5, models.UpdateItemAvailabilityScheduleRequest{Windows: tt.windows})
+
+			testhelpers.AssertAppError(t, err, apperrors.ValidationFailed)
+			if res != nil {
+				t.Errorf("expected nil response, got %+v", res)
+			}
+		})
+	}
+}
+
+func TestAdminService_UpdateItemBundle(t *testing.T) {
+	tests := []struct {
+		name  string
+		slots []models.BundleSlotRequest
+	}{
+		{
+			name:  "異常系: セット商品自身を構成商品にする",
+			slots: []models.BundleSlotRequest{{Name: "ラーメン", Choices: []models.BundleChoiceRequest{{ItemID: 5}, {ItemID: 30}}}},
+		},
+		{
+			name:  "異常系: 同じ枠に同じ商品が重複",
+			slots: []models.BundleSlotRequest{{Name: "餃子", Choices: []models.BundleChoiceRequest{{ItemID: 20}, {ItemID: 20, PriceDelta: 50}}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adminService := services.NewAdminService(NewOrderRepositoryMockForAdmin(), &ItemRepositoryMock{}, nil, nil, nil, &sqlx.DB{})
+
+			res, err := adminService.UpdateItemBundle(context.Background(), 1, 30, models.UpdateItemBundleRequest{Slots: tt.slots})
+
+			testhelpers.AssertAppError(t, err, apperrors.ValidationFailed)
+			if res != nil {
+				t.Errorf("expected nil response, got %+v", res)
+			}
+		})
+	}
+}
@@ -38,14 +38,16 @@ type authService struct {
 	usr repositories.UserRepository
 	shr repositories.ShopRepository
 	orr repositories.OrderRepository
+	ptr repositories.PointRepository
 	db  *sqlx.DB
 }
 
-func NewAuthService(usr repositories.UserRepository, shr repositories.ShopRepository, orr repositories.OrderRepository, db *sqlx.DB) AuthServicer {
+func NewAuthService(usr repositories.UserRepository, shr repositories.ShopRepository, orr repositories.OrderRepository, ptr repositories.PointRepository, db *sqlx.DB) AuthServicer {
 	return &authService{
 		usr: usr,
 		shr: shr,
 		orr: orr,
+		ptr: ptr,
 		db:  db,
 	}
 }
@@ -80,6 +82,10 @@ func (s *authService) signUpWithGuestOrderLinking(ctx context.Context, req model
 	if err := s.orr.UpdateUserIDByGuestToken(ctx, tx, req.GuestOrderToken, newUser.UserID); err != nil {
 		return models.UserResponse{}, "", apperrors.Unknown.Wrap(err, "ゲスト注文の引き継ぎに失敗しました。")
 	}
+	// 引き継いだ注文が既にお渡し済みであれば、さかのぼってポイントを付与する
+	if _, err := s.ptr.CreditPointsForGuestOrder(ctx, tx, req.GuestOrderToken, pointExpiresAt(time.Now())); err != nil {
+		return models.UserResponse{}, "", err
+	}
 
 	// トークン生成
 	token, err := s.createToken(ctx, *newUser)
@@ -127,7 +133,7 @@ func (s *authService) LogIn(ctx context.Context, req models.AuthenticateRequest)
 		var appErr *apperrors.AppError
 		if errors.As(err, &appErr) {
 			if appErr.ErrCode == apperrors.NoData {
-				return models.UserResponse{}, "", apperrors.Unauthorized.Wrap(err, "メールアドレスが見つかりません。")
+				return models.UserResponse{}, "", apperrors.Unauthorized.WrapMessage(err, apperrors.MsgEmailNotFound, nil)
 			}
 		}
 		return models.UserResponse{}, "", apperrors.Unknown.Wrap(err, "ログイン処理中に予期せぬエラーが発生しました。")
@@ -156,6 +162,10 @@ func (s *authService) logInWithGuestOrderLinking(ctx context.Context, req models
 	if err := s.orr.UpdateUserIDByGuestToken(ctx, tx, req.GuestOrderToken, user.UserID); err != nil {
 		return models.UserResponse{}, "", apperrors.Unknown.Wrap(err, "ゲスト注文の引き継ぎに失敗しました。")
 	}
+	// 引き継いだ注文が既にお渡し済みであれば、さかのぼってポイントを付与する
+	if _, err := s.ptr.CreditPointsForGuestOrder(ctx, tx, req.GuestOrderToken, pointExpiresAt(time.Now())); err != nil {
+		return models.UserResponse{}, "", err
+	}
 
 	// トークン生成
 	token, err := s.createToken(ctx, user)
@@ -98,7 +98,7 @@ func TestAuthService_SignUp_Integration(t *testing.T) {
 	userRepo := repositories.NewUserRepository()
 	shopRepo := repositories.NewShopRepository()
 	orderRepo := repositories.NewOrderRepository()
-	authService := services.NewAuthService(userRepo, shopRepo, orderRepo, db)
+	authService := services.NewAuthService(userRepo, shopRepo, orderRepo, repositories.NewPointRepository(), db)
 
 	tests := []struct {
 		name             string
@@ -302,7 +302,7 @@ func TestAuthService_LogIn_Integration(t *testing.T) {
 	userRepo := repositories.NewUserRepository()
 	shopRepo := repositories.NewShopRepository()
 	orderRepo := repositories.NewOrderRepository()
-	authService := services.NewAuthService(userRepo, shopRepo, orderRepo, db)
+	authService := services.NewAuthService(userRepo, shopRepo, orderRepo, repositories.NewPointRepository(), db)
 
 	// テスト用ユーザーとゲスト注文を事前作成
 	var userID int
@@ -93,6 +93,30 @@ func (m *ShopRepositoryMockForAuth) FindShopByID(ctx context.Context, dbtx repos
 	panic("not implemented")
 }
 
+func (m *ShopRepositoryMockForAuth) FindShopsWithinRadius(ctx context.Context, dbtx repositories.DBTX, latitude float64, longitude float64, radiusMeters float64) ([]repositories.NearbyShopDBResult, error) {
+	panic("not implemented")
+}
+
+func (m *ShopRepositoryMockForAuth) UpdateShopCoordinates(ctx context.Context, dbtx repositories.DBTX, shopID int, latitude float64, longitude float64) error {
+	panic("not implemented")
+}
+
+func (m *ShopRepositoryMockForAuth) UpdateShopDefaultPrepTime(ctx context.Context, dbtx repositories.DBTX, shopID int, defaultPrepSeconds int) error {
+	panic("not implemented")
+}
+
+func (m *ShopRepositoryMockForAuth) UpdateShopInvoiceRegistrationNumber(ctx context.Context, dbtx repositories.DBTX, shopID int, registrationNumber *string) error {
+	panic("not implemented")
+}
+
+func (m *ShopRepositoryMockForAuth) UpdateShopPointRate(ctx context.Context, dbtx repositories.DBTX, shopID int, pointRate int) error {
+	panic("not implemented")
+}
+
+func (m *ShopRepositoryMockForAuth) UpdateShopTimeZone(ctx context.Context, dbtx repositories.DBTX, shopID int, timeZone string) error {
+	panic("not implemented")
+}
+
 // OrderRepositoryMockForAuth - OrderRepositoryのモック実装（Auth用、DBTX対応）
 type OrderRepositoryMockForAuth struct {
 	UpdateUserIDByGuestTokenFunc func(ctx context.Context, dbtx repositories.DBTX, guestToken string, userID int) error
@@ -139,6 +163,10 @@ func (m *OrderRepositoryMockForAuth) FindOrderByIDAndShopID(ctx context.Context,
 	panic("not implemented")
 }
 
+func (m *OrderRepositoryMockForAuth) FindOrderByIDAndShopIDForUpdate(ctx context.Context, dbtx repositories.DBTX, orderID int, shopID int) (*models.Order, error) {
+	panic("not implemented")
+}
+
 func (m *OrderRepositoryMockForAuth) UpdateOrderStatus(ctx context.Context, dbtx repositories.DBTX, orderID int, shopID int, newStatus models.OrderStatus) error {
 	panic("not implemented")
 }
@@ -147,6 +175,34 @@ func (m *OrderRepositoryMockForAuth) DeleteOrderByIDAndShopID(ctx context.Contex
 	panic("not implemented")
 }
 
+func (m *OrderRepositoryMockForAuth) FindCookingQueue(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]repositories.KitchenQueueEntry, error) {
+	panic("not implemented")
+}
+
+func (m *OrderRepositoryMockForAuth) FindRecentKitchenDurations(ctx context.Context, dbtx repositories.DBTX, shopID int, since time.Time, limit int) ([]repositories.KitchenDurationSample, error) {
+	panic("not implemented")
+}
+
+func (m *OrderRepositoryMockForAuth) FindOrderByID(ctx context.Context, dbtx repositories.DBTX, orderID int) (*models.Order, error) {
+	panic("not implemented")
+}
+
+func (m *OrderRepositoryMockForAuth) FindTaxLinesByOrderIDs(ctx context.Context, dbtx repositories.DBTX, orderIDs []int) (map[int][]models.OrderTaxLine, error) {
+	panic("not implemented")
+}
+
+func (m *OrderRepositoryMockForAuth) FindReceiptOrder(ctx context.Context, dbtx repositories.DBTX, orderID int) (*repositories.ReceiptOrderDB, error) {
+	panic("not implemented")
+}
+
+func (m *OrderRepositoryMockForAuth) FindReceiptLines(ctx context.Context, dbtx repositories.DBTX, orderID int) ([]repositories.ReceiptLineDB, error) {
+	panic("not implemented")
+}
+
+func (m *OrderRepositoryMockForAuth) FindDiscountsByOrderIDs(ctx context.Context, dbtx repositories.DBTX, orderIDs []int) (map[int][]models.OrderDiscount, error) {
+	panic("not implemented")
+}
+
 // テスト定数
 const (
 	testEmail           = "test@example.com"
@@ -290,7 +346,7 @@ func TestAuthService_SignUp(t *testing.T) {
 
 			// サービス作成（単体テスト用 - トランザクションが必要な場合は結合テストで実施）
 			mockDB := &sqlx.DB{} // 注意: これはトランザクションを使わないケースのみでテスト
-			authService := services.NewAuthService(userRepo, shopRepo, orderRepo, mockDB)
+			authService := services.NewAuthService(userRepo, shopRepo, orderRepo, nil, mockDB)
 
 			// テスト実行
 			gotUser, gotToken, err := authService.SignUp(context.Background(), tt.req)
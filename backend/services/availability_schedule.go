@@ -0,0 +1,141 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+)
+
+const minutesPerDay = 24 * 60
+
+// タイムゾーンを読み込めない店舗は日本時間で判定する
+var defaultShopLocation = time.FixedZone("JST", 9*60*60)
+
+// shopLocation は店舗のタイムゾーン（IANA名）を読み込みます
+func shopLocation(timeZone string) *time.Location {
+	if timeZone == "" {
+		return defaultShopLocation
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return defaultShopLocation
+	}
+	return loc
+}
+
+// IsWithinSchedule は時刻tが商品を注文できる時間帯に入っているかを、店舗のタイムゾーンの曜日と時刻で判定します。
+// 時間帯が1つもない商品はいつでも注文できます。
+func IsWithinSchedule(windows []models.AvailabilityWindow, loc *time.Location, t time.Time) bool {
+	if len(windows) == 0 {
+		return true
+	}
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	for _, w := range windows {
+		if w.DayOfWeek == local.Weekday() && w.StartMinute <= minute && minute < w.EndMinute {
+			return true
+		}
+	}
+	return false
+}
+
+// NextAvailableTime は時刻t以降で最初に注文できるようになる日時を返します。tが時間帯の中ならtをそのまま返します。
+// 時間帯が1つもない場合もtを返し、1週間先まで注文できる時間帯がない場合はfalseを返します。
+func NextAvailableTime(windows []models.AvailabilityWindow, loc *time.Location, t time.Time) (time.Time, bool) {
+	if IsWithinSchedule(windows, loc, t) {
+		return t, true
+	}
+	local := t.In(loc)
+	var next time.Time
+	// 今日の残りの時間帯から、来週の同じ曜日の時間帯までを調べる
+	for offset := 0; offset <= 7; offset++ {
+		day := time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, loc)
+		for _, w := range windows {
+			if w.DayOfWeek != day.Weekday() {
+				continue
+			}
+			// 分で日付を組み立てると、夏時間の切り替わる日もその地域の時刻として正規化される
+			start := time.Date(day.Year(), day.Month(), day.Day(), 0, w.StartMinute, 0, 0, loc)
+			if start.After(t) && (next.IsZero() || start.Before(next)) {
+				next = start
+			}
+		}
+		if !next.IsZero() {
+			return next, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// buildAvailabilityWindows は時間帯の指定を曜日ごとの時間帯に展開します。
+// 開始が終了より後の時間帯や、同じ曜日で重なる時間帯はエラーにします。
+func buildAvailabilityWindows(req []models.AvailabilityWindowRequest) ([]models.AvailabilityWindow, error) {
+	windows := make([]models.AvailabilityWindow, 0, len(req))
+	for _, r := range req {
+		start, err := parseScheduleMinute(r.Start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseScheduleMinute(r.End)
+		if err != nil {
+			return nil, err
+		}
+		if start >= end {
+			return nil, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgScheduleEndBeforeStart, apperrors.Params{"start": r.Start, "end": r.End})
+		}
+		for _, day := range r.Days {
+			windows = append(windows, models.AvailabilityWindow{DayOfWeek: time.Weekday(day), StartMinute: start, EndMinute: end})
+		}
+	}
+
+	sort.Slice(windows, func(i, j int) bool {
+		if windows[i].DayOfWeek != windows[j].DayOfWeek {
+			return windows[i].DayOfWeek < windows[j].DayOfWeek
+		}
+		return windows[i].StartMinute < windows[j].StartMinute
+	})
+	for i := 1; i < len(windows); i++ {
+		prev, w := windows[i-1], windows[i]
+		if prev.DayOfWeek == w.DayOfWeek && w.StartMinute < prev.EndMinute {
+			return nil, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgScheduleOverlap, apperrors.Params{
+				"day": w.DayOfWeek, "start": formatScheduleMinute(prev.StartMinute), "end": formatScheduleMinute(prev.EndMinute),
+				"other_start": formatScheduleMinute(w.StartMinute), "other_end": formatScheduleMinute(w.EndMinute),
+			})
+		}
+	}
+	return windows, nil
+}
+
+// parseScheduleMinute は "07:30" を0時からの分にします。"24:00"は日の終わりです
+func parseScheduleMinute(s string) (int, error) {
+	if s == "24:00" {
+		return minutesPerDay, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, apperrors.ValidationFailed.WrapMessage(err, apperrors.MsgInvalidTimeOfDay, apperrors.Params{"value": s})
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatScheduleMinute(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+func toAvailabilityWindowResponses(windows []models.AvailabilityWindow) []models.AvailabilityWindowResponse {
+	if len(windows) == 0 {
+		return nil
+	}
+	res := make([]models.AvailabilityWindowResponse, len(windows))
+	for i, w := range windows {
+		res[i] = models.AvailabilityWindowResponse{
+			DayOfWeek: int(w.DayOfWeek),
+			Start:     formatScheduleMinute(w.StartMinute),
+			End:       formatScheduleMinute(w.EndMinute),
+		}
+	}
+	return res
+}
@@ -0,0 +1,89 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/services"
+)
+
+func TestAvailabilitySchedule(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 朝食は毎日7時〜10時、ランチは平日11時〜14時
+	var breakfast, lunch []models.AvailabilityWindow
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		breakfast = append(breakfast, models.AvailabilityWindow{DayOfWeek: day, StartMinute: 7 * 60, EndMinute: 10 * 60})
+		if day != time.Sunday && day != time.Saturday {
+			lunch = append(lunch, models.AvailabilityWindow{DayOfWeek: day, StartMinute: 11 * 60, EndMinute: 14 * 60})
+		}
+	}
+
+	// 2025-07-04は金曜日
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2025, 7, day, hour, minute, 0, 0, tokyo)
+	}
+
+	tests := []struct {
+		name       string
+		windows    []models.AvailabilityWindow
+		loc        *time.Location
+		now        time.Time
+		wantWithin bool
+		wantNext   time.Time
+	}{
+		{name: "時間帯の設定がなければいつでも注文できる", windows: nil, loc: tokyo, now: at(4, 3, 0), wantWithin: true, wantNext: at(4, 3, 0)},
+		{name: "朝食の時間帯の中", windows: breakfast, loc: tokyo, now: at(4, 7, 0), wantWithin: true, wantNext: at(4, 7, 0)},
+		{name: "終了時刻ちょうどは時間帯の外で、翌朝から注文できる", windows: breakfast, loc: tokyo, now: at(4, 10, 0), wantNext: at(5, 7, 0)},
+		{name: "開店前は当日の開始時刻から注文できる", windows: breakfast, loc: tokyo, now: at(4, 6, 59), wantNext: at(4, 7, 0)},
+		{name: "金曜のランチ後は月曜のランチから注文できる", windows: lunch, loc: tokyo, now: at(4, 15, 0), wantNext: at(7, 11, 0)},
+		{name: "土曜のランチは注文できない", windows: lunch, loc: tokyo, now: at(5, 12, 0), wantNext: at(7, 11, 0)},
+		{
+			// UTCの金曜22時は日本の土曜7時
+			name: "店舗のタイムゾーンの曜日と時刻で判定する", windows: breakfast, loc: tokyo,
+			now: time.Date(2025, 7, 4, 22, 0, 0, 0, time.UTC), wantWithin: true, wantNext: time.Date(2025, 7, 4, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "ニューヨークの店舗は現地時刻で判定する",
+			windows: breakfast,
+			loc:     mustLoadLocation(t, "America/New_York"),
+			now:     at(4, 8, 0), // ニューヨークでは木曜19時
+			// 夏時間（UTC-4）の金曜7時
+			wantNext: time.Date(2025, 7, 4, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "同じ曜日の時間帯しかなければ翌週に注文できる",
+			windows:  []models.AvailabilityWindow{{DayOfWeek: time.Friday, StartMinute: 7 * 60, EndMinute: 8 * 60}},
+			loc:      tokyo,
+			now:      at(4, 9, 0),
+			wantNext: at(11, 7, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := services.IsWithinSchedule(tt.windows, tt.loc, tt.now); got != tt.wantWithin {
+				t.Errorf("IsWithinSchedule = %v, want %v", got, tt.wantWithin)
+			}
+			next, ok := services.NextAvailableTime(tt.windows, tt.loc, tt.now)
+			if !ok {
+				t.Fatal("NextAvailableTime returned false")
+			}
+			if !next.Equal(tt.wantNext) {
+				t.Errorf("NextAvailableTime = %v, want %v", next, tt.wantNext)
+			}
+		})
+	}
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BlobStore は商品画像などのファイルの保存先を抽象化したものです。
+// キーは "items/1/xxx.jpg" のような / 区切りのパスで、保存したファイルは URL で公開されます。
+type BlobStore interface {
+	// Put はキーにファイルを保存します。同じキーのファイルは上書きします
+	Put(ctx context.Context, key string, contentType string, data []byte) error
+	// Delete はキーのファイルを削除します。ファイルがなくてもエラーにしません
+	Delete(ctx context.Context, key string) error
+	// URL はキーのファイルを取得できる公開URLを返します
+	URL(key string) string
+}
+
+// LocalBlobStore はローカルのディレクトリにファイルを保存します。
+// 保存したファイルは baseURL で配信する想定です（main.go で echo の Static に登録する）。
+type LocalBlobStore struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocalBlobStore(dir string, baseURL string) *LocalBlobStore {
+	return &LocalBlobStore{dir: dir, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+func (s *LocalBlobStore) Put(ctx context.Context, key string, contentType string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("local blob store: %w", err)
+	}
+	// 書き込み途中のファイルが配信されないよう、一時ファイルに書いてから置き換える
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("local blob store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("local blob store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("local blob store: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("local blob store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("local blob store: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("local blob store: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalBlobStore) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// path はキーを保存先のパスにします。ディレクトリの外を指すキーはエラーにします
+func (s *LocalBlobStore) path(key string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("local blob store: invalid key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
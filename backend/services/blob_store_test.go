@@ -0,0 +1,184 @@
+package services_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/services"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := services.NewLocalBlobStore(dir, "/images/")
+
+	testhelpers.AssertNoError(t, store.Put(ctx, "items/1/a.jpg", "image/jpeg", []byte("jpeg")))
+	got, err := os.ReadFile(filepath.Join(dir, "items", "1", "a.jpg"))
+	testhelpers.AssertNoError(t, err)
+	if string(got) != "jpeg" {
+		t.Errorf("file = %q, want %q", got, "jpeg")
+	}
+	if url := store.URL("items/1/a.jpg"); url != "/images/items/1/a.jpg" {
+		t.Errorf("URL = %q", url)
+	}
+
+	testhelpers.AssertNoError(t, store.Delete(ctx, "items/1/a.jpg"))
+	if _, err := os.Stat(filepath.Join(dir, "items", "1", "a.jpg")); !os.IsNotExist(err) {
+		t.Errorf("file is not deleted: %v", err)
+	}
+	// 存在しないファイルの削除はエラーにしない
+	testhelpers.AssertNoError(t, store.Delete(ctx, "items/1/a.jpg"))
+
+	// ディレクトリの外には書き込めない
+	if err := store.Put(ctx, "../escape.jpg", "image/jpeg", []byte("x")); err == nil {
+		t.Error("expected an error for a key outside the directory")
+	}
+}
+
+// fakeS3Server はテスト用のS3互換ストレージです。署名バージョン4の署名を検証し、オブジェクトをメモリに保存します
+type fakeS3Server struct {
+	accessKeyID     string
+	secretAccessKey string
+	region          string
+
+	mu      sync.Mutex
+	objects map[string]string // パスとContent-Type
+	bodies  map[string][]byte
+}
+
+func (f *fakeS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	if err := f.verify(r, body); err != "" {
+		http.Error(w, err, http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[r.URL.Path] = r.Header.Get("Content-Type")
+		f.bodies[r.URL.Path] = body
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		delete(f.bodies, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// verify はAuthorizationヘッダーの署名をリクエストから計算し直して比べます
+func (f *fakeS3Server) verify(r *http.Request, body []byte) string {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 ") {
+		return "missing signature"
+	}
+	params := map[string]string{}
+	for _, part := range strings.Split(strings.TrimPrefix(auth, "AWS4-HMAC-SHA256 "), ", ") {
+		key, value, _ := strings.Cut(part, "=")
+		params[key] = value
+	}
+	credential := strings.SplitN(params["Credential"], "/", 2)
+	if len(credential) != 2 || credential[0] != f.accessKeyID {
+		return "unknown access key"
+	}
+	scope := credential[1]
+	date := strings.SplitN(scope, "/", 2)[0]
+	if scope != date+"/"+f.region+"/s3/aws4_request" {
+		return "invalid scope"
+	}
+
+	bodyHash := sha256.Sum256(body)
+	if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(bodyHash[:]) {
+		return "payload hash mismatch"
+	}
+
+	signedHeaders := strings.Split(params["SignedHeaders"], ";")
+	if !sort.StringsAreSorted(signedHeaders) {
+		return "signed headers are not sorted"
+	}
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaders {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	canonicalRequest := strings.Join([]string{
+		r.Method, r.URL.EscapedPath(), r.URL.RawQuery, canonicalHeaders.String(), params["SignedHeaders"], hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + r.Header.Get("X-Amz-Date") + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := []byte("AWS4" + f.secretAccessKey)
+	for _, part := range []string{date, f.region, "s3", "aws4_request", stringToSign} {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(part))
+		key = mac.Sum(nil)
+	}
+	if params["Signature"] != hex.EncodeToString(key) {
+		return "signature mismatch"
+	}
+	return ""
+}
+
+func TestS3BlobStore(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeS3Server{
+		accessKeyID:     "AKIDEXAMPLE",
+		secretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		region:          "ap-northeast-1",
+		objects:         map[string]string{},
+		bodies:          map[string][]byte{},
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	store := services.NewS3BlobStore(services.S3Config{
+		Endpoint:        server.URL,
+		Region:          "ap-northeast-1",
+		Bucket:          "menu-images",
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		PublicBaseURL:   "https://cdn.example.com/",
+	}, server.Client())
+
+	testhelpers.AssertNoError(t, store.Put(ctx, "items/1/a b.jpg", "image/jpeg", []byte("jpeg")))
+	if got := fake.objects["/menu-images/items/1/a b.jpg"]; got != "image/jpeg" {
+		t.Fatalf("objects = %v", fake.objects)
+	}
+	if got := string(fake.bodies["/menu-images/items/1/a b.jpg"]); got != "jpeg" {
+		t.Errorf("body = %q, want %q", got, "jpeg")
+	}
+	if url := store.URL("items/1/a b.jpg"); url != "https://cdn.example.com/items/1/a%20b.jpg" {
+		t.Errorf("URL = %q", url)
+	}
+
+	testhelpers.AssertNoError(t, store.Delete(ctx, "items/1/a b.jpg"))
+	if len(fake.objects) != 0 {
+		t.Errorf("objects = %v, want empty", fake.objects)
+	}
+
+	// 署名が合わなければエラーを返す
+	wrongKey := services.NewS3BlobStore(services.S3Config{
+		Endpoint: server.URL, Region: "ap-northeast-1", Bucket: "menu-images", AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wrong",
+	}, server.Client())
+	if err := wrongKey.Put(ctx, "items/1/b.jpg", "image/jpeg", []byte("jpeg")); err == nil || !strings.Contains(err.Error(), "signature mismatch") {
+		t.Errorf("err = %v, want signature mismatch", err)
+	}
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+)
+
+// bundleChoiceAvailable は構成商品を枠の数量だけ用意できるか（販売中で在庫があり、販売時間帯の中か）を判定します
+func bundleChoiceAvailable(choice models.BundleChoice, quantity int, loc *time.Location, now time.Time) bool {
+	if !choice.IsAvailable {
+		return false
+	}
+	if choice.StockQuantity != nil && *choice.StockQuantity < quantity {
+		return false
+	}
+	return IsWithinSchedule(choice.AvailabilityWindows, loc, now)
+}
+
+// ResolveBundleSelection は注文で選ばれた選択肢をセット商品の枠と照合し、1個あたりの加算額と注文商品に保存する構成商品を返します。
+// 選択肢が1つの枠は指定がなくてもその構成商品を選び、複数ある枠はちょうど1つの指定が必要です。
+// 返す構成商品は枠の表示順に並びます。セットでない商品に選択肢を指定した場合もエラーにします。
+// modifierPriceDeltaは選んだオプションの加算額で、オプションと選択肢の値引きを合わせて価格が0円未満になる場合もエラーにします。
+func ResolveBundleSelection(item models.Item, modifierPriceDelta int, slots []models.BundleSlot, choiceIDs []int, loc *time.Location, now time.Time) (int, []models.OrderItemComponent, error) {
+	selected := make(map[int]bool, len(choiceIDs))
+	for _, id := range choiceIDs {
+		if selected[id] {
+			return 0, nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgBundleChoiceDuplicated, apperrors.Params{"item_name": item.ItemName, "choice_id": id})
+		}
+		selected[id] = true
+	}
+
+	var priceDelta int
+	var components []models.OrderItemComponent
+	matched := 0
+	for _, slot := range slots {
+		var chosen []models.BundleChoice
+		for _, choice := range slot.Choices {
+			if selected[choice.BundleChoiceID] {
+				chosen = append(chosen, choice)
+			}
+		}
+		matched += len(chosen)
+
+		if len(chosen) == 0 && len(slot.Choices) == 1 {
+			chosen = slot.Choices
+		}
+		if len(chosen) == 0 {
+			return 0, nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgBundleSlotRequired, apperrors.Params{"item_name": item.ItemName, "slot_name": slot.Name})
+		}
+		if len(chosen) > 1 {
+			return 0, nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgBundleSlotSingleChoice, apperrors.Params{"item_name": item.ItemName, "slot_name": slot.Name})
+		}
+
+		choice := chosen[0]
+		if !bundleChoiceAvailable(choice, slot.Quantity, loc, now) {
+			return 0, nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgBundleChoiceUnavailable, apperrors.Params{"item_name": item.ItemName, "choice_name": choice.ItemName})
+		}
+		priceDelta += choice.PriceDelta
+		components = append(components, models.OrderItemComponent{
+			BundleChoiceID: sql.NullInt64{Int64: int64(choice.BundleChoiceID), Valid: true},
+			ItemID:         choice.ItemID,
+			SlotName:       slot.Name,
+			ItemName:       choice.ItemName,
+			Quantity:       slot.Quantity,
+			PriceDelta:     choice.PriceDelta,
+		})
+	}
+
+	if matched != len(selected) {
+		return 0, nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgUnknownBundleChoice, apperrors.Params{"item_name": item.ItemName})
+	}
+	if item.Price+modifierPriceDelta+priceDelta < 0 {
+		return 0, nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgBundleNegativePrice, apperrors.Params{"item_name": item.ItemName})
+	}
+
+	return priceDelta, components, nil
+}
+
+// applyBundle はセット商品の構成をメニューに設定します。選べる構成商品のない枠があれば販売停止にし、
+// 在庫数を管理している構成商品があれば、作れるセットの数を在庫数にします
+func applyBundle(item *models.ItemListResponse, slots []models.BundleSlot, loc *time.Location, now time.Time) {
+	if len(slots) == 0 {
+		return
+	}
+	item.Bundle = &models.BundleResponse{Slots: toBundleSlotResponses(slots, loc, now)}
+
+	stock := item.StockQuantity
+	for _, slot := range slots {
+		if len(slot.Choices) > 0 {
+			item.Bundle.RegularPrice += slot.Choices[0].Price * slot.Quantity
+		}
+
+		// 枠で作れるセットの数は、選べる構成商品ごとの数の合計。在庫数を管理していない構成商品があれば制限はない
+		available, limited, capacity := false, true, 0
+		for _, choice := range slot.Choices {
+			if !bundleChoiceAvailable(choice, slot.Quantity, loc, now) {
+				continue
+			}
+			available = true
+			if choice.StockQuantity == nil {
+				limited = false
+				continue
+			}
+			capacity += *choice.StockQuantity / slot.Quantity
+		}
+		if !available {
+			item.IsAvailable = false
+			continue
+		}
+		if limited && (stock == nil || capacity < *stock) {
+			stock = &capacity
+		}
+	}
+	item.StockQuantity = stock
+	if stock != nil && *stock == 0 {
+		item.IsAvailable = false
+	}
+}
+
+// toBundleSlotResponses はセット商品の枠をメニュー表示用のレスポンスに変換します
+func toBundleSlotResponses(slots []models.BundleSlot, loc *time.Location, now time.Time) []models.BundleSlotResponse {
+	responses := make([]models.BundleSlotResponse, len(slots))
+	for i, slot := range slots {
+		choices := make([]models.BundleChoiceResponse, len(slot.Choices))
+		for j, c := range slot.Choices {
+			choices[j] = models.BundleChoiceResponse{
+				BundleChoiceID: c.BundleChoiceID,
+				ItemID:         c.ItemID,
+				ItemName:       c.ItemName,
+				PriceDelta:     c.PriceDelta,
+				IsAvailable:    bundleChoiceAvailable(c, slot.Quantity, loc, now),
+			}
+		}
+		responses[i] = models.BundleSlotResponse{
+			BundleSlotID: slot.BundleSlotID,
+			Name:         slot.Name,
+			Quantity:     slot.Quantity,
+			Choices:      choices,
+		}
+	}
+	return responses
+}
@@ -0,0 +1,166 @@
+package services_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/google/go-cmp/cmp"
+)
+
+// newTestBundleSlots はラーメンを選べて、餃子が固定のセットを作ります
+func newTestBundleSlots() []models.BundleSlot {
+	return []models.BundleSlot{
+		{
+			BundleSlotID: 1,
+			Name:         "ラーメン",
+			Quantity:     1,
+			Choices: []models.BundleChoice{
+				{BundleChoiceID: 11, ItemID: 5, ItemName: "特製豚骨ラーメン", Price: 950, IsAvailable: true},
+				{BundleChoiceID: 12, ItemID: 6, ItemName: "味玉つけ麺", Price: 1050, PriceDelta: 100, IsAvailable: true},
+				{BundleChoiceID: 13, ItemID: 7, ItemName: "限定まぜそば", Price: 1000, IsAvailable: true, StockQuantity: intPtr(0)},
+			},
+		},
+		{
+			BundleSlotID: 2,
+			Name:         "餃子",
+			Quantity:     2,
+			Choices: []models.BundleChoice{
+				{BundleChoiceID: 21, ItemID: 20, ItemName: "焼き餃子", Price: 300, IsAvailable: true},
+			},
+		},
+	}
+}
+
+func bundleChoiceID(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: true}
+}
+
+func TestResolveBundleSelection(t *testing.T) {
+	item := models.Item{ItemID: 30, ItemName: "ラーメン餃子セット", Price: 1200}
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+	// 2025-07-04（金）12:00
+	now := time.Date(2025, 7, 4, 12, 0, 0, 0, tokyo)
+
+	dinnerOnly := newTestBundleSlots()
+	dinnerOnly[0].Choices[1].AvailabilityWindows = []models.AvailabilityWindow{{DayOfWeek: time.Friday, StartMinute: 17 * 60, EndMinute: 22 * 60}}
+	// 豚骨ラーメンを選ぶと1000円引き
+	discounted := newTestBundleSlots()
+	discounted[0].Choices[0].PriceDelta = -1000
+
+	tests := []struct {
+		name               string
+		slots              []models.BundleSlot
+		choiceIDs          []int
+		modifierPriceDelta int
+		wantDelta          int
+		wantComponents     []models.OrderItemComponent
+		expectedErrCode    apperrors.ErrCode
+	}{
+		{
+			name:      "正常系: セットでない商品",
+			slots:     nil,
+			choiceIDs: nil,
+		},
+		{
+			name:      "正常系: 選んだラーメンと固定の餃子が枠の順に並ぶ",
+			slots:     newTestBundleSlots(),
+			choiceIDs: []int{12},
+			wantDelta: 100,
+			wantComponents: []models.OrderItemComponent{
+				{BundleChoiceID: bundleChoiceID(12), ItemID: 6, SlotName: "ラーメン", ItemName: "味玉つけ麺", Quantity: 1, PriceDelta: 100},
+				{BundleChoiceID: bundleChoiceID(21), ItemID: 20, SlotName: "餃子", ItemName: "焼き餃子", Quantity: 2},
+			},
+		},
+		{
+			name:      "正常系: 固定の枠の選択肢を指定してもよい",
+			slots:     newTestBundleSlots(),
+			choiceIDs: []int{21, 11},
+			wantComponents: []models.OrderItemComponent{
+				{BundleChoiceID: bundleChoiceID(11), ItemID: 5, SlotName: "ラーメン", ItemName: "特製豚骨ラーメン", Quantity: 1},
+				{BundleChoiceID: bundleChoiceID(21), ItemID: 20, SlotName: "餃子", ItemName: "焼き餃子", Quantity: 2},
+			},
+		},
+		{
+			name:               "正常系: オプションと選択肢の値引きを合わせて0円ちょうど",
+			slots:              discounted,
+			choiceIDs:          []int{11},
+			modifierPriceDelta: -200,
+			wantDelta:          -1000,
+			wantComponents: []models.OrderItemComponent{
+				{BundleChoiceID: bundleChoiceID(11), ItemID: 5, SlotName: "ラーメン", ItemName: "特製豚骨ラーメン", Quantity: 1, PriceDelta: -1000},
+				{BundleChoiceID: bundleChoiceID(21), ItemID: 20, SlotName: "餃子", ItemName: "焼き餃子", Quantity: 2},
+			},
+		},
+		{
+			name:               "異常系: オプションと選択肢の値引きを合わせると0円未満",
+			slots:              discounted,
+			choiceIDs:          []int{11},
+			modifierPriceDelta: -300,
+			expectedErrCode:    apperrors.BadParam,
+		},
+		{
+			name:            "異常系: 選択肢が複数ある枠が未選択",
+			slots:           newTestBundleSlots(),
+			choiceIDs:       nil,
+			expectedErrCode: apperrors.BadParam,
+		},
+		{
+			name:            "異常系: 1つの枠で2つ選択",
+			slots:           newTestBundleSlots(),
+			choiceIDs:       []int{11, 12},
+			expectedErrCode: apperrors.BadParam,
+		},
+		{
+			name:            "異常系: 在庫切れの構成商品",
+			slots:           newTestBundleSlots(),
+			choiceIDs:       []int{13},
+			expectedErrCode: apperrors.BadParam,
+		},
+		{
+			name:            "異常系: 販売時間帯の外の構成商品",
+			slots:           dinnerOnly,
+			choiceIDs:       []int{12},
+			expectedErrCode: apperrors.BadParam,
+		},
+		{
+			name:            "異常系: 存在しない選択肢",
+			slots:           newTestBundleSlots(),
+			choiceIDs:       []int{11, 99},
+			expectedErrCode: apperrors.BadParam,
+		},
+		{
+			name:            "異常系: 選択肢の重複",
+			slots:           newTestBundleSlots(),
+			choiceIDs:       []int{11, 11},
+			expectedErrCode: apperrors.BadParam,
+		},
+		{
+			name:            "異常系: セットでない商品に選択肢を指定",
+			slots:           nil,
+			choiceIDs:       []int{11},
+			expectedErrCode: apperrors.BadParam,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delta, components, err := services.ResolveBundleSelection(item, tt.modifierPriceDelta, tt.slots, tt.choiceIDs, tokyo, now)
+			if tt.expectedErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+				return
+			}
+			testhelpers.AssertNoError(t, err)
+			if delta != tt.wantDelta {
+				t.Errorf("delta = %d, want %d", delta, tt.wantDelta)
+			}
+			if diff := cmp.Diff(tt.wantComponents, components); diff != "" {
+				t.Errorf("components mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
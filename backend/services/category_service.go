@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/jmoiron/sqlx"
+)
+
+type CategoryServicer interface {
+	CreateCategory(ctx context.Context, shopID int, req models.CategoryRequest) (*models.CategoryResponse, error)
+	GetShopCategories(ctx context.Context, shopID int) ([]models.CategoryResponse, error)
+	UpdateCategory(ctx context.Context, shopID int, categoryID int, req models.CategoryRequest) (*models.CategoryResponse, error)
+	DeleteCategory(ctx context.Context, shopID int, categoryID int) error
+	AssignItemCategory(ctx context.Context, shopID int, itemID int, categoryID *int) error
+}
+
+type categoryService struct {
+	r  repositories.CategoryRepository
+	db *sqlx.DB
+}
+
+func NewCategoryService(r repositories.CategoryRepository, db *sqlx.DB) CategoryServicer {
+	return &categoryService{r, db}
+}
+
+// CreateCategory は店舗の商品カテゴリを登録します
+func (s *categoryService) CreateCategory(ctx context.Context, shopID int, req models.CategoryRequest) (*models.CategoryResponse, error) {
+	category := &models.Category{ShopID: shopID, Name: req.Name, SortOrder: req.SortOrder}
+	if err := s.r.CreateCategory(ctx, s.db, category); err != nil {
+		return nil, err
+	}
+	res := toCategoryResponse(*category)
+	return &res, nil
+}
+
+// GetShopCategories は店舗のカテゴリを表示順に取得します
+func (s *categoryService) GetShopCategories(ctx context.Context, shopID int) ([]models.CategoryResponse, error) {
+	categories, err := s.r.FindCategoriesByShopID(ctx, s.db, shopID)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]models.CategoryResponse, len(categories))
+	for i, category := range categories {
+		responses[i] = toCategoryResponse(category)
+	}
+	return responses, nil
+}
+
+// UpdateCategory は店舗のカテゴリの名前と表示順を更新します
+func (s *categoryService) UpdateCategory(ctx context.Context, shopID int, categoryID int, req models.CategoryRequest) (*models.CategoryResponse, error) {
+	category := &models.Category{CategoryID: categoryID, ShopID: shopID, Name: req.Name, SortOrder: req.SortOrder}
+	if err := s.r.UpdateCategory(ctx, s.db, category); err != nil {
+		return nil, err
+	}
+	res := toCategoryResponse(*category)
+	return &res, nil
+}
+
+// DeleteCategory は店舗のカテゴリを削除します。カテゴリの商品はメニューの「その他」に表示されます
+func (s *categoryService) DeleteCategory(ctx context.Context, shopID int, categoryID int) error {
+	return s.r.DeleteCategory(ctx, s.db, shopID, categoryID)
+}
+
+// AssignItemCategory は店舗の商品のカテゴリを設定します（nilでカテゴリなしに戻す）
+func (s *categoryService) AssignItemCategory(ctx context.Context, shopID int, itemID int, categoryID *int) error {
+	return s.r.AssignItemCategory(ctx, s.db, shopID, itemID, categoryID)
+}
+
+func toCategoryResponse(category models.Category) models.CategoryResponse {
+	return models.CategoryResponse{
+		CategoryID: category.CategoryID,
+		Name:       category.Name,
+		SortOrder:  category.SortOrder,
+	}
+}
@@ -0,0 +1,244 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jmoiron/sqlx"
+)
+
+// newCategoryRepositoryMockWithShops は、カテゴリID→店舗IDと商品ID→扱っている店舗IDをもとに、
+// リポジトリと同じく他の店舗のカテゴリや商品をNoDataにするモックを返します
+func newCategoryRepositoryMockWithShops(categoryShops map[int]int, itemShops map[int]int) *CategoryRepositoryMock {
+	notFound := func() error {
+		return apperrors.NoData.Wrap(nil, "指定されたカテゴリが見つからないか、この店舗のものではありません。")
+	}
+	return &CategoryRepositoryMock{
+		UpdateCategoryFunc: func(ctx context.Context, dbtx repositories.DBTX, category *models.Category) error {
+			if categoryShops[category.CategoryID] != category.ShopID {
+				return notFound()
+			}
+			return nil
+		},
+		DeleteCategoryFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int, categoryID int) error {
+			if categoryShops[categoryID] != shopID {
+				return notFound()
+			}
+			return nil
+		},
+		AssignItemCategoryFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, categoryID *int) error {
+			if itemShops[itemID] != shopID || (categoryID != nil && categoryShops[*categoryID] != shopID) {
+				return apperrors.NoData.Wrap(nil, "指定された商品をこの店舗で扱っていないか、カテゴリがこの店舗のものではありません。")
+			}
+			return nil
+		},
+	}
+}
+
+func TestCategoryService_CreateCategory(t *testing.T) {
+	tests := []struct {
+		name            string
+		req             models.CategoryRequest
+		createErr       error
+		want            *models.CategoryResponse
+		expectedErrCode apperrors.ErrCode
+	}{
+		{
+			name: "正常系: 店舗のカテゴリとして登録する",
+			req:  models.CategoryRequest{Name: "ラーメン", SortOrder: 2},
+			want: &models.CategoryResponse{CategoryID: 10, Name: "ラーメン", SortOrder: 2},
+		},
+		{
+			name:            "異常系: 店舗に同じ名前のカテゴリがある",
+			req:             models.CategoryRequest{Name: "ラーメン"},
+			createErr:       apperrors.Conflict.WrapMessage(nil, apperrors.MsgDuplicateCategoryName, nil),
+			expectedErrCode: apperrors.Conflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *models.Category
+			repo := &CategoryRepositoryMock{
+				CreateCategoryFunc: func(ctx context.Context, dbtx repositories.DBTX, category *models.Category) error {
+					if tt.createErr != nil {
+						return tt.createErr
+					}
+					category.CategoryID = 10
+					created = category
+					return nil
+				},
+			}
+
+			got, err := services.NewCategoryService(repo, &sqlx.DB{}).CreateCategory(context.Background(), 1, tt.req)
+
+			if tt.expectedErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+				return
+			}
+			testhelpers.AssertNoError(t, err)
+			if created.ShopID != 1 || created.Name != tt.req.Name || created.SortOrder != tt.req.SortOrder {
+				t.Errorf("登録したカテゴリ = %+v, want shop 1 with %+v", created, tt.req)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCategoryService_GetShopCategories(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories []models.Category
+		want       []models.CategoryResponse
+	}{
+		{
+			name: "正常系: リポジトリの表示順のまま返す",
+			categories: []models.Category{
+				{CategoryID: 3, ShopID: 1, Name: "定食", SortOrder: 0},
+				{CategoryID: 1, ShopID: 1, Name: "ラーメン", SortOrder: 1},
+				{CategoryID: 2, ShopID: 1, Name: "ドリンク", SortOrder: 1},
+			},
+			want: []models.CategoryResponse{
+				{CategoryID: 3, Name: "定食", SortOrder: 0},
+				{CategoryID: 1, Name: "ラーメン", SortOrder: 1},
+				{CategoryID: 2, Name: "ドリンク", SortOrder: 1},
+			},
+		},
+		{
+			name: "正常系: カテゴリがない店舗は空の一覧",
+			want: []models.CategoryResponse{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &CategoryRepositoryMock{
+				FindCategoriesByShopIDFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]models.Category, error) {
+					if shopID != 1 {
+						t.Errorf("shopID = %d, want 1", shopID)
+					}
+					return tt.categories, nil
+				},
+			}
+
+			got, err := services.NewCategoryService(repo, &sqlx.DB{}).GetShopCategories(context.Background(), 1)
+			testhelpers.AssertNoError(t, err)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("categories mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCategoryService_UpdateCategory(t *testing.T) {
+	// カテゴリ1は店舗1、カテゴリ2は店舗2のもの
+	repo := newCategoryRepositoryMockWithShops(map[int]int{1: 1, 2: 2}, nil)
+	categoryService := services.NewCategoryService(repo, &sqlx.DB{})
+
+	tests := []struct {
+		name            string
+		categoryID      int
+		req             models.CategoryRequest
+		want            *models.CategoryResponse
+		expectedErrCode apperrors.ErrCode
+	}{
+		{
+			name:       "正常系: 名前と表示順を更新する",
+			categoryID: 1,
+			req:        models.CategoryRequest{Name: "麺類", SortOrder: 5},
+			want:       &models.CategoryResponse{CategoryID: 1, Name: "麺類", SortOrder: 5},
+		},
+		{
+			name:            "異常系: 他の店舗のカテゴリは更新できない",
+			categoryID:      2,
+			req:             models.CategoryRequest{Name: "麺類"},
+			expectedErrCode: apperrors.NoData,
+		},
+		{
+			name:            "異常系: 存在しないカテゴリ",
+			categoryID:      99,
+			req:             models.CategoryRequest{Name: "麺類"},
+			expectedErrCode: apperrors.NoData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := categoryService.UpdateCategory(context.Background(), 1, tt.categoryID, tt.req)
+
+			if tt.expectedErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+				return
+			}
+			testhelpers.AssertNoError(t, err)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCategoryService_DeleteCategory(t *testing.T) {
+	repo := newCategoryRepositoryMockWithShops(map[int]int{1: 1, 2: 2}, nil)
+	categoryService := services.NewCategoryService(repo, &sqlx.DB{})
+
+	tests := []struct {
+		name            string
+		categoryID      int
+		expectedErrCode apperrors.ErrCode
+	}{
+		{name: "正常系: 店舗のカテゴリを削除する", categoryID: 1},
+		{name: "異常系: 他の店舗のカテゴリは削除できない", categoryID: 2, expectedErrCode: apperrors.NoData},
+		{name: "異常系: 存在しないカテゴリ", categoryID: 99, expectedErrCode: apperrors.NoData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := categoryService.DeleteCategory(context.Background(), 1, tt.categoryID)
+
+			if tt.expectedErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+				return
+			}
+			testhelpers.AssertNoError(t, err)
+		})
+	}
+}
+
+func TestCategoryService_AssignItemCategory(t *testing.T) {
+	// 商品1は店舗1、商品2は店舗2で扱っている
+	repo := newCategoryRepositoryMockWithShops(map[int]int{1: 1, 2: 2}, map[int]int{1: 1, 2: 2})
+	categoryService := services.NewCategoryService(repo, &sqlx.DB{})
+
+	tests := []struct {
+		name            string
+		itemID          int
+		categoryID      *int
+		expectedErrCode apperrors.ErrCode
+	}{
+		{name: "正常系: 店舗のカテゴリを設定する", itemID: 1, categoryID: intPtr(1)},
+		{name: "正常系: nilでカテゴリなしに戻す", itemID: 1, categoryID: nil},
+		{name: "異常系: 他の店舗のカテゴリは設定できない", itemID: 1, categoryID: intPtr(2), expectedErrCode: apperrors.NoData},
+		{name: "異常系: 店舗で扱っていない商品", itemID: 2, categoryID: intPtr(1), expectedErrCode: apperrors.NoData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := categoryService.AssignItemCategory(context.Background(), 1, tt.itemID, tt.categoryID)
+
+			if tt.expectedErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+				return
+			}
+			testhelpers.AssertNoError(t, err)
+		})
+	}
+}
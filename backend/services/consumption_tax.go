@@ -0,0 +1,65 @@
+package services
+
+import (
+	"sort"
+
+	"github.com/A4-dev-team/mobileorder.git/models"
+)
+
+// 消費税率（%）
+const (
+	ReducedTaxRate  = 8  // 軽減税率。持ち帰りの飲食料品
+	StandardTaxRate = 10 // 標準税率。店内飲食と酒類など
+)
+
+// TaxRateFor は商品の税区分と飲食区分から適用する消費税率を返します。
+// 飲食料品でも店内飲食は外食として標準税率になり、酒類などは持ち帰りでも標準税率です。
+func TaxRateFor(category models.TaxCategory, diningOption models.DiningOption) int {
+	if category == models.TaxCategoryFood && diningOption != models.DineIn {
+		return ReducedTaxRate
+	}
+	return StandardTaxRate
+}
+
+// CalculateConsumptionTax は注文商品の税込金額を税率ごとに合計し、税率ごとに含まれる消費税額を割り戻して計算します。
+// 商品の価格は税込（総額表示）で登録するため、注文の合計はメニューに表示した価格の合計から値引きを引いた額になります。
+// クーポンの値引きは税率ごとの対象金額から差し引き、値引き後の金額に含まれる消費税を計算します。
+// 適格請求書の要件に合わせて端数処理は商品ごとではなく税率ごとに1回だけ行い、1円未満は切り捨てます。
+// subtotalは値引き前の税込の小計、taxは合計に含まれる消費税額です。内訳は税率の低い順に並べます。
+func CalculateConsumptionTax(items []models.OrderItem, discounts []models.OrderDiscount) (subtotal int, tax int, lines []models.OrderTaxLine) {
+	taxable := make(map[int]int)
+	for _, item := range items {
+		amount := (item.PriceAtOrder + item.ModifierPriceDelta) * item.Quantity
+		taxable[item.TaxRate] += amount
+		subtotal += amount
+	}
+	for _, discount := range discounts {
+		taxable[discount.TaxRate] -= discount.Amount
+	}
+
+	lines = make([]models.OrderTaxLine, 0, len(taxable))
+	for rate, amount := range taxable {
+		line := models.OrderTaxLine{
+			TaxRate:       rate,
+			TaxableAmount: amount,
+			TaxAmount:     includedTax(amount, rate),
+		}
+		lines = append(lines, line)
+		tax += line.TaxAmount
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i].TaxRate < lines[j].TaxRate })
+	return subtotal, tax, lines
+}
+
+// includedTax は税込金額に含まれる消費税額（1円未満切り捨て）を返します
+func includedTax(amount int, rate int) int {
+	return amount * rate / (100 + rate)
+}
+
+// diningOptionOrDefault は飲食区分が指定されていない注文を持ち帰りとして扱います
+func diningOptionOrDefault(o models.DiningOption) models.DiningOption {
+	if o == models.UnknownDiningOption {
+		return models.Takeout
+	}
+	return o
+}
@@ -0,0 +1,98 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTaxRateFor(t *testing.T) {
+	tests := []struct {
+		name         string
+		category     models.TaxCategory
+		diningOption models.DiningOption
+		want         int
+	}{
+		{name: "飲食料品の持ち帰りは軽減税率", category: models.TaxCategoryFood, diningOption: models.Takeout, want: services.ReducedTaxRate},
+		{name: "飲食料品でも店内飲食は標準税率", category: models.TaxCategoryFood, diningOption: models.DineIn, want: services.StandardTaxRate},
+		{name: "酒類などは持ち帰りでも標準税率", category: models.TaxCategoryStandard, diningOption: models.Takeout, want: services.StandardTaxRate},
+		{name: "酒類などの店内飲食は標準税率", category: models.TaxCategoryStandard, diningOption: models.DineIn, want: services.StandardTaxRate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := services.TaxRateFor(tt.category, tt.diningOption); got != tt.want {
+				t.Errorf("TaxRateFor(%v, %v) = %d, want %d", tt.category, tt.diningOption, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateConsumptionTax(t *testing.T) {
+	tests := []struct {
+		name         string
+		items        []models.OrderItem
+		discounts    []models.OrderDiscount
+		wantSubtotal int
+		wantTax      int
+		wantLines    []models.OrderTaxLine
+	}{
+		{
+			name: "税率ごとに合計してから端数を切り捨てる",
+			items: []models.OrderItem{
+				// 商品ごとに割り戻すと 9 + 9 = 18円になるが、税率ごとでは 265 * 8/108 = 19.6 → 19円
+				{Quantity: 1, PriceAtOrder: 133, TaxRate: 8},
+				{Quantity: 1, PriceAtOrder: 132, TaxRate: 8},
+			},
+			wantSubtotal: 265,
+			wantTax:      19,
+			wantLines: []models.OrderTaxLine{
+				{TaxRate: 8, TaxableAmount: 265, TaxAmount: 19},
+			},
+		},
+		{
+			name: "軽減税率と標準税率が混在する注文はオプションと数量を含めて税率ごとに分ける",
+			items: []models.OrderItem{
+				{Quantity: 1, PriceAtOrder: 550, TaxRate: 10},
+				{Quantity: 2, PriceAtOrder: 850, ModifierPriceDelta: 105, TaxRate: 8},
+			},
+			wantSubtotal: 2460,
+			wantTax:      191, // 1910 * 8/108 = 141.4 → 141, 550 * 10/110 = 50
+			wantLines: []models.OrderTaxLine{
+				{TaxRate: 8, TaxableAmount: 1910, TaxAmount: 141},
+				{TaxRate: 10, TaxableAmount: 550, TaxAmount: 50},
+			},
+		},
+		{
+			name: "値引きは税率ごとの対象金額から引いてから含まれる税額を計算し、小計は値引き前のまま",
+			items: []models.OrderItem{
+				{Quantity: 2, PriceAtOrder: 850, TaxRate: 8},
+				{Quantity: 1, PriceAtOrder: 550, TaxRate: 10},
+			},
+			discounts: []models.OrderDiscount{
+				{TaxRate: 8, Amount: 170},
+				{TaxRate: 10, Amount: 55},
+			},
+			wantSubtotal: 2250,
+			wantTax:      158, // 1530 * 8/108 = 113.3 → 113, 495 * 10/110 = 45
+			wantLines: []models.OrderTaxLine{
+				{TaxRate: 8, TaxableAmount: 1530, TaxAmount: 113},
+				{TaxRate: 10, TaxableAmount: 495, TaxAmount: 45},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subtotal, tax, lines := services.CalculateConsumptionTax(tt.items, tt.discounts)
+			if subtotal != tt.wantSubtotal || tax != tt.wantTax {
+				t.Errorf("subtotal, tax = %d, %d, want %d, %d", subtotal, tax, tt.wantSubtotal, tt.wantTax)
+			}
+			if diff := cmp.Diff(tt.wantLines, lines); diff != "" {
+				t.Errorf("lines mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
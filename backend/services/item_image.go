@@ -0,0 +1,139 @@
+package services
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"golang.org/x/image/webp"
+)
+
+const (
+	// MaxItemImageSize はアップロードできる商品画像のファイルサイズの上限です
+	MaxItemImageSize = 5 << 20
+	// 展開後のメモリを抑えるため、縦横の画素数にも上限を設ける
+	maxItemImageDimension = 6000
+	// 表示用の画像とサムネイルの長辺。これより小さい画像は拡大しない
+	itemImageMaxEdge     = 1200
+	itemThumbnailMaxEdge = 320
+	itemImageJPEGQuality = 85
+)
+
+// ItemImageRendition はアップロードされた画像から生成した、保存する1枚の画像です
+type ItemImageRendition struct {
+	Data        []byte
+	ContentType string
+	Ext         string // ".jpg" か ".png"
+}
+
+type ProcessedItemImage struct {
+	Image     ItemImageRendition // 長辺1200pxまでの表示用の画像
+	Thumbnail ItemImageRendition // 長辺320pxまでのサムネイル
+}
+
+// 画像形式ごとのデコーダ。形式はファイルの先頭のバイト列で判定する
+var itemImageDecoders = map[string]struct {
+	decodeConfig func(io.Reader) (image.Config, error)
+	decode       func(io.Reader) (image.Image, error)
+}{
+	"image/jpeg": {jpeg.DecodeConfig, jpeg.Decode},
+	"image/png":  {png.DecodeConfig, png.Decode},
+	"image/webp": {webp.DecodeConfig, webp.Decode},
+}
+
+// ProcessItemImage はJPEG・PNG・WebPの画像を検証し、表示用の画像とサムネイルを生成します。
+// 生成する画像は透過がなければJPEG、あればPNGです（WebPは書き出せないため変換する）。
+// 元の画像のメタデータ（撮影位置など）は引き継ぎません。
+func ProcessItemImage(data []byte) (*ProcessedItemImage, error) {
+	if len(data) > MaxItemImageSize {
+		return nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgItemImageTooLarge, apperrors.Params{"max_mb": MaxItemImageSize >> 20})
+	}
+	decoder, ok := itemImageDecoders[http.DetectContentType(data)]
+	if !ok {
+		return nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgUnsupportedImageType, nil)
+	}
+
+	cfg, err := decoder.decodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, apperrors.BadParam.WrapMessage(err, apperrors.MsgItemImageUnreadable, nil)
+	}
+	if cfg.Width > maxItemImageDimension || cfg.Height > maxItemImageDimension {
+		return nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgItemImageDimensionTooLarge, apperrors.Params{"max": maxItemImageDimension})
+	}
+	src, err := decoder.decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, apperrors.BadParam.WrapMessage(err, apperrors.MsgItemImageUnreadable, nil)
+	}
+	if src.Bounds().Empty() {
+		return nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgItemImageUnreadable, nil)
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, src.Bounds().Dx(), src.Bounds().Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, src.Bounds().Min, draw.Src)
+
+	res := &ProcessedItemImage{}
+	if res.Image, err = encodeItemImage(resizeToFit(rgba, itemImageMaxEdge)); err != nil {
+		return nil, err
+	}
+	if res.Thumbnail, err = encodeItemImage(resizeToFit(rgba, itemThumbnailMaxEdge)); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// resizeToFit は長辺がmaxEdgeに収まるよう、縦横比を保って縮小します。
+// 縮小先の1画素に対応する元の画素を平均する（面積平均法）ため、縮小しても細部がちらつきません。
+func resizeToFit(src *image.RGBA, maxEdge int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	if sw <= maxEdge && sh <= maxEdge {
+		return src
+	}
+	dw, dh := maxEdge, max(1, sh*maxEdge/sw)
+	if sh > sw {
+		dw, dh = max(1, sw*maxEdge/sh), maxEdge
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for dy := 0; dy < dh; dy++ {
+		y0, y1 := dy*sh/dh, max((dy+1)*sh/dh, dy*sh/dh+1)
+		for dx := 0; dx < dw; dx++ {
+			x0, x1 := dx*sw/dw, max((dx+1)*sw/dw, dx*sw/dw+1)
+			// RGBAはアルファ乗算済みなので、そのまま平均すれば透過部分の色がにじまない
+			var sum [4]int
+			for y := y0; y < y1; y++ {
+				row := src.Pix[y*src.Stride+x0*4 : y*src.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += int(row[i])
+					sum[1] += int(row[i+1])
+					sum[2] += int(row[i+2])
+					sum[3] += int(row[i+3])
+				}
+			}
+			n := (y1 - y0) * (x1 - x0)
+			p := dst.Pix[dy*dst.Stride+dx*4:]
+			for c := range sum {
+				p[c] = uint8((sum[c] + n/2) / n)
+			}
+		}
+	}
+	return dst
+}
+
+func encodeItemImage(img *image.RGBA) (ItemImageRendition, error) {
+	var buf bytes.Buffer
+	if img.Opaque() {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: itemImageJPEGQuality}); err != nil {
+			return ItemImageRendition{}, apperrors.Unknown.Wrap(err, "画像の変換に失敗しました。")
+		}
+		return ItemImageRendition{Data: buf.Bytes(), ContentType: "image/jpeg", Ext: ".jpg"}, nil
+	}
+	if err := png.Encode(&buf, img); err != nil {
+		return ItemImageRendition{}, apperrors.Unknown.Wrap(err, "画像の変換に失敗しました。")
+	}
+	return ItemImageRendition{Data: buf.Bytes(), ContentType: "image/png", Ext: ".png"}, nil
+}
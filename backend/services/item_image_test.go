@@ -0,0 +1,95 @@
+package services_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/services"
+)
+
+// encodeTestPNG は左半分が赤、右半分が青の画像を作ります。opaqueでなければ右半分を透明にします
+func encodeTestPNG(t *testing.T, width int, height int, opaque bool) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if x < width/2 {
+				img.Set(x, y, color.NRGBA{R: 255, A: 255})
+			} else if opaque {
+				img.Set(x, y, color.NRGBA{B: 255, A: 255})
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestProcessItemImage(t *testing.T) {
+	t.Run("大きい画像は縮小してJPEGにする", func(t *testing.T) {
+		res, err := services.ProcessItemImage(encodeTestPNG(t, 2400, 1600, true))
+		testhelpers.AssertNoError(t, err)
+
+		if res.Image.ContentType != "image/jpeg" || res.Image.Ext != ".jpg" || res.Thumbnail.ContentType != "image/jpeg" {
+			t.Fatalf("content types = %s, %s, want image/jpeg", res.Image.ContentType, res.Thumbnail.ContentType)
+		}
+		img, err := jpeg.Decode(bytes.NewReader(res.Image.Data))
+		testhelpers.AssertNoError(t, err)
+		if got := img.Bounds().Size(); got != image.Pt(1200, 800) {
+			t.Errorf("image size = %v, want (1200,800)", got)
+		}
+		thumb, err := jpeg.Decode(bytes.NewReader(res.Thumbnail.Data))
+		testhelpers.AssertNoError(t, err)
+		if got := thumb.Bounds().Size(); got != image.Pt(320, 213) {
+			t.Errorf("thumbnail size = %v, want (320,213)", got)
+		}
+		// 縮小しても左右の色が保たれる
+		if r, _, b, _ := thumb.At(10, 100).RGBA(); r>>8 < 200 || b>>8 > 50 {
+			t.Errorf("left side is not red: r=%d b=%d", r>>8, b>>8)
+		}
+		if r, _, b, _ := thumb.At(310, 100).RGBA(); r>>8 > 50 || b>>8 < 200 {
+			t.Errorf("right side is not blue: r=%d b=%d", r>>8, b>>8)
+		}
+	})
+
+	t.Run("小さい画像は拡大せず、透過があればPNGにする", func(t *testing.T) {
+		res, err := services.ProcessItemImage(encodeTestPNG(t, 200, 300, false))
+		testhelpers.AssertNoError(t, err)
+
+		if res.Thumbnail.ContentType != "image/png" || res.Thumbnail.Ext != ".png" {
+			t.Fatalf("thumbnail content type = %s, want image/png", res.Thumbnail.ContentType)
+		}
+		img, err := png.Decode(bytes.NewReader(res.Image.Data))
+		testhelpers.AssertNoError(t, err)
+		if got := img.Bounds().Size(); got != image.Pt(200, 300) {
+			t.Errorf("image size = %v, want (200,300)", got)
+		}
+		if _, _, _, a := img.At(150, 10).RGBA(); a != 0 {
+			t.Errorf("alpha = %d, want transparent", a)
+		}
+	})
+
+	t.Run("不正な画像", func(t *testing.T) {
+		tests := map[string][]byte{
+			"GIF":       []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;"),
+			"テキスト":      []byte("not an image"),
+			"壊れたPNG":    encodeTestPNG(t, 10, 10, true)[:40],
+			"大きすぎる縦横":   encodeTestPNG(t, 6001, 1, true),
+			"大きすぎるファイル": append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, services.MaxItemImageSize)...),
+		}
+		for name, data := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := services.ProcessItemImage(data)
+				testhelpers.AssertAppError(t, err, apperrors.BadParam)
+			})
+		}
+	})
+}
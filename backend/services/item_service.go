@@ -1,28 +1,570 @@
 package services
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/i18n"
 	"github.com/A4-dev-team/mobileorder.git/models"
 	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/A4-dev-team/mobileorder.git/validators"
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
 
 type ItemServicer interface {
-	GetItemList(shopID int) ([]models.ItemListResponse, error)
+	GetItemList(shopID int, query models.ItemListQuery) ([]models.ItemListResponse, error)
+	GetMenu(ctx context.Context, shopID int, query models.ItemListQuery) (*models.MenuResponse, error)
+	ImportMenu(ctx context.Context, shopID int, format string, file io.Reader, dryRun bool) (*models.MenuImportResponse, error)
+	ExportMenu(shopID int) ([]models.MenuItemRow, error)
+	UploadItemImage(ctx context.Context, shopID int, itemID int, data []byte) (*models.ItemImageResponse, error)
+	SchedulePriceChange(ctx context.Context, shopID int, itemID int, req models.SchedulePriceChangeRequest) (*models.PriceChangeResponse, error)
+	GetPriceHistory(ctx context.Context, shopID int, itemID int) (*models.PriceHistoryResponse, error)
+	CancelPriceChange(ctx context.Context, shopID int, itemID int, priceChangeID int) error
 }
 
 type itemService struct {
-	r  repositories.ItemRepository
-	db *sqlx.DB
+	r     repositories.ItemRepository
+	cr    repositories.CategoryRepository
+	trr   repositories.TranslationRepository
+	blobs BlobStore
+	db    *sqlx.DB
+}
+
+func NewItemService(r repositories.ItemRepository, cr repositories.CategoryRepository, trr repositories.TranslationRepository, blobs BlobStore, db *sqlx.DB) ItemServicer {
+	return &itemService{r, cr, trr, blobs, db}
+}
+
+// GetItemList は店舗の商品一覧を返します。query.Langが日本語以外なら、翻訳のある商品名・説明を翻訳します
+func (s *itemService) GetItemList(shopID int, query models.ItemListQuery) ([]models.ItemListResponse, error) {
+	rows, err := s.r.GetItemList(s.db, shopID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	itemIDs := make([]int, len(rows))
+	for i, row := range rows {
+		itemIDs[i] = row.ItemID
+	}
+	modifierGroupsMap, err := s.r.FindModifierGroupsByItemIDs(context.Background(), s.db, shopID, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	bundleSlotsMap, err := s.r.FindBundleSlotsByItemIDs(context.Background(), s.db, shopID, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	loc := shopLocation(rows[0].TimeZone)
+	itemList := make([]models.ItemListResponse, len(rows))
+	for i, row := range rows {
+		itemList[i] = models.ItemListResponse{
+			ItemID:         row.ItemID,
+			ItemName:       row.ItemName,
+			Description:    row.Description,
+			Price:          row.Price,
+			IsAvailable:    row.IsAvailable,
+			StockQuantity:  row.StockQuantity,
+			CategoryID:     row.CategoryID,
+			TaxCategory:    row.TaxCategory,
+			ModifierGroups: toModifierGroupResponses(modifierGroupsMap[row.ItemID]),
+			Allergens:      row.Allergens,
+			DietaryTags:    row.DietaryTags,
+			ImageURL:       s.blobURL(row.ImageKey),
+			ThumbnailURL:   s.blobURL(row.ThumbnailKey),
+		}
+		applyAvailabilitySchedule(&itemList[i], row.Schedule, loc, now)
+		applyBundle(&itemList[i], bundleSlotsMap[row.ItemID], loc, now)
+	}
+	if i18n.IsTranslationLanguage(query.Lang) {
+		translations, err := s.trr.FindItemTranslations(context.Background(), s.db, query.Lang, translatedItemIDs(itemList))
+		if err != nil {
+			return nil, err
+		}
+		translateItemList(itemList, translations)
+	}
+
+	return filterItemList(itemList, query), nil
+}
+
+// filterItemList は絞り込み条件に合う商品を残します。除くアレルゲンを1つでも含む商品と、
+// 指定した食事の制限のどれかに対応していない商品を除きます。条件がなければそのまま返します
+func filterItemList(itemList []models.ItemListResponse, query models.ItemListQuery) []models.ItemListResponse {
+	if len(query.ExcludeAllergens) == 0 && len(query.DietaryTags) == 0 {
+		return itemList
+	}
+
+	filtered := make([]models.ItemListResponse, 0, len(itemList))
+	for _, item := range itemList {
+		if containsAny(item.Allergens, query.ExcludeAllergens) || !containsAll(item.DietaryTags, query.DietaryTags) {
+			continue
+		}
+		filtered = append(filtered, item)
+	}
+	return filtered
+}
+
+func containsAny(codes []string, targets []string) bool {
+	for _, target := range targets {
+		if slices.Contains(codes, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAll(codes []string, targets []string) bool {
+	for _, target := range targets {
+		if !slices.Contains(codes, target) {
+			return false
+		}
+	}
+	return true
+}
+
+// applyAvailabilitySchedule は販売時間帯の外の商品を販売停止にし、次に注文できる日時を設定します
+func applyAvailabilitySchedule(item *models.ItemListResponse, schedule []models.AvailabilityWindow, loc *time.Location, now time.Time) {
+	item.AvailabilityWindows = toAvailabilityWindowResponses(schedule)
+	if IsWithinSchedule(schedule, loc, now) {
+		return
+	}
+	item.IsAvailable = false
+	if next, ok := NextAvailableTime(schedule, loc, now); ok {
+		next = next.In(loc)
+		item.NextAvailableAt = &next
+	}
+}
+
+// uncategorizedSectionName はカテゴリのない商品をまとめるメニューのセクション名です
+const uncategorizedSectionName = "その他"
+
+// GetMenu は店舗の商品をカテゴリの表示順にまとめて返します。
+// 商品のないカテゴリは含めず、カテゴリのない商品は最後の「その他」にまとめます。絞り込み条件と翻訳は商品一覧と同じです。
+func (s *itemService) GetMenu(ctx context.Context, shopID int, query models.ItemListQuery) (*models.MenuResponse, error) {
+	itemList, err := s.GetItemList(shopID, query)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.cr.FindCategoriesByShopID(ctx, s.db, shopID)
+	if err != nil {
+		return nil, err
+	}
+	if i18n.IsTranslationLanguage(query.Lang) {
+		categoryIDs := make([]int, len(categories))
+		for i, category := range categories {
+			categoryIDs[i] = category.CategoryID
+		}
+		translations, err := s.trr.FindCategoryTranslations(ctx, s.db, query.Lang, categoryIDs)
+		if err != nil {
+			return nil, err
+		}
+		translateCategories(categories, translations)
+	}
+	return buildMenu(shopID, categories, itemList, localizedUncategorizedSectionName(query.Lang)), nil
 }
 
-func NewItemService(r repositories.ItemRepository, db *sqlx.DB) ItemServicer {
-	return &itemService{r, db}
+// buildMenu は商品をカテゴリごとのセクションに分けます。セクション内の商品は商品一覧と同じ順に並びます
+func buildMenu(shopID int, categories []models.Category, itemList []models.ItemListResponse, uncategorizedName string) *models.MenuResponse {
+	itemsByCategory := make(map[int][]models.ItemListResponse, len(categories))
+	var uncategorized []models.ItemListResponse
+	for _, item := range itemList {
+		if item.CategoryID == nil {
+			uncategorized = append(uncategorized, item)
+			continue
+		}
+		itemsByCategory[*item.CategoryID] = append(itemsByCategory[*item.CategoryID], item)
+	}
+
+	res := &models.MenuResponse{ShopID: shopID, Sections: []models.MenuSection{}}
+	for _, category := range categories {
+		items := itemsByCategory[category.CategoryID]
+		if len(items) == 0 {
+			continue
+		}
+		categoryID := category.CategoryID
+		res.Sections = append(res.Sections, models.MenuSection{CategoryID: &categoryID, Name: category.Name, Items: items})
+	}
+	if len(uncategorized) > 0 {
+		res.Sections = append(res.Sections, models.MenuSection{Name: uncategorizedName, Items: uncategorized})
+	}
+	return res
+}
+
+// ImportMenu はメニューファイル（csv, json）の商品を店舗の商品として登録・更新します。
+// 変更は1つのトランザクションで反映し、エラーのある行が1つでもあれば何も反映しません。dryRunでは差分とエラーだけを返します。
+func (s *itemService) ImportMenu(ctx context.Context, shopID int, format string, file io.Reader, dryRun bool) (*models.MenuImportResponse, error) {
+	rows, rowErrors, err := parseMenuFile(format, file)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		current, err := s.r.FindShopMenuItems(ctx, s.db, shopID)
+		if err != nil {
+			return nil, err
+		}
+		res, _ := buildMenuImport(shopID, rows, rowErrors, current)
+		res.DryRun = true
+		return res, nil
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.Unknown.Wrap(err, "トランザクションの開始に失敗しました。")
+	}
+	defer tx.Rollback()
+
+	current, err := s.r.FindShopMenuItems(ctx, tx, shopID)
+	if err != nil {
+		return nil, err
+	}
+	res, items := buildMenuImport(shopID, rows, rowErrors, current)
+	if len(res.Errors) > 0 {
+		return res, nil
+	}
+
+	for i, change := range res.Changes {
+		item := items[i]
+		if change.Action == menuActionCreate {
+			if err := s.r.CreateShopMenuItem(ctx, tx, shopID, item); err != nil {
+				return nil, err
+			}
+			itemID := item.ItemID
+			res.Changes[i].ItemID = &itemID
+			continue
+		}
+		if err := s.r.UpdateShopMenuItem(ctx, tx, shopID, item); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.Unknown.Wrap(err, "トランザクションのコミットに失敗しました。")
+	}
+	res.Applied = true
+	return res, nil
 }
 
-func (s *itemService) GetItemList(shopID int) ([]models.ItemListResponse, error) {
+// ExportMenu は店舗の商品一覧を、取り込みと同じ形式の行にします。販売状態は商品一覧と同じく、在庫数が0の商品は販売停止になります。
+// 取り込むと全店舗共通の価格が変わるため、価格は店舗ごとの価格ではなくitems.priceを書き出します
+func (s *itemService) ExportMenu(shopID int) ([]models.MenuItemRow, error) {
 	itemList, err := s.r.GetItemList(s.db, shopID)
 	if err != nil {
 		return nil, err
 	}
-	return itemList, nil
+
+	rows := make([]models.MenuItemRow, len(itemList))
+	for i, item := range itemList {
+		itemID := item.ItemID
+		isAvailable := item.IsAvailable
+		rows[i] = models.MenuItemRow{
+			ItemID:        &itemID,
+			ItemName:      item.ItemName,
+			Description:   item.Description,
+			Price:         item.BasePrice,
+			TaxCategory:   item.TaxCategory.String(),
+			IsAvailable:   &isAvailable,
+			StockQuantity: item.StockQuantity,
+		}
+	}
+	return rows, nil
+}
+
+const (
+	menuActionCreate = "create"
+	menuActionUpdate = "update"
+)
+
+// buildMenuImport はメニューファイルの行を店舗の現在の商品と比べ、登録・更新する商品とその差分を求めます。
+// 返す商品は res.Changes と同じ順に並びます。
+func buildMenuImport(shopID int, rows []models.MenuItemRow, rowErrors []models.MenuRowError, current []models.Item) (*models.MenuImportResponse, []*models.Item) {
+	res := &models.MenuImportResponse{
+		ShopID:  shopID,
+		Changes: []models.MenuItemChange{},
+		Errors:  []models.MenuRowError{},
+	}
+	currentByID := make(map[int]models.Item, len(current))
+	for _, item := range current {
+		currentByID[item.ItemID] = item
+	}
+
+	// 読み取りで失敗した項目は検証のエラーを重ねて返さず、その行の差分も求めない
+	type rowField struct {
+		row   int
+		field string
+	}
+	parseFailed := make(map[rowField]bool)
+	invalidRows := make(map[int]bool)
+	for _, rowError := range rowErrors {
+		parseFailed[rowField{rowError.Row, rowError.Field}] = true
+		invalidRows[rowError.Row] = true
+	}
+	res.Errors = append(res.Errors, rowErrors...)
+
+	validator := validators.NewValidator[models.MenuItemRow]()
+	seenItemIDs := make(map[int]int)
+	var items []*models.Item
+	for i, row := range rows {
+		rowNumber := i + 1
+		if err := validator.Validate(row); err != nil {
+			for _, rowError := range menuRowValidationErrors(rowNumber, err) {
+				if !parseFailed[rowField{rowNumber, rowError.Field}] {
+					res.Errors = append(res.Errors, rowError)
+				}
+			}
+			continue
+		}
+		if invalidRows[rowNumber] {
+			continue
+		}
+
+		if row.ItemID == nil {
+			item := &models.Item{
+				ItemName:      row.ItemName,
+				Description:   row.Description,
+				Price:         row.Price,
+				IsAvailable:   row.IsAvailable == nil || *row.IsAvailable,
+				TaxCategory:   parseTaxCategory(row.TaxCategory),
+				StockQuantity: row.StockQuantity,
+			}
+			res.Created++
+			res.Changes = append(res.Changes, models.MenuItemChange{
+				Row:      rowNumber,
+				Action:   menuActionCreate,
+				ItemName: item.ItemName,
+				Fields:   diffMenuItem(nil, item),
+			})
+			items = append(items, item)
+			continue
+		}
+
+		itemID := *row.ItemID
+		if firstRow, ok := seenItemIDs[itemID]; ok {
+			res.Errors = append(res.Errors, newMenuRowError(rowNumber, "item_id", apperrors.MsgMenuRowDuplicateItemID, apperrors.Params{"row": firstRow}))
+			continue
+		}
+		seenItemIDs[itemID] = rowNumber
+		before, ok := currentByID[itemID]
+		if !ok {
+			res.Errors = append(res.Errors, newMenuRowError(rowNumber, "item_id", apperrors.MsgMenuRowItemNotInShop, nil))
+			continue
+		}
+
+		item := &models.Item{
+			ItemID:        itemID,
+			ItemName:      row.ItemName,
+			Description:   row.Description,
+			Price:         row.Price,
+			IsAvailable:   before.IsAvailable,
+			TaxCategory:   parseTaxCategory(row.TaxCategory),
+			StockQuantity: row.StockQuantity,
+		}
+		if row.IsAvailable != nil {
+			item.IsAvailable = *row.IsAvailable
+		}
+		fields := diffMenuItem(&before, item)
+		if len(fields) == 0 {
+			res.Unchanged++
+			continue
+		}
+		res.Updated++
+		res.Changes = append(res.Changes, models.MenuItemChange{
+			Row:      rowNumber,
+			Action:   menuActionUpdate,
+			ItemID:   &item.ItemID,
+			ItemName: item.ItemName,
+			Fields:   fields,
+		})
+		items = append(items, item)
+	}
+
+	sort.SliceStable(res.Errors, func(i, j int) bool { return res.Errors[i].Row < res.Errors[j].Row })
+	return res, items
+}
+
+// diffMenuItem は商品の項目ごとの変更を返します。beforeがnilのときは登録する商品のすべての項目を返します
+func diffMenuItem(before *models.Item, after *models.Item) []models.MenuFieldChange {
+	stock := func(q *int) any {
+		if q == nil {
+			return nil
+		}
+		return *q
+	}
+	type field struct {
+		name          string
+		before, after any
+	}
+	fields := []field{
+		{"item_name", nil, after.ItemName},
+		{"description", nil, after.Description},
+		{"price", nil, after.Price},
+		{"tax_category", nil, after.TaxCategory.String()},
+		{"is_available", nil, after.IsAvailable},
+		{"stock_quantity", nil, stock(after.StockQuantity)},
+	}
+	if before == nil {
+		changes := make([]models.MenuFieldChange, len(fields))
+		for i, f := range fields {
+			changes[i] = models.MenuFieldChange{Field: f.name, After: f.after}
+		}
+		return changes
+	}
+
+	fields[0].before = before.ItemName
+	fields[1].before = before.Description
+	fields[2].before = before.Price
+	fields[3].before = before.TaxCategory.String()
+	fields[4].before = before.IsAvailable
+	fields[5].before = stock(before.StockQuantity)
+	var changes []models.MenuFieldChange
+	for _, f := range fields {
+		if f.before != f.after {
+			changes = append(changes, models.MenuFieldChange{Field: f.name, Before: f.before, After: f.after})
+		}
+	}
+	return changes
+}
+
+// UploadItemImage は担当店舗の商品の画像を検証して表示用の画像とサムネイルを生成し、BlobStoreに保存します。
+// 差し替えた古い画像は削除します。
+func (s *itemService) UploadItemImage(ctx context.Context, shopID int, itemID int, data []byte) (*models.ItemImageResponse, error) {
+	processed, err := ProcessItemImage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// 差し替え中も古いURLの画像を配信できるよう、アップロードごとに新しいキーにする
+	baseKey := fmt.Sprintf("items/%d/%s", itemID, uuid.NewString())
+	imageKey := baseKey + processed.Image.Ext
+	thumbnailKey := baseKey + "_thumb" + processed.Thumbnail.Ext
+	if err := s.blobs.Put(ctx, imageKey, processed.Image.ContentType, processed.Image.Data); err != nil {
+		return nil, apperrors.InsertDataFailed.Wrap(err, "画像の保存に失敗しました。")
+	}
+	if err := s.blobs.Put(ctx, thumbnailKey, processed.Thumbnail.ContentType, processed.Thumbnail.Data); err != nil {
+		s.deleteBlobs(ctx, imageKey)
+		return nil, apperrors.InsertDataFailed.Wrap(err, "画像の保存に失敗しました。")
+	}
+
+	previousKeys, err := s.r.UpdateItemImage(ctx, s.db, shopID, itemID, imageKey, thumbnailKey)
+	if err != nil {
+		s.deleteBlobs(ctx, imageKey, thumbnailKey)
+		return nil, err
+	}
+	s.deleteBlobs(ctx, previousKeys...)
+
+	return &models.ItemImageResponse{
+		ItemID:       itemID,
+		ImageURL:     s.blobs.URL(imageKey),
+		ThumbnailURL: s.blobs.URL(thumbnailKey),
+	}, nil
+}
+
+// deleteBlobs は使わなくなった画像を削除します。
+// 残ったファイルは配信されないだけなので、削除に失敗してもエラーにせずログに残します。
+func (s *itemService) deleteBlobs(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := s.blobs.Delete(ctx, key); err != nil {
+			log.Printf("failed to delete blob %q: %v", key, err)
+		}
+	}
+}
+
+func (s *itemService) blobURL(key *string) *string {
+	if key == nil {
+		return nil
+	}
+	url := s.blobs.URL(*key)
+	return &url
+}
+
+// SchedulePriceChange は店舗の商品の価格を変更します。effective_atを指定するとその日時に反映する予定として登録し、
+// 省略するとすぐに反映します。priceがnilの場合は店舗ごとの価格をやめて全店舗共通の価格に戻します。
+func (s *itemService) SchedulePriceChange(ctx context.Context, shopID int, itemID int, req models.SchedulePriceChangeRequest) (*models.PriceChangeResponse, error) {
+	change := &models.PriceChange{ItemID: itemID, Price: req.Price}
+	if req.EffectiveAt != nil {
+		if !req.EffectiveAt.After(time.Now()) {
+			return nil, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgPriceChangeInPast, nil)
+		}
+		change.EffectiveAt = *req.EffectiveAt
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.Unknown.Wrap(err, "トランザクションの開始に失敗しました。")
+	}
+	defer tx.Rollback()
+
+	if err := s.r.CreatePriceChange(ctx, tx, shopID, change); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.Unknown.Wrap(err, "トランザクションのコミットに失敗しました。")
+	}
+	res := toPriceChangeResponse(*change)
+	return &res, nil
+}
+
+// GetPriceHistory は店舗の商品の現在の価格と、価格変更の履歴・予定を返します。
+// 注文時の価格（price_at_order）が共通の価格と違う理由は、注文日時に反映されていた変更で確認できます。
+func (s *itemService) GetPriceHistory(ctx context.Context, shopID int, itemID int) (*models.PriceHistoryResponse, error) {
+	changes, err := s.r.FindPriceChanges(ctx, s.db, shopID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.r.ValidateAndGetItemsForShop(ctx, s.db, shopID, []int{itemID})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.PriceHistoryResponse{
+		ItemID:       itemID,
+		BasePrice:    items[itemID].BasePrice,
+		CurrentPrice: items[itemID].Price,
+		Changes:      make([]models.PriceChangeResponse, len(changes)),
+	}
+	for i, change := range changes {
+		res.Changes[i] = toPriceChangeResponse(change)
+	}
+	return res, nil
+}
+
+// CancelPriceChange はまだ反映していない店舗の商品の価格変更を取り消します
+func (s *itemService) CancelPriceChange(ctx context.Context, shopID int, itemID int, priceChangeID int) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return apperrors.Unknown.Wrap(err, "トランザクションの開始に失敗しました。")
+	}
+	defer tx.Rollback()
+
+	if err := s.r.CancelPriceChange(ctx, tx, shopID, itemID, priceChangeID); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return apperrors.Unknown.Wrap(err, "トランザクションのコミットに失敗しました。")
+	}
+	return nil
+}
+
+func toPriceChangeResponse(change models.PriceChange) models.PriceChangeResponse {
+	res := models.PriceChangeResponse{
+		PriceChangeID: change.PriceChangeID,
+		ItemID:        change.ItemID,
+		Price:         change.Price,
+		EffectiveAt:   change.EffectiveAt.UTC(),
+		CreatedAt:     change.CreatedAt,
+	}
+	if change.AppliedAt.Valid {
+		appliedAt := change.AppliedAt.Time.UTC()
+		res.AppliedAt = &appliedAt
+	}
+	return res
 }
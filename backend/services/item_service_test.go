@@ -1,6 +1,22 @@
 package services_test
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jmoiron/sqlx"
+)
 
 // 最低限のテスト（ビルドエラーを避けるため）
 func TestItemService_Placeholder(t *testing.T) {
@@ -8,3 +24,751 @@ func TestItemService_Placeholder(t *testing.T) {
 	// repository層の実装が完了したら追加予定
 	t.Skip("Implementation pending")
 }
+
+// ItemRepositoryMockForItem - ItemService用のItemRepositoryモック
+type ItemRepositoryMockForItem struct {
+	ItemRepositoryMock
+	GetItemListFunc       func(dbtx repositories.DBTX, shopID int) ([]repositories.ItemListDB, error)
+	FindShopMenuItemsFunc func(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]models.Item, error)
+	UpdateItemImageFunc   func(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, imageKey string, thumbnailKey string) ([]string, error)
+
+	ValidateAndGetItemsForShopFunc func(ctx context.Context, dbtx repositories.DBTX, shopID int, itemIDs []int) (map[int]models.Item, error)
+	FindPriceChangesFunc           func(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int) ([]models.PriceChange, error)
+	FindBundleSlotsByItemIDsFunc   func(ctx context.Context, dbtx repositories.DBTX, shopID int, itemIDs []int) (map[int][]models.BundleSlot, error)
+}
+
+func (m *ItemRepositoryMockForItem) GetItemList(dbtx repositories.DBTX, shopID int) ([]repositories.ItemListDB, error) {
+	if m.GetItemListFunc != nil {
+		return m.GetItemListFunc(dbtx, shopID)
+	}
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForItem) FindModifierGroupsByItemIDs(ctx context.Context, dbtx repositories.DBTX, shopID int, itemIDs []int) (map[int][]models.ModifierGroup, error) {
+	return map[int][]models.ModifierGroup{}, nil
+}
+
+func (m *ItemRepositoryMockForItem) FindBundleSlotsByItemIDs(ctx context.Context, dbtx repositories.DBTX, shopID int, itemIDs []int) (map[int][]models.BundleSlot, error) {
+	if m.FindBundleSlotsByItemIDsFunc != nil {
+		return m.FindBundleSlotsByItemIDsFunc(ctx, dbtx, shopID, itemIDs)
+	}
+	return map[int][]models.BundleSlot{}, nil
+}
+
+func (m *ItemRepositoryMockForItem) FindShopMenuItems(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]models.Item, error) {
+	if m.FindShopMenuItemsFunc != nil {
+		return m.FindShopMenuItemsFunc(ctx, dbtx, shopID)
+	}
+	panic("not implemented")
+}
+
+// CategoryRepositoryMock - CategoryRepositoryのモック実装
+type CategoryRepositoryMock struct {
+	CreateCategoryFunc         func(ctx context.Context, dbtx repositories.DBTX, category *models.Category) error
+	FindCategoriesByShopIDFunc func(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]models.Category, error)
+	UpdateCategoryFunc         func(ctx context.Context, dbtx repositories.DBTX, category *models.Category) error
+	DeleteCategoryFunc         func(ctx context.Context, dbtx repositories.DBTX, shopID int, categoryID int) error
+	AssignItemCategoryFunc     func(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, categoryID *int) error
+}
+
+func (m *CategoryRepositoryMock) CreateCategory(ctx context.Context, dbtx repositories.DBTX, category *models.Category) error {
+	if m.CreateCategoryFunc != nil {
+		return m.CreateCategoryFunc(ctx, dbtx, category)
+	}
+	panic("not implemented")
+}
+
+func (m *CategoryRepositoryMock) FindCategoriesByShopID(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]models.Category, error) {
+	if m.FindCategoriesByShopIDFunc != nil {
+		return m.FindCategoriesByShopIDFunc(ctx, dbtx, shopID)
+	}
+	panic("not implemented")
+}
+
+func (m *CategoryRepositoryMock) UpdateCategory(ctx context.Context, dbtx repositories.DBTX, category *models.Category) error {
+	if m.UpdateCategoryFunc != nil {
+		return m.UpdateCategoryFunc(ctx, dbtx, category)
+	}
+	panic("not implemented")
+}
+
+func (m *CategoryRepositoryMock) DeleteCategory(ctx context.Context, dbtx repositories.DBTX, shopID int, categoryID int) error {
+	if m.DeleteCategoryFunc != nil {
+		return m.DeleteCategoryFunc(ctx, dbtx, shopID, categoryID)
+	}
+	panic("not implemented")
+}
+
+func (m *CategoryRepositoryMock) AssignItemCategory(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, categoryID *int) error {
+	if m.AssignItemCategoryFunc != nil {
+		return m.AssignItemCategoryFunc(ctx, dbtx, shopID, itemID, categoryID)
+	}
+	panic("not implemented")
+}
+
+func TestItemService_GetMenu(t *testing.T) {
+	repo := &ItemRepositoryMockForItem{
+		GetItemListFunc: func(dbtx repositories.DBTX, shopID int) ([]repositories.ItemListDB, error) {
+			return []repositories.ItemListDB{
+				{ItemID: 1, ItemName: "醤油ラーメン", CategoryID: intPtr(10)},
+				{ItemID: 2, ItemName: "瓶ビール", CategoryID: intPtr(30)},
+				{ItemID: 3, ItemName: "季節の一品", ImageKey: stringPtr("items/3/a.jpg"), ThumbnailKey: stringPtr("items/3/a_thumb.jpg")},
+				{ItemID: 4, ItemName: "味噌ラーメン", CategoryID: intPtr(10)},
+			}, nil
+		},
+	}
+	categoryRepo := &CategoryRepositoryMock{
+		FindCategoriesByShopIDFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]models.Category, error) {
+			// 表示順に返る
+			return []models.Category{
+				{CategoryID: 10, ShopID: shopID, Name: "ラーメン", SortOrder: 1},
+				{CategoryID: 20, ShopID: shopID, Name: "サイドメニュー", SortOrder: 2},
+				{CategoryID: 30, ShopID: shopID, Name: "ドリンク", SortOrder: 3},
+			}, nil
+		},
+	}
+
+	got, err := services.NewItemService(repo, categoryRepo, nil, NewBlobStoreMock(), &sqlx.DB{}).GetMenu(context.Background(), 1, models.ItemListQuery{})
+	testhelpers.AssertNoError(t, err)
+
+	// 商品のないサイドメニューは含めず、カテゴリのない商品は最後の「その他」にまとめる
+	want := &models.MenuResponse{
+		ShopID: 1,
+		Sections: []models.MenuSection{
+			{CategoryID: intPtr(10), Name: "ラーメン", Items: []models.ItemListResponse{
+				{ItemID: 1, ItemName: "醤油ラーメン", CategoryID: intPtr(10)},
+				{ItemID: 4, ItemName: "味噌ラーメン", CategoryID: intPtr(10)},
+			}},
+			{CategoryID: intPtr(30), Name: "ドリンク", Items: []models.ItemListResponse{
+				{ItemID: 2, ItemName: "瓶ビール", CategoryID: intPtr(30)},
+			}},
+			{Name: "その他", Items: []models.ItemListResponse{
+				{
+					ItemID: 3, ItemName: "季節の一品",
+					ImageURL: stringPtr("https://cdn.example.com/items/3/a.jpg"), ThumbnailURL: stringPtr("https://cdn.example.com/items/3/a_thumb.jpg"),
+				},
+			}},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("menu mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestItemService_GetItemList_AvailabilitySchedule(t *testing.T) {
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+	now := time.Now().In(tokyo)
+	today := now.Weekday()
+	tomorrow := (today + 1) % 7
+
+	repo := &ItemRepositoryMockForItem{
+		GetItemListFunc: func(dbtx repositories.DBTX, shopID int) ([]repositories.ItemListDB, error) {
+			return []repositories.ItemListDB{
+				{ItemID: 1, ItemName: "コーヒー", IsAvailable: true, TimeZone: "Asia/Tokyo"},
+				{ItemID: 2, ItemName: "日替わり定食", IsAvailable: true, TimeZone: "Asia/Tokyo", Schedule: []models.AvailabilityWindow{
+					{ItemID: 2, DayOfWeek: today, StartMinute: 0, EndMinute: 1440},
+				}},
+				{ItemID: 3, ItemName: "モーニングセット", IsAvailable: true, TimeZone: "Asia/Tokyo", Schedule: []models.AvailabilityWindow{
+					{ItemID: 3, DayOfWeek: tomorrow, StartMinute: 7 * 60, EndMinute: 10 * 60},
+				}},
+			}, nil
+		},
+	}
+
+	got, err := services.NewItemService(repo, &CategoryRepositoryMock{}, nil, NewBlobStoreMock(), &sqlx.DB{}).GetItemList(1, models.ItemListQuery{})
+	testhelpers.AssertNoError(t, err)
+
+	if !got[0].IsAvailable || got[0].NextAvailableAt != nil || got[0].AvailabilityWindows != nil {
+		t.Errorf("時間帯のない商品 = %+v", got[0])
+	}
+	if !got[1].IsAvailable || got[1].NextAvailableAt != nil {
+		t.Errorf("時間帯の中の商品 = %+v", got[1])
+	}
+	if diff := cmp.Diff([]models.AvailabilityWindowResponse{{DayOfWeek: int(today), Start: "00:00", End: "24:00"}}, got[1].AvailabilityWindows); diff != "" {
+		t.Errorf("availability windows mismatch (-want +got):\n%s", diff)
+	}
+
+	// 時間帯の外の商品は販売停止になり、次に注文できる日時を返す
+	if got[2].IsAvailable {
+		t.Error("時間帯の外の商品が販売中になっています")
+	}
+	wantNext := time.Date(now.Year(), now.Month(), now.Day()+1, 7, 0, 0, 0, tokyo)
+	if got[2].NextAvailableAt == nil || !got[2].NextAvailableAt.Equal(wantNext) {
+		t.Errorf("NextAvailableAt = %v, want %v", got[2].NextAvailableAt, wantNext)
+	}
+}
+
+func TestItemService_GetItemList_Bundle(t *testing.T) {
+	repo := &ItemRepositoryMockForItem{
+		GetItemListFunc: func(dbtx repositories.DBTX, shopID int) ([]repositories.ItemListDB, error) {
+			return []repositories.ItemListDB{
+				{ItemID: 30, ItemName: "ラーメン餃子セット", Price: 1200, IsAvailable: true, TimeZone: "Asia/Tokyo"},
+				{ItemID: 31, ItemName: "チャーシュー丼セット", Price: 1300, IsAvailable: true, TimeZone: "Asia/Tokyo"},
+				{ItemID: 32, ItemName: "ビールセット", Price: 900, IsAvailable: true, TimeZone: "Asia/Tokyo", StockQuantity: intPtr(3)},
+			}, nil
+		},
+		FindBundleSlotsByItemIDsFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int, itemIDs []int) (map[int][]models.BundleSlot, error) {
+			gyoza := models.BundleSlot{BundleSlotID: 2, Name: "餃子", Quantity: 2, Choices: []models.BundleChoice{
+				{BundleChoiceID: 21, ItemID: 20, ItemName: "焼き餃子", Price: 300, IsAvailable: true, StockQuantity: intPtr(9)},
+			}}
+			return map[int][]models.BundleSlot{
+				30: {
+					{BundleSlotID: 1, Name: "ラーメン", Quantity: 1, Choices: []models.BundleChoice{
+						{BundleChoiceID: 11, ItemID: 5, ItemName: "特製豚骨ラーメン", Price: 950, IsAvailable: true, StockQuantity: intPtr(1)},
+						{BundleChoiceID: 12, ItemID: 6, ItemName: "味玉つけ麺", Price: 1050, PriceDelta: 100, IsAvailable: true, StockQuantity: intPtr(2)},
+						{BundleChoiceID: 13, ItemID: 7, ItemName: "限定まぜそば", Price: 1000, IsAvailable: false},
+					}},
+					gyoza,
+				},
+				31: {
+					{BundleSlotID: 3, Name: "丼", Quantity: 1, Choices: []models.BundleChoice{
+						{BundleChoiceID: 31, ItemID: 8, ItemName: "チャーシュー丼", Price: 400, IsAvailable: true, StockQuantity: intPtr(0)},
+					}},
+				},
+				32: {
+					{BundleSlotID: 4, Name: "ビール", Quantity: 1, Choices: []models.BundleChoice{
+						{BundleChoiceID: 41, ItemID: 4, ItemName: "瓶ビール", Price: 550, IsAvailable: true},
+					}},
+					gyoza,
+				},
+			}, nil
+		},
+	}
+
+	got, err := services.NewItemService(repo, &CategoryRepositoryMock{}, nil, NewBlobStoreMock(), &sqlx.DB{}).GetItemList(2, models.ItemListQuery{})
+	testhelpers.AssertNoError(t, err)
+
+	// ラーメンは合わせて3杯、餃子は4セット分あるので3セットまで作れる
+	wantBundle := &models.BundleResponse{
+		RegularPrice: 950 + 300*2,
+		Slots: []models.BundleSlotResponse{
+			{BundleSlotID: 1, Name: "ラーメン", Quantity: 1, Choices: []models.BundleChoiceResponse{
+				{BundleChoiceID: 11, ItemID: 5, ItemName: "特製豚骨ラーメン", IsAvailable: true},
+				{BundleChoiceID: 12, ItemID: 6, ItemName: "味玉つけ麺", PriceDelta: 100, IsAvailable: true},
+				{BundleChoiceID: 13, ItemID: 7, ItemName: "限定まぜそば"},
+			}},
+			{BundleSlotID: 2, Name: "餃子", Quantity: 2, Choices: []models.BundleChoiceResponse{
+				{BundleChoiceID: 21, ItemID: 20, ItemName: "焼き餃子", IsAvailable: true},
+			}},
+		},
+	}
+	if diff := cmp.Diff(wantBundle, got[0].Bundle); diff != "" {
+		t.Errorf("bundle mismatch (-want +got):\n%s", diff)
+	}
+	if !got[0].IsAvailable || got[0].StockQuantity == nil || *got[0].StockQuantity != 3 {
+		t.Errorf("ラーメン餃子セット: is_available = %v, stock_quantity = %v, want true, 3", got[0].IsAvailable, got[0].StockQuantity)
+	}
+
+	// 選べる構成商品のない枠があれば販売停止
+	if got[1].IsAvailable {
+		t.Error("構成商品が売り切れのセットが販売中になっています")
+	}
+
+	// セット自体の在庫数の方が少なければそのまま
+	if !got[2].IsAvailable || got[2].StockQuantity == nil || *got[2].StockQuantity != 3 {
+		t.Errorf("ビールセット: is_available = %v, stock_quantity = %v, want true, 3", got[2].IsAvailable, got[2].StockQuantity)
+	}
+}
+
+func (m *ItemRepositoryMockForItem) UpdateItemImage(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, imageKey string, thumbnailKey string) ([]string, error) {
+	if m.UpdateItemImageFunc != nil {
+		return m.UpdateItemImageFunc(ctx, dbtx, shopID, itemID, imageKey, thumbnailKey)
+	}
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForItem) ValidateAndGetItemsForShop(ctx context.Context, dbtx repositories.DBTX, shopID int, itemIDs []int) (map[int]models.Item, error) {
+	if m.ValidateAndGetItemsForShopFunc != nil {
+		return m.ValidateAndGetItemsForShopFunc(ctx, dbtx, shopID, itemIDs)
+	}
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForItem) FindPriceChanges(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int) ([]models.PriceChange, error) {
+	if m.FindPriceChangesFunc != nil {
+		return m.FindPriceChangesFunc(ctx, dbtx, shopID, itemID)
+	}
+	panic("not implemented")
+}
+
+// BlobStoreMock - メモリに保存するBlobStoreのモック実装
+type BlobStoreMock struct {
+	Blobs map[string]string // キーとContent-Type
+}
+
+func NewBlobStoreMock() *BlobStoreMock {
+	return &BlobStoreMock{Blobs: map[string]string{}}
+}
+
+func (m *BlobStoreMock) Put(ctx context.Context, key string, contentType string, data []byte) error {
+	m.Blobs[key] = contentType
+	return nil
+}
+
+func (m *BlobStoreMock) Delete(ctx context.Context, key string) error {
+	delete(m.Blobs, key)
+	return nil
+}
+
+func (m *BlobStoreMock) URL(key string) string {
+	return "https://cdn.example.com/" + key
+}
+
+func TestItemService_GetItemList_DietaryFilter(t *testing.T) {
+	repo := &ItemRepositoryMockForItem{
+		GetItemListFunc: func(dbtx repositories.DBTX, shopID int) ([]repositories.ItemListDB, error) {
+			return []repositories.ItemListDB{
+				{ItemID: 1, ItemName: "海老天そば", Allergens: []string{"buckwheat", "egg", "shrimp", "wheat"}, DietaryTags: []string{}},
+				{ItemID: 2, ItemName: "野菜カレー", Allergens: []string{"milk", "wheat"}, DietaryTags: []string{"vegetarian"}},
+				{ItemID: 3, ItemName: "豆腐サラダ", Allergens: []string{}, DietaryTags: []string{"gluten_free", "vegan", "vegetarian"}},
+				{ItemID: 4, ItemName: "かにクリームコロッケ", Allergens: []string{"crab", "egg", "milk", "wheat"}, DietaryTags: []string{}},
+			}, nil
+		},
+	}
+	itemService := services.NewItemService(repo, &CategoryRepositoryMock{}, nil, NewBlobStoreMock(), &sqlx.DB{})
+
+	tests := []struct {
+		name    string
+		query   models.ItemListQuery
+		wantIDs []int
+	}{
+		{name: "条件なし", query: models.ItemListQuery{}, wantIDs: []int{1, 2, 3, 4}},
+		{name: "えびとかにを除く", query: models.ItemListQuery{ExcludeAllergens: []string{"shrimp", "crab"}}, wantIDs: []int{2, 3}},
+		{name: "ベジタリアン", query: models.ItemListQuery{DietaryTags: []string{"vegetarian"}}, wantIDs: []int{2, 3}},
+		{name: "食事の制限は全てに対応している商品だけ", query: models.ItemListQuery{DietaryTags: []string{"vegetarian", "vegan"}}, wantIDs: []int{3}},
+		{name: "乳を除くベジタリアン", query: models.ItemListQuery{ExcludeAllergens: []string{"milk"}, DietaryTags: []string{"vegetarian"}}, wantIDs: []int{3}},
+		{name: "該当なしは空の配列", query: models.ItemListQuery{DietaryTags: []string{"halal"}}, wantIDs: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := itemService.GetItemList(1, tt.query)
+			testhelpers.AssertNoError(t, err)
+
+			gotIDs := make([]int, len(got))
+			for i, item := range got {
+				gotIDs[i] = item.ItemID
+			}
+			if diff := cmp.Diff(tt.wantIDs, gotIDs); diff != "" {
+				t.Errorf("item ids mismatch (-want +got):\n%s", diff)
+			}
+			if got == nil {
+				t.Error("items should be an empty slice, got nil")
+			}
+		})
+	}
+}
+
+func TestItemService_UploadItemImage(t *testing.T) {
+	blobs := NewBlobStoreMock()
+	blobs.Blobs["items/1/old.jpg"] = "image/jpeg"
+	blobs.Blobs["items/1/old_thumb.jpg"] = "image/jpeg"
+
+	var savedKeys []string
+	repo := &ItemRepositoryMockForItem{
+		UpdateItemImageFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, imageKey string, thumbnailKey string) ([]string, error) {
+			if itemID != 1 {
+				return nil, apperrors.NoData.Wrap(nil, "指定された商品が見つからないか、この店舗の商品ではありません。")
+			}
+			savedKeys = []string{imageKey, thumbnailKey}
+			return []string{"items/1/old.jpg", "items/1/old_thumb.jpg"}, nil
+		},
+	}
+	itemService := services.NewItemService(repo, &CategoryRepositoryMock{}, nil, blobs, &sqlx.DB{})
+
+	t.Run("画像を差し替え、古い画像を削除する", func(t *testing.T) {
+		res, err := itemService.UploadItemImage(context.Background(), 1, 1, encodeTestPNG(t, 800, 600, true))
+		testhelpers.AssertNoError(t, err)
+
+		if len(blobs.Blobs) != 2 || blobs.Blobs[savedKeys[0]] != "image/jpeg" || blobs.Blobs[savedKeys[1]] != "image/jpeg" {
+			t.Fatalf("blobs = %v, want only the new image and thumbnail", blobs.Blobs)
+		}
+		if !strings.HasPrefix(savedKeys[0], "items/1/") || !strings.HasSuffix(savedKeys[1], "_thumb.jpg") {
+			t.Errorf("unexpected keys: %v", savedKeys)
+		}
+		if res.ImageURL != "https://cdn.example.com/"+savedKeys[0] || res.ThumbnailURL != "https://cdn.example.com/"+savedKeys[1] {
+			t.Errorf("unexpected urls: %+v", res)
+		}
+	})
+
+	t.Run("店舗の商品でなければ保存した画像を削除する", func(t *testing.T) {
+		before := len(blobs.Blobs)
+		_, err := itemService.UploadItemImage(context.Background(), 1, 2, encodeTestPNG(t, 100, 100, true))
+		testhelpers.AssertAppError(t, err, apperrors.NoData)
+		if len(blobs.Blobs) != before {
+			t.Errorf("blobs = %v, uploaded images are left", blobs.Blobs)
+		}
+	})
+
+	t.Run("画像でないファイル", func(t *testing.T) {
+		_, err := itemService.UploadItemImage(context.Background(), 1, 1, []byte("GIF89a"))
+		testhelpers.AssertAppError(t, err, apperrors.BadParam)
+	})
+}
+
+// 行ごとのエラーは日本語の文言で比べ、カタログの文言の種類とパラメータは比べない
+var ignoreMenuRowMessageID = cmpopts.IgnoreFields(models.MenuRowError{}, "MessageID", "Params")
+
+func TestItemService_ImportMenu_DryRun(t *testing.T) {
+	current := []models.Item{
+		{ItemID: 1, ItemName: "唐揚げ定食", Description: "国産鶏もも肉", Price: 800, IsAvailable: true, TaxCategory: models.TaxCategoryFood},
+		{ItemID: 2, ItemName: "瓶ビール", Price: 500, IsAvailable: true, TaxCategory: models.TaxCategoryStandard, StockQuantity: intPtr(10)},
+	}
+	repo := &ItemRepositoryMockForItem{
+		FindShopMenuItemsFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]models.Item, error) {
+			return current, nil
+		},
+	}
+	itemService := services.NewItemService(repo, &CategoryRepositoryMock{}, nil, NewBlobStoreMock(), &sqlx.DB{})
+
+	t.Run("CSVの差分と行ごとのエラー", func(t *testing.T) {
+		file := "\uFEFFitem_id,item_name,description,price,tax_category,is_available,stock_quantity\n" +
+			"1,唐揚げ定食,国産鶏もも肉,850,food,,\n" + // 価格を変更
+			"2,瓶ビール,,500,standard,true,10\n" + // 変更なし
+			",ポテト,,300,food,,\n" + // 新しい商品
+			"3,他店舗の商品,,100,food,,\n" +
+			",,,abc,drink,,\n" +
+			"1,唐揚げ定食,,800,food,,\n"
+
+		got, err := itemService.ImportMenu(context.Background(), 1, "csv", strings.NewReader(file), true)
+		testhelpers.AssertNoError(t, err)
+
+		want := &models.MenuImportResponse{
+			ShopID: 1, DryRun: true, Created: 1, Updated: 1, Unchanged: 1,
+			Changes: []models.MenuItemChange{
+				{Row: 1, Action: "update", ItemID: intPtr(1), ItemName: "唐揚げ定食", Fields: []models.MenuFieldChange{
+					{Field: "price", Before: 800, After: 850},
+				}},
+				{Row: 3, Action: "create", ItemName: "ポテト", Fields: []models.MenuFieldChange{
+					{Field: "item_name", After: "ポテト"},
+					{Field: "description", After: ""},
+					{Field: "price", After: 300},
+					{Field: "tax_category", After: "food"},
+					{Field: "is_available", After: true},
+					{Field: "stock_quantity", After: nil},
+				}},
+			},
+			Errors: []models.MenuRowError{
+				{Row: 4, Field: "item_id", Message: "この店舗の商品ではありません。"},
+				{Row: 5, Field: "price", Message: "整数で指定してください。"},
+				{Row: 5, Field: "item_name", Message: "必須です。"},
+				{Row: 5, Field: "tax_category", Message: "food、standardのいずれかで指定してください。"},
+				{Row: 6, Field: "item_id", Message: "同じ商品IDが1行目にもあります。"},
+			},
+		}
+		if diff := cmp.Diff(want, got, ignoreMenuRowMessageID); diff != "" {
+			t.Errorf("import mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("JSONの検証エラー", func(t *testing.T) {
+		file := `[{"item_name": "", "price": -1, "tax_category": "drink"}]`
+
+		got, err := itemService.ImportMenu(context.Background(), 1, "json", strings.NewReader(file), true)
+		testhelpers.AssertNoError(t, err)
+
+		want := []models.MenuRowError{
+			{Row: 1, Field: "item_name", Message: "必須です。"},
+			{Row: 1, Field: "price", Message: "0以上で指定してください。"},
+			{Row: 1, Field: "tax_category", Message: "food、standardのいずれかで指定してください。"},
+		}
+		if diff := cmp.Diff(want, got.Errors, ignoreMenuRowMessageID); diff != "" {
+			t.Errorf("errors mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ファイル全体を読み取れない", func(t *testing.T) {
+		_, err := itemService.ImportMenu(context.Background(), 1, "csv", strings.NewReader("item_name,price,unknown\n"), true)
+		testhelpers.AssertAppError(t, err, apperrors.BadParam)
+
+		_, err = itemService.ImportMenu(context.Background(), 1, "csv", strings.NewReader("item_name,price,tax_category\n"), true)
+		testhelpers.AssertAppError(t, err, apperrors.BadParam)
+	})
+}
+
+func TestItemService_ExportMenu(t *testing.T) {
+	repo := &ItemRepositoryMockForItem{
+		GetItemListFunc: func(dbtx repositories.DBTX, shopID int) ([]repositories.ItemListDB, error) {
+			return []repositories.ItemListDB{
+				{ItemID: 1, ItemName: "唐揚げ定食", Description: "国産鶏もも肉, 特製だれ", Price: 800, BasePrice: 800, IsAvailable: true, TaxCategory: models.TaxCategoryFood},
+				// 店舗ごとの価格ではなく共通の価格を書き出す
+				{ItemID: 2, ItemName: "瓶ビール", Price: 550, BasePrice: 500, IsAvailable: false, TaxCategory: models.TaxCategoryStandard, StockQuantity: intPtr(0)},
+			}, nil
+		},
+	}
+
+	rows, err := services.NewItemService(repo, &CategoryRepositoryMock{}, nil, NewBlobStoreMock(), &sqlx.DB{}).ExportMenu(1)
+	testhelpers.AssertNoError(t, err)
+
+	want := "\uFEFFitem_id,item_name,description,price,tax_category,is_available,stock_quantity\n" +
+		"1,唐揚げ定食,\"国産鶏もも肉, 特製だれ\",800,food,true,\n" +
+		"2,瓶ビール,,500,standard,false,0\n"
+	if got := string(services.RenderMenuCSV(rows)); got != want {
+		t.Errorf("CSV = %q, want %q", got, want)
+	}
+}
+
+func TestItemService_ImportMenu_CSV(t *testing.T) {
+	const header = "item_id,item_name,description,price,tax_category,is_available,stock_quantity\n"
+	manyRows := func(n int) string {
+		var b strings.Builder
+		b.WriteString("item_name,price,tax_category\n")
+		for i := 0; i < n; i++ {
+			b.WriteString("ポテト,300,food\n")
+		}
+		return b.String()
+	}
+
+	tests := []struct {
+		name            string
+		file            string
+		wantCreated     int
+		wantErrors      []models.MenuRowError
+		expectedErrCode apperrors.ErrCode
+	}{
+		{
+			name:        "正常系: 列の順番が違っても見出しの名前で読み取る",
+			file:        "tax_category, price ,item_name\nstandard,500,ハイボール\n",
+			wantCreated: 1,
+		},
+		{
+			name:        "正常系: 任意の列は省略できる",
+			file:        "item_name,price,tax_category\nポテト,300,food\n",
+			wantCreated: 1,
+		},
+		{
+			name: "正常系: 数値や真偽値として読み取れない値は行ごとのエラー",
+			file: header +
+				"x,唐揚げ定食,,800,food,,\n" +
+				",ポテト,,1.5,food,,\n" +
+				",ポテト,,300,food,yes,\n" +
+				",ポテト,,300,food,,many\n" +
+				",ポテト,,,food,,\n",
+			wantErrors: []models.MenuRowError{
+				{Row: 1, Field: "item_id", Message: "整数で指定してください。"},
+				{Row: 2, Field: "price", Message: "整数で指定してください。"},
+				{Row: 3, Field: "is_available", Message: "trueかfalseで指定してください。"},
+				{Row: 4, Field: "stock_quantity", Message: "整数で指定してください。"},
+				{Row: 5, Field: "price", Message: "必須です。"},
+			},
+		},
+		{
+			name:        "正常系: 行数の上限までは取り込める",
+			file:        manyRows(1000),
+			wantCreated: 1000,
+		},
+		{
+			name:            "異常系: 行数の上限を超える",
+			file:            manyRows(1001),
+			expectedErrCode: apperrors.BadParam,
+		},
+		{
+			name:            "異常系: 見出しに不明な列がある",
+			file:            "item_name,price,tax_category,category\nポテト,300,food,サイド\n",
+			expectedErrCode: apperrors.BadParam,
+		},
+		{
+			name:            "異常系: 見出しに必須の列がない",
+			file:            "item_name,price\nポテト,300\n",
+			expectedErrCode: apperrors.BadParam,
+		},
+		{
+			name:            "異常系: 列の数が見出しと違う行がある",
+			file:            "item_name,price,tax_category\nポテト,300\n",
+			expectedErrCode: apperrors.BadParam,
+		},
+		{
+			name:            "異常系: 見出しだけで商品の行がない",
+			file:            header,
+			expectedErrCode: apperrors.BadParam,
+		},
+		{
+			name:            "異常系: 空のファイル",
+			file:            "",
+			expectedErrCode: apperrors.BadParam,
+		},
+	}
+
+	repo := &ItemRepositoryMockForItem{
+		FindShopMenuItemsFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]models.Item, error) {
+			return []models.Item{{ItemID: 1, ItemName: "唐揚げ定食", Price: 800, IsAvailable: true, TaxCategory: models.TaxCategoryFood}}, nil
+		},
+	}
+	itemService := services.NewItemService(repo, &CategoryRepositoryMock{}, nil, NewBlobStoreMock(), &sqlx.DB{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := itemService.ImportMenu(context.Background(), 1, "csv", strings.NewReader(tt.file), true)
+
+			if tt.expectedErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+				return
+			}
+			testhelpers.AssertNoError(t, err)
+			if got.Created != tt.wantCreated {
+				t.Errorf("Created = %d, want %d", got.Created, tt.wantCreated)
+			}
+			wantErrors := tt.wantErrors
+			if wantErrors == nil {
+				wantErrors = []models.MenuRowError{}
+			}
+			if diff := cmp.Diff(wantErrors, got.Errors, ignoreMenuRowMessageID); diff != "" {
+				t.Errorf("errors mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestItemService_ImportMenu_ItemIDs(t *testing.T) {
+	current := []models.Item{
+		{ItemID: 1, ItemName: "唐揚げ定食", Price: 800, IsAvailable: true, TaxCategory: models.TaxCategoryFood},
+		{ItemID: 2, ItemName: "瓶ビール", Price: 500, IsAvailable: true, TaxCategory: models.TaxCategoryStandard},
+	}
+	repo := &ItemRepositoryMockForItem{
+		FindShopMenuItemsFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]models.Item, error) {
+			return current, nil
+		},
+	}
+	itemService := services.NewItemService(repo, &CategoryRepositoryMock{}, nil, NewBlobStoreMock(), &sqlx.DB{})
+
+	tests := []struct {
+		name          string
+		file          string
+		wantUpdated   int
+		wantUnchanged int
+		wantErrors    []models.MenuRowError
+	}{
+		{
+			name: "同じ商品IDの2行目以降はエラーにし、最初の行だけ比べる",
+			file: "item_id,item_name,price,tax_category\n" +
+				"1,唐揚げ定食,850,food\n" +
+				"2,瓶ビール,500,standard\n" +
+				"1,唐揚げ定食,900,food\n" +
+				"1,唐揚げ定食,950,food\n",
+			wantUpdated:   1,
+			wantUnchanged: 1,
+			wantErrors: []models.MenuRowError{
+				{Row: 3, Field: "item_id", Message: "同じ商品IDが1行目にもあります。"},
+				{Row: 4, Field: "item_id", Message: "同じ商品IDが1行目にもあります。"},
+			},
+		},
+		{
+			name: "他の店舗の商品や存在しない商品IDはエラー",
+			file: "item_id,item_name,price,tax_category\n" +
+				"3,他店舗の商品,100,food\n" +
+				"2,瓶ビール,550,standard\n" +
+				"999,存在しない商品,100,food\n",
+			wantUpdated: 1,
+			wantErrors: []models.MenuRowError{
+				{Row: 1, Field: "item_id", Message: "この店舗の商品ではありません。"},
+				{Row: 3, Field: "item_id", Message: "この店舗の商品ではありません。"},
+			},
+		},
+		{
+			name: "他の店舗の商品IDが重なる場合は重複のエラー",
+			file: "item_id,item_name,price,tax_category\n" +
+				"3,他店舗の商品,100,food\n" +
+				"3,他店舗の商品,100,food\n",
+			wantErrors: []models.MenuRowError{
+				{Row: 1, Field: "item_id", Message: "この店舗の商品ではありません。"},
+				{Row: 2, Field: "item_id", Message: "同じ商品IDが1行目にもあります。"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := itemService.ImportMenu(context.Background(), 1, "csv", strings.NewReader(tt.file), true)
+			testhelpers.AssertNoError(t, err)
+
+			if got.Created != 0 || got.Updated != tt.wantUpdated || got.Unchanged != tt.wantUnchanged {
+				t.Errorf("Created, Updated, Unchanged = %d, %d, %d, want 0, %d, %d", got.Created, got.Updated, got.Unchanged, tt.wantUpdated, tt.wantUnchanged)
+			}
+			if diff := cmp.Diff(tt.wantErrors, got.Errors, ignoreMenuRowMessageID); diff != "" {
+				t.Errorf("errors mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// 書き出したメニューをそのまま取り込むと、変更のない商品だけになる
+func TestItemService_ExportImportMenu_RoundTrip(t *testing.T) {
+	repo := &ItemRepositoryMockForItem{
+		GetItemListFunc: func(dbtx repositories.DBTX, shopID int) ([]repositories.ItemListDB, error) {
+			return []repositories.ItemListDB{
+				{ItemID: 1, ItemName: "唐揚げ定食", Description: "国産鶏もも肉, \"特製\"だれ\n大盛り無料", Price: 850, BasePrice: 800, IsAvailable: true, TaxCategory: models.TaxCategoryFood},
+				{ItemID: 2, ItemName: "瓶ビール", Price: 500, BasePrice: 500, IsAvailable: false, TaxCategory: models.TaxCategoryStandard, StockQuantity: intPtr(0)},
+				{ItemID: 3, ItemName: "ポテト", Price: 300, BasePrice: 300, IsAvailable: true, TaxCategory: models.TaxCategoryFood, StockQuantity: intPtr(20)},
+			}, nil
+		},
+		FindShopMenuItemsFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]models.Item, error) {
+			return []models.Item{
+				{ItemID: 1, ItemName: "唐揚げ定食", Description: "国産鶏もも肉, \"特製\"だれ\n大盛り無料", Price: 800, IsAvailable: true, TaxCategory: models.TaxCategoryFood},
+				{ItemID: 2, ItemName: "瓶ビール", Price: 500, IsAvailable: false, TaxCategory: models.TaxCategoryStandard, StockQuantity: intPtr(0)},
+				{ItemID: 3, ItemName: "ポテト", Price: 300, IsAvailable: true, TaxCategory: models.TaxCategoryFood, StockQuantity: intPtr(20)},
+			}, nil
+		},
+	}
+	itemService := services.NewItemService(repo, &CategoryRepositoryMock{}, nil, NewBlobStoreMock(), &sqlx.DB{})
+
+	rows, err := itemService.ExportMenu(1)
+	testhelpers.AssertNoError(t, err)
+
+	got, err := itemService.ImportMenu(context.Background(), 1, "csv", bytes.NewReader(services.RenderMenuCSV(rows)), true)
+	testhelpers.AssertNoError(t, err)
+
+	want := &models.MenuImportResponse{
+		ShopID:    1,
+		DryRun:    true,
+		Unchanged: 3,
+		Changes:   []models.MenuItemChange{},
+		Errors:    []models.MenuRowError{},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("import mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestItemService_SchedulePriceChange_PastEffectiveAt(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	_, err := services.NewItemService(&ItemRepositoryMockForItem{}, &CategoryRepositoryMock{}, nil, NewBlobStoreMock(), &sqlx.DB{}).
+		SchedulePriceChange(context.Background(), 1, 3, models.SchedulePriceChangeRequest{Price: intPtr(800), EffectiveAt: &past})
+	testhelpers.AssertAppError(t, err, apperrors.ValidationFailed)
+}
+
+func TestItemService_GetPriceHistory(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	applied := time.Date(2025, 9, 1, 0, 0, 3, 0, time.UTC)
+	repo := &ItemRepositoryMockForItem{
+		FindPriceChangesFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int) ([]models.PriceChange, error) {
+			return []models.PriceChange{
+				{PriceChangeID: 2, ItemID: itemID, Price: nil, EffectiveAt: time.Date(2025, 10, 1, 9, 0, 0, 0, jst)},
+				{PriceChangeID: 1, ItemID: itemID, Price: intPtr(800), EffectiveAt: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), AppliedAt: sql.NullTime{Time: applied, Valid: true}},
+			}, nil
+		},
+		ValidateAndGetItemsForShopFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int, itemIDs []int) (map[int]models.Item, error) {
+			return map[int]models.Item{3: {ItemID: 3, Price: 800, BasePrice: 750}}, nil
+		},
+	}
+
+	got, err := services.NewItemService(repo, &CategoryRepositoryMock{}, nil, NewBlobStoreMock(), &sqlx.DB{}).GetPriceHistory(context.Background(), 1, 3)
+	testhelpers.AssertNoError(t, err)
+
+	want := &models.PriceHistoryResponse{
+		ItemID:       3,
+		BasePrice:    750,
+		CurrentPrice: 800,
+		Changes: []models.PriceChangeResponse{
+			// 予定の変更はapplied_atがnull。日時はUTCで返す
+			{PriceChangeID: 2, ItemID: 3, EffectiveAt: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)},
+			{PriceChangeID: 1, ItemID: 3, Price: intPtr(800), EffectiveAt: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), AppliedAt: &applied},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("price history mismatch (-want +got):\n%s", diff)
+	}
+}
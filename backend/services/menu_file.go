@@ -0,0 +1,218 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/i18n"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/validators"
+)
+
+// 一度に取り込めるメニューの行数
+const maxMenuRows = 1000
+
+// メニューのCSVの列。書き出しはこの順に並べ、取り込みでは見出しの名前で列を探す
+var menuCSVColumns = []string{"item_id", "item_name", "description", "price", "tax_category", "is_available", "stock_quantity"}
+
+// menuRequiredCSVColumns はCSVの見出しに必ず含める列です
+var menuRequiredCSVColumns = []string{"item_name", "price", "tax_category"}
+
+// parseMenuFile はメニューファイル（csv, json）を読み取ります。
+// CSVの値を数値や真偽値として読み取れない行はエラーとして返し、ファイル全体を読み取れない場合はerrorを返します。
+func parseMenuFile(format string, r io.Reader) ([]models.MenuItemRow, []models.MenuRowError, error) {
+	var rows []models.MenuItemRow
+	var rowErrors []models.MenuRowError
+	switch format {
+	case "json":
+		if err := json.NewDecoder(r).Decode(&rows); err != nil {
+			return nil, nil, apperrors.BadParam.WrapMessage(err, apperrors.MsgMenuFileInvalidJSON, nil)
+		}
+	case "csv":
+		var err error
+		rows, rowErrors, err = parseMenuCSV(r)
+		if err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgInvalidMenuFormat, nil)
+	}
+
+	if len(rows) == 0 {
+		return nil, nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgMenuFileEmpty, nil)
+	}
+	if len(rows) > maxMenuRows {
+		return nil, nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgMenuFileTooManyRows, apperrors.Params{"max": maxMenuRows})
+	}
+	return rows, rowErrors, nil
+}
+
+func parseMenuCSV(r io.Reader) ([]models.MenuItemRow, []models.MenuRowError, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, apperrors.BadParam.WrapMessage(err, apperrors.MsgMenuFileHeaderUnreadable, nil)
+	}
+
+	// Excelで保存したCSVは先頭にBOMが付く
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\uFEFF")
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if !containsString(menuCSVColumns, name) {
+			return nil, nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgMenuFileUnknownColumn, apperrors.Params{"column": name})
+		}
+		columns[name] = i
+	}
+	for _, name := range menuRequiredCSVColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgMenuFileMissingColumn, apperrors.Params{"column": name})
+		}
+	}
+
+	var rows []models.MenuItemRow
+	var rowErrors []models.MenuRowError
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, apperrors.BadParam.WrapMessage(err, apperrors.MsgMenuFileInvalidCSV, nil)
+		}
+		if len(rows) >= maxMenuRows {
+			return nil, nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgMenuFileTooManyRows, apperrors.Params{"max": maxMenuRows})
+		}
+
+		rowNumber := len(rows) + 1
+		value := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		addError := func(field string, id apperrors.MessageID) {
+			rowErrors = append(rowErrors, newMenuRowError(rowNumber, field, id, nil))
+		}
+
+		row := models.MenuItemRow{
+			ItemName:    value("item_name"),
+			Description: value("description"),
+			TaxCategory: value("tax_category"),
+		}
+		if v := value("item_id"); v != "" {
+			itemID, err := strconv.Atoi(v)
+			if err != nil {
+				addError("item_id", apperrors.MsgFieldInteger)
+			}
+			row.ItemID = &itemID
+		}
+		if v := value("price"); v == "" {
+			addError("price", apperrors.MsgFieldRequired)
+		} else if price, err := strconv.Atoi(v); err != nil {
+			addError("price", apperrors.MsgFieldInteger)
+		} else {
+			row.Price = price
+		}
+		if v := value("is_available"); v != "" {
+			isAvailable, err := strconv.ParseBool(v)
+			if err != nil {
+				addError("is_available", apperrors.MsgFieldBoolean)
+			}
+			row.IsAvailable = &isAvailable
+		}
+		if v := value("stock_quantity"); v != "" {
+			stockQuantity, err := strconv.Atoi(v)
+			if err != nil {
+				addError("stock_quantity", apperrors.MsgFieldInteger)
+			}
+			row.StockQuantity = &stockQuantity
+		}
+		rows = append(rows, row)
+	}
+	return rows, rowErrors, nil
+}
+
+// menuRowValidationErrors は validators での検証エラーを、メニューファイルの列ごとのエラーにします
+func menuRowValidationErrors(rowNumber int, err error) []models.MenuRowError {
+	fieldErrors := validators.FieldErrors(err)
+	if fieldErrors == nil {
+		return []models.MenuRowError{{Row: rowNumber, Message: err.Error()}}
+	}
+
+	rowErrors := make([]models.MenuRowError, 0, len(fieldErrors))
+	for _, fe := range fieldErrors {
+		rowErrors = append(rowErrors, models.MenuRowError{Row: rowNumber, Field: fe.Field, Message: fe.Message, MessageID: fe.MessageID, Params: fe.Params})
+	}
+	return rowErrors
+}
+
+// newMenuRowError はカタログのメッセージでメニューファイルの行のエラーを作ります。Messageには日本語の文言が入ります
+func newMenuRowError(rowNumber int, field string, id apperrors.MessageID, params apperrors.Params) models.MenuRowError {
+	return models.MenuRowError{
+		Row:       rowNumber,
+		Field:     field,
+		Message:   apperrors.Text(i18n.DefaultLanguage, id, params),
+		MessageID: id,
+		Params:    params,
+	}
+}
+
+// RenderMenuCSV はメニューを取り込みと同じ形式のCSVにします。Excelで文字化けしないようBOMを付けます
+func RenderMenuCSV(rows []models.MenuItemRow) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("\uFEFF")
+	w := csv.NewWriter(&buf)
+	w.Write(menuCSVColumns)
+	for _, row := range rows {
+		record := make([]string, 0, len(menuCSVColumns))
+		if row.ItemID != nil {
+			record = append(record, strconv.Itoa(*row.ItemID))
+		} else {
+			record = append(record, "")
+		}
+		record = append(record, row.ItemName, row.Description, strconv.Itoa(row.Price), row.TaxCategory)
+		if row.IsAvailable != nil {
+			record = append(record, strconv.FormatBool(*row.IsAvailable))
+		} else {
+			record = append(record, "")
+		}
+		if row.StockQuantity != nil {
+			record = append(record, strconv.Itoa(*row.StockQuantity))
+		} else {
+			record = append(record, "")
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	return buf.Bytes()
+}
+
+// parseTaxCategory は消費税の区分の名前（food, standard）を TaxCategory にします
+func parseTaxCategory(name string) models.TaxCategory {
+	switch name {
+	case models.TaxCategoryFood.String():
+		return models.TaxCategoryFood
+	case models.TaxCategoryStandard.String():
+		return models.TaxCategoryStandard
+	default:
+		return models.UnknownTaxCategory
+	}
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+)
+
+// モック決済で結果を切り替えるための決済トークン。これ以外のトークン（空を含む）は与信が通る
+const (
+	MockPaymentTokenDeclined = "tok_mock_declined" // カード拒否として失敗させる
+	MockPaymentTokenPending  = "tok_mock_pending"  // 承認待ちにし、結果をWebhookで通知する想定にする
+)
+
+const (
+	mockWebhookAuthorized = "payment.authorized"
+	mockWebhookFailed     = "payment.failed"
+)
+
+// MockPaymentProvider は外部と通信しないローカル用の決済代行会社です。
+// 決済IDは冪等キーから決まるため、同じ入力には常に同じ結果を返します。
+// Webhookは本番の決済代行会社と同じくHMAC-SHA256の署名で検証します。
+type MockPaymentProvider struct {
+	webhookSecret []byte
+}
+
+func NewMockPaymentProvider(webhookSecret string) *MockPaymentProvider {
+	return &MockPaymentProvider{webhookSecret: []byte(webhookSecret)}
+}
+
+func (p *MockPaymentProvider) Name() string {
+	return "mock"
+}
+
+func (p *MockPaymentProvider) Authorize(ctx context.Context, req PaymentAuthorizeRequest) (PaymentProviderResult, error) {
+	if req.IdempotencyKey == "" {
+		return PaymentProviderResult{}, errors.New("mock payment: idempotency key is required")
+	}
+	if req.Amount < 0 {
+		return PaymentProviderResult{}, fmt.Errorf("mock payment: invalid amount %d", req.Amount)
+	}
+
+	result := PaymentProviderResult{ProviderPaymentID: mockPaymentID("pay", req.IdempotencyKey)}
+	switch req.PaymentToken {
+	case MockPaymentTokenDeclined:
+		result.Status = models.PaymentFailed
+		result.FailureReason = "card_declined"
+	case MockPaymentTokenPending:
+		result.Status = models.PaymentPending
+	default:
+		result.Status = models.PaymentAuthorized
+	}
+	return result, nil
+}
+
+func (p *MockPaymentProvider) Capture(ctx context.Context, req PaymentCaptureRequest) (PaymentProviderResult, error) {
+	if !strings.HasPrefix(req.ProviderPaymentID, "mock_pay_") {
+		return PaymentProviderResult{}, fmt.Errorf("mock payment: unknown payment %q", req.ProviderPaymentID)
+	}
+	if req.IdempotencyKey == "" {
+		return PaymentProviderResult{}, errors.New("mock payment: idempotency key is required")
+	}
+	if req.Amount < 0 {
+		return PaymentProviderResult{}, fmt.Errorf("mock payment: invalid amount %d", req.Amount)
+	}
+	return PaymentProviderResult{ProviderPaymentID: req.ProviderPaymentID, Status: models.PaymentCaptured}, nil
+}
+
+func (p *MockPaymentProvider) Refund(ctx context.Context, req PaymentRefundRequest) (PaymentRefundResult, error) {
+	if !strings.HasPrefix(req.ProviderPaymentID, "mock_pay_") {
+		return PaymentRefundResult{}, fmt.Errorf("mock payment: unknown payment %q", req.ProviderPaymentID)
+	}
+	if req.IdempotencyKey == "" {
+		return PaymentRefundResult{}, errors.New("mock payment: idempotency key is required")
+	}
+	if req.Amount <= 0 {
+		return PaymentRefundResult{}, fmt.Errorf("mock payment: invalid refund amount %d", req.Amount)
+	}
+	return PaymentRefundResult{ProviderRefundID: mockPaymentID("re", req.IdempotencyKey)}, nil
+}
+
+// モック決済のWebhookの本文
+type mockWebhookPayload struct {
+	Type              string `json:"type"`
+	ProviderPaymentID string `json:"provider_payment_id"`
+	FailureReason     string `json:"failure_reason,omitempty"`
+}
+
+func (p *MockPaymentProvider) VerifyWebhook(payload []byte, signature string) (*PaymentWebhookEvent, error) {
+	if len(p.webhookSecret) == 0 {
+		return nil, apperrors.Unauthorized.WrapMessage(nil, apperrors.MsgWebhookSecretMissing, nil)
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(got, p.sign(payload)) {
+		return nil, apperrors.Unauthorized.WrapMessage(err, apperrors.MsgInvalidWebhookSignature, nil)
+	}
+
+	var body mockWebhookPayload
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return nil, apperrors.ReqBodyDecodeFailed.WrapMessage(err, apperrors.MsgInvalidWebhookPayload, nil)
+	}
+	if body.ProviderPaymentID == "" {
+		return nil, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgWebhookMissingPaymentID, nil)
+	}
+
+	event := &PaymentWebhookEvent{ProviderPaymentID: body.ProviderPaymentID}
+	switch body.Type {
+	case mockWebhookAuthorized:
+		event.Status = models.PaymentAuthorized
+	case mockWebhookFailed:
+		event.Status = models.PaymentFailed
+		event.FailureReason = body.FailureReason
+	default:
+		return nil, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgUnsupportedWebhookType, apperrors.Params{"type": body.Type})
+	}
+	return event, nil
+}
+
+// SignWebhook はモック決済のWebhookの署名（HMAC-SHA256の16進数表記）を作ります。
+// ローカルでWebhookを送って動作確認するときに使います。
+func (p *MockPaymentProvider) SignWebhook(payload []byte) string {
+	return hex.EncodeToString(p.sign(payload))
+}
+
+func (p *MockPaymentProvider) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, p.webhookSecret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func mockPaymentID(prefix string, idempotencyKey string) string {
+	sum := sha256.Sum256([]byte(idempotencyKey))
+	return "mock_" + prefix + "_" + hex.EncodeToString(sum[:12])
+}
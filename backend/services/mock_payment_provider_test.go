@@ -0,0 +1,132 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/services"
+)
+
+func TestMockPaymentProvider_Authorize(t *testing.T) {
+	provider := services.NewMockPaymentProvider("secret")
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		token             string
+		wantStatus        models.PaymentStatus
+		wantFailureReason string
+	}{
+		{name: "トークンなしは与信が通る", token: "", wantStatus: models.PaymentAuthorized},
+		{name: "通常のトークンは与信が通る", token: "tok_visa", wantStatus: models.PaymentAuthorized},
+		{name: "拒否用のトークンは失敗する", token: services.MockPaymentTokenDeclined, wantStatus: models.PaymentFailed, wantFailureReason: "card_declined"},
+		{name: "承認待ち用のトークンは承認待ちになる", token: services.MockPaymentTokenPending, wantStatus: models.PaymentPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := services.PaymentAuthorizeRequest{IdempotencyKey: "payment-1", Amount: 1200, PaymentToken: tt.token}
+			got, err := provider.Authorize(ctx, req)
+			testhelpers.AssertNoError(t, err)
+			if got.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", got.Status, tt.wantStatus)
+			}
+			if got.FailureReason != tt.wantFailureReason {
+				t.Errorf("FailureReason = %q, want %q", got.FailureReason, tt.wantFailureReason)
+			}
+
+			// 同じ冪等キーなら同じ決済IDになる
+			again, err := provider.Authorize(ctx, req)
+			testhelpers.AssertNoError(t, err)
+			if again.ProviderPaymentID != got.ProviderPaymentID {
+				t.Errorf("ProviderPaymentID が一致しません: %q != %q", again.ProviderPaymentID, got.ProviderPaymentID)
+			}
+		})
+	}
+
+	t.Run("冪等キーが違えば決済IDも違う", func(t *testing.T) {
+		a, _ := provider.Authorize(ctx, services.PaymentAuthorizeRequest{IdempotencyKey: "payment-1", Amount: 100})
+		b, _ := provider.Authorize(ctx, services.PaymentAuthorizeRequest{IdempotencyKey: "payment-2", Amount: 100})
+		if a.ProviderPaymentID == b.ProviderPaymentID {
+			t.Errorf("異なる冪等キーで同じ決済ID %q が返されました", a.ProviderPaymentID)
+		}
+	})
+
+	t.Run("冪等キーがない場合はエラー", func(t *testing.T) {
+		if _, err := provider.Authorize(ctx, services.PaymentAuthorizeRequest{Amount: 100}); err == nil {
+			t.Error("エラーが期待されましたが、nilが返されました")
+		}
+	})
+}
+
+func TestMockPaymentProvider_CaptureAndRefund(t *testing.T) {
+	provider := services.NewMockPaymentProvider("secret")
+	ctx := context.Background()
+
+	authorized, err := provider.Authorize(ctx, services.PaymentAuthorizeRequest{IdempotencyKey: "payment-1", Amount: 1200})
+	testhelpers.AssertNoError(t, err)
+
+	captured, err := provider.Capture(ctx, services.PaymentCaptureRequest{IdempotencyKey: "capture-1", ProviderPaymentID: authorized.ProviderPaymentID, Amount: 1200})
+	testhelpers.AssertNoError(t, err)
+	if captured.Status != models.PaymentCaptured {
+		t.Errorf("Status = %v, want %v", captured.Status, models.PaymentCaptured)
+	}
+
+	if _, err := provider.Capture(ctx, services.PaymentCaptureRequest{IdempotencyKey: "capture-2", ProviderPaymentID: "unknown", Amount: 1200}); err == nil {
+		t.Error("存在しない決済の売上確定はエラーになるべきです")
+	}
+	if _, err := provider.Capture(ctx, services.PaymentCaptureRequest{ProviderPaymentID: authorized.ProviderPaymentID, Amount: 1200}); err == nil {
+		t.Error("冪等キーのない売上確定はエラーになるべきです")
+	}
+
+	refund, err := provider.Refund(ctx, services.PaymentRefundRequest{IdempotencyKey: "refund-1", ProviderPaymentID: authorized.ProviderPaymentID, Amount: 500})
+	testhelpers.AssertNoError(t, err)
+	if refund.ProviderRefundID == "" {
+		t.Error("ProviderRefundID が空です")
+	}
+
+	if _, err := provider.Refund(ctx, services.PaymentRefundRequest{IdempotencyKey: "refund-2", ProviderPaymentID: authorized.ProviderPaymentID, Amount: 0}); err == nil {
+		t.Error("0円の返金はエラーになるべきです")
+	}
+}
+
+func TestMockPaymentProvider_VerifyWebhook(t *testing.T) {
+	provider := services.NewMockPaymentProvider("secret")
+	payload := []byte(`{"type":"payment.failed","provider_payment_id":"mock_pay_abc","failure_reason":"insufficient_funds"}`)
+
+	t.Run("正しい署名は受け付ける", func(t *testing.T) {
+		event, err := provider.VerifyWebhook(payload, provider.SignWebhook(payload))
+		testhelpers.AssertNoError(t, err)
+		if event.ProviderPaymentID != "mock_pay_abc" || event.Status != models.PaymentFailed || event.FailureReason != "insufficient_funds" {
+			t.Errorf("想定外のイベント: %+v", event)
+		}
+	})
+
+	t.Run("別の鍵で署名された通知は拒否する", func(t *testing.T) {
+		other := services.NewMockPaymentProvider("other-secret")
+		_, err := provider.VerifyWebhook(payload, other.SignWebhook(payload))
+		testhelpers.AssertAppError(t, err, apperrors.Unauthorized)
+	})
+
+	t.Run("改ざんされた本文は拒否する", func(t *testing.T) {
+		signature := provider.SignWebhook(payload)
+		tampered := []byte(`{"type":"payment.authorized","provider_payment_id":"mock_pay_abc"}`)
+		_, err := provider.VerifyWebhook(tampered, signature)
+		testhelpers.AssertAppError(t, err, apperrors.Unauthorized)
+	})
+
+	t.Run("署名鍵が未設定なら常に拒否する", func(t *testing.T) {
+		unsigned := services.NewMockPaymentProvider("")
+		_, err := unsigned.VerifyWebhook(payload, unsigned.SignWebhook(payload))
+		testhelpers.AssertAppError(t, err, apperrors.Unauthorized)
+	})
+
+	t.Run("未対応の種類は拒否する", func(t *testing.T) {
+		unknown := []byte(`{"type":"payment.disputed","provider_payment_id":"mock_pay_abc"}`)
+		_, err := provider.VerifyWebhook(unknown, provider.SignWebhook(unknown))
+		testhelpers.AssertAppError(t, err, apperrors.ValidationFailed)
+	})
+}
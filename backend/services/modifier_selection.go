@@ -0,0 +1,91 @@
+package services
+
+import (
+	"database/sql"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+)
+
+// ResolveModifierSelection は注文で選ばれたオプションを商品のオプショングループと照合し、
+// 1個あたりの加算額と注文商品に保存するオプションを返します。
+// 返すオプションはグループと選択肢の表示順に並びます。加算後の価格が0円未満になる選択はBadParamを返します。
+func ResolveModifierSelection(item models.Item, groups []models.ModifierGroup, optionIDs []int) (int, []models.OrderItemModifier, error) {
+	selected := make(map[int]bool, len(optionIDs))
+	for _, id := range optionIDs {
+		if selected[id] {
+			return 0, nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgModifierOptionDuplicated, apperrors.Params{"item_name": item.ItemName, "option_id": id})
+		}
+		selected[id] = true
+	}
+
+	var priceDelta int
+	var modifiers []models.OrderItemModifier
+	matched := 0
+	for _, group := range groups {
+		count := 0
+		for _, option := range group.Options {
+			if !selected[option.ModifierOptionID] {
+				continue
+			}
+			if !option.IsAvailable {
+				return 0, nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgModifierOptionUnavailable, apperrors.Params{"item_name": item.ItemName, "option_name": option.Name})
+			}
+			count++
+			priceDelta += option.PriceDelta
+			modifiers = append(modifiers, models.OrderItemModifier{
+				ModifierOptionID: sql.NullInt64{Int64: int64(option.ModifierOptionID), Valid: true},
+				GroupName:        group.Name,
+				OptionName:       option.Name,
+				PriceDelta:       option.PriceDelta,
+			})
+		}
+		matched += count
+
+		if count < group.MinSelect {
+			return 0, nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgModifierMinSelect, apperrors.Params{"item_name": item.ItemName, "group_name": group.Name, "min": group.MinSelect})
+		}
+		if count > group.MaxSelect {
+			return 0, nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgModifierMaxSelect, apperrors.Params{"item_name": item.ItemName, "group_name": group.Name, "max": group.MaxSelect})
+		}
+	}
+
+	if matched != len(selected) {
+		return 0, nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgUnknownModifierOption, apperrors.Params{"item_name": item.ItemName})
+	}
+	// 値引きのオプションで1個あたりの価格がマイナスにならないようにする
+	if item.Price+priceDelta < 0 {
+		return 0, nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgModifierNegativePrice, apperrors.Params{"item_name": item.ItemName})
+	}
+
+	return priceDelta, modifiers, nil
+}
+
+// toModifierGroupResponses はオプショングループをメニュー表示用のレスポンスに変換します
+func toModifierGroupResponses(groups []models.ModifierGroup) []models.ModifierGroupResponse {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	responses := make([]models.ModifierGroupResponse, len(groups))
+	for i, g := range groups {
+		options := make([]models.ModifierOptionResponse, len(g.Options))
+		for j, o := range g.Options {
+			options[j] = models.ModifierOptionResponse{
+				ModifierOptionID: o.ModifierOptionID,
+				Name:             o.Name,
+				PriceDelta:       o.PriceDelta,
+				IsAvailable:      o.IsAvailable,
+			}
+		}
+		responses[i] = models.ModifierGroupResponse{
+			ModifierGroupID: g.ModifierGroupID,
+			Name:            g.Name,
+			SelectionType:   g.SelectionType.String(),
+			MinSelect:       g.MinSelect,
+			MaxSelect:       g.MaxSelect,
+			Options:         options,
+		}
+	}
+	return responses
+}
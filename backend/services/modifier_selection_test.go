@@ -0,0 +1,169 @@
+package services_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/google/go-cmp/cmp"
+)
+
+func newTestModifierGroups() []models.ModifierGroup {
+	return []models.ModifierGroup{
+		{
+			ModifierGroupID: 1,
+			Name:            "サイズ",
+			SelectionType:   models.SingleSelect,
+			MinSelect:       1,
+			MaxSelect:       1,
+			Options: []models.ModifierOption{
+				{ModifierOptionID: 11, Name: "並盛り", PriceDelta: 0, IsAvailable: true},
+				{ModifierOptionID: 12, Name: "大盛り", PriceDelta: 100, IsAvailable: true},
+			},
+		},
+		{
+			ModifierGroupID: 2,
+			Name:            "トッピング",
+			SelectionType:   models.MultiSelect,
+			MinSelect:       0,
+			MaxSelect:       2,
+			Options: []models.ModifierOption{
+				{ModifierOptionID: 21, Name: "味玉", PriceDelta: 120, IsAvailable: true},
+				{ModifierOptionID: 22, Name: "替え玉", PriceDelta: 150, IsAvailable: true},
+				{ModifierOptionID: 23, Name: "ねぎ抜き", PriceDelta: -20, IsAvailable: true},
+				{ModifierOptionID: 24, Name: "チャーシュー", PriceDelta: 200, IsAvailable: false},
+			},
+		},
+	}
+}
+
+// newTestDiscountModifierGroups は商品価格（950円）に近い値引きの選択肢を持つグループを返します
+func newTestDiscountModifierGroups() []models.ModifierGroup {
+	return []models.ModifierGroup{
+		{
+			ModifierGroupID: 3,
+			Name:            "クーポン",
+			SelectionType:   models.SingleSelect,
+			MinSelect:       0,
+			MaxSelect:       1,
+			Options: []models.ModifierOption{
+				{ModifierOptionID: 31, Name: "無料券", PriceDelta: -950, IsAvailable: true},
+				{ModifierOptionID: 32, Name: "1000円引き", PriceDelta: -1000, IsAvailable: true},
+			},
+		},
+	}
+}
+
+func modifierOptionID(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: true}
+}
+
+func TestResolveModifierSelection(t *testing.T) {
+	item := models.Item{ItemID: 5, ItemName: "特製豚骨ラーメン", Price: 950}
+
+	tests := []struct {
+		name            string
+		groups          []models.ModifierGroup
+		optionIDs       []int
+		wantDelta       int
+		wantModifiers   []models.OrderItemModifier
+		expectedErrCode apperrors.ErrCode
+	}{
+		{
+			name:      "正常系: オプションのない商品",
+			groups:    nil,
+			optionIDs: nil,
+			wantDelta: 0,
+		},
+		{
+			name:      "正常系: 単一選択と複数選択を組み合わせ、表示順に並ぶ",
+			groups:    newTestModifierGroups(),
+			optionIDs: []int{23, 12, 21},
+			wantDelta: 100 + 120 - 20,
+			wantModifiers: []models.OrderItemModifier{
+				{ModifierOptionID: modifierOptionID(12), GroupName: "サイズ", OptionName: "大盛り", PriceDelta: 100},
+				{ModifierOptionID: modifierOptionID(21), GroupName: "トッピング", OptionName: "味玉", PriceDelta: 120},
+				{ModifierOptionID: modifierOptionID(23), GroupName: "トッピング", OptionName: "ねぎ抜き", PriceDelta: -20},
+			},
+		},
+		{
+			name:            "異常系: 必須グループが未選択",
+			groups:          newTestModifierGroups(),
+			optionIDs:       []int{21},
+			expectedErrCode: apperrors.BadParam,
+		},
+		{
+			name:            "異常系: 単一選択のグループで複数選択",
+			groups:          newTestModifierGroups(),
+			optionIDs:       []int{11, 12},
+			expectedErrCode: apperrors.BadParam,
+		},
+		{
+			name:            "異常系: 最大選択数を超えている",
+			groups:          newTestModifierGroups(),
+			optionIDs:       []int{11, 21, 22, 23},
+			expectedErrCode: apperrors.BadParam,
+		},
+		{
+			name:            "異常系: 選択できない選択肢",
+			groups:          newTestModifierGroups(),
+			optionIDs:       []int{11, 24},
+			expectedErrCode: apperrors.BadParam,
+		},
+		{
+			name:            "異常系: 他の商品の選択肢",
+			groups:          newTestModifierGroups(),
+			optionIDs:       []int{11, 99},
+			expectedErrCode: apperrors.BadParam,
+		},
+		{
+			name:            "異常系: オプションのない商品に選択肢を指定",
+			groups:          nil,
+			optionIDs:       []int{11},
+			expectedErrCode: apperrors.BadParam,
+		},
+		{
+			name:            "異常系: 同じ選択肢が重複している",
+			groups:          newTestModifierGroups(),
+			optionIDs:       []int{11, 21, 21},
+			expectedErrCode: apperrors.BadParam,
+		},
+		{
+			name:      "正常系: 値引き後の価格がちょうど0円",
+			groups:    newTestDiscountModifierGroups(),
+			optionIDs: []int{31},
+			wantDelta: -950,
+			wantModifiers: []models.OrderItemModifier{
+				{ModifierOptionID: modifierOptionID(31), GroupName: "クーポン", OptionName: "無料券", PriceDelta: -950},
+			},
+		},
+		{
+			name:            "異常系: 値引き後の価格が0円未満",
+			groups:          newTestDiscountModifierGroups(),
+			optionIDs:       []int{32},
+			expectedErrCode: apperrors.BadParam,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delta, modifiers, err := services.ResolveModifierSelection(item, tt.groups, tt.optionIDs)
+
+			if tt.expectedErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+				return
+			}
+
+			testhelpers.AssertNoError(t, err)
+			if delta != tt.wantDelta {
+				t.Errorf("delta = %d, want %d", delta, tt.wantDelta)
+			}
+			if diff := cmp.Diff(tt.wantModifiers, modifiers); diff != "" {
+				t.Errorf("modifiers mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/A4-dev-team/mobileorder.git/models"
+)
+
+// 書き出す注文の日時は日本時間で表示する
+const orderExportTimeLayout = "2006-01-02 15:04:05"
+
+// 書き出す列。Numericの列はExcelで数値として扱う
+var orderExportColumns = []struct {
+	Header  string
+	Numeric bool
+}{
+	{"注文ID", true},
+	{"注文日時", false},
+	{"ステータス", false},
+	{"飲食区分", false},
+	{"お客様", false},
+	{"商品ID", true},
+	{"商品名", false},
+	{"数量", true},
+	{"単価（税込）", true},
+	{"オプション加算額（税込）", true},
+	{"金額（税込）", true},
+	{"税率（%）", true},
+	{"注文小計（税込）", true},
+	{"注文値引き（税込）", true},
+	{"注文消費税（内税）", true},
+	{"注文合計（税込）", true},
+}
+
+// orderExportWriter は書き出す行を1行ずつ出力します。Closeで残りを書き出します
+type orderExportWriter interface {
+	WriteRow(values []string) error
+	Close() error
+}
+
+// newOrderExportWriter は format（csv, xlsx）に応じた書き出し先を作り、見出しの行を書き込みます
+func newOrderExportWriter(w io.Writer, format string) (orderExportWriter, error) {
+	var ew orderExportWriter
+	if format == "xlsx" {
+		xw, err := newXLSXOrderExportWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		ew = xw
+	} else {
+		cw, err := newCSVOrderExportWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		ew = cw
+	}
+
+	headers := make([]string, len(orderExportColumns))
+	for i, column := range orderExportColumns {
+		headers[i] = column.Header
+	}
+	if err := ew.WriteRow(headers); err != nil {
+		return nil, err
+	}
+	return ew, nil
+}
+
+// orderExportRow は注文の商品1行を書き出す値にします
+func orderExportRow(line models.OrderExportLine) []string {
+	return []string{
+		strconv.Itoa(line.OrderID),
+		line.OrderDate.In(reportLocation).Format(orderExportTimeLayout),
+		orderStatusLabel(line.Status),
+		diningOptionLabel(line.DiningOption.String()),
+		orderExportCustomer(line),
+		strconv.Itoa(line.ItemID),
+		line.ItemName,
+		strconv.Itoa(line.Quantity),
+		strconv.Itoa(line.PriceAtOrder),
+		strconv.Itoa(line.ModifierPriceDelta),
+		strconv.Itoa((line.PriceAtOrder + line.ModifierPriceDelta) * line.Quantity),
+		strconv.Itoa(line.TaxRate),
+		strconv.Itoa(line.SubtotalAmount),
+		strconv.Itoa(line.DiscountAmount),
+		strconv.Itoa(line.TaxAmount),
+		strconv.Itoa(line.TotalAmount),
+	}
+}
+
+// orderExportCustomer は会員のメールアドレスを返します。
+// ゲスト注文のトークンは領収書を見るのに使えるため、末尾の4文字だけを残して伏せます。
+func orderExportCustomer(line models.OrderExportLine) string {
+	if line.CustomerEmail.Valid {
+		return line.CustomerEmail.String
+	}
+	token := line.GuestOrderToken.String
+	if len(token) <= 4 {
+		return "ゲスト"
+	}
+	return "ゲスト（****" + token[len(token)-4:] + "）"
+}
+
+func orderStatusLabel(status models.OrderStatus) string {
+	switch status {
+	case models.Cooking:
+		return "調理中"
+	case models.Completed:
+		return "調理完了"
+	case models.Handed:
+		return "お渡し済み"
+	default:
+		return status.String()
+	}
+}
+
+// csvOrderExportWriter はExcelで文字化けしないよう、BOM付きのUTF-8でCSVを書き出します
+type csvOrderExportWriter struct {
+	w *csv.Writer
+}
+
+func newCSVOrderExportWriter(w io.Writer) (*csvOrderExportWriter, error) {
+	if _, err := io.WriteString(w, "\uFEFF"); err != nil {
+		return nil, err
+	}
+	return &csvOrderExportWriter{w: csv.NewWriter(w)}, nil
+}
+
+func (c *csvOrderExportWriter) WriteRow(values []string) error {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		if i < len(orderExportColumns) && orderExportColumns[i].Numeric {
+			escaped[i] = value
+			continue
+		}
+		escaped[i] = escapeCSVFormula(value)
+	}
+	return c.w.Write(escaped)
+}
+
+func (c *csvOrderExportWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// escapeCSVFormula は表計算ソフトで数式として実行されないよう、記号で始まる文字列の先頭に ' を付けます
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// XLSXはZIPにまとめたXMLで、シートの行はZIPに直接書き込んでいく
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="注文" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetFooter = `</sheetData></worksheet>`
+)
+
+// xlsxOrderExportWriter は1枚のシートだけのXLSXを書き出します。文字列はインライン文字列として書き込みます
+type xlsxOrderExportWriter struct {
+	zw    *zip.Writer
+	sheet *bufio.Writer
+	rows  int
+}
+
+func newXLSXOrderExportWriter(w io.Writer) (*xlsxOrderExportWriter, error) {
+	zw := zip.NewWriter(w)
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, part := range parts {
+		pw, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(pw, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	// シートは最後のファイルにして、行を書き込むたびにZIPへ流す
+	sw, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	sheet := bufio.NewWriter(sw)
+	if _, err := sheet.WriteString(xlsxSheetHeader); err != nil {
+		return nil, err
+	}
+	return &xlsxOrderExportWriter{zw: zw, sheet: sheet}, nil
+}
+
+func (x *xlsxOrderExportWriter) WriteRow(values []string) error {
+	x.rows++
+	// 見出しの行は数値の列も文字列として書き込む
+	header := x.rows == 1
+	x.sheet.WriteString(`<row r="` + strconv.Itoa(x.rows) + `">`)
+	for i, value := range values {
+		if !header && i < len(orderExportColumns) && orderExportColumns[i].Numeric {
+			x.sheet.WriteString(`<c><v>` + value + `</v></c>`)
+			continue
+		}
+		x.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		if err := xml.EscapeText(x.sheet, []byte(value)); err != nil {
+			return err
+		}
+		x.sheet.WriteString(`</t></is></c>`)
+	}
+	_, err := x.sheet.WriteString(`</row>`)
+	return err
+}
+
+func (x *xlsxOrderExportWriter) Close() error {
+	if _, err := x.sheet.WriteString(xlsxSheetFooter); err != nil {
+		return err
+	}
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+	return x.zw.Close()
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"database/sql"
+	"strings"
+	"unicode"
+
+	"github.com/A4-dev-team/mobileorder.git/models"
+)
+
+// SanitizeNote はお客様が入力したメモを厨房で表示できる形に整えます。
+// 改行やタブは空白に置き換え、制御文字やゼロ幅文字・文字方向の制御文字は取り除き、
+// 連続する空白を1つにまとめて前後の空白を削ります。
+func SanitizeNote(note string) string {
+	var b strings.Builder
+	b.Grow(len(note))
+	pendingSpace := false
+	for _, r := range note {
+		switch {
+		case unicode.IsSpace(r):
+			pendingSpace = b.Len() > 0
+			continue
+		case unicode.IsControl(r), unicode.Is(unicode.Cf, r), r == unicode.ReplacementChar:
+			continue
+		}
+		if pendingSpace {
+			b.WriteRune(' ')
+			pendingSpace = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// toNoteNullString は整えたメモを保存用に変換します。空のメモはNULLとして保存します。
+func toNoteNullString(note string) sql.NullString {
+	sanitized := SanitizeNote(note)
+	return sql.NullString{String: sanitized, Valid: sanitized != ""}
+}
+
+// hasItemNote は注文商品のいずれかにメモが付いているかを返します。
+func hasItemNote(items []models.ItemDetail) bool {
+	for _, item := range items {
+		if item.Note != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/services"
+)
+
+func TestSanitizeNote(t *testing.T) {
+	tests := []struct {
+		name string
+		note string
+		want string
+	}{
+		{name: "そのまま", note: "ネギ抜きでお願いします", want: "ネギ抜きでお願いします"},
+		{name: "前後の空白を削る", note: "  辛さ控えめ \n", want: "辛さ控えめ"},
+		{name: "改行やタブは1つの空白にまとめる", note: "麺硬め\r\n\tスープ少なめ", want: "麺硬め スープ少なめ"},
+		{name: "全角空白も空白として扱う", note: "卵　アレルギー", want: "卵 アレルギー"},
+		{name: "制御文字を取り除く", note: "そば\x00\x1bアレルギー", want: "そばアレルギー"},
+		{name: "ゼロ幅文字や文字方向の制御文字を取り除く", note: "えび\u200bかに\u202e抜き", want: "えびかに抜き"},
+		{name: "空白だけのメモは空になる", note: " \n　 ", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := services.SanitizeNote(tt.note); got != tt.want {
+				t.Errorf("SanitizeNote(%q) = %q, want %q", tt.note, got, tt.want)
+			}
+		})
+	}
+}
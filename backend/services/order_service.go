@@ -2,10 +2,15 @@ package services
 
 import (
 	"context"
+	"crypto/subtle"
 	"database/sql"
+	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/i18n"
 	"github.com/A4-dev-team/mobileorder.git/models"
 	"github.com/A4-dev-team/mobileorder.git/repositories"
 	"github.com/google/uuid"
@@ -13,30 +18,43 @@ import (
 )
 
 type OrderServicer interface {
-	CreateOrder(ctx context.Context, shopID int, reqItem []models.OrderItemRequest) (*models.Order, error)
-	CreateAuthenticatedOrder(ctx context.Context, userID int, shopID int, items []models.OrderItemRequest) (*models.Order, error)
-	GetUserOrders(ctx context.Context, userID int) ([]models.OrderListResponse, error)
+	CreateOrder(ctx context.Context, shopID int, req models.CreateOrderRequest) (*models.Order, error)
+	CreateAuthenticatedOrder(ctx context.Context, userID int, shopID int, req models.CreateOrderRequest) (*models.Order, error)
+	GetUserOrders(ctx context.Context, userID int, lang string) ([]models.OrderListResponse, error)
 	GetOrderStatus(ctx context.Context, userID int, orderID int) (*models.OrderStatusResponse, error)
+	GetReceipt(ctx context.Context, orderID int, userID *int, guestToken string, recipient string) (*models.ReceiptResponse, error)
 }
 
 type orderService struct {
 	orr repositories.OrderRepository
 	itr repositories.ItemRepository
+	prr repositories.PromotionRepository
+	ptr repositories.PointRepository
+	trr repositories.TranslationRepository
+	pys PaymentServicer
 	db  *sqlx.DB
 }
 
-func NewOrderService(orr repositories.OrderRepository, itr repositories.ItemRepository, db *sqlx.DB) OrderServicer {
+func NewOrderService(orr repositories.OrderRepository, itr repositories.ItemRepository, prr repositories.PromotionRepository, ptr repositories.PointRepository, trr repositories.TranslationRepository, pys PaymentServicer, db *sqlx.DB) OrderServicer {
 	return &orderService{
 		orr: orr,
 		itr: itr,
+		prr: prr,
+		ptr: ptr,
+		trr: trr,
+		pys: pys,
 		db:  db,
 	}
 }
 
-func NewOrderServiceForTest(orr repositories.OrderRepository, itr repositories.ItemRepository, db *sqlx.DB) OrderServicer {
+func NewOrderServiceForTest(orr repositories.OrderRepository, itr repositories.ItemRepository, prr repositories.PromotionRepository, ptr repositories.PointRepository, trr repositories.TranslationRepository, pys PaymentServicer, db *sqlx.DB) OrderServicer {
 	return &orderService{
 		orr: orr,
 		itr: itr,
+		prr: prr,
+		ptr: ptr,
+		trr: trr,
+		pys: pys,
 		db:  db,
 	}
 }
@@ -51,29 +69,9 @@ func generateguestToken() (string, error) {
 }
 
 // ログイン(サインアップ)できてない状態で注文作成
-func (s *orderService) CreateOrder(ctx context.Context, shopID int, items []models.OrderItemRequest) (*models.Order, error) {
-	tx, err := s.db.BeginTxx(ctx, nil)
-	if err != nil {
-		return nil, apperrors.Unknown.Wrap(err, "トランザクションの開始に失敗しました。")
-	}
-	defer func() {
-		if p := recover(); p != nil {
-			tx.Rollback()
-			panic(p)
-		} else if err != nil {
-			tx.Rollback()
-		} else {
-			err = tx.Commit()
-			if err != nil {
-				err = apperrors.Unknown.Wrap(err, "トランザクションのコミットに失敗しました。")
-			}
-		}
-	}()
-
-	// 商品の検証
-	totalAmount, orderItemsToCreate, err := s.validateAndPrepareOrderItems(ctx, tx, s.itr, shopID, items)
-	if err != nil {
-		return nil, err
+func (s *orderService) CreateOrder(ctx context.Context, shopID int, req models.CreateOrderRequest) (*models.Order, error) {
+	if req.UsePoints > 0 {
+		return nil, apperrors.Unauthorized.WrapMessage(nil, apperrors.MsgLoginRequiredForPoints, nil)
 	}
 
 	guestToken, err := generateguestToken()
@@ -83,12 +81,14 @@ func (s *orderService) CreateOrder(ctx context.Context, shopID int, items []mode
 
 	order := &models.Order{
 		ShopID:          shopID,
-		TotalAmount:     totalAmount,
-		Status:          models.Cooking,
+		Status:          models.PendingPayment,
 		GuestOrderToken: sql.NullString{String: guestToken, Valid: true},
+		Note:            toNoteNullString(req.Note),
+		HasAllergy:      req.HasAllergy,
+		DiningOption:    diningOptionOrDefault(req.DiningOption),
 	}
 
-	if err := s.orr.CreateOrder(ctx, tx, order, orderItemsToCreate); err != nil {
+	if err := s.placeOrder(ctx, order, req); err != nil {
 		return nil, err
 	}
 
@@ -96,85 +96,238 @@ func (s *orderService) CreateOrder(ctx context.Context, shopID int, items []mode
 }
 
 // ログイン(サインアップ)できてる状態で注文作成
-func (s *orderService) CreateAuthenticatedOrder(ctx context.Context, userID int, shopID int, items []models.OrderItemRequest) (*models.Order, error) {
+func (s *orderService) CreateAuthenticatedOrder(ctx context.Context, userID int, shopID int, req models.CreateOrderRequest) (*models.Order, error) {
+	order := &models.Order{
+		UserID:       sql.NullInt64{Int64: int64(userID), Valid: true},
+		ShopID:       shopID,
+		Status:       models.PendingPayment,
+		Note:         toNoteNullString(req.Note),
+		HasAllergy:   req.HasAllergy,
+		DiningOption: diningOptionOrDefault(req.DiningOption),
+	}
+
+	if err := s.placeOrder(ctx, order, req); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// placeOrder は注文を決済待ちで登録してから決済します。
+// クーポンコードが指定された場合は値引きを計算し、利用回数の加算と利用記録を注文の登録と同じトランザクションで行います。
+// ポイントを使う場合はクーポンの値引き後の金額から差し引き、残高の差し引きも同じトランザクションで行います。
+// 決済が確定した注文だけが調理中になり、厨房の注文一覧に表示されます。
+func (s *orderService) placeOrder(ctx context.Context, order *models.Order, req models.CreateOrderRequest) error {
 	tx, err := s.db.BeginTxx(ctx, nil)
 	if err != nil {
-		return nil, apperrors.Unknown.Wrap(err, "トランザクションの開始に失敗しました。")
-	}
-	defer func() {
-		if p := recover(); p != nil {
-			tx.Rollback()
-			panic(p)
-		} else if err != nil {
-			tx.Rollback()
-		} else {
-			err = tx.Commit()
-			if err != nil {
-				err = apperrors.Unknown.Wrap(err, "トランザクションのコミットに失敗しました。")
-			}
-		}
-	}()
+		return apperrors.Unknown.Wrap(err, "トランザクションの開始に失敗しました。")
+	}
+	defer tx.Rollback()
 
 	// 商品の検証
-	totalAmount, orderItemsToCreate, err := s.validateAndPrepareOrderItems(ctx, tx, s.itr, shopID, items)
+	orderItemsToCreate, err := s.validateAndPrepareOrderItems(ctx, tx, s.itr, order.ShopID, order.DiningOption, req.Items)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	order := &models.Order{
-		UserID:      sql.NullInt64{Int64: int64(userID), Valid: true},
-		ShopID:      shopID,
-		TotalAmount: totalAmount,
-		Status:      models.Cooking,
+	var promo *models.Promotion
+	if req.PromotionCode != "" {
+		promo, err = s.applyPromotion(ctx, tx, order, orderItemsToCreate, req.PromotionCode)
+		if err != nil {
+			return err
+		}
 	}
+	promotionDiscount := order.DiscountAmount
+	if req.UsePoints > 0 {
+		pointDiscounts, err := CalculatePointsDiscount(orderItemsToCreate, order.Discounts, req.UsePoints)
+		if err != nil {
+			return err
+		}
+		order.Discounts = append(order.Discounts, pointDiscounts...)
+		order.DiscountAmount = totalDiscount(order.Discounts)
+	}
+	order.SubtotalAmount, order.TaxAmount, order.TaxLines = CalculateConsumptionTax(orderItemsToCreate, order.Discounts)
+	order.TotalAmount = order.SubtotalAmount - order.DiscountAmount
 
 	if err := s.orr.CreateOrder(ctx, tx, order, orderItemsToCreate); err != nil {
+		return err
+	}
+	if promo != nil {
+		if err := s.prr.RedeemPromotion(ctx, tx, promo.PromotionID, order.OrderID, order.UserID, promotionDiscount); err != nil {
+			return err
+		}
+	}
+	if req.UsePoints > 0 {
+		userID := int(order.UserID.Int64)
+		now := time.Now()
+		if err := s.ptr.ExpirePoints(ctx, tx, userID, now); err != nil {
+			return err
+		}
+		if err := s.ptr.RedeemPoints(ctx, tx, userID, order.OrderID, order.ShopID, req.UsePoints, now); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperrors.Unknown.Wrap(err, "トランザクションのコミットに失敗しました。")
+	}
+
+	// 決済代行会社とのやり取りの間に在庫の行ロックを持ち続けないよう、決済は注文の登録をコミットしてから行う
+	return s.pys.ProcessOrderPayment(ctx, order, req.PaymentToken)
+}
+
+// applyPromotion はクーポンコードのクーポンを注文商品に適用して、注文に値引きを設定します。
+// 使えないコードはValidationFailed、ユーザーごとの利用上限があるクーポンをゲストが使おうとした場合はUnauthorized、
+// ユーザーごとの利用上限に達している場合はConflictを返します。クーポンの行ロックはトランザクションの終わりまで持ちます。
+func (s *orderService) applyPromotion(ctx context.Context, tx repositories.DBTX, order *models.Order, items []models.OrderItem, code string) (*models.Promotion, error) {
+	promo, err := s.prr.FindPromotionByCodeForUpdate(ctx, tx, order.ShopID, code)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) && appErr.ErrCode == apperrors.NoData {
+			return nil, apperrors.ValidationFailed.WrapMessage(err, apperrors.MsgInvalidPromotionCode, nil)
+		}
 		return nil, err
 	}
 
-	return order, nil
+	if promo.UsageLimitPerUser.Valid {
+		// ゲストは利用回数を数えられないため、ユーザーごとの上限があるクーポンは使えない
+		if !order.UserID.Valid {
+			return nil, apperrors.Unauthorized.WrapMessage(nil, apperrors.MsgPromotionLoginRequired, nil)
+		}
+		used, err := s.prr.CountUserRedemptions(ctx, tx, promo.PromotionID, int(order.UserID.Int64))
+		if err != nil {
+			return nil, err
+		}
+		if used >= int(promo.UsageLimitPerUser.Int64) {
+			return nil, apperrors.Conflict.WrapMessage(nil, apperrors.MsgPromotionUserLimit, apperrors.Params{"limit": promo.UsageLimitPerUser.Int64})
+		}
+	}
+
+	discounts, err := CalculatePromotionDiscount(promo, items, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	order.Discounts = discounts
+	order.DiscountAmount = totalDiscount(discounts)
+	return promo, nil
 }
 
-// 商品が店のものとあっているかの検証と合計金額とorder_itemテーブルに入れるためのデータを作るヘルパーメソッド
-func (s *orderService) validateAndPrepareOrderItems(ctx context.Context, dbtx repositories.DBTX, itr repositories.ItemRepository, shopID int, items []models.OrderItemRequest) (int, []models.OrderItem, error) {
+// 商品が店のものとあっているかの検証とorder_itemテーブルに入れるためのデータを作るヘルパーメソッド
+// 注文商品には飲食区分に応じた消費税率を付ける。セット商品は選ばれた構成商品を付け、構成商品の在庫数も減らす
+func (s *orderService) validateAndPrepareOrderItems(ctx context.Context, dbtx repositories.DBTX, itr repositories.ItemRepository, shopID int, diningOption models.DiningOption, items []models.OrderItemRequest) ([]models.OrderItem, error) {
 
 	if len(items) == 0 {
-		return 0, nil, apperrors.BadParam.Wrap(nil, "注文には少なくとも1つの商品が必要です。")
+		return nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgEmptyOrder, nil)
 	}
 
-	itemIDs := make([]int, len(items))
-	for i, item := range items {
-		itemIDs[i] = item.ItemID
+	// オプション違いで同じ商品が複数行に分かれることがあるため、商品IDは重複を除いて検証する
+	itemIDs := make([]int, 0, len(items))
+	seen := make(map[int]bool, len(items))
+	for _, item := range items {
+		if !seen[item.ItemID] {
+			seen[item.ItemID] = true
+			itemIDs = append(itemIDs, item.ItemID)
+		}
 	}
 	//店に所属する商品IDに対する商品のマップを取得
 	validItemMap, err := itr.ValidateAndGetItemsForShop(ctx, dbtx, shopID, itemIDs)
 	if err != nil {
-		return 0, nil, err
+		return nil, err
+	}
+
+	modifierGroupsMap, err := itr.FindModifierGroupsByItemIDs(ctx, dbtx, shopID, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	bundleSlotsMap, err := itr.FindBundleSlotsByItemIDs(ctx, dbtx, shopID, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 在庫数を管理している商品ごとの注文数。セット商品は構成商品の数も含める
+	stockDemand := make(map[int]int)
+	componentTracked := make(map[int]bool)
+	for _, slots := range bundleSlotsMap {
+		for _, slot := range slots {
+			for _, choice := range slot.Choices {
+				componentTracked[choice.ItemID] = choice.StockQuantity != nil
+			}
+		}
 	}
 
-	var totalAmount int = 0
+	// 商品はすべて同じ店舗のものなので、タイムゾーンは1度だけ読み込む
+	now := time.Now()
+	loc := shopLocation(validItemMap[items[0].ItemID].TimeZone)
 	orderItemsToCreate := make([]models.OrderItem, len(items))
 	for i, item := range items {
 		itemModel := validItemMap[item.ItemID]
 
-		if !itemModel.IsAvailable {
-			return 0, nil, apperrors.BadParam.Wrapf(nil, "対象の商品 '%s' (ID: %d) は、現在在庫切れです", itemModel.ItemName, itemModel.ItemID)
+		if !IsWithinSchedule(itemModel.AvailabilityWindows, loc, now) {
+			params := apperrors.Params{"item_name": itemModel.ItemName, "item_id": itemModel.ItemID}
+			if next, ok := NextAvailableTime(itemModel.AvailabilityWindows, loc, now); ok {
+				params["next_available_at"] = next.In(loc)
+				return nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgItemOutsideScheduleUntil, params)
+			}
+			return nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgItemOutsideSchedule, params)
 		}
 
-		priceAtOrder := itemModel.Price
-		totalAmount += priceAtOrder * item.Quantity
+		if !itemModel.IsAvailable || (itemModel.StockQuantity != nil && *itemModel.StockQuantity == 0) {
+			return nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgItemSoldOut, apperrors.Params{"item_name": itemModel.ItemName, "item_id": itemModel.ItemID})
+		}
+		if itemModel.StockQuantity != nil && *itemModel.StockQuantity < item.Quantity {
+			return nil, apperrors.Conflict.WrapMessage(nil, apperrors.MsgItemInsufficientStock,
+				apperrors.Params{"item_name": itemModel.ItemName, "item_id": itemModel.ItemID, "remaining": *itemModel.StockQuantity})
+		}
 
+		modifierPriceDelta, modifiers, err := ResolveModifierSelection(itemModel, modifierGroupsMap[item.ItemID], item.ModifierOptionIDs)
+		if err != nil {
+			return nil, err
+		}
+		bundlePriceDelta, components, err := ResolveBundleSelection(itemModel, modifierPriceDelta, bundleSlotsMap[item.ItemID], item.BundleChoiceIDs, loc, now)
+		if err != nil {
+			return nil, err
+		}
+
+		if itemModel.StockQuantity != nil {
+			stockDemand[item.ItemID] += item.Quantity
+		}
+		for _, component := range components {
+			if componentTracked[component.ItemID] {
+				stockDemand[component.ItemID] += item.Quantity * component.Quantity
+			}
+		}
+
+		priceAtOrder := itemModel.Price
 		orderItemsToCreate[i] = models.OrderItem{
-			ItemID:       item.ItemID,
-			Quantity:     item.Quantity,
-			PriceAtOrder: priceAtOrder,
+			ItemID:             item.ItemID,
+			Quantity:           item.Quantity,
+			PriceAtOrder:       priceAtOrder,
+			ModifierPriceDelta: modifierPriceDelta + bundlePriceDelta,
+			Modifiers:          modifiers,
+			Components:         components,
+			Note:               toNoteNullString(item.Note),
+			TaxRate:            TaxRateFor(itemModel.TaxCategory, diningOption),
+		}
+	}
+
+	// 在庫を管理している商品は同じトランザクション内で在庫数を減らす。
+	// 同時注文でのデッドロックを避けるため、商品IDの昇順でロックを取る
+	stockItemIDs := make([]int, 0, len(stockDemand))
+	for itemID := range stockDemand {
+		stockItemIDs = append(stockItemIDs, itemID)
+	}
+	sort.Ints(stockItemIDs)
+	for _, itemID := range stockItemIDs {
+		if err := itr.DecrementStock(ctx, dbtx, shopID, itemID, stockDemand[itemID]); err != nil {
+			return nil, err
 		}
 	}
-	return totalAmount, orderItemsToCreate, nil
+
+	return orderItemsToCreate, nil
 }
 
-// GetUserOrders は、注文一覧ページのためのやつ
-func (s *orderService) GetUserOrders(ctx context.Context, userID int) ([]models.OrderListResponse, error) {
+// GetUserOrders は、注文一覧ページのためのやつ。langが日本語以外なら、翻訳のある店舗名・商品名を翻訳する
+func (s *orderService) GetUserOrders(ctx context.Context, userID int, lang string) ([]models.OrderListResponse, error) {
 
 	orders, err := s.orr.FindActiveUserOrders(ctx, s.db, userID)
 	if err != nil {
@@ -193,24 +346,111 @@ func (s *orderService) GetUserOrders(ctx context.Context, userID int) ([]models.
 	if err != nil {
 		return nil, err
 	}
+	taxLinesMap, err := s.orr.FindTaxLinesByOrderIDs(ctx, s.db, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+	discountsMap, err := s.orr.FindDiscountsByOrderIDs(ctx, s.db, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+	shopTranslations := map[int]models.ShopTranslation{}
+	if i18n.IsTranslationLanguage(lang) {
+		shopTranslations, err = s.translateUserOrders(ctx, lang, orders, orderItemsMap)
+		if err != nil {
+			return nil, err
+		}
+	}
 
+	now := time.Now()
+	kitchens := make(map[int]KitchenSnapshot)
 	resDTOs := make([]models.OrderListResponse, len(orders))
 	for i, repoOrder := range orders {
+		var estimatedReadyAt *time.Time
+		switch repoOrder.Status {
+		case models.Cooking:
+			kitchen, ok := kitchens[repoOrder.ShopID]
+			if !ok {
+				kitchen, err = s.loadKitchenSnapshot(ctx, repoOrder.ShopID, now)
+				if err != nil {
+					return nil, err
+				}
+				kitchens[repoOrder.ShopID] = kitchen
+			}
+			if readyAt, ok := kitchen.EstimateReadyAt(repoOrder.OrderID, now); ok {
+				estimatedReadyAt = &readyAt
+			}
+		case models.Completed:
+			if repoOrder.CompletedAt.Valid {
+				estimatedReadyAt = &repoOrder.CompletedAt.Time
+			}
+		}
+
+		taxLines := taxLinesMap[repoOrder.OrderID]
+		if taxLines == nil {
+			taxLines = []models.OrderTaxLine{}
+		}
+		discounts := discountsMap[repoOrder.OrderID]
+		if discounts == nil {
+			discounts = []models.OrderDiscount{}
+		}
+		shopName := repoOrder.ShopName
+		if t, ok := shopTranslations[repoOrder.ShopID]; ok {
+			shopName = t.Name
+		}
 		resDTOs[i] = models.OrderListResponse{
-			OrderID:      repoOrder.OrderID,
-			ShopName:     repoOrder.ShopName,
-			Location:     repoOrder.Location,
-			OrderDate:    repoOrder.OrderDate,
-			TotalAmount:  repoOrder.TotalAmount,
-			Status:       repoOrder.Status.String(),
-			WaitingCount: repoOrder.WaitingCount,
-			Items:        orderItemsMap[repoOrder.OrderID],
+			OrderID:          repoOrder.OrderID,
+			ShopName:         shopName,
+			Location:         repoOrder.Location,
+			OrderDate:        repoOrder.OrderDate,
+			TotalAmount:      repoOrder.TotalAmount,
+			Status:           repoOrder.Status.String(),
+			WaitingCount:     repoOrder.WaitingCount,
+			Items:            orderItemsMap[repoOrder.OrderID],
+			EstimatedReadyAt: estimatedReadyAt,
+			DiningOption:     repoOrder.DiningOption.String(),
+			SubtotalAmount:   repoOrder.SubtotalAmount,
+			TaxAmount:        repoOrder.TaxAmount,
+			TaxBreakdown:     taxLines,
+			DiscountAmount:   repoOrder.DiscountAmount,
+			Discounts:        discounts,
 		}
 	}
 
 	return resDTOs, nil
 }
 
+// translateUserOrders は注文商品の商品名を翻訳し、注文した店舗の翻訳を返します
+func (s *orderService) translateUserOrders(ctx context.Context, lang string, orders []repositories.OrderWithDetailsDB, orderItemsMap map[int][]models.ItemDetail) (map[int]models.ShopTranslation, error) {
+	shopIDs := make([]int, 0, len(orders))
+	seenShops := make(map[int]bool)
+	for _, o := range orders {
+		if !seenShops[o.ShopID] {
+			seenShops[o.ShopID] = true
+			shopIDs = append(shopIDs, o.ShopID)
+		}
+	}
+	var itemIDs []int
+	seenItems := make(map[int]bool)
+	for _, items := range orderItemsMap {
+		for _, item := range items {
+			if !seenItems[item.ItemID] {
+				seenItems[item.ItemID] = true
+				itemIDs = append(itemIDs, item.ItemID)
+			}
+		}
+	}
+
+	itemTranslations, err := s.trr.FindItemTranslations(ctx, s.db, lang, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, items := range orderItemsMap {
+		translateOrderItems(items, itemTranslations)
+	}
+	return s.trr.FindShopTranslations(ctx, s.db, lang, shopIDs)
+}
+
 // GetOrderStatus は、単一注文のステータスと待ち人数を取得
 func (s *orderService) GetOrderStatus(ctx context.Context, userID int, orderID int) (*models.OrderStatusResponse, error) {
 
@@ -220,16 +460,128 @@ func (s *orderService) GetOrderStatus(ctx context.Context, userID int, orderID i
 	}
 
 	var waitingCount int
-	if order.Status == models.Cooking {
+	var estimatedReadyAt *time.Time
+	switch order.Status {
+	case models.Cooking:
 		waitingCount, err = s.orr.CountWaitingOrders(ctx, s.db, order.ShopID, order.OrderDate)
 		if err != nil {
 			return nil, err
 		}
+
+		now := time.Now()
+		kitchen, err := s.loadKitchenSnapshot(ctx, order.ShopID, now)
+		if err != nil {
+			return nil, err
+		}
+		if readyAt, ok := kitchen.EstimateReadyAt(order.OrderID, now); ok {
+			estimatedReadyAt = &readyAt
+		}
+	case models.Completed:
+		if order.CompletedAt.Valid {
+			estimatedReadyAt = &order.CompletedAt.Time
+		}
 	}
 
 	return &models.OrderStatusResponse{
-		OrderID:      order.OrderID,
-		Status:       order.Status.String(),
-		WaitingCount: waitingCount,
+		OrderID:          order.OrderID,
+		Status:           order.Status.String(),
+		WaitingCount:     waitingCount,
+		EstimatedReadyAt: estimatedReadyAt,
 	}, nil
 }
+
+// GetReceipt は注文の領収書を作成します。
+// 注文したユーザー本人（userID）か、ゲスト用トークンの持ち主だけが取得できます。
+func (s *orderService) GetReceipt(ctx context.Context, orderID int, userID *int, guestToken string, recipient string) (*models.ReceiptResponse, error) {
+	order, err := s.orr.FindReceiptOrder(ctx, s.db, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccessReceipt(order, userID, guestToken) {
+		// 他人の注文があるかどうかを推測されないよう、見つからない場合と同じエラーにする
+		return nil, apperrors.NoData.WrapMessage(nil, apperrors.MsgReceiptNotFound, nil)
+	}
+	if order.Status == models.PendingPayment || order.Status == models.Cancelled {
+		return nil, apperrors.Conflict.WrapMessage(nil, apperrors.MsgReceiptUnpaidOrder, nil)
+	}
+
+	lines, err := s.orr.FindReceiptLines(ctx, s.db, orderID)
+	if err != nil {
+		return nil, err
+	}
+	taxLinesMap, err := s.orr.FindTaxLinesByOrderIDs(ctx, s.db, []int{orderID})
+	if err != nil {
+		return nil, err
+	}
+	taxBreakdown := taxLinesMap[orderID]
+	if taxBreakdown == nil {
+		taxBreakdown = []models.OrderTaxLine{}
+	}
+	discountsMap, err := s.orr.FindDiscountsByOrderIDs(ctx, s.db, []int{orderID})
+	if err != nil {
+		return nil, err
+	}
+	discounts := discountsMap[orderID]
+	if discounts == nil {
+		discounts = []models.OrderDiscount{}
+	}
+
+	receiptLines := make([]models.ReceiptLineResponse, len(lines))
+	for i, line := range lines {
+		receiptLines[i] = models.ReceiptLineResponse{
+			ItemName:      line.ItemName,
+			Quantity:      line.Quantity,
+			UnitPrice:     line.UnitPrice,
+			Amount:        line.UnitPrice * line.Quantity,
+			TaxRate:       line.TaxRate,
+			IsReducedRate: line.TaxRate == ReducedTaxRate,
+		}
+	}
+
+	var registrationNumber *string
+	if order.InvoiceRegistrationNumber.Valid {
+		registrationNumber = &order.InvoiceRegistrationNumber.String
+	}
+
+	return &models.ReceiptResponse{
+		OrderID:                   order.OrderID,
+		Recipient:                 recipient,
+		ShopName:                  order.ShopName,
+		ShopLocation:              order.ShopLocation,
+		InvoiceRegistrationNumber: registrationNumber,
+		// 税を計算する前の注文は税率ごとの内訳がないため、適格請求書の要件を満たさない
+		IsQualifiedInvoice: registrationNumber != nil && len(taxBreakdown) > 0,
+		TransactionDate:    order.OrderDate,
+		IssuedAt:           time.Now(),
+		DiningOption:       order.DiningOption.String(),
+		Lines:              receiptLines,
+		SubtotalAmount:     order.SubtotalAmount,
+		DiscountAmount:     order.DiscountAmount,
+		TaxAmount:          order.TaxAmount,
+		TotalAmount:        order.TotalAmount,
+		TaxBreakdown:       taxBreakdown,
+		Discounts:          discounts,
+	}, nil
+}
+
+// canAccessReceipt は注文したユーザー本人か、ゲスト用トークンが一致するかを確認します
+func canAccessReceipt(order *repositories.ReceiptOrderDB, userID *int, guestToken string) bool {
+	if userID != nil && order.UserID.Valid && order.UserID.Int64 == int64(*userID) {
+		return true
+	}
+	return guestToken != "" && order.GuestOrderToken.Valid &&
+		subtle.ConstantTimeCompare([]byte(guestToken), []byte(order.GuestOrderToken.String)) == 1
+}
+
+// loadKitchenSnapshot は受け取り予定時刻の推定に必要な店舗の調理状況を取得します
+func (s *orderService) loadKitchenSnapshot(ctx context.Context, shopID int, now time.Time) (KitchenSnapshot, error) {
+	queue, err := s.orr.FindCookingQueue(ctx, s.db, shopID)
+	if err != nil {
+		return KitchenSnapshot{}, err
+	}
+	samples, err := s.orr.FindRecentKitchenDurations(ctx, s.db, shopID, now.Add(-kitchenSampleWindow), kitchenSampleLimit)
+	if err != nil {
+		return KitchenSnapshot{}, err
+	}
+	return NewKitchenSnapshot(queue, samples), nil
+}
@@ -2,8 +2,11 @@ package services_test
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/A4-dev-team/mobileorder.git/apperrors"
 	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
@@ -129,6 +132,23 @@ func ensureTestDataExists(t *testing.T, db *sqlx.DB) {
 	}
 }
 
+// testWebhookSecret はテストで使うモック決済のWebhook署名鍵です
+const testWebhookSecret = "test-webhook-secret"
+
+// newTestPaymentService はモック決済で決済するPaymentServiceを作ります
+func newTestPaymentService(db *sqlx.DB) services.PaymentServicer {
+	return services.NewPaymentService(
+		repositories.NewPaymentRepository(),
+		repositories.NewRefundRepository(),
+		repositories.NewOrderRepository(),
+		repositories.NewItemRepository(),
+		repositories.NewPromotionRepository(),
+		repositories.NewPointRepository(),
+		services.NewMockPaymentProvider(testWebhookSecret),
+		db,
+	)
+}
+
 func TestOrderService_CreateOrder_Integration(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Integration test skipped in short mode")
@@ -146,7 +166,7 @@ func TestOrderService_CreateOrder_Integration(t *testing.T) {
 	itemRepo := repositories.NewItemRepository()
 
 	// サービス初期化
-	orderService := services.NewOrderService(orderRepo, itemRepo, db)
+	orderService := services.NewOrderService(orderRepo, itemRepo, repositories.NewPromotionRepository(), repositories.NewPointRepository(), repositories.NewTranslationRepository(), newTestPaymentService(db), db)
 
 	ctx := context.Background()
 
@@ -180,6 +200,14 @@ func TestOrderService_CreateOrder_Integration(t *testing.T) {
 				if order.Status != models.Cooking {
 					t.Errorf("Expected Status=Cooking, got %v", order.Status)
 				}
+				// 飲食区分を省略した注文は持ち帰りとして軽減税率になる
+				if order.DiningOption != models.Takeout || len(order.TaxLines) != 1 || order.TaxLines[0].TaxRate != services.ReducedTaxRate {
+					t.Errorf("Expected takeout order taxed at %d%%, got %v %+v", services.ReducedTaxRate, order.DiningOption, order.TaxLines)
+				}
+				// 価格は税込なので、合計は小計と同じで消費税はその内数になる
+				if order.TotalAmount != order.SubtotalAmount || order.TaxAmount != order.TotalAmount*services.ReducedTaxRate/(100+services.ReducedTaxRate) {
+					t.Errorf("TotalAmount = %d, TaxAmount = %d, want total = subtotal %d with tax included", order.TotalAmount, order.TaxAmount, order.SubtotalAmount)
+				}
 			},
 		},
 		{
@@ -203,7 +231,7 @@ func TestOrderService_CreateOrder_Integration(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// テスト実行
-			order, err := orderService.CreateOrder(ctx, tt.shopID, tt.items)
+			order, err := orderService.CreateOrder(ctx, tt.shopID, models.CreateOrderRequest{Items: tt.items})
 
 			// エラーアサーション
 			if tt.expectedErrCode == "" {
@@ -235,7 +263,7 @@ func TestOrderService_CreateAuthenticatedOrder_Integration(t *testing.T) {
 	itemRepo := repositories.NewItemRepository()
 
 	// サービス初期化
-	orderService := services.NewOrderService(orderRepo, itemRepo, db)
+	orderService := services.NewOrderService(orderRepo, itemRepo, repositories.NewPromotionRepository(), repositories.NewPointRepository(), repositories.NewTranslationRepository(), newTestPaymentService(db), db)
 
 	ctx := context.Background()
 
@@ -296,7 +324,7 @@ func TestOrderService_CreateAuthenticatedOrder_Integration(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// テスト実行
-			order, err := orderService.CreateAuthenticatedOrder(ctx, tt.userID, tt.shopID, tt.items)
+			order, err := orderService.CreateAuthenticatedOrder(ctx, tt.userID, tt.shopID, models.CreateOrderRequest{Items: tt.items})
 
 			// エラーアサーション
 			if tt.expectedErrCode == "" {
@@ -329,7 +357,7 @@ func TestOrderService_TransactionRollback_Integration(t *testing.T) {
 	itemRepo := repositories.NewItemRepository()
 
 	// サービス初期化
-	orderService := services.NewOrderService(orderRepo, itemRepo, db)
+	orderService := services.NewOrderService(orderRepo, itemRepo, repositories.NewPromotionRepository(), repositories.NewPointRepository(), repositories.NewTranslationRepository(), newTestPaymentService(db), db)
 
 	ctx := context.Background()
 
@@ -342,9 +370,9 @@ func TestOrderService_TransactionRollback_Integration(t *testing.T) {
 		}
 
 		// 存在しない商品での注文作成を試行（トランザクション内で失敗するはず）
-		_, err = orderService.CreateOrder(ctx, 1, []models.OrderItemRequest{
+		_, err = orderService.CreateOrder(ctx, 1, models.CreateOrderRequest{Items: []models.OrderItemRequest{
 			{ItemID: 99999, Quantity: 1}, // 存在しない商品ID
-		})
+		}})
 
 		// エラーが発生することを確認
 		if err == nil {
@@ -382,7 +410,7 @@ func TestOrderService_TransactionCommit_Integration(t *testing.T) {
 	itemRepo := repositories.NewItemRepository()
 
 	// サービス初期化
-	orderService := services.NewOrderService(orderRepo, itemRepo, db)
+	orderService := services.NewOrderService(orderRepo, itemRepo, repositories.NewPromotionRepository(), repositories.NewPointRepository(), repositories.NewTranslationRepository(), newTestPaymentService(db), db)
 
 	ctx := context.Background()
 
@@ -403,7 +431,7 @@ func TestOrderService_TransactionCommit_Integration(t *testing.T) {
 			{ItemID: 1, Quantity: 2},
 		}
 
-		order, err := orderService.CreateOrder(ctx, 1, items)
+		order, err := orderService.CreateOrder(ctx, 1, models.CreateOrderRequest{Items: items})
 		if err != nil {
 			t.Fatalf("Failed to create order: %v", err)
 		}
@@ -428,3 +456,81 @@ func TestOrderService_TransactionCommit_Integration(t *testing.T) {
 		}
 	})
 }
+
+// TestOrderService_CreateOrderWithPromotion_Integration クーポン利用の結合テスト
+func TestOrderService_CreateOrderWithPromotion_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Integration test skipped in short mode")
+	}
+
+	db := setupOrderTestDB(t)
+	defer db.Close()
+
+	ensureTestDataExists(t, db)
+
+	// 1人1回まで・100円引きのクーポンを用意する（他のテストと衝突しないようコードは実行ごとに変える）
+	code := fmt.Sprintf("IT%d", time.Now().UnixNano()%1000000000)
+	var promotionID int
+	err := db.QueryRow(`
+		INSERT INTO promotions (shop_id, code, name, discount_type, discount_value, usage_limit_per_user)
+		VALUES (1, $1, '結合テスト用クーポン', 2, 100, 1)
+		RETURNING promotion_id
+	`, code).Scan(&promotionID)
+	if err != nil {
+		t.Fatalf("Failed to create test promotion: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := db.Exec("DELETE FROM promotions WHERE promotion_id = $1", promotionID); err != nil {
+			t.Logf("Failed to delete test promotion: %v", err)
+		}
+	})
+
+	orderService := services.NewOrderService(
+		repositories.NewOrderRepository(),
+		repositories.NewItemRepository(),
+		repositories.NewPromotionRepository(),
+		repositories.NewPointRepository(),
+		repositories.NewTranslationRepository(),
+		newTestPaymentService(db),
+		db,
+	)
+	ctx := context.Background()
+	req := models.CreateOrderRequest{
+		Items:         []models.OrderItemRequest{{ItemID: 1, Quantity: 2}},
+		PromotionCode: strings.ToLower(code),
+	}
+
+	t.Run("正常系: 値引き後の金額で消費税を計算し、利用を記録する", func(t *testing.T) {
+		order, err := orderService.CreateAuthenticatedOrder(ctx, 1, 1, req)
+		testhelpers.AssertNoError(t, err)
+
+		if order.DiscountAmount != 100 {
+			t.Errorf("Expected DiscountAmount=100, got %d", order.DiscountAmount)
+		}
+		if order.TotalAmount != order.SubtotalAmount-order.DiscountAmount {
+			t.Errorf("Total mismatch: subtotal=%d discount=%d tax=%d total=%d",
+				order.SubtotalAmount, order.DiscountAmount, order.TaxAmount, order.TotalAmount)
+		}
+
+		var usedCount, redemptions int
+		if err := db.QueryRow("SELECT used_count FROM promotions WHERE promotion_id = $1", promotionID).Scan(&usedCount); err != nil {
+			t.Fatalf("Failed to get used_count: %v", err)
+		}
+		if err := db.QueryRow("SELECT COUNT(*) FROM promotion_redemptions WHERE order_id = $1", order.OrderID).Scan(&redemptions); err != nil {
+			t.Fatalf("Failed to get redemptions: %v", err)
+		}
+		if usedCount != 1 || redemptions != 1 {
+			t.Errorf("Expected used_count=1 and 1 redemption, got used_count=%d redemptions=%d", usedCount, redemptions)
+		}
+	})
+
+	t.Run("異常系: 1人あたりの利用上限を超える", func(t *testing.T) {
+		_, err := orderService.CreateAuthenticatedOrder(ctx, 1, 1, req)
+		testhelpers.AssertAppError(t, err, apperrors.Conflict)
+	})
+
+	t.Run("異常系: ゲスト注文では1人あたりの上限があるクーポンを使えない", func(t *testing.T) {
+		_, err := orderService.CreateOrder(ctx, 1, req)
+		testhelpers.AssertAppError(t, err, apperrors.Unauthorized)
+	})
+}
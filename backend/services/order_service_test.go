@@ -2,6 +2,7 @@ package services_test
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -11,13 +12,14 @@ import (
 	"github.com/A4-dev-team/mobileorder.git/repositories"
 	"github.com/A4-dev-team/mobileorder.git/services"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/jmoiron/sqlx"
 )
 
 // ItemRepositoryMockForOrder - OrderService用のItemRepositoryモック（DBTX対応）
 type ItemRepositoryMockForOrder struct {
 	ValidateAndGetItemsForShopFunc func(ctx context.Context, dbtx repositories.DBTX, shopID int, itemIDs []int) (map[int]models.Item, error)
-	GetItemListFunc                func(dbtx repositories.DBTX, shopID int) ([]models.ItemListResponse, error)
+	GetItemListFunc                func(dbtx repositories.DBTX, shopID int) ([]repositories.ItemListDB, error)
 }
 
 func NewItemRepositoryMockForOrder() *ItemRepositoryMockForOrder {
@@ -31,7 +33,7 @@ func (m *ItemRepositoryMockForOrder) ValidateAndGetItemsForShop(ctx context.Cont
 	panic("not implemented")
 }
 
-func (m *ItemRepositoryMockForOrder) GetItemList(dbtx repositories.DBTX, shopID int) ([]models.ItemListResponse, error) {
+func (m *ItemRepositoryMockForOrder) GetItemList(dbtx repositories.DBTX, shopID int) ([]repositories.ItemListDB, error) {
 	if m.GetItemListFunc != nil {
 		return m.GetItemListFunc(dbtx, shopID)
 	}
@@ -58,13 +60,93 @@ func (m *ItemRepositoryMockForOrder) DeleteItem(ctx context.Context, dbtx reposi
 	panic("not implemented")
 }
 
+func (m *ItemRepositoryMockForOrder) UpdateItemPrepTime(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, prepSeconds *int) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForOrder) DecrementStock(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, quantity int) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForOrder) RestockOrderItems(ctx context.Context, dbtx repositories.DBTX, shopID int, orderID int) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForOrder) UpdateItemStock(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, stockQuantity *int) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForOrder) FindModifierGroupsByItemIDs(ctx context.Context, dbtx repositories.DBTX, shopID int, itemIDs []int) (map[int][]models.ModifierGroup, error) {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForOrder) CreateModifierGroup(ctx context.Context, dbtx repositories.DBTX, shopID int, group *models.ModifierGroup) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForOrder) DeleteModifierGroup(ctx context.Context, dbtx repositories.DBTX, shopID int, modifierGroupID int) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForOrder) FindShopMenuItems(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]models.Item, error) {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForOrder) CreateShopMenuItem(ctx context.Context, dbtx repositories.DBTX, shopID int, item *models.Item) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForOrder) UpdateShopMenuItem(ctx context.Context, dbtx repositories.DBTX, shopID int, item *models.Item) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForOrder) UpdateItemImage(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, imageKey string, thumbnailKey string) ([]string, error) {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForOrder) ReplaceAvailabilityWindows(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, windows []models.AvailabilityWindow) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForOrder) CreatePriceChange(ctx context.Context, dbtx repositories.DBTX, shopID int, change *models.PriceChange) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForOrder) FindPriceChanges(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int) ([]models.PriceChange, error) {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForOrder) CancelPriceChange(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, priceChangeID int) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForOrder) FindBundleSlotsByItemIDs(ctx context.Context, dbtx repositories.DBTX, shopID int, itemIDs []int) (map[int][]models.BundleSlot, error) {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForOrder) ReplaceBundleSlots(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, slots []models.BundleSlot) error {
+	panic("not implemented")
+}
+
+func (m *ItemRepositoryMockForOrder) ReplaceItemDietaryInfo(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, allergens []string, dietaryTags []string) error {
+	panic("not implemented")
+}
+
 // OrderRepositoryMockForOrder - OrderService用のOrderRepositoryモック（DBTX対応）
 type OrderRepositoryMockForOrder struct {
-	CreateOrderFunc          func(ctx context.Context, dbtx repositories.DBTX, order *models.Order, items []models.OrderItem) error
-	FindActiveUserOrdersFunc func(ctx context.Context, dbtx repositories.DBTX, userID int) ([]repositories.OrderWithDetailsDB, error)
-	FindItemsByOrderIDsFunc  func(ctx context.Context, dbtx repositories.DBTX, orderIDs []int) (map[int][]models.ItemDetail, error)
-	FindOrderByIDAndUserFunc func(ctx context.Context, dbtx repositories.DBTX, orderID int, userID int) (*models.Order, error)
-	CountWaitingOrdersFunc   func(ctx context.Context, dbtx repositories.DBTX, shopID int, orderDate time.Time) (int, error)
+	CreateOrderFunc             func(ctx context.Context, dbtx repositories.DBTX, order *models.Order, items []models.OrderItem) error
+	FindActiveUserOrdersFunc    func(ctx context.Context, dbtx repositories.DBTX, userID int) ([]repositories.OrderWithDetailsDB, error)
+	FindItemsByOrderIDsFunc     func(ctx context.Context, dbtx repositories.DBTX, orderIDs []int) (map[int][]models.ItemDetail, error)
+	FindTaxLinesByOrderIDsFunc  func(ctx context.Context, dbtx repositories.DBTX, orderIDs []int) (map[int][]models.OrderTaxLine, error)
+	FindDiscountsByOrderIDsFunc func(ctx context.Context, dbtx repositories.DBTX, orderIDs []int) (map[int][]models.OrderDiscount, error)
+	FindOrderByIDAndUserFunc    func(ctx context.Context, dbtx repositories.DBTX, orderID int, userID int) (*models.Order, error)
+	CountWaitingOrdersFunc      func(ctx context.Context, dbtx repositories.DBTX, shopID int, orderDate time.Time) (int, error)
+
+	FindCookingQueueFunc           func(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]repositories.KitchenQueueEntry, error)
+	FindRecentKitchenDurationsFunc func(ctx context.Context, dbtx repositories.DBTX, shopID int, since time.Time, limit int) ([]repositories.KitchenDurationSample, error)
+
+	FindReceiptOrderFunc func(ctx context.Context, dbtx repositories.DBTX, orderID int) (*repositories.ReceiptOrderDB, error)
+	FindReceiptLinesFunc func(ctx context.Context, dbtx repositories.DBTX, orderID int) ([]repositories.ReceiptLineDB, error)
 }
 
 func NewOrderRepositoryMockForOrder() *OrderRepositoryMockForOrder {
@@ -96,6 +178,13 @@ func (m *OrderRepositoryMockForOrder) FindItemsByOrderIDs(ctx context.Context, d
 	panic("not implemented")
 }
 
+func (m *OrderRepositoryMockForOrder) FindTaxLinesByOrderIDs(ctx context.Context, dbtx repositories.DBTX, orderIDs []int) (map[int][]models.OrderTaxLine, error) {
+	if m.FindTaxLinesByOrderIDsFunc != nil {
+		return m.FindTaxLinesByOrderIDsFunc(ctx, dbtx, orderIDs)
+	}
+	panic("not implemented")
+}
+
 func (m *OrderRepositoryMockForOrder) FindOrderByIDAndUser(ctx context.Context, dbtx repositories.DBTX, orderID int, userID int) (*models.Order, error) {
 	if m.FindOrderByIDAndUserFunc != nil {
 		return m.FindOrderByIDAndUserFunc(ctx, dbtx, orderID, userID)
@@ -118,6 +207,10 @@ func (m *OrderRepositoryMockForOrder) FindOrderByIDAndShopID(ctx context.Context
 	panic("not implemented")
 }
 
+func (m *OrderRepositoryMockForOrder) FindOrderByIDAndShopIDForUpdate(ctx context.Context, dbtx repositories.DBTX, orderID int, shopID int) (*models.Order, error) {
+	panic("not implemented")
+}
+
 func (m *OrderRepositoryMockForOrder) UpdateOrderStatus(ctx context.Context, dbtx repositories.DBTX, orderID int, shopID int, newStatus models.OrderStatus) error {
 	panic("not implemented")
 }
@@ -126,6 +219,49 @@ func (m *OrderRepositoryMockForOrder) DeleteOrderByIDAndShopID(ctx context.Conte
 	panic("not implemented")
 }
 
+func (m *OrderRepositoryMockForOrder) FindCookingQueue(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]repositories.KitchenQueueEntry, error) {
+	if m.FindCookingQueueFunc != nil {
+		return m.FindCookingQueueFunc(ctx, dbtx, shopID)
+	}
+	panic("not implemented")
+}
+
+func (m *OrderRepositoryMockForOrder) FindRecentKitchenDurations(ctx context.Context, dbtx repositories.DBTX, shopID int, since time.Time, limit int) ([]repositories.KitchenDurationSample, error) {
+	if m.FindRecentKitchenDurationsFunc != nil {
+		return m.FindRecentKitchenDurationsFunc(ctx, dbtx, shopID, since, limit)
+	}
+	panic("not implemented")
+}
+
+func (m *OrderRepositoryMockForOrder) FindOrderByID(ctx context.Context, dbtx repositories.DBTX, orderID int) (*models.Order, error) {
+	panic("not implemented")
+}
+
+func (m *OrderRepositoryMockForOrder) FindReceiptOrder(ctx context.Context, dbtx repositories.DBTX, orderID int) (*repositories.ReceiptOrderDB, error) {
+	if m.FindReceiptOrderFunc != nil {
+		return m.FindReceiptOrderFunc(ctx, dbtx, orderID)
+	}
+	panic("not implemented")
+}
+
+func (m *OrderRepositoryMockForOrder) FindReceiptLines(ctx context.Context, dbtx repositories.DBTX, orderID int) ([]repositories.ReceiptLineDB, error) {
+	if m.FindReceiptLinesFunc != nil {
+		return m.FindReceiptLinesFunc(ctx, dbtx, orderID)
+	}
+	panic("not implemented")
+}
+
+func (m *OrderRepositoryMockForOrder) FindDiscountsByOrderIDs(ctx context.Context, dbtx repositories.DBTX, orderIDs []int) (map[int][]models.OrderDiscount, error) {
+	if m.FindDiscountsByOrderIDsFunc != nil {
+		return m.FindDiscountsByOrderIDsFunc(ctx, dbtx, orderIDs)
+	}
+	panic("not implemented")
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
 // テスト定数
 const (
 	testOrderUserID = 1
@@ -152,9 +288,14 @@ func TestOrderService_GetUserOrders(t *testing.T) {
 							ShopName:     "テストショップ",
 							Location:     "テスト場所",
 							OrderDate:    time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
-							TotalAmount:  300,
+							TotalAmount:  250,
 							Status:       models.Cooking,
 							WaitingCount: 2,
+
+							DiningOption:   models.Takeout,
+							SubtotalAmount: 300,
+							TaxAmount:      18,
+							DiscountAmount: 50,
 						},
 					}, nil
 				}
@@ -166,6 +307,24 @@ func TestOrderService_GetUserOrders(t *testing.T) {
 						},
 					}, nil
 				}
+				m.FindTaxLinesByOrderIDsFunc = func(ctx context.Context, dbtx repositories.DBTX, orderIDs []int) (map[int][]models.OrderTaxLine, error) {
+					return map[int][]models.OrderTaxLine{
+						testOrderID: {{OrderID: testOrderID, TaxRate: 8, TaxableAmount: 250, TaxAmount: 18}},
+					}, nil
+				}
+				m.FindDiscountsByOrderIDsFunc = func(ctx context.Context, dbtx repositories.DBTX, orderIDs []int) (map[int][]models.OrderDiscount, error) {
+					return map[int][]models.OrderDiscount{
+						testOrderID: {{OrderID: testOrderID, Source: models.PromotionSource, Code: "SPRING50", Name: "春の50円引き", TaxRate: 8, Amount: 50}},
+					}, nil
+				}
+				m.FindCookingQueueFunc = func(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]repositories.KitchenQueueEntry, error) {
+					return []repositories.KitchenQueueEntry{
+						{OrderID: testOrderID, OrderDate: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), WorkloadSeconds: 600},
+					}, nil
+				}
+				m.FindRecentKitchenDurationsFunc = func(ctx context.Context, dbtx repositories.DBTX, shopID int, since time.Time, limit int) ([]repositories.KitchenDurationSample, error) {
+					return nil, nil
+				}
 			},
 			wantOrders: []models.OrderListResponse{
 				{
@@ -173,13 +332,19 @@ func TestOrderService_GetUserOrders(t *testing.T) {
 					ShopName:     "テストショップ",
 					Location:     "テスト場所",
 					OrderDate:    time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
-					TotalAmount:  300,
+					TotalAmount:  250,
 					Status:       models.Cooking.String(),
 					WaitingCount: 2,
 					Items: []models.ItemDetail{
 						{ItemName: "商品1", Quantity: 2},
 						{ItemName: "商品2", Quantity: 1},
 					},
+					DiningOption:   "takeout",
+					SubtotalAmount: 300,
+					TaxAmount:      18,
+					TaxBreakdown:   []models.OrderTaxLine{{OrderID: testOrderID, TaxRate: 8, TaxableAmount: 250, TaxAmount: 18}},
+					DiscountAmount: 50,
+					Discounts:      []models.OrderDiscount{{OrderID: testOrderID, Source: models.PromotionSource, Code: "SPRING50", Name: "春の50円引き", TaxRate: 8, Amount: 50}},
 				},
 			},
 			expectedErrCode: "",
@@ -236,10 +401,10 @@ func TestOrderService_GetUserOrders(t *testing.T) {
 			mockDB := &sqlx.DB{}
 
 			// サービス初期化（DBTX対応 - NewOrderServiceForTestを使わずに直接NewOrderServiceを使用）
-			orderService := services.NewOrderService(orderRepo, itemRepo, mockDB)
+			orderService := services.NewOrderService(orderRepo, itemRepo, nil, nil, nil, nil, mockDB)
 
 			// テスト実行
-			gotOrders, err := orderService.GetUserOrders(context.Background(), tt.userID)
+			gotOrders, err := orderService.GetUserOrders(context.Background(), tt.userID, "ja")
 
 			// エラーアサーション
 			if tt.expectedErrCode == "" {
@@ -248,11 +413,17 @@ func TestOrderService_GetUserOrders(t *testing.T) {
 				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
 			}
 
-			// 正常系の場合のアサーション
+			// 正常系の場合のアサーション（推定時刻は現在時刻に依存するため個別に検証）
 			if tt.expectedErrCode == "" {
-				if diff := cmp.Diff(tt.wantOrders, gotOrders); diff != "" {
+				opts := cmpopts.IgnoreFields(models.OrderListResponse{}, "EstimatedReadyAt")
+				if diff := cmp.Diff(tt.wantOrders, gotOrders, opts); diff != "" {
 					t.Errorf("%s: orders mismatch (-want +got):\n%s", tt.name, diff)
 				}
+				for _, order := range gotOrders {
+					if order.Status == models.Cooking.String() && order.EstimatedReadyAt == nil {
+						t.Errorf("調理中の注文(ID: %d)に受け取り予定時刻が設定されていません", order.OrderID)
+					}
+				}
 			}
 		})
 	}
@@ -283,11 +454,22 @@ func TestOrderService_GetOrderStatus(t *testing.T) {
 				m.CountWaitingOrdersFunc = func(ctx context.Context, dbtx repositories.DBTX, shopID int, orderDate time.Time) (int, error) {
 					return 3, nil
 				}
+				m.FindCookingQueueFunc = func(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]repositories.KitchenQueueEntry, error) {
+					// 先頭の注文が遠い未来に入っているため、推定時刻は現在時刻に依存しない
+					return []repositories.KitchenQueueEntry{
+						{OrderID: 99, OrderDate: time.Date(2999, 1, 1, 12, 0, 0, 0, time.UTC), WorkloadSeconds: 300},
+						{OrderID: testOrderID, OrderDate: time.Date(2999, 1, 1, 12, 1, 0, 0, time.UTC), WorkloadSeconds: 600},
+					}, nil
+				}
+				m.FindRecentKitchenDurationsFunc = func(ctx context.Context, dbtx repositories.DBTX, shopID int, since time.Time, limit int) ([]repositories.KitchenDurationSample, error) {
+					return nil, nil
+				}
 			},
 			wantStatus: &models.OrderStatusResponse{
-				OrderID:      testOrderID,
-				Status:       models.Cooking.String(),
-				WaitingCount: 3,
+				OrderID:          testOrderID,
+				Status:           models.Cooking.String(),
+				WaitingCount:     3,
+				EstimatedReadyAt: timePtr(time.Date(2999, 1, 1, 12, 15, 0, 0, time.UTC)),
 			},
 			expectedErrCode: "",
 		},
@@ -298,17 +480,19 @@ func TestOrderService_GetOrderStatus(t *testing.T) {
 			setupOrderRepo: func(m *OrderRepositoryMockForOrder) {
 				m.FindOrderByIDAndUserFunc = func(ctx context.Context, dbtx repositories.DBTX, orderID int, userID int) (*models.Order, error) {
 					return &models.Order{
-						OrderID:   testOrderID,
-						ShopID:    testOrderShopID,
-						Status:    models.Completed,
-						OrderDate: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+						OrderID:     testOrderID,
+						ShopID:      testOrderShopID,
+						Status:      models.Completed,
+						OrderDate:   time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+						CompletedAt: sql.NullTime{Time: time.Date(2024, 1, 1, 12, 8, 0, 0, time.UTC), Valid: true},
 					}, nil
 				}
 			},
 			wantStatus: &models.OrderStatusResponse{
-				OrderID:      testOrderID,
-				Status:       models.Completed.String(),
-				WaitingCount: 0,
+				OrderID:          testOrderID,
+				Status:           models.Completed.String(),
+				WaitingCount:     0,
+				EstimatedReadyAt: timePtr(time.Date(2024, 1, 1, 12, 8, 0, 0, time.UTC)),
 			},
 			expectedErrCode: "",
 		},
@@ -358,7 +542,7 @@ func TestOrderService_GetOrderStatus(t *testing.T) {
 			mockDB := &sqlx.DB{}
 
 			// サービス初期化（DBTX対応）
-			orderService := services.NewOrderService(orderRepo, itemRepo, mockDB)
+			orderService := services.NewOrderService(orderRepo, itemRepo, nil, nil, nil, nil, mockDB)
 
 			// テスト実行
 			gotStatus, err := orderService.GetOrderStatus(context.Background(), tt.userID, tt.orderID)
@@ -380,5 +564,184 @@ func TestOrderService_GetOrderStatus(t *testing.T) {
 	}
 }
 
+func TestOrderService_GetReceipt(t *testing.T) {
+	registrationNumber := "T1234567890123"
+	orderDate := time.Date(2025, 8, 14, 10, 0, 0, 0, time.UTC)
+	newReceiptOrder := func(status models.OrderStatus) *repositories.ReceiptOrderDB {
+		return &repositories.ReceiptOrderDB{
+			OrderID:                   testOrderID,
+			UserID:                    sql.NullInt64{Int64: int64(testOrderUserID), Valid: true},
+			GuestOrderToken:           sql.NullString{String: "guest-token", Valid: true},
+			Status:                    status,
+			OrderDate:                 orderDate,
+			DiningOption:              models.Takeout,
+			SubtotalAmount:            2250,
+			TaxAmount:                 175,
+			TotalAmount:               2250,
+			ShopName:                  "A4食堂",
+			ShopLocation:              "神戸市灘区六甲台町1-1",
+			InvoiceRegistrationNumber: sql.NullString{String: registrationNumber, Valid: true},
+		}
+	}
+	setupLines := func(m *OrderRepositoryMockForOrder) {
+		m.FindReceiptLinesFunc = func(ctx context.Context, dbtx repositories.DBTX, orderID int) ([]repositories.ReceiptLineDB, error) {
+			return []repositories.ReceiptLineDB{
+				{ItemName: "唐揚げ定食", Quantity: 2, UnitPrice: 850, TaxRate: 8},
+				{ItemName: "瓶ビール（中瓶）", Quantity: 1, UnitPrice: 550, TaxRate: 10},
+			}, nil
+		}
+		m.FindTaxLinesByOrderIDsFunc = func(ctx context.Context, dbtx repositories.DBTX, orderIDs []int) (map[int][]models.OrderTaxLine, error) {
+			return map[int][]models.OrderTaxLine{
+				testOrderID: {
+					{OrderID: testOrderID, TaxRate: 8, TaxableAmount: 1700, TaxAmount: 125},
+					{OrderID: testOrderID, TaxRate: 10, TaxableAmount: 550, TaxAmount: 50},
+				},
+			}, nil
+		}
+		m.FindDiscountsByOrderIDsFunc = func(ctx context.Context, dbtx repositories.DBTX, orderIDs []int) (map[int][]models.OrderDiscount, error) {
+			return map[int][]models.OrderDiscount{}, nil
+		}
+	}
+	userID := testOrderUserID
+	otherUserID := testOrderUserID + 1
+
+	tests := []struct {
+		name            string
+		userID          *int
+		guestToken      string
+		setupOrderRepo  func(*OrderRepositoryMockForOrder)
+		want            *models.ReceiptResponse
+		expectedErrCode apperrors.ErrCode
+	}{
+		{
+			name:   "正常系: 注文したユーザーは適格請求書を取得できる",
+			userID: &userID,
+			setupOrderRepo: func(m *OrderRepositoryMockForOrder) {
+				m.FindReceiptOrderFunc = func(ctx context.Context, dbtx repositories.DBTX, orderID int) (*repositories.ReceiptOrderDB, error) {
+					return newReceiptOrder(models.Handed), nil
+				}
+				setupLines(m)
+			},
+			want: &models.ReceiptResponse{
+				OrderID:                   testOrderID,
+				Recipient:                 "株式会社サンプル",
+				ShopName:                  "A4食堂",
+				ShopLocation:              "神戸市灘区六甲台町1-1",
+				InvoiceRegistrationNumber: &registrationNumber,
+				IsQualifiedInvoice:        true,
+				TransactionDate:           orderDate,
+				DiningOption:              "takeout",
+				Lines: []models.ReceiptLineResponse{
+					{ItemName: "唐揚げ定食", Quantity: 2, UnitPrice: 850, Amount: 1700, TaxRate: 8, IsReducedRate: true},
+					{ItemName: "瓶ビール（中瓶）", Quantity: 1, UnitPrice: 550, Amount: 550, TaxRate: 10},
+				},
+				SubtotalAmount: 2250,
+				TaxAmount:      175,
+				TotalAmount:    2250,
+				TaxBreakdown: []models.OrderTaxLine{
+					{OrderID: testOrderID, TaxRate: 8, TaxableAmount: 1700, TaxAmount: 125},
+					{OrderID: testOrderID, TaxRate: 10, TaxableAmount: 550, TaxAmount: 50},
+				},
+				Discounts: []models.OrderDiscount{},
+			},
+		},
+		{
+			name:       "正常系: ゲスト用トークンで取得できる。登録番号がない店舗は適格請求書にならない",
+			guestToken: "guest-token",
+			setupOrderRepo: func(m *OrderRepositoryMockForOrder) {
+				m.FindReceiptOrderFunc = func(ctx context.Context, dbtx repositories.DBTX, orderID int) (*repositories.ReceiptOrderDB, error) {
+					order := newReceiptOrder(models.Cooking)
+					order.UserID = sql.NullInt64{}
+					order.InvoiceRegistrationNumber = sql.NullString{}
+					return order, nil
+				}
+				setupLines(m)
+			},
+			want: &models.ReceiptResponse{
+				OrderID:         testOrderID,
+				Recipient:       "株式会社サンプル",
+				ShopName:        "A4食堂",
+				ShopLocation:    "神戸市灘区六甲台町1-1",
+				TransactionDate: orderDate,
+				DiningOption:    "takeout",
+				Lines: []models.ReceiptLineResponse{
+					{ItemName: "唐揚げ定食", Quantity: 2, UnitPrice: 850, Amount: 1700, TaxRate: 8, IsReducedRate: true},
+					{ItemName: "瓶ビール（中瓶）", Quantity: 1, UnitPrice: 550, Amount: 550, TaxRate: 10},
+				},
+				SubtotalAmount: 2250,
+				TaxAmount:      175,
+				TotalAmount:    2250,
+				TaxBreakdown: []models.OrderTaxLine{
+					{OrderID: testOrderID, TaxRate: 8, TaxableAmount: 1700, TaxAmount: 125},
+					{OrderID: testOrderID, TaxRate: 10, TaxableAmount: 550, TaxAmount: 50},
+				},
+				Discounts: []models.OrderDiscount{},
+			},
+		},
+		{
+			name:   "異常系: 他のユーザーの注文は見つからない扱い",
+			userID: &otherUserID,
+			setupOrderRepo: func(m *OrderRepositoryMockForOrder) {
+				m.FindReceiptOrderFunc = func(ctx context.Context, dbtx repositories.DBTX, orderID int) (*repositories.ReceiptOrderDB, error) {
+					return newReceiptOrder(models.Handed), nil
+				}
+			},
+			expectedErrCode: apperrors.NoData,
+		},
+		{
+			name:       "異常系: ゲスト用トークンが一致しない",
+			guestToken: "wrong-token",
+			setupOrderRepo: func(m *OrderRepositoryMockForOrder) {
+				m.FindReceiptOrderFunc = func(ctx context.Context, dbtx repositories.DBTX, orderID int) (*repositories.ReceiptOrderDB, error) {
+					return newReceiptOrder(models.Handed), nil
+				}
+			},
+			expectedErrCode: apperrors.NoData,
+		},
+		{
+			name:   "異常系: 決済待ちの注文",
+			userID: &userID,
+			setupOrderRepo: func(m *OrderRepositoryMockForOrder) {
+				m.FindReceiptOrderFunc = func(ctx context.Context, dbtx repositories.DBTX, orderID int) (*repositories.ReceiptOrderDB, error) {
+					return newReceiptOrder(models.PendingPayment), nil
+				}
+			},
+			expectedErrCode: apperrors.Conflict,
+		},
+		{
+			name:   "異常系: 注文が存在しない",
+			userID: &userID,
+			setupOrderRepo: func(m *OrderRepositoryMockForOrder) {
+				m.FindReceiptOrderFunc = func(ctx context.Context, dbtx repositories.DBTX, orderID int) (*repositories.ReceiptOrderDB, error) {
+					return nil, apperrors.NoData.Wrap(nil, "注文が見つからないか、アクセス権がありません。")
+				}
+			},
+			expectedErrCode: apperrors.NoData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderRepo := NewOrderRepositoryMockForOrder()
+			tt.setupOrderRepo(orderRepo)
+
+			orderService := services.NewOrderService(orderRepo, NewItemRepositoryMockForOrder(), nil, nil, nil, nil, &sqlx.DB{})
+			got, err := orderService.GetReceipt(context.Background(), testOrderID, tt.userID, tt.guestToken, "株式会社サンプル")
+
+			if tt.expectedErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+				return
+			}
+			testhelpers.AssertNoError(t, err)
+			if got.IssuedAt.IsZero() {
+				t.Error("発行日時が設定されていません")
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(models.ReceiptResponse{}, "IssuedAt")); diff != "" {
+				t.Errorf("領収書が一致しません (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 // NOTE: CreateOrderとCreateAuthenticatedOrderのテストは
 // トランザクションを使用するため、order_service_integration_test.goに移動しました
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+
+	"github.com/A4-dev-team/mobileorder.git/models"
+)
+
+// PaymentProvider は決済代行会社とのやり取りを抽象化したものです。
+// カードの拒否などの決済結果はerrorではなくPaymentProviderResult.Statusで返し、
+// errorは通信障害など結果が分からない場合にだけ返します。
+type PaymentProvider interface {
+	// Name は payments.provider に保存する決済代行会社の識別子です
+	Name() string
+	// Authorize は与信を取ります。非同期の決済ではPaymentPendingを返し、結果はWebhookで届きます
+	Authorize(ctx context.Context, req PaymentAuthorizeRequest) (PaymentProviderResult, error)
+	// Capture は与信済みの決済の売上を確定します。結果が分からなかった場合は同じ冪等キーで再送できます
+	Capture(ctx context.Context, req PaymentCaptureRequest) (PaymentProviderResult, error)
+	// Refund は売上確定済みの決済を返金します
+	Refund(ctx context.Context, req PaymentRefundRequest) (PaymentRefundResult, error)
+	// VerifyWebhook はWebhookの署名を検証し、通知された決済結果を返します
+	VerifyWebhook(payload []byte, signature string) (*PaymentWebhookEvent, error)
+}
+
+type PaymentAuthorizeRequest struct {
+	IdempotencyKey string // 同じキーでの再送は同じ決済として扱われる
+	Amount         int    // 円単位の整数
+	PaymentToken   string // クライアントがカード情報などをトークン化したもの
+}
+
+type PaymentProviderResult struct {
+	ProviderPaymentID string
+	Status            models.PaymentStatus
+	FailureReason     string // StatusがPaymentFailedの場合のみ
+}
+
+type PaymentCaptureRequest struct {
+	IdempotencyKey    string
+	ProviderPaymentID string
+	Amount            int
+}
+
+type PaymentRefundRequest struct {
+	IdempotencyKey    string
+	ProviderPaymentID string
+	Amount            int
+}
+
+type PaymentRefundResult struct {
+	ProviderRefundID string
+}
+
+// Webhookで通知された決済結果。StatusはPaymentAuthorizedかPaymentFailedのいずれか
+type PaymentWebhookEvent struct {
+	ProviderPaymentID string
+	Status            models.PaymentStatus
+	FailureReason     string
+}
@@ -0,0 +1,449 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/jmoiron/sqlx"
+)
+
+type PaymentServicer interface {
+	ProcessOrderPayment(ctx context.Context, order *models.Order, paymentToken string) error
+	HandleWebhook(ctx context.Context, payload []byte, signature string) error
+	RefundOrder(ctx context.Context, adminShopID int, staffUserID int, orderID int, req models.CreateRefundRequest) (*models.RefundResponse, error)
+	ExpireStalePayments(ctx context.Context, orderedBefore time.Time) error
+	RetryPendingRefunds(ctx context.Context, createdBefore time.Time) error
+}
+
+type paymentService struct {
+	pyr      repositories.PaymentRepository
+	rfr      repositories.RefundRepository
+	orr      repositories.OrderRepository
+	itr      repositories.ItemRepository
+	prr      repositories.PromotionRepository
+	ptr      repositories.PointRepository
+	provider PaymentProvider
+	db       *sqlx.DB
+}
+
+func NewPaymentService(pyr repositories.PaymentRepository, rfr repositories.RefundRepository, orr repositories.OrderRepository, itr repositories.ItemRepository, prr repositories.PromotionRepository, ptr repositories.PointRepository, provider PaymentProvider, db *sqlx.DB) PaymentServicer {
+	return &paymentService{
+		pyr:      pyr,
+		rfr:      rfr,
+		orr:      orr,
+		itr:      itr,
+		prr:      prr,
+		ptr:      ptr,
+		provider: provider,
+		db:       db,
+	}
+}
+
+// ProcessOrderPayment は決済待ちの注文の与信を取り、売上を確定して注文を調理中にします。
+// 非同期の決済で承認待ちになった場合や、売上確定の結果が分からなかった場合は注文を決済待ちのまま返し、
+// 結果はWebhookか期限切れの処理（ExpireStalePayments）で反映します。
+// 決済が失敗した注文は在庫を戻して取り消し、PaymentFailedを返します。
+func (s *paymentService) ProcessOrderPayment(ctx context.Context, order *models.Order, paymentToken string) error {
+	payment := &models.Payment{
+		OrderID:  sql.NullInt64{Int64: int64(order.OrderID), Valid: true},
+		Provider: s.provider.Name(),
+		Amount:   order.TotalAmount,
+		Status:   models.PaymentPending,
+	}
+	if err := s.pyr.CreatePayment(ctx, s.db, payment); err != nil {
+		return s.abandonOrder(ctx, order, nil, "payment_not_started", err)
+	}
+
+	result, err := s.provider.Authorize(ctx, PaymentAuthorizeRequest{
+		IdempotencyKey: paymentIdempotencyKey(payment.PaymentID),
+		Amount:         payment.Amount,
+		PaymentToken:   paymentToken,
+	})
+	if err != nil {
+		// 結果が分からない場合も注文は取り消す。与信が取れていても売上を確定しなければ期限切れで解放される
+		return s.abandonOrder(ctx, order, payment, "provider_error", err)
+	}
+	payment.ProviderPaymentID = sql.NullString{String: result.ProviderPaymentID, Valid: result.ProviderPaymentID != ""}
+
+	switch result.Status {
+	case models.PaymentPending:
+		return s.pyr.UpdatePaymentResult(ctx, s.db, payment)
+	case models.PaymentAuthorized:
+		claimed, err := s.pyr.MarkPaymentCapturing(ctx, s.db, payment)
+		if err != nil || !claimed {
+			return err
+		}
+		if err := s.capture(ctx, order, payment); err != nil {
+			return err
+		}
+		if payment.Status == models.PaymentFailed {
+			return apperrors.PaymentFailed.WrapMessage(nil, apperrors.MsgPaymentNotCompleted, apperrors.Params{"reason": payment.FailureReason.String})
+		}
+		return nil
+	default:
+		reason := result.FailureReason
+		if reason == "" {
+			reason = "declined"
+		}
+		return s.abandonOrder(ctx, order, payment, reason, nil)
+	}
+}
+
+// HandleWebhook は決済代行会社から届いた非同期の決済結果を反映します。
+// 重複して届いた通知や、既に結果が確定している決済への通知は何もせずに受け付けます。
+func (s *paymentService) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+	event, err := s.provider.VerifyWebhook(payload, signature)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return apperrors.Unknown.Wrap(err, "トランザクションの開始に失敗しました。")
+	}
+	defer tx.Rollback()
+
+	payment, err := s.pyr.FindPaymentByProviderPaymentIDForUpdate(ctx, tx, s.provider.Name(), event.ProviderPaymentID)
+	if err != nil {
+		return err
+	}
+	if payment.Status != models.PaymentPending || !payment.OrderID.Valid {
+		return nil
+	}
+
+	order, err := s.orr.FindOrderByID(ctx, tx, int(payment.OrderID.Int64))
+	if err != nil {
+		return err
+	}
+	if order.Status != models.PendingPayment {
+		return apperrors.Conflict.WrapMessage(nil, apperrors.MsgPaymentResultStatusConflict, apperrors.Params{"status": order.Status.String()})
+	}
+
+	if event.Status != models.PaymentAuthorized {
+		if err := s.failOrder(ctx, tx, order, payment, event.FailureReason); err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return apperrors.Unknown.Wrap(err, "トランザクションのコミットに失敗しました。")
+		}
+		return nil
+	}
+
+	// 売上確定中にしてからコミットし、決済代行会社への依頼は行ロックを離してから行う
+	if _, err := s.pyr.MarkPaymentCapturing(ctx, tx, payment); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return apperrors.Unknown.Wrap(err, "トランザクションのコミットに失敗しました。")
+	}
+	return s.capture(ctx, order, payment)
+}
+
+// ExpireStalePayments は orderedBefore より前に注文されたまま決済待ちになっている注文を片付けます。
+// 売上確定中の決済は同じ冪等キーで売上確定をやり直し、それ以外は注文を取り消します。
+// 1件の失敗で残りの注文を止めないよう、エラーは最後にまとめて返します。
+func (s *paymentService) ExpireStalePayments(ctx context.Context, orderedBefore time.Time) error {
+	orderIDs, err := s.pyr.FindStalePendingPaymentOrderIDs(ctx, s.db, orderedBefore)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, orderID := range orderIDs {
+		if err := s.expireOrder(ctx, orderID); err != nil {
+			errs = append(errs, fmt.Errorf("order %d: %w", orderID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// expireOrder は決済待ちのまま期限が過ぎた注文を1件片付けます
+func (s *paymentService) expireOrder(ctx context.Context, orderID int) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return apperrors.Unknown.Wrap(err, "トランザクションの開始に失敗しました。")
+	}
+	defer tx.Rollback()
+
+	payment, err := s.pyr.FindLatestPaymentByOrderIDForUpdate(ctx, tx, orderID)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if !errors.As(err, &appErr) || appErr.ErrCode != apperrors.NoData {
+			return err
+		}
+		// 決済の登録前に止まった注文
+		payment = nil
+	}
+	order, err := s.orr.FindOrderByID(ctx, tx, orderID)
+	if err != nil {
+		return err
+	}
+	if order.Status != models.PendingPayment {
+		return nil
+	}
+
+	if payment != nil && payment.Status == models.PaymentCapturing {
+		if err := tx.Commit(); err != nil {
+			return apperrors.Unknown.Wrap(err, "トランザクションのコミットに失敗しました。")
+		}
+		return s.capture(ctx, order, payment)
+	}
+
+	if err := s.failOrder(ctx, tx, order, payment, "expired"); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return apperrors.Unknown.Wrap(err, "トランザクションのコミットに失敗しました。")
+	}
+	return nil
+}
+
+// RefundOrder は店舗の注文の決済を返金します。req.Itemsを指定すると注文商品ごとの部分返金、
+// 省略するとまだ返金していない金額の全額返金になります。返金額の合計が支払額を超える場合はConflictを返します。
+// 返金は返金依頼中として記録してコミットしてから決済代行会社に依頼し、結果が返ってから返金済みにします。
+// 結果が分からなかった場合は返金依頼中のまま残してPaymentFailedを返し、RetryPendingRefundsで同じ冪等キーを使ってやり直します。
+func (s *paymentService) RefundOrder(ctx context.Context, adminShopID int, staffUserID int, orderID int, req models.CreateRefundRequest) (*models.RefundResponse, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.Unknown.Wrap(err, "トランザクションの開始に失敗しました。")
+	}
+	defer tx.Rollback()
+
+	if _, err := s.orr.FindOrderByIDAndShopID(ctx, tx, orderID, adminShopID); err != nil {
+		return nil, err
+	}
+	payment, err := s.pyr.FindCapturedPaymentByOrderIDForUpdate(ctx, tx, orderID)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) && appErr.ErrCode == apperrors.NoData {
+			return nil, apperrors.Conflict.WrapMessage(err, apperrors.MsgRefundUnpaidOrder, nil)
+		}
+		return nil, err
+	}
+
+	lines, err := s.rfr.FindRefundableOrderItems(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	discountsMap, err := s.orr.FindDiscountsByOrderIDs(ctx, tx, []int{orderID})
+	if err != nil {
+		return nil, err
+	}
+	items, amount, err := CalculateRefundItems(lines, discountsMap[orderID], req.Items)
+	if err != nil {
+		return nil, err
+	}
+	remaining := payment.Amount - payment.RefundedAmount
+	if len(req.Items) == 0 {
+		// 全額返金は、商品ごとの金額ではなく支払額の残りをそのまま返す。
+		// 数量がすべて返金済みでも、商品ごとの返金で切り捨てた端数が残っていれば返金する
+		amount = remaining
+	}
+	if amount <= 0 {
+		return nil, apperrors.Conflict.WrapMessage(nil, apperrors.MsgOrderFullyRefunded, nil)
+	}
+	if amount > remaining {
+		return nil, apperrors.Conflict.WrapMessage(nil, apperrors.MsgRefundAmountExceeded, apperrors.Params{"amount": amount, "remaining": remaining})
+	}
+
+	refund := &models.Refund{
+		PaymentID:   payment.PaymentID,
+		OrderID:     sql.NullInt64{Int64: int64(orderID), Valid: true},
+		Amount:      amount,
+		Reason:      req.Reason,
+		StaffUserID: staffUserID,
+		Status:      models.RefundPending,
+		Items:       items,
+	}
+	if err := s.rfr.CreateRefund(ctx, tx, refund); err != nil {
+		return nil, err
+	}
+	// 返金依頼中の金額も返金済みに含め、同時に依頼された返金が支払額を超えないようにする
+	if err := s.pyr.AddRefundedAmount(ctx, tx, payment.PaymentID, amount); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.Unknown.Wrap(err, "トランザクションのコミットに失敗しました。")
+	}
+
+	if err := s.completeRefund(ctx, refund.RefundID, payment.ProviderPaymentID.String, amount); err != nil {
+		return nil, apperrors.PaymentFailed.WrapMessage(err, apperrors.MsgRefundResultUnknown, nil)
+	}
+
+	itemNames := make(map[int]string, len(lines))
+	for _, line := range lines {
+		itemNames[line.OrderItemID] = line.ItemName
+	}
+	itemResponses := make([]models.RefundItemResponse, len(items))
+	for i, item := range items {
+		itemResponses[i] = models.RefundItemResponse{
+			OrderItemID: item.OrderItemID,
+			ItemName:    itemNames[item.OrderItemID],
+			Quantity:    item.Quantity,
+			Amount:      item.Amount,
+		}
+	}
+	return &models.RefundResponse{
+		RefundID:      refund.RefundID,
+		OrderID:       orderID,
+		Amount:        refund.Amount,
+		Reason:        refund.Reason,
+		StaffUserID:   refund.StaffUserID,
+		Items:         itemResponses,
+		RefundedTotal: payment.RefundedAmount + amount,
+		PaidAmount:    payment.Amount,
+		CreatedAt:     refund.CreatedAt,
+	}, nil
+}
+
+// RetryPendingRefunds は createdBefore より前に登録されたまま返金依頼中になっている返金を、同じ冪等キーで決済代行会社に依頼し直します。
+// 1件の失敗で残りの返金を止めないよう、エラーは最後にまとめて返します。
+func (s *paymentService) RetryPendingRefunds(ctx context.Context, createdBefore time.Time) error {
+	refunds, err := s.rfr.FindPendingRefunds(ctx, s.db, createdBefore)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, refund := range refunds {
+		if err := s.completeRefund(ctx, refund.RefundID, refund.ProviderPaymentID, refund.Amount); err != nil {
+			errs = append(errs, fmt.Errorf("refund %d: %w", refund.RefundID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// completeRefund は返金依頼中の返金を決済代行会社に依頼し、返金済みにします。
+// 冪等キーは返金IDから作るため、やり直しても二重に返金しません。
+func (s *paymentService) completeRefund(ctx context.Context, refundID int, providerPaymentID string, amount int) error {
+	result, err := s.provider.Refund(ctx, PaymentRefundRequest{
+		IdempotencyKey:    refundIdempotencyKey(refundID),
+		ProviderPaymentID: providerPaymentID,
+		Amount:            amount,
+	})
+	if err != nil {
+		return err
+	}
+	return s.rfr.CompleteRefund(ctx, s.db, refundID, result.ProviderRefundID)
+}
+
+// capture は売上確定中にした決済の売上を決済代行会社で確定し、結果を反映します。
+// 決済代行会社への依頼はトランザクションの外で行い、結果は決済の行ロックを取ってから反映します。
+// 売上を確定できなかった場合は注文を取り消し、payment.StatusをPaymentFailedにしてnilを返します。
+// 結果が分からなかった場合は売上確定中のまま残し、期限切れの処理で同じ冪等キーを使ってやり直します。
+func (s *paymentService) capture(ctx context.Context, order *models.Order, payment *models.Payment) error {
+	result, err := s.provider.Capture(ctx, PaymentCaptureRequest{
+		IdempotencyKey:    captureIdempotencyKey(payment.PaymentID),
+		ProviderPaymentID: payment.ProviderPaymentID.String,
+		Amount:            payment.Amount,
+	})
+	if err != nil {
+		log.Printf("payment %d: capture result unknown, will retry: %v", payment.PaymentID, err)
+		return nil
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return apperrors.Unknown.Wrap(err, "トランザクションの開始に失敗しました。")
+	}
+	defer tx.Rollback()
+
+	current, err := s.pyr.FindPaymentByIDForUpdate(ctx, tx, payment.PaymentID)
+	if err != nil {
+		return err
+	}
+	if current.Status != models.PaymentCapturing {
+		// 他の処理が先に結果を反映した
+		*payment = *current
+		return nil
+	}
+
+	if result.Status == models.PaymentCaptured {
+		payment.Status = models.PaymentCaptured
+		if err := s.pyr.UpdatePaymentResult(ctx, tx, payment); err != nil {
+			return err
+		}
+		if err := s.orr.UpdateOrderStatus(ctx, tx, order.OrderID, order.ShopID, models.Cooking); err != nil {
+			return err
+		}
+		order.Status = models.Cooking
+	} else {
+		reason := result.FailureReason
+		if reason == "" {
+			reason = "capture_failed"
+		}
+		if err := s.failOrder(ctx, tx, order, payment, reason); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperrors.Unknown.Wrap(err, "トランザクションのコミットに失敗しました。")
+	}
+	return nil
+}
+
+// failOrder は決済を失敗として記録し、注文の在庫とクーポンの利用回数、使ったポイントを戻して注文を取り消しにします。
+// 取り消した注文は売上の集計や領収書の対象にはならないが、記録として残す。
+func (s *paymentService) failOrder(ctx context.Context, dbtx repositories.DBTX, order *models.Order, payment *models.Payment, reason string) error {
+	if payment != nil {
+		payment.Status = models.PaymentFailed
+		payment.FailureReason = sql.NullString{String: reason, Valid: reason != ""}
+		if err := s.pyr.UpdatePaymentResult(ctx, dbtx, payment); err != nil {
+			return err
+		}
+	}
+	if err := s.itr.RestockOrderItems(ctx, dbtx, order.ShopID, order.OrderID); err != nil {
+		return err
+	}
+	if err := s.prr.ReleaseRedemptionByOrderID(ctx, dbtx, order.OrderID); err != nil {
+		return err
+	}
+	if err := s.ptr.RestorePointsByOrderID(ctx, dbtx, order.OrderID); err != nil {
+		return err
+	}
+	if err := s.orr.UpdateOrderStatus(ctx, dbtx, order.OrderID, order.ShopID, models.Cancelled); err != nil {
+		return err
+	}
+	order.Status = models.Cancelled
+	return nil
+}
+
+// abandonOrder は売上を確定する前に決済が失敗した注文を取り消し、PaymentFailedを返します
+func (s *paymentService) abandonOrder(ctx context.Context, order *models.Order, payment *models.Payment, reason string, cause error) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return apperrors.Unknown.Wrap(err, "トランザクションの開始に失敗しました。")
+	}
+	defer tx.Rollback()
+
+	if err := s.failOrder(ctx, tx, order, payment, reason); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return apperrors.Unknown.Wrap(err, "トランザクションのコミットに失敗しました。")
+	}
+	return apperrors.PaymentFailed.WrapMessage(cause, apperrors.MsgPaymentFailedOrderCancelled, apperrors.Params{"reason": reason})
+}
+
+// 決済代行会社への冪等キー。同じ決済の再送が二重の与信にならないよう決済IDから作る
+func paymentIdempotencyKey(paymentID int) string {
+	return fmt.Sprintf("payment-%d", paymentID)
+}
+
+// 返金の冪等キー。結果が分からずにやり直しても二重に返金しないよう返金IDから作る
+func refundIdempotencyKey(refundID int) string {
+	return fmt.Sprintf("refund-%d", refundID)
+}
+
+// 売上確定の冪等キー。結果が分からずにやり直しても二重に確定しないよう決済IDから作る
+func captureIdempotencyKey(paymentID int) string {
+	return fmt.Sprintf("capture-%d", paymentID)
+}
@@ -0,0 +1,266 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/A4-dev-team/mobileorder.git/services"
+)
+
+/*
+=== PaymentService 結合テスト ===
+
+モック決済を使って、注文作成から決済確定・失敗までの流れを実DBで確認します。
+- 与信が通った注文は売上が確定し、調理中になる
+- 拒否された注文は取り消しになり、決済は失敗として記録が残る
+- 承認待ちの注文はWebhookの通知で調理中になる（重複した通知は無視する）
+- 決済待ちのまま期限が過ぎた注文は取り消し、売上確定中の決済は確定し直す
+*/
+
+func TestPaymentService_OrderPaymentFlow_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Integration test skipped in short mode")
+	}
+
+	db := setupOrderTestDB(t)
+	defer db.Close()
+	ensureTestDataExists(t, db)
+
+	provider := services.NewMockPaymentProvider(testWebhookSecret)
+	paymentService := services.NewPaymentService(
+		repositories.NewPaymentRepository(),
+		repositories.NewRefundRepository(),
+		repositories.NewOrderRepository(),
+		repositories.NewItemRepository(),
+		repositories.NewPromotionRepository(),
+		repositories.NewPointRepository(),
+		provider,
+		db,
+	)
+	orderService := services.NewOrderService(repositories.NewOrderRepository(), repositories.NewItemRepository(), repositories.NewPromotionRepository(), repositories.NewPointRepository(), repositories.NewTranslationRepository(), paymentService, db)
+	ctx := context.Background()
+
+	findPayment := func(t *testing.T, orderID int) models.Payment {
+		t.Helper()
+		var payment models.Payment
+		if err := db.Get(&payment, "SELECT * FROM payments WHERE order_id = $1", orderID); err != nil {
+			t.Fatalf("決済の取得に失敗しました: %v", err)
+		}
+		return payment
+	}
+	findOrderStatus := func(t *testing.T, orderID int) models.OrderStatus {
+		t.Helper()
+		var status models.OrderStatus
+		if err := db.Get(&status, "SELECT status FROM orders WHERE order_id = $1", orderID); err != nil {
+			t.Fatalf("注文の取得に失敗しました: %v", err)
+		}
+		return status
+	}
+
+	t.Run("正常系: 与信が通ると売上が確定して調理中になる", func(t *testing.T) {
+		order, err := orderService.CreateOrder(ctx, 1, models.CreateOrderRequest{
+			Items:        []models.OrderItemRequest{{ItemID: 1, Quantity: 1}},
+			PaymentToken: "tok_visa",
+		})
+		testhelpers.AssertNoError(t, err)
+		if order.Status != models.Cooking {
+			t.Errorf("Status = %v, want %v", order.Status, models.Cooking)
+		}
+
+		payment := findPayment(t, order.OrderID)
+		if payment.Status != models.PaymentCaptured || payment.Amount != order.TotalAmount || payment.Provider != "mock" {
+			t.Errorf("想定外の決済: %+v", payment)
+		}
+	})
+
+	t.Run("異常系: 拒否されると注文は取り消しになり、決済の記録は残る", func(t *testing.T) {
+		_, err := orderService.CreateOrder(ctx, 1, models.CreateOrderRequest{
+			Items:        []models.OrderItemRequest{{ItemID: 1, Quantity: 1}},
+			PaymentToken: services.MockPaymentTokenDeclined,
+		})
+		testhelpers.AssertAppError(t, err, apperrors.PaymentFailed)
+
+		var orderID int
+		if err := db.Get(&orderID, "SELECT MAX(order_id) FROM orders WHERE user_id = 1"); err != nil {
+			t.Fatalf("注文の取得に失敗しました: %v", err)
+		}
+		if status := findOrderStatus(t, orderID); status != models.Cancelled {
+			t.Errorf("Status = %v, want %v", status, models.Cancelled)
+		}
+		payment := findPayment(t, orderID)
+		if payment.Status != models.PaymentFailed || payment.FailureReason.String != "card_declined" {
+			t.Errorf("想定外の決済: %+v", payment)
+		}
+	})
+
+	t.Run("正常系: 承認待ちの注文はWebhookで調理中になる", func(t *testing.T) {
+		order, err := orderService.CreateOrder(ctx, 1, models.CreateOrderRequest{
+			Items:        []models.OrderItemRequest{{ItemID: 1, Quantity: 1}},
+			PaymentToken: services.MockPaymentTokenPending,
+		})
+		testhelpers.AssertNoError(t, err)
+		if order.Status != models.PendingPayment {
+			t.Fatalf("Status = %v, want %v", order.Status, models.PendingPayment)
+		}
+
+		payment := findPayment(t, order.OrderID)
+		if payment.Status != models.PaymentPending || !payment.ProviderPaymentID.Valid {
+			t.Fatalf("想定外の決済: %+v", payment)
+		}
+
+		payload := []byte(fmt.Sprintf(`{"type":"payment.authorized","provider_payment_id":%q}`, payment.ProviderPaymentID.String))
+		signature := provider.SignWebhook(payload)
+		testhelpers.AssertNoError(t, paymentService.HandleWebhook(ctx, payload, signature))
+		// 同じ通知が重複して届いても何もしない
+		testhelpers.AssertNoError(t, paymentService.HandleWebhook(ctx, payload, signature))
+
+		if status := findOrderStatus(t, order.OrderID); status != models.Cooking {
+			t.Errorf("Status = %v, want %v", status, models.Cooking)
+		}
+		if payment := findPayment(t, order.OrderID); payment.Status != models.PaymentCaptured {
+			t.Errorf("決済のStatus = %v, want %v", payment.Status, models.PaymentCaptured)
+		}
+	})
+
+	t.Run("正常系: 期限が過ぎた決済待ちの注文は取り消し、売上確定中の決済は確定し直す", func(t *testing.T) {
+		pending, err := orderService.CreateOrder(ctx, 1, models.CreateOrderRequest{
+			Items:        []models.OrderItemRequest{{ItemID: 1, Quantity: 1}},
+			PaymentToken: services.MockPaymentTokenPending,
+		})
+		testhelpers.AssertNoError(t, err)
+		capturing, err := orderService.CreateOrder(ctx, 1, models.CreateOrderRequest{
+			Items:        []models.OrderItemRequest{{ItemID: 1, Quantity: 1}},
+			PaymentToken: services.MockPaymentTokenPending,
+		})
+		testhelpers.AssertNoError(t, err)
+		// 売上確定を依頼したまま結果が分からなかった決済
+		if _, err := db.Exec("UPDATE payments SET status = $1 WHERE order_id = $2", models.PaymentCapturing, capturing.OrderID); err != nil {
+			t.Fatalf("決済の更新に失敗しました: %v", err)
+		}
+
+		testhelpers.AssertNoError(t, paymentService.ExpireStalePayments(ctx, time.Now().Add(time.Minute)))
+
+		if status := findOrderStatus(t, pending.OrderID); status != models.Cancelled {
+			t.Errorf("期限切れの注文のStatus = %v, want %v", status, models.Cancelled)
+		}
+		if payment := findPayment(t, pending.OrderID); payment.Status != models.PaymentFailed || payment.FailureReason.String != "expired" {
+			t.Errorf("期限切れの決済 = %+v", payment)
+		}
+		if status := findOrderStatus(t, capturing.OrderID); status != models.Cooking {
+			t.Errorf("売上確定中だった注文のStatus = %v, want %v", status, models.Cooking)
+		}
+		if payment := findPayment(t, capturing.OrderID); payment.Status != models.PaymentCaptured {
+			t.Errorf("売上確定中だった決済のStatus = %v, want %v", payment.Status, models.PaymentCaptured)
+		}
+	})
+
+	t.Run("異常系: 署名が不正なWebhookは拒否する", func(t *testing.T) {
+		payload := []byte(`{"type":"payment.authorized","provider_payment_id":"mock_pay_abc"}`)
+		err := paymentService.HandleWebhook(ctx, payload, "deadbeef")
+		testhelpers.AssertAppError(t, err, apperrors.Unauthorized)
+	})
+
+	t.Run("正常系: 部分返金のあと残りを全額返金し、支払額を超える返金は拒否する", func(t *testing.T) {
+		order, err := orderService.CreateOrder(ctx, 1, models.CreateOrderRequest{
+			Items:        []models.OrderItemRequest{{ItemID: 1, Quantity: 2}},
+			PaymentToken: "tok_visa",
+		})
+		testhelpers.AssertNoError(t, err)
+		var orderItemID int
+		if err := db.Get(&orderItemID, "SELECT order_item_id FROM order_item WHERE order_id = $1", order.OrderID); err != nil {
+			t.Fatalf("注文商品の取得に失敗しました: %v", err)
+		}
+		// 部分返金は1個分の注文時の価格（税込）になる
+		unitPrice := order.SubtotalAmount / 2
+
+		partial, err := paymentService.RefundOrder(ctx, 1, 1, order.OrderID, models.CreateRefundRequest{
+			Reason: "品切れのため",
+			Items:  []models.RefundItemRequest{{OrderItemID: orderItemID, Quantity: 1}},
+		})
+		testhelpers.AssertNoError(t, err)
+		if partial.Amount != unitPrice || partial.RefundedTotal != unitPrice {
+			t.Errorf("部分返金 = %+v, want amount %d", partial, unitPrice)
+		}
+
+		_, err = paymentService.RefundOrder(ctx, 1, 1, order.OrderID, models.CreateRefundRequest{
+			Reason: "品切れのため",
+			Items:  []models.RefundItemRequest{{OrderItemID: orderItemID, Quantity: 2}},
+		})
+		testhelpers.AssertAppError(t, err, apperrors.Conflict)
+
+		full, err := paymentService.RefundOrder(ctx, 1, 1, order.OrderID, models.CreateRefundRequest{Reason: "注文取り消し"})
+		testhelpers.AssertNoError(t, err)
+		if full.Amount != order.TotalAmount-unitPrice || full.RefundedTotal != order.TotalAmount {
+			t.Errorf("全額返金 = %+v, want refunded total %d", full, order.TotalAmount)
+		}
+
+		_, err = paymentService.RefundOrder(ctx, 1, 1, order.OrderID, models.CreateRefundRequest{Reason: "注文取り消し"})
+		testhelpers.AssertAppError(t, err, apperrors.Conflict)
+
+		if payment := findPayment(t, order.OrderID); payment.RefundedAmount != order.TotalAmount {
+			t.Errorf("RefundedAmount = %d, want %d", payment.RefundedAmount, order.TotalAmount)
+		}
+	})
+
+	t.Run("正常系: 商品ごとにすべて返金したあと、切り捨てた端数を全額返金で返す", func(t *testing.T) {
+		order, err := orderService.CreateOrder(ctx, 1, models.CreateOrderRequest{
+			Items:        []models.OrderItemRequest{{ItemID: 1, Quantity: 3}},
+			PaymentToken: "tok_visa",
+		})
+		testhelpers.AssertNoError(t, err)
+		var line struct {
+			OrderItemID int `db:"order_item_id"`
+			TaxRate     int `db:"tax_rate"`
+		}
+		if err := db.Get(&line, "SELECT order_item_id, tax_rate FROM order_item WHERE order_id = $1", order.OrderID); err != nil {
+			t.Fatalf("注文商品の取得に失敗しました: %v", err)
+		}
+		// 10円の値引きを3個に按分すると、1個ずつの返金では1円未満が切り捨てられる
+		if _, err := db.Exec("INSERT INTO order_discounts (order_id, tax_rate, code, name, amount) VALUES ($1, $2, 'TEST10', 'テスト値引き', 10)", order.OrderID, line.TaxRate); err != nil {
+			t.Fatalf("値引きの登録に失敗しました: %v", err)
+		}
+		paid := order.SubtotalAmount - 10
+		if _, err := db.Exec("UPDATE payments SET amount = $1 WHERE order_id = $2", paid, order.OrderID); err != nil {
+			t.Fatalf("決済の更新に失敗しました: %v", err)
+		}
+
+		refunded := 0
+		for i := 0; i < 3; i++ {
+			res, err := paymentService.RefundOrder(ctx, 1, 1, order.OrderID, models.CreateRefundRequest{
+				Reason: "品切れのため",
+				Items:  []models.RefundItemRequest{{OrderItemID: line.OrderItemID, Quantity: 1}},
+			})
+			testhelpers.AssertNoError(t, err)
+			refunded += res.Amount
+		}
+		if refunded >= paid {
+			t.Fatalf("商品ごとの返金の合計 = %d, want less than %d", refunded, paid)
+		}
+
+		full, err := paymentService.RefundOrder(ctx, 1, 1, order.OrderID, models.CreateRefundRequest{Reason: "端数の返金"})
+		testhelpers.AssertNoError(t, err)
+		if full.Amount != paid-refunded || full.RefundedTotal != paid || len(full.Items) != 0 {
+			t.Errorf("全額返金 = %+v, want amount %d", full, paid-refunded)
+		}
+
+		_, err = paymentService.RefundOrder(ctx, 1, 1, order.OrderID, models.CreateRefundRequest{Reason: "端数の返金"})
+		testhelpers.AssertAppError(t, err, apperrors.Conflict)
+	})
+
+	t.Run("異常系: 他の店舗の注文は返金できない", func(t *testing.T) {
+		order, err := orderService.CreateOrder(ctx, 1, models.CreateOrderRequest{
+			Items:        []models.OrderItemRequest{{ItemID: 1, Quantity: 1}},
+			PaymentToken: "tok_visa",
+		})
+		testhelpers.AssertNoError(t, err)
+
+		_, err = paymentService.RefundOrder(ctx, 999, 1, order.OrderID, models.CreateRefundRequest{Reason: "誤操作"})
+		testhelpers.AssertAppError(t, err, apperrors.NoData)
+	})
+}
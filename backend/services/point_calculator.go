@@ -0,0 +1,46 @@
+package services
+
+import (
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+)
+
+// CalculatePointsDiscount は使うポイントを値引きとして、税率ごとの値引き額（税込）に按分します。1ポイントは1円です。
+// クーポンの値引きがある場合は、クーポンの値引き後の金額に対して使います。
+// 値引き後の小計を超えるポイントは使えず、ValidationFailedを返します。
+func CalculatePointsDiscount(items []models.OrderItem, discounts []models.OrderDiscount, points int) ([]models.OrderDiscount, error) {
+	base := make(map[int]int)
+	for _, item := range items {
+		base[item.TaxRate] += (item.PriceAtOrder + item.ModifierPriceDelta) * item.Quantity
+	}
+	for _, d := range discounts {
+		base[d.TaxRate] -= d.Amount
+	}
+	baseTotal := 0
+	for rate, amount := range base {
+		if amount <= 0 {
+			delete(base, rate)
+			continue
+		}
+		baseTotal += amount
+	}
+
+	if points > baseTotal {
+		return nil, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgPointsExceedSubtotal, apperrors.Params{"amount": baseTotal})
+	}
+
+	amounts, rates := allocateByTaxRate(points, base)
+	result := make([]models.OrderDiscount, 0, len(rates))
+	for _, rate := range rates {
+		if amounts[rate] == 0 {
+			continue
+		}
+		result = append(result, models.OrderDiscount{
+			Source:  models.PointsSource,
+			Name:    "ポイント利用",
+			TaxRate: rate,
+			Amount:  amounts[rate],
+		})
+	}
+	return result, nil
+}
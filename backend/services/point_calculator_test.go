@@ -0,0 +1,70 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCalculatePointsDiscount(t *testing.T) {
+	// 唐揚げ定食（持ち帰りで8%）2つと瓶ビール（10%）1本。小計2250円
+	items := []models.OrderItem{
+		{ItemID: 1, Quantity: 2, PriceAtOrder: 850, TaxRate: 8},
+		{ItemID: 2, Quantity: 1, PriceAtOrder: 500, ModifierPriceDelta: 50, TaxRate: 10},
+	}
+	points := func(rate int, amount int) models.OrderDiscount {
+		return models.OrderDiscount{Source: models.PointsSource, Name: "ポイント利用", TaxRate: rate, Amount: amount}
+	}
+
+	tests := []struct {
+		name            string
+		discounts       []models.OrderDiscount
+		points          int
+		want            []models.OrderDiscount
+		expectedErrCode apperrors.ErrCode
+	}{
+		{
+			name:   "正常系: ポイントは税率ごとの金額の比で按分する",
+			points: 100,
+			// 100 * 1700 / 2250 = 75.5 → 75、100 * 550 / 2250 = 24.4 → 24。残りの1ポイントは8%に寄せる
+			want: []models.OrderDiscount{points(8, 76), points(10, 24)},
+		},
+		{
+			name:      "正常系: クーポンの値引き後の金額に対して使う",
+			discounts: []models.OrderDiscount{{Source: models.PromotionSource, TaxRate: 10, Amount: 550}},
+			points:    300,
+			want:      []models.OrderDiscount{points(8, 300)},
+		},
+		{
+			name:   "正常系: 小計ちょうどまで使える",
+			points: 2250,
+			want:   []models.OrderDiscount{points(8, 1700), points(10, 550)},
+		},
+		{
+			name:            "異常系: 値引き後の小計を超える",
+			discounts:       []models.OrderDiscount{{Source: models.PromotionSource, TaxRate: 8, Amount: 100}},
+			points:          2151,
+			expectedErrCode: apperrors.ValidationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := services.CalculatePointsDiscount(items, tt.discounts, tt.points)
+
+			if tt.expectedErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+				return
+			}
+
+			testhelpers.AssertNoError(t, err)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("discounts mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/jmoiron/sqlx"
+)
+
+// pointHistoryLimit はポイント履歴で返す最大件数です
+const pointHistoryLimit = 50
+
+// pointExpiresAt は獲得したポイントの有効期限（獲得から1年）を返します
+func pointExpiresAt(earnedAt time.Time) time.Time {
+	return earnedAt.AddDate(1, 0, 0)
+}
+
+type PointServicer interface {
+	GetMyPoints(ctx context.Context, userID int) (*models.PointsResponse, error)
+}
+
+type pointService struct {
+	ptr repositories.PointRepository
+	db  *sqlx.DB
+}
+
+func NewPointService(ptr repositories.PointRepository, db *sqlx.DB) PointServicer {
+	return &pointService{
+		ptr: ptr,
+		db:  db,
+	}
+}
+
+// GetMyPoints はユーザーのポイント残高と履歴を返します。有効期限が過ぎたポイントはここで失効させます。
+func (s *pointService) GetMyPoints(ctx context.Context, userID int) (*models.PointsResponse, error) {
+	now := time.Now()
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.Unknown.Wrap(err, "トランザクションの開始に失敗しました。")
+	}
+	defer tx.Rollback()
+
+	if err := s.ptr.ExpirePoints(ctx, tx, userID, now); err != nil {
+		return nil, err
+	}
+	lots, err := s.ptr.FindPointLots(ctx, tx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	transactions, err := s.ptr.FindPointTransactions(ctx, tx, userID, pointHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.Unknown.Wrap(err, "トランザクションのコミットに失敗しました。")
+	}
+	return toPointsResponse(lots, transactions), nil
+}
+
+// toPointsResponse は有効期限の近い順に並んだ使えるポイントと台帳から、残高と履歴のレスポンスを作ります
+func toPointsResponse(lots []models.PointTransaction, transactions []models.PointTransaction) *models.PointsResponse {
+	res := &models.PointsResponse{History: make([]models.PointTransactionResponse, len(transactions))}
+	for _, lot := range lots {
+		res.Balance += lot.Remaining
+		// 同じ日時に失効するポイントはまとめて返す
+		if res.NextExpiration == nil {
+			res.NextExpiration = &models.PointExpirationResponse{ExpiresAt: lot.ExpiresAt.Time}
+		}
+		if lot.ExpiresAt.Time.Equal(res.NextExpiration.ExpiresAt) {
+			res.NextExpiration.Points += lot.Remaining
+		}
+	}
+
+	for i, tr := range transactions {
+		res.History[i] = models.PointTransactionResponse{
+			Kind:      tr.Kind.String(),
+			Points:    tr.Points,
+			OrderID:   fromNullInt64(tr.OrderID),
+			ShopID:    fromNullInt64(tr.ShopID),
+			CreatedAt: tr.CreatedAt,
+		}
+		if tr.Kind == models.PointEarned || tr.Kind == models.PointRestored {
+			res.History[i].ExpiresAt = &tr.ExpiresAt.Time
+		}
+	}
+	return res
+}
@@ -0,0 +1,157 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/google/go-cmp/cmp"
+)
+
+// PointRepositoryMock - PointService用のPointRepositoryモック
+type PointRepositoryMock struct {
+	ExpirePointsFunc          func(ctx context.Context, dbtx repositories.DBTX, userID int, now time.Time) error
+	FindPointLotsFunc         func(ctx context.Context, dbtx repositories.DBTX, userID int, now time.Time) ([]models.PointTransaction, error)
+	FindPointTransactionsFunc func(ctx context.Context, dbtx repositories.DBTX, userID int, limit int) ([]models.PointTransaction, error)
+}
+
+func (m *PointRepositoryMock) CreditPointsForOrder(ctx context.Context, dbtx repositories.DBTX, orderID int, expiresAt time.Time) (int, error) {
+	panic("not implemented")
+}
+
+func (m *PointRepositoryMock) CreditPointsForGuestOrder(ctx context.Context, dbtx repositories.DBTX, guestToken string, expiresAt time.Time) (int, error) {
+	panic("not implemented")
+}
+
+func (m *PointRepositoryMock) ExpirePoints(ctx context.Context, dbtx repositories.DBTX, userID int, now time.Time) error {
+	if m.ExpirePointsFunc != nil {
+		return m.ExpirePointsFunc(ctx, dbtx, userID, now)
+	}
+	panic("not implemented")
+}
+
+func (m *PointRepositoryMock) RedeemPoints(ctx context.Context, dbtx repositories.DBTX, userID int, orderID int, shopID int, points int, now time.Time) error {
+	panic("not implemented")
+}
+
+func (m *PointRepositoryMock) RestorePointsByOrderID(ctx context.Context, dbtx repositories.DBTX, orderID int) error {
+	panic("not implemented")
+}
+
+func (m *PointRepositoryMock) FindPointLots(ctx context.Context, dbtx repositories.DBTX, userID int, now time.Time) ([]models.PointTransaction, error) {
+	if m.FindPointLotsFunc != nil {
+		return m.FindPointLotsFunc(ctx, dbtx, userID, now)
+	}
+	panic("not implemented")
+}
+
+func (m *PointRepositoryMock) FindPointTransactions(ctx context.Context, dbtx repositories.DBTX, userID int, limit int) ([]models.PointTransaction, error) {
+	if m.FindPointTransactionsFunc != nil {
+		return m.FindPointTransactionsFunc(ctx, dbtx, userID, limit)
+	}
+	panic("not implemented")
+}
+
+func TestPointService_GetMyPoints(t *testing.T) {
+	db := setupAdminTestDB(t)
+	defer db.Close()
+
+	soon := time.Date(2026, 5, 1, 3, 0, 0, 0, time.UTC)
+	later := time.Date(2026, 8, 1, 3, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2025, 5, 1, 3, 0, 0, 0, time.UTC)
+	validTime := func(t time.Time) sql.NullTime { return sql.NullTime{Time: t, Valid: true} }
+	validInt := func(v int) sql.NullInt64 { return sql.NullInt64{Int64: int64(v), Valid: true} }
+
+	tests := []struct {
+		name            string
+		setupRepo       func(*PointRepositoryMock)
+		want            *models.PointsResponse
+		expectedErrCode apperrors.ErrCode
+	}{
+		{
+			name: "正常系: 同じ日時に失効するポイントをまとめ、獲得と返還だけに有効期限を付ける",
+			setupRepo: func(m *PointRepositoryMock) {
+				m.FindPointLotsFunc = func(ctx context.Context, dbtx repositories.DBTX, userID int, now time.Time) ([]models.PointTransaction, error) {
+					return []models.PointTransaction{
+						{Kind: models.PointEarned, Remaining: 10, ExpiresAt: validTime(soon)},
+						{Kind: models.PointRestored, Remaining: 5, ExpiresAt: validTime(soon)},
+						{Kind: models.PointEarned, Remaining: 20, ExpiresAt: validTime(later)},
+					}, nil
+				}
+				m.FindPointTransactionsFunc = func(ctx context.Context, dbtx repositories.DBTX, userID int, limit int) ([]models.PointTransaction, error) {
+					if limit != 50 {
+						t.Errorf("limit = %d, want 50", limit)
+					}
+					return []models.PointTransaction{
+						{Kind: models.PointRedeemed, Points: -8, OrderID: validInt(11), ShopID: validInt(1), CreatedAt: createdAt},
+						{Kind: models.PointEarned, Points: 20, OrderID: validInt(10), ShopID: validInt(1), ExpiresAt: validTime(later), CreatedAt: createdAt},
+						{Kind: models.PointExpired, Points: -3, CreatedAt: createdAt},
+					}, nil
+				}
+			},
+			want: &models.PointsResponse{
+				Balance:        35,
+				NextExpiration: &models.PointExpirationResponse{Points: 15, ExpiresAt: soon},
+				History: []models.PointTransactionResponse{
+					{Kind: "redeem", Points: -8, OrderID: intPtr(11), ShopID: intPtr(1), CreatedAt: createdAt},
+					{Kind: "earn", Points: 20, OrderID: intPtr(10), ShopID: intPtr(1), ExpiresAt: &later, CreatedAt: createdAt},
+					{Kind: "expire", Points: -3, CreatedAt: createdAt},
+				},
+			},
+		},
+		{
+			name: "正常系: ポイントがない",
+			setupRepo: func(m *PointRepositoryMock) {
+				m.FindPointLotsFunc = func(ctx context.Context, dbtx repositories.DBTX, userID int, now time.Time) ([]models.PointTransaction, error) {
+					return nil, nil
+				}
+				m.FindPointTransactionsFunc = func(ctx context.Context, dbtx repositories.DBTX, userID int, limit int) ([]models.PointTransaction, error) {
+					return nil, nil
+				}
+			},
+			want: &models.PointsResponse{History: []models.PointTransactionResponse{}},
+		},
+		{
+			name: "異常系: 失効に失敗したら残高を返さない",
+			setupRepo: func(m *PointRepositoryMock) {
+				m.ExpirePointsFunc = func(ctx context.Context, dbtx repositories.DBTX, userID int, now time.Time) error {
+					return apperrors.UpdateDataFailed.Wrap(nil, "ポイントの失効に失敗しました。")
+				}
+			},
+			expectedErrCode: apperrors.UpdateDataFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var expiredUserID int
+			repo := &PointRepositoryMock{
+				ExpirePointsFunc: func(ctx context.Context, dbtx repositories.DBTX, userID int, now time.Time) error {
+					expiredUserID = userID
+					return nil
+				},
+			}
+			tt.setupRepo(repo)
+
+			got, err := services.NewPointService(repo, db).GetMyPoints(context.Background(), 7)
+
+			if tt.expectedErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+				return
+			}
+			testhelpers.AssertNoError(t, err)
+			if expiredUserID != 7 {
+				t.Errorf("失効させたユーザー = %d, want 7", expiredUserID)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("points mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
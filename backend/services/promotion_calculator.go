@@ -0,0 +1,139 @@
+package services
+
+import (
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+)
+
+// CalculatePromotionDiscount は注文商品にクーポンを適用し、税率ごとの値引き額（税込）を返します。
+// 利用条件（有効期間、最低利用金額、対象商品と数量）を満たさない場合はValidationFailedを返します。
+// 値引きする数量に上限があるクーポンは、対象商品の安いものから順に値引きします。
+// 割引率の1円未満は切り捨て、値引き額は対象商品の合計を超えません。
+// 税率の異なる商品にまたがる値引きは対象金額の比で按分し、按分の端数は対象金額が最も大きい税率に寄せます。
+func CalculatePromotionDiscount(promo *models.Promotion, items []models.OrderItem, now time.Time) ([]models.OrderDiscount, error) {
+	if !promo.IsActive ||
+		(promo.StartsAt.Valid && now.Before(promo.StartsAt.Time)) ||
+		(promo.EndsAt.Valid && !now.Before(promo.EndsAt.Time)) {
+		return nil, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgInvalidPromotionCode, nil)
+	}
+
+	eligible := make(map[int]bool, len(promo.ItemIDs))
+	for _, itemID := range promo.ItemIDs {
+		eligible[itemID] = true
+	}
+
+	// 値引きの対象になる商品。数量分を単価ごとにまとめて持つ
+	type eligibleLine struct {
+		unitPrice int
+		quantity  int
+		taxRate   int
+	}
+	subtotal := 0
+	quantity := 0
+	var lines []eligibleLine
+	for _, item := range items {
+		unitPrice := item.PriceAtOrder + item.ModifierPriceDelta
+		subtotal += unitPrice * item.Quantity
+		if len(eligible) > 0 && !eligible[item.ItemID] {
+			continue
+		}
+		quantity += item.Quantity
+		lines = append(lines, eligibleLine{unitPrice: unitPrice, quantity: item.Quantity, taxRate: item.TaxRate})
+	}
+
+	if subtotal < promo.MinSpend {
+		return nil, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgPromotionMinSpend, apperrors.Params{"amount": promo.MinSpend})
+	}
+	if quantity == 0 {
+		return nil, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgPromotionNoTargetItems, nil)
+	}
+	if quantity < promo.MinItemQuantity {
+		return nil, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgPromotionMinQuantity, apperrors.Params{"quantity": promo.MinItemQuantity})
+	}
+
+	remaining := quantity
+	if promo.MaxDiscountedUnits.Valid {
+		remaining = min(remaining, int(promo.MaxDiscountedUnits.Int64))
+		sort.SliceStable(lines, func(i, j int) bool { return lines[i].unitPrice < lines[j].unitPrice })
+	}
+	base := make(map[int]int)
+	baseTotal := 0
+	for _, line := range lines {
+		if remaining == 0 {
+			break
+		}
+		q := min(line.quantity, remaining)
+		remaining -= q
+		base[line.taxRate] += line.unitPrice * q
+		baseTotal += line.unitPrice * q
+	}
+
+	var discount int
+	switch promo.DiscountType {
+	case models.PercentageDiscount:
+		discount = baseTotal * promo.DiscountValue / 100
+	case models.FixedDiscount:
+		discount = min(promo.DiscountValue, baseTotal)
+	default:
+		return nil, apperrors.Unknown.Wrapf(nil, "不正な割引方式のクーポンです (ID: %d)。", promo.PromotionID)
+	}
+	if discount <= 0 {
+		// 0円の商品だけが対象の場合など、値引きが発生しないクーポンは使わせない
+		return nil, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgPromotionNoDiscountableItems, nil)
+	}
+
+	amounts, rates := allocateByTaxRate(discount, base)
+	discounts := make([]models.OrderDiscount, 0, len(rates))
+	for _, rate := range rates {
+		if amounts[rate] == 0 {
+			continue
+		}
+		discounts = append(discounts, models.OrderDiscount{
+			Source:      models.PromotionSource,
+			PromotionID: sql.NullInt64{Int64: int64(promo.PromotionID), Valid: true},
+			Code:        promo.Code,
+			Name:        promo.Name,
+			TaxRate:     rate,
+			Amount:      amounts[rate],
+		})
+	}
+	return discounts, nil
+}
+
+// allocateByTaxRate は値引き額を税率ごとの対象金額の比で按分し、税率ごとの値引き額と昇順の税率を返します。
+// 按分の1円未満は切り捨て、残りは対象金額が最も大きい税率に寄せます。
+func allocateByTaxRate(amount int, base map[int]int) (map[int]int, []int) {
+	rates := make([]int, 0, len(base))
+	baseTotal := 0
+	for rate, b := range base {
+		rates = append(rates, rate)
+		baseTotal += b
+	}
+	sort.Ints(rates)
+
+	largest := rates[0]
+	allocated := 0
+	amounts := make(map[int]int, len(rates))
+	for _, rate := range rates {
+		amounts[rate] = amount * base[rate] / baseTotal
+		allocated += amounts[rate]
+		if base[rate] >= base[largest] {
+			largest = rate
+		}
+	}
+	amounts[largest] += amount - allocated
+	return amounts, rates
+}
+
+// totalDiscount は税率ごとの値引きの合計を返します
+func totalDiscount(discounts []models.OrderDiscount) int {
+	total := 0
+	for _, d := range discounts {
+		total += d.Amount
+	}
+	return total
+}
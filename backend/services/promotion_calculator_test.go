@@ -0,0 +1,177 @@
+package services_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCalculatePromotionDiscount(t *testing.T) {
+	now := time.Date(2025, 4, 10, 12, 0, 0, 0, time.UTC)
+	// 唐揚げ定食（持ち帰りで8%）2つと瓶ビール（10%）1本
+	mixedItems := []models.OrderItem{
+		{ItemID: 1, Quantity: 2, PriceAtOrder: 850, TaxRate: 8},
+		{ItemID: 2, Quantity: 1, PriceAtOrder: 550, TaxRate: 10},
+	}
+	// 同じドリンクをオプション違いで2杯
+	drinks := []models.OrderItem{
+		{ItemID: 1, Quantity: 1, PriceAtOrder: 850, TaxRate: 8},
+		{ItemID: 3, Quantity: 1, PriceAtOrder: 150, ModifierPriceDelta: 50, TaxRate: 8},
+		{ItemID: 3, Quantity: 1, PriceAtOrder: 150, TaxRate: 8},
+	}
+	newPromotion := func(discountType models.DiscountType, value int) *models.Promotion {
+		return &models.Promotion{PromotionID: 1, Code: "CAMPUS", Name: "学園祭クーポン", DiscountType: discountType, DiscountValue: value, IsActive: true}
+	}
+	discount := func(rate int, amount int) models.OrderDiscount {
+		return models.OrderDiscount{
+			Source:      models.PromotionSource,
+			PromotionID: sql.NullInt64{Int64: 1, Valid: true},
+			Code:        "CAMPUS",
+			Name:        "学園祭クーポン",
+			TaxRate:     rate,
+			Amount:      amount,
+		}
+	}
+
+	tests := []struct {
+		name            string
+		promo           func() *models.Promotion
+		items           []models.OrderItem
+		want            []models.OrderDiscount
+		expectedErrCode apperrors.ErrCode
+	}{
+		{
+			name:  "正常系: 値引き額は税率ごとの金額の比で按分し、端数は金額の大きい税率に寄せる",
+			promo: func() *models.Promotion { return newPromotion(models.FixedDiscount, 100) },
+			items: mixedItems,
+			// 100 * 1700 / 2250 = 75.5 → 75、100 * 550 / 2250 = 24.4 → 24。残りの1円は8%に寄せる
+			want: []models.OrderDiscount{discount(8, 76), discount(10, 24)},
+		},
+		{
+			name: "正常系: 割引率は対象商品だけにかけ、1円未満は切り捨てる",
+			promo: func() *models.Promotion {
+				p := newPromotion(models.PercentageDiscount, 15)
+				p.ItemIDs = []int{2}
+				return p
+			},
+			items: mixedItems,
+			want:  []models.OrderDiscount{discount(10, 82)}, // 550 * 15% = 82.5 → 82
+		},
+		{
+			name: "正常系: 2杯目無料は対象商品の安いものから上限数だけ値引きする",
+			promo: func() *models.Promotion {
+				p := newPromotion(models.PercentageDiscount, 100)
+				p.ItemIDs = []int{3}
+				p.MinItemQuantity = 2
+				p.MaxDiscountedUnits = sql.NullInt64{Int64: 1, Valid: true}
+				return p
+			},
+			items: drinks,
+			want:  []models.OrderDiscount{discount(8, 150)},
+		},
+		{
+			name: "正常系: 値引き額は対象商品の合計を超えない",
+			promo: func() *models.Promotion {
+				p := newPromotion(models.FixedDiscount, 1000)
+				p.ItemIDs = []int{2}
+				return p
+			},
+			items: mixedItems,
+			want:  []models.OrderDiscount{discount(10, 550)},
+		},
+		{
+			name: "正常系: 最低利用金額は対象商品ではなく注文全体の小計で判定する",
+			promo: func() *models.Promotion {
+				p := newPromotion(models.FixedDiscount, 100)
+				p.ItemIDs = []int{2}
+				p.MinSpend = 2250
+				return p
+			},
+			items: mixedItems,
+			want:  []models.OrderDiscount{discount(10, 100)},
+		},
+		{
+			name: "異常系: 有効期限が過ぎている",
+			promo: func() *models.Promotion {
+				p := newPromotion(models.FixedDiscount, 100)
+				p.EndsAt = sql.NullTime{Time: now, Valid: true}
+				return p
+			},
+			items:           mixedItems,
+			expectedErrCode: apperrors.ValidationFailed,
+		},
+		{
+			name: "異常系: 利用開始前",
+			promo: func() *models.Promotion {
+				p := newPromotion(models.FixedDiscount, 100)
+				p.StartsAt = sql.NullTime{Time: now.Add(time.Hour), Valid: true}
+				return p
+			},
+			items:           mixedItems,
+			expectedErrCode: apperrors.ValidationFailed,
+		},
+		{
+			name: "異常系: 無効化されたクーポン",
+			promo: func() *models.Promotion {
+				p := newPromotion(models.FixedDiscount, 100)
+				p.IsActive = false
+				return p
+			},
+			items:           mixedItems,
+			expectedErrCode: apperrors.ValidationFailed,
+		},
+		{
+			name: "異常系: 最低利用金額に届かない",
+			promo: func() *models.Promotion {
+				p := newPromotion(models.FixedDiscount, 100)
+				p.MinSpend = 2251
+				return p
+			},
+			items:           mixedItems,
+			expectedErrCode: apperrors.ValidationFailed,
+		},
+		{
+			name: "異常系: 対象商品が注文に含まれていない",
+			promo: func() *models.Promotion {
+				p := newPromotion(models.FixedDiscount, 100)
+				p.ItemIDs = []int{3}
+				return p
+			},
+			items:           mixedItems,
+			expectedErrCode: apperrors.ValidationFailed,
+		},
+		{
+			name: "異常系: 対象商品の数量が足りない",
+			promo: func() *models.Promotion {
+				p := newPromotion(models.PercentageDiscount, 100)
+				p.ItemIDs = []int{3}
+				p.MinItemQuantity = 3
+				return p
+			},
+			items:           drinks,
+			expectedErrCode: apperrors.ValidationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := services.CalculatePromotionDiscount(tt.promo(), tt.items, now)
+
+			if tt.expectedErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+				return
+			}
+
+			testhelpers.AssertNoError(t, err)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("discounts mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/jmoiron/sqlx"
+)
+
+type PromotionServicer interface {
+	CreatePromotion(ctx context.Context, shopID int, req models.CreatePromotionRequest) (*models.PromotionResponse, error)
+	GetShopPromotions(ctx context.Context, shopID int) ([]models.PromotionResponse, error)
+	DeactivatePromotion(ctx context.Context, shopID int, promotionID int) error
+	CreateGlobalPromotion(ctx context.Context, req models.CreatePromotionRequest) (*models.PromotionResponse, error)
+	GetGlobalPromotions(ctx context.Context) ([]models.PromotionResponse, error)
+	DeactivateGlobalPromotion(ctx context.Context, promotionID int) error
+}
+
+type promotionService struct {
+	prr repositories.PromotionRepository
+	itr repositories.ItemRepository
+	db  *sqlx.DB
+}
+
+func NewPromotionService(prr repositories.PromotionRepository, itr repositories.ItemRepository, db *sqlx.DB) PromotionServicer {
+	return &promotionService{
+		prr: prr,
+		itr: itr,
+		db:  db,
+	}
+}
+
+// CreatePromotion は店舗のクーポンを登録します。コードは大文字にそろえて保存します。
+// 対象商品を指定する場合は、すべて店舗で扱っている商品である必要があります。
+func (s *promotionService) CreatePromotion(ctx context.Context, shopID int, req models.CreatePromotionRequest) (*models.PromotionResponse, error) {
+	return s.createPromotion(ctx, sql.NullInt64{Int64: int64(shopID), Valid: true}, req)
+}
+
+// CreateGlobalPromotion は全店舗共通のクーポンを登録します。対象商品は登録されている商品であれば、どの店舗の商品でも指定できます。
+func (s *promotionService) CreateGlobalPromotion(ctx context.Context, req models.CreatePromotionRequest) (*models.PromotionResponse, error) {
+	return s.createPromotion(ctx, sql.NullInt64{}, req)
+}
+
+// createPromotion はクーポンを登録します。shopIDがNULLの場合は全店舗共通のクーポンになります
+func (s *promotionService) createPromotion(ctx context.Context, shopID sql.NullInt64, req models.CreatePromotionRequest) (*models.PromotionResponse, error) {
+	if req.DiscountType == models.PercentageDiscount && req.DiscountValue > 100 {
+		return nil, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgInvalidDiscountPercentage, nil)
+	}
+	if req.StartsAt != nil && req.EndsAt != nil && !req.StartsAt.Before(*req.EndsAt) {
+		return nil, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgPromotionEndsBeforeStart, nil)
+	}
+
+	promo := &models.Promotion{
+		ShopID:             shopID,
+		Code:               strings.ToUpper(req.Code),
+		Name:               req.Name,
+		DiscountType:       req.DiscountType,
+		DiscountValue:      req.DiscountValue,
+		MinSpend:           req.MinSpend,
+		MinItemQuantity:    req.MinItemQuantity,
+		MaxDiscountedUnits: toNullInt64(req.MaxDiscountedUnits),
+		UsageLimitTotal:    toNullInt64(req.UsageLimitTotal),
+		UsageLimitPerUser:  toNullInt64(req.UsageLimitPerUser),
+		ItemIDs:            uniqueInts(req.ItemIDs),
+	}
+	if req.StartsAt != nil {
+		promo.StartsAt = sql.NullTime{Time: *req.StartsAt, Valid: true}
+	}
+	if req.EndsAt != nil {
+		promo.EndsAt = sql.NullTime{Time: *req.EndsAt, Valid: true}
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.Unknown.Wrap(err, "トランザクションの開始に失敗しました。")
+	}
+	defer tx.Rollback()
+
+	if shopID.Valid && len(promo.ItemIDs) > 0 {
+		if _, err := s.itr.ValidateAndGetItemsForShop(ctx, tx, int(shopID.Int64), promo.ItemIDs); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.prr.CreatePromotion(ctx, tx, promo); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.Unknown.Wrap(err, "トランザクションのコミットに失敗しました。")
+	}
+
+	res := toPromotionResponse(*promo)
+	return &res, nil
+}
+
+// GetShopPromotions は店舗のクーポンを新しい順に取得します
+func (s *promotionService) GetShopPromotions(ctx context.Context, shopID int) ([]models.PromotionResponse, error) {
+	promos, err := s.prr.FindPromotionsByShopID(ctx, s.db, shopID)
+	if err != nil {
+		return nil, err
+	}
+	return toPromotionResponses(promos), nil
+}
+
+// GetGlobalPromotions は全店舗共通のクーポンを新しい順に取得します
+func (s *promotionService) GetGlobalPromotions(ctx context.Context) ([]models.PromotionResponse, error) {
+	promos, err := s.prr.FindGlobalPromotions(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	return toPromotionResponses(promos), nil
+}
+
+// DeactivatePromotion は店舗のクーポンを使えなくします。利用済みの注文の値引きはそのまま残ります。
+func (s *promotionService) DeactivatePromotion(ctx context.Context, shopID int, promotionID int) error {
+	return s.prr.DeactivatePromotion(ctx, s.db, shopID, promotionID)
+}
+
+// DeactivateGlobalPromotion は全店舗共通のクーポンを使えなくします。利用済みの注文の値引きはそのまま残ります。
+func (s *promotionService) DeactivateGlobalPromotion(ctx context.Context, promotionID int) error {
+	return s.prr.DeactivateGlobalPromotion(ctx, s.db, promotionID)
+}
+
+func toPromotionResponses(promos []models.Promotion) []models.PromotionResponse {
+	responses := make([]models.PromotionResponse, len(promos))
+	for i, promo := range promos {
+		responses[i] = toPromotionResponse(promo)
+	}
+	return responses
+}
+
+func toPromotionResponse(promo models.Promotion) models.PromotionResponse {
+	res := models.PromotionResponse{
+		PromotionID:        promo.PromotionID,
+		ShopID:             fromNullInt64(promo.ShopID),
+		Code:               promo.Code,
+		Name:               promo.Name,
+		DiscountType:       promo.DiscountType.String(),
+		DiscountValue:      promo.DiscountValue,
+		MinSpend:           promo.MinSpend,
+		MinItemQuantity:    promo.MinItemQuantity,
+		MaxDiscountedUnits: fromNullInt64(promo.MaxDiscountedUnits),
+		UsageLimitTotal:    fromNullInt64(promo.UsageLimitTotal),
+		UsageLimitPerUser:  fromNullInt64(promo.UsageLimitPerUser),
+		UsedCount:          promo.UsedCount,
+		IsActive:           promo.IsActive,
+		ItemIDs:            promo.ItemIDs,
+	}
+	if promo.StartsAt.Valid {
+		res.StartsAt = &promo.StartsAt.Time
+	}
+	if promo.EndsAt.Valid {
+		res.EndsAt = &promo.EndsAt.Time
+	}
+	if res.ItemIDs == nil {
+		res.ItemIDs = []int{}
+	}
+	return res
+}
+
+func toNullInt64(v *int) sql.NullInt64 {
+	if v == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*v), Valid: true}
+}
+
+func fromNullInt64(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	n := int(v.Int64)
+	return &n
+}
+
+// uniqueInts は順序を保ったまま重複を取り除きます
+func uniqueInts(values []int) []int {
+	seen := make(map[int]bool, len(values))
+	var result []int
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,249 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jmoiron/sqlx"
+)
+
+// PromotionRepositoryMock - PromotionService用のPromotionRepositoryモック
+type PromotionRepositoryMock struct {
+	CreatePromotionFunc           func(ctx context.Context, dbtx repositories.DBTX, promo *models.Promotion) error
+	FindPromotionsByShopIDFunc    func(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]models.Promotion, error)
+	FindGlobalPromotionsFunc      func(ctx context.Context, dbtx repositories.DBTX) ([]models.Promotion, error)
+	DeactivatePromotionFunc       func(ctx context.Context, dbtx repositories.DBTX, shopID int, promotionID int) error
+	DeactivateGlobalPromotionFunc func(ctx context.Context, dbtx repositories.DBTX, promotionID int) error
+}
+
+func (m *PromotionRepositoryMock) FindPromotionByCodeForUpdate(ctx context.Context, dbtx repositories.DBTX, shopID int, code string) (*models.Promotion, error) {
+	panic("not implemented")
+}
+
+func (m *PromotionRepositoryMock) CountUserRedemptions(ctx context.Context, dbtx repositories.DBTX, promotionID int, userID int) (int, error) {
+	panic("not implemented")
+}
+
+func (m *PromotionRepositoryMock) RedeemPromotion(ctx context.Context, dbtx repositories.DBTX, promotionID int, orderID int, userID sql.NullInt64, discountAmount int) error {
+	panic("not implemented")
+}
+
+func (m *PromotionRepositoryMock) ReleaseRedemptionByOrderID(ctx context.Context, dbtx repositories.DBTX, orderID int) error {
+	panic("not implemented")
+}
+
+func (m *PromotionRepositoryMock) CreatePromotion(ctx context.Context, dbtx repositories.DBTX, promo *models.Promotion) error {
+	if m.CreatePromotionFunc != nil {
+		return m.CreatePromotionFunc(ctx, dbtx, promo)
+	}
+	panic("not implemented")
+}
+
+func (m *PromotionRepositoryMock) FindPromotionsByShopID(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]models.Promotion, error) {
+	if m.FindPromotionsByShopIDFunc != nil {
+		return m.FindPromotionsByShopIDFunc(ctx, dbtx, shopID)
+	}
+	panic("not implemented")
+}
+
+func (m *PromotionRepositoryMock) FindGlobalPromotions(ctx context.Context, dbtx repositories.DBTX) ([]models.Promotion, error) {
+	if m.FindGlobalPromotionsFunc != nil {
+		return m.FindGlobalPromotionsFunc(ctx, dbtx)
+	}
+	panic("not implemented")
+}
+
+func (m *PromotionRepositoryMock) DeactivatePromotion(ctx context.Context, dbtx repositories.DBTX, shopID int, promotionID int) error {
+	if m.DeactivatePromotionFunc != nil {
+		return m.DeactivatePromotionFunc(ctx, dbtx, shopID, promotionID)
+	}
+	panic("not implemented")
+}
+
+func (m *PromotionRepositoryMock) DeactivateGlobalPromotion(ctx context.Context, dbtx repositories.DBTX, promotionID int) error {
+	if m.DeactivateGlobalPromotionFunc != nil {
+		return m.DeactivateGlobalPromotionFunc(ctx, dbtx, promotionID)
+	}
+	panic("not implemented")
+}
+
+func TestPromotionService_CreatePromotion(t *testing.T) {
+	startsAt := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	endsAt := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		req             models.CreatePromotionRequest
+		expectedErrCode apperrors.ErrCode
+	}{
+		{
+			name:            "異常系: 割引率が100%を超える",
+			req:             models.CreatePromotionRequest{Code: "HALF", Name: "半額", DiscountType: models.PercentageDiscount, DiscountValue: 150},
+			expectedErrCode: apperrors.ValidationFailed,
+		},
+		{
+			name:            "異常系: 終了が開始より前",
+			req:             models.CreatePromotionRequest{Code: "SPRING", Name: "春の値引き", DiscountType: models.FixedDiscount, DiscountValue: 100, StartsAt: &startsAt, EndsAt: &endsAt},
+			expectedErrCode: apperrors.ValidationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 登録の前に検証するため、リポジトリとDBは使わない
+			promotionService := services.NewPromotionService(&PromotionRepositoryMock{}, NewItemRepositoryMockForOrder(), &sqlx.DB{})
+
+			_, err := promotionService.CreatePromotion(context.Background(), 1, tt.req)
+			testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+
+			_, err = promotionService.CreateGlobalPromotion(context.Background(), tt.req)
+			testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+		})
+	}
+}
+
+func TestPromotionService_CreatePromotion_ShopAndGlobal(t *testing.T) {
+	db := setupAdminTestDB(t)
+	defer db.Close()
+
+	req := models.CreatePromotionRequest{Code: "drink2", Name: "ドリンク2杯目無料", DiscountType: models.PercentageDiscount, DiscountValue: 100, ItemIDs: []int{5, 5, 6}}
+
+	t.Run("正常系: 店舗のクーポンは対象商品が店舗の商品か確認する", func(t *testing.T) {
+		var validatedShopID int
+		itemRepo := NewItemRepositoryMockForOrder()
+		itemRepo.ValidateAndGetItemsForShopFunc = func(ctx context.Context, dbtx repositories.DBTX, shopID int, itemIDs []int) (map[int]models.Item, error) {
+			validatedShopID = shopID
+			if diff := cmp.Diff([]int{5, 6}, itemIDs); diff != "" {
+				t.Errorf("確認する商品が一致しません (-want +got):\n%s", diff)
+			}
+			return map[int]models.Item{}, nil
+		}
+		promoRepo := &PromotionRepositoryMock{
+			CreatePromotionFunc: func(ctx context.Context, dbtx repositories.DBTX, promo *models.Promotion) error {
+				if !promo.ShopID.Valid || promo.ShopID.Int64 != 1 {
+					t.Errorf("ShopID = %v, want 1", promo.ShopID)
+				}
+				promo.PromotionID = 3
+				promo.IsActive = true
+				return nil
+			},
+		}
+
+		res, err := services.NewPromotionService(promoRepo, itemRepo, db).CreatePromotion(context.Background(), 1, req)
+		testhelpers.AssertNoError(t, err)
+		if validatedShopID != 1 {
+			t.Errorf("店舗 %d の商品として確認しました, want 1", validatedShopID)
+		}
+		want := &models.PromotionResponse{
+			PromotionID: 3, ShopID: intPtr(1), Code: "DRINK2", Name: "ドリンク2杯目無料", DiscountType: "percentage", DiscountValue: 100,
+			IsActive: true, ItemIDs: []int{5, 6},
+		}
+		if diff := cmp.Diff(want, res); diff != "" {
+			t.Errorf("response mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("正常系: 全店舗共通のクーポンは店舗を持たず、店舗の商品か確認しない", func(t *testing.T) {
+		promoRepo := &PromotionRepositoryMock{
+			CreatePromotionFunc: func(ctx context.Context, dbtx repositories.DBTX, promo *models.Promotion) error {
+				if promo.ShopID.Valid {
+					t.Errorf("ShopID = %v, want NULL", promo.ShopID)
+				}
+				promo.PromotionID = 4
+				return nil
+			},
+		}
+
+		res, err := services.NewPromotionService(promoRepo, NewItemRepositoryMockForOrder(), db).CreateGlobalPromotion(context.Background(), req)
+		testhelpers.AssertNoError(t, err)
+		if res.ShopID != nil || res.Code != "DRINK2" {
+			t.Errorf("response = %+v, want global DRINK2", res)
+		}
+	})
+
+	t.Run("異常系: 店舗で扱っていない商品は登録しない", func(t *testing.T) {
+		itemRepo := NewItemRepositoryMockForOrder()
+		itemRepo.ValidateAndGetItemsForShopFunc = func(ctx context.Context, dbtx repositories.DBTX, shopID int, itemIDs []int) (map[int]models.Item, error) {
+			return nil, apperrors.BadParam.WrapMessage(nil, apperrors.MsgUnknownShopItem, nil)
+		}
+
+		_, err := services.NewPromotionService(&PromotionRepositoryMock{}, itemRepo, db).CreatePromotion(context.Background(), 1, req)
+		testhelpers.AssertAppError(t, err, apperrors.BadParam)
+	})
+}
+
+func TestPromotionService_GetPromotions(t *testing.T) {
+	promos := []models.Promotion{
+		{
+			PromotionID: 2, Code: "WELCOME", Name: "はじめての方100円引き", DiscountType: models.FixedDiscount, DiscountValue: 100,
+			UsageLimitPerUser: sql.NullInt64{Int64: 1, Valid: true}, UsedCount: 12, IsActive: true,
+		},
+	}
+	want := []models.PromotionResponse{
+		{
+			PromotionID: 2, Code: "WELCOME", Name: "はじめての方100円引き", DiscountType: "fixed", DiscountValue: 100,
+			UsageLimitPerUser: intPtr(1), UsedCount: 12, IsActive: true, ItemIDs: []int{},
+		},
+	}
+	promoRepo := &PromotionRepositoryMock{
+		FindPromotionsByShopIDFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]models.Promotion, error) {
+			if shopID != 1 {
+				t.Errorf("shopID = %d, want 1", shopID)
+			}
+			return promos, nil
+		},
+		FindGlobalPromotionsFunc: func(ctx context.Context, dbtx repositories.DBTX) ([]models.Promotion, error) {
+			return promos, nil
+		},
+	}
+	promotionService := services.NewPromotionService(promoRepo, NewItemRepositoryMockForOrder(), &sqlx.DB{})
+
+	t.Run("店舗のクーポン", func(t *testing.T) {
+		got, err := promotionService.GetShopPromotions(context.Background(), 1)
+		testhelpers.AssertNoError(t, err)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("promotions mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("全店舗共通のクーポン", func(t *testing.T) {
+		got, err := promotionService.GetGlobalPromotions(context.Background())
+		testhelpers.AssertNoError(t, err)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("promotions mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestPromotionService_DeactivatePromotion(t *testing.T) {
+	var deactivatedShopID, deactivatedGlobalID int
+	promoRepo := &PromotionRepositoryMock{
+		DeactivatePromotionFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int, promotionID int) error {
+			deactivatedShopID = shopID
+			return apperrors.NoData.Wrap(nil, "指定されたクーポンが見つからないか、この店舗のものではありません。")
+		},
+		DeactivateGlobalPromotionFunc: func(ctx context.Context, dbtx repositories.DBTX, promotionID int) error {
+			deactivatedGlobalID = promotionID
+			return nil
+		},
+	}
+	promotionService := services.NewPromotionService(promoRepo, NewItemRepositoryMockForOrder(), &sqlx.DB{})
+
+	testhelpers.AssertAppError(t, promotionService.DeactivatePromotion(context.Background(), 2, 4), apperrors.NoData)
+	if deactivatedShopID != 2 {
+		t.Errorf("shopID = %d, want 2", deactivatedShopID)
+	}
+
+	testhelpers.AssertNoError(t, promotionService.DeactivateGlobalPromotion(context.Background(), 5))
+	if deactivatedGlobalID != 5 {
+		t.Errorf("promotionID = %d, want 5", deactivatedGlobalID)
+	}
+}
@@ -39,7 +39,7 @@ func (r *RateLimiter) CheckRateLimit(email string) error {
 	if attempt.BlockedAt != nil {
 		// 10分間のブロック
 		if time.Since(*attempt.BlockedAt) < 10*time.Minute {
-			return apperrors.Forbidden.Wrap(nil, "アクセスが一時的にブロックされています。10分後に再試行してください。")
+			return apperrors.Forbidden.WrapMessage(nil, apperrors.MsgAccessBlocked, nil)
 		}
 		// ブロック解除
 		attempt.BlockedAt = nil
@@ -50,7 +50,7 @@ func (r *RateLimiter) CheckRateLimit(email string) error {
 	if time.Since(attempt.LastTry) < time.Minute && attempt.Attempts >= 5 {
 		now := time.Now()
 		attempt.BlockedAt = &now
-		return apperrors.Forbidden.Wrap(nil, "短時間に多数のアクセスが検出されました。10分後に再試行してください。")
+		return apperrors.Forbidden.WrapMessage(nil, apperrors.MsgTooManyRequests, nil)
 	}
 
 	// 1分経過していれば試行回数をリセット
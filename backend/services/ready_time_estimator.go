@@ -0,0 +1,94 @@
+package services
+
+import (
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+)
+
+const (
+	// 実績から補正係数を求める対象期間と件数
+	kitchenSampleWindow = 7 * 24 * time.Hour
+	kitchenSampleLimit  = 30
+	// 補正係数を使うのに必要な最低実績件数
+	minKitchenSamples = 3
+	// 実績が極端な場合でも推定が破綻しないよう補正係数を制限する
+	minKitchenSpeedFactor = 0.25
+	maxKitchenSpeedFactor = 4.0
+)
+
+// KitchenSnapshot は店舗の厨房の状況です。受け取り予定時刻の推定に使います。
+type KitchenSnapshot struct {
+	Queue           []repositories.KitchenQueueEntry // 調理中の注文（調理順）
+	LastCompletedAt *time.Time                       // 直近で調理完了した日時
+	SpeedFactor     float64                          // 見込み時間に対する実際の調理時間の比率
+}
+
+// NewKitchenSnapshot は調理中の注文と実績から厨房の状況を組み立てます
+func NewKitchenSnapshot(queue []repositories.KitchenQueueEntry, samples []repositories.KitchenDurationSample) KitchenSnapshot {
+	snapshot := KitchenSnapshot{
+		Queue:       queue,
+		SpeedFactor: CalibrateKitchenSpeed(samples),
+	}
+	if len(samples) > 0 {
+		lastCompletedAt := samples[0].CompletedAt
+		snapshot.LastCompletedAt = &lastCompletedAt
+	}
+	return snapshot
+}
+
+// CalibrateKitchenSpeed は最近の実績から、見込み調理時間に対する実際の調理時間の比率を求めます。
+// 実績が少ない場合は1（見込み通り）を返します。
+func CalibrateKitchenSpeed(samples []repositories.KitchenDurationSample) float64 {
+	var totalWorkload, totalActual float64
+	count := 0
+	for _, sample := range samples {
+		if sample.WorkloadSeconds <= 0 || sample.ActualSeconds < 0 {
+			continue
+		}
+		totalWorkload += float64(sample.WorkloadSeconds)
+		totalActual += sample.ActualSeconds
+		count++
+	}
+	if count < minKitchenSamples || totalWorkload == 0 {
+		return 1
+	}
+
+	factor := totalActual / totalWorkload
+	if factor < minKitchenSpeedFactor {
+		return minKitchenSpeedFactor
+	}
+	if factor > maxKitchenSpeedFactor {
+		return maxKitchenSpeedFactor
+	}
+	return factor
+}
+
+// EstimateReadyAt は調理中の注文の受け取り予定時刻を推定します。
+// 厨房は注文を順番に調理すると仮定し、先頭の注文の調理開始時刻に、対象の注文までの見込み時間の累計を補正して加えます。
+// 推定時刻を過ぎている場合はnowを返します。対象の注文が調理中でない場合はfalseを返します。
+func (k KitchenSnapshot) EstimateReadyAt(orderID int, now time.Time) (time.Time, bool) {
+	if len(k.Queue) == 0 {
+		return time.Time{}, false
+	}
+
+	// 先頭の注文は、注文日時か直前の注文の完了日時の遅い方から調理が始まっている
+	startedAt := k.Queue[0].OrderDate
+	if k.LastCompletedAt != nil && k.LastCompletedAt.After(startedAt) {
+		startedAt = *k.LastCompletedAt
+	}
+
+	cumulativeSeconds := 0
+	for _, entry := range k.Queue {
+		cumulativeSeconds += entry.WorkloadSeconds
+		if entry.OrderID != orderID {
+			continue
+		}
+		readyAt := startedAt.Add(time.Duration(float64(cumulativeSeconds) * k.SpeedFactor * float64(time.Second)))
+		if readyAt.Before(now) {
+			readyAt = now
+		}
+		return readyAt, true
+	}
+	return time.Time{}, false
+}
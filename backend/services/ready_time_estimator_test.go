@@ -0,0 +1,171 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/A4-dev-team/mobileorder.git/services"
+)
+
+func TestCalibrateKitchenSpeed(t *testing.T) {
+	completedAt := time.Date(2025, 8, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		samples []repositories.KitchenDurationSample
+		want    float64
+	}{
+		{
+			name:    "正常系: 実績がない場合は見込み通り",
+			samples: nil,
+			want:    1,
+		},
+		{
+			name: "正常系: 実績が最低件数に満たない場合は見込み通り",
+			samples: []repositories.KitchenDurationSample{
+				{CompletedAt: completedAt, WorkloadSeconds: 300, ActualSeconds: 600},
+				{CompletedAt: completedAt, WorkloadSeconds: 300, ActualSeconds: 600},
+			},
+			want: 1,
+		},
+		{
+			name: "正常系: 見込みの1.5倍かかっている",
+			samples: []repositories.KitchenDurationSample{
+				{CompletedAt: completedAt, WorkloadSeconds: 300, ActualSeconds: 450},
+				{CompletedAt: completedAt, WorkloadSeconds: 600, ActualSeconds: 900},
+				{CompletedAt: completedAt, WorkloadSeconds: 100, ActualSeconds: 150},
+			},
+			want: 1.5,
+		},
+		{
+			name: "正常系: 見込み時間0の実績は除外される",
+			samples: []repositories.KitchenDurationSample{
+				{CompletedAt: completedAt, WorkloadSeconds: 0, ActualSeconds: 9999},
+				{CompletedAt: completedAt, WorkloadSeconds: 300, ActualSeconds: 150},
+				{CompletedAt: completedAt, WorkloadSeconds: 300, ActualSeconds: 150},
+				{CompletedAt: completedAt, WorkloadSeconds: 300, ActualSeconds: 150},
+			},
+			want: 0.5,
+		},
+		{
+			name: "正常系: 極端に遅い実績は上限で制限される",
+			samples: []repositories.KitchenDurationSample{
+				{CompletedAt: completedAt, WorkloadSeconds: 60, ActualSeconds: 3600},
+				{CompletedAt: completedAt, WorkloadSeconds: 60, ActualSeconds: 3600},
+				{CompletedAt: completedAt, WorkloadSeconds: 60, ActualSeconds: 3600},
+			},
+			want: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := services.CalibrateKitchenSpeed(tt.samples); got != tt.want {
+				t.Errorf("CalibrateKitchenSpeed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKitchenSnapshot_EstimateReadyAt(t *testing.T) {
+	base := time.Date(2025, 8, 16, 12, 0, 0, 0, time.UTC)
+	queue := []repositories.KitchenQueueEntry{
+		{OrderID: 1, OrderDate: base, WorkloadSeconds: 300},
+		{OrderID: 2, OrderDate: base.Add(1 * time.Minute), WorkloadSeconds: 600},
+		{OrderID: 3, OrderDate: base.Add(2 * time.Minute), WorkloadSeconds: 120},
+	}
+	lastCompletedAt := base.Add(2 * time.Minute)
+
+	tests := []struct {
+		name     string
+		snapshot services.KitchenSnapshot
+		orderID  int
+		now      time.Time
+		want     time.Time
+		wantOK   bool
+	}{
+		{
+			name:     "正常系: 先頭の注文は注文日時から見込み時間後",
+			snapshot: services.KitchenSnapshot{Queue: queue, SpeedFactor: 1},
+			orderID:  1,
+			now:      base,
+			want:     base.Add(5 * time.Minute),
+			wantOK:   true,
+		},
+		{
+			name:     "正常系: 前に並ぶ注文の見込み時間が累積される",
+			snapshot: services.KitchenSnapshot{Queue: queue, SpeedFactor: 1},
+			orderID:  3,
+			now:      base,
+			want:     base.Add(17 * time.Minute),
+			wantOK:   true,
+		},
+		{
+			name:     "正常系: 実績の補正係数が反映される",
+			snapshot: services.KitchenSnapshot{Queue: queue, SpeedFactor: 2},
+			orderID:  2,
+			now:      base,
+			want:     base.Add(30 * time.Minute),
+			wantOK:   true,
+		},
+		{
+			name:     "正常系: 直前の注文の完了後から調理が始まる",
+			snapshot: services.KitchenSnapshot{Queue: queue, SpeedFactor: 1, LastCompletedAt: &lastCompletedAt},
+			orderID:  1,
+			now:      base,
+			want:     base.Add(7 * time.Minute),
+			wantOK:   true,
+		},
+		{
+			name:     "正常系: 推定時刻を過ぎている場合は現在時刻",
+			snapshot: services.KitchenSnapshot{Queue: queue, SpeedFactor: 1},
+			orderID:  1,
+			now:      base.Add(1 * time.Hour),
+			want:     base.Add(1 * time.Hour),
+			wantOK:   true,
+		},
+		{
+			name:     "異常系: 調理中でない注文は推定できない",
+			snapshot: services.KitchenSnapshot{Queue: queue, SpeedFactor: 1},
+			orderID:  99,
+			now:      base,
+			wantOK:   false,
+		},
+		{
+			name:     "異常系: 調理中の注文がない",
+			snapshot: services.KitchenSnapshot{SpeedFactor: 1},
+			orderID:  1,
+			now:      base,
+			wantOK:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.snapshot.EstimateReadyAt(tt.orderID, tt.now)
+			if ok != tt.wantOK {
+				t.Fatalf("EstimateReadyAt() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && !got.Equal(tt.want) {
+				t.Errorf("EstimateReadyAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewKitchenSnapshot(t *testing.T) {
+	latest := time.Date(2025, 8, 16, 12, 30, 0, 0, time.UTC)
+	samples := []repositories.KitchenDurationSample{
+		{CompletedAt: latest, WorkloadSeconds: 300, ActualSeconds: 300},
+		{CompletedAt: latest.Add(-10 * time.Minute), WorkloadSeconds: 300, ActualSeconds: 300},
+	}
+
+	snapshot := services.NewKitchenSnapshot(nil, samples)
+	if snapshot.LastCompletedAt == nil || !snapshot.LastCompletedAt.Equal(latest) {
+		t.Errorf("LastCompletedAt = %v, want %v", snapshot.LastCompletedAt, latest)
+	}
+	if snapshot.SpeedFactor != 1 {
+		t.Errorf("SpeedFactor = %v, want 1", snapshot.SpeedFactor)
+	}
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/A4-dev-team/mobileorder.git/models"
+)
+
+// PDFの寸法はポイント（1/72インチ）。A4縦に出力する
+const (
+	receiptPDFPageWidth  = 595.0
+	receiptPDFPageHeight = 842.0
+	receiptPDFMargin     = 56.0
+	receiptPDFTitleSize  = 20.0
+	receiptPDFFontSize   = 11.0
+	receiptPDFLineHeight = 18.0
+)
+
+// 日本語は閲覧ソフトが持つ標準の和文フォント（平成角ゴシック）で表示し、フォントは埋め込まない
+const receiptPDFFonts = `<< /Type /Font /Subtype /Type0 /BaseFont /HeiseiKakuGo-W5 /Encoding /UniJIS-UCS2-H /DescendantFonts [4 0 R] >>`
+
+const receiptPDFCIDFont = `<< /Type /Font /Subtype /CIDFontType0 /BaseFont /HeiseiKakuGo-W5` +
+	` /CIDSystemInfo << /Registry (Adobe) /Ordering (Japan1) /Supplement 2 >>` +
+	` /FontDescriptor 5 0 R /DW 1000 /W [1 95 500] >>`
+
+const receiptPDFFontDescriptor = `<< /Type /FontDescriptor /FontName /HeiseiKakuGo-W5 /Flags 4` +
+	` /FontBBox [-92 -250 1010 922] /ItalicAngle 0 /Ascent 880 /Descent -120 /CapHeight 700 /StemV 93 >>`
+
+// RenderReceiptPDF は領収書をPDFにします。明細が1ページに収まらない場合は改ページします
+func RenderReceiptPDF(r *models.ReceiptResponse) []byte {
+	var pages []*bytes.Buffer
+	var content *bytes.Buffer
+	var y float64
+	newPage := func() {
+		content = &bytes.Buffer{}
+		pages = append(pages, content)
+		y = receiptPDFPageHeight - receiptPDFMargin
+	}
+
+	newPage()
+	y -= receiptPDFTitleSize
+	titleX := (receiptPDFPageWidth - pdfTextWidth(receiptTitle, receiptPDFTitleSize)) / 2
+	writePDFText(content, titleX, y, receiptPDFTitleSize, receiptTitle)
+	y -= receiptPDFLineHeight
+
+	right := receiptPDFPageWidth - receiptPDFMargin
+	for _, row := range buildReceiptRows(r) {
+		y -= receiptPDFLineHeight
+		if y < receiptPDFMargin {
+			newPage()
+			y -= receiptPDFLineHeight
+		}
+		if row.Rule {
+			lineY := y + receiptPDFFontSize/2
+			fmt.Fprintf(content, "%.2f w %.2f %.2f m %.2f %.2f l S\n", 0.5, receiptPDFMargin, lineY, right, lineY)
+			continue
+		}
+		if row.Left != "" {
+			writePDFText(content, receiptPDFMargin, y, receiptPDFFontSize, row.Left)
+		}
+		if row.Right != "" {
+			writePDFText(content, right-pdfTextWidth(row.Right, receiptPDFFontSize), y, receiptPDFFontSize, row.Right)
+		}
+	}
+
+	// 1: カタログ、2: ページツリー、3〜5: フォント、6以降: ページと内容を交互に置く
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"",
+		receiptPDFFonts,
+		receiptPDFCIDFont,
+		receiptPDFFontDescriptor,
+	}
+	kids := &bytes.Buffer{}
+	for i, page := range pages {
+		pageID := len(objects) + 1
+		if i > 0 {
+			kids.WriteByte(' ')
+		}
+		fmt.Fprintf(kids, "%d 0 R", pageID)
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+				receiptPDFPageWidth, receiptPDFPageHeight, pageID+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", kids.String(), len(pages))
+
+	return writePDF(objects)
+}
+
+// writePDF はオブジェクトを番号順に並べ、相互参照表を付けてPDFファイルにします
+func writePDF(objects []string) []byte {
+	var b bytes.Buffer
+	// 2行目はバイナリを含むファイルであることを示すコメント
+	b.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xrefOffset := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xrefOffset)
+	return b.Bytes()
+}
+
+// writePDFText は左下を(x, y)として1行の文字列を描画します
+func writePDFText(content *bytes.Buffer, x float64, y float64, size float64, text string) {
+	fmt.Fprintf(content, "BT /F1 %.1f Tf %.2f %.2f Td <%s> Tj ET\n", size, x, y, encodePDFText(text))
+}
+
+// encodePDFText は文字列をUniJIS-UCS2-H向けのUTF-16BEの16進数にします。
+// UCS-2の範囲外の文字は表示できないため「?」に置き換えます。
+func encodePDFText(text string) string {
+	var b bytes.Buffer
+	for _, r := range text {
+		if r > 0xFFFF {
+			r = '?'
+		}
+		fmt.Fprintf(&b, "%04X", r)
+	}
+	return b.String()
+}
+
+// pdfTextWidth は半角を0.5文字、全角を1文字として描画幅を見積もります
+func pdfTextWidth(text string, size float64) float64 {
+	return float64(displayWidth(text)) * size / 2
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/models"
+)
+
+const (
+	// テキストの領収書の1行の幅（半角文字数）
+	receiptTextWidth = 40
+	receiptTitle     = "領収書"
+)
+
+// 領収書の日時は日本時間で表示する
+var receiptLocation = time.FixedZone("JST", 9*60*60)
+
+// 領収書の1行。Leftを左寄せ、Rightを右寄せで表示する
+type receiptRow struct {
+	Left  string
+	Right string
+	Rule  bool // 区切り線
+}
+
+// buildReceiptRows はテキストとPDFで共通の領収書のレイアウトを組み立てます
+func buildReceiptRows(r *models.ReceiptResponse) []receiptRow {
+	var rows []receiptRow
+	if r.Recipient != "" {
+		rows = append(rows, receiptRow{Left: r.Recipient + " 様"}, receiptRow{})
+	}
+
+	rows = append(rows, receiptRow{Left: r.ShopName})
+	if r.ShopLocation != "" {
+		rows = append(rows, receiptRow{Left: r.ShopLocation})
+	}
+	if r.InvoiceRegistrationNumber != nil {
+		rows = append(rows, receiptRow{Left: "登録番号: " + *r.InvoiceRegistrationNumber})
+	}
+	rows = append(rows,
+		receiptRow{},
+		receiptRow{Left: "注文番号", Right: strconv.Itoa(r.OrderID)},
+		receiptRow{Left: "取引日時", Right: r.TransactionDate.In(receiptLocation).Format("2006年01月02日 15:04")},
+		receiptRow{Left: "発行日", Right: r.IssuedAt.In(receiptLocation).Format("2006年01月02日")},
+		receiptRow{Left: "区分", Right: diningOptionLabel(r.DiningOption)},
+		receiptRow{Rule: true},
+	)
+
+	hasReducedRate := false
+	for _, line := range r.Lines {
+		name := line.ItemName
+		if line.IsReducedRate {
+			name += " ※"
+			hasReducedRate = true
+		}
+		rows = append(rows,
+			receiptRow{Left: name},
+			receiptRow{Left: fmt.Sprintf("  %s × %d", formatYen(line.UnitPrice), line.Quantity), Right: formatYen(line.Amount)},
+		)
+	}
+
+	rows = append(rows,
+		receiptRow{Rule: true},
+		receiptRow{Left: "小計（税込）", Right: formatYen(r.SubtotalAmount)},
+	)
+	for _, discount := range r.Discounts {
+		label := fmt.Sprintf("  値引き %s（%d%%対象）", discount.Code, discount.TaxRate)
+		if discount.Source == models.PointsSource {
+			label = fmt.Sprintf("  ポイント利用（%d%%対象）", discount.TaxRate)
+		}
+		rows = append(rows, receiptRow{Left: label, Right: formatYen(-discount.Amount)})
+	}
+	for _, taxLine := range r.TaxBreakdown {
+		rows = append(rows,
+			receiptRow{Left: fmt.Sprintf("  %d%%対象（税込）", taxLine.TaxRate), Right: formatYen(taxLine.TaxableAmount)},
+			receiptRow{Left: fmt.Sprintf("  内消費税（%d%%）", taxLine.TaxRate), Right: formatYen(taxLine.TaxAmount)},
+		)
+	}
+	rows = append(rows,
+		receiptRow{Left: "内消費税合計", Right: formatYen(r.TaxAmount)},
+		receiptRow{Left: "合計（税込）", Right: formatYen(r.TotalAmount)},
+		receiptRow{Rule: true},
+	)
+	if hasReducedRate {
+		rows = append(rows, receiptRow{Left: fmt.Sprintf("※は軽減税率（%d%%）対象商品です", ReducedTaxRate)})
+	}
+	rows = append(rows, receiptRow{Left: "上記正に領収いたしました"})
+	return rows
+}
+
+// RenderReceiptText は領収書を等幅フォント向けのテキストにします
+func RenderReceiptText(r *models.ReceiptResponse) string {
+	var b strings.Builder
+	titlePadding := (receiptTextWidth - displayWidth(receiptTitle)) / 2
+	b.WriteString(strings.Repeat(" ", titlePadding) + receiptTitle + "\n\n")
+
+	for _, row := range buildReceiptRows(r) {
+		if row.Rule {
+			b.WriteString(strings.Repeat("-", receiptTextWidth) + "\n")
+			continue
+		}
+		line := row.Left
+		if row.Right != "" {
+			// 幅に収まらない場合も左右の間に1文字は空ける
+			padding := max(receiptTextWidth-displayWidth(row.Left)-displayWidth(row.Right), 1)
+			line += strings.Repeat(" ", padding) + row.Right
+		}
+		b.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+	return b.String()
+}
+
+// displayWidth は文字列の表示幅を半角1、全角2として数えます
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		if isHalfWidth(r) {
+			width++
+		} else {
+			width += 2
+		}
+	}
+	return width
+}
+
+// isHalfWidth はASCIIと半角カナを半角として扱います
+func isHalfWidth(r rune) bool {
+	return r < 0x80 || (r >= 0xFF61 && r <= 0xFF9F)
+}
+
+// formatYen は金額を3桁区切りにして「円」を付けます
+func formatYen(amount int) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	digits := strconv.Itoa(amount)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return sign + b.String() + "円"
+}
+
+func diningOptionLabel(diningOption string) string {
+	switch diningOption {
+	case models.DineIn.String():
+		return "店内飲食"
+	case models.Takeout.String():
+		return "持ち帰り"
+	default:
+		return diningOption
+	}
+}
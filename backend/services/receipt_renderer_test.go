@@ -0,0 +1,147 @@
+package services_test
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/google/go-cmp/cmp"
+)
+
+func newTestReceipt() *models.ReceiptResponse {
+	registrationNumber := "T1234567890123"
+	return &models.ReceiptResponse{
+		OrderID:                   10,
+		Recipient:                 "株式会社サンプル",
+		ShopName:                  "A4食堂",
+		ShopLocation:              "神戸市灘区六甲台町1-1",
+		InvoiceRegistrationNumber: &registrationNumber,
+		IsQualifiedInvoice:        true,
+		TransactionDate:           time.Date(2025, 8, 14, 10, 30, 0, 0, time.UTC),
+		IssuedAt:                  time.Date(2025, 8, 14, 15, 0, 0, 0, time.UTC),
+		DiningOption:              "takeout",
+		Lines: []models.ReceiptLineResponse{
+			{ItemName: "唐揚げ定食", Quantity: 2, UnitPrice: 850, Amount: 1700, TaxRate: 8, IsReducedRate: true},
+			{ItemName: "瓶ビール（中瓶）", Quantity: 1, UnitPrice: 550, Amount: 550, TaxRate: 10},
+		},
+		SubtotalAmount: 2250,
+		TaxAmount:      175,
+		TotalAmount:    2250,
+		TaxBreakdown: []models.OrderTaxLine{
+			{TaxRate: 8, TaxableAmount: 1700, TaxAmount: 125},
+			{TaxRate: 10, TaxableAmount: 550, TaxAmount: 50},
+		},
+	}
+}
+
+func TestRenderReceiptText(t *testing.T) {
+	want := `                 領収書
+
+株式会社サンプル 様
+
+A4食堂
+神戸市灘区六甲台町1-1
+登録番号: T1234567890123
+
+注文番号                              10
+取引日時            2025年08月14日 19:30
+発行日                    2025年08月15日
+区分                            持ち帰り
+----------------------------------------
+唐揚げ定食 ※
+  850円 × 2                     1,700円
+瓶ビール（中瓶）
+  550円 × 1                       550円
+----------------------------------------
+小計（税込）                     2,250円
+  8%対象（税込）                 1,700円
+  内消費税（8%）                   125円
+  10%対象（税込）                  550円
+  内消費税（10%）                   50円
+内消費税合計                       175円
+合計（税込）                     2,250円
+----------------------------------------
+※は軽減税率（8%）対象商品です
+上記正に領収いたしました
+`
+
+	got := services.RenderReceiptText(newTestReceipt())
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("テキストの領収書が一致しません (-want +got):\n%s", diff)
+	}
+}
+
+func TestRenderReceiptText_WithoutRegistrationNumber(t *testing.T) {
+	receipt := newTestReceipt()
+	receipt.Recipient = ""
+	receipt.InvoiceRegistrationNumber = nil
+	receipt.IsQualifiedInvoice = false
+
+	got := services.RenderReceiptText(receipt)
+	if strings.Contains(got, "登録番号") || strings.Contains(got, " 様") {
+		t.Errorf("登録番号と宛名のない領収書に表示されています:\n%s", got)
+	}
+}
+
+func TestRenderReceiptPDF(t *testing.T) {
+	tests := []struct {
+		name      string
+		lineCount int
+		wantPages int
+	}{
+		{name: "正常系: 1ページに収まる", lineCount: 2, wantPages: 1},
+		{name: "正常系: 明細が多い場合は改ページする", lineCount: 20, wantPages: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt := newTestReceipt()
+			receipt.Lines = nil
+			for i := 0; i < tt.lineCount; i++ {
+				receipt.Lines = append(receipt.Lines, models.ReceiptLineResponse{ItemName: "唐揚げ定食", Quantity: 1, UnitPrice: 850, Amount: 850, TaxRate: 8, IsReducedRate: true})
+			}
+
+			pdf := services.RenderReceiptPDF(receipt)
+
+			if !bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+				t.Fatal("PDFのヘッダーまたは終端が不正です")
+			}
+			if got := bytes.Count(pdf, []byte("/Type /Page ")); got != tt.wantPages {
+				t.Errorf("ページ数 = %d, want %d", got, tt.wantPages)
+			}
+			if !bytes.Contains(pdf, []byte("/HeiseiKakuGo-W5 /Encoding /UniJIS-UCS2-H")) {
+				t.Error("和文フォントが指定されていません")
+			}
+			// 店舗名「A4食堂」がUTF-16BEで書き込まれている
+			if !bytes.Contains(pdf, []byte("<0041003498DF5802>")) {
+				t.Error("店舗名が描画されていません")
+			}
+
+			// 相互参照表の位置と各オブジェクトの位置が正しいこと
+			m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+			if m == nil {
+				t.Fatal("startxrefが見つかりません")
+			}
+			xrefOffset, _ := strconv.Atoi(string(m[1]))
+			if !bytes.HasPrefix(pdf[xrefOffset:], []byte("xref\n")) {
+				t.Fatalf("startxref(%d)が相互参照表を指していません", xrefOffset)
+			}
+			entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[xrefOffset:], -1)
+			for i, entry := range entries {
+				offset, _ := strconv.Atoi(string(entry[1]))
+				if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(pdf[offset:], []byte(want)) {
+					t.Errorf("オブジェクト%dの位置(%d)が不正です", i+1, offset)
+				}
+			}
+			if want := 5 + tt.wantPages*2; len(entries) != want {
+				t.Errorf("オブジェクト数 = %d, want %d", len(entries), want)
+			}
+		})
+	}
+}
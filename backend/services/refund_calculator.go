@@ -0,0 +1,90 @@
+package services
+
+import (
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+)
+
+// CalculateRefundItems は返金する注文商品と数量から、注文時の単価（税込）をもとに返金額を計算します。
+// クーポンやポイントの値引き（discounts）は税率ごとに記録しているため、同じ税率の注文商品に金額の割合で按分し、
+// 値引き後の金額を返金します。
+// 値引き後の金額を数量で割った1円未満は切り捨てるため、支払額とのずれは全額返金で調整されます。
+// reqItemsが空の場合は、まだ返金していない数量をすべて返金します。数量がすべて返金済みでも、
+// 支払額の残り（切り捨てた端数）を返金できるよう、空の内訳を返します。
+// 同じ注文商品が複数回指定された場合は数量を合算し、返金できる数量を超える場合はConflictを返します。
+func CalculateRefundItems(lines []repositories.RefundableOrderItemDB, discounts []models.OrderDiscount, reqItems []models.RefundItemRequest) ([]models.RefundItem, int, error) {
+	lineDiscounts := allocateDiscounts(lines, discounts)
+
+	remaining := make(map[int]int, len(lines))
+	for _, line := range lines {
+		remaining[line.OrderItemID] = line.Quantity - line.RefundedQuantity
+	}
+
+	requested := make(map[int]int, len(lines))
+	if len(reqItems) == 0 {
+		for _, line := range lines {
+			if q := remaining[line.OrderItemID]; q > 0 {
+				requested[line.OrderItemID] = q
+			}
+		}
+	}
+	for _, req := range reqItems {
+		if _, ok := remaining[req.OrderItemID]; !ok {
+			return nil, 0, apperrors.BadParam.WrapMessage(nil, apperrors.MsgOrderItemNotInOrder, apperrors.Params{"order_item_id": req.OrderItemID})
+		}
+		requested[req.OrderItemID] += req.Quantity
+	}
+
+	var items []models.RefundItem
+	total := 0
+	// 注文商品の順に並べ、記録と返金額の内訳を安定させる
+	for _, line := range lines {
+		quantity, ok := requested[line.OrderItemID]
+		if !ok {
+			continue
+		}
+		if quantity > remaining[line.OrderItemID] {
+			return nil, 0, apperrors.Conflict.WrapMessage(nil, apperrors.MsgRefundQuantityExceeded,
+				apperrors.Params{"order_item_id": line.OrderItemID, "remaining": remaining[line.OrderItemID]})
+		}
+		paid := line.UnitPrice*line.Quantity - lineDiscounts[line.OrderItemID]
+		amount := paid * quantity / line.Quantity
+		items = append(items, models.RefundItem{
+			OrderItemID: line.OrderItemID,
+			Quantity:    quantity,
+			Amount:      amount,
+		})
+		total += amount
+	}
+	if len(items) == 0 && len(reqItems) > 0 {
+		return nil, 0, apperrors.Conflict.WrapMessage(nil, apperrors.MsgNothingToRefund, nil)
+	}
+	return items, total, nil
+}
+
+// allocateDiscounts は税率ごとの値引き額を、同じ税率の注文商品に金額の割合で按分します。
+// 累計の割合から按分額を決めるため、1円未満の端数を含めても按分額の合計は値引き額と一致します。
+func allocateDiscounts(lines []repositories.RefundableOrderItemDB, discounts []models.OrderDiscount) map[int]int {
+	discountByRate := make(map[int]int)
+	for _, discount := range discounts {
+		discountByRate[discount.TaxRate] += discount.Amount
+	}
+	baseByRate := make(map[int]int)
+	for _, line := range lines {
+		baseByRate[line.TaxRate] += line.UnitPrice * line.Quantity
+	}
+
+	allocated := make(map[int]int, len(lines))
+	cumulative := make(map[int]int)
+	for _, line := range lines {
+		discount, base := discountByRate[line.TaxRate], baseByRate[line.TaxRate]
+		if discount == 0 || base == 0 {
+			continue
+		}
+		before := discount * cumulative[line.TaxRate] / base
+		cumulative[line.TaxRate] += line.UnitPrice * line.Quantity
+		allocated[line.OrderItemID] = discount*cumulative[line.TaxRate]/base - before
+	}
+	return allocated
+}
@@ -0,0 +1,145 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/google/go-cmp/cmp"
+)
+
+func newTestRefundableLines() []repositories.RefundableOrderItemDB {
+	return []repositories.RefundableOrderItemDB{
+		{OrderItemID: 1, ItemName: "唐揚げ定食", Quantity: 2, UnitPrice: 900},
+		{OrderItemID: 2, ItemName: "味噌汁", Quantity: 3, UnitPrice: 150, TaxRate: 8, RefundedQuantity: 1},
+		{OrderItemID: 3, ItemName: "コーラ", Quantity: 1, UnitPrice: 200, RefundedQuantity: 1},
+	}
+}
+
+func TestCalculateRefundItems(t *testing.T) {
+	tests := []struct {
+		name            string
+		lines           []repositories.RefundableOrderItemDB
+		discounts       []models.OrderDiscount
+		reqItems        []models.RefundItemRequest
+		wantItems       []models.RefundItem
+		wantTotal       int
+		expectedErrCode apperrors.ErrCode
+	}{
+		{
+			name:  "正常系: 商品を指定しない場合は未返金の数量をすべて返金する",
+			lines: newTestRefundableLines(),
+			wantItems: []models.RefundItem{
+				{OrderItemID: 1, Quantity: 2, Amount: 1800},
+				{OrderItemID: 2, Quantity: 2, Amount: 300},
+			},
+			wantTotal: 2100,
+		},
+		{
+			name:     "正常系: 一部の商品だけ返金する",
+			lines:    newTestRefundableLines(),
+			reqItems: []models.RefundItemRequest{{OrderItemID: 1, Quantity: 1}},
+			wantItems: []models.RefundItem{
+				{OrderItemID: 1, Quantity: 1, Amount: 900},
+			},
+			wantTotal: 900,
+		},
+		{
+			name:     "正常系: 同じ注文商品の指定は数量を合算し、注文商品の順に並べる",
+			lines:    newTestRefundableLines(),
+			reqItems: []models.RefundItemRequest{{OrderItemID: 2, Quantity: 1}, {OrderItemID: 1, Quantity: 1}, {OrderItemID: 2, Quantity: 1}},
+			wantItems: []models.RefundItem{
+				{OrderItemID: 1, Quantity: 1, Amount: 900},
+				{OrderItemID: 2, Quantity: 2, Amount: 300},
+			},
+			wantTotal: 1200,
+		},
+		{
+			name:      "正常系: 値引き後の金額を数量で割った1円未満は切り捨てる",
+			lines:     []repositories.RefundableOrderItemDB{{OrderItemID: 4, ItemName: "おにぎり", Quantity: 3, UnitPrice: 135, TaxRate: 8}},
+			discounts: []models.OrderDiscount{{Source: models.PromotionSource, TaxRate: 8, Amount: 10}},
+			reqItems:  []models.RefundItemRequest{{OrderItemID: 4, Quantity: 1}},
+			wantItems: []models.RefundItem{
+				// (405 - 10) / 3 = 131.6 → 131円
+				{OrderItemID: 4, Quantity: 1, Amount: 131},
+			},
+			wantTotal: 131,
+		},
+		{
+			name: "正常系: 値引きは同じ税率の注文商品に金額の割合で按分して差し引く",
+			lines: []repositories.RefundableOrderItemDB{
+				{OrderItemID: 1, ItemName: "唐揚げ定食", Quantity: 2, UnitPrice: 900, TaxRate: 10},
+				{OrderItemID: 2, ItemName: "味噌汁", Quantity: 3, UnitPrice: 150, TaxRate: 8, RefundedQuantity: 1},
+				{OrderItemID: 3, ItemName: "コーラ", Quantity: 1, UnitPrice: 200, TaxRate: 10},
+			},
+			discounts: []models.OrderDiscount{
+				{Source: models.PromotionSource, TaxRate: 10, Amount: 110},
+				{Source: models.PointsSource, TaxRate: 8, Amount: 45},
+			},
+			reqItems: []models.RefundItemRequest{{OrderItemID: 1, Quantity: 1}, {OrderItemID: 2, Quantity: 2}},
+			wantItems: []models.RefundItem{
+				// 税率10%の値引き110円のうち1800/2000の99円を按分し、(1800-99)/2 = 850.5 → 850円
+				{OrderItemID: 1, Quantity: 1, Amount: 850},
+				// 税率8%の値引き45円を全額按分し、(450-45)*2/3 = 270円
+				{OrderItemID: 2, Quantity: 2, Amount: 270},
+			},
+			wantTotal: 1120,
+		},
+		{
+			name:            "異常系: 注文に含まれない商品",
+			lines:           newTestRefundableLines(),
+			reqItems:        []models.RefundItemRequest{{OrderItemID: 99, Quantity: 1}},
+			expectedErrCode: apperrors.BadParam,
+		},
+		{
+			name:            "異常系: 返金済みの数量を含めると注文数を超える",
+			lines:           newTestRefundableLines(),
+			reqItems:        []models.RefundItemRequest{{OrderItemID: 2, Quantity: 3}},
+			expectedErrCode: apperrors.Conflict,
+		},
+		{
+			name:            "異常系: 合算すると注文数を超える",
+			lines:           newTestRefundableLines(),
+			reqItems:        []models.RefundItemRequest{{OrderItemID: 1, Quantity: 2}, {OrderItemID: 1, Quantity: 1}},
+			expectedErrCode: apperrors.Conflict,
+		},
+		{
+			name: "正常系: 商品を指定せず、すべて返金済みの場合は空の内訳",
+			lines: []repositories.RefundableOrderItemDB{
+				{OrderItemID: 3, ItemName: "コーラ", Quantity: 1, UnitPrice: 200, RefundedQuantity: 1},
+			},
+			wantItems: nil,
+			wantTotal: 0,
+		},
+		{
+			name: "異常系: 返金済みの商品を指定",
+			lines: []repositories.RefundableOrderItemDB{
+				{OrderItemID: 3, ItemName: "コーラ", Quantity: 1, UnitPrice: 200, RefundedQuantity: 1},
+			},
+			reqItems:        []models.RefundItemRequest{{OrderItemID: 3, Quantity: 1}},
+			expectedErrCode: apperrors.Conflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, total, err := services.CalculateRefundItems(tt.lines, tt.discounts, tt.reqItems)
+
+			if tt.expectedErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+				return
+			}
+
+			testhelpers.AssertNoError(t, err)
+			if total != tt.wantTotal {
+				t.Errorf("total = %d, want %d", total, tt.wantTotal)
+			}
+			if diff := cmp.Diff(tt.wantItems, items); diff != "" {
+				t.Errorf("items mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,405 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// defaultReportDays は期間を省略したときに集計する日数（今日を含む）です
+	defaultReportDays = 30
+	// maxReportDays は一度に集計できる最大の日数です
+	maxReportDays    = 366
+	reportDateLayout = "2006-01-02"
+	// defaultUncollectedMinutes は調理完了から受け取られていない注文として知らせるまでの既定の時間（分）です
+	defaultUncollectedMinutes = 15
+)
+
+// 売上は日本時間の日付で集計する
+var reportLocation = time.FixedZone("JST", 9*60*60)
+
+type ReportServicer interface {
+	GetSalesReport(ctx context.Context, shopID int, query models.ReportQuery) (*models.SalesReportResponse, error)
+	GetItemSalesReport(ctx context.Context, shopID int, query models.ReportQuery) (*models.ItemSalesReportResponse, error)
+	GetKitchenMetrics(ctx context.Context, shopID int, query models.KitchenMetricsQuery) (*models.KitchenMetricsResponse, error)
+	ExportOrders(ctx context.Context, shopID int, query models.OrderExportQuery) (*OrderExport, error)
+}
+
+// OrderExport は書き出す注文のファイルです。WriteToを呼ぶまでデータベースから読み出しません
+type OrderExport struct {
+	Filename    string
+	ContentType string
+	WriteTo     func(w io.Writer) error
+}
+
+type reportService struct {
+	rpr repositories.ReportRepository
+	db  *sqlx.DB
+	now func() time.Time
+}
+
+func NewReportService(rpr repositories.ReportRepository, db *sqlx.DB) ReportServicer {
+	return &reportService{
+		rpr: rpr,
+		db:  db,
+		now: time.Now,
+	}
+}
+
+// NewReportServiceForTest は「今日」を固定してReportServiceを作ります（テスト用）
+func NewReportServiceForTest(rpr repositories.ReportRepository, db *sqlx.DB, now func() time.Time) ReportServicer {
+	return &reportService{
+		rpr: rpr,
+		db:  db,
+		now: now,
+	}
+}
+
+// GetSalesReport は店舗の売上を日・週・月ごとに集計します。注文のない期間も0として返します。
+func (s *reportService) GetSalesReport(ctx context.Context, shopID int, query models.ReportQuery) (*models.SalesReportResponse, error) {
+	from, to, err := s.reportRange(query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+	groupBy := query.GroupBy
+	if groupBy == "" {
+		groupBy = "day"
+	}
+
+	periods, err := s.rpr.FindSalesByPeriod(ctx, s.db, shopID, from, to.AddDate(0, 0, 1), groupBy)
+	if err != nil {
+		return nil, err
+	}
+	return buildSalesReport(shopID, from, to, groupBy, periods), nil
+}
+
+// GetItemSalesReport は店舗の売上を商品ごとに集計します
+func (s *reportService) GetItemSalesReport(ctx context.Context, shopID int, query models.ReportQuery) (*models.ItemSalesReportResponse, error) {
+	from, to, err := s.reportRange(query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.rpr.FindItemSales(ctx, s.db, shopID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.ItemSalesReportResponse{
+		ShopID: shopID,
+		From:   from.Format(reportDateLayout),
+		To:     to.Format(reportDateLayout),
+		Items:  make([]models.ItemSalesReportEntry, len(items)),
+	}
+	for i, item := range items {
+		res.Items[i] = models.ItemSalesReportEntry{
+			ItemID:      item.ItemID,
+			ItemName:    item.ItemName,
+			Quantity:    item.Quantity,
+			OrderCount:  item.OrderCount,
+			SalesAmount: item.SalesAmount,
+		}
+	}
+	return res, nil
+}
+
+// ExportOrders は店舗の注文を商品ごとに1行ずつ、CSVまたはXLSXで書き出す準備をします。
+// 書き出しは1行ずつ行い、注文をまとめてメモリに載せません。期間の指定は売上レポートと同じです。
+func (s *reportService) ExportOrders(ctx context.Context, shopID int, query models.OrderExportQuery) (*OrderExport, error) {
+	from, to, err := s.reportRange(query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+	format := query.Format
+	if format == "" {
+		format = "csv"
+	}
+	contentType := "text/csv; charset=utf-8"
+	if format == "xlsx" {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	return &OrderExport{
+		Filename:    fmt.Sprintf("orders_shop%d_%s_%s.%s", shopID, from.Format("20060102"), to.Format("20060102"), format),
+		ContentType: contentType,
+		WriteTo: func(w io.Writer) error {
+			// 最初の行を読み出せてから書き始め、取得に失敗したときは何も書き込まずにエラーを返せるようにする
+			var ew orderExportWriter
+			start := func() error {
+				if ew != nil {
+					return nil
+				}
+				var err error
+				ew, err = newOrderExportWriter(w, format)
+				if err != nil {
+					return apperrors.Unknown.WrapMessage(err, apperrors.MsgOrderExportFailed, nil)
+				}
+				return nil
+			}
+
+			err := s.rpr.StreamOrderExportLines(ctx, s.db, shopID, from, to.AddDate(0, 0, 1), func(line models.OrderExportLine) error {
+				if err := start(); err != nil {
+					return err
+				}
+				if err := ew.WriteRow(orderExportRow(line)); err != nil {
+					return apperrors.Unknown.WrapMessage(err, apperrors.MsgOrderExportFailed, nil)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if err := start(); err != nil {
+				return err
+			}
+			if err := ew.Close(); err != nil {
+				return apperrors.Unknown.WrapMessage(err, apperrors.MsgOrderExportFailed, nil)
+			}
+			return nil
+		},
+	}, nil
+}
+
+// GetKitchenMetrics は店舗の注文から調理完了まで、調理完了からお渡しまでの時間を、全体・注文した時刻・商品ごとに集計します。
+// あわせて、調理完了から一定の時間が過ぎても受け取られていない注文を返します。
+func (s *reportService) GetKitchenMetrics(ctx context.Context, shopID int, query models.KitchenMetricsQuery) (*models.KitchenMetricsResponse, error) {
+	from, to, err := s.reportRange(query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+	uncollectedMinutes := query.UncollectedMinutes
+	if uncollectedMinutes == 0 {
+		uncollectedMinutes = defaultUncollectedMinutes
+	}
+
+	timings, err := s.rpr.FindKitchenTimings(ctx, s.db, shopID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	uncollected, err := s.rpr.FindUncollectedOrders(ctx, s.db, shopID, now.Add(-time.Duration(uncollectedMinutes)*time.Minute))
+	if err != nil {
+		return nil, err
+	}
+
+	res := buildKitchenMetrics(timings)
+	res.ShopID = shopID
+	res.From = from.Format(reportDateLayout)
+	res.To = to.Format(reportDateLayout)
+	res.UncollectedMinutes = uncollectedMinutes
+	res.UncollectedOrders = make([]models.UncollectedOrderResponse, len(uncollected))
+	for i, order := range uncollected {
+		res.UncollectedOrders[i] = models.UncollectedOrderResponse{
+			OrderID:        order.OrderID,
+			OrderDate:      order.OrderDate,
+			CompletedAt:    order.CompletedAt.Time,
+			WaitingSeconds: int(now.Sub(order.CompletedAt.Time).Seconds()),
+		}
+	}
+	return res, nil
+}
+
+// reportRange は集計する最初の日と最後の日（どちらも日本時間の0時）を返します。省略した場合は今日までの30日間です。
+func (s *reportService) reportRange(fromDate string, toDate string) (time.Time, time.Time, error) {
+	now := s.now().In(reportLocation)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, reportLocation)
+	if toDate != "" {
+		parsed, err := time.ParseInLocation(reportDateLayout, toDate, reportLocation)
+		if err != nil {
+			return time.Time{}, time.Time{}, apperrors.ValidationFailed.WrapMessage(err, apperrors.MsgInvalidReportTo, nil)
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -(defaultReportDays - 1))
+	if fromDate != "" {
+		parsed, err := time.ParseInLocation(reportDateLayout, fromDate, reportLocation)
+		if err != nil {
+			return time.Time{}, time.Time{}, apperrors.ValidationFailed.WrapMessage(err, apperrors.MsgInvalidReportFrom, nil)
+		}
+		from = parsed
+	}
+
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgReportRangeReversed, nil)
+	}
+	if to.Sub(from) >= maxReportDays*24*time.Hour {
+		return time.Time{}, time.Time{}, apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgReportRangeTooLong, apperrors.Params{"max_days": maxReportDays})
+	}
+	return from, to, nil
+}
+
+// buildSalesReport は集計結果に注文のない期間を0で補い、期間全体の合計とあわせて返します。
+// 週は月曜日、月は1日から始まるため、最初の期間はfromより前の日から始まることがあります。
+func buildSalesReport(shopID int, from time.Time, to time.Time, groupBy string, periods []models.SalesPeriod) *models.SalesReportResponse {
+	byStart := make(map[string]models.SalesPeriod, len(periods))
+	var total models.SalesPeriod
+	for _, p := range periods {
+		byStart[p.PeriodStart.Format(reportDateLayout)] = p
+		total.OrderCount += p.OrderCount
+		total.ItemsSold += p.ItemsSold
+		total.SubtotalAmount += p.SubtotalAmount
+		total.DiscountAmount += p.DiscountAmount
+		total.TaxAmount += p.TaxAmount
+		total.TotalAmount += p.TotalAmount
+		total.RefundedAmount += p.RefundedAmount
+	}
+
+	res := &models.SalesReportResponse{
+		ShopID:  shopID,
+		From:    from.Format(reportDateLayout),
+		To:      to.Format(reportDateLayout),
+		GroupBy: groupBy,
+		Summary: toSalesReportEntry(total),
+		Periods: []models.SalesReportEntry{},
+	}
+	for start := truncateReportPeriod(from, groupBy); !start.After(to); start = nextReportPeriod(start, groupBy) {
+		key := start.Format(reportDateLayout)
+		entry := toSalesReportEntry(byStart[key])
+		entry.PeriodStart = key
+		res.Periods = append(res.Periods, entry)
+	}
+	return res
+}
+
+func toSalesReportEntry(p models.SalesPeriod) models.SalesReportEntry {
+	entry := models.SalesReportEntry{
+		OrderCount:     p.OrderCount,
+		ItemsSold:      p.ItemsSold,
+		Revenue:        p.TotalAmount,
+		NetSales:       p.SubtotalAmount - p.DiscountAmount - p.TaxAmount,
+		DiscountAmount: p.DiscountAmount,
+		TaxAmount:      p.TaxAmount,
+		RefundedAmount: p.RefundedAmount,
+	}
+	if p.OrderCount > 0 {
+		entry.AverageTicket = p.TotalAmount / p.OrderCount
+	}
+	return entry
+}
+
+// truncateReportPeriod は日付を含む期間の初日を返します。週は月曜日から始まります
+func truncateReportPeriod(date time.Time, groupBy string) time.Time {
+	switch groupBy {
+	case "week":
+		// time.Weekdayは日曜日が0なので、月曜日からの日数に直す
+		return date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+	case "month":
+		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	default:
+		return date
+	}
+}
+
+func nextReportPeriod(start time.Time, groupBy string) time.Time {
+	switch groupBy {
+	case "week":
+		return start.AddDate(0, 0, 7)
+	case "month":
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// kitchenDurations は所要時間の集計対象の秒数です
+type kitchenDurations struct {
+	cook   []int
+	pickup []int
+}
+
+func (d *kitchenDurations) add(t models.KitchenTiming) {
+	d.cook = append(d.cook, int(t.CompletedAt.Sub(t.OrderDate).Seconds()))
+	if t.HandedAt.Valid {
+		d.pickup = append(d.pickup, int(t.HandedAt.Time.Sub(t.CompletedAt).Seconds()))
+	}
+}
+
+// buildKitchenMetrics は注文の商品ごとの実績を、全体・注文した時刻（日本時間）・商品ごとの統計にまとめます。
+// 全体と時刻ごとは注文を1件として数え、商品ごとはその商品を含む注文を1件として数えます。
+func buildKitchenMetrics(timings []models.KitchenTiming) *models.KitchenMetricsResponse {
+	var total kitchenDurations
+	byHour := make(map[int]*kitchenDurations)
+	byItem := make(map[int]*kitchenDurations)
+	itemNames := make(map[int]string)
+	seenOrders := make(map[int]bool)
+	seenItems := make(map[[2]int]bool)
+
+	for _, t := range timings {
+		if !seenOrders[t.OrderID] {
+			seenOrders[t.OrderID] = true
+			total.add(t)
+			hour := t.OrderDate.In(reportLocation).Hour()
+			if byHour[hour] == nil {
+				byHour[hour] = &kitchenDurations{}
+			}
+			byHour[hour].add(t)
+		}
+		// 同じ商品をオプション違いで複数行注文していても1件として数える
+		key := [2]int{t.OrderID, t.ItemID}
+		if !seenItems[key] {
+			seenItems[key] = true
+			if byItem[t.ItemID] == nil {
+				byItem[t.ItemID] = &kitchenDurations{}
+				itemNames[t.ItemID] = t.ItemName
+			}
+			byItem[t.ItemID].add(t)
+		}
+	}
+
+	res := &models.KitchenMetricsResponse{
+		CookTime:   durationStats(total.cook),
+		PickupWait: durationStats(total.pickup),
+		ByHour:     []models.KitchenHourMetrics{},
+		ByItem:     []models.KitchenItemMetrics{},
+	}
+	for hour := 0; hour < 24; hour++ {
+		if d, ok := byHour[hour]; ok {
+			res.ByHour = append(res.ByHour, models.KitchenHourMetrics{
+				Hour:       hour,
+				CookTime:   durationStats(d.cook),
+				PickupWait: durationStats(d.pickup),
+			})
+		}
+	}
+	itemIDs := make([]int, 0, len(byItem))
+	for itemID := range byItem {
+		itemIDs = append(itemIDs, itemID)
+	}
+	sort.Ints(itemIDs)
+	for _, itemID := range itemIDs {
+		res.ByItem = append(res.ByItem, models.KitchenItemMetrics{
+			ItemID:     itemID,
+			ItemName:   itemNames[itemID],
+			CookTime:   durationStats(byItem[itemID].cook),
+			PickupWait: durationStats(byItem[itemID].pickup),
+		})
+	}
+	return res
+}
+
+// durationStats は秒数の中央値・90パーセンタイル・最大値を最近傍順位法で求めます
+func durationStats(seconds []int) models.DurationStats {
+	if len(seconds) == 0 {
+		return models.DurationStats{}
+	}
+	sorted := append([]int(nil), seconds...)
+	sort.Ints(sorted)
+	percentile := func(p int) int {
+		rank := (p*len(sorted) + 99) / 100 // ceil(p/100 * n)
+		return sorted[rank-1]
+	}
+	return models.DurationStats{
+		Count:      len(sorted),
+		P50Seconds: percentile(50),
+		P90Seconds: percentile(90),
+		MaxSeconds: sorted[len(sorted)-1],
+	}
+}
@@ -0,0 +1,378 @@
+package services_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jmoiron/sqlx"
+)
+
+// ReportRepositoryMock - ReportRepositoryのモック実装
+type ReportRepositoryMock struct {
+	FindSalesByPeriodFunc      func(ctx context.Context, dbtx repositories.DBTX, shopID int, from time.Time, to time.Time, unit string) ([]models.SalesPeriod, error)
+	FindItemSalesFunc          func(ctx context.Context, dbtx repositories.DBTX, shopID int, from time.Time, to time.Time) ([]models.ItemSales, error)
+	FindKitchenTimingsFunc     func(ctx context.Context, dbtx repositories.DBTX, shopID int, from time.Time, to time.Time) ([]models.KitchenTiming, error)
+	FindUncollectedOrdersFunc  func(ctx context.Context, dbtx repositories.DBTX, shopID int, completedBefore time.Time) ([]models.Order, error)
+	StreamOrderExportLinesFunc func(ctx context.Context, dbtx repositories.DBTX, shopID int, from time.Time, to time.Time, fn func(models.OrderExportLine) error) error
+}
+
+func (m *ReportRepositoryMock) FindSalesByPeriod(ctx context.Context, dbtx repositories.DBTX, shopID int, from time.Time, to time.Time, unit string) ([]models.SalesPeriod, error) {
+	if m.FindSalesByPeriodFunc != nil {
+		return m.FindSalesByPeriodFunc(ctx, dbtx, shopID, from, to, unit)
+	}
+	panic("not implemented")
+}
+
+func (m *ReportRepositoryMock) FindItemSales(ctx context.Context, dbtx repositories.DBTX, shopID int, from time.Time, to time.Time) ([]models.ItemSales, error) {
+	if m.FindItemSalesFunc != nil {
+		return m.FindItemSalesFunc(ctx, dbtx, shopID, from, to)
+	}
+	panic("not implemented")
+}
+
+func (m *ReportRepositoryMock) FindKitchenTimings(ctx context.Context, dbtx repositories.DBTX, shopID int, from time.Time, to time.Time) ([]models.KitchenTiming, error) {
+	if m.FindKitchenTimingsFunc != nil {
+		return m.FindKitchenTimingsFunc(ctx, dbtx, shopID, from, to)
+	}
+	panic("not implemented")
+}
+
+func (m *ReportRepositoryMock) FindUncollectedOrders(ctx context.Context, dbtx repositories.DBTX, shopID int, completedBefore time.Time) ([]models.Order, error) {
+	if m.FindUncollectedOrdersFunc != nil {
+		return m.FindUncollectedOrdersFunc(ctx, dbtx, shopID, completedBefore)
+	}
+	panic("not implemented")
+}
+
+func (m *ReportRepositoryMock) StreamOrderExportLines(ctx context.Context, dbtx repositories.DBTX, shopID int, from time.Time, to time.Time, fn func(models.OrderExportLine) error) error {
+	if m.StreamOrderExportLinesFunc != nil {
+		return m.StreamOrderExportLinesFunc(ctx, dbtx, shopID, from, to, fn)
+	}
+	panic("not implemented")
+}
+
+var jst = time.FixedZone("JST", 9*60*60)
+
+func TestReportService_GetSalesReport(t *testing.T) {
+	// 2025-04-10 00:30（日本時間）。UTCではまだ4月9日
+	now := time.Date(2025, 4, 9, 15, 30, 0, 0, time.UTC)
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2025, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name            string
+		query           models.ReportQuery
+		periods         []models.SalesPeriod
+		wantFrom        time.Time
+		wantTo          time.Time
+		wantUnit        string
+		want            *models.SalesReportResponse
+		expectedErrCode apperrors.ErrCode
+	}{
+		{
+			name:  "正常系: 注文のない日を0で補う",
+			query: models.ReportQuery{From: "2025-04-01", To: "2025-04-03"},
+			periods: []models.SalesPeriod{
+				{PeriodStart: day(4, 1), OrderCount: 2, ItemsSold: 3, SubtotalAmount: 2152, DiscountAmount: 100, TaxAmount: 152, TotalAmount: 2052},
+				{PeriodStart: day(4, 3), OrderCount: 1, ItemsSold: 1, SubtotalAmount: 540, TaxAmount: 40, TotalAmount: 540, RefundedAmount: 540},
+			},
+			wantFrom: time.Date(2025, 4, 1, 0, 0, 0, 0, jst),
+			wantTo:   time.Date(2025, 4, 4, 0, 0, 0, 0, jst),
+			wantUnit: "day",
+			want: &models.SalesReportResponse{
+				ShopID: 1, From: "2025-04-01", To: "2025-04-03", GroupBy: "day",
+				Summary: models.SalesReportEntry{OrderCount: 3, ItemsSold: 4, Revenue: 2592, NetSales: 2400, DiscountAmount: 100, TaxAmount: 192, RefundedAmount: 540, AverageTicket: 864},
+				Periods: []models.SalesReportEntry{
+					{PeriodStart: "2025-04-01", OrderCount: 2, ItemsSold: 3, Revenue: 2052, NetSales: 1900, DiscountAmount: 100, TaxAmount: 152, AverageTicket: 1026},
+					{PeriodStart: "2025-04-02"},
+					{PeriodStart: "2025-04-03", OrderCount: 1, ItemsSold: 1, Revenue: 540, NetSales: 500, TaxAmount: 40, RefundedAmount: 540, AverageTicket: 540},
+				},
+			},
+		},
+		{
+			name:     "正常系: 週は月曜日から始まる",
+			query:    models.ReportQuery{From: "2025-04-02", To: "2025-04-14", GroupBy: "week"},
+			wantFrom: time.Date(2025, 4, 2, 0, 0, 0, 0, jst),
+			wantTo:   time.Date(2025, 4, 15, 0, 0, 0, 0, jst),
+			wantUnit: "week",
+			want: &models.SalesReportResponse{
+				ShopID: 1, From: "2025-04-02", To: "2025-04-14", GroupBy: "week",
+				Periods: []models.SalesReportEntry{{PeriodStart: "2025-03-31"}, {PeriodStart: "2025-04-07"}, {PeriodStart: "2025-04-14"}},
+			},
+		},
+		{
+			name:     "正常系: 月ごと",
+			query:    models.ReportQuery{From: "2025-01-15", To: "2025-03-01", GroupBy: "month"},
+			periods:  []models.SalesPeriod{{PeriodStart: day(2, 1), OrderCount: 1, TotalAmount: 1080, SubtotalAmount: 1080, TaxAmount: 80}},
+			wantFrom: time.Date(2025, 1, 15, 0, 0, 0, 0, jst),
+			wantTo:   time.Date(2025, 3, 2, 0, 0, 0, 0, jst),
+			wantUnit: "month",
+			want: &models.SalesReportResponse{
+				ShopID: 1, From: "2025-01-15", To: "2025-03-01", GroupBy: "month",
+				Summary: models.SalesReportEntry{OrderCount: 1, Revenue: 1080, NetSales: 1000, TaxAmount: 80, AverageTicket: 1080},
+				Periods: []models.SalesReportEntry{
+					{PeriodStart: "2025-01-01"},
+					{PeriodStart: "2025-02-01", OrderCount: 1, Revenue: 1080, NetSales: 1000, TaxAmount: 80, AverageTicket: 1080},
+					{PeriodStart: "2025-03-01"},
+				},
+			},
+		},
+		{
+			name:     "正常系: 省略すると日本時間の今日までの30日間",
+			wantFrom: time.Date(2025, 3, 12, 0, 0, 0, 0, jst),
+			wantTo:   time.Date(2025, 4, 11, 0, 0, 0, 0, jst),
+			wantUnit: "day",
+		},
+		{
+			name:            "異常系: 終了日が開始日より前",
+			query:           models.ReportQuery{From: "2025-04-02", To: "2025-04-01"},
+			expectedErrCode: apperrors.ValidationFailed,
+		},
+		{
+			name:            "異常系: 集計期間が366日を超える",
+			query:           models.ReportQuery{From: "2024-01-01", To: "2025-01-01"},
+			expectedErrCode: apperrors.ValidationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &ReportRepositoryMock{
+				FindSalesByPeriodFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int, from time.Time, to time.Time, unit string) ([]models.SalesPeriod, error) {
+					if !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+						t.Errorf("集計期間 = %v 〜 %v, want %v 〜 %v", from, to, tt.wantFrom, tt.wantTo)
+					}
+					if unit != tt.wantUnit {
+						t.Errorf("集計単位 = %q, want %q", unit, tt.wantUnit)
+					}
+					return tt.periods, nil
+				},
+			}
+
+			reportService := services.NewReportServiceForTest(repo, &sqlx.DB{}, func() time.Time { return now })
+			got, err := reportService.GetSalesReport(context.Background(), 1, tt.query)
+
+			if tt.expectedErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+				return
+			}
+			testhelpers.AssertNoError(t, err)
+			if tt.want == nil {
+				if len(got.Periods) != 30 {
+					t.Errorf("期間の数 = %d, want 30", len(got.Periods))
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("report mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReportService_GetKitchenMetrics(t *testing.T) {
+	now := time.Date(2025, 4, 10, 3, 0, 0, 0, time.UTC)
+	// 日本時間の4月9日12時台に注文
+	at := func(minutes int) time.Time {
+		return time.Date(2025, 4, 9, 3, 0, 0, 0, time.UTC).Add(time.Duration(minutes) * time.Minute)
+	}
+	handed := func(minutes int) sql.NullTime {
+		return sql.NullTime{Time: at(minutes), Valid: true}
+	}
+	timings := []models.KitchenTiming{
+		// 注文1: 唐揚げ定食2行（オプション違い）と瓶ビール。10分で完了、2分後にお渡し
+		{OrderID: 1, ItemID: 1, ItemName: "唐揚げ定食", OrderDate: at(0), CompletedAt: at(10), HandedAt: handed(12)},
+		{OrderID: 1, ItemID: 1, ItemName: "唐揚げ定食", OrderDate: at(0), CompletedAt: at(10), HandedAt: handed(12)},
+		{OrderID: 1, ItemID: 4, ItemName: "瓶ビール", OrderDate: at(0), CompletedAt: at(10), HandedAt: handed(12)},
+		// 注文2: 唐揚げ定食。5分で完了、まだお渡ししていない
+		{OrderID: 2, ItemID: 1, ItemName: "唐揚げ定食", OrderDate: at(20), CompletedAt: at(25)},
+		// 注文3: 日本時間の13時台に注文。20分で完了、30分後にお渡し
+		{OrderID: 3, ItemID: 4, ItemName: "瓶ビール", OrderDate: at(60), CompletedAt: at(80), HandedAt: handed(110)},
+	}
+
+	var gotCompletedBefore time.Time
+	repo := &ReportRepositoryMock{
+		FindKitchenTimingsFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int, from time.Time, to time.Time) ([]models.KitchenTiming, error) {
+			return timings, nil
+		},
+		FindUncollectedOrdersFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int, completedBefore time.Time) ([]models.Order, error) {
+			gotCompletedBefore = completedBefore
+			return []models.Order{{OrderID: 2, OrderDate: at(20), CompletedAt: sql.NullTime{Time: at(25), Valid: true}}}, nil
+		},
+	}
+
+	reportService := services.NewReportServiceForTest(repo, &sqlx.DB{}, func() time.Time { return now })
+	got, err := reportService.GetKitchenMetrics(context.Background(), 1, models.KitchenMetricsQuery{From: "2025-04-09", To: "2025-04-09"})
+	testhelpers.AssertNoError(t, err)
+
+	if want := now.Add(-15 * time.Minute); !gotCompletedBefore.Equal(want) {
+		t.Errorf("受け取られていない注文の基準 = %v, want %v", gotCompletedBefore, want)
+	}
+	want := &models.KitchenMetricsResponse{
+		ShopID:     1,
+		From:       "2025-04-09",
+		To:         "2025-04-09",
+		CookTime:   models.DurationStats{Count: 3, P50Seconds: 600, P90Seconds: 1200, MaxSeconds: 1200},
+		PickupWait: models.DurationStats{Count: 2, P50Seconds: 120, P90Seconds: 1800, MaxSeconds: 1800},
+		ByHour: []models.KitchenHourMetrics{
+			{
+				Hour:       12,
+				CookTime:   models.DurationStats{Count: 2, P50Seconds: 300, P90Seconds: 600, MaxSeconds: 600},
+				PickupWait: models.DurationStats{Count: 1, P50Seconds: 120, P90Seconds: 120, MaxSeconds: 120},
+			},
+			{
+				Hour:       13,
+				CookTime:   models.DurationStats{Count: 1, P50Seconds: 1200, P90Seconds: 1200, MaxSeconds: 1200},
+				PickupWait: models.DurationStats{Count: 1, P50Seconds: 1800, P90Seconds: 1800, MaxSeconds: 1800},
+			},
+		},
+		ByItem: []models.KitchenItemMetrics{
+			{
+				ItemID:     1,
+				ItemName:   "唐揚げ定食",
+				CookTime:   models.DurationStats{Count: 2, P50Seconds: 300, P90Seconds: 600, MaxSeconds: 600},
+				PickupWait: models.DurationStats{Count: 1, P50Seconds: 120, P90Seconds: 120, MaxSeconds: 120},
+			},
+			{
+				ItemID:     4,
+				ItemName:   "瓶ビール",
+				CookTime:   models.DurationStats{Count: 2, P50Seconds: 600, P90Seconds: 1200, MaxSeconds: 1200},
+				PickupWait: models.DurationStats{Count: 2, P50Seconds: 120, P90Seconds: 1800, MaxSeconds: 1800},
+			},
+		},
+		UncollectedMinutes: 15,
+		UncollectedOrders: []models.UncollectedOrderResponse{
+			{OrderID: 2, OrderDate: at(20), CompletedAt: at(25), WaitingSeconds: int(now.Sub(at(25)).Seconds())},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("metrics mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReportService_ExportOrders(t *testing.T) {
+	now := time.Date(2025, 4, 30, 3, 0, 0, 0, time.UTC)
+	lines := []models.OrderExportLine{
+		{
+			OrderID: 1, OrderDate: time.Date(2025, 4, 1, 3, 0, 0, 0, time.UTC), Status: models.Handed, DiningOption: models.Takeout,
+			CustomerEmail:  sql.NullString{String: "customer1@example.com", Valid: true},
+			SubtotalAmount: 1700, DiscountAmount: 100, TaxAmount: 118, TotalAmount: 1600,
+			ItemID: 1, ItemName: "唐揚げ定食", Quantity: 2, PriceAtOrder: 800, ModifierPriceDelta: 50, TaxRate: 8,
+		},
+		{
+			OrderID: 2, OrderDate: time.Date(2025, 4, 2, 15, 30, 0, 0, time.UTC), Status: models.Cooking, DiningOption: models.DineIn,
+			GuestOrderToken: sql.NullString{String: "0b8e5a4c-1f2d-4e3a-9c7b-5d6e7f8a1234", Valid: true},
+			SubtotalAmount:  500, TaxAmount: 45, TotalAmount: 500,
+			ItemID: 4, ItemName: "=瓶ビール & <おつまみ>", Quantity: 1, PriceAtOrder: 500, TaxRate: 10,
+		},
+	}
+
+	var gotFrom, gotTo time.Time
+	repo := &ReportRepositoryMock{
+		StreamOrderExportLinesFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int, from time.Time, to time.Time, fn func(models.OrderExportLine) error) error {
+			gotFrom, gotTo = from, to
+			for _, line := range lines {
+				if err := fn(line); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	reportService := services.NewReportServiceForTest(repo, &sqlx.DB{}, func() time.Time { return now })
+
+	t.Run("CSV", func(t *testing.T) {
+		export, err := reportService.ExportOrders(context.Background(), 1, models.OrderExportQuery{From: "2025-04-01", To: "2025-04-30"})
+		testhelpers.AssertNoError(t, err)
+		if export.Filename != "orders_shop1_20250401_20250430.csv" || export.ContentType != "text/csv; charset=utf-8" {
+			t.Errorf("ファイル名または形式が想定外です: %s, %s", export.Filename, export.ContentType)
+		}
+
+		var buf bytes.Buffer
+		testhelpers.AssertNoError(t, export.WriteTo(&buf))
+		if !gotFrom.Equal(time.Date(2025, 4, 1, 0, 0, 0, 0, jst)) || !gotTo.Equal(time.Date(2025, 5, 1, 0, 0, 0, 0, jst)) {
+			t.Errorf("期間 = %v〜%v", gotFrom, gotTo)
+		}
+
+		got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		want := []string{
+			"\uFEFF注文ID,注文日時,ステータス,飲食区分,お客様,商品ID,商品名,数量,単価（税込）,オプション加算額（税込）,金額（税込）,税率（%）,注文小計（税込）,注文値引き（税込）,注文消費税（内税）,注文合計（税込）",
+			"1,2025-04-01 12:00:00,お渡し済み,持ち帰り,customer1@example.com,1,唐揚げ定食,2,800,50,1700,8,1700,100,118,1600",
+			// ゲストはトークンを伏せ、数式とみなされる文字列は ' を付けて書き出す
+			"2,2025-04-03 00:30:00,調理中,店内飲食,ゲスト（****1234）,4,'=瓶ビール & <おつまみ>,1,500,0,500,10,500,0,45,500",
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("CSV mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("XLSX", func(t *testing.T) {
+		export, err := reportService.ExportOrders(context.Background(), 1, models.OrderExportQuery{Format: "xlsx"})
+		testhelpers.AssertNoError(t, err)
+		if export.Filename != "orders_shop1_20250401_20250430.xlsx" {
+			t.Errorf("ファイル名 = %s", export.Filename)
+		}
+
+		var buf bytes.Buffer
+		testhelpers.AssertNoError(t, export.WriteTo(&buf))
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		testhelpers.AssertNoError(t, err)
+		var sheet string
+		for _, f := range zr.File {
+			if f.Name != "xl/worksheets/sheet1.xml" {
+				continue
+			}
+			rc, err := f.Open()
+			testhelpers.AssertNoError(t, err)
+			b, err := io.ReadAll(rc)
+			rc.Close()
+			testhelpers.AssertNoError(t, err)
+			sheet = string(b)
+		}
+		for _, want := range []string{
+			`<row r="3">`,
+			`<c><v>1600</v></c>`,
+			`<t xml:space="preserve">=瓶ビール &amp; &lt;おつまみ&gt;</t>`,
+			`<t xml:space="preserve">ゲスト（****1234）</t>`,
+		} {
+			if !strings.Contains(sheet, want) {
+				t.Errorf("シートに %s が含まれていません: %s", want, sheet)
+			}
+		}
+	})
+
+	t.Run("取得に失敗したときは何も書き込まない", func(t *testing.T) {
+		failing := &ReportRepositoryMock{
+			StreamOrderExportLinesFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int, from time.Time, to time.Time, fn func(models.OrderExportLine) error) error {
+				return apperrors.GetDataFailed.Wrap(errors.New("db error"), "書き出す注文の取得に失敗しました。")
+			},
+		}
+		export, err := services.NewReportServiceForTest(failing, &sqlx.DB{}, func() time.Time { return now }).ExportOrders(context.Background(), 1, models.OrderExportQuery{})
+		testhelpers.AssertNoError(t, err)
+
+		var buf bytes.Buffer
+		testhelpers.AssertAppError(t, export.WriteTo(&buf), apperrors.GetDataFailed)
+		if buf.Len() != 0 {
+			t.Errorf("書き込まれた内容 = %q, want empty", buf.String())
+		}
+	})
+
+	t.Run("期間が長すぎる", func(t *testing.T) {
+		_, err := reportService.ExportOrders(context.Background(), 1, models.OrderExportQuery{From: "2024-01-01", To: "2025-04-30"})
+		testhelpers.AssertAppError(t, err, apperrors.ValidationFailed)
+	})
+}
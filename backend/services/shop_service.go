@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/i18n"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/jmoiron/sqlx"
+)
+
+// 適格請求書発行事業者の登録番号は「T」と13桁の数字
+var invoiceRegistrationNumberPattern = regexp.MustCompile(`^T[0-9]{13}$`)
+
+type ShopServicer interface {
+	GetNearbyShops(ctx context.Context, latitude float64, longitude float64, radiusMeters float64, lang string) ([]models.NearbyShopResponse, error)
+	UpdateShopCoordinates(ctx context.Context, shopID int, latitude float64, longitude float64) error
+	UpdateDefaultPrepTime(ctx context.Context, shopID int, defaultPrepSeconds int) error
+	UpdateInvoiceRegistrationNumber(ctx context.Context, shopID int, registrationNumber *string) error
+	UpdatePointRate(ctx context.Context, shopID int, pointRate int) error
+	UpdateTimeZone(ctx context.Context, shopID int, timeZone string) error
+}
+
+type shopService struct {
+	shr repositories.ShopRepository
+	trr repositories.TranslationRepository
+	db  *sqlx.DB
+}
+
+func NewShopService(shr repositories.ShopRepository, trr repositories.TranslationRepository, db *sqlx.DB) ShopServicer {
+	return &shopService{
+		shr: shr,
+		trr: trr,
+		db:  db,
+	}
+}
+
+// GetNearbyShops は指定地点から半径内の店舗を近い順に取得します。langが日本語以外なら、翻訳のある店舗名・説明を翻訳します
+func (s *shopService) GetNearbyShops(ctx context.Context, latitude float64, longitude float64, radiusMeters float64, lang string) ([]models.NearbyShopResponse, error) {
+	shops, err := s.shr.FindShopsWithinRadius(ctx, s.db, latitude, longitude, radiusMeters)
+	if err != nil {
+		return nil, err
+	}
+	translations := map[int]models.ShopTranslation{}
+	if i18n.IsTranslationLanguage(lang) && len(shops) > 0 {
+		shopIDs := make([]int, len(shops))
+		for i, shop := range shops {
+			shopIDs[i] = shop.ShopID
+		}
+		translations, err = s.trr.FindShopTranslations(ctx, s.db, lang, shopIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	responses := make([]models.NearbyShopResponse, len(shops))
+	for i, shop := range shops {
+		responses[i] = models.NearbyShopResponse{
+			ShopID:         shop.ShopID,
+			Name:           shop.Name,
+			Description:    shop.Description,
+			Location:       shop.Location,
+			IsOpen:         shop.IsOpen,
+			Latitude:       shop.Latitude,
+			Longitude:      shop.Longitude,
+			DistanceMeters: shop.DistanceMeters,
+		}
+		if t, ok := translations[shop.ShopID]; ok {
+			responses[i].Name = t.Name
+			if t.Description != nil {
+				responses[i].Description = *t.Description
+			}
+		}
+	}
+	return responses, nil
+}
+
+// UpdateShopCoordinates は店舗の緯度経度を更新します
+func (s *shopService) UpdateShopCoordinates(ctx context.Context, shopID int, latitude float64, longitude float64) error {
+	return s.shr.UpdateShopCoordinates(ctx, s.db, shopID, latitude, longitude)
+}
+
+// UpdateDefaultPrepTime は店舗の既定調理時間を更新します
+func (s *shopService) UpdateDefaultPrepTime(ctx context.Context, shopID int, defaultPrepSeconds int) error {
+	return s.shr.UpdateShopDefaultPrepTime(ctx, s.db, shopID, defaultPrepSeconds)
+}
+
+// UpdateInvoiceRegistrationNumber は店舗の適格請求書発行事業者の登録番号を更新します。nilで登録番号を削除します
+func (s *shopService) UpdateInvoiceRegistrationNumber(ctx context.Context, shopID int, registrationNumber *string) error {
+	if registrationNumber != nil && !invoiceRegistrationNumberPattern.MatchString(*registrationNumber) {
+		return apperrors.ValidationFailed.WrapMessage(nil, apperrors.MsgInvalidInvoiceNumber, nil)
+	}
+	return s.shr.UpdateShopInvoiceRegistrationNumber(ctx, s.db, shopID, registrationNumber)
+}
+
+// UpdatePointRate は店舗のポイント還元率（値引き後の小計100円につき付与するポイント）を更新します
+func (s *shopService) UpdatePointRate(ctx context.Context, shopID int, pointRate int) error {
+	return s.shr.UpdateShopPointRate(ctx, s.db, shopID, pointRate)
+}
+
+// UpdateTimeZone は店舗のタイムゾーン（"Asia/Tokyo"などのIANA名）を更新します
+func (s *shopService) UpdateTimeZone(ctx context.Context, shopID int, timeZone string) error {
+	// "Local"はサーバーの設定で変わるため、店舗のタイムゾーンには使えない
+	if _, err := time.LoadLocation(timeZone); err != nil || timeZone == "Local" {
+		return apperrors.ValidationFailed.WrapMessage(err, apperrors.MsgInvalidTimeZone, apperrors.Params{"time_zone": timeZone})
+	}
+	return s.shr.UpdateShopTimeZone(ctx, s.db, shopID, timeZone)
+}
@@ -0,0 +1,226 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jmoiron/sqlx"
+)
+
+// ShopRepositoryMockForShop - ShopService用のShopRepositoryモック
+type ShopRepositoryMockForShop struct {
+	FindShopsWithinRadiusFunc func(ctx context.Context, dbtx repositories.DBTX, latitude float64, longitude float64, radiusMeters float64) ([]repositories.NearbyShopDBResult, error)
+	UpdateShopCoordinatesFunc func(ctx context.Context, dbtx repositories.DBTX, shopID int, latitude float64, longitude float64) error
+
+	UpdateShopInvoiceRegistrationNumberFunc func(ctx context.Context, dbtx repositories.DBTX, shopID int, registrationNumber *string) error
+	UpdateShopTimeZoneFunc                  func(ctx context.Context, dbtx repositories.DBTX, shopID int, timeZone string) error
+}
+
+func (m *ShopRepositoryMockForShop) FindShopIDByAdminID(ctx context.Context, dbtx repositories.DBTX, userID int) (int, error) {
+	panic("not implemented")
+}
+
+func (m *ShopRepositoryMockForShop) FindShopsWithinRadius(ctx context.Context, dbtx repositories.DBTX, latitude float64, longitude float64, radiusMeters float64) ([]repositories.NearbyShopDBResult, error) {
+	if m.FindShopsWithinRadiusFunc != nil {
+		return m.FindShopsWithinRadiusFunc(ctx, dbtx, latitude, longitude, radiusMeters)
+	}
+	panic("not implemented")
+}
+
+func (m *ShopRepositoryMockForShop) UpdateShopCoordinates(ctx context.Context, dbtx repositories.DBTX, shopID int, latitude float64, longitude float64) error {
+	if m.UpdateShopCoordinatesFunc != nil {
+		return m.UpdateShopCoordinatesFunc(ctx, dbtx, shopID, latitude, longitude)
+	}
+	panic("not implemented")
+}
+
+func (m *ShopRepositoryMockForShop) UpdateShopDefaultPrepTime(ctx context.Context, dbtx repositories.DBTX, shopID int, defaultPrepSeconds int) error {
+	panic("not implemented")
+}
+
+func (m *ShopRepositoryMockForShop) UpdateShopInvoiceRegistrationNumber(ctx context.Context, dbtx repositories.DBTX, shopID int, registrationNumber *string) error {
+	if m.UpdateShopInvoiceRegistrationNumberFunc != nil {
+		return m.UpdateShopInvoiceRegistrationNumberFunc(ctx, dbtx, shopID, registrationNumber)
+	}
+	panic("not implemented")
+}
+
+func (m *ShopRepositoryMockForShop) UpdateShopPointRate(ctx context.Context, dbtx repositories.DBTX, shopID int, pointRate int) error {
+	panic("not implemented")
+}
+
+func (m *ShopRepositoryMockForShop) UpdateShopTimeZone(ctx context.Context, dbtx repositories.DBTX, shopID int, timeZone string) error {
+	if m.UpdateShopTimeZoneFunc != nil {
+		return m.UpdateShopTimeZoneFunc(ctx, dbtx, shopID, timeZone)
+	}
+	panic("not implemented")
+}
+
+func TestShopService_GetNearbyShops(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupRepo       func(*ShopRepositoryMockForShop)
+		want            []models.NearbyShopResponse
+		expectedErrCode apperrors.ErrCode
+	}{
+		{
+			name: "正常系: 距離付きの店舗一覧に変換される",
+			setupRepo: func(m *ShopRepositoryMockForShop) {
+				m.FindShopsWithinRadiusFunc = func(ctx context.Context, dbtx repositories.DBTX, latitude float64, longitude float64, radiusMeters float64) ([]repositories.NearbyShopDBResult, error) {
+					if radiusMeters != 1500 {
+						t.Errorf("期待される半径 = 1500, 実際 = %v", radiusMeters)
+					}
+					return []repositories.NearbyShopDBResult{
+						{ShopID: 3, Name: "三宮ベーカリーカフェ", Location: "神戸市中央区三宮町1-8-1", IsOpen: true, Latitude: 34.6916, Longitude: 135.1925, DistanceMeters: 120.5},
+					}, nil
+				}
+			},
+			want: []models.NearbyShopResponse{
+				{ShopID: 3, Name: "三宮ベーカリーカフェ", Location: "神戸市中央区三宮町1-8-1", IsOpen: true, Latitude: 34.6916, Longitude: 135.1925, DistanceMeters: 120.5},
+			},
+		},
+		{
+			name: "正常系: 該当店舗がない場合は空のスライス",
+			setupRepo: func(m *ShopRepositoryMockForShop) {
+				m.FindShopsWithinRadiusFunc = func(ctx context.Context, dbtx repositories.DBTX, latitude float64, longitude float64, radiusMeters float64) ([]repositories.NearbyShopDBResult, error) {
+					return nil, nil
+				}
+			},
+			want: []models.NearbyShopResponse{},
+		},
+		{
+			name: "異常系: リポジトリでエラー",
+			setupRepo: func(m *ShopRepositoryMockForShop) {
+				m.FindShopsWithinRadiusFunc = func(ctx context.Context, dbtx repositories.DBTX, latitude float64, longitude float64, radiusMeters float64) ([]repositories.NearbyShopDBResult, error) {
+					return nil, apperrors.GetDataFailed.Wrap(errors.New("db error"), "周辺店舗の取得に失敗しました。")
+				}
+			},
+			expectedErrCode: apperrors.GetDataFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &ShopRepositoryMockForShop{}
+			tt.setupRepo(repo)
+
+			shopService := services.NewShopService(repo, nil, &sqlx.DB{})
+			got, err := shopService.GetNearbyShops(context.Background(), 34.69, 135.19, 1500, "ja")
+
+			if tt.expectedErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+				return
+			}
+			testhelpers.AssertNoError(t, err)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("shops mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestShopService_UpdateShopCoordinates(t *testing.T) {
+	repo := &ShopRepositoryMockForShop{
+		UpdateShopCoordinatesFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int, latitude float64, longitude float64) error {
+			if shopID != 1 || latitude != 34.7256 || longitude != 135.2352 {
+				t.Errorf("想定外の引数: shopID=%d, latitude=%v, longitude=%v", shopID, latitude, longitude)
+			}
+			return nil
+		},
+	}
+
+	shopService := services.NewShopService(repo, nil, &sqlx.DB{})
+	err := shopService.UpdateShopCoordinates(context.Background(), 1, 34.7256, 135.2352)
+	testhelpers.AssertNoError(t, err)
+}
+
+func TestShopService_UpdateInvoiceRegistrationNumber(t *testing.T) {
+	validNumber := "T1234567890123"
+	tests := []struct {
+		name               string
+		registrationNumber *string
+		expectRepoCall     bool
+		expectedErrCode    apperrors.ErrCode
+	}{
+		{name: "正常系: 登録番号を設定できる", registrationNumber: &validNumber, expectRepoCall: true},
+		{name: "正常系: nilで登録番号を削除できる", registrationNumber: nil, expectRepoCall: true},
+		{name: "異常系: Tで始まらない", registrationNumber: stringPtr("11234567890123"), expectedErrCode: apperrors.ValidationFailed},
+		{name: "異常系: 数字が13桁ではない", registrationNumber: stringPtr("T123456789012"), expectedErrCode: apperrors.ValidationFailed},
+		{name: "異常系: 数字以外を含む", registrationNumber: stringPtr("T12345678901AB"), expectedErrCode: apperrors.ValidationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			repo := &ShopRepositoryMockForShop{
+				UpdateShopInvoiceRegistrationNumberFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int, registrationNumber *string) error {
+					called = true
+					if diff := cmp.Diff(tt.registrationNumber, registrationNumber); diff != "" {
+						t.Errorf("登録番号が一致しません (-want +got):\n%s", diff)
+					}
+					return nil
+				},
+			}
+
+			shopService := services.NewShopService(repo, nil, &sqlx.DB{})
+			err := shopService.UpdateInvoiceRegistrationNumber(context.Background(), 1, tt.registrationNumber)
+			if tt.expectedErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+			} else {
+				testhelpers.AssertNoError(t, err)
+			}
+			if called != tt.expectRepoCall {
+				t.Errorf("リポジトリの呼び出し = %v, want %v", called, tt.expectRepoCall)
+			}
+		})
+	}
+}
+
+func TestShopService_UpdateTimeZone(t *testing.T) {
+	tests := []struct {
+		name            string
+		timeZone        string
+		expectedErrCode apperrors.ErrCode
+	}{
+		{name: "正常系: IANAの名前を設定できる", timeZone: "Asia/Tokyo"},
+		{name: "正常系: UTCを設定できる", timeZone: "UTC"},
+		{name: "異常系: 存在しないタイムゾーン", timeZone: "Asia/Nowhere", expectedErrCode: apperrors.ValidationFailed},
+		{name: "異常系: サーバーに依存するLocal", timeZone: "Local", expectedErrCode: apperrors.ValidationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			repo := &ShopRepositoryMockForShop{
+				UpdateShopTimeZoneFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int, timeZone string) error {
+					called = true
+					if shopID != 1 || timeZone != tt.timeZone {
+						t.Errorf("想定外の引数: shopID=%d, timeZone=%s", shopID, timeZone)
+					}
+					return nil
+				},
+			}
+
+			err := services.NewShopService(repo, nil, &sqlx.DB{}).UpdateTimeZone(context.Background(), 1, tt.timeZone)
+			if tt.expectedErrCode != "" {
+				testhelpers.AssertAppError(t, err, tt.expectedErrCode)
+			} else {
+				testhelpers.AssertNoError(t, err)
+			}
+			if called != (tt.expectedErrCode == "") {
+				t.Errorf("リポジトリの呼び出し = %v", called)
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
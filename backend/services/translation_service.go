@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+
+	"github.com/A4-dev-team/mobileorder.git/i18n"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/jmoiron/sqlx"
+)
+
+type TranslationServicer interface {
+	UpsertItemTranslation(ctx context.Context, adminShopID int, itemID int, lang string, req models.ItemTranslationRequest) (*models.ItemTranslation, error)
+	DeleteItemTranslation(ctx context.Context, adminShopID int, itemID int, lang string) error
+	UpsertCategoryTranslation(ctx context.Context, shopID int, categoryID int, lang string, req models.CategoryTranslationRequest) (*models.CategoryTranslation, error)
+	DeleteCategoryTranslation(ctx context.Context, shopID int, categoryID int, lang string) error
+	UpsertShopTranslation(ctx context.Context, shopID int, lang string, req models.ShopTranslationRequest) (*models.ShopTranslation, error)
+	DeleteShopTranslation(ctx context.Context, shopID int, lang string) error
+}
+
+type translationService struct {
+	trr repositories.TranslationRepository
+	db  *sqlx.DB
+}
+
+func NewTranslationService(trr repositories.TranslationRepository, db *sqlx.DB) TranslationServicer {
+	return &translationService{
+		trr: trr,
+		db:  db,
+	}
+}
+
+// UpsertItemTranslation は担当店舗の商品の翻訳を登録・更新します。商品の翻訳は商品を扱う全店舗で表示されます
+func (s *translationService) UpsertItemTranslation(ctx context.Context, adminShopID int, itemID int, lang string, req models.ItemTranslationRequest) (*models.ItemTranslation, error) {
+	translation := &models.ItemTranslation{ItemID: itemID, Lang: lang, ItemName: req.ItemName, Description: req.Description}
+	if err := s.trr.UpsertItemTranslation(ctx, s.db, adminShopID, translation); err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
+
+// DeleteItemTranslation は担当店舗の商品の翻訳を削除します。削除後は日本語で表示されます
+func (s *translationService) DeleteItemTranslation(ctx context.Context, adminShopID int, itemID int, lang string) error {
+	return s.trr.DeleteItemTranslation(ctx, s.db, adminShopID, itemID, lang)
+}
+
+// UpsertCategoryTranslation は店舗のカテゴリ名の翻訳を登録・更新します
+func (s *translationService) UpsertCategoryTranslation(ctx context.Context, shopID int, categoryID int, lang string, req models.CategoryTranslationRequest) (*models.CategoryTranslation, error) {
+	translation := &models.CategoryTranslation{CategoryID: categoryID, Lang: lang, Name: req.Name}
+	if err := s.trr.UpsertCategoryTranslation(ctx, s.db, shopID, translation); err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
+
+// DeleteCategoryTranslation は店舗のカテゴリ名の翻訳を削除します
+func (s *translationService) DeleteCategoryTranslation(ctx context.Context, shopID int, categoryID int, lang string) error {
+	return s.trr.DeleteCategoryTranslation(ctx, s.db, shopID, categoryID, lang)
+}
+
+// UpsertShopTranslation は店舗名と説明の翻訳を登録・更新します
+func (s *translationService) UpsertShopTranslation(ctx context.Context, shopID int, lang string, req models.ShopTranslationRequest) (*models.ShopTranslation, error) {
+	translation := &models.ShopTranslation{ShopID: shopID, Lang: lang, Name: req.Name, Description: req.Description}
+	if err := s.trr.UpsertShopTranslation(ctx, s.db, translation); err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
+
+// DeleteShopTranslation は店舗名と説明の翻訳を削除します
+func (s *translationService) DeleteShopTranslation(ctx context.Context, shopID int, lang string) error {
+	return s.trr.DeleteShopTranslation(ctx, s.db, shopID, lang)
+}
+
+// translateItemList は商品名・説明とセットの構成商品名を翻訳します。翻訳のない商品は日本語のままにします。
+// 説明の翻訳がNULLの場合は元の説明を残します
+func translateItemList(itemList []models.ItemListResponse, translations map[int]models.ItemTranslation) {
+	for i := range itemList {
+		item := &itemList[i]
+		if t, ok := translations[item.ItemID]; ok {
+			item.ItemName = t.ItemName
+			if t.Description != nil {
+				item.Description = *t.Description
+			}
+		}
+		if item.Bundle == nil {
+			continue
+		}
+		for j := range item.Bundle.Slots {
+			choices := item.Bundle.Slots[j].Choices
+			for k := range choices {
+				if t, ok := translations[choices[k].ItemID]; ok {
+					choices[k].ItemName = t.ItemName
+				}
+			}
+		}
+	}
+}
+
+// translatedItemIDs は翻訳を取得する商品のID（セットの構成商品を含む）を返します
+func translatedItemIDs(itemList []models.ItemListResponse) []int {
+	seen := make(map[int]bool)
+	var itemIDs []int
+	add := func(itemID int) {
+		if !seen[itemID] {
+			seen[itemID] = true
+			itemIDs = append(itemIDs, itemID)
+		}
+	}
+	for _, item := range itemList {
+		add(item.ItemID)
+		if item.Bundle == nil {
+			continue
+		}
+		for _, slot := range item.Bundle.Slots {
+			for _, choice := range slot.Choices {
+				add(choice.ItemID)
+			}
+		}
+	}
+	return itemIDs
+}
+
+// translateCategories はカテゴリ名を翻訳します
+func translateCategories(categories []models.Category, translations map[int]models.CategoryTranslation) {
+	for i := range categories {
+		if t, ok := translations[categories[i].CategoryID]; ok {
+			categories[i].Name = t.Name
+		}
+	}
+}
+
+// translateOrderItems は注文商品の商品名を翻訳します。オプションや構成商品は注文時の名前のままです
+func translateOrderItems(items []models.ItemDetail, translations map[int]models.ItemTranslation) {
+	for i := range items {
+		if t, ok := translations[items[i].ItemID]; ok {
+			items[i].ItemName = t.ItemName
+		}
+	}
+}
+
+// uncategorizedSectionNames は「その他」のセクション名の翻訳です
+var uncategorizedSectionNames = map[string]string{
+	"en": "Other",
+	"zh": "其他",
+	"ko": "기타",
+}
+
+// localizedUncategorizedSectionName は言語に合わせた「その他」のセクション名を返します
+func localizedUncategorizedSectionName(lang string) string {
+	if !i18n.IsTranslationLanguage(lang) {
+		return uncategorizedSectionName
+	}
+	return uncategorizedSectionNames[lang]
+}
@@ -0,0 +1,178 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/A4-dev-team/mobileorder.git/internal/testhelpers"
+	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/A4-dev-team/mobileorder.git/repositories"
+	"github.com/A4-dev-team/mobileorder.git/services"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jmoiron/sqlx"
+)
+
+// TranslationRepositoryMock - 登録済みの翻訳を返すTranslationRepositoryのモック
+type TranslationRepositoryMock struct {
+	Items      map[int]models.ItemTranslation
+	Categories map[int]models.CategoryTranslation
+	Shops      map[int]models.ShopTranslation
+	Langs      []string // 翻訳を取得した言語
+}
+
+func (m *TranslationRepositoryMock) FindItemTranslations(ctx context.Context, dbtx repositories.DBTX, lang string, itemIDs []int) (map[int]models.ItemTranslation, error) {
+	m.Langs = append(m.Langs, lang)
+	translations := make(map[int]models.ItemTranslation)
+	for _, id := range itemIDs {
+		if t, ok := m.Items[id]; ok {
+			translations[id] = t
+		}
+	}
+	return translations, nil
+}
+
+func (m *TranslationRepositoryMock) FindCategoryTranslations(ctx context.Context, dbtx repositories.DBTX, lang string, categoryIDs []int) (map[int]models.CategoryTranslation, error) {
+	m.Langs = append(m.Langs, lang)
+	translations := make(map[int]models.CategoryTranslation)
+	for _, id := range categoryIDs {
+		if t, ok := m.Categories[id]; ok {
+			translations[id] = t
+		}
+	}
+	return translations, nil
+}
+
+func (m *TranslationRepositoryMock) FindShopTranslations(ctx context.Context, dbtx repositories.DBTX, lang string, shopIDs []int) (map[int]models.ShopTranslation, error) {
+	m.Langs = append(m.Langs, lang)
+	translations := make(map[int]models.ShopTranslation)
+	for _, id := range shopIDs {
+		if t, ok := m.Shops[id]; ok {
+			translations[id] = t
+		}
+	}
+	return translations, nil
+}
+
+func (m *TranslationRepositoryMock) UpsertItemTranslation(ctx context.Context, dbtx repositories.DBTX, shopID int, translation *models.ItemTranslation) error {
+	panic("not implemented")
+}
+
+func (m *TranslationRepositoryMock) DeleteItemTranslation(ctx context.Context, dbtx repositories.DBTX, shopID int, itemID int, lang string) error {
+	panic("not implemented")
+}
+
+func (m *TranslationRepositoryMock) UpsertCategoryTranslation(ctx context.Context, dbtx repositories.DBTX, shopID int, translation *models.CategoryTranslation) error {
+	panic("not implemented")
+}
+
+func (m *TranslationRepositoryMock) DeleteCategoryTranslation(ctx context.Context, dbtx repositories.DBTX, shopID int, categoryID int, lang string) error {
+	panic("not implemented")
+}
+
+func (m *TranslationRepositoryMock) UpsertShopTranslation(ctx context.Context, dbtx repositories.DBTX, translation *models.ShopTranslation) error {
+	panic("not implemented")
+}
+
+func (m *TranslationRepositoryMock) DeleteShopTranslation(ctx context.Context, dbtx repositories.DBTX, shopID int, lang string) error {
+	panic("not implemented")
+}
+
+func TestItemService_GetMenu_Translation(t *testing.T) {
+	repo := &ItemRepositoryMockForItem{
+		GetItemListFunc: func(dbtx repositories.DBTX, shopID int) ([]repositories.ItemListDB, error) {
+			return []repositories.ItemListDB{
+				{ItemID: 1, ItemName: "醤油ラーメン", Description: "鶏ガラの醤油スープ", CategoryID: intPtr(10)},
+				{ItemID: 2, ItemName: "味噌ラーメン", Description: "白味噌のスープ", CategoryID: intPtr(10)},
+				{ItemID: 3, ItemName: "季節の一品", Description: "季節の食材を使った一品"},
+				{ItemID: 30, ItemName: "ラーメン餃子セット", IsAvailable: true, TimeZone: "Asia/Tokyo"},
+			}, nil
+		},
+		FindBundleSlotsByItemIDsFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int, itemIDs []int) (map[int][]models.BundleSlot, error) {
+			return map[int][]models.BundleSlot{
+				30: {{BundleSlotID: 1, Name: "ラーメン", Quantity: 1, Choices: []models.BundleChoice{
+					{BundleChoiceID: 11, ItemID: 1, ItemName: "醤油ラーメン", IsAvailable: true},
+					{BundleChoiceID: 12, ItemID: 2, ItemName: "味噌ラーメン", IsAvailable: true},
+				}}},
+			}, nil
+		},
+	}
+	categoryRepo := &CategoryRepositoryMock{
+		FindCategoriesByShopIDFunc: func(ctx context.Context, dbtx repositories.DBTX, shopID int) ([]models.Category, error) {
+			return []models.Category{{CategoryID: 10, ShopID: shopID, Name: "ラーメン", SortOrder: 1}}, nil
+		},
+	}
+	translationRepo := &TranslationRepositoryMock{
+		Items: map[int]models.ItemTranslation{
+			1: {ItemID: 1, Lang: "en", ItemName: "Shoyu Ramen", Description: stringPtr("Soy sauce soup with chicken broth")},
+			3: {ItemID: 3, Lang: "en", ItemName: "Seasonal Dish"}, // 説明の翻訳はない
+		},
+		Categories: map[int]models.CategoryTranslation{10: {CategoryID: 10, Lang: "en", Name: "Ramen"}},
+	}
+
+	itemService := services.NewItemService(repo, categoryRepo, translationRepo, NewBlobStoreMock(), &sqlx.DB{})
+	got, err := itemService.GetMenu(context.Background(), 1, models.ItemListQuery{Lang: "en"})
+	testhelpers.AssertNoError(t, err)
+
+	// 翻訳のない商品と説明は日本語のまま。セットの構成商品名も翻訳する
+	var names [][]string
+	for _, section := range got.Sections {
+		sectionNames := []string{section.Name}
+		for _, item := range section.Items {
+			sectionNames = append(sectionNames, item.ItemName+"/"+item.Description)
+			if item.Bundle != nil {
+				for _, choice := range item.Bundle.Slots[0].Choices {
+					sectionNames = append(sectionNames, choice.ItemName)
+				}
+			}
+		}
+		names = append(names, sectionNames)
+	}
+	want := [][]string{
+		{"Ramen", "Shoyu Ramen/Soy sauce soup with chicken broth", "味噌ラーメン/白味噌のスープ"},
+		{"Other", "Seasonal Dish/季節の食材を使った一品", "ラーメン餃子セット/", "Shoyu Ramen", "味噌ラーメン"},
+	}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("translated menu mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"en", "en"}, translationRepo.Langs); diff != "" {
+		t.Errorf("translation langs mismatch (-want +got):\n%s", diff)
+	}
+
+	// 日本語では翻訳を取得しない
+	translationRepo.Langs = nil
+	got, err = itemService.GetMenu(context.Background(), 1, models.ItemListQuery{Lang: "ja"})
+	testhelpers.AssertNoError(t, err)
+	if got.Sections[0].Name != "ラーメン" || got.Sections[1].Name != "その他" || translationRepo.Langs != nil {
+		t.Errorf("日本語のメニューが翻訳されています: %+v", got.Sections)
+	}
+}
+
+func TestShopService_GetNearbyShops_Translation(t *testing.T) {
+	repo := &ShopRepositoryMockForShop{
+		FindShopsWithinRadiusFunc: func(ctx context.Context, dbtx repositories.DBTX, latitude float64, longitude float64, radiusMeters float64) ([]repositories.NearbyShopDBResult, error) {
+			return []repositories.NearbyShopDBResult{
+				{ShopID: 1, Name: "A4食堂", Description: "学生街の定食屋です。"},
+				{ShopID: 2, Name: "元町ラーメン 一番星", Description: "豚骨魚介のダブルスープ"},
+				{ShopID: 3, Name: "三宮ベーカリーカフェ", Description: "焼きたてのパン"},
+			}, nil
+		},
+	}
+	translationRepo := &TranslationRepositoryMock{
+		Shops: map[int]models.ShopTranslation{
+			1: {ShopID: 1, Lang: "ko", Name: "A4 식당", Description: stringPtr("학생가의 정식집입니다.")},
+			2: {ShopID: 2, Lang: "ko", Name: "모토마치 라멘 이치방보시"},
+		},
+	}
+
+	got, err := services.NewShopService(repo, translationRepo, &sqlx.DB{}).GetNearbyShops(context.Background(), 34.69, 135.19, 1500, "ko")
+	testhelpers.AssertNoError(t, err)
+
+	var names []string
+	for _, shop := range got {
+		names = append(names, shop.Name+"/"+shop.Description)
+	}
+	want := []string{"A4 식당/학생가의 정식집입니다.", "모토마치 라멘 이치방보시/豚骨魚介のダブルスープ", "三宮ベーカリーカフェ/焼きたてのパン"}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("translated shops mismatch (-want +got):\n%s", diff)
+	}
+}
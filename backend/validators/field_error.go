@@ -0,0 +1,112 @@
+package validators
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
+	"github.com/go-playground/validator/v10"
+)
+
+// datetimeFormats はdatetimeの書式を、利用者に見せる書式にします
+var datetimeFormats = map[string]string{
+	"2006-01-02": "YYYY-MM-DD",
+	"15:04":      "HH:MM",
+}
+
+// FieldErrors はValidateのエラーを、項目ごとの検証エラーにします。
+// 項目はJSONでの位置（items[0].quantityなど）で、検証エラーでなければnilを返します
+func FieldErrors(err error) []apperrors.FieldError {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil
+	}
+
+	fields := make([]apperrors.FieldError, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		fields = append(fields, newFieldError(fe))
+	}
+	return fields
+}
+
+func newFieldError(fe validator.FieldError) apperrors.FieldError {
+	// 先頭の構造体名を除いたものをJSONでの位置にする
+	_, field, ok := strings.Cut(fe.Namespace(), ".")
+	if !ok {
+		field = fe.Field()
+	}
+
+	// 「datetime=15:04|eq=24:00」のようなOR条件は、最初の条件で説明する
+	rule, param := fe.Tag(), fe.Param()
+	if first, _, ok := strings.Cut(rule, "|"); ok {
+		rule, param, _ = strings.Cut(first, "=")
+	}
+
+	id, params := fieldMessage(fe.Kind(), rule, param)
+	return apperrors.NewFieldError(field, rule, param, id, params)
+}
+
+// fieldMessage は検証ルールと値の種類から、カタログのメッセージを決めます
+func fieldMessage(kind reflect.Kind, rule string, param string) (apperrors.MessageID, apperrors.Params) {
+	params := apperrors.Params{"param": param}
+	switch rule {
+	case "required":
+		return apperrors.MsgFieldRequired, nil
+	case "min":
+		return sizeMessage(kind, apperrors.MsgFieldMin, apperrors.MsgFieldMinLength, apperrors.MsgFieldMinItems), params
+	case "max":
+		return sizeMessage(kind, apperrors.MsgFieldMax, apperrors.MsgFieldMaxLength, apperrors.MsgFieldMaxItems), params
+	case "len":
+		return apperrors.MsgFieldLength, params
+	case "gtefield":
+		return apperrors.MsgFieldGteField, apperrors.Params{"param": snakeCase(param)}
+	case "oneof":
+		return apperrors.MsgFieldOneOf, apperrors.Params{"values": strings.Fields(param)}
+	case "unique":
+		return apperrors.MsgFieldUnique, nil
+	case "email":
+		return apperrors.MsgFieldEmail, nil
+	case "uuid4":
+		return apperrors.MsgFieldUUID, nil
+	case "alphanum":
+		return apperrors.MsgFieldAlphanum, nil
+	case "datetime":
+		format, ok := datetimeFormats[param]
+		if !ok {
+			format = param
+		}
+		return apperrors.MsgFieldDatetime, apperrors.Params{"format": format}
+	case "timezone":
+		return apperrors.MsgFieldTimeZone, nil
+	default:
+		return apperrors.MsgFieldInvalid, nil
+	}
+}
+
+// sizeMessage はmin・maxのメッセージを、数値・文字数・件数のどれで数えるかで選びます
+func sizeMessage(kind reflect.Kind, number, length, items apperrors.MessageID) apperrors.MessageID {
+	switch kind {
+	case reflect.String:
+		return length
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return items
+	default:
+		return number
+	}
+}
+
+// snakeCase はgtefieldなどで指定されたフィールド名（MinSelect）をJSONの名前（min_select）にします
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if 'A' <= r && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -1,6 +1,8 @@
 package validators
 
 import (
+	"reflect"
+	"strings"
 	"sync"
 
 	"github.com/go-playground/validator/v10"
@@ -22,6 +24,8 @@ var (
 func getSingletonValidator() *validator.Validate {
 	once.Do(func() {
 		singletonValidator = validator.New()
+		// エラーの項目名をJSONやクエリパラメータでの名前にする
+		singletonValidator.RegisterTagNameFunc(fieldName)
 	})
 	return singletonValidator
 }
@@ -33,3 +37,14 @@ func NewValidator[T any]() Validator[T] {
 func (v *customValidator[T]) Validate(t T) error {
 	return v.validate.Struct(t)
 }
+
+// fieldName はjson、query、paramのタグから項目名を返します。タグがなければ空文字を返し、構造体のフィールド名が使われます
+func fieldName(sf reflect.StructField) string {
+	for _, key := range []string{"json", "query", "param"} {
+		name := strings.Split(sf.Tag.Get(key), ",")[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return ""
+}
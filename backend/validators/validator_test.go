@@ -1,9 +1,14 @@
 package validators
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
+	"github.com/A4-dev-team/mobileorder.git/apperrors"
 	"github.com/A4-dev-team/mobileorder.git/models"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 func TestValidator(t *testing.T) {
@@ -75,6 +80,42 @@ func TestValidator(t *testing.T) {
 				},
 				wantError: true,
 			},
+			{
+				name: "正常系: メモとアレルギー申告付き",
+				testData: models.CreateOrderRequest{
+					Items: []models.OrderItemRequest{
+						{ItemID: 1, Quantity: 1, Note: "辛さ控えめで"},
+					},
+					Note:       strings.Repeat("あ", 200),
+					HasAllergy: true,
+				},
+				wantError: false,
+			},
+			{
+				name: "異常系: 決済トークンが長すぎる",
+				testData: models.CreateOrderRequest{
+					Items:        []models.OrderItemRequest{{ItemID: 1, Quantity: 1}},
+					PaymentToken: strings.Repeat("a", 256),
+				},
+				wantError: true,
+			},
+			{
+				name: "異常系: 注文メモが長すぎる",
+				testData: models.CreateOrderRequest{
+					Items: []models.OrderItemRequest{{ItemID: 1, Quantity: 1}},
+					Note:  strings.Repeat("あ", 201),
+				},
+				wantError: true,
+			},
+			{
+				name: "異常系: 商品メモが長すぎる",
+				testData: models.CreateOrderRequest{
+					Items: []models.OrderItemRequest{
+						{ItemID: 1, Quantity: 1, Note: strings.Repeat("a", 101)},
+					},
+				},
+				wantError: true,
+			},
 		}
 
 		validator := NewValidator[models.CreateOrderRequest]()
@@ -111,6 +152,24 @@ func TestValidator(t *testing.T) {
 				},
 				wantError: false,
 			},
+			{
+				name: "正常系: オプションを選択した商品アイテム",
+				testData: models.OrderItemRequest{
+					ItemID:            1,
+					Quantity:          1,
+					ModifierOptionIDs: []int{3, 5},
+				},
+				wantError: false,
+			},
+			{
+				name: "異常系: オプションIDが無効（0以下）",
+				testData: models.OrderItemRequest{
+					ItemID:            1,
+					Quantity:          1,
+					ModifierOptionIDs: []int{0},
+				},
+				wantError: true,
+			},
 			{
 				name: "正常系: 大きな値での有効な商品アイテム",
 				testData: models.OrderItemRequest{
@@ -273,3 +332,66 @@ func TestValidator(t *testing.T) {
 		}
 	})
 }
+
+func TestFieldErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []apperrors.FieldError
+	}{
+		{
+			name: "配列の要素はJSONでの位置になる",
+			err: NewValidator[models.CreateOrderRequest]().Validate(models.CreateOrderRequest{
+				Items: []models.OrderItemRequest{{ItemID: 1, Quantity: 1}, {ItemID: 2, Quantity: -1}},
+				Note:  strings.Repeat("あ", 201),
+			}),
+			want: []apperrors.FieldError{
+				{Field: "items[1].quantity", Rule: "min", Param: "1", Message: "1以上で指定してください。"},
+				{Field: "note", Rule: "max", Param: "200", Message: "200文字以内で指定してください。"},
+			},
+		},
+		{
+			name: "件数と必須",
+			err: NewValidator[models.CreateOrderRequest]().Validate(models.CreateOrderRequest{
+				Items: []models.OrderItemRequest{},
+			}),
+			want: []apperrors.FieldError{
+				{Field: "items", Rule: "min", Param: "1", Message: "1件以上指定してください。"},
+			},
+		},
+		{
+			name: "選択肢とOR条件",
+			err: NewValidator[models.AvailabilityWindowRequest]().Validate(models.AvailabilityWindowRequest{
+				Days: []int{1}, Start: "11:00", End: "25:00",
+			}),
+			want: []apperrors.FieldError{
+				{Field: "end", Rule: "datetime", Param: "15:04", Message: "HH:MMの形式で指定してください。"},
+			},
+		},
+		{
+			name: "クエリパラメータはパラメータ名",
+			err: NewValidator[models.ItemListQuery]().Validate(models.ItemListQuery{
+				ExcludeAllergens: []string{"shrimp", "fish"},
+			}),
+			want: []apperrors.FieldError{
+				{Field: "exclude_allergens[1]", Rule: "oneof", Param: "shrimp crab walnut wheat buckwheat egg milk peanut",
+					Message: "shrimp、crab、walnut、wheat、buckwheat、egg、milk、peanutのいずれかで指定してください。"},
+			},
+		},
+		{
+			name: "検証エラーでなければnil",
+			err:  errors.New("unexpected"),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FieldErrors(tt.err)
+			opts := cmpopts.IgnoreFields(apperrors.FieldError{}, "MessageID", "Params")
+			if diff := cmp.Diff(tt.want, got, opts); diff != "" {
+				t.Errorf("FieldErrors() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}